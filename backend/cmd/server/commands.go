@@ -0,0 +1,35 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+
+	"opscore/backend/internal/git_repository/infrastructure/persistence"
+)
+
+// runCommand runs an administrative command against the database instead of serving the API.
+//
+//	reencrypt-credentials  Re-encrypts every stored access token, deploy key and GitHub App private
+//	                       key the way new secrets are encrypted: with the key provider's current
+//	                       master key when KEY_PROVIDER is set, and with the active ENCRYPTION_KEY
+//	                       otherwise. Run it after a key rotation or after enabling a key provider,
+//	                       while the previous keys are still configured.
+func runCommand(ctx context.Context, db *pgxpool.Pool, args []string) error {
+	switch args[0] {
+	case "reencrypt-credentials":
+		encryptor, err := provideEncryptor()
+		if err != nil {
+			return err
+		}
+		updated, err := persistence.ReencryptCredentials(ctx, db, encryptor)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Re-encrypted the credentials of %d repositories and GitHub Apps with key %q.\n", updated, encryptor.ActiveKeyID())
+		return nil
+	default:
+		return fmt.Errorf("unknown command %q (available: reencrypt-credentials)", args[0])
+	}
+}
@@ -1,39 +1,61 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"log/slog"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/jackc/pgx/v5/pgxpool"
 
 	repository "opscore/backend/internal/git_repository/application/usecase"
+	gitentity "opscore/backend/internal/git_repository/domain/entity"
 	"opscore/backend/internal/git_repository/infrastructure/encryption"
 	"opscore/backend/internal/git_repository/infrastructure/git"
 	"opscore/backend/internal/git_repository/infrastructure/persistence"
 	repohandlers "opscore/backend/internal/git_repository/interfaces/api/handlers"
 
 	docusecase "opscore/backend/internal/document/application/usecase"
+	docpersistence "opscore/backend/internal/document/infrastructure/persistence"
 	dochandlers "opscore/backend/internal/document/interfaces/api/handlers"
 
 	execusecase "opscore/backend/internal/execution_record/application/usecase"
+	execpersistence "opscore/backend/internal/execution_record/infrastructure/persistence"
 	exechandlers "opscore/backend/internal/execution_record/interfaces/api/handlers"
 	"opscore/backend/internal/execution_record/infrastructure/storage"
 
 	userusecase "opscore/backend/internal/user/application/usecase"
 	userhandlers "opscore/backend/internal/user/interfaces/api/handlers"
+	"opscore/backend/internal/user/infrastructure/oidc"
 	userpersistence "opscore/backend/internal/user/infrastructure/persistence"
 
 	viewhistoryusecase "opscore/backend/internal/view_history/application/usecase"
+	viewhistorypersistence "opscore/backend/internal/view_history/infrastructure/persistence"
 	viewhistoryhandlers "opscore/backend/internal/view_history/interfaces/api/handlers"
 
 	viewstatsusecase "opscore/backend/internal/view_statistics/application/usecase"
+	viewstatspersistence "opscore/backend/internal/view_statistics/infrastructure/persistence"
 	viewstatshandlers "opscore/backend/internal/view_statistics/interfaces/api/handlers"
 )
 
 // Base path for cloning repositories
 const baseClonePath = "./cloned_repos" // TODO: Make this configurable
 
+// Maximum number of repositories waiting for a background sync
+const syncQueueCapacity = 100
+
+// Defaults for the scheduled sync of all repositories
+const (
+	defaultSyncInterval = time.Hour
+	defaultSyncJitter   = 5 * time.Minute
+)
+
+// defaultTokenCheckInterval is how often access tokens are verified; TOKEN_CHECK_INTERVAL=0 disables the check.
+const defaultTokenCheckInterval = 24 * time.Hour
+
 // SlogLoggerAdapter は slog.Logger を handlers.Logger インターフェースに適応させる
 type SlogLoggerAdapter struct {
 	logger *slog.Logger
@@ -55,9 +77,59 @@ func (a *SlogLoggerAdapter) Warn(msg string, args ...any) {
 	a.logger.Warn(msg, args...)
 }
 
-// provideGitManager creates a GitManager instance.
-func provideGitManager() (git.GitManager, error) {
-	return git.NewGithubApiManager(baseClonePath)
+// provideGitManager creates a GitManager instance that routes each repository to the manager of its provider,
+// and the store of uploaded archives that local repositories are served from.
+// Self-hosted GitLab instances served under a path prefix are listed in GITLAB_INSTANCE_URLS (comma-separated).
+// githubApps provides the GitHub Apps that repositories registered with an installation authenticate as.
+func provideGitManager(githubApps git.GitHubAppFinder) (git.GitManager, git.ArchiveStore, error) {
+	git.SetLogger(provideAppLogger())
+	githubManager, err := git.NewGithubApiManager(baseClonePath, githubApps)
+	if err != nil {
+		return nil, nil, err
+	}
+	gitlabManager, err := git.NewGitlabApiManager(baseClonePath, nil, splitList(os.Getenv("GITLAB_INSTANCE_URLS")))
+	if err != nil {
+		return nil, nil, err
+	}
+	// Gitea/Forgejo, generic hosts and SSH deploy keys are served by the git CLI;
+	// SSH host keys are verified against SSH_KNOWN_HOSTS_FILE
+	cliManager, err := git.NewCliGitManager(baseClonePath, os.Getenv("SSH_KNOWN_HOSTS_FILE"))
+	if err != nil {
+		return nil, nil, err
+	}
+	localManager, err := provideLocalSourceManager()
+	if err != nil {
+		return nil, nil, err
+	}
+	return git.NewProviderGitManager(map[gitentity.Provider]git.GitManager{
+		gitentity.ProviderGitHub:  githubManager,
+		gitentity.ProviderGitLab:  gitlabManager,
+		gitentity.ProviderGitea:   cliManager,
+		gitentity.ProviderGeneric: cliManager,
+		gitentity.ProviderLocal:   localManager,
+	}, cliManager), localManager, nil
+}
+
+// provideLocalSourceManager creates the manager of repositories served from local directories and uploaded archives.
+// Uploaded archives are kept in LOCAL_UPLOAD_PATH; file:// sources may only be read from the comma-separated
+// directories in LOCAL_SOURCE_ROOTS.
+func provideLocalSourceManager() (git.LocalSourceManager, error) {
+	uploadPath := os.Getenv("LOCAL_UPLOAD_PATH")
+	if uploadPath == "" {
+		uploadPath = "./data/uploads"
+	}
+	return git.NewLocalSourceManager(baseClonePath, uploadPath, splitList(os.Getenv("LOCAL_SOURCE_ROOTS")))
+}
+
+// splitList splits a comma-separated environment variable into its non-empty entries.
+func splitList(value string) []string {
+	var entries []string
+	for _, entry := range strings.Split(value, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
 }
 
 // provideAppLogger creates a structured logger based on ADR-0008.
@@ -94,6 +166,14 @@ func provideRepoHandlerLogger() repohandlers.Logger {
 	return &SlogLoggerAdapter{logger: provideAppLogger()}
 }
 
+// provideWebhookSecrets reads the webhook secrets; webhooks are disabled for providers without one.
+func provideWebhookSecrets() repohandlers.WebhookSecrets {
+	return repohandlers.WebhookSecrets{
+		GitHub: os.Getenv("GITHUB_WEBHOOK_SECRET"),
+		GitLab: os.Getenv("GITLAB_WEBHOOK_TOKEN"),
+	}
+}
+
 // provideDocHandlerLogger adapts slog.Logger to the document handlers.Logger interface.
 func provideDocHandlerLogger() dochandlers.Logger {
 	return &SlogLoggerAdapter{logger: provideAppLogger()}
@@ -114,41 +194,231 @@ func provideViewStatsHandlerLogger() viewstatshandlers.Logger {
 	return &SlogLoggerAdapter{logger: provideAppLogger()}
 }
 
-// provideEncryptor creates an Encryptor from the environment variable.
+// provideSessionTTL reads the login session lifetime from the environment.
+func provideSessionTTL() time.Duration {
+	ttlStr := os.Getenv("SESSION_TTL")
+	if ttlStr == "" {
+		return userusecase.DefaultSessionTTL
+	}
+
+	ttl, err := time.ParseDuration(ttlStr)
+	if err != nil || ttl <= 0 {
+		slog.Warn("Invalid SESSION_TTL, using default", "value", ttlStr)
+		return userusecase.DefaultSessionTTL
+	}
+	return ttl
+}
+
+// provideSyncSchedule reads the scheduled sync interval and jitter from the environment.
+// SYNC_INTERVAL=0 disables scheduled syncs.
+func provideSyncSchedule() (interval, jitter time.Duration) {
+	return provideDuration("SYNC_INTERVAL", defaultSyncInterval), provideDuration("SYNC_JITTER", defaultSyncJitter)
+}
+
+// provideDuration reads a non-negative duration from the environment, falling back to the default.
+func provideDuration(name string, defaultValue time.Duration) time.Duration {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+
+	d, err := time.ParseDuration(value)
+	if err != nil || d < 0 {
+		slog.Warn("Invalid "+name+", using default", "value", value)
+		return defaultValue
+	}
+	return d
+}
+
+// provideOIDCProvider creates the OpenID Connect provider from the environment.
+// Single sign-on is disabled (nil is returned) when OIDC_ISSUER_URL is not set.
+func provideOIDCProvider() (*oidc.Provider, error) {
+	issuerURL := os.Getenv("OIDC_ISSUER_URL")
+	if issuerURL == "" {
+		return nil, nil
+	}
+
+	var scopes []string
+	if scopesStr := os.Getenv("OIDC_SCOPES"); scopesStr != "" {
+		scopes = strings.Fields(strings.ReplaceAll(scopesStr, ",", " "))
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
+	defer cancel()
+
+	return oidc.NewProvider(ctx, oidc.Config{
+		IssuerURL:    issuerURL,
+		ClientID:     os.Getenv("OIDC_CLIENT_ID"),
+		ClientSecret: os.Getenv("OIDC_CLIENT_SECRET"),
+		RedirectURL:  os.Getenv("OIDC_REDIRECT_URL"),
+		Scopes:       scopes,
+		GroupsClaim:  os.Getenv("OIDC_GROUPS_CLAIM"),
+	})
+}
+
+// developmentEncryptionKey is used when ENCRYPTION_KEY is not set. It is published in the source,
+// so it is refused in production.
+const developmentEncryptionKey = "dev-key-123456789012345678901234"
+
+// isProduction reports whether the server runs in production (APP_ENV=production).
+func isProduction() bool {
+	return os.Getenv("APP_ENV") == "production"
+}
+
+// provideEncryptor creates an Encryptor from the environment variables.
+// With KEY_PROVIDER set, each secret is encrypted with its own data key wrapped by the key provider
+// (envelope encryption), and the ENCRYPTION_KEY keyring is only needed to read secrets written before.
 func provideEncryptor() (*encryption.Encryptor, error) {
+	provider, err := provideKeyProvider()
+	if err != nil {
+		return nil, err
+	}
+	keyring, err := provideKeyring(provider != nil)
+	if err != nil {
+		return nil, err
+	}
+	if provider == nil {
+		return encryption.NewKeyringEncryptor(keyring), nil
+	}
+	return encryption.NewEnvelopeEncryptor(provider, keyring), nil
+}
+
+// provideKeyProvider creates the key provider selected by KEY_PROVIDER, or returns nil when it is not set.
+//
+//	local  master keys from the JSON key file ENCRYPTION_KEY_FILE
+//	kms    master key KMS_KEY_ID of the key management service at KMS_URL, authenticated with KMS_TOKEN
+func provideKeyProvider() (encryption.KeyProvider, error) {
+	switch kind := os.Getenv("KEY_PROVIDER"); kind {
+	case "":
+		return nil, nil
+	case "local":
+		path := os.Getenv("ENCRYPTION_KEY_FILE")
+		if path == "" {
+			return nil, errors.New("ENCRYPTION_KEY_FILE must be set when KEY_PROVIDER=local")
+		}
+		provider, err := encryption.LoadLocalKeyProvider(path)
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	case "kms":
+		provider, err := encryption.NewKMSKeyProvider(os.Getenv("KMS_URL"), os.Getenv("KMS_KEY_ID"), os.Getenv("KMS_TOKEN"))
+		if err != nil {
+			return nil, err
+		}
+		return provider, nil
+	default:
+		return nil, fmt.Errorf("unknown KEY_PROVIDER %q (use local or kms)", kind)
+	}
+}
+
+// provideKeyring creates the keyring for direct encryption from the environment variables.
+// ENCRYPTION_KEY (identified by ENCRYPTION_KEY_ID) encrypts new data; the comma-separated "id:key" pairs
+// of ENCRYPTION_PREVIOUS_KEYS are only used to decrypt data written before a key rotation.
+// When optional, a missing ENCRYPTION_KEY yields no keyring instead of the development default key.
+func provideKeyring(optional bool) (*encryption.Keyring, error) {
 	keyStr := os.Getenv("ENCRYPTION_KEY")
 	if keyStr == "" {
+		if optional {
+			return nil, nil
+		}
+		if isProduction() {
+			return nil, errors.New("ENCRYPTION_KEY must be set when APP_ENV=production")
+		}
 		slog.Warn("ENCRYPTION_KEY not set, using development default key. DO NOT USE IN PRODUCTION!")
-		keyStr = "dev-key-123456789012345678901234"
+		keyStr = developmentEncryptionKey
+	}
+
+	key, err := encryption.ParseKey(keyStr)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_KEY: %w", err)
+	}
+	if isProduction() && string(key) == developmentEncryptionKey {
+		return nil, errors.New("the development default ENCRYPTION_KEY must not be used when APP_ENV=production")
 	}
 
-	key := []byte(keyStr)
-	if len(key) != 32 {
-		return nil, encryption.ErrInvalidKey
+	keyID := os.Getenv("ENCRYPTION_KEY_ID")
+	if keyID == "" {
+		keyID = encryption.DefaultKeyID
 	}
+	keyring, err := encryption.NewKeyring(keyID, key)
+	if err != nil {
+		return nil, fmt.Errorf("ENCRYPTION_KEY: %w", err)
+	}
+
+	for _, entry := range strings.Split(os.Getenv("ENCRYPTION_PREVIOUS_KEYS"), ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		id, previousStr, ok := strings.Cut(entry, ":")
+		if !ok {
+			return nil, errors.New("ENCRYPTION_PREVIOUS_KEYS: each entry must be id:key")
+		}
+		previousKey, err := encryption.ParseKey(previousStr)
+		if err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_PREVIOUS_KEYS: key %q: %w", id, err)
+		}
+		if err := keyring.AddDecryptionKey(id, previousKey); err != nil {
+			return nil, fmt.Errorf("ENCRYPTION_PREVIOUS_KEYS: %w", err)
+		}
+	}
+
+	return keyring, nil
+}
 
-	return encryption.NewEncryptor(key)
+// API bundles the handlers and use cases that main wires into the router.
+type API struct {
+	RepositoryHandler      *repohandlers.RepositoryHandler
+	WebhookHandler         *repohandlers.WebhookHandler
+	SyncHandler            *repohandlers.SyncHandler
+	GitHubAppHandler       *repohandlers.GitHubAppHandler
+	ArchiveHandler         *repohandlers.ArchiveHandler
+	DocumentHandler        *dochandlers.DocumentHandler
+	VariableHandler        *dochandlers.VariableHandler
+	AccessGrantHandler     *dochandlers.AccessGrantHandler
+	ImportHandler          *dochandlers.ImportHandler
+	ExecutionRecordHandler *exechandlers.ExecutionRecordHandler
+	AttachmentHandler      *exechandlers.AttachmentHandler
+	UserHandler            *userhandlers.UserHandler
+	GroupHandler           *userhandlers.GroupHandler
+	RoleHandler            *userhandlers.RoleHandler
+	ViewHistoryHandler     *viewhistoryhandlers.ViewHistoryHandler
+	ViewStatsHandler       *viewstatshandlers.ViewStatisticsHandler
+	AuthHandler            *userhandlers.AuthHandler
+	OIDCHandler            *userhandlers.OIDCHandler // nil when single sign-on is not configured
+	AuthUseCase            userusecase.AuthUseCase
+	AuthorizationUseCase   userusecase.AuthorizationUseCase
+	SyncWorker             *repository.SyncWorker    // Must be started by main to process queued syncs
+	SyncScheduler          *repository.SyncScheduler // Must be started by main to enqueue periodic syncs
+	TokenChecker           *repository.TokenChecker  // Must be started by main to verify access tokens periodically
 }
 
 // InitializeAPI initializes all dependencies for the API handlers, using Postgres.
-func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandlers.DocumentHandler, *dochandlers.VariableHandler, *exechandlers.ExecutionRecordHandler, *exechandlers.AttachmentHandler, *userhandlers.UserHandler, *userhandlers.GroupHandler, *viewhistoryhandlers.ViewHistoryHandler, *viewstatshandlers.ViewStatisticsHandler, error) {
+func InitializeAPI(db *pgxpool.Pool) (*API, error) {
 	// Create encryptor
 	encryptor, err := provideEncryptor()
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 
 	// Create repository (persistence layer)
 	repositoryRepository := persistence.NewPostgresRepository(db, encryptor)
+	githubAppRepository := persistence.NewPostgresGitHubAppRepository(db, encryptor)
 
 	// Create git manager
-	gitManager, err := provideGitManager()
+	gitManager, archiveStore, err := provideGitManager(githubAppRepository)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 
+	// Create background sync worker
+	syncRunRepository := persistence.NewPostgresSyncRunRepository(db)
+	syncRunner := repository.NewSyncRunner(repositoryRepository, syncRunRepository, gitManager)
+	syncWorker := repository.NewSyncWorker(syncRunner, provideAppLogger(), syncQueueCapacity)
+
 	// Create use case
-	repositoryUseCase := repository.NewRepositoryUseCase(repositoryRepository, gitManager)
+	repositoryUseCase := repository.NewRepositoryUseCase(repositoryRepository, gitManager, syncWorker)
 
 	// Create repo logger
 	repoLogger := provideRepoHandlerLogger()
@@ -156,15 +426,55 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	// Create and return repository handler
 	repositoryHandler := repohandlers.NewRepositoryHandler(repositoryUseCase, repoLogger)
 
-	// Create document repository (in-memory for now, until persistence is implemented)
-	// TODO: Replace with actual persistence implementation when DB migration is complete
-	documentRepository := NewInMemoryDocumentRepository()
+	// Create sync scheduler and sync history handler
+	syncInterval, syncJitter := provideSyncSchedule()
+	syncScheduler := repository.NewSyncScheduler(repositoryRepository, syncWorker, syncInterval, syncJitter, provideAppLogger())
+	syncUseCase := repository.NewSyncUseCase(repositoryRepository, syncRunRepository, syncWorker)
+	syncHandler := repohandlers.NewSyncHandler(syncUseCase, repoLogger)
+
+	// Create GitHub App handler
+	githubAppUseCase := repository.NewGitHubAppUseCase(githubAppRepository, repositoryUseCase)
+	githubAppHandler := repohandlers.NewGitHubAppHandler(githubAppUseCase, repoLogger)
+
+	// Create handler of repositories served from uploaded archives
+	archiveUseCase := repository.NewArchiveUseCase(repositoryRepository, repositoryUseCase, archiveStore, syncWorker)
+	archiveHandler := repohandlers.NewArchiveHandler(archiveUseCase, repoLogger)
+
+	// Create the periodic access token check
+	tokenChecker := repository.NewTokenChecker(repositoryRepository, gitManager, provideDuration("TOKEN_CHECK_INTERVAL", defaultTokenCheckInterval), provideAppLogger())
+
+	// Create webhook handler
+	webhookUseCase := repository.NewWebhookUseCase(repositoryRepository, syncWorker)
+	webhookHandler := repohandlers.NewWebhookHandler(webhookUseCase, provideWebhookSecrets(), repoLogger)
+
+	// Create user and role repositories
+	userRepository := userpersistence.NewUserRepositoryImpl(db)
+	roleRepository := userpersistence.NewRoleRepositoryImpl(db)
+
+	// Create authorization use case
+	authorizationUseCase := userusecase.NewAuthorizationUseCase(userRepository, roleRepository)
+
+	// Create document repository
+	documentRepository := docpersistence.NewDocumentRepositoryImpl(db)
+
+	// Create document ACL repository
+	accessGrantRepository := docpersistence.NewAccessGrantRepositoryImpl(db)
 
 	// Create document use case
-	documentUseCase := docusecase.NewDocumentUseCase(documentRepository)
+	documentUseCase := docusecase.NewDocumentUseCase(documentRepository, accessGrantRepository, repositoryUseCase, authorizationUseCase)
 
 	// Create variable use case
-	variableUseCase := docusecase.NewVariableUseCase(documentRepository)
+	variableUseCase := docusecase.NewVariableUseCase(documentRepository, accessGrantRepository, authorizationUseCase)
+
+	// Create document import use case
+	importUseCase := docusecase.NewImportUseCase(documentRepository, documentUseCase, repositoryUseCase, repositoryUseCase)
+
+	// Publish or flag document updates after each repository sync
+	autoUpdateUseCase := docusecase.NewAutoUpdateUseCase(documentRepository, documentUseCase, repositoryUseCase, repositoryUseCase, provideAppLogger())
+	syncWorker.AddListener(autoUpdateUseCase)
+
+	// Create document ACL use case
+	accessGrantUseCase := docusecase.NewAccessGrantUseCase(documentRepository, accessGrantRepository, authorizationUseCase)
 
 	// Create document logger
 	docLogger := provideDocHandlerLogger()
@@ -175,18 +485,24 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	// Create variable handler
 	variableHandler := dochandlers.NewVariableHandler(variableUseCase, docLogger)
 
-	// Create execution record repository (in-memory for now)
-	executionRecordRepository := NewInMemoryExecutionRecordRepository()
+	// Create document ACL handler
+	accessGrantHandler := dochandlers.NewAccessGrantHandler(accessGrantUseCase, docLogger)
+
+	// Create document import handler
+	importHandler := dochandlers.NewImportHandler(importUseCase, docLogger)
+
+	// Create execution record repository
+	executionRecordRepository := execpersistence.NewExecutionRecordRepositoryImpl(db)
+
+	// Create execution record share repository
+	shareGrantRepository := execpersistence.NewShareGrantRepositoryImpl(db)
 
 	// Create execution record use case
-	executionRecordUseCase := execusecase.NewExecutionRecordUsecase(executionRecordRepository)
+	executionRecordUseCase := execusecase.NewExecutionRecordUsecase(executionRecordRepository, shareGrantRepository, documentUseCase, authorizationUseCase)
 
 	// Create execution record handler
 	executionRecordHandler := exechandlers.NewExecutionRecordHandler(executionRecordUseCase)
 
-	// Create attachment repository (in-memory for now)
-	attachmentRepository := NewInMemoryAttachmentRepository()
-
 	// Create storage manager (local storage for now)
 	storageBasePath := os.Getenv("ATTACHMENT_STORAGE_PATH")
 	if storageBasePath == "" {
@@ -194,18 +510,18 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	}
 	storageManager, err := storage.NewLocalStorageManager(storageBasePath)
 	if err != nil {
-		return nil, nil, nil, nil, nil, nil, nil, err
+		return nil, err
 	}
 
+	// Create attachment repository
+	attachmentRepository := execpersistence.NewAttachmentRepositoryImpl(db, storageManager)
+
 	// Create attachment use case
-	attachmentUseCase := execusecase.NewAttachmentUsecase(attachmentRepository, executionRecordRepository, storageManager)
+	attachmentUseCase := execusecase.NewAttachmentUsecase(attachmentRepository, executionRecordRepository, shareGrantRepository, storageManager, authorizationUseCase)
 
 	// Create attachment handler
 	attachmentHandler := exechandlers.NewAttachmentHandler(attachmentUseCase)
 
-	// Create user repository
-	userRepository := userpersistence.NewUserRepositoryImpl(db)
-
 	// Create group repository
 	groupRepository := userpersistence.NewGroupRepositoryImpl(db)
 
@@ -224,8 +540,36 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	// Create group handler
 	groupHandler := userhandlers.NewGroupHandler(groupUseCase, userLogger)
 
-	// Create view history repository (in-memory for now)
-	viewHistoryRepository := NewInMemoryViewHistoryRepository()
+	// Create credential and session repositories
+	credentialRepository := userpersistence.NewCredentialRepositoryImpl(db)
+	sessionRepository := userpersistence.NewSessionRepositoryImpl(db)
+
+	// Create role use case
+	roleUseCase := userusecase.NewRoleUseCase(roleRepository, userRepository, groupRepository)
+
+	// Create role handler
+	roleHandler := userhandlers.NewRoleHandler(roleUseCase, authorizationUseCase, userLogger)
+
+	// Create auth use case
+	authUseCase := userusecase.NewAuthUseCase(userRepository, credentialRepository, sessionRepository, authorizationUseCase, provideSessionTTL())
+
+	// Create auth handler
+	authHandler := userhandlers.NewAuthHandler(authUseCase, userLogger)
+
+	// Create OIDC handler when single sign-on is configured
+	oidcProvider, err := provideOIDCProvider()
+	if err != nil {
+		return nil, err
+	}
+	var oidcHandler *userhandlers.OIDCHandler
+	if oidcProvider != nil {
+		identityRepository := userpersistence.NewExternalIdentityRepositoryImpl(db)
+		oidcUseCase := userusecase.NewOIDCUseCase(oidcProvider, userRepository, groupRepository, identityRepository, sessionRepository, provideSessionTTL())
+		oidcHandler = userhandlers.NewOIDCHandler(oidcUseCase, userLogger, os.Getenv("OIDC_POST_LOGIN_REDIRECT"))
+	}
+
+	// Create view history repository
+	viewHistoryRepository := viewhistorypersistence.NewViewHistoryRepositoryImpl(db)
 
 	// Create view history use case
 	viewHistoryUseCase := viewhistoryusecase.NewViewHistoryUseCase(viewHistoryRepository)
@@ -236,8 +580,8 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	// Create view history handler
 	viewHistoryHandler := viewhistoryhandlers.NewViewHistoryHandler(viewHistoryUseCase, viewHistoryLogger)
 
-	// Create view statistics repository (in-memory for now)
-	viewStatsRepository := NewInMemoryViewStatisticsRepository()
+	// Create view statistics repository
+	viewStatsRepository := viewstatspersistence.NewViewStatisticsRepositoryImpl(db)
 
 	// Create view statistics use case
 	viewStatsUseCase := viewstatsusecase.NewViewStatisticsUseCase(viewStatsRepository)
@@ -248,5 +592,29 @@ func InitializeAPI(db *pgxpool.Pool) (*repohandlers.RepositoryHandler, *dochandl
 	// Create view statistics handler
 	viewStatsHandler := viewstatshandlers.NewViewStatisticsHandler(viewStatsUseCase, viewStatsLogger)
 
-	return repositoryHandler, documentHandler, variableHandler, executionRecordHandler, attachmentHandler, userHandler, groupHandler, viewHistoryHandler, viewStatsHandler, nil
+	return &API{
+		RepositoryHandler:      repositoryHandler,
+		WebhookHandler:         webhookHandler,
+		SyncHandler:            syncHandler,
+		GitHubAppHandler:       githubAppHandler,
+		ArchiveHandler:         archiveHandler,
+		DocumentHandler:        documentHandler,
+		VariableHandler:        variableHandler,
+		AccessGrantHandler:     accessGrantHandler,
+		ImportHandler:          importHandler,
+		ExecutionRecordHandler: executionRecordHandler,
+		AttachmentHandler:      attachmentHandler,
+		UserHandler:            userHandler,
+		GroupHandler:           groupHandler,
+		RoleHandler:            roleHandler,
+		ViewHistoryHandler:     viewHistoryHandler,
+		ViewStatsHandler:       viewStatsHandler,
+		AuthHandler:            authHandler,
+		OIDCHandler:            oidcHandler,
+		AuthUseCase:            authUseCase,
+		AuthorizationUseCase:   authorizationUseCase,
+		SyncWorker:             syncWorker,
+		SyncScheduler:          syncScheduler,
+		TokenChecker:           tokenChecker,
+	}, nil
 }
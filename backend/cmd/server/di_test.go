@@ -0,0 +1,111 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestProvideEncryptor はprovideEncryptorのテストです
+func TestProvideEncryptor(t *testing.T) {
+	newKey := func(t *testing.T) string {
+		key := make([]byte, 32)
+		_, err := rand.Read(key)
+		require.NoError(t, err)
+		return base64.StdEncoding.EncodeToString(key)
+	}
+
+	// テスト：本番環境では開発用の既定キーで起動できないことを確認する
+	t.Run("本番環境では開発用の既定キーを拒否する", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+
+		t.Setenv("ENCRYPTION_KEY", "")
+		_, err := provideEncryptor()
+		assert.Error(t, err)
+
+		t.Setenv("ENCRYPTION_KEY", developmentEncryptionKey)
+		_, err = provideEncryptor()
+		assert.Error(t, err)
+	})
+
+	// テスト：開発環境ではキーが未設定でも既定キーで起動できることを確認する
+	t.Run("開発環境では既定キーを使う", func(t *testing.T) {
+		t.Setenv("APP_ENV", "")
+		t.Setenv("ENCRYPTION_KEY", "")
+
+		encryptor, err := provideEncryptor()
+		require.NoError(t, err)
+		assert.Equal(t, "default", encryptor.Keyring().ActiveKeyID())
+	})
+
+	// テスト：ローテーション後も以前のキーで暗号化したデータを復号できることを確認する
+	t.Run("以前のキーで暗号化したデータを復号できる", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+		oldKey, currentKey := newKey(t), newKey(t)
+
+		t.Setenv("ENCRYPTION_KEY", oldKey)
+		t.Setenv("ENCRYPTION_KEY_ID", "2024")
+		t.Setenv("ENCRYPTION_PREVIOUS_KEYS", "")
+		oldEncryptor, err := provideEncryptor()
+		require.NoError(t, err)
+		ciphertext, err := oldEncryptor.Encrypt("ghp_token")
+		require.NoError(t, err)
+
+		t.Setenv("ENCRYPTION_KEY", currentKey)
+		t.Setenv("ENCRYPTION_KEY_ID", "2025")
+		t.Setenv("ENCRYPTION_PREVIOUS_KEYS", "2024:"+oldKey)
+		encryptor, err := provideEncryptor()
+		require.NoError(t, err)
+
+		plaintext, err := encryptor.Decrypt(ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_token", plaintext)
+		assert.Equal(t, []string{"2025", "2024"}, encryptor.Keyring().KeyIDs())
+	})
+
+	// テスト：以前のキーの形式が不正な場合はエラーになることを確認する
+	t.Run("以前のキーの形式が不正な場合はエラーになる", func(t *testing.T) {
+		t.Setenv("APP_ENV", "")
+		t.Setenv("ENCRYPTION_KEY", newKey(t))
+		t.Setenv("ENCRYPTION_KEY_ID", "")
+		t.Setenv("ENCRYPTION_PREVIOUS_KEYS", "missing-separator")
+
+		_, err := provideEncryptor()
+		require.Error(t, err)
+		assert.True(t, strings.Contains(err.Error(), "ENCRYPTION_PREVIOUS_KEYS"))
+	})
+
+	// テスト：鍵ファイルを指定すると封筒暗号化になり、ENCRYPTION_KEYがなくても起動できることを確認する
+	t.Run("鍵ファイルを指定すると封筒暗号化になる", func(t *testing.T) {
+		t.Setenv("APP_ENV", "production")
+		t.Setenv("ENCRYPTION_KEY", "")
+		t.Setenv("ENCRYPTION_PREVIOUS_KEYS", "")
+		keyFile := filepath.Join(t.TempDir(), "keys.json")
+		require.NoError(t, os.WriteFile(keyFile, []byte(`{"active": "master-1", "keys": {"master-1": "`+newKey(t)+`"}}`), 0o600))
+		t.Setenv("KEY_PROVIDER", "local")
+		t.Setenv("ENCRYPTION_KEY_FILE", keyFile)
+
+		encryptor, err := provideEncryptor()
+		require.NoError(t, err)
+		assert.Equal(t, "master-1", encryptor.ActiveKeyID())
+		assert.Nil(t, encryptor.Keyring())
+
+		ciphertext, err := encryptor.Encrypt("ghp_token")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(ciphertext, "v2:master-1:"))
+	})
+
+	// テスト：未知のキープロバイダーはエラーになることを確認する
+	t.Run("未知のキープロバイダーはエラーになる", func(t *testing.T) {
+		t.Setenv("KEY_PROVIDER", "hsm")
+
+		_, err := provideEncryptor()
+		assert.Error(t, err)
+	})
+}
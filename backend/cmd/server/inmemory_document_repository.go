@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"fmt"
 	"sync"
 
 	"opscore/backend/internal/document/domain/entity"
@@ -78,7 +79,14 @@ func (r *InMemoryDocumentRepository) FindPublished(ctx context.Context, filters
 
 	var result []entity.Document
 	for _, doc := range r.documents {
-		if doc.IsPublished() {
+		if !doc.IsPublished() {
+			continue
+		}
+		matched, err := matchesDocumentFilters(doc, filters)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
 			result = append(result, doc)
 		}
 	}
@@ -86,6 +94,40 @@ func (r *InMemoryDocumentRepository) FindPublished(ctx context.Context, filters
 	return result, nil
 }
 
+// matchesDocumentFilters reports whether a document satisfies every filter.
+func matchesDocumentFilters(doc entity.Document, filters []repository.Filter) (bool, error) {
+	current := doc.CurrentVersion()
+	for _, f := range filters {
+		switch f := f.(type) {
+		case repository.RepositoryFilter:
+			if !doc.RepositoryID().Equals(f.RepositoryID) {
+				return false, nil
+			}
+		case repository.TypeFilter:
+			if current == nil || !current.Type().Equals(f.Type) {
+				return false, nil
+			}
+		case repository.TagFilter:
+			if current == nil || !hasTag(current.Tags(), f.Tag) {
+				return false, nil
+			}
+		default:
+			return false, fmt.Errorf("unsupported document filter %T", f)
+		}
+	}
+	return true, nil
+}
+
+// hasTag reports whether tags contains tag.
+func hasTag(tags []value_object.Tag, tag value_object.Tag) bool {
+	for _, t := range tags {
+		if t.Equals(tag) {
+			return true
+		}
+	}
+	return false
+}
+
 // Update updates an existing document.
 func (r *InMemoryDocumentRepository) Update(ctx context.Context, document entity.Document) error {
 	r.mu.Lock()
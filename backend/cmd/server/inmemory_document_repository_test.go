@@ -0,0 +1,16 @@
+package main
+
+import (
+	"testing"
+
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/repository/repositorytest"
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+// TestInMemoryDocumentRepository_Contract keeps the in-memory repository in line with the PostgreSQL one
+func TestInMemoryDocumentRepository_Contract(t *testing.T) {
+	repositorytest.RunDocumentRepositoryContract(t, func(t *testing.T, _ ...value_object.RepositoryID) repository.DocumentRepository {
+		return NewInMemoryDocumentRepository()
+	})
+}
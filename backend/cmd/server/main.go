@@ -2,11 +2,16 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"sync"
+	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jackc/pgx/v5/pgxpool"
@@ -14,8 +19,14 @@ import (
 	ginSwagger "github.com/swaggo/gin-swagger"
 
 	_ "opscore/backend/docs" // docs is generated by Swag CLI
+	userdto "opscore/backend/internal/user/application/dto"
+	uservo "opscore/backend/internal/user/domain/value_object"
+	authmiddleware "opscore/backend/internal/user/interfaces/api/middleware"
 )
 
+// shutdownTimeout bounds how long in-flight requests may take to finish once shutdown starts.
+const shutdownTimeout = 10 * time.Second
+
 // @title OpsCore Backend API
 // @version 1.0
 // @description This is the API documentation for the OpsCore backend service.
@@ -47,12 +58,60 @@ func main() {
 	fmt.Println("Successfully connected to the database.")
 	// --- End Database Connection ---
 
+	// Administrative commands (e.g. `server reencrypt-credentials`) run and exit without serving the API
+	if len(os.Args) > 1 {
+		if err := runCommand(context.Background(), dbpool, os.Args[1:]); err != nil {
+			fmt.Fprintf(os.Stderr, "Command %s failed: %v\n", os.Args[1], err)
+			os.Exit(1)
+		}
+		return
+	}
+
 	// Initialize dependencies using Wire, passing the db pool
-	repoHandler, docHandler, varHandler, execHandler, attachHandler, userHandler, groupHandler, viewHistoryHandler, viewStatsHandler, err := InitializeAPI(dbpool) // Pass dbpool and handle error
+	app, err := InitializeAPI(dbpool) // Pass dbpool and handle error
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Failed to initialize API dependencies: %v\n", err)
 		os.Exit(1)
 	}
+	repoHandler, docHandler, varHandler, accessHandler := app.RepositoryHandler, app.DocumentHandler, app.VariableHandler, app.AccessGrantHandler
+	importHandler := app.ImportHandler
+	execHandler, attachHandler := app.ExecutionRecordHandler, app.AttachmentHandler
+	userHandler, groupHandler, roleHandler := app.UserHandler, app.GroupHandler, app.RoleHandler
+	viewHistoryHandler, viewStatsHandler := app.ViewHistoryHandler, app.ViewStatsHandler
+	authHandler, oidcHandler, authUseCase := app.AuthHandler, app.OIDCHandler, app.AuthUseCase
+	webhookHandler, syncHandler, githubAppHandler, archiveHandler := app.WebhookHandler, app.SyncHandler, app.GitHubAppHandler, app.ArchiveHandler
+
+	// Background work stops when the server is asked to shut down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	// Run repository syncs enqueued by webhooks, the scheduler and operators in the background
+	var workers sync.WaitGroup
+	for _, start := range []func(context.Context){app.SyncWorker.Start, app.SyncScheduler.Start, app.TokenChecker.Start} {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			start(ctx)
+		}()
+	}
+
+	// requirePermission rejects callers that do not hold the permission through their roles
+	requirePermission := func(permission string) gin.HandlerFunc {
+		return authmiddleware.RequirePermission(app.AuthorizationUseCase, permission)
+	}
+
+	// Create the initial administrator when ADMIN_EMAIL and ADMIN_PASSWORD are set
+	if adminEmail, adminPassword := os.Getenv("ADMIN_EMAIL"), os.Getenv("ADMIN_PASSWORD"); adminEmail != "" && adminPassword != "" {
+		err = authUseCase.EnsureAdmin(context.Background(), userdto.BootstrapAdminRequest{
+			Name:     os.Getenv("ADMIN_NAME"),
+			Email:    adminEmail,
+			Password: adminPassword,
+		})
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to create initial administrator: %v\n", err)
+			os.Exit(1)
+		}
+	}
 
 	r := gin.Default()
 
@@ -61,7 +120,7 @@ func main() {
 		c.Writer.Header().Set("Access-Control-Allow-Origin", "*")
 		c.Writer.Header().Set("Access-Control-Allow-Credentials", "true")
 		c.Writer.Header().Set("Access-Control-Allow-Headers", "Content-Type, Content-Length, Accept-Encoding, X-CSRF-Token, Authorization, accept, origin, Cache-Control, X-Requested-With")
-		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, DELETE")
+		c.Writer.Header().Set("Access-Control-Allow-Methods", "POST, OPTIONS, GET, PUT, PATCH, DELETE")
 
 		if c.Request.Method == "OPTIONS" {
 			c.AbortWithStatus(204)
@@ -77,80 +136,138 @@ func main() {
 
 	// API v1 routes group
 	v1 := r.Group("/api/v1")
+
+	// Public routes
+	v1.GET("/health", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"status": "ok"})
+	})
+	v1.POST("/auth/login", authHandler.Login)
+	if oidcHandler != nil {
+		v1.GET("/auth/oidc/login", oidcHandler.Login)
+		v1.GET("/auth/oidc/callback", oidcHandler.Callback)
+	}
+	// Webhooks are authenticated by their signature or secret token instead of a session
+	v1.POST("/webhooks/:provider", webhookHandler.ReceiveWebhook)
+
+	// Every other route requires an authenticated caller
+	api := v1.Group("", authmiddleware.RequireAuthentication(authUseCase))
 	{
+		// Auth routes
+		api.POST("/auth/logout", authHandler.Logout)
+		api.GET("/auth/me", authHandler.Me)
+		api.PUT("/auth/password", authHandler.ChangePassword)
+		api.GET("/auth/me/permissions", roleHandler.GetMyPermissions)
+
 		// Repository routes - use methods from the initialized handler
-		v1.POST("/repositories", repoHandler.RegisterRepository)
-		v1.GET("/repositories", repoHandler.ListRepositories)      // Adding this route to list all repositories
-		v1.GET("/repositories/:repoId", repoHandler.GetRepository) // New route to get repository details by ID
-		v1.GET("/repositories/:repoId/files", repoHandler.ListRepositoryFiles)
-		v1.POST("/repositories/:repoId/files/select", repoHandler.SelectRepositoryFiles)
-		v1.GET("/repositories/:repoId/markdown", repoHandler.GetSelectedMarkdown)
-		v1.PUT("/repositories/:repoId/token", repoHandler.UpdateAccessToken) // アクセストークン更新用エンドポイント
+		api.POST("/repositories", requirePermission(uservo.PermissionRepositoryManage), repoHandler.RegisterRepository)
+		api.POST("/repositories/archives", requirePermission(uservo.PermissionRepositoryManage), archiveHandler.RegisterArchive)
+		api.GET("/repositories", repoHandler.ListRepositories)      // Adding this route to list all repositories
+		api.GET("/repositories/:repoId", repoHandler.GetRepository) // New route to get repository details by ID
+		api.PATCH("/repositories/:repoId", requirePermission(uservo.PermissionRepositoryManage), repoHandler.UpdateRepository)
+		api.DELETE("/repositories/:repoId", requirePermission(uservo.PermissionRepositoryManage), repoHandler.DeleteRepository)
+		// Raw repository files bypass document ACLs, so reading them requires repository:manage
+		api.GET("/repositories/:repoId/files", requirePermission(uservo.PermissionRepositoryManage), repoHandler.ListRepositoryFiles)
+		api.POST("/repositories/:repoId/files/select", requirePermission(uservo.PermissionRepositoryManage), repoHandler.SelectRepositoryFiles)
+		api.PUT("/repositories/:repoId/files/rules", requirePermission(uservo.PermissionRepositoryManage), repoHandler.UpdateFileRules)
+		api.GET("/repositories/:repoId/markdown", requirePermission(uservo.PermissionRepositoryManage), repoHandler.GetSelectedMarkdown)
+		api.PUT("/repositories/:repoId/token", requirePermission(uservo.PermissionRepositoryManage), repoHandler.UpdateAccessToken) // アクセストークン更新用エンドポイント
+		api.POST("/repositories/:repoId/token/verify", requirePermission(uservo.PermissionRepositoryManage), repoHandler.VerifyAccessToken)
+		api.PUT("/repositories/:repoId/tracking", requirePermission(uservo.PermissionRepositoryManage), repoHandler.UpdateTracking)
+		api.PUT("/repositories/:repoId/archive", requirePermission(uservo.PermissionRepositoryManage), archiveHandler.ReplaceArchive)
+		api.GET("/repositories/:repoId/sync-runs", syncHandler.ListSyncRuns)
+		api.POST("/repositories/:repoId/sync", requirePermission(uservo.PermissionRepositoryManage), syncHandler.TriggerSync)
+		api.POST("/github-apps", requirePermission(uservo.PermissionRepositoryManage), githubAppHandler.RegisterGitHubApp)
+		api.GET("/github-apps", requirePermission(uservo.PermissionRepositoryManage), githubAppHandler.ListGitHubApps)
+		api.POST("/github-apps/:appId/repositories", requirePermission(uservo.PermissionRepositoryManage), githubAppHandler.RegisterInstallationRepository)
 
 		// Document routes
-		v1.POST("/documents", docHandler.CreateDocument)
-		v1.GET("/documents", docHandler.ListDocuments)
-		v1.GET("/documents/:docId", docHandler.GetDocument)
-		v1.PUT("/documents/:docId", docHandler.UpdateDocument)
-		v1.PATCH("/documents/:docId/metadata", docHandler.UpdateDocumentMetadata)
-		v1.GET("/documents/:docId/versions", docHandler.GetDocumentVersions)
-		v1.GET("/documents/:docId/versions/:version", docHandler.GetDocumentVersion)
-		v1.POST("/documents/:docId/versions/:version/publish", docHandler.PublishDocumentVersion)
-		v1.POST("/documents/:docId/versions/:version/rollback", docHandler.RollbackDocumentVersion)
+		api.POST("/documents", requirePermission(uservo.PermissionDocumentPublish), docHandler.CreateDocument)
+		api.GET("/documents", docHandler.ListDocuments)
+		api.GET("/documents/:docId", docHandler.GetDocument)
+		api.PUT("/documents/:docId", requirePermission(uservo.PermissionDocumentPublish), docHandler.UpdateDocument)
+		api.PATCH("/documents/:docId/metadata", requirePermission(uservo.PermissionDocumentPublish), docHandler.UpdateDocumentMetadata)
+		api.GET("/documents/:docId/versions", docHandler.GetDocumentVersions)
+		api.GET("/documents/:docId/versions/:version", docHandler.GetDocumentVersion)
+		api.POST("/documents/:docId/versions/:version/publish", requirePermission(uservo.PermissionDocumentPublish), docHandler.PublishDocumentVersion)
+		api.POST("/documents/:docId/versions/:version/rollback", requirePermission(uservo.PermissionDocumentPublish), docHandler.RollbackDocumentVersion)
+		api.POST("/repositories/:repoId/documents/import", requirePermission(uservo.PermissionDocumentPublish), importHandler.ImportDocuments)
+
+		// Document ACL routes (owner or admin only, checked in the use case)
+		api.GET("/documents/:docId/access", accessHandler.ListAccessGrants)
+		api.PUT("/documents/:docId/access/:principalType/:principalId", accessHandler.GrantAccess)
+		api.DELETE("/documents/:docId/access/:principalType/:principalId", accessHandler.RevokeAccess)
 
 		// Variable routes
-		v1.GET("/documents/:docId/variables", varHandler.GetVariableDefinitions)
-		v1.POST("/documents/:docId/validate-variables", varHandler.ValidateVariableValues)
+		api.GET("/documents/:docId/variables", varHandler.GetVariableDefinitions)
+		api.POST("/documents/:docId/validate-variables", varHandler.ValidateVariableValues)
 
 		// Execution record routes
-		v1.POST("/execution-records", execHandler.CreateExecutionRecord)
-		v1.GET("/execution-records", execHandler.SearchExecutionRecords)
-		v1.GET("/execution-records/:id", execHandler.GetExecutionRecord)
-		v1.PUT("/execution-records/:id/title", execHandler.UpdateTitle)
-		v1.PUT("/execution-records/:id/notes", execHandler.UpdateNotes)
-		v1.PUT("/execution-records/:id/access-scope", execHandler.UpdateAccessScope)
-		v1.POST("/execution-records/:id/complete", execHandler.Complete)
-		v1.POST("/execution-records/:id/fail", execHandler.MarkAsFailed)
-		v1.POST("/execution-records/:id/steps", execHandler.AddStep)
-		v1.PUT("/execution-records/:id/steps/:stepNumber/notes", execHandler.UpdateStepNotes)
-		v1.DELETE("/execution-records/:id", execHandler.DeleteExecutionRecord)
+		api.POST("/execution-records", execHandler.CreateExecutionRecord)
+		api.GET("/execution-records", execHandler.SearchExecutionRecords)
+		api.GET("/execution-records/:id", execHandler.GetExecutionRecord)
+		api.PUT("/execution-records/:id/title", execHandler.UpdateTitle)
+		api.PUT("/execution-records/:id/notes", execHandler.UpdateNotes)
+		api.PUT("/execution-records/:id/access-scope", execHandler.UpdateAccessScope)
+		api.POST("/execution-records/:id/complete", execHandler.Complete)
+		api.POST("/execution-records/:id/fail", execHandler.MarkAsFailed)
+		api.POST("/execution-records/:id/steps", execHandler.AddStep)
+		api.PUT("/execution-records/:id/steps/:stepNumber/notes", execHandler.UpdateStepNotes)
+		api.DELETE("/execution-records/:id", requirePermission(uservo.PermissionExecutionDelete), execHandler.DeleteExecutionRecord)
+		api.GET("/execution-records/:id/shares", execHandler.ListShares)
+		api.PUT("/execution-records/:id/shares/:principalType/:principalId", execHandler.ShareRecord)
+		api.DELETE("/execution-records/:id/shares/:principalType/:principalId", execHandler.UnshareRecord)
 
 		// Attachment routes
-		v1.POST("/execution-records/:id/attachments", attachHandler.UploadAttachment)
-		v1.GET("/attachments/:id", attachHandler.GetAttachment)
-		v1.GET("/attachments/:id/download", attachHandler.DownloadAttachment)
-		v1.GET("/attachments/:id/url", attachHandler.GetAttachmentURL)
-		v1.GET("/execution-records/:id/attachments", attachHandler.ListAttachments)
-		v1.GET("/execution-records/:id/steps/:stepId/attachments", attachHandler.ListStepAttachments)
-		v1.DELETE("/attachments/:id", attachHandler.DeleteAttachment)
+		api.POST("/execution-records/:id/attachments", attachHandler.UploadAttachment)
+		api.GET("/attachments/:id", attachHandler.GetAttachment)
+		api.GET("/attachments/:id/download", attachHandler.DownloadAttachment)
+		api.GET("/attachments/:id/url", attachHandler.GetAttachmentURL)
+		api.GET("/execution-records/:id/attachments", attachHandler.ListAttachments)
+		api.GET("/execution-records/:id/steps/:stepId/attachments", attachHandler.ListStepAttachments)
+		api.DELETE("/attachments/:id", requirePermission(uservo.PermissionExecutionDelete), attachHandler.DeleteAttachment)
 
 		// User routes
-		v1.POST("/users", userHandler.CreateUser)
-		v1.GET("/users/:userId", userHandler.GetUser)
-		v1.GET("/users", userHandler.ListUsers)
-		v1.PUT("/users/:userId", userHandler.UpdateUser)
-		v1.DELETE("/users/:userId", userHandler.DeleteUser)
+		api.POST("/users", requirePermission(uservo.PermissionUserAdmin), userHandler.CreateUser)
+		api.GET("/users/:userId", userHandler.GetUser)
+		api.GET("/users", userHandler.ListUsers)
+		api.PUT("/users/:userId", requirePermission(uservo.PermissionUserAdmin), userHandler.UpdateUser)
+		api.DELETE("/users/:userId", requirePermission(uservo.PermissionUserAdmin), userHandler.DeleteUser)
+		api.PUT("/users/:userId/password", authHandler.SetUserPassword)
 
 		// Group routes
-		v1.POST("/groups", groupHandler.CreateGroup)
-		v1.GET("/groups/:groupId", groupHandler.GetGroup)
-		v1.GET("/groups", groupHandler.ListGroups)
-		v1.PUT("/groups/:groupId", groupHandler.UpdateGroup)
-		v1.DELETE("/groups/:groupId", groupHandler.DeleteGroup)
-		v1.POST("/groups/:groupId/members", groupHandler.AddMember)
-		v1.DELETE("/groups/:groupId/members", groupHandler.RemoveMember)
-		v1.GET("/users/:userId/groups", groupHandler.GetUserGroups)
+		api.POST("/groups", requirePermission(uservo.PermissionUserAdmin), groupHandler.CreateGroup)
+		api.GET("/groups/:groupId", groupHandler.GetGroup)
+		api.GET("/groups", groupHandler.ListGroups)
+		api.PUT("/groups/:groupId", requirePermission(uservo.PermissionUserAdmin), groupHandler.UpdateGroup)
+		api.DELETE("/groups/:groupId", requirePermission(uservo.PermissionUserAdmin), groupHandler.DeleteGroup)
+		api.POST("/groups/:groupId/members", requirePermission(uservo.PermissionUserAdmin), groupHandler.AddMember)
+		api.DELETE("/groups/:groupId/members", requirePermission(uservo.PermissionUserAdmin), groupHandler.RemoveMember)
+		api.GET("/users/:userId/groups", groupHandler.GetUserGroups)
+
+		// Role and permission routes
+		api.GET("/permissions", roleHandler.ListPermissions)
+		api.GET("/roles", roleHandler.ListRoles)
+		api.GET("/roles/:roleId", roleHandler.GetRole)
+		api.POST("/roles", requirePermission(uservo.PermissionUserAdmin), roleHandler.CreateRole)
+		api.PUT("/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.UpdateRole)
+		api.DELETE("/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.DeleteRole)
+		api.GET("/users/:userId/roles", roleHandler.GetUserRoles)
+		api.PUT("/users/:userId/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.AssignUserRole)
+		api.DELETE("/users/:userId/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.UnassignUserRole)
+		api.GET("/groups/:groupId/roles", roleHandler.GetGroupRoles)
+		api.PUT("/groups/:groupId/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.AssignGroupRole)
+		api.DELETE("/groups/:groupId/roles/:roleId", requirePermission(uservo.PermissionUserAdmin), roleHandler.UnassignGroupRole)
 
 		// View history routes
-		v1.POST("/documents/:id/views", viewHistoryHandler.RecordView)
-		v1.GET("/users/:id/view-history", viewHistoryHandler.GetUserViewHistory)
-		v1.GET("/documents/:id/view-history", viewHistoryHandler.GetDocumentViewHistory)
+		api.POST("/documents/:id/views", viewHistoryHandler.RecordView)
+		api.GET("/users/:id/view-history", viewHistoryHandler.GetUserViewHistory)
+		api.GET("/documents/:id/view-history", viewHistoryHandler.GetDocumentViewHistory)
 
 		// View statistics routes
-		v1.GET("/documents/:id/statistics", viewStatsHandler.GetDocumentStatistics)
-		v1.GET("/users/:id/statistics", viewStatsHandler.GetUserStatistics)
-		v1.GET("/statistics/popular-documents", viewStatsHandler.GetPopularDocuments)
-		v1.GET("/statistics/recent-documents", viewStatsHandler.GetRecentlyViewedDocuments)
+		api.GET("/documents/:id/statistics", viewStatsHandler.GetDocumentStatistics)
+		api.GET("/users/:id/statistics", viewStatsHandler.GetUserStatistics)
+		api.GET("/statistics/popular-documents", viewStatsHandler.GetPopularDocuments)
+		api.GET("/statistics/recent-documents", viewStatsHandler.GetRecentlyViewedDocuments)
 	}
 
 	// Static file serving (Frontend)
@@ -173,6 +290,28 @@ func main() {
 	})
 
 	port := "8080"
+	server := &http.Server{Addr: ":" + port, Handler: r}
+	serverErr := make(chan error, 1)
+	go func() {
+		serverErr <- server.ListenAndServe()
+	}()
 	println("Backend server listening at http://localhost:" + port)
-	r.Run(":" + port)
+
+	select {
+	case err := <-serverErr:
+		fmt.Fprintf(os.Stderr, "Server stopped: %v\n", err)
+		stop()
+		workers.Wait()
+		os.Exit(1)
+	case <-ctx.Done():
+	}
+
+	fmt.Println("Shutting down...")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	if err := server.Shutdown(shutdownCtx); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		fmt.Fprintf(os.Stderr, "Failed to shut down the server: %v\n", err)
+	}
+	// A sync in progress is cancelled; wait for it to stop before the database pool is closed
+	workers.Wait()
 }
@@ -0,0 +1,26 @@
+package dto
+
+import "time"
+
+// Caller identifies the user on whose behalf a use case accesses documents
+type Caller struct {
+	UserID   string
+	GroupIDs []string
+}
+
+// GrantAccessRequest represents the use case request for granting access to a document
+type GrantAccessRequest struct {
+	PrincipalType string // "user" or "group"
+	PrincipalID   string
+	Level         string // "view" or "execute"
+}
+
+// AccessGrantResponse represents the use case response for a document ACL entry
+type AccessGrantResponse struct {
+	DocumentID    string
+	PrincipalType string
+	PrincipalID   string
+	Level         string
+	GrantedBy     string
+	GrantedAt     time.Time
+}
@@ -14,16 +14,18 @@ func ToDocumentResponse(doc entity.Document) DocumentResponse {
 	}
 
 	return DocumentResponse{
-		ID:             doc.ID().String(),
-		RepositoryID:   doc.RepositoryID().String(),
-		Owner:          doc.Owner(),
-		IsPublished:    doc.IsPublished(),
-		IsAutoUpdate:   doc.IsAutoUpdate(),
-		AccessScope:    doc.AccessScope().String(),
-		CurrentVersion: currentVersion,
-		VersionCount:   len(doc.Versions()),
-		CreatedAt:      doc.CreatedAt(),
-		UpdatedAt:      doc.UpdatedAt(),
+		ID:              doc.ID().String(),
+		RepositoryID:    doc.RepositoryID().String(),
+		Owner:           doc.Owner(),
+		IsPublished:     doc.IsPublished(),
+		IsAutoUpdate:    doc.IsAutoUpdate(),
+		AccessScope:     doc.AccessScope().String(),
+		UpdateAvailable: doc.IsUpdateAvailable(),
+		PendingCommit:   doc.PendingCommitHash().String(),
+		CurrentVersion:  currentVersion,
+		VersionCount:    len(doc.Versions()),
+		CreatedAt:       doc.CreatedAt(),
+		UpdatedAt:       doc.UpdatedAt(),
 	}
 }
 
@@ -78,16 +80,17 @@ func ToDocumentListItemResponse(doc entity.Document) DocumentListItemResponse {
 	}
 
 	return DocumentListItemResponse{
-		ID:           doc.ID().String(),
-		RepositoryID: doc.RepositoryID().String(),
-		Title:        title,
-		Owner:        doc.Owner(),
-		DocType:      docType,
-		Tags:         tags,
-		IsPublished:  doc.IsPublished(),
-		VersionCount: len(doc.Versions()),
-		CreatedAt:    doc.CreatedAt(),
-		UpdatedAt:    doc.UpdatedAt(),
+		ID:              doc.ID().String(),
+		RepositoryID:    doc.RepositoryID().String(),
+		Title:           title,
+		Owner:           doc.Owner(),
+		DocType:         docType,
+		Tags:            tags,
+		IsPublished:     doc.IsPublished(),
+		VersionCount:    len(doc.Versions()),
+		CreatedAt:       doc.CreatedAt(),
+		UpdatedAt:       doc.UpdatedAt(),
+		UpdateAvailable: doc.IsUpdateAvailable(),
 	}
 }
 
@@ -148,3 +151,29 @@ func ToVariableDefinitionSlice(variables []VariableDefinitionDTO) ([]value_objec
 	}
 	return result, nil
 }
+
+// ToAccessor converts a Caller to the domain Accessor; override is whether the caller bypasses access scopes and ACLs
+func ToAccessor(caller Caller, override bool) value_object.Accessor {
+	return value_object.NewAccessor(caller.UserID, caller.GroupIDs, override)
+}
+
+// ToAccessGrantResponse converts a domain AccessGrant to a DTO AccessGrantResponse
+func ToAccessGrantResponse(grant entity.AccessGrant) AccessGrantResponse {
+	return AccessGrantResponse{
+		DocumentID:    grant.DocumentID().String(),
+		PrincipalType: grant.PrincipalType().String(),
+		PrincipalID:   grant.PrincipalID(),
+		Level:         grant.Level().String(),
+		GrantedBy:     grant.GrantedBy(),
+		GrantedAt:     grant.GrantedAt(),
+	}
+}
+
+// ToAccessGrantListResponse converts a slice of domain AccessGrants to DTOs
+func ToAccessGrantListResponse(grants []entity.AccessGrant) []AccessGrantResponse {
+	result := make([]AccessGrantResponse, len(grants))
+	for i, grant := range grants {
+		result[i] = ToAccessGrantResponse(grant)
+	}
+	return result
+}
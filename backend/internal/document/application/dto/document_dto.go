@@ -6,7 +6,7 @@ import "time"
 type CreateDocumentRequest struct {
 	RepositoryID string
 	FilePath     string
-	CommitHash   string
+	CommitHash   string // Optional; resolved from the repository when empty
 	Title        string
 	DocType      string   // "procedure" or "knowledge"
 	Owner        string
@@ -20,7 +20,7 @@ type CreateDocumentRequest struct {
 // UpdateDocumentRequest represents the use case request for updating a document
 type UpdateDocumentRequest struct {
 	FilePath   string
-	CommitHash string
+	CommitHash string // Optional; resolved from the repository when empty
 	Title      string
 	DocType    string
 	Tags       []string
@@ -55,6 +55,8 @@ type DocumentResponse struct {
 	IsPublished     bool
 	IsAutoUpdate    bool
 	AccessScope     string
+	UpdateAvailable bool   // An upstream change is waiting for manual review
+	PendingCommit   string // Commit of the upstream change when UpdateAvailable
 	CurrentVersion  *DocumentVersionResponse
 	VersionCount    int
 	CreatedAt       time.Time
@@ -80,16 +82,17 @@ type DocumentVersionResponse struct {
 
 // DocumentListItemResponse represents a document item in a list response
 type DocumentListItemResponse struct {
-	ID           string
-	RepositoryID string
-	Title        string
-	Owner        string
-	DocType      string
-	Tags         []string
-	IsPublished  bool
-	VersionCount int
-	CreatedAt    time.Time
-	UpdatedAt    time.Time
+	ID              string
+	RepositoryID    string
+	Title           string
+	Owner           string
+	DocType         string
+	Tags            []string
+	IsPublished     bool
+	VersionCount    int
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+	UpdateAvailable bool
 }
 
 // VersionHistoryResponse represents the version history for a document
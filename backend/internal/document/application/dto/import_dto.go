@@ -0,0 +1,37 @@
+package dto
+
+// Import statuses reported for each managed file
+const (
+	ImportStatusCreated   = "created"
+	ImportStatusUpdated   = "updated"
+	ImportStatusUnchanged = "unchanged"
+	ImportStatusSkipped   = "skipped"
+	ImportStatusFailed    = "failed"
+)
+
+// ImportDocumentsRequest represents the use case request for importing documents from a repository
+type ImportDocumentsRequest struct {
+	RepositoryID string
+	Owner        string // Owner of the documents created by the import
+	AccessScope  string // Access scope of the documents created by the import; "public" when empty
+}
+
+// ImportFileError represents a validation error found in a managed file
+type ImportFileError struct {
+	Field   string
+	Message string
+}
+
+// ImportFileResult represents the outcome of importing a single managed file
+type ImportFileResult struct {
+	FilePath   string
+	Status     string // "created", "updated", "unchanged", "skipped" or "failed"
+	DocumentID string
+	Errors     []ImportFileError
+}
+
+// ImportResultResponse represents the use case response for an import
+type ImportResultResponse struct {
+	RepositoryID string
+	Files        []ImportFileResult
+}
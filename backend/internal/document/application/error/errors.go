@@ -66,6 +66,26 @@ func (e *ValidationFailedError) ErrorCode() ErrorCode {
 	return e.Code
 }
 
+// ForbiddenError represents an authorization failure
+type ForbiddenError struct {
+	Code     ErrorCode
+	Resource string
+	Action   string
+	UserID   string
+}
+
+func (e *ForbiddenError) Error() string {
+	return fmt.Sprintf("[%s] user %s is forbidden to %s on resource %s", e.Code, e.UserID, e.Action, e.Resource)
+}
+
+func (e *ForbiddenError) Is(target error) bool {
+	return target == ErrForbidden
+}
+
+func (e *ForbiddenError) ErrorCode() ErrorCode {
+	return e.Code
+}
+
 // ConflictError represents a resource conflict (e.g., duplicate key)
 type ConflictError struct {
 	Code         ErrorCode
@@ -126,3 +146,13 @@ func NewConflictError(resourceType, identifier, reason string, cause error) *Con
 		Cause:        cause,
 	}
 }
+
+// NewForbiddenError creates a new ForbiddenError with the correct error code
+func NewForbiddenError(resource, action, userID string) *ForbiddenError {
+	return &ForbiddenError{
+		Code:     CodeForbidden,
+		Resource: resource,
+		Action:   action,
+		UserID:   userID,
+	}
+}
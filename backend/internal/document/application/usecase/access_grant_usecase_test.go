@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+	uservo "opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAccessGrantUseCase_GrantAccess(t *testing.T) {
+	t.Run("所有者はグループに閲覧権限を付与できる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.accessGrant")).Return(nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf())
+		result, err := uc.GrantAccess(context.Background(), dto.Caller{UserID: "test-owner"}, doc.ID().String(), &dto.GrantAccessRequest{
+			PrincipalType: "group",
+			PrincipalID:   "sre",
+			Level:         "execute",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "group", result.PrincipalType)
+		assert.Equal(t, "sre", result.PrincipalID)
+		assert.Equal(t, "execute", result.Level)
+		assert.Equal(t, "test-owner", result.GrantedBy)
+
+		mockGrantRepo.AssertExpectations(t)
+	})
+
+	t.Run("無効なアクセスレベルではバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf(uservo.PermissionUserAdmin))
+		result, err := uc.GrantAccess(context.Background(), dto.Caller{UserID: "admin-1"}, doc.ID().String(), &dto.GrantAccessRequest{
+			PrincipalType: "team",
+			PrincipalID:   "sre",
+			Level:         "owner",
+		})
+
+		assert.Nil(t, result)
+		var validationErr *apperror.ValidationFailedError
+		assert.True(t, errors.As(err, &validationErr))
+		assert.Len(t, validationErr.Errors, 2)
+		mockGrantRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("閲覧権限しか持たないユーザーは権限を付与できない", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, doc.ID()).Return(grants, nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf())
+		_, err := uc.GrantAccess(context.Background(), dto.Caller{UserID: "user-1", GroupIDs: []string{"sre"}}, doc.ID().String(), &dto.GrantAccessRequest{
+			PrincipalType: "user",
+			PrincipalID:   "user-1",
+			Level:         "execute",
+		})
+
+		assert.True(t, errors.Is(err, apperror.ErrForbidden))
+		mockGrantRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+func TestAccessGrantUseCase_ListGrants(t *testing.T) {
+	t.Run("管理者はACLを一覧できる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, doc.ID()).Return(grants, nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf(uservo.PermissionUserAdmin))
+		result, err := uc.ListGrants(context.Background(), dto.Caller{UserID: "admin-1"}, doc.ID().String())
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 1)
+		assert.Equal(t, "sre", result[0].PrincipalID)
+	})
+
+	t.Run("閲覧できないユーザーにはドキュメントが存在しないものとして扱われる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, doc.ID()).Return(grants, nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf())
+		result, err := uc.ListGrants(context.Background(), dto.Caller{UserID: "user-2"}, doc.ID().String())
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+	})
+}
+
+func TestAccessGrantUseCase_RevokeAccess(t *testing.T) {
+	t.Run("所有者はアクセス権を取り消せる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("Delete", mock.Anything, doc.ID(), value_object.PrincipalTypeGroup, "sre").Return(nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, mockGrantRepo, permissionsOf())
+		err := uc.RevokeAccess(context.Background(), dto.Caller{UserID: "test-owner"}, doc.ID().String(), "group", "sre")
+
+		assert.NoError(t, err)
+		mockGrantRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しないドキュメントではエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		docID := value_object.GenerateDocumentID()
+
+		mockRepo.On("FindByID", mock.Anything, docID).Return(nil, nil)
+
+		uc := NewAccessGrantUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
+		err := uc.RevokeAccess(context.Background(), dto.Caller{UserID: "test-owner"}, docID.String(), "group", "sre")
+
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+	})
+}
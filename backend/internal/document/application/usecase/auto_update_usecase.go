@@ -0,0 +1,171 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+// AutoUpdateUseCase defines the interface for following upstream changes to document sources.
+type AutoUpdateUseCase interface {
+	// RepositorySynced publishes a new version of every auto-update document whose source file changed in a
+	// repository sync, and flags the other documents whose source file changed as having an update available.
+	RepositorySynced(ctx context.Context, repositoryID string, changedFiles []string) error
+}
+
+// autoUpdateUseCase implements the AutoUpdateUseCase interface.
+type autoUpdateUseCase struct {
+	repo    repository.DocumentRepository
+	docs    DocumentUseCase
+	files   ManagedFileReader
+	commits SourceCommitResolver
+	logger  *slog.Logger
+}
+
+// NewAutoUpdateUseCase creates a new instance of autoUpdateUseCase.
+func NewAutoUpdateUseCase(repo repository.DocumentRepository, docs DocumentUseCase, files ManagedFileReader, commits SourceCommitResolver, logger *slog.Logger) AutoUpdateUseCase {
+	return &autoUpdateUseCase{
+		repo:    repo,
+		docs:    docs,
+		files:   files,
+		commits: commits,
+		logger:  logger,
+	}
+}
+
+// RepositorySynced applies the upstream changes of a sync to the repository's documents.
+// A document whose update cannot be published automatically is flagged for manual review instead.
+func (uc *autoUpdateUseCase) RepositorySynced(ctx context.Context, repositoryID string, changedFiles []string) error {
+	repoID, err := value_object.NewRepositoryID(repositoryID)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "repository_id", Message: err.Error()},
+		})
+	}
+
+	changed := make(map[string]bool, len(changedFiles))
+	for _, f := range changedFiles {
+		changed[f] = true
+	}
+
+	docs, err := uc.repo.FindByRepositoryID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to find documents: %w", err)
+	}
+
+	var errs []error
+	for _, doc := range docs {
+		version := latestVersion(doc)
+		if version == nil || !changed[version.Source().FilePath().String()] {
+			continue
+		}
+		if err := uc.applyChange(ctx, doc, version); err != nil {
+			errs = append(errs, fmt.Errorf("document %s: %w", doc.ID().String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// applyChange publishes or flags the upstream change of a single document's source file.
+func (uc *autoUpdateUseCase) applyChange(ctx context.Context, doc entity.Document, version entity.DocumentVersion) error {
+	repositoryID := doc.RepositoryID().String()
+	filePath := version.Source().FilePath().String()
+
+	// Resolve the commit that last touched the file; an unchanged commit means there is nothing new
+	commit, err := uc.commits.ResolveFileCommit(ctx, repositoryID, filePath)
+	if err != nil {
+		uc.logger.Warn("Source file of document could not be resolved", "doc_id", doc.ID().String(), "repo_id", repositoryID, "file_path", filePath, "error", err.Error())
+		return nil
+	}
+	commitHash, err := value_object.NewCommitHash(commit)
+	if err != nil {
+		return fmt.Errorf("invalid commit hash of %s: %w", filePath, err)
+	}
+	if commitHash == version.Source().CommitHash() || commitHash == doc.PendingCommitHash() {
+		return nil
+	}
+
+	// Documents without auto-update, and unpublished ones, wait for a person to publish the change
+	if !doc.IsAutoUpdate() || !doc.IsPublished() {
+		return uc.markUpdateAvailable(ctx, doc, commitHash, "auto-update is disabled")
+	}
+
+	req, reason := uc.buildUpdate(ctx, repositoryID, filePath, commit, version)
+	if req == nil {
+		return uc.markUpdateAvailable(ctx, doc, commitHash, reason)
+	}
+
+	response, err := uc.docs.UpdateDocument(ctx, doc.ID().String(), req)
+	if err != nil {
+		var validationErr *apperror.ValidationFailedError
+		if errors.As(err, &validationErr) {
+			return uc.markUpdateAvailable(ctx, doc, commitHash, err.Error())
+		}
+		return fmt.Errorf("failed to publish new version: %w", err)
+	}
+
+	uc.logger.Info("Document auto-updated", "doc_id", response.ID, "repo_id", repositoryID, "file_path", filePath,
+		"version", response.CurrentVersion.VersionNumber, "commit", commit, "previous_commit", version.Source().CommitHash().String())
+	return nil
+}
+
+// buildUpdate reads the changed file and builds the new version from its frontmatter.
+// A file without frontmatter keeps the metadata of the current version. When the file cannot
+// be published automatically, it returns nil and the reason.
+func (uc *autoUpdateUseCase) buildUpdate(ctx context.Context, repositoryID, filePath, commit string, version entity.DocumentVersion) (*dto.UpdateDocumentRequest, string) {
+	content, err := uc.files.ReadManagedFile(ctx, repositoryID, filePath)
+	if err != nil {
+		return nil, fmt.Sprintf("file could not be read: %v", err)
+	}
+
+	fm, body, err := parseFrontmatter(content)
+	if errors.Is(err, errNoFrontmatter) {
+		current := dto.ToDocumentVersionResponse(version)
+		return &dto.UpdateDocumentRequest{
+			FilePath:   filePath,
+			CommitHash: commit,
+			Title:      current.Title,
+			DocType:    current.DocType,
+			Tags:       current.Tags,
+			Variables:  current.Variables,
+			Content:    string(content),
+		}, ""
+	}
+	if err != nil {
+		return nil, err.Error()
+	}
+	if fieldErrors := validateFrontmatter(fm, body); len(fieldErrors) > 0 {
+		return nil, apperror.NewValidationFailedError(fieldErrors).Error()
+	}
+
+	return &dto.UpdateDocumentRequest{
+		FilePath:   filePath,
+		CommitHash: commit,
+		Title:      fm.Title,
+		DocType:    fm.Type,
+		Tags:       fm.Tags,
+		Variables:  fm.variableDTOs(),
+		Content:    body,
+	}, ""
+}
+
+// markUpdateAvailable flags the document for manual review of the upstream change.
+func (uc *autoUpdateUseCase) markUpdateAvailable(ctx context.Context, doc entity.Document, commitHash value_object.CommitHash, reason string) error {
+	if err := doc.MarkUpdateAvailable(commitHash); err != nil {
+		return fmt.Errorf("failed to flag update: %w", err)
+	}
+	if err := uc.repo.Update(ctx, doc); err != nil {
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+
+	uc.logger.Info("Document update available", "doc_id", doc.ID().String(), "repo_id", doc.RepositoryID().String(),
+		"commit", commitHash.String(), "reason", reason)
+	return nil
+}
@@ -0,0 +1,148 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newAutoUpdateUseCaseForTest wires the auto-update use case to mocks, using the real document use case underneath
+func newAutoUpdateUseCaseForTest() (AutoUpdateUseCase, *repository.MockDocumentRepository, *MockManagedFileReader, *MockSourceCommitResolver) {
+	mockRepo := new(repository.MockDocumentRepository)
+	mockFiles := new(MockManagedFileReader)
+	mockCommits := new(MockSourceCommitResolver)
+	docs := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), mockCommits, permissionsOf())
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+	return NewAutoUpdateUseCase(mockRepo, docs, mockFiles, mockCommits, logger), mockRepo, mockFiles, mockCommits
+}
+
+func TestAutoUpdateUseCase_RepositorySynced(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("自動更新が有効なドキュメントは新しいバージョンが公開される", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t) // docs/test.md at abc1234567890
+		doc.EnableAutoUpdate()
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/test.md").Return([]byte(importProcedure), nil)
+		mockRepo.On("FindByID", ctx, doc.ID()).Return(doc, nil)
+		mockRepo.On("Update", ctx, doc).Return(nil)
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.NoError(t, err)
+		require.Len(t, doc.Versions(), 2)
+		assert.Equal(t, 2, doc.CurrentVersion().VersionNumber().Int())
+		assert.Equal(t, "def4567890123", doc.CurrentVersion().Source().CommitHash().String())
+		assert.Equal(t, "Database Backup Procedure", doc.CurrentVersion().Title())
+		require.Len(t, doc.CurrentVersion().Variables(), 1)
+		assert.False(t, doc.IsUpdateAvailable())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("Frontmatterのないファイルは現在のメタデータのまま本文が更新される", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t)
+		doc.EnableAutoUpdate()
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/test.md").Return([]byte("# Updated Content\n"), nil)
+		mockRepo.On("FindByID", ctx, doc.ID()).Return(doc, nil)
+		mockRepo.On("Update", ctx, doc).Return(nil)
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.NoError(t, err)
+		require.Len(t, doc.Versions(), 2)
+		assert.Equal(t, "Test Document", doc.CurrentVersion().Title())
+		assert.Equal(t, "procedure", doc.CurrentVersion().Type().String())
+		assert.Equal(t, "# Updated Content\n", doc.CurrentVersion().Content())
+	})
+
+	t.Run("自動更新が無効なドキュメントは更新ありとしてマークされる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t)
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockRepo.On("Update", ctx, doc).Return(nil)
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.NoError(t, err)
+		assert.Len(t, doc.Versions(), 1)
+		assert.True(t, doc.IsUpdateAvailable())
+		assert.Equal(t, "def4567890123", doc.PendingCommitHash().String())
+		mockFiles.AssertNotCalled(t, "ReadManagedFile", mock.Anything, mock.Anything, mock.Anything)
+
+		// テスト：同じコミットで再度同期されても再保存されない
+		err = uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.NoError(t, err)
+		mockRepo.AssertNumberOfCalls(t, "Update", 1)
+	})
+
+	t.Run("Frontmatterの検証に失敗した場合は公開せず更新ありとしてマークされる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t)
+		doc.EnableAutoUpdate()
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/test.md").Return([]byte("---\ntype: runbook\n---\nBody\n"), nil)
+		mockRepo.On("Update", ctx, doc).Return(nil)
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.NoError(t, err)
+		assert.Len(t, doc.Versions(), 1)
+		assert.True(t, doc.IsUpdateAvailable())
+		mockRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("変更されていないファイルやコミットが同じファイルは対象外になる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t)
+		doc.EnableAutoUpdate()
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/other.md"})
+		require.NoError(t, err)
+		mockCommits.AssertNotCalled(t, "ResolveFileCommit", mock.Anything, mock.Anything, mock.Anything)
+
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("abc1234567890", nil)
+
+		err = uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+		require.NoError(t, err)
+		assert.Len(t, doc.Versions(), 1)
+		mockFiles.AssertNotCalled(t, "ReadManagedFile", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("保存に失敗したドキュメントはエラーとして返される", func(t *testing.T) {
+		uc, mockRepo, _, mockCommits := newAutoUpdateUseCaseForTest()
+		doc := createTestDocument(t)
+
+		mockRepo.On("FindByRepositoryID", ctx, doc.RepositoryID()).Return([]entity.Document{doc}, nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockRepo.On("Update", ctx, doc).Return(errors.New("database error"))
+
+		err := uc.RepositorySynced(ctx, importRepositoryID, []string{"docs/test.md"})
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), doc.ID().String())
+	})
+}
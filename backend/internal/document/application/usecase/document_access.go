@@ -0,0 +1,114 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+	uservo "opscore/backend/internal/user/domain/value_object"
+)
+
+// overridePermissions are the permissions whose holders may access every document regardless of
+// its access scope and ACL entries.
+var overridePermissions = []string{uservo.PermissionUserAdmin, uservo.PermissionDocumentPublish}
+
+// PermissionLister lists the permissions a user holds through their roles.
+type PermissionLister interface {
+	// EffectivePermissions returns the permissions held by the user.
+	EffectivePermissions(ctx context.Context, userID string) ([]string, error)
+}
+
+// documentAccess enforces document ACLs on behalf of the document and variable use cases.
+type documentAccess struct {
+	grantRepo   repository.AccessGrantRepository
+	permissions PermissionLister
+}
+
+// accessorFor returns the domain accessor of the caller, which overrides access scopes and ACLs when
+// the caller holds one of the overridePermissions.
+func (a documentAccess) accessorFor(ctx context.Context, caller dto.Caller) (value_object.Accessor, error) {
+	if caller.UserID == "" {
+		return dto.ToAccessor(caller, false), nil
+	}
+	held, err := a.permissions.EffectivePermissions(ctx, caller.UserID)
+	if err != nil {
+		return value_object.Accessor{}, fmt.Errorf("failed to find permissions: %w", err)
+	}
+	for _, permission := range held {
+		for _, override := range overridePermissions {
+			if permission == override {
+				return dto.ToAccessor(caller, true), nil
+			}
+		}
+	}
+	return dto.ToAccessor(caller, false), nil
+}
+
+// levelFor returns the highest access level the caller holds on the document.
+// ACL entries are only looked up when the scope, ownership or override permissions do not already decide.
+func (a documentAccess) levelFor(ctx context.Context, doc entity.Document, caller dto.Caller) (value_object.AccessLevel, bool, error) {
+	accessor, err := a.accessorFor(ctx, caller)
+	if err != nil {
+		return "", false, err
+	}
+	if level, ok := doc.AccessLevelFor(accessor, nil); ok {
+		return level, true, nil
+	}
+
+	grants, err := a.grantRepo.FindByDocumentID(ctx, doc.ID())
+	if err != nil {
+		return "", false, fmt.Errorf("failed to find access grants: %w", err)
+	}
+
+	level, ok := doc.AccessLevelFor(accessor, grants)
+	return level, ok, nil
+}
+
+// require returns an error unless the caller holds the required level on the document.
+// Callers who cannot view the document get a NotFoundError so that its existence is not revealed.
+func (a documentAccess) require(ctx context.Context, doc entity.Document, caller dto.Caller, required value_object.AccessLevel) error {
+	level, ok, err := a.levelFor(ctx, doc, caller)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return apperror.NewNotFoundError("Document", doc.ID().String(), nil)
+	}
+	if !level.Includes(required) {
+		return apperror.NewForbiddenError("Document "+doc.ID().String(), required.String(), caller.UserID)
+	}
+	return nil
+}
+
+// filterViewable returns the documents the caller may view, preserving their order.
+func (a documentAccess) filterViewable(ctx context.Context, docs []entity.Document, caller dto.Caller) ([]entity.Document, error) {
+	accessor, err := a.accessorFor(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []entity.AccessGrant
+	for _, doc := range docs {
+		if _, ok := doc.AccessLevelFor(accessor, nil); ok {
+			continue
+		}
+		// At least one document depends on ACL entries; load the caller's entries once
+		grants, err = a.grantRepo.FindByPrincipals(ctx, caller.UserID, caller.GroupIDs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to find access grants: %w", err)
+		}
+		break
+	}
+
+	viewable := make([]entity.Document, 0, len(docs))
+	for _, doc := range docs {
+		if _, ok := doc.AccessLevelFor(accessor, grants); ok {
+			viewable = append(viewable, doc)
+		}
+	}
+	return viewable, nil
+}
@@ -19,20 +19,20 @@ type DocumentUseCase interface {
 	// UpdateDocument updates an existing document by creating a new version.
 	UpdateDocument(ctx context.Context, documentID string, req *dto.UpdateDocumentRequest) (*dto.DocumentResponse, error)
 
-	// GetDocument retrieves a document by its ID.
-	GetDocument(ctx context.Context, documentID string) (*dto.DocumentResponse, error)
+	// GetDocument retrieves a document by its ID if the caller may view it.
+	GetDocument(ctx context.Context, caller dto.Caller, documentID string) (*dto.DocumentResponse, error)
 
-	// GetDocumentVersion retrieves a specific version of a document.
-	GetDocumentVersion(ctx context.Context, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error)
+	// GetDocumentVersion retrieves a specific version of a document if the caller may view it.
+	GetDocumentVersion(ctx context.Context, caller dto.Caller, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error)
 
-	// ListDocuments retrieves all documents.
-	ListDocuments(ctx context.Context) ([]dto.DocumentListItemResponse, error)
+	// ListDocuments retrieves all documents the caller may view.
+	ListDocuments(ctx context.Context, caller dto.Caller) ([]dto.DocumentListItemResponse, error)
 
-	// ListDocumentsByRepository retrieves all documents for a given repository.
-	ListDocumentsByRepository(ctx context.Context, repositoryID string) ([]dto.DocumentListItemResponse, error)
+	// ListDocumentsByRepository retrieves all documents for a given repository that the caller may view.
+	ListDocumentsByRepository(ctx context.Context, caller dto.Caller, repositoryID string) ([]dto.DocumentListItemResponse, error)
 
-	// GetDocumentVersions retrieves all versions for a document.
-	GetDocumentVersions(ctx context.Context, documentID string) (*dto.VersionHistoryResponse, error)
+	// GetDocumentVersions retrieves all versions for a document if the caller may view it.
+	GetDocumentVersions(ctx context.Context, caller dto.Caller, documentID string) (*dto.VersionHistoryResponse, error)
 
 	// PublishDocumentVersion publishes a specific version.
 	PublishDocumentVersion(ctx context.Context, documentID string, versionNumber int) (*dto.DocumentResponse, error)
@@ -42,18 +42,54 @@ type DocumentUseCase interface {
 
 	// UpdateDocumentMetadata updates the document metadata (owner, access scope, etc.).
 	UpdateDocumentMetadata(ctx context.Context, documentID string, req *dto.UpdateDocumentMetadataRequest) (*dto.DocumentResponse, error)
+
+	// DocumentAccessLevel returns the highest access level the caller holds on a document,
+	// or false when the document does not exist or the caller may not view it.
+	DocumentAccessLevel(ctx context.Context, caller dto.Caller, documentID string) (value_object.AccessLevel, bool, error)
+}
+
+// SourceCommitResolver resolves the commit a document's source file was read at.
+type SourceCommitResolver interface {
+	// ResolveFileCommit returns the last commit of the repository's local copy that touched the file.
+	ResolveFileCommit(ctx context.Context, repositoryID string, filePath string) (string, error)
 }
 
 // documentUseCase implements the DocumentUseCase interface.
 type documentUseCase struct {
-	repo repository.DocumentRepository
+	repo    repository.DocumentRepository
+	access  documentAccess
+	commits SourceCommitResolver
 }
 
 // NewDocumentUseCase creates a new instance of documentUseCase.
-func NewDocumentUseCase(repo repository.DocumentRepository) DocumentUseCase {
+func NewDocumentUseCase(repo repository.DocumentRepository, grantRepo repository.AccessGrantRepository, commits SourceCommitResolver, permissions PermissionLister) DocumentUseCase {
 	return &documentUseCase{
-		repo: repo,
+		repo:    repo,
+		access:  documentAccess{grantRepo: grantRepo, permissions: permissions},
+		commits: commits,
+	}
+}
+
+// resolveCommitHash returns the requested commit hash, or the last commit that touched the file
+// when the request leaves it empty.
+func (uc *documentUseCase) resolveCommitHash(ctx context.Context, repositoryID value_object.RepositoryID, filePath value_object.FilePath, requested string) (value_object.CommitHash, error) {
+	if requested == "" {
+		resolved, err := uc.commits.ResolveFileCommit(ctx, repositoryID.String(), filePath.String())
+		if err != nil {
+			return "", apperror.NewValidationFailedError([]apperror.FieldError{
+				{Field: "commit_hash", Message: fmt.Sprintf("commit hash was not given and could not be resolved from the repository: %v", err)},
+			})
+		}
+		requested = resolved
+	}
+
+	commitHash, err := value_object.NewCommitHash(requested)
+	if err != nil {
+		return "", apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "commit_hash", Message: err.Error()},
+		})
 	}
+	return commitHash, nil
 }
 
 // CreateDocument creates a new document with an initial version.
@@ -82,7 +118,7 @@ func (uc *documentUseCase) CreateDocument(ctx context.Context, req *dto.CreateDo
 		})
 	}
 
-	// Create file path and commit hash
+	// Create file path and resolve the commit hash
 	filePath, err := value_object.NewFilePath(req.FilePath)
 	if err != nil {
 		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
@@ -90,11 +126,9 @@ func (uc *documentUseCase) CreateDocument(ctx context.Context, req *dto.CreateDo
 		})
 	}
 
-	commitHash, err := value_object.NewCommitHash(req.CommitHash)
+	commitHash, err := uc.resolveCommitHash(ctx, repositoryID, filePath, req.CommitHash)
 	if err != nil {
-		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
-			{Field: "commit_hash", Message: err.Error()},
-		})
+		return nil, err
 	}
 
 	// Create document source
@@ -200,7 +234,7 @@ func (uc *documentUseCase) UpdateDocument(ctx context.Context, documentID string
 		})
 	}
 
-	// Create file path and commit hash
+	// Create file path and resolve the commit hash
 	filePath, err := value_object.NewFilePath(req.FilePath)
 	if err != nil {
 		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
@@ -208,11 +242,9 @@ func (uc *documentUseCase) UpdateDocument(ctx context.Context, documentID string
 		})
 	}
 
-	commitHash, err := value_object.NewCommitHash(req.CommitHash)
+	commitHash, err := uc.resolveCommitHash(ctx, doc.RepositoryID(), filePath, req.CommitHash)
 	if err != nil {
-		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
-			{Field: "commit_hash", Message: err.Error()},
-		})
+		return nil, err
 	}
 
 	// Create document source
@@ -270,8 +302,8 @@ func (uc *documentUseCase) UpdateDocument(ctx context.Context, documentID string
 	return &response, nil
 }
 
-// GetDocument retrieves a document by its ID.
-func (uc *documentUseCase) GetDocument(ctx context.Context, documentID string) (*dto.DocumentResponse, error) {
+// GetDocument retrieves a document by its ID if the caller may view it.
+func (uc *documentUseCase) GetDocument(ctx context.Context, caller dto.Caller, documentID string) (*dto.DocumentResponse, error) {
 	// Validate document ID
 	docID, err := value_object.NewDocumentID(documentID)
 	if err != nil {
@@ -289,13 +321,38 @@ func (uc *documentUseCase) GetDocument(ctx context.Context, documentID string) (
 		return nil, apperror.NewNotFoundError("Document", documentID, nil)
 	}
 
+	// Check that the caller may view the document
+	if err := uc.access.require(ctx, doc, caller, value_object.AccessLevelView); err != nil {
+		return nil, err
+	}
+
 	// Return the response
 	response := dto.ToDocumentResponse(doc)
 	return &response, nil
 }
 
-// GetDocumentVersion retrieves a specific version of a document.
-func (uc *documentUseCase) GetDocumentVersion(ctx context.Context, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error) {
+// DocumentAccessLevel returns the highest access level the caller holds on a document,
+// or false when the document does not exist or the caller may not view it.
+func (uc *documentUseCase) DocumentAccessLevel(ctx context.Context, caller dto.Caller, documentID string) (value_object.AccessLevel, bool, error) {
+	docID, err := value_object.NewDocumentID(documentID)
+	if err != nil {
+		return "", false, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "document_id", Message: err.Error()},
+		})
+	}
+
+	doc, err := uc.repo.FindByID(ctx, docID)
+	if err != nil {
+		return "", false, fmt.Errorf("failed to find document: %w", err)
+	}
+	if doc == nil {
+		return "", false, nil
+	}
+	return uc.access.levelFor(ctx, doc, caller)
+}
+
+// GetDocumentVersion retrieves a specific version of a document if the caller may view it.
+func (uc *documentUseCase) GetDocumentVersion(ctx context.Context, caller dto.Caller, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error) {
 	// Validate document ID
 	docID, err := value_object.NewDocumentID(documentID)
 	if err != nil {
@@ -312,6 +369,18 @@ func (uc *documentUseCase) GetDocumentVersion(ctx context.Context, documentID st
 		})
 	}
 
+	// Find the document and check that the caller may view it
+	doc, err := uc.repo.FindByID(ctx, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find document: %w", err)
+	}
+	if doc == nil {
+		return nil, apperror.NewNotFoundError("Document", documentID, nil)
+	}
+	if err := uc.access.require(ctx, doc, caller, value_object.AccessLevelView); err != nil {
+		return nil, err
+	}
+
 	// Find the version
 	version, err := uc.repo.FindVersionByNumber(ctx, docID, verNum)
 	if err != nil {
@@ -326,20 +395,26 @@ func (uc *documentUseCase) GetDocumentVersion(ctx context.Context, documentID st
 	return &response, nil
 }
 
-// ListDocuments retrieves all documents.
-func (uc *documentUseCase) ListDocuments(ctx context.Context) ([]dto.DocumentListItemResponse, error) {
+// ListDocuments retrieves all documents the caller may view.
+func (uc *documentUseCase) ListDocuments(ctx context.Context, caller dto.Caller) ([]dto.DocumentListItemResponse, error) {
 	// Find all published documents
 	docs, err := uc.repo.FindPublished(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("failed to find documents: %w", err)
 	}
 
+	// Keep only the documents the caller may view
+	docs, err = uc.access.filterViewable(ctx, docs, caller)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return the response
 	return dto.ToDocumentListResponse(docs), nil
 }
 
-// ListDocumentsByRepository retrieves all documents for a given repository.
-func (uc *documentUseCase) ListDocumentsByRepository(ctx context.Context, repositoryID string) ([]dto.DocumentListItemResponse, error) {
+// ListDocumentsByRepository retrieves all documents for a given repository that the caller may view.
+func (uc *documentUseCase) ListDocumentsByRepository(ctx context.Context, caller dto.Caller, repositoryID string) ([]dto.DocumentListItemResponse, error) {
 	// Validate repository ID
 	repoID, err := value_object.NewRepositoryID(repositoryID)
 	if err != nil {
@@ -354,12 +429,18 @@ func (uc *documentUseCase) ListDocumentsByRepository(ctx context.Context, reposi
 		return nil, fmt.Errorf("failed to find documents: %w", err)
 	}
 
+	// Keep only the documents the caller may view
+	docs, err = uc.access.filterViewable(ctx, docs, caller)
+	if err != nil {
+		return nil, err
+	}
+
 	// Return the response
 	return dto.ToDocumentListResponse(docs), nil
 }
 
-// GetDocumentVersions retrieves all versions for a document.
-func (uc *documentUseCase) GetDocumentVersions(ctx context.Context, documentID string) (*dto.VersionHistoryResponse, error) {
+// GetDocumentVersions retrieves all versions for a document if the caller may view it.
+func (uc *documentUseCase) GetDocumentVersions(ctx context.Context, caller dto.Caller, documentID string) (*dto.VersionHistoryResponse, error) {
 	// Validate document ID
 	docID, err := value_object.NewDocumentID(documentID)
 	if err != nil {
@@ -376,6 +457,9 @@ func (uc *documentUseCase) GetDocumentVersions(ctx context.Context, documentID s
 	if doc == nil {
 		return nil, apperror.NewNotFoundError("Document", documentID, nil)
 	}
+	if err := uc.access.require(ctx, doc, caller, value_object.AccessLevelView); err != nil {
+		return nil, err
+	}
 
 	// Find all versions
 	versions, err := uc.repo.FindVersionsByDocumentID(ctx, docID)
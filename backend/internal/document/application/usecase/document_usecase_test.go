@@ -10,11 +10,20 @@ import (
 	"opscore/backend/internal/document/domain/entity"
 	"opscore/backend/internal/document/domain/repository"
 	"opscore/backend/internal/document/domain/value_object"
+	uservo "opscore/backend/internal/user/domain/value_object"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
+// permissionsOf returns a PermissionLister under which every user holds the given permissions
+func permissionsOf(permissions ...string) *MockPermissionLister {
+	m := new(MockPermissionLister)
+	m.On("EffectivePermissions", mock.Anything, mock.Anything).Return(permissions, nil).Maybe()
+	return m
+}
+
 // Helper function to create a test document
 func createTestDocument(t *testing.T) entity.Document {
 	docID := value_object.GenerateDocumentID()
@@ -36,6 +45,19 @@ func createTestDocument(t *testing.T) entity.Document {
 	return doc
 }
 
+// Helper function to create a private test document visible to a group
+func createPrivateTestDocument(t *testing.T, groupID string) (entity.Document, []entity.AccessGrant) {
+	doc := createTestDocument(t)
+	privateScope, _ := value_object.NewAccessScope("private")
+	err := doc.UpdateAccessScope(privateScope)
+	assert.NoError(t, err)
+
+	grant, err := entity.NewAccessGrant(doc.ID(), value_object.PrincipalTypeGroup, groupID, value_object.AccessLevelView, "test-owner")
+	assert.NoError(t, err)
+
+	return doc, []entity.AccessGrant{grant}
+}
+
 func TestDocumentUseCase_CreateDocument(t *testing.T) {
 	t.Run("正常にドキュメントを作成できる", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
@@ -66,7 +88,7 @@ func TestDocumentUseCase_CreateDocument(t *testing.T) {
 		// Mock Save to succeed
 		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.document")).Return(nil)
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.CreateDocument(context.Background(), req)
 
 		assert.NoError(t, err)
@@ -82,6 +104,56 @@ func TestDocumentUseCase_CreateDocument(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("コミットハッシュを省略するとサーバー側で解決される", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockCommits := new(MockSourceCommitResolver)
+
+		req := &dto.CreateDocumentRequest{
+			RepositoryID: "a1b2c3d4-e5f6-7890-1234-567890abcdef",
+			FilePath:     "docs/test.md",
+			Title:        "Test Document",
+			DocType:      "procedure",
+			Owner:        "test-owner",
+			Content:      "# Test",
+			AccessScope:  "public",
+		}
+
+		mockRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.document")).Return(nil)
+		mockCommits.On("ResolveFileCommit", mock.Anything, req.RepositoryID, req.FilePath).Return("abc1234567890abcdef", nil)
+
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), mockCommits, permissionsOf())
+		result, err := uc.CreateDocument(context.Background(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.CurrentVersion)
+		assert.Equal(t, "abc1234567890abcdef", result.CurrentVersion.CommitHash)
+		mockCommits.AssertExpectations(t)
+	})
+
+	t.Run("コミットハッシュを解決できない場合はバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockCommits := new(MockSourceCommitResolver)
+
+		req := &dto.CreateDocumentRequest{
+			RepositoryID: "a1b2c3d4-e5f6-7890-1234-567890abcdef",
+			FilePath:     "docs/missing.md",
+			Title:        "Test Document",
+			DocType:      "procedure",
+			Owner:        "test-owner",
+			Content:      "# Test",
+			AccessScope:  "public",
+		}
+
+		mockCommits.On("ResolveFileCommit", mock.Anything, req.RepositoryID, req.FilePath).Return("", errors.New("file does not exist in repository history"))
+
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), mockCommits, permissionsOf())
+		result, err := uc.CreateDocument(context.Background(), req)
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, apperror.ErrBadRequest))
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
 	t.Run("無効なリポジトリIDでエラーになる", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
@@ -96,7 +168,7 @@ func TestDocumentUseCase_CreateDocument(t *testing.T) {
 			AccessScope:  "public",
 		}
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.CreateDocument(context.Background(), req)
 
 		assert.Error(t, err)
@@ -118,7 +190,7 @@ func TestDocumentUseCase_CreateDocument(t *testing.T) {
 			AccessScope:  "public",
 		}
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.CreateDocument(context.Background(), req)
 
 		assert.Error(t, err)
@@ -135,8 +207,8 @@ func TestDocumentUseCase_GetDocument(t *testing.T) {
 		docID := testDoc.ID()
 		mockRepo.On("FindByID", mock.Anything, docID).Return(testDoc, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.GetDocument(context.Background(), docID.String())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocument(context.Background(), dto.Caller{}, docID.String())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -151,8 +223,8 @@ func TestDocumentUseCase_GetDocument(t *testing.T) {
 		docID, _ := value_object.NewDocumentID("a1b2c3d4-e5f6-7890-1234-567890abcdef")
 		mockRepo.On("FindByID", mock.Anything, docID).Return(nil, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.GetDocument(context.Background(), docID.String())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocument(context.Background(), dto.Caller{}, docID.String())
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -164,13 +236,134 @@ func TestDocumentUseCase_GetDocument(t *testing.T) {
 	t.Run("無効なドキュメントIDでエラーになる", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.GetDocument(context.Background(), "invalid-uuid")
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocument(context.Background(), dto.Caller{}, "invalid-uuid")
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
 		assert.True(t, errors.Is(err, apperror.ErrBadRequest))
 	})
+
+	t.Run("アクセス権を付与されたグループのメンバーは非公開ドキュメントを取得できる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, testDoc.ID()).Return(grants, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocument(context.Background(), dto.Caller{UserID: "user-1", GroupIDs: []string{"sre"}}, testDoc.ID().String())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+
+		mockRepo.AssertExpectations(t)
+		mockGrantRepo.AssertExpectations(t)
+	})
+
+	t.Run("アクセス権のないユーザーには非公開ドキュメントが存在しないものとして扱われる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, testDoc.ID()).Return(grants, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocument(context.Background(), dto.Caller{UserID: "user-2", GroupIDs: []string{"dev"}}, testDoc.ID().String())
+
+		assert.Nil(t, result)
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+	})
+
+	t.Run("所有者と管理者はACLを参照せずに非公開ドキュメントを取得できる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+
+		permissions := new(MockPermissionLister)
+		permissions.On("EffectivePermissions", mock.Anything, "test-owner").Return([]string{}, nil)
+		permissions.On("EffectivePermissions", mock.Anything, "admin-1").Return([]string{uservo.PermissionUserAdmin}, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissions)
+		_, err := uc.GetDocument(context.Background(), dto.Caller{UserID: "test-owner"}, testDoc.ID().String())
+		assert.NoError(t, err)
+		_, err = uc.GetDocument(context.Background(), dto.Caller{UserID: "admin-1"}, testDoc.ID().String())
+		assert.NoError(t, err)
+
+		mockGrantRepo.AssertNotCalled(t, "FindByDocumentID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("ロールでdocument:publishを付与されたユーザーはACLを参照せずに非公開ドキュメントを取得できる", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf(uservo.PermissionDocumentPublish))
+		_, err := uc.GetDocument(context.Background(), dto.Caller{UserID: "publisher-1"}, testDoc.ID().String())
+
+		assert.NoError(t, err)
+		mockGrantRepo.AssertNotCalled(t, "FindByDocumentID", mock.Anything, mock.Anything)
+	})
+
+	t.Run("権限の取得に失敗した場合はエラーを返す", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, _ := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+		permissions := new(MockPermissionLister)
+		permissions.On("EffectivePermissions", mock.Anything, "user-1").Return(nil, errors.New("db error"))
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissions)
+		result, err := uc.GetDocument(context.Background(), dto.Caller{UserID: "user-1"}, testDoc.ID().String())
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
+
+func TestDocumentUseCase_DocumentAccessLevel(t *testing.T) {
+	t.Run("付与されたアクセスレベルを返す", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, testDoc.ID()).Return(grants, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf())
+		level, ok, err := uc.DocumentAccessLevel(context.Background(), dto.Caller{UserID: "user-1", GroupIDs: []string{"sre"}}, testDoc.ID().String())
+
+		require.NoError(t, err)
+		assert.True(t, ok)
+		assert.Equal(t, value_object.AccessLevelView, level)
+	})
+
+	t.Run("閲覧できないドキュメントと存在しないドキュメントは区別されない", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		testDoc, grants := createPrivateTestDocument(t, "sre")
+		missingID := value_object.GenerateDocumentID()
+
+		mockRepo.On("FindByID", mock.Anything, testDoc.ID()).Return(testDoc, nil)
+		mockRepo.On("FindByID", mock.Anything, missingID).Return(nil, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, testDoc.ID()).Return(grants, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf())
+		caller := dto.Caller{UserID: "user-2", GroupIDs: []string{"dev"}}
+		_, ok, err := uc.DocumentAccessLevel(context.Background(), caller, testDoc.ID().String())
+		require.NoError(t, err)
+		assert.False(t, ok)
+		_, ok, err = uc.DocumentAccessLevel(context.Background(), caller, missingID.String())
+		require.NoError(t, err)
+		assert.False(t, ok)
+	})
 }
 
 func TestDocumentUseCase_ListDocuments(t *testing.T) {
@@ -181,8 +374,8 @@ func TestDocumentUseCase_ListDocuments(t *testing.T) {
 
 		mockRepo.On("FindPublished", mock.Anything, mock.Anything).Return(docs, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.ListDocuments(context.Background())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.ListDocuments(context.Background(), dto.Caller{})
 
 		assert.NoError(t, err)
 		assert.Len(t, result, 1)
@@ -190,14 +383,36 @@ func TestDocumentUseCase_ListDocuments(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 	})
 
+	t.Run("閲覧できない非公開ドキュメントは一覧から除外される", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		publicDoc := createTestDocument(t)
+		sreDoc, grants := createPrivateTestDocument(t, "sre")
+		otherDoc, _ := createPrivateTestDocument(t, "dba")
+
+		mockRepo.On("FindPublished", mock.Anything, mock.Anything).Return([]entity.Document{publicDoc, sreDoc, otherDoc}, nil)
+		mockGrantRepo.On("FindByPrincipals", mock.Anything, "user-1", []string{"sre"}).Return(grants, nil)
+
+		uc := NewDocumentUseCase(mockRepo, mockGrantRepo, new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.ListDocuments(context.Background(), dto.Caller{UserID: "user-1", GroupIDs: []string{"sre"}})
+
+		assert.NoError(t, err)
+		assert.Len(t, result, 2)
+		assert.Equal(t, publicDoc.ID().String(), result[0].ID)
+		assert.Equal(t, sreDoc.ID().String(), result[1].ID)
+
+		mockRepo.AssertExpectations(t)
+		mockGrantRepo.AssertExpectations(t)
+	})
+
 	t.Run("ドキュメントが存在しない場合は空のリストを返す", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 		var emptyDocs []entity.Document
 
 		mockRepo.On("FindPublished", mock.Anything, mock.Anything).Return(emptyDocs, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.ListDocuments(context.Background())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.ListDocuments(context.Background(), dto.Caller{})
 
 		assert.NoError(t, err)
 		assert.Empty(t, result)
@@ -224,7 +439,7 @@ func TestDocumentUseCase_UpdateDocument(t *testing.T) {
 			Content:    "# Updated Content",
 		}
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.UpdateDocument(context.Background(), docID.String(), req)
 
 		assert.NoError(t, err)
@@ -249,7 +464,7 @@ func TestDocumentUseCase_UpdateDocument(t *testing.T) {
 			Content:    "# Test",
 		}
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.UpdateDocument(context.Background(), docID.String(), req)
 
 		assert.Error(t, err)
@@ -258,6 +473,32 @@ func TestDocumentUseCase_UpdateDocument(t *testing.T) {
 
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("コミットハッシュを省略するとファイルの最終コミットが記録される", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockCommits := new(MockSourceCommitResolver)
+		testDoc := createTestDocument(t)
+
+		docID := testDoc.ID()
+		mockRepo.On("FindByID", mock.Anything, docID).Return(testDoc, nil)
+		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.document")).Return(nil)
+		mockCommits.On("ResolveFileCommit", mock.Anything, "a1b2c3d4-e5f6-7890-1234-567890abcdef", "docs/updated.md").Return("0123456789abcdef0123456789abcdef01234567", nil)
+
+		req := &dto.UpdateDocumentRequest{
+			FilePath: "docs/updated.md",
+			Title:    "Updated Document",
+			DocType:  "procedure",
+			Content:  "# Updated Content",
+		}
+
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), mockCommits, permissionsOf())
+		result, err := uc.UpdateDocument(context.Background(), docID.String(), req)
+
+		require.NoError(t, err)
+		require.NotNil(t, result.CurrentVersion)
+		assert.Equal(t, "0123456789abcdef0123456789abcdef01234567", result.CurrentVersion.CommitHash)
+		mockCommits.AssertExpectations(t)
+	})
 }
 
 func TestDocumentUseCase_GetDocumentVersions(t *testing.T) {
@@ -271,8 +512,8 @@ func TestDocumentUseCase_GetDocumentVersions(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, docID).Return(testDoc, nil)
 		mockRepo.On("FindVersionsByDocumentID", mock.Anything, docID).Return(versions, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.GetDocumentVersions(context.Background(), docID.String())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocumentVersions(context.Background(), dto.Caller{}, docID.String())
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -288,8 +529,8 @@ func TestDocumentUseCase_GetDocumentVersions(t *testing.T) {
 		docID, _ := value_object.NewDocumentID("a1b2c3d4-e5f6-7890-1234-567890abcdef")
 		mockRepo.On("FindByID", mock.Anything, docID).Return(nil, nil)
 
-		uc := NewDocumentUseCase(mockRepo)
-		result, err := uc.GetDocumentVersions(context.Background(), docID.String())
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
+		result, err := uc.GetDocumentVersions(context.Background(), dto.Caller{}, docID.String())
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -324,7 +565,7 @@ func TestDocumentUseCase_RollbackDocumentVersion(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, docID).Return(doc, nil)
 		mockRepo.On("Update", mock.Anything, mock.AnythingOfType("*entity.document")).Return(nil)
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.RollbackDocumentVersion(context.Background(), docID.String(), 1)
 
 		assert.NoError(t, err)
@@ -351,7 +592,7 @@ func TestDocumentUseCase_UpdateDocumentMetadata(t *testing.T) {
 			IsAutoUpdate: &isAutoUpdate,
 		}
 
-		uc := NewDocumentUseCase(mockRepo)
+		uc := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), new(MockSourceCommitResolver), permissionsOf())
 		result, err := uc.UpdateDocumentMetadata(context.Background(), docID.String(), req)
 
 		assert.NoError(t, err)
@@ -0,0 +1,86 @@
+package usecase
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"opscore/backend/internal/document/application/dto"
+
+	"gopkg.in/yaml.v3"
+)
+
+// frontmatterDelimiter opens and closes the YAML frontmatter block of a Markdown file
+const frontmatterDelimiter = "---"
+
+// errNoFrontmatter is returned when a Markdown file does not start with a frontmatter block
+var errNoFrontmatter = errors.New("file has no frontmatter")
+
+// frontmatter holds the document metadata defined at the top of a Markdown file (ADR 0013)
+type frontmatter struct {
+	Title     string                `yaml:"title"`
+	Type      string                `yaml:"type"`
+	Tags      []string              `yaml:"tags"`
+	Variables []frontmatterVariable `yaml:"variables"`
+}
+
+// frontmatterVariable is a single entry of the frontmatter variables array
+type frontmatterVariable struct {
+	Name         string      `yaml:"name"`
+	Label        string      `yaml:"label"`
+	Description  string      `yaml:"description"`
+	Type         string      `yaml:"type"`
+	Required     bool        `yaml:"required"`
+	DefaultValue interface{} `yaml:"defaultValue"`
+}
+
+// parseFrontmatter splits a Markdown file into its frontmatter and the body that follows it.
+// Keys the document model does not use (owner, version, category, ...) are ignored.
+func parseFrontmatter(content []byte) (frontmatter, string, error) {
+	text := strings.TrimPrefix(string(content), "\ufeff")
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+
+	lines := strings.SplitAfter(text, "\n")
+	if len(lines) == 0 || strings.TrimRight(lines[0], " \t\n") != frontmatterDelimiter {
+		return frontmatter{}, "", errNoFrontmatter
+	}
+
+	// Find the line closing the block
+	end := -1
+	for i := 1; i < len(lines); i++ {
+		if strings.TrimRight(lines[i], " \t\n") == frontmatterDelimiter {
+			end = i
+			break
+		}
+	}
+	if end == -1 {
+		return frontmatter{}, "", errors.New("frontmatter is not closed with ---")
+	}
+
+	var fm frontmatter
+	if err := yaml.Unmarshal([]byte(strings.Join(lines[1:end], "")), &fm); err != nil {
+		return frontmatter{}, "", fmt.Errorf("invalid frontmatter: %w", err)
+	}
+
+	body := strings.TrimLeft(strings.Join(lines[end+1:], ""), "\n")
+	return fm, body, nil
+}
+
+// variableDTOs converts the frontmatter variables into use case DTOs
+func (fm frontmatter) variableDTOs() []dto.VariableDefinitionDTO {
+	if len(fm.Variables) == 0 {
+		return nil
+	}
+	variables := make([]dto.VariableDefinitionDTO, len(fm.Variables))
+	for i, v := range fm.Variables {
+		variables[i] = dto.VariableDefinitionDTO{
+			Name:         v.Name,
+			Label:        v.Label,
+			Description:  v.Description,
+			Type:         v.Type,
+			Required:     v.Required,
+			DefaultValue: v.DefaultValue,
+		}
+	}
+	return variables
+}
@@ -0,0 +1,95 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseFrontmatter(t *testing.T) {
+	t.Run("ADR 0013の形式のFrontmatterを解析できる", func(t *testing.T) {
+		content := `---
+title: "Database Backup Procedure"
+owner: "Database Team"
+version: "1.0"
+type: "procedure"
+tags:
+  - database
+  - backup
+variables:
+  - name: server_name
+    label: "サーバー名"
+    description: "バックアップ対象のサーバー名を入力してください"
+    type: string
+    required: true
+    defaultValue: "prod-db-01"
+  - name: retention_days
+    label: "保持期間（日数）"
+    type: number
+    required: false
+    defaultValue: 30
+  - name: enable_compression
+    label: "圧縮を有効化"
+    type: boolean
+    defaultValue: true
+---
+
+# Database Backup Procedure
+
+1. Connect to {{server_name}}
+`
+
+		fm, body, err := parseFrontmatter([]byte(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Database Backup Procedure", fm.Title)
+		assert.Equal(t, "procedure", fm.Type)
+		assert.Equal(t, []string{"database", "backup"}, fm.Tags)
+		require.Len(t, fm.Variables, 3)
+		assert.Equal(t, "server_name", fm.Variables[0].Name)
+		assert.Equal(t, "サーバー名", fm.Variables[0].Label)
+		assert.True(t, fm.Variables[0].Required)
+		assert.Equal(t, "prod-db-01", fm.Variables[0].DefaultValue)
+		assert.Equal(t, 30, fm.Variables[1].DefaultValue)
+		assert.Equal(t, true, fm.Variables[2].DefaultValue)
+		assert.Equal(t, "# Database Backup Procedure\n\n1. Connect to {{server_name}}\n", body)
+
+		variables := fm.variableDTOs()
+		require.Len(t, variables, 3)
+		assert.Equal(t, "number", variables[1].Type)
+	})
+
+	t.Run("CRLFの改行とBOMを扱える", func(t *testing.T) {
+		content := "\ufeff---\r\ntitle: Runbook\r\ntype: knowledge\r\ntags: [ops]\r\n---\r\nBody\r\n"
+
+		fm, body, err := parseFrontmatter([]byte(content))
+
+		require.NoError(t, err)
+		assert.Equal(t, "Runbook", fm.Title)
+		assert.Equal(t, "knowledge", fm.Type)
+		assert.Equal(t, []string{"ops"}, fm.Tags)
+		assert.Nil(t, fm.variableDTOs())
+		assert.Equal(t, "Body\n", body)
+	})
+
+	t.Run("Frontmatterがないファイルはエラーになる", func(t *testing.T) {
+		_, _, err := parseFrontmatter([]byte("# Title\n\n---\n"))
+
+		assert.ErrorIs(t, err, errNoFrontmatter)
+	})
+
+	t.Run("閉じられていないFrontmatterはエラーになる", func(t *testing.T) {
+		_, _, err := parseFrontmatter([]byte("---\ntitle: Runbook\n"))
+
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, errNoFrontmatter)
+	})
+
+	t.Run("不正なYAMLはエラーになる", func(t *testing.T) {
+		_, _, err := parseFrontmatter([]byte("---\ntitle: [unclosed\n---\nBody\n"))
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid frontmatter")
+	})
+}
@@ -0,0 +1,250 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+// ImportUseCase defines the interface for importing documents from repository files.
+type ImportUseCase interface {
+	// ImportFromRepository creates or updates a document for every managed Markdown file that has frontmatter.
+	ImportFromRepository(ctx context.Context, req *dto.ImportDocumentsRequest) (*dto.ImportResultResponse, error)
+}
+
+// ManagedFileReader reads the files selected for management in a repository.
+type ManagedFileReader interface {
+	// ListManagedFiles returns the paths of the files selected for management in the repository.
+	ListManagedFiles(ctx context.Context, repositoryID string) ([]string, error)
+	// ReadManagedFile returns the content of a managed file from the repository's local copy.
+	ReadManagedFile(ctx context.Context, repositoryID string, filePath string) ([]byte, error)
+}
+
+// importUseCase implements the ImportUseCase interface.
+type importUseCase struct {
+	repo    repository.DocumentRepository
+	docs    DocumentUseCase
+	files   ManagedFileReader
+	commits SourceCommitResolver
+}
+
+// NewImportUseCase creates a new instance of importUseCase.
+func NewImportUseCase(repo repository.DocumentRepository, docs DocumentUseCase, files ManagedFileReader, commits SourceCommitResolver) ImportUseCase {
+	return &importUseCase{
+		repo:    repo,
+		docs:    docs,
+		files:   files,
+		commits: commits,
+	}
+}
+
+// ImportFromRepository creates or updates a document for every managed Markdown file that has frontmatter.
+// A file whose last commit matches the source of its document is left unchanged. Problems with a single
+// file are reported in its result; only failures that affect the whole import are returned as errors.
+func (uc *importUseCase) ImportFromRepository(ctx context.Context, req *dto.ImportDocumentsRequest) (*dto.ImportResultResponse, error) {
+	// Validate repository ID
+	repositoryID, err := value_object.NewRepositoryID(req.RepositoryID)
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "repository_id", Message: err.Error()},
+		})
+	}
+
+	// Default the access scope of created documents
+	accessScope := req.AccessScope
+	if accessScope == "" {
+		accessScope = value_object.AccessScopePublic.String()
+	}
+	if _, err := value_object.NewAccessScope(accessScope); err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "access_scope", Message: err.Error()},
+		})
+	}
+
+	// List the managed files of the repository
+	filePaths, err := uc.files.ListManagedFiles(ctx, repositoryID.String())
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "repository_id", Message: fmt.Sprintf("managed files could not be listed: %v", err)},
+		})
+	}
+
+	// Index the existing documents of the repository by their source file
+	docs, err := uc.repo.FindByRepositoryID(ctx, repositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find documents: %w", err)
+	}
+	existing := make(map[string]entity.Document, len(docs))
+	for _, doc := range docs {
+		if version := latestVersion(doc); version != nil {
+			existing[version.Source().FilePath().String()] = doc
+		}
+	}
+
+	response := &dto.ImportResultResponse{
+		RepositoryID: repositoryID.String(),
+		Files:        make([]dto.ImportFileResult, 0, len(filePaths)),
+	}
+	for _, filePath := range filePaths {
+		result, err := uc.importFile(ctx, repositoryID.String(), filePath, existing[filePath], req.Owner, accessScope)
+		if err != nil {
+			return nil, err
+		}
+		response.Files = append(response.Files, result)
+	}
+
+	return response, nil
+}
+
+// importFile creates or updates the document of a single managed file.
+func (uc *importUseCase) importFile(ctx context.Context, repositoryID, filePath string, doc entity.Document, owner, accessScope string) (dto.ImportFileResult, error) {
+	result := dto.ImportFileResult{FilePath: filePath}
+	if doc != nil {
+		result.DocumentID = doc.ID().String()
+	}
+
+	// Only Markdown files can carry frontmatter
+	ext := strings.ToLower(filepath.Ext(filePath))
+	if ext != ".md" && ext != ".markdown" {
+		return skipped(result, "file_path", "not a Markdown file"), nil
+	}
+
+	// Read and parse the file
+	content, err := uc.files.ReadManagedFile(ctx, repositoryID, filePath)
+	if err != nil {
+		return failed(result, apperror.FieldError{Field: "file_path", Message: fmt.Sprintf("file could not be read: %v", err)}), nil
+	}
+	fm, body, err := parseFrontmatter(content)
+	if errors.Is(err, errNoFrontmatter) {
+		return skipped(result, "frontmatter", err.Error()), nil
+	}
+	if err != nil {
+		return failed(result, apperror.FieldError{Field: "frontmatter", Message: err.Error()}), nil
+	}
+	if fieldErrors := validateFrontmatter(fm, body); len(fieldErrors) > 0 {
+		return failed(result, fieldErrors...), nil
+	}
+
+	// Resolve the commit the file was read at
+	commitHash, err := uc.commits.ResolveFileCommit(ctx, repositoryID, filePath)
+	if err != nil {
+		return failed(result, apperror.FieldError{Field: "commit_hash", Message: fmt.Sprintf("commit could not be resolved: %v", err)}), nil
+	}
+
+	// Create the document, or publish a new version when the file changed since the last import
+	var response *dto.DocumentResponse
+	if doc == nil {
+		response, err = uc.docs.CreateDocument(ctx, &dto.CreateDocumentRequest{
+			RepositoryID: repositoryID,
+			FilePath:     filePath,
+			CommitHash:   commitHash,
+			Title:        fm.Title,
+			DocType:      fm.Type,
+			Owner:        owner,
+			Tags:         fm.Tags,
+			Variables:    fm.variableDTOs(),
+			Content:      body,
+			AccessScope:  accessScope,
+		})
+		result.Status = dto.ImportStatusCreated
+	} else {
+		if latestVersion(doc).Source().CommitHash().String() == commitHash {
+			result.Status = dto.ImportStatusUnchanged
+			return result, nil
+		}
+		response, err = uc.docs.UpdateDocument(ctx, doc.ID().String(), &dto.UpdateDocumentRequest{
+			FilePath:   filePath,
+			CommitHash: commitHash,
+			Title:      fm.Title,
+			DocType:    fm.Type,
+			Tags:       fm.Tags,
+			Variables:  fm.variableDTOs(),
+			Content:    body,
+		})
+		result.Status = dto.ImportStatusUpdated
+	}
+	if err != nil {
+		var validationErr *apperror.ValidationFailedError
+		if errors.As(err, &validationErr) {
+			return failed(result, validationErr.Errors...), nil
+		}
+		return dto.ImportFileResult{}, fmt.Errorf("failed to import %s: %w", filePath, err)
+	}
+
+	result.DocumentID = response.ID
+	return result, nil
+}
+
+// validateFrontmatter collects every problem in the frontmatter of a file, keyed by frontmatter field.
+func validateFrontmatter(fm frontmatter, body string) []apperror.FieldError {
+	var fieldErrors []apperror.FieldError
+	if strings.TrimSpace(fm.Title) == "" {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: "title", Message: "title is required"})
+	}
+	if _, err := value_object.NewDocumentType(fm.Type); err != nil {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: "type", Message: err.Error()})
+	}
+	for i, tag := range fm.Tags {
+		if _, err := value_object.NewTag(tag); err != nil {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: fmt.Sprintf("tags[%d]", i), Message: err.Error()})
+		}
+	}
+	names := make(map[string]bool, len(fm.Variables))
+	for i, v := range fm.Variables {
+		field := fmt.Sprintf("variables[%d]", i)
+		varType, err := value_object.NewVariableType(v.Type)
+		if err != nil {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field + ".type", Message: err.Error()})
+			continue
+		}
+		if _, err := value_object.NewVariableDefinition(v.Name, v.Label, v.Description, varType, v.Required, v.DefaultValue); err != nil {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field, Message: err.Error()})
+			continue
+		}
+		if names[v.Name] {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: field + ".name", Message: fmt.Sprintf("variable %s is defined more than once", v.Name)})
+		}
+		names[v.Name] = true
+	}
+	if strings.TrimSpace(body) == "" {
+		fieldErrors = append(fieldErrors, apperror.FieldError{Field: "content", Message: "document body cannot be empty"})
+	}
+	return fieldErrors
+}
+
+// latestVersion returns the current version of a document, or its newest version when none is current.
+func latestVersion(doc entity.Document) entity.DocumentVersion {
+	if current := doc.CurrentVersion(); current != nil {
+		return current
+	}
+	versions := doc.Versions()
+	if len(versions) == 0 {
+		return nil
+	}
+	return versions[len(versions)-1]
+}
+
+// skipped marks a file that the import does not apply to.
+func skipped(result dto.ImportFileResult, field, message string) dto.ImportFileResult {
+	result.Status = dto.ImportStatusSkipped
+	result.Errors = []dto.ImportFileError{{Field: field, Message: message}}
+	return result
+}
+
+// failed marks a file that could not be imported.
+func failed(result dto.ImportFileResult, fieldErrors ...apperror.FieldError) dto.ImportFileResult {
+	result.Status = dto.ImportStatusFailed
+	result.Errors = make([]dto.ImportFileError, len(fieldErrors))
+	for i, fe := range fieldErrors {
+		result.Errors[i] = dto.ImportFileError{Field: fe.Field, Message: fe.Message}
+	}
+	return result
+}
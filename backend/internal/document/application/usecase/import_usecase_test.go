@@ -0,0 +1,242 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const importRepositoryID = "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+
+const importProcedure = `---
+title: Database Backup Procedure
+type: procedure
+tags: [database, backup]
+variables:
+  - name: server_name
+    label: Server Name
+    type: string
+    required: true
+    defaultValue: prod-db-01
+---
+# Database Backup Procedure
+
+Connect to {{server_name}}.
+`
+
+// newImportUseCaseForTest wires the import use case to mocks, using the real document use case underneath
+func newImportUseCaseForTest() (ImportUseCase, *repository.MockDocumentRepository, *MockManagedFileReader, *MockSourceCommitResolver) {
+	mockRepo := new(repository.MockDocumentRepository)
+	mockFiles := new(MockManagedFileReader)
+	mockCommits := new(MockSourceCommitResolver)
+	docs := NewDocumentUseCase(mockRepo, new(repository.MockAccessGrantRepository), mockCommits, permissionsOf())
+	return NewImportUseCase(mockRepo, docs, mockFiles, mockCommits), mockRepo, mockFiles, mockCommits
+}
+
+func TestImportUseCase_ImportFromRepository(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Frontmatterから新しいドキュメントを作成できる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/backup.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/backup.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/backup.md").Return("abc1234567890", nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{}, nil)
+		var saved entity.Document
+		mockRepo.On("Save", ctx, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(entity.Document)
+		}).Return(nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, dto.ImportStatusCreated, result.Files[0].Status)
+		assert.Empty(t, result.Files[0].Errors)
+
+		// テスト：Frontmatterの内容が最初のバージョンとして公開される
+		require.NotNil(t, saved)
+		assert.Equal(t, saved.ID().String(), result.Files[0].DocumentID)
+		assert.Equal(t, "user-1", saved.Owner())
+		assert.True(t, saved.AccessScope().IsPublic())
+		version := saved.CurrentVersion()
+		require.NotNil(t, version)
+		assert.Equal(t, "Database Backup Procedure", version.Title())
+		assert.Equal(t, "procedure", version.Type().String())
+		assert.Equal(t, "abc1234567890", version.Source().CommitHash().String())
+		assert.Len(t, version.Tags(), 2)
+		require.Len(t, version.Variables(), 1)
+		assert.Equal(t, "server_name", version.Variables()[0].Name())
+		assert.Equal(t, "# Database Backup Procedure\n\nConnect to {{server_name}}.\n", version.Content())
+	})
+
+	t.Run("変更されたファイルは既存ドキュメントの新しいバージョンになる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+		doc := createTestDocument(t) // docs/test.md at abc1234567890
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/test.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/test.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("def4567890123", nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{doc}, nil)
+		mockRepo.On("FindByID", ctx, doc.ID()).Return(doc, nil)
+		mockRepo.On("Update", ctx, doc).Return(nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, dto.ImportStatusUpdated, result.Files[0].Status)
+		assert.Equal(t, doc.ID().String(), result.Files[0].DocumentID)
+		assert.Len(t, doc.Versions(), 2)
+		assert.Equal(t, "def4567890123", doc.CurrentVersion().Source().CommitHash().String())
+		assert.Equal(t, "Database Backup Procedure", doc.CurrentVersion().Title())
+		mockRepo.AssertExpectations(t)
+	})
+
+	t.Run("コミットが変わっていないファイルは変更なしになる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+		doc := createTestDocument(t)
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/test.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/test.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/test.md").Return("abc1234567890", nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{doc}, nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 1)
+		assert.Equal(t, dto.ImportStatusUnchanged, result.Files[0].Status)
+		assert.Len(t, doc.Versions(), 1)
+		mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+	})
+
+	t.Run("Markdown以外とFrontmatterのないファイルはスキップされる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, _ := newImportUseCaseForTest()
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"scripts/backup.sh", "docs/notes.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/notes.md").Return([]byte("# Notes\n"), nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{}, nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 2)
+		assert.Equal(t, dto.ImportStatusSkipped, result.Files[0].Status)
+		assert.Equal(t, dto.ImportStatusSkipped, result.Files[1].Status)
+		assert.Equal(t, "frontmatter", result.Files[1].Errors[0].Field)
+		mockFiles.AssertNotCalled(t, "ReadManagedFile", ctx, importRepositoryID, "scripts/backup.sh")
+	})
+
+	t.Run("Frontmatterの検証エラーがファイルごとに返され他のファイルは取り込まれる", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+		invalid := `---
+type: runbook
+variables:
+  - name: 1st
+    label: First
+    type: string
+  - name: port
+    label: Port
+    type: integer
+---
+Body
+`
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/invalid.md", "docs/broken.md", "docs/backup.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/invalid.md").Return([]byte(invalid), nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/broken.md").Return([]byte("---\ntags: [a\n---\nBody\n"), nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/backup.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/backup.md").Return("abc1234567890", nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{}, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 3)
+
+		// テスト：すべての問題がフィールドごとに報告される
+		assert.Equal(t, dto.ImportStatusFailed, result.Files[0].Status)
+		fields := make([]string, len(result.Files[0].Errors))
+		for i, e := range result.Files[0].Errors {
+			fields[i] = e.Field
+		}
+		assert.Equal(t, []string{"title", "type", "variables[0]", "variables[1].type"}, fields)
+
+		assert.Equal(t, dto.ImportStatusFailed, result.Files[1].Status)
+		assert.Equal(t, "frontmatter", result.Files[1].Errors[0].Field)
+
+		assert.Equal(t, dto.ImportStatusCreated, result.Files[2].Status)
+		mockRepo.AssertNumberOfCalls(t, "Save", 1)
+	})
+
+	t.Run("読み込みやコミットの解決に失敗したファイルは失敗として返される", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/missing.md", "docs/backup.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/missing.md").Return(nil, errors.New("file not found"))
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/backup.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/backup.md").Return("", errors.New("not cloned"))
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{}, nil)
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		require.NoError(t, err)
+		require.Len(t, result.Files, 2)
+		assert.Equal(t, dto.ImportStatusFailed, result.Files[0].Status)
+		assert.Equal(t, "file_path", result.Files[0].Errors[0].Field)
+		assert.Equal(t, dto.ImportStatusFailed, result.Files[1].Status)
+		assert.Equal(t, "commit_hash", result.Files[1].Errors[0].Field)
+	})
+
+	t.Run("保存に失敗した場合はエラーを返す", func(t *testing.T) {
+		uc, mockRepo, mockFiles, mockCommits := newImportUseCaseForTest()
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return([]string{"docs/backup.md"}, nil)
+		mockFiles.On("ReadManagedFile", ctx, importRepositoryID, "docs/backup.md").Return([]byte(importProcedure), nil)
+		mockCommits.On("ResolveFileCommit", ctx, importRepositoryID, "docs/backup.md").Return("abc1234567890", nil)
+		mockRepo.On("FindByRepositoryID", ctx, mock.Anything).Return([]entity.Document{}, nil)
+		mockRepo.On("Save", ctx, mock.Anything).Return(errors.New("database error"))
+
+		result, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		assert.Error(t, err)
+		assert.Nil(t, result)
+	})
+
+	t.Run("不正なリポジトリIDやアクセス範囲はバリデーションエラーになる", func(t *testing.T) {
+		uc, _, _, _ := newImportUseCaseForTest()
+
+		_, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: "", Owner: "user-1"})
+		var validationErr *apperror.ValidationFailedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "repository_id", validationErr.Errors[0].Field)
+
+		_, err = uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1", AccessScope: "everyone"})
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "access_scope", validationErr.Errors[0].Field)
+	})
+
+	t.Run("管理対象ファイルを取得できない場合はバリデーションエラーになる", func(t *testing.T) {
+		uc, _, mockFiles, _ := newImportUseCaseForTest()
+
+		mockFiles.On("ListManagedFiles", ctx, importRepositoryID).Return(nil, errors.New("repository not found"))
+
+		_, err := uc.ImportFromRepository(ctx, &dto.ImportDocumentsRequest{RepositoryID: importRepositoryID, Owner: "user-1"})
+
+		var validationErr *apperror.ValidationFailedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Contains(t, validationErr.Errors[0].Message, "repository not found")
+	})
+}
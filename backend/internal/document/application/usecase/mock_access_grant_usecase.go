@@ -0,0 +1,38 @@
+package usecase
+
+import (
+	"context"
+
+	"opscore/backend/internal/document/application/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccessGrantUseCase is a mock implementation of AccessGrantUseCase for testing.
+type MockAccessGrantUseCase struct {
+	mock.Mock
+}
+
+// ListGrants mocks the ListGrants method.
+func (m *MockAccessGrantUseCase) ListGrants(ctx context.Context, caller dto.Caller, documentID string) ([]dto.AccessGrantResponse, error) {
+	args := m.Called(ctx, caller, documentID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.AccessGrantResponse), args.Error(1)
+}
+
+// GrantAccess mocks the GrantAccess method.
+func (m *MockAccessGrantUseCase) GrantAccess(ctx context.Context, caller dto.Caller, documentID string, req *dto.GrantAccessRequest) (*dto.AccessGrantResponse, error) {
+	args := m.Called(ctx, caller, documentID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.AccessGrantResponse), args.Error(1)
+}
+
+// RevokeAccess mocks the RevokeAccess method.
+func (m *MockAccessGrantUseCase) RevokeAccess(ctx context.Context, caller dto.Caller, documentID, principalType, principalID string) error {
+	args := m.Called(ctx, caller, documentID, principalType, principalID)
+	return args.Error(0)
+}
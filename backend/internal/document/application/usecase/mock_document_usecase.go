@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"opscore/backend/internal/document/application/dto"
+	"opscore/backend/internal/document/domain/value_object"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -32,8 +33,8 @@ func (m *MockDocumentUseCase) UpdateDocument(ctx context.Context, documentID str
 }
 
 // GetDocument mocks the GetDocument method.
-func (m *MockDocumentUseCase) GetDocument(ctx context.Context, documentID string) (*dto.DocumentResponse, error) {
-	args := m.Called(ctx, documentID)
+func (m *MockDocumentUseCase) GetDocument(ctx context.Context, caller dto.Caller, documentID string) (*dto.DocumentResponse, error) {
+	args := m.Called(ctx, caller, documentID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -41,8 +42,8 @@ func (m *MockDocumentUseCase) GetDocument(ctx context.Context, documentID string
 }
 
 // GetDocumentVersion mocks the GetDocumentVersion method.
-func (m *MockDocumentUseCase) GetDocumentVersion(ctx context.Context, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error) {
-	args := m.Called(ctx, documentID, versionNumber)
+func (m *MockDocumentUseCase) GetDocumentVersion(ctx context.Context, caller dto.Caller, documentID string, versionNumber int) (*dto.DocumentVersionResponse, error) {
+	args := m.Called(ctx, caller, documentID, versionNumber)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -50,8 +51,8 @@ func (m *MockDocumentUseCase) GetDocumentVersion(ctx context.Context, documentID
 }
 
 // ListDocuments mocks the ListDocuments method.
-func (m *MockDocumentUseCase) ListDocuments(ctx context.Context) ([]dto.DocumentListItemResponse, error) {
-	args := m.Called(ctx)
+func (m *MockDocumentUseCase) ListDocuments(ctx context.Context, caller dto.Caller) ([]dto.DocumentListItemResponse, error) {
+	args := m.Called(ctx, caller)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -59,8 +60,8 @@ func (m *MockDocumentUseCase) ListDocuments(ctx context.Context) ([]dto.Document
 }
 
 // ListDocumentsByRepository mocks the ListDocumentsByRepository method.
-func (m *MockDocumentUseCase) ListDocumentsByRepository(ctx context.Context, repositoryID string) ([]dto.DocumentListItemResponse, error) {
-	args := m.Called(ctx, repositoryID)
+func (m *MockDocumentUseCase) ListDocumentsByRepository(ctx context.Context, caller dto.Caller, repositoryID string) ([]dto.DocumentListItemResponse, error) {
+	args := m.Called(ctx, caller, repositoryID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -68,8 +69,8 @@ func (m *MockDocumentUseCase) ListDocumentsByRepository(ctx context.Context, rep
 }
 
 // GetDocumentVersions mocks the GetDocumentVersions method.
-func (m *MockDocumentUseCase) GetDocumentVersions(ctx context.Context, documentID string) (*dto.VersionHistoryResponse, error) {
-	args := m.Called(ctx, documentID)
+func (m *MockDocumentUseCase) GetDocumentVersions(ctx context.Context, caller dto.Caller, documentID string) (*dto.VersionHistoryResponse, error) {
+	args := m.Called(ctx, caller, documentID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -102,3 +103,9 @@ func (m *MockDocumentUseCase) UpdateDocumentMetadata(ctx context.Context, docume
 	}
 	return args.Get(0).(*dto.DocumentResponse), args.Error(1)
 }
+
+// DocumentAccessLevel mocks the DocumentAccessLevel method.
+func (m *MockDocumentUseCase) DocumentAccessLevel(ctx context.Context, caller dto.Caller, documentID string) (value_object.AccessLevel, bool, error) {
+	args := m.Called(ctx, caller, documentID)
+	return args.Get(0).(value_object.AccessLevel), args.Bool(1), args.Error(2)
+}
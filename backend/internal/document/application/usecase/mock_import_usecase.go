@@ -0,0 +1,23 @@
+package usecase
+
+import (
+	"context"
+
+	"opscore/backend/internal/document/application/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockImportUseCase is a mock implementation of ImportUseCase for testing
+type MockImportUseCase struct {
+	mock.Mock
+}
+
+// ImportFromRepository mocks the ImportFromRepository method
+func (m *MockImportUseCase) ImportFromRepository(ctx context.Context, req *dto.ImportDocumentsRequest) (*dto.ImportResultResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ImportResultResponse), args.Error(1)
+}
@@ -0,0 +1,30 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockManagedFileReader is a mock implementation of ManagedFileReader for testing.
+type MockManagedFileReader struct {
+	mock.Mock
+}
+
+// ListManagedFiles mocks the ListManagedFiles method.
+func (m *MockManagedFileReader) ListManagedFiles(ctx context.Context, repositoryID string) ([]string, error) {
+	args := m.Called(ctx, repositoryID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// ReadManagedFile mocks the ReadManagedFile method.
+func (m *MockManagedFileReader) ReadManagedFile(ctx context.Context, repositoryID string, filePath string) ([]byte, error) {
+	args := m.Called(ctx, repositoryID, filePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
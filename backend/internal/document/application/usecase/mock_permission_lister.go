@@ -0,0 +1,21 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockPermissionLister is a mock implementation of PermissionLister for testing.
+type MockPermissionLister struct {
+	mock.Mock
+}
+
+// EffectivePermissions mocks the EffectivePermissions method.
+func (m *MockPermissionLister) EffectivePermissions(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
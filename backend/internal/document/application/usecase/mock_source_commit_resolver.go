@@ -0,0 +1,18 @@
+package usecase
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSourceCommitResolver is a mock implementation of SourceCommitResolver for testing.
+type MockSourceCommitResolver struct {
+	mock.Mock
+}
+
+// ResolveFileCommit mocks the ResolveFileCommit method.
+func (m *MockSourceCommitResolver) ResolveFileCommit(ctx context.Context, repositoryID string, filePath string) (string, error) {
+	args := m.Called(ctx, repositoryID, filePath)
+	return args.String(0), args.Error(1)
+}
@@ -14,8 +14,8 @@ type MockVariableUseCase struct {
 }
 
 // GetVariableDefinitions mocks the GetVariableDefinitions method
-func (m *MockVariableUseCase) GetVariableDefinitions(ctx context.Context, documentID string) ([]dto.VariableDefinitionDTO, error) {
-	args := m.Called(ctx, documentID)
+func (m *MockVariableUseCase) GetVariableDefinitions(ctx context.Context, caller dto.Caller, documentID string) ([]dto.VariableDefinitionDTO, error) {
+	args := m.Called(ctx, caller, documentID)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -23,8 +23,8 @@ func (m *MockVariableUseCase) GetVariableDefinitions(ctx context.Context, docume
 }
 
 // ValidateVariableValues mocks the ValidateVariableValues method
-func (m *MockVariableUseCase) ValidateVariableValues(ctx context.Context, documentID string, values []VariableValue) error {
-	args := m.Called(ctx, documentID, values)
+func (m *MockVariableUseCase) ValidateVariableValues(ctx context.Context, caller dto.Caller, documentID string, values []VariableValue) error {
+	args := m.Called(ctx, caller, documentID, values)
 	return args.Error(0)
 }
 
@@ -19,11 +19,11 @@ type VariableValue struct {
 
 // VariableUseCase defines the interface for variable-related use cases
 type VariableUseCase interface {
-	// GetVariableDefinitions retrieves variable definitions for a document
-	GetVariableDefinitions(ctx context.Context, documentID string) ([]dto.VariableDefinitionDTO, error)
+	// GetVariableDefinitions retrieves variable definitions for a document the caller may view
+	GetVariableDefinitions(ctx context.Context, caller dto.Caller, documentID string) ([]dto.VariableDefinitionDTO, error)
 
-	// ValidateVariableValues validates variable values against their definitions
-	ValidateVariableValues(ctx context.Context, documentID string, values []VariableValue) error
+	// ValidateVariableValues validates variable values against their definitions for a document the caller may execute
+	ValidateVariableValues(ctx context.Context, caller dto.Caller, documentID string, values []VariableValue) error
 
 	// SubstituteVariables replaces variable placeholders in content with provided values
 	SubstituteVariables(ctx context.Context, content string, values []VariableValue) (string, error)
@@ -32,17 +32,24 @@ type VariableUseCase interface {
 // variableUseCase implements the VariableUseCase interface
 type variableUseCase struct {
 	docRepo repository.DocumentRepository
+	access  documentAccess
 }
 
 // NewVariableUseCase creates a new instance of variableUseCase
-func NewVariableUseCase(docRepo repository.DocumentRepository) VariableUseCase {
+func NewVariableUseCase(docRepo repository.DocumentRepository, grantRepo repository.AccessGrantRepository, permissions PermissionLister) VariableUseCase {
 	return &variableUseCase{
 		docRepo: docRepo,
+		access:  documentAccess{grantRepo: grantRepo, permissions: permissions},
 	}
 }
 
-// GetVariableDefinitions retrieves variable definitions for a document
-func (uc *variableUseCase) GetVariableDefinitions(ctx context.Context, documentID string) ([]dto.VariableDefinitionDTO, error) {
+// GetVariableDefinitions retrieves variable definitions for a document the caller may view
+func (uc *variableUseCase) GetVariableDefinitions(ctx context.Context, caller dto.Caller, documentID string) ([]dto.VariableDefinitionDTO, error) {
+	return uc.definitions(ctx, caller, documentID, value_object.AccessLevelView)
+}
+
+// definitions loads the variable definitions after checking the caller holds the required access level
+func (uc *variableUseCase) definitions(ctx context.Context, caller dto.Caller, documentID string, required value_object.AccessLevel) ([]dto.VariableDefinitionDTO, error) {
 	// Validate document ID
 	docID, err := value_object.NewDocumentID(documentID)
 	if err != nil {
@@ -59,6 +66,9 @@ func (uc *variableUseCase) GetVariableDefinitions(ctx context.Context, documentI
 	if doc == nil {
 		return nil, apperror.NewNotFoundError("Document", documentID, nil)
 	}
+	if err := uc.access.require(ctx, doc, caller, required); err != nil {
+		return nil, err
+	}
 
 	// Get current version
 	currentVersion := doc.CurrentVersion()
@@ -84,9 +94,9 @@ func (uc *variableUseCase) GetVariableDefinitions(ctx context.Context, documentI
 }
 
 // ValidateVariableValues validates variable values against their definitions
-func (uc *variableUseCase) ValidateVariableValues(ctx context.Context, documentID string, values []VariableValue) error {
+func (uc *variableUseCase) ValidateVariableValues(ctx context.Context, caller dto.Caller, documentID string, values []VariableValue) error {
 	// Get variable definitions
-	definitions, err := uc.GetVariableDefinitions(ctx, documentID)
+	definitions, err := uc.definitions(ctx, caller, documentID, value_object.AccessLevelExecute)
 	if err != nil {
 		return err
 	}
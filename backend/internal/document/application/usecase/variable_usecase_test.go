@@ -2,8 +2,11 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"testing"
 
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
 	"opscore/backend/internal/document/domain/entity"
 	"opscore/backend/internal/document/domain/repository"
 	"opscore/backend/internal/document/domain/value_object"
@@ -23,8 +26,8 @@ func TestVariableUseCase_GetVariableDefinitions(t *testing.T) {
 		// Mock FindByID to return the document
 		mockRepo.On("FindByID", mock.Anything, mock.AnythingOfType("value_object.DocumentID")).Return(doc, nil)
 
-		uc := NewVariableUseCase(mockRepo)
-		result, err := uc.GetVariableDefinitions(context.Background(), docID)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
+		result, err := uc.GetVariableDefinitions(context.Background(), dto.Caller{}, docID)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, result)
@@ -42,8 +45,8 @@ func TestVariableUseCase_GetVariableDefinitions(t *testing.T) {
 		// Mock FindByID to return nil (not found)
 		mockRepo.On("FindByID", mock.Anything, mock.AnythingOfType("value_object.DocumentID")).Return(nil, nil)
 
-		uc := NewVariableUseCase(mockRepo)
-		result, err := uc.GetVariableDefinitions(context.Background(), docID)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
+		result, err := uc.GetVariableDefinitions(context.Background(), dto.Caller{}, docID)
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -54,8 +57,8 @@ func TestVariableUseCase_GetVariableDefinitions(t *testing.T) {
 	t.Run("無効なドキュメントIDの場合はエラーを返す", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
-		result, err := uc.GetVariableDefinitions(context.Background(), "invalid-id")
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
+		result, err := uc.GetVariableDefinitions(context.Background(), dto.Caller{}, "invalid-id")
 
 		assert.Error(t, err)
 		assert.Nil(t, result)
@@ -73,14 +76,14 @@ func TestVariableUseCase_ValidateVariableValues(t *testing.T) {
 		// Mock FindByID to return the document
 		mockRepo.On("FindByID", mock.Anything, mock.AnythingOfType("value_object.DocumentID")).Return(doc, nil)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		values := []VariableValue{
 			{Name: "server_name", Value: "prod-server"},
 			{Name: "backup_path", Value: "/backup/db"},
 		}
 
-		err := uc.ValidateVariableValues(context.Background(), docID, values)
+		err := uc.ValidateVariableValues(context.Background(), dto.Caller{}, docID, values)
 
 		assert.NoError(t, err)
 
@@ -97,26 +100,44 @@ func TestVariableUseCase_ValidateVariableValues(t *testing.T) {
 		// Mock FindByID to return the document
 		mockRepo.On("FindByID", mock.Anything, mock.AnythingOfType("value_object.DocumentID")).Return(doc, nil)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		// Missing required variable 'server_name'
 		values := []VariableValue{
 			{Name: "backup_path", Value: "/backup/db"},
 		}
 
-		err := uc.ValidateVariableValues(context.Background(), docID, values)
+		err := uc.ValidateVariableValues(context.Background(), dto.Caller{}, docID, values)
 
 		assert.Error(t, err)
 
 		mockRepo.AssertExpectations(t)
 	})
+
+	t.Run("閲覧権限のみのユーザーは変数値を検証できない", func(t *testing.T) {
+		mockRepo := new(repository.MockDocumentRepository)
+		mockGrantRepo := new(repository.MockAccessGrantRepository)
+		doc, grants := createPrivateTestDocument(t, "sre")
+
+		mockRepo.On("FindByID", mock.Anything, doc.ID()).Return(doc, nil)
+		mockGrantRepo.On("FindByDocumentID", mock.Anything, doc.ID()).Return(grants, nil)
+
+		uc := NewVariableUseCase(mockRepo, mockGrantRepo, permissionsOf())
+		caller := dto.Caller{UserID: "user-1", GroupIDs: []string{"sre"}}
+
+		_, err := uc.GetVariableDefinitions(context.Background(), caller, doc.ID().String())
+		assert.NoError(t, err)
+
+		err = uc.ValidateVariableValues(context.Background(), caller, doc.ID().String(), nil)
+		assert.True(t, errors.Is(err, apperror.ErrForbidden))
+	})
 }
 
 func TestVariableUseCase_SubstituteVariables(t *testing.T) {
 	t.Run("変数を正しく置換できる", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		content := "Connect to {{server_name}} and backup to {{backup_path}}"
 		values := []VariableValue{
@@ -133,7 +154,7 @@ func TestVariableUseCase_SubstituteVariables(t *testing.T) {
 	t.Run("複数箇所の同じ変数を置換できる", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		content := "Server: {{server_name}}, Connect to {{server_name}}"
 		values := []VariableValue{
@@ -149,7 +170,7 @@ func TestVariableUseCase_SubstituteVariables(t *testing.T) {
 	t.Run("変数が存在しない場合はそのまま残す", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		content := "Connect to {{server_name}} and backup to {{backup_path}}"
 		values := []VariableValue{
@@ -165,7 +186,7 @@ func TestVariableUseCase_SubstituteVariables(t *testing.T) {
 	t.Run("変数値にnilを指定した場合は空文字列に置換される", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		content := "Server: {{server_name}}, Path: {{backup_path}}"
 		values := []VariableValue{
@@ -182,7 +203,7 @@ func TestVariableUseCase_SubstituteVariables(t *testing.T) {
 	t.Run("変数値に特殊文字が含まれていても正しく置換される", func(t *testing.T) {
 		mockRepo := new(repository.MockDocumentRepository)
 
-		uc := NewVariableUseCase(mockRepo)
+		uc := NewVariableUseCase(mockRepo, new(repository.MockAccessGrantRepository), permissionsOf())
 
 		content := "Path: {{path}}, Pattern: {{pattern}}"
 		values := []VariableValue{
@@ -0,0 +1,103 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+// accessGrant represents an ACL entry granting a user or group access to a document.
+type accessGrant struct {
+	documentID    value_object.DocumentID
+	principalType value_object.PrincipalType
+	principalID   string
+	level         value_object.AccessLevel
+	grantedBy     string
+	grantedAt     time.Time
+}
+
+// AccessGrant is the interface for a document ACL entry.
+type AccessGrant interface {
+	DocumentID() value_object.DocumentID
+	PrincipalType() value_object.PrincipalType
+	PrincipalID() string
+	Level() value_object.AccessLevel
+	GrantedBy() string
+	GrantedAt() time.Time
+}
+
+// NewAccessGrant creates a new AccessGrant instance.
+func NewAccessGrant(
+	documentID value_object.DocumentID,
+	principalType value_object.PrincipalType,
+	principalID string,
+	level value_object.AccessLevel,
+	grantedBy string,
+) (AccessGrant, error) {
+	if documentID.IsEmpty() {
+		return nil, errors.New("document ID cannot be empty")
+	}
+	if !principalType.IsValid() {
+		return nil, errors.New("invalid principal type")
+	}
+	if principalID == "" {
+		return nil, errors.New("principal ID cannot be empty")
+	}
+	if !level.IsValid() {
+		return nil, errors.New("invalid access level")
+	}
+
+	return &accessGrant{
+		documentID:    documentID,
+		principalType: principalType,
+		principalID:   principalID,
+		level:         level,
+		grantedBy:     grantedBy,
+		grantedAt:     time.Now(),
+	}, nil
+}
+
+// ReconstructAccessGrant reconstructs an AccessGrant from persistence data.
+func ReconstructAccessGrant(
+	documentID value_object.DocumentID,
+	principalType value_object.PrincipalType,
+	principalID string,
+	level value_object.AccessLevel,
+	grantedBy string,
+	grantedAt time.Time,
+) AccessGrant {
+	return &accessGrant{
+		documentID:    documentID,
+		principalType: principalType,
+		principalID:   principalID,
+		level:         level,
+		grantedBy:     grantedBy,
+		grantedAt:     grantedAt,
+	}
+}
+
+// Getter methods
+func (g *accessGrant) DocumentID() value_object.DocumentID {
+	return g.documentID
+}
+
+func (g *accessGrant) PrincipalType() value_object.PrincipalType {
+	return g.principalType
+}
+
+func (g *accessGrant) PrincipalID() string {
+	return g.principalID
+}
+
+func (g *accessGrant) Level() value_object.AccessLevel {
+	return g.level
+}
+
+func (g *accessGrant) GrantedBy() string {
+	return g.grantedBy
+}
+
+func (g *accessGrant) GrantedAt() time.Time {
+	return g.grantedAt
+}
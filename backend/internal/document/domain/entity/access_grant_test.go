@@ -0,0 +1,83 @@
+package entity
+
+import (
+	"testing"
+
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+func TestNewAccessGrant(t *testing.T) {
+	docID := value_object.GenerateDocumentID()
+
+	tests := []struct {
+		name          string
+		documentID    value_object.DocumentID
+		principalType value_object.PrincipalType
+		principalID   string
+		level         value_object.AccessLevel
+		wantErr       bool
+	}{
+		{name: "valid group grant", documentID: docID, principalType: value_object.PrincipalTypeGroup, principalID: "group-sre", level: value_object.AccessLevelView},
+		{name: "empty document ID", documentID: value_object.DocumentID(""), principalType: value_object.PrincipalTypeUser, principalID: "user-1", level: value_object.AccessLevelView, wantErr: true},
+		{name: "invalid principal type", documentID: docID, principalType: value_object.PrincipalType("team"), principalID: "team-1", level: value_object.AccessLevelView, wantErr: true},
+		{name: "empty principal ID", documentID: docID, principalType: value_object.PrincipalTypeUser, principalID: "", level: value_object.AccessLevelView, wantErr: true},
+		{name: "invalid level", documentID: docID, principalType: value_object.PrincipalTypeUser, principalID: "user-1", level: value_object.AccessLevel("admin"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAccessGrant(tt.documentID, tt.principalType, tt.principalID, tt.level, "owner-1")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAccessGrant() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.PrincipalID() != tt.principalID {
+				t.Errorf("PrincipalID() = %v, want %v", got.PrincipalID(), tt.principalID)
+			}
+		})
+	}
+}
+
+func TestDocument_AccessLevelFor(t *testing.T) {
+	repoID, _ := value_object.NewRepositoryID("a1b2c3d4-e5f6-4789-abcd-ef0123456789")
+	private, _ := NewDocument(value_object.GenerateDocumentID(), repoID, "owner-1", value_object.AccessScopePrivate)
+	public, _ := NewDocument(value_object.GenerateDocumentID(), repoID, "owner-1", value_object.AccessScopePublic)
+
+	grant := func(doc Document, pt value_object.PrincipalType, id string, level value_object.AccessLevel) AccessGrant {
+		g, err := NewAccessGrant(doc.ID(), pt, id, level, "owner-1")
+		if err != nil {
+			t.Fatalf("NewAccessGrant() error = %v", err)
+		}
+		return g
+	}
+	grants := []AccessGrant{
+		grant(private, value_object.PrincipalTypeGroup, "group-sre", value_object.AccessLevelView),
+		grant(private, value_object.PrincipalTypeUser, "user-oncall", value_object.AccessLevelExecute),
+		grant(public, value_object.PrincipalTypeUser, "user-other", value_object.AccessLevelExecute),
+	}
+
+	tests := []struct {
+		name      string
+		doc       Document
+		accessor  value_object.Accessor
+		wantLevel value_object.AccessLevel
+		wantOK    bool
+	}{
+		{name: "public document is open to everyone", doc: public, accessor: value_object.NewAccessor("user-x", nil, false), wantLevel: value_object.AccessLevelExecute, wantOK: true},
+		{name: "owner has full access", doc: private, accessor: value_object.NewAccessor("owner-1", nil, false), wantLevel: value_object.AccessLevelExecute, wantOK: true},
+		{name: "admin has full access", doc: private, accessor: value_object.NewAccessor("admin-1", nil, true), wantLevel: value_object.AccessLevelExecute, wantOK: true},
+		{name: "group member can view", doc: private, accessor: value_object.NewAccessor("user-sre", []string{"group-sre"}, false), wantLevel: value_object.AccessLevelView, wantOK: true},
+		{name: "highest grant wins", doc: private, accessor: value_object.NewAccessor("user-oncall", []string{"group-sre"}, false), wantLevel: value_object.AccessLevelExecute, wantOK: true},
+		{name: "grants on other documents are ignored", doc: private, accessor: value_object.NewAccessor("user-other", nil, false), wantLevel: "", wantOK: false},
+		{name: "anonymous has no access", doc: private, accessor: value_object.NewAccessor("", nil, false), wantLevel: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, ok := tt.doc.AccessLevelFor(tt.accessor, grants)
+			if ok != tt.wantOK || level != tt.wantLevel {
+				t.Errorf("AccessLevelFor() = (%v, %v), want (%v, %v)", level, ok, tt.wantLevel, tt.wantOK)
+			}
+		})
+	}
+}
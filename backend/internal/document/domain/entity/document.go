@@ -15,6 +15,7 @@ type document struct {
 	isPublished    bool
 	isAutoUpdate   bool
 	accessScope    value_object.AccessScope
+	pendingCommit  value_object.CommitHash // Upstream commit awaiting manual review; empty when up to date
 	currentVersion DocumentVersion
 	versions       []DocumentVersion
 	createdAt      time.Time
@@ -29,6 +30,8 @@ type Document interface {
 	IsPublished() bool
 	IsAutoUpdate() bool
 	AccessScope() value_object.AccessScope
+	IsUpdateAvailable() bool
+	PendingCommitHash() value_object.CommitHash
 	CurrentVersion() DocumentVersion
 	Versions() []DocumentVersion
 	CreatedAt() time.Time
@@ -47,8 +50,10 @@ type Document interface {
 	UpdateAccessScope(scope value_object.AccessScope) error
 	EnableAutoUpdate()
 	DisableAutoUpdate()
+	MarkUpdateAvailable(commitHash value_object.CommitHash) error
 	RollbackToVersion(versionNumber value_object.VersionNumber) error
 	AddVersion(version DocumentVersion) error
+	AccessLevelFor(accessor value_object.Accessor, grants []AccessGrant) (value_object.AccessLevel, bool)
 }
 
 // NewDocument creates a new Document instance.
@@ -93,6 +98,7 @@ func ReconstructDocument(
 	isPublished bool,
 	isAutoUpdate bool,
 	accessScope value_object.AccessScope,
+	pendingCommit value_object.CommitHash,
 	currentVersion DocumentVersion,
 	versions []DocumentVersion,
 	createdAt time.Time,
@@ -105,6 +111,7 @@ func ReconstructDocument(
 		isPublished:    isPublished,
 		isAutoUpdate:   isAutoUpdate,
 		accessScope:    accessScope,
+		pendingCommit:  pendingCommit,
 		currentVersion: currentVersion,
 		versions:       versions,
 		createdAt:      createdAt,
@@ -137,6 +144,14 @@ func (d *document) AccessScope() value_object.AccessScope {
 	return d.accessScope
 }
 
+func (d *document) IsUpdateAvailable() bool {
+	return !d.pendingCommit.IsEmpty()
+}
+
+func (d *document) PendingCommitHash() value_object.CommitHash {
+	return d.pendingCommit
+}
+
 func (d *document) CurrentVersion() DocumentVersion {
 	return d.currentVersion
 }
@@ -218,6 +233,7 @@ func (d *document) Publish(
 	d.versions = append(d.versions, newVersion)
 	d.currentVersion = newVersion
 	d.isPublished = true
+	d.pendingCommit = ""
 	d.updatedAt = time.Now()
 
 	return nil
@@ -263,6 +279,18 @@ func (d *document) DisableAutoUpdate() {
 	d.updatedAt = time.Now()
 }
 
+// MarkUpdateAvailable records that the source file changed upstream at commitHash
+// and is waiting for a new version to be published manually.
+func (d *document) MarkUpdateAvailable(commitHash value_object.CommitHash) error {
+	if commitHash.IsEmpty() {
+		return errors.New("commit hash cannot be empty")
+	}
+
+	d.pendingCommit = commitHash
+	d.updatedAt = time.Now()
+	return nil
+}
+
 // RollbackToVersion rolls back the document to a specific version.
 func (d *document) RollbackToVersion(versionNumber value_object.VersionNumber) error {
 	if !d.isPublished {
@@ -310,3 +338,24 @@ func (d *document) AddVersion(version DocumentVersion) error {
 
 	return nil
 }
+
+// AccessLevelFor returns the highest access level the accessor holds on the document.
+// Administrators, the owner and everyone on public documents hold execute access;
+// on private documents other users only hold what the ACL entries grant them or their groups.
+func (d *document) AccessLevelFor(accessor value_object.Accessor, grants []AccessGrant) (value_object.AccessLevel, bool) {
+	if accessor.IsAdmin() || d.accessScope.IsPublic() || (accessor.UserID() != "" && accessor.UserID() == d.owner) {
+		return value_object.AccessLevelExecute, true
+	}
+
+	var held value_object.AccessLevel
+	for _, g := range grants {
+		if !g.DocumentID().Equals(d.id) || !accessor.Matches(g.PrincipalType(), g.PrincipalID()) {
+			continue
+		}
+		if g.Level().Includes(held) || held == "" {
+			held = g.Level()
+		}
+	}
+
+	return held, held != ""
+}
@@ -191,6 +191,37 @@ func TestDocument_AutoUpdate(t *testing.T) {
 	}
 }
 
+func TestDocument_MarkUpdateAvailable(t *testing.T) {
+	doc := createTestDocument(t)
+
+	if doc.IsUpdateAvailable() {
+		t.Error("IsUpdateAvailable() = true for new document")
+	}
+
+	if err := doc.MarkUpdateAvailable(value_object.CommitHash("")); err == nil {
+		t.Error("MarkUpdateAvailable() should return error for empty commit hash")
+	}
+
+	pending, _ := value_object.NewCommitHash("def4567890")
+	if err := doc.MarkUpdateAvailable(pending); err != nil {
+		t.Fatalf("MarkUpdateAvailable() error = %v", err)
+	}
+	if !doc.IsUpdateAvailable() || doc.PendingCommitHash() != pending {
+		t.Errorf("PendingCommitHash() = %q, want %q", doc.PendingCommitHash(), pending)
+	}
+
+	// Publishing the change clears the flag
+	path, _ := value_object.NewFilePath("docs/test.md")
+	source, _ := value_object.NewDocumentSource(path, pending)
+	docType, _ := value_object.NewDocumentType("procedure")
+	if err := doc.Publish(source, "Test Title", docType, nil, nil, "# Test"); err != nil {
+		t.Fatalf("Publish() error = %v", err)
+	}
+	if doc.IsUpdateAvailable() {
+		t.Error("IsUpdateAvailable() = true after Publish()")
+	}
+}
+
 func TestDocument_RollbackToVersion(t *testing.T) {
 	doc := createTestDocument(t)
 
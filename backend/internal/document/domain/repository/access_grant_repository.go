@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/value_object"
+)
+
+// AccessGrantRepository defines the interface for document ACL persistence.
+type AccessGrantRepository interface {
+	// Save creates an ACL entry or replaces the level of an existing entry for the same principal.
+	Save(ctx context.Context, grant entity.AccessGrant) error
+
+	// Delete removes the ACL entry of a principal from a document.
+	Delete(ctx context.Context, docID value_object.DocumentID, principalType value_object.PrincipalType, principalID string) error
+
+	// FindByDocumentID retrieves all ACL entries of a document.
+	FindByDocumentID(ctx context.Context, docID value_object.DocumentID) ([]entity.AccessGrant, error)
+
+	// FindByPrincipals retrieves the ACL entries granted to a user or to any of the given groups.
+	FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.AccessGrant, error)
+}
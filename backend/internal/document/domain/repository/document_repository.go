@@ -12,6 +12,30 @@ type Filter interface {
 	Apply(ctx context.Context) context.Context
 }
 
+// RepositoryFilter restricts a query to the documents of a repository.
+type RepositoryFilter struct {
+	RepositoryID value_object.RepositoryID
+}
+
+// Apply implements Filter; implementations translate the filter into their query.
+func (f RepositoryFilter) Apply(ctx context.Context) context.Context { return ctx }
+
+// TypeFilter restricts a query to documents whose current version has the given type.
+type TypeFilter struct {
+	Type value_object.DocumentType
+}
+
+// Apply implements Filter; implementations translate the filter into their query.
+func (f TypeFilter) Apply(ctx context.Context) context.Context { return ctx }
+
+// TagFilter restricts a query to documents whose current version carries the given tag.
+type TagFilter struct {
+	Tag value_object.Tag
+}
+
+// Apply implements Filter; implementations translate the filter into their query.
+func (f TagFilter) Apply(ctx context.Context) context.Context { return ctx }
+
 // DocumentRepository defines the interface for document persistence.
 type DocumentRepository interface {
 	// Save creates a new document.
@@ -0,0 +1,45 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/value_object"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockAccessGrantRepository is a mock implementation of AccessGrantRepository for testing.
+type MockAccessGrantRepository struct {
+	mock.Mock
+}
+
+// Save mocks the Save method.
+func (m *MockAccessGrantRepository) Save(ctx context.Context, grant entity.AccessGrant) error {
+	args := m.Called(ctx, grant)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method.
+func (m *MockAccessGrantRepository) Delete(ctx context.Context, docID value_object.DocumentID, principalType value_object.PrincipalType, principalID string) error {
+	args := m.Called(ctx, docID, principalType, principalID)
+	return args.Error(0)
+}
+
+// FindByDocumentID mocks the FindByDocumentID method.
+func (m *MockAccessGrantRepository) FindByDocumentID(ctx context.Context, docID value_object.DocumentID) ([]entity.AccessGrant, error) {
+	args := m.Called(ctx, docID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AccessGrant), args.Error(1)
+}
+
+// FindByPrincipals mocks the FindByPrincipals method.
+func (m *MockAccessGrantRepository) FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.AccessGrant, error) {
+	args := m.Called(ctx, userID, groupIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.AccessGrant), args.Error(1)
+}
@@ -0,0 +1,314 @@
+// Package repositorytest provides a contract test suite shared by every DocumentRepository implementation.
+package repositorytest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Factory returns a fresh, empty repository. Implementations backed by a database
+// must make sure the given repository IDs exist so that foreign keys are satisfied.
+type Factory func(t *testing.T, repositoryIDs ...value_object.RepositoryID) repository.DocumentRepository
+
+// RunDocumentRepositoryContract runs the behaviour every DocumentRepository must provide.
+func RunDocumentRepositoryContract(t *testing.T, newRepo Factory) {
+	ctx := context.Background()
+
+	t.Run("保存したドキュメントを取得できる", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "Deploy procedure")
+		require.NoError(t, repo.Save(ctx, doc))
+
+		found, err := repo.FindByID(ctx, doc.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+
+		assert.True(t, found.ID().Equals(doc.ID()))
+		assert.True(t, found.RepositoryID().Equals(repoID))
+		assert.Equal(t, "user-1", found.Owner())
+		assert.True(t, found.IsPublished())
+		assert.False(t, found.IsAutoUpdate())
+		assert.Equal(t, value_object.AccessScopePublic, found.AccessScope())
+		assert.WithinDuration(t, doc.CreatedAt(), found.CreatedAt(), time.Second)
+		require.Len(t, found.Versions(), 1)
+
+		current := found.CurrentVersion()
+		require.NotNil(t, current)
+		assert.True(t, current.ID().Equals(doc.CurrentVersion().ID()))
+		assert.Equal(t, 1, current.VersionNumber().Int())
+		assert.True(t, current.IsCurrentVersion())
+		assert.Equal(t, "Deploy procedure", current.Title())
+		assert.Equal(t, value_object.DocumentTypeProcedure, current.Type())
+		assert.Equal(t, "docs/deploy.md", current.Source().FilePath().String())
+		assert.Equal(t, "abc1234def", current.Source().CommitHash().String())
+		assert.Equal(t, "# Deploy", current.Content())
+		assert.Nil(t, current.UnpublishedAt())
+
+		require.Len(t, current.Tags(), 2)
+		assert.Equal(t, "ops", current.Tags()[0].String())
+		assert.Equal(t, "deploy", current.Tags()[1].String())
+
+		require.Len(t, current.Variables(), 2)
+		assert.Equal(t, "server_name", current.Variables()[0].Name())
+		assert.Equal(t, "Server name", current.Variables()[0].Label())
+		assert.Equal(t, value_object.VariableTypeString, current.Variables()[0].Type())
+		assert.True(t, current.Variables()[0].Required())
+		assert.Equal(t, "web-01", current.Variables()[0].DefaultValue())
+		assert.Equal(t, "dry_run", current.Variables()[1].Name())
+		assert.Equal(t, value_object.VariableTypeBoolean, current.Variables()[1].Type())
+		assert.Equal(t, true, current.Variables()[1].DefaultValue())
+	})
+
+	t.Run("存在しないIDではnilを返す", func(t *testing.T) {
+		repo := newRepo(t)
+
+		found, err := repo.FindByID(ctx, value_object.GenerateDocumentID())
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("更新で新しいバージョンが追加される", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "Deploy procedure")
+		require.NoError(t, repo.Save(ctx, doc))
+
+		publish(t, doc, "Deploy procedure v2", "bcd2345efa")
+		doc.EnableAutoUpdate()
+		require.NoError(t, doc.UpdateAccessScope(value_object.AccessScopePrivate))
+		require.NoError(t, repo.Update(ctx, doc))
+
+		found, err := repo.FindByID(ctx, doc.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.IsAutoUpdate())
+		assert.Equal(t, value_object.AccessScopePrivate, found.AccessScope())
+		require.Len(t, found.Versions(), 2)
+		require.NotNil(t, found.CurrentVersion())
+		assert.Equal(t, 2, found.CurrentVersion().VersionNumber().Int())
+		assert.Equal(t, "Deploy procedure v2", found.CurrentVersion().Title())
+
+		versions, err := repo.FindVersionsByDocumentID(ctx, doc.ID())
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+
+		v1, err := repo.FindVersionByNumber(ctx, doc.ID(), mustVersionNumber(t, 1))
+		require.NoError(t, err)
+		require.NotNil(t, v1)
+		assert.Equal(t, "Deploy procedure", v1.Title())
+	})
+
+	t.Run("更新ありのコミットが保存される", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "Deploy procedure")
+		require.NoError(t, repo.Save(ctx, doc))
+
+		pending, err := value_object.NewCommitHash("cde3456fab")
+		require.NoError(t, err)
+		require.NoError(t, doc.MarkUpdateAvailable(pending))
+		require.NoError(t, repo.Update(ctx, doc))
+
+		found, err := repo.FindByID(ctx, doc.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.IsUpdateAvailable())
+		assert.Equal(t, pending, found.PendingCommitHash())
+	})
+
+	t.Run("ロールバックで現在のバージョンが切り替わる", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "Deploy procedure")
+		publish(t, doc, "Deploy procedure v2", "bcd2345efa")
+		require.NoError(t, repo.Save(ctx, doc))
+
+		require.NoError(t, doc.RollbackToVersion(mustVersionNumber(t, 1)))
+		require.NoError(t, repo.Update(ctx, doc))
+
+		found, err := repo.FindByID(ctx, doc.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found.CurrentVersion())
+		assert.Equal(t, 1, found.CurrentVersion().VersionNumber().Int())
+		assert.Len(t, found.Versions(), 2)
+	})
+
+	t.Run("リポジトリIDで検索できる", func(t *testing.T) {
+		repoA := newRepositoryID(t)
+		repoB := newRepositoryID(t)
+		repo := newRepo(t, repoA, repoB)
+
+		require.NoError(t, repo.Save(ctx, newPublishedDocument(t, repoA, "A1")))
+		require.NoError(t, repo.Save(ctx, newPublishedDocument(t, repoA, "A2")))
+		require.NoError(t, repo.Save(ctx, newPublishedDocument(t, repoB, "B1")))
+
+		docs, err := repo.FindByRepositoryID(ctx, repoA)
+		require.NoError(t, err)
+		assert.Len(t, docs, 2)
+		for _, d := range docs {
+			assert.True(t, d.RepositoryID().Equals(repoA))
+		}
+	})
+
+	t.Run("公開中のドキュメントのみ取得できる", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		published := newPublishedDocument(t, repoID, "Published")
+		require.NoError(t, repo.Save(ctx, published))
+
+		unpublished := newPublishedDocument(t, repoID, "Unpublished")
+		require.NoError(t, repo.Save(ctx, unpublished))
+		require.NoError(t, unpublished.Unpublish())
+		require.NoError(t, repo.Update(ctx, unpublished))
+
+		draft, err := entity.NewDocument(value_object.GenerateDocumentID(), repoID, "user-1", value_object.AccessScopePublic)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, draft))
+
+		docs, err := repo.FindPublished(ctx)
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.True(t, docs[0].ID().Equals(published.ID()))
+
+		found, err := repo.FindByID(ctx, unpublished.ID())
+		require.NoError(t, err)
+		assert.False(t, found.IsPublished())
+		require.NotNil(t, found.CurrentVersion())
+		assert.NotNil(t, found.CurrentVersion().UnpublishedAt())
+	})
+
+	t.Run("公開中のドキュメントをフィルタで絞り込める", func(t *testing.T) {
+		repoA := newRepositoryID(t)
+		repoB := newRepositoryID(t)
+		repo := newRepo(t, repoA, repoB)
+
+		docA := newPublishedDocument(t, repoA, "Deploy A")
+		require.NoError(t, repo.Save(ctx, docA))
+		docB := newPublishedDocument(t, repoB, "Deploy B")
+		require.NoError(t, repo.Save(ctx, docB))
+
+		docs, err := repo.FindPublished(ctx, repository.RepositoryFilter{RepositoryID: repoA})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.True(t, docs[0].ID().Equals(docA.ID()))
+
+		docs, err = repo.FindPublished(ctx, repository.TypeFilter{Type: value_object.DocumentTypeProcedure}, repository.RepositoryFilter{RepositoryID: repoB})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.True(t, docs[0].ID().Equals(docB.ID()))
+
+		docs, err = repo.FindPublished(ctx, repository.TypeFilter{Type: value_object.DocumentTypeKnowledge})
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+
+		tagOps, err := value_object.NewTag("ops")
+		require.NoError(t, err)
+		docs, err = repo.FindPublished(ctx, repository.TagFilter{Tag: tagOps}, repository.RepositoryFilter{RepositoryID: repoA})
+		require.NoError(t, err)
+		require.Len(t, docs, 1)
+		assert.True(t, docs[0].ID().Equals(docA.ID()))
+
+		tagMissing, err := value_object.NewTag("missing")
+		require.NoError(t, err)
+		docs, err = repo.FindPublished(ctx, repository.TagFilter{Tag: tagMissing})
+		require.NoError(t, err)
+		assert.Empty(t, docs)
+	})
+
+	t.Run("削除したドキュメントとバージョンは取得できない", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "To be deleted")
+		require.NoError(t, repo.Save(ctx, doc))
+		require.NoError(t, repo.Delete(ctx, doc.ID()))
+
+		found, err := repo.FindByID(ctx, doc.ID())
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		versions, err := repo.FindVersionsByDocumentID(ctx, doc.ID())
+		require.NoError(t, err)
+		assert.Empty(t, versions)
+	})
+
+	t.Run("存在しないバージョン番号ではnilを返す", func(t *testing.T) {
+		repoID := newRepositoryID(t)
+		repo := newRepo(t, repoID)
+
+		doc := newPublishedDocument(t, repoID, "Deploy procedure")
+		require.NoError(t, repo.Save(ctx, doc))
+
+		version, err := repo.FindVersionByNumber(ctx, doc.ID(), mustVersionNumber(t, 99))
+		require.NoError(t, err)
+		assert.Nil(t, version)
+	})
+}
+
+func newRepositoryID(t *testing.T) value_object.RepositoryID {
+	t.Helper()
+	id, err := value_object.NewRepositoryID(uuid.NewString())
+	require.NoError(t, err)
+	return id
+}
+
+func mustVersionNumber(t *testing.T, n int) value_object.VersionNumber {
+	t.Helper()
+	v, err := value_object.NewVersionNumber(n)
+	require.NoError(t, err)
+	return v
+}
+
+func newPublishedDocument(t *testing.T, repoID value_object.RepositoryID, title string) entity.Document {
+	t.Helper()
+	doc, err := entity.NewDocument(value_object.GenerateDocumentID(), repoID, "user-1", value_object.AccessScopePublic)
+	require.NoError(t, err)
+	publish(t, doc, title, "abc1234def")
+	return doc
+}
+
+func publish(t *testing.T, doc entity.Document, title string, commit string) {
+	t.Helper()
+
+	filePath, err := value_object.NewFilePath("docs/deploy.md")
+	require.NoError(t, err)
+	commitHash, err := value_object.NewCommitHash(commit)
+	require.NoError(t, err)
+	source, err := value_object.NewDocumentSource(filePath, commitHash)
+	require.NoError(t, err)
+
+	tagOps, err := value_object.NewTag("ops")
+	require.NoError(t, err)
+	tagDeploy, err := value_object.NewTag("deploy")
+	require.NoError(t, err)
+
+	serverName, err := value_object.NewVariableDefinition("server_name", "Server name", "Target host", value_object.VariableTypeString, true, "web-01")
+	require.NoError(t, err)
+	dryRun, err := value_object.NewVariableDefinition("dry_run", "Dry run", "", value_object.VariableTypeBoolean, false, true)
+	require.NoError(t, err)
+
+	err = doc.Publish(
+		source,
+		title,
+		value_object.DocumentTypeProcedure,
+		[]value_object.Tag{tagOps, tagDeploy},
+		[]value_object.VariableDefinition{serverName, dryRun},
+		"# Deploy",
+	)
+	require.NoError(t, err)
+}
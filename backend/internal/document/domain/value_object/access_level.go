@@ -0,0 +1,50 @@
+package value_object
+
+import "errors"
+
+// AccessLevel represents the level of access an ACL entry grants on a document.
+// Execute access also allows editing and always includes view access.
+type AccessLevel string
+
+const (
+	// AccessLevelView allows reading the document and its versions.
+	AccessLevelView AccessLevel = "view"
+	// AccessLevelExecute allows running and editing the document in addition to viewing it.
+	AccessLevelExecute AccessLevel = "execute"
+)
+
+// NewAccessLevel creates a new AccessLevel from a string.
+func NewAccessLevel(level string) (AccessLevel, error) {
+	accessLevel := AccessLevel(level)
+	if !accessLevel.IsValid() {
+		return "", errors.New("invalid access level: must be 'view' or 'execute'")
+	}
+	return accessLevel, nil
+}
+
+// IsValid checks if the AccessLevel is valid.
+func (a AccessLevel) IsValid() bool {
+	return a == AccessLevelView || a == AccessLevelExecute
+}
+
+// String returns the string representation of AccessLevel.
+func (a AccessLevel) String() string {
+	return string(a)
+}
+
+// Equals checks if two AccessLevels are equal.
+func (a AccessLevel) Equals(other AccessLevel) bool {
+	return a == other
+}
+
+// Includes returns true if holding this level also satisfies the required level.
+func (a AccessLevel) Includes(required AccessLevel) bool {
+	switch a {
+	case AccessLevelExecute:
+		return required.IsValid()
+	case AccessLevelView:
+		return required == AccessLevelView
+	default:
+		return false
+	}
+}
@@ -0,0 +1,78 @@
+package value_object
+
+import "testing"
+
+func TestNewAccessLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		want    AccessLevel
+		wantErr bool
+	}{
+		{name: "view level", level: "view", want: AccessLevelView},
+		{name: "execute level", level: "execute", want: AccessLevelExecute},
+		{name: "edit is not a level", level: "edit", want: "", wantErr: true},
+		{name: "empty level", level: "", want: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewAccessLevel(tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewAccessLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got != tt.want {
+				t.Errorf("NewAccessLevel() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessLevel_Includes(t *testing.T) {
+	tests := []struct {
+		name     string
+		held     AccessLevel
+		required AccessLevel
+		want     bool
+	}{
+		{name: "execute includes view", held: AccessLevelExecute, required: AccessLevelView, want: true},
+		{name: "execute includes execute", held: AccessLevelExecute, required: AccessLevelExecute, want: true},
+		{name: "view includes view", held: AccessLevelView, required: AccessLevelView, want: true},
+		{name: "view does not include execute", held: AccessLevelView, required: AccessLevelExecute, want: false},
+		{name: "empty level includes nothing", held: "", required: AccessLevelView, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.held.Includes(tt.required); got != tt.want {
+				t.Errorf("Includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccessor_Matches(t *testing.T) {
+	accessor := NewAccessor("user-1", []string{"group-sre"}, false)
+
+	tests := []struct {
+		name          string
+		principalType PrincipalType
+		principalID   string
+		want          bool
+	}{
+		{name: "same user", principalType: PrincipalTypeUser, principalID: "user-1", want: true},
+		{name: "other user", principalType: PrincipalTypeUser, principalID: "user-2", want: false},
+		{name: "member group", principalType: PrincipalTypeGroup, principalID: "group-sre", want: true},
+		{name: "other group", principalType: PrincipalTypeGroup, principalID: "group-dev", want: false},
+		{name: "user ID is not a group", principalType: PrincipalTypeGroup, principalID: "user-1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := accessor.Matches(tt.principalType, tt.principalID); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
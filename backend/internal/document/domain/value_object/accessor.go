@@ -0,0 +1,49 @@
+package value_object
+
+// Accessor represents the user on whose behalf a document is accessed.
+type Accessor struct {
+	userID   string
+	groupIDs []string
+	isAdmin  bool
+}
+
+// NewAccessor creates a new Accessor.
+func NewAccessor(userID string, groupIDs []string, isAdmin bool) Accessor {
+	ids := make([]string, len(groupIDs))
+	copy(ids, groupIDs)
+	return Accessor{
+		userID:   userID,
+		groupIDs: ids,
+		isAdmin:  isAdmin,
+	}
+}
+
+// UserID returns the ID of the accessing user.
+func (a Accessor) UserID() string {
+	return a.userID
+}
+
+// GroupIDs returns the IDs of the groups the accessing user belongs to.
+func (a Accessor) GroupIDs() []string {
+	return a.groupIDs
+}
+
+// IsAdmin returns true if the accessing user is an administrator.
+func (a Accessor) IsAdmin() bool {
+	return a.isAdmin
+}
+
+// Matches returns true if the principal refers to the accessing user or one of their groups.
+func (a Accessor) Matches(principalType PrincipalType, principalID string) bool {
+	switch principalType {
+	case PrincipalTypeUser:
+		return a.userID != "" && a.userID == principalID
+	case PrincipalTypeGroup:
+		for _, id := range a.groupIDs {
+			if id == principalID {
+				return true
+			}
+		}
+	}
+	return false
+}
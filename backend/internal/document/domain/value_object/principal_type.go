@@ -0,0 +1,37 @@
+package value_object
+
+import "errors"
+
+// PrincipalType represents the kind of principal an ACL entry grants access to.
+type PrincipalType string
+
+const (
+	// PrincipalTypeUser grants access to a single user.
+	PrincipalTypeUser PrincipalType = "user"
+	// PrincipalTypeGroup grants access to every member of a group.
+	PrincipalTypeGroup PrincipalType = "group"
+)
+
+// NewPrincipalType creates a new PrincipalType from a string.
+func NewPrincipalType(principalType string) (PrincipalType, error) {
+	pt := PrincipalType(principalType)
+	if !pt.IsValid() {
+		return "", errors.New("invalid principal type: must be 'user' or 'group'")
+	}
+	return pt, nil
+}
+
+// IsValid checks if the PrincipalType is valid.
+func (p PrincipalType) IsValid() bool {
+	return p == PrincipalTypeUser || p == PrincipalTypeGroup
+}
+
+// String returns the string representation of PrincipalType.
+func (p PrincipalType) String() string {
+	return string(p)
+}
+
+// Equals checks if two PrincipalTypes are equal.
+func (p PrincipalType) Equals(other PrincipalType) bool {
+	return p == other
+}
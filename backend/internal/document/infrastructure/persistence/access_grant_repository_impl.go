@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AccessGrantRepositoryImpl is a PostgreSQL implementation of the AccessGrantRepository interface.
+type AccessGrantRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewAccessGrantRepositoryImpl creates a new AccessGrantRepositoryImpl
+func NewAccessGrantRepositoryImpl(db *pgxpool.Pool) repository.AccessGrantRepository {
+	return &AccessGrantRepositoryImpl{db: db}
+}
+
+const selectAccessGrantColumns = `
+	SELECT document_id, principal_type, principal_id, access_level, granted_by, granted_at
+	FROM document_access_grants
+`
+
+// Save creates an ACL entry or replaces the level of an existing entry for the same principal
+func (r *AccessGrantRepositoryImpl) Save(ctx context.Context, grant entity.AccessGrant) error {
+	query := `
+		INSERT INTO document_access_grants (document_id, principal_type, principal_id, access_level, granted_by, granted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (document_id, principal_type, principal_id) DO UPDATE SET
+			access_level = EXCLUDED.access_level,
+			granted_by = EXCLUDED.granted_by,
+			granted_at = EXCLUDED.granted_at;
+	`
+	_, err := r.db.Exec(ctx, query,
+		grant.DocumentID().String(),
+		grant.PrincipalType().String(),
+		grant.PrincipalID(),
+		grant.Level().String(),
+		grant.GrantedBy(),
+		grant.GrantedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save access grant: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the ACL entry of a principal from a document
+func (r *AccessGrantRepositoryImpl) Delete(ctx context.Context, docID value_object.DocumentID, principalType value_object.PrincipalType, principalID string) error {
+	query := `
+		DELETE FROM document_access_grants
+		WHERE document_id = $1 AND principal_type = $2 AND principal_id = $3;
+	`
+
+	if _, err := r.db.Exec(ctx, query, docID.String(), principalType.String(), principalID); err != nil {
+		return fmt.Errorf("failed to delete access grant: %w", err)
+	}
+
+	return nil
+}
+
+// FindByDocumentID retrieves all ACL entries of a document
+func (r *AccessGrantRepositoryImpl) FindByDocumentID(ctx context.Context, docID value_object.DocumentID) ([]entity.AccessGrant, error) {
+	query := selectAccessGrantColumns + `
+		WHERE document_id = $1
+		ORDER BY principal_type, principal_id;
+	`
+
+	return r.findGrants(ctx, query, docID.String())
+}
+
+// FindByPrincipals retrieves the ACL entries granted to a user or to any of the given groups
+func (r *AccessGrantRepositoryImpl) FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.AccessGrant, error) {
+	query := selectAccessGrantColumns + `
+		WHERE (principal_type = 'user' AND principal_id = $1)
+			OR (principal_type = 'group' AND principal_id = ANY($2))
+		ORDER BY document_id;
+	`
+
+	if groupIDs == nil {
+		groupIDs = []string{}
+	}
+
+	return r.findGrants(ctx, query, userID, groupIDs)
+}
+
+// findGrants runs a query returning ACL entry rows
+func (r *AccessGrantRepositoryImpl) findGrants(ctx context.Context, query string, args ...interface{}) ([]entity.AccessGrant, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query access grants: %w", err)
+	}
+	defer rows.Close()
+
+	grants := []entity.AccessGrant{}
+	for rows.Next() {
+		grant, err := scanAccessGrantRow(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan access grant row: %w", err)
+		}
+		grants = append(grants, grant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over access grant rows: %w", err)
+	}
+
+	return grants, nil
+}
+
+// scanAccessGrantRow scans a single ACL entry row and converts it to a domain entity
+func scanAccessGrantRow(row pgx.Row) (entity.AccessGrant, error) {
+	var docID, principalType, principalID, level, grantedBy string
+	var grantedAt time.Time
+
+	if err := row.Scan(&docID, &principalType, &principalID, &level, &grantedBy, &grantedAt); err != nil {
+		return nil, err
+	}
+
+	documentID, err := value_object.NewDocumentID(docID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+	pt, err := value_object.NewPrincipalType(principalType)
+	if err != nil {
+		return nil, err
+	}
+	accessLevel, err := value_object.NewAccessLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructAccessGrant(documentID, pt, principalID, accessLevel, grantedBy, grantedAt), nil
+}
@@ -0,0 +1,72 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/shared/infrastructure/testdb"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessGrantRepositoryImpl(t *testing.T) {
+	ctx := context.Background()
+	db := testdb.New(t)
+
+	repoID, _ := value_object.NewRepositoryID(uuid.NewString())
+	_, err := db.Exec(ctx, `INSERT INTO repositories (id, name, url) VALUES ($1, $2, $3);`,
+		repoID.String(), "runbooks", "https://github.com/example/runbooks")
+	require.NoError(t, err)
+
+	doc, err := entity.NewDocument(value_object.GenerateDocumentID(), repoID, "owner-1", value_object.AccessScopePrivate)
+	require.NoError(t, err)
+	filePath, _ := value_object.NewFilePath("prod/failover.md")
+	commitHash, _ := value_object.NewCommitHash("abc1234def")
+	source, _ := value_object.NewDocumentSource(filePath, commitHash)
+	require.NoError(t, doc.Publish(source, "Failover", value_object.DocumentTypeProcedure, nil, nil, "# Failover"))
+	require.NoError(t, NewDocumentRepositoryImpl(db).Save(ctx, doc))
+
+	repo := NewAccessGrantRepositoryImpl(db)
+
+	t.Run("保存したACLエントリをドキュメントとプリンシパルで取得できる", func(t *testing.T) {
+		groupGrant, _ := entity.NewAccessGrant(doc.ID(), value_object.PrincipalTypeGroup, "group-sre", value_object.AccessLevelView, "owner-1")
+		userGrant, _ := entity.NewAccessGrant(doc.ID(), value_object.PrincipalTypeUser, "user-1", value_object.AccessLevelExecute, "owner-1")
+		require.NoError(t, repo.Save(ctx, groupGrant))
+		require.NoError(t, repo.Save(ctx, userGrant))
+
+		byDoc, err := repo.FindByDocumentID(ctx, doc.ID())
+		require.NoError(t, err)
+		assert.Len(t, byDoc, 2)
+
+		byGroup, err := repo.FindByPrincipals(ctx, "user-2", []string{"group-sre"})
+		require.NoError(t, err)
+		require.Len(t, byGroup, 1)
+		assert.Equal(t, value_object.AccessLevelView, byGroup[0].Level())
+
+		none, err := repo.FindByPrincipals(ctx, "user-2", nil)
+		require.NoError(t, err)
+		assert.Empty(t, none)
+	})
+
+	t.Run("同じプリンシパルへの再付与でレベルが置き換わる", func(t *testing.T) {
+		upgraded, _ := entity.NewAccessGrant(doc.ID(), value_object.PrincipalTypeGroup, "group-sre", value_object.AccessLevelExecute, "owner-1")
+		require.NoError(t, repo.Save(ctx, upgraded))
+
+		grants, err := repo.FindByPrincipals(ctx, "", []string{"group-sre"})
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, value_object.AccessLevelExecute, grants[0].Level())
+	})
+
+	t.Run("削除したACLエントリは取得されない", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, doc.ID(), value_object.PrincipalTypeUser, "user-1"))
+
+		grants, err := repo.FindByPrincipals(ctx, "user-1", nil)
+		require.NoError(t, err)
+		assert.Empty(t, grants)
+	})
+}
@@ -0,0 +1,623 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"opscore/backend/internal/document/domain/entity"
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// DocumentRepositoryImpl is a PostgreSQL implementation of the DocumentRepository interface.
+// A document and its versions are treated as one aggregate and written in a single transaction.
+type DocumentRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewDocumentRepositoryImpl creates a new DocumentRepositoryImpl
+func NewDocumentRepositoryImpl(db *pgxpool.Pool) repository.DocumentRepository {
+	return &DocumentRepositoryImpl{db: db}
+}
+
+// variableDefinitionRecord is the JSONB representation of a VariableDefinition
+type variableDefinitionRecord struct {
+	Name         string      `json:"name"`
+	Label        string      `json:"label"`
+	Description  string      `json:"description,omitempty"`
+	Type         string      `json:"type"`
+	Required     bool        `json:"required"`
+	DefaultValue interface{} `json:"default_value,omitempty"`
+}
+
+const selectDocumentColumns = `
+	SELECT id, repository_id, owner, is_published, is_auto_update, access_scope,
+		pending_commit_hash, current_version_id, created_at, updated_at
+	FROM documents
+`
+
+const selectVersionColumns = `
+	SELECT id, document_id, version_number, file_path, commit_hash, title, doc_type,
+		tags, variables, content, published_at, unpublished_at
+	FROM document_versions
+`
+
+// Save creates a new document together with all of its versions
+func (r *DocumentRepositoryImpl) Save(ctx context.Context, document entity.Document) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO documents (id, repository_id, owner, is_published, is_auto_update, access_scope, pending_commit_hash, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);
+	`
+	_, err = tx.Exec(ctx, query,
+		document.ID().String(),
+		document.RepositoryID().String(),
+		document.Owner(),
+		document.IsPublished(),
+		document.IsAutoUpdate(),
+		document.AccessScope().String(),
+		document.PendingCommitHash().String(),
+		document.CreatedAt(),
+		document.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save document: %w", err)
+	}
+
+	if err := r.saveVersions(ctx, tx, document); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing document and upserts its versions
+func (r *DocumentRepositoryImpl) Update(ctx context.Context, document entity.Document) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE documents
+		SET owner = $1, is_published = $2, is_auto_update = $3, access_scope = $4, pending_commit_hash = $5, updated_at = $6
+		WHERE id = $7;
+	`
+	result, err := tx.Exec(ctx, query,
+		document.Owner(),
+		document.IsPublished(),
+		document.IsAutoUpdate(),
+		document.AccessScope().String(),
+		document.PendingCommitHash().String(),
+		document.UpdatedAt(),
+		document.ID().String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update document: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("document with ID %s not found", document.ID().String())
+	}
+
+	if err := r.saveVersions(ctx, tx, document); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// saveVersions upserts every version of the document and points current_version_id at the current one
+func (r *DocumentRepositoryImpl) saveVersions(ctx context.Context, tx pgx.Tx, document entity.Document) error {
+	versions := document.Versions()
+	if len(versions) > 0 {
+		batch := &pgx.Batch{}
+		for _, v := range versions {
+			query, args, err := upsertVersionQuery(v)
+			if err != nil {
+				return err
+			}
+			batch.Queue(query, args...)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+		for range versions {
+			if _, err := results.Exec(); err != nil {
+				results.Close()
+				return fmt.Errorf("failed to save document version: %w", err)
+			}
+		}
+		if err := results.Close(); err != nil {
+			return fmt.Errorf("failed to save document versions: %w", err)
+		}
+	}
+
+	var currentVersionID *string
+	if current := document.CurrentVersion(); current != nil {
+		id := current.ID().String()
+		currentVersionID = &id
+	}
+
+	_, err := tx.Exec(ctx, `UPDATE documents SET current_version_id = $1 WHERE id = $2;`,
+		currentVersionID, document.ID().String())
+	if err != nil {
+		return fmt.Errorf("failed to update current version: %w", err)
+	}
+
+	return nil
+}
+
+// upsertVersionQuery builds the statement that inserts a version or refreshes its mutable columns.
+// Everything except the unpublished timestamp is immutable once a version has been written.
+func upsertVersionQuery(version entity.DocumentVersion) (string, []interface{}, error) {
+	variables, err := marshalVariables(version.Variables())
+	if err != nil {
+		return "", nil, err
+	}
+
+	query := `
+		INSERT INTO document_versions (id, document_id, version_number, file_path, commit_hash, title, doc_type,
+			tags, variables, content, published_at, unpublished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+		ON CONFLICT (id) DO UPDATE SET
+			unpublished_at = EXCLUDED.unpublished_at;
+	`
+	args := []interface{}{
+		version.ID().String(),
+		version.DocumentID().String(),
+		version.VersionNumber().Int(),
+		version.Source().FilePath().String(),
+		version.Source().CommitHash().String(),
+		version.Title(),
+		version.Type().String(),
+		tagsToStrings(version.Tags()),
+		variables,
+		version.Content(),
+		version.PublishedAt(),
+		version.UnpublishedAt(),
+	}
+
+	return query, args, nil
+}
+
+// FindByID retrieves a document by its ID
+func (r *DocumentRepositoryImpl) FindByID(ctx context.Context, id value_object.DocumentID) (entity.Document, error) {
+	row := r.db.QueryRow(ctx, selectDocumentColumns+` WHERE id = $1;`, id.String())
+
+	rec, err := scanDocumentRow(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find document by ID: %w", err)
+	}
+
+	return r.loadDocument(ctx, rec)
+}
+
+// FindByRepositoryID retrieves all documents for a given repository
+func (r *DocumentRepositoryImpl) FindByRepositoryID(ctx context.Context, repoID value_object.RepositoryID) ([]entity.Document, error) {
+	return r.findDocuments(ctx, selectDocumentColumns+` WHERE repository_id = $1 ORDER BY created_at DESC;`, repoID.String())
+}
+
+// FindPublished retrieves published documents matching all of the given filters.
+func (r *DocumentRepositoryImpl) FindPublished(ctx context.Context, filters ...repository.Filter) ([]entity.Document, error) {
+	conditions := []string{"is_published = true"}
+	var args []interface{}
+	for _, f := range filters {
+		ctx = f.Apply(ctx)
+
+		switch f := f.(type) {
+		case repository.RepositoryFilter:
+			args = append(args, f.RepositoryID.String())
+			conditions = append(conditions, fmt.Sprintf("repository_id = $%d", len(args)))
+		case repository.TypeFilter:
+			args = append(args, f.Type.String())
+			conditions = append(conditions, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM document_versions v WHERE v.id = documents.current_version_id AND v.doc_type = $%d)", len(args)))
+		case repository.TagFilter:
+			args = append(args, f.Tag.String())
+			conditions = append(conditions, fmt.Sprintf(
+				"EXISTS (SELECT 1 FROM document_versions v WHERE v.id = documents.current_version_id AND $%d = ANY(v.tags))", len(args)))
+		default:
+			return nil, fmt.Errorf("unsupported document filter %T", f)
+		}
+	}
+
+	query := selectDocumentColumns + ` WHERE ` + strings.Join(conditions, " AND ") + ` ORDER BY updated_at DESC;`
+	return r.findDocuments(ctx, query, args...)
+}
+
+// Delete deletes a document and its versions
+func (r *DocumentRepositoryImpl) Delete(ctx context.Context, id value_object.DocumentID) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Break the documents -> document_versions reference before the cascade removes the versions
+	_, err = tx.Exec(ctx, `UPDATE documents SET current_version_id = NULL WHERE id = $1;`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to clear current version: %w", err)
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM documents WHERE id = $1;`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete document: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// SaveVersion saves a single document version
+func (r *DocumentRepositoryImpl) SaveVersion(ctx context.Context, version entity.DocumentVersion) error {
+	query, args, err := upsertVersionQuery(version)
+	if err != nil {
+		return err
+	}
+
+	if _, err := r.db.Exec(ctx, query, args...); err != nil {
+		return fmt.Errorf("failed to save document version: %w", err)
+	}
+
+	return nil
+}
+
+// FindVersionsByDocumentID retrieves all versions for a given document ordered by version number
+func (r *DocumentRepositoryImpl) FindVersionsByDocumentID(ctx context.Context, docID value_object.DocumentID) ([]entity.DocumentVersion, error) {
+	currentVersionID, err := r.currentVersionID(ctx, docID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	return r.findVersions(ctx, docID.String(), currentVersionID)
+}
+
+// FindVersionByNumber retrieves a specific version by document ID and version number
+func (r *DocumentRepositoryImpl) FindVersionByNumber(ctx context.Context, docID value_object.DocumentID, versionNumber value_object.VersionNumber) (entity.DocumentVersion, error) {
+	currentVersionID, err := r.currentVersionID(ctx, docID.String())
+	if err != nil {
+		return nil, err
+	}
+
+	row := r.db.QueryRow(ctx, selectVersionColumns+` WHERE document_id = $1 AND version_number = $2;`,
+		docID.String(), versionNumber.Int())
+
+	version, err := scanVersionRow(row, currentVersionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find document version: %w", err)
+	}
+
+	return version, nil
+}
+
+// currentVersionID returns the current version ID of a document, or an empty string if it has none
+func (r *DocumentRepositoryImpl) currentVersionID(ctx context.Context, docID string) (string, error) {
+	var currentVersionID *string
+	err := r.db.QueryRow(ctx, `SELECT current_version_id FROM documents WHERE id = $1;`, docID).Scan(&currentVersionID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", nil
+		}
+		return "", fmt.Errorf("failed to find current version: %w", err)
+	}
+
+	if currentVersionID == nil {
+		return "", nil
+	}
+	return *currentVersionID, nil
+}
+
+// documentRecord holds a scanned documents row
+type documentRecord struct {
+	id               string
+	repositoryID     string
+	owner            string
+	isPublished      bool
+	isAutoUpdate     bool
+	accessScope      string
+	pendingCommit    string
+	currentVersionID *string
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// findDocuments runs a documents query and loads the versions of every row
+func (r *DocumentRepositoryImpl) findDocuments(ctx context.Context, query string, args ...interface{}) ([]entity.Document, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query documents: %w", err)
+	}
+
+	var records []documentRecord
+	for rows.Next() {
+		rec, err := scanDocumentRow(rows)
+		if err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan document row: %w", err)
+		}
+		records = append(records, rec)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over document rows: %w", err)
+	}
+
+	var documents []entity.Document
+	for _, rec := range records {
+		doc, err := r.loadDocument(ctx, rec)
+		if err != nil {
+			return nil, err
+		}
+		documents = append(documents, doc)
+	}
+
+	return documents, nil
+}
+
+// loadDocument loads the versions of a document row and reconstructs the aggregate
+func (r *DocumentRepositoryImpl) loadDocument(ctx context.Context, rec documentRecord) (entity.Document, error) {
+	currentVersionID := ""
+	if rec.currentVersionID != nil {
+		currentVersionID = *rec.currentVersionID
+	}
+
+	versions, err := r.findVersions(ctx, rec.id, currentVersionID)
+	if err != nil {
+		return nil, err
+	}
+
+	var currentVersion entity.DocumentVersion
+	for _, v := range versions {
+		if v.IsCurrentVersion() {
+			currentVersion = v
+		}
+	}
+	if versions == nil {
+		versions = []entity.DocumentVersion{}
+	}
+
+	id, err := value_object.NewDocumentID(rec.id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+	repoID, err := value_object.NewRepositoryID(rec.repositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid repository ID: %w", err)
+	}
+	scope, err := value_object.NewAccessScope(rec.accessScope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access scope: %w", err)
+	}
+
+	return entity.ReconstructDocument(
+		id,
+		repoID,
+		rec.owner,
+		rec.isPublished,
+		rec.isAutoUpdate,
+		scope,
+		value_object.CommitHash(rec.pendingCommit),
+		currentVersion,
+		versions,
+		rec.createdAt,
+		rec.updatedAt,
+	), nil
+}
+
+// findVersions retrieves the versions of a document, flagging the one matching currentVersionID as current
+func (r *DocumentRepositoryImpl) findVersions(ctx context.Context, docID string, currentVersionID string) ([]entity.DocumentVersion, error) {
+	rows, err := r.db.Query(ctx, selectVersionColumns+` WHERE document_id = $1 ORDER BY version_number;`, docID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query document versions: %w", err)
+	}
+	defer rows.Close()
+
+	var versions []entity.DocumentVersion
+	for rows.Next() {
+		version, err := scanVersionRow(rows, currentVersionID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan document version row: %w", err)
+		}
+		versions = append(versions, version)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over document version rows: %w", err)
+	}
+
+	return versions, nil
+}
+
+// scanDocumentRow scans a row selected with selectDocumentColumns
+func scanDocumentRow(row pgx.Row) (documentRecord, error) {
+	var rec documentRecord
+	err := row.Scan(
+		&rec.id,
+		&rec.repositoryID,
+		&rec.owner,
+		&rec.isPublished,
+		&rec.isAutoUpdate,
+		&rec.accessScope,
+		&rec.pendingCommit,
+		&rec.currentVersionID,
+		&rec.createdAt,
+		&rec.updatedAt,
+	)
+	return rec, err
+}
+
+// scanVersionRow scans a row selected with selectVersionColumns into a domain entity
+func scanVersionRow(row pgx.Row, currentVersionID string) (entity.DocumentVersion, error) {
+	var (
+		id, documentID, filePath, commitHash, title, docType, content string
+		versionNumber                                                 int
+		tags                                                          []string
+		variables                                                     []byte
+		publishedAt                                                   time.Time
+		unpublishedAt                                                 *time.Time
+	)
+
+	err := row.Scan(
+		&id,
+		&documentID,
+		&versionNumber,
+		&filePath,
+		&commitHash,
+		&title,
+		&docType,
+		&tags,
+		&variables,
+		&content,
+		&publishedAt,
+		&unpublishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	versionID, err := value_object.NewVersionID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version ID: %w", err)
+	}
+	docID, err := value_object.NewDocumentID(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+	verNum, err := value_object.NewVersionNumber(versionNumber)
+	if err != nil {
+		return nil, fmt.Errorf("invalid version number: %w", err)
+	}
+	fp, err := value_object.NewFilePath(filePath)
+	if err != nil {
+		return nil, fmt.Errorf("invalid file path: %w", err)
+	}
+	ch, err := value_object.NewCommitHash(commitHash)
+	if err != nil {
+		return nil, fmt.Errorf("invalid commit hash: %w", err)
+	}
+	source, err := value_object.NewDocumentSource(fp, ch)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document source: %w", err)
+	}
+	dt, err := value_object.NewDocumentType(docType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document type: %w", err)
+	}
+
+	tagVOs := make([]value_object.Tag, 0, len(tags))
+	for _, t := range tags {
+		tag, err := value_object.NewTag(t)
+		if err != nil {
+			return nil, fmt.Errorf("invalid tag: %w", err)
+		}
+		tagVOs = append(tagVOs, tag)
+	}
+
+	vars, err := unmarshalVariables(variables)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructDocumentVersion(
+		versionID,
+		docID,
+		verNum,
+		source,
+		title,
+		dt,
+		tagVOs,
+		vars,
+		content,
+		publishedAt,
+		unpublishedAt,
+		id == currentVersionID,
+	), nil
+}
+
+// tagsToStrings converts tags to a TEXT[] friendly slice
+func tagsToStrings(tags []value_object.Tag) []string {
+	result := make([]string, len(tags))
+	for i, t := range tags {
+		result[i] = t.String()
+	}
+	return result
+}
+
+// marshalVariables encodes variable definitions for the JSONB column
+func marshalVariables(variables []value_object.VariableDefinition) ([]byte, error) {
+	records := make([]variableDefinitionRecord, len(variables))
+	for i, v := range variables {
+		records[i] = variableDefinitionRecord{
+			Name:         v.Name(),
+			Label:        v.Label(),
+			Description:  v.Description(),
+			Type:         v.Type().String(),
+			Required:     v.Required(),
+			DefaultValue: v.DefaultValue(),
+		}
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variables: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalVariables decodes variable definitions from the JSONB column
+func unmarshalVariables(data []byte) ([]value_object.VariableDefinition, error) {
+	if len(data) == 0 {
+		return []value_object.VariableDefinition{}, nil
+	}
+
+	var records []variableDefinitionRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variables: %w", err)
+	}
+
+	variables := make([]value_object.VariableDefinition, 0, len(records))
+	for _, rec := range records {
+		varType, err := value_object.NewVariableType(rec.Type)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variable type: %w", err)
+		}
+		v, err := value_object.NewVariableDefinition(rec.Name, rec.Label, rec.Description, varType, rec.Required, rec.DefaultValue)
+		if err != nil {
+			return nil, fmt.Errorf("invalid variable definition: %w", err)
+		}
+		variables = append(variables, v)
+	}
+
+	return variables, nil
+}
@@ -0,0 +1,29 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+
+	"opscore/backend/internal/document/domain/repository"
+	"opscore/backend/internal/document/domain/repository/repositorytest"
+	"opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/shared/infrastructure/testdb"
+
+	"github.com/stretchr/testify/require"
+)
+
+// TestDocumentRepositoryImpl_Contract runs the shared DocumentRepository contract against PostgreSQL
+func TestDocumentRepositoryImpl_Contract(t *testing.T) {
+	repositorytest.RunDocumentRepositoryContract(t, func(t *testing.T, repositoryIDs ...value_object.RepositoryID) repository.DocumentRepository {
+		db := testdb.New(t)
+
+		for _, id := range repositoryIDs {
+			_, err := db.Exec(context.Background(),
+				`INSERT INTO repositories (id, name, url) VALUES ($1, $2, $3);`,
+				id.String(), "repo-"+id.String(), "https://github.com/example/"+id.String())
+			require.NoError(t, err)
+		}
+
+		return NewDocumentRepositoryImpl(db)
+	})
+}
@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"net/http"
+
+	"opscore/backend/internal/document/application/dto"
+	"opscore/backend/internal/document/application/usecase"
+	"opscore/backend/internal/document/interfaces/api/schema"
+	intererror "opscore/backend/internal/document/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AccessGrantHandler holds dependencies for document ACL handlers.
+type AccessGrantHandler struct {
+	grantUseCase usecase.AccessGrantUseCase
+	logger       Logger
+}
+
+// NewAccessGrantHandler creates a new AccessGrantHandler.
+func NewAccessGrantHandler(uc usecase.AccessGrantUseCase, logger Logger) *AccessGrantHandler {
+	return &AccessGrantHandler{
+		grantUseCase: uc,
+		logger:       logger,
+	}
+}
+
+// ListAccessGrants godoc
+// @Summary List document ACL entries
+// @Description Retrieves the users and groups granted access to a document. Only the owner and holders of the user:admin or document:publish permission may list them.
+// @Tags documents
+// @Produce json
+// @Param docId path string true "Document ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Success 200 {object} schema.ListAccessGrantsResponse "Successfully retrieved ACL entries"
+// @Failure 400 {object} schema.ErrorResponse "Invalid document ID format"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Document not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /documents/{docId}/access [get]
+func (h *AccessGrantHandler) ListAccessGrants(c *gin.Context) {
+	docID := c.Param("docId")
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Listing document access grants", "request_id", requestID, "doc_id", docID)
+	result, err := h.grantUseCase.ListGrants(c.Request.Context(), callerFromContext(c), docID)
+
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to list document access grants", "request_id", requestID, "doc_id", docID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message, Details: httpErr.Details})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.ListAccessGrantsResponse{Grants: schema.FromAccessGrantListDTO(result)})
+}
+
+// GrantAccess godoc
+// @Summary Grant access to a document
+// @Description Grants a user or group view or execute access to a document, replacing any existing level. Only the owner and holders of the user:admin or document:publish permission may grant access.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param docId path string true "Document ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param principalType path string true "Principal type" Enums(user, group)
+// @Param principalId path string true "User ID or group ID" example:"sre"
+// @Param grant body schema.GrantAccessRequest true "Access level"
+// @Success 200 {object} schema.AccessGrantResponse "Access granted successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Document not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /documents/{docId}/access/{principalType}/{principalId} [put]
+func (h *AccessGrantHandler) GrantAccess(c *gin.Context) {
+	docID := c.Param("docId")
+	requestID := c.GetString("request_id")
+
+	var req schema.GrantAccessRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "doc_id", docID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request format"})
+		return
+	}
+
+	dtoReq := dto.GrantAccessRequest{
+		PrincipalType: c.Param("principalType"),
+		PrincipalID:   c.Param("principalId"),
+		Level:         req.Level,
+	}
+
+	h.logger.Info("Granting document access", "request_id", requestID, "doc_id", docID, "principal_type", dtoReq.PrincipalType, "principal_id", dtoReq.PrincipalID, "level", dtoReq.Level)
+	result, err := h.grantUseCase.GrantAccess(c.Request.Context(), callerFromContext(c), docID, &dtoReq)
+
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to grant document access", "request_id", requestID, "doc_id", docID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message, Details: httpErr.Details})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.FromAccessGrantDTO(*result))
+}
+
+// RevokeAccess godoc
+// @Summary Revoke access to a document
+// @Description Removes the ACL entry of a user or group from a document. Only the owner and holders of the user:admin or document:publish permission may revoke access.
+// @Tags documents
+// @Param docId path string true "Document ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param principalType path string true "Principal type" Enums(user, group)
+// @Param principalId path string true "User ID or group ID" example:"sre"
+// @Success 204 "Access revoked successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Document not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /documents/{docId}/access/{principalType}/{principalId} [delete]
+func (h *AccessGrantHandler) RevokeAccess(c *gin.Context) {
+	docID := c.Param("docId")
+	principalType := c.Param("principalType")
+	principalID := c.Param("principalId")
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Revoking document access", "request_id", requestID, "doc_id", docID, "principal_type", principalType, "principal_id", principalID)
+	err := h.grantUseCase.RevokeAccess(c.Request.Context(), callerFromContext(c), docID, principalType, principalID)
+
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to revoke document access", "request_id", requestID, "doc_id", docID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message, Details: httpErr.Details})
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
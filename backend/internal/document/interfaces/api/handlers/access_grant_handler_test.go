@@ -0,0 +1,106 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/application/usecase"
+	"opscore/backend/internal/document/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupAccessGrantTest() (*usecase.MockAccessGrantUseCase, *AccessGrantHandler, *gin.Engine, *httptest.ResponseRecorder) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(usecase.MockAccessGrantUseCase)
+	mockLogger := new(MockLogger)
+	mockLogger.On("Info", mock.AnythingOfType("string"), mock.Anything).Maybe()
+	mockLogger.On("Error", mock.AnythingOfType("string"), mock.Anything).Maybe()
+
+	handler := NewAccessGrantHandler(mockUseCase, mockLogger)
+
+	router := gin.New()
+	router.Use(func(c *gin.Context) {
+		c.Set("request_id", "test-request-id")
+		c.Set("user_id", "owner-1")
+		c.Set("user_role", "user")
+		c.Set("user_group_ids", []string{"sre"})
+		c.Next()
+	})
+
+	return mockUseCase, handler, router, httptest.NewRecorder()
+}
+
+func TestAccessGrantHandler_GrantAccess(t *testing.T) {
+	t.Run("認証済みユーザーとしてアクセス権を付与できる", func(t *testing.T) {
+		mockUseCase, handler, router, rec := setupAccessGrantTest()
+
+		caller := dto.Caller{UserID: "owner-1", GroupIDs: []string{"sre"}}
+		expectedReq := &dto.GrantAccessRequest{PrincipalType: "group", PrincipalID: "sre", Level: "view"}
+		mockUseCase.On("GrantAccess", mock.Anything, caller, "doc-1", expectedReq).Return(&dto.AccessGrantResponse{
+			DocumentID:    "doc-1",
+			PrincipalType: "group",
+			PrincipalID:   "sre",
+			Level:         "view",
+			GrantedBy:     "owner-1",
+			GrantedAt:     time.Now(),
+		}, nil)
+
+		router.PUT("/documents/:docId/access/:principalType/:principalId", handler.GrantAccess)
+
+		body, _ := json.Marshal(schema.GrantAccessRequest{Level: "view"})
+		req, _ := http.NewRequest("PUT", "/documents/doc-1/access/group/sre", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response schema.AccessGrantResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+		assert.Equal(t, "sre", response.PrincipalID)
+		assert.Equal(t, "view", response.Level)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("所有者以外はForbiddenになる", func(t *testing.T) {
+		mockUseCase, handler, router, rec := setupAccessGrantTest()
+
+		mockUseCase.On("GrantAccess", mock.Anything, mock.Anything, "doc-1", mock.Anything).
+			Return(nil, apperror.NewForbiddenError("Document doc-1", "grant access", "owner-1"))
+
+		router.PUT("/documents/:docId/access/:principalType/:principalId", handler.GrantAccess)
+
+		req, _ := http.NewRequest("PUT", "/documents/doc-1/access/user/user-2", bytes.NewBufferString(`{"level":"execute"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusForbidden, rec.Code)
+	})
+}
+
+func TestAccessGrantHandler_RevokeAccess(t *testing.T) {
+	t.Run("アクセス権を取り消せる", func(t *testing.T) {
+		mockUseCase, handler, router, rec := setupAccessGrantTest()
+
+		mockUseCase.On("RevokeAccess", mock.Anything, mock.Anything, "doc-1", "group", "sre").Return(nil)
+
+		router.DELETE("/documents/:docId/access/:principalType/:principalId", handler.RevokeAccess)
+
+		req, _ := http.NewRequest("DELETE", "/documents/doc-1/access/group/sre", nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
@@ -0,0 +1,15 @@
+package handlers
+
+import (
+	"opscore/backend/internal/document/application/dto"
+
+	"github.com/gin-gonic/gin"
+)
+
+// callerFromContext builds the caller identity stored in the gin context by the authentication middleware
+func callerFromContext(c *gin.Context) dto.Caller {
+	return dto.Caller{
+		UserID:   c.GetString("user_id"),
+		GroupIDs: c.GetStringSlice("user_group_ids"),
+	}
+}
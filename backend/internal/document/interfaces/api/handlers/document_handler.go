@@ -94,7 +94,7 @@ func (h *DocumentHandler) GetDocument(c *gin.Context) {
 	}
 
 	h.logger.Info("Getting document details", "request_id", requestID, "doc_id", docID)
-	result, err := h.docUseCase.GetDocument(c.Request.Context(), docID)
+	result, err := h.docUseCase.GetDocument(c.Request.Context(), callerFromContext(c), docID)
 
 	if err != nil {
 		httpErr := intererror.MapToHTTPError(err, requestID)
@@ -158,7 +158,7 @@ func (h *DocumentHandler) UpdateDocument(c *gin.Context) {
 
 // ListDocuments godoc
 // @Summary List all documents
-// @Description Retrieves a list of all published documents
+// @Description Retrieves a list of all published documents the caller may view
 // @Tags documents
 // @Produce json
 // @Param repository_id query string false "Filter by repository ID"
@@ -175,14 +175,14 @@ func (h *DocumentHandler) ListDocuments(c *gin.Context) {
 	var err error
 
 	if repositoryID != "" {
-		dtoResp, e := h.docUseCase.ListDocumentsByRepository(c.Request.Context(), repositoryID)
+		dtoResp, e := h.docUseCase.ListDocumentsByRepository(c.Request.Context(), callerFromContext(c), repositoryID)
 		if e != nil {
 			err = e
 		} else {
 			docs = schema.FromDocumentListDTO(dtoResp)
 		}
 	} else {
-		dtoResp, e := h.docUseCase.ListDocuments(c.Request.Context())
+		dtoResp, e := h.docUseCase.ListDocuments(c.Request.Context(), callerFromContext(c))
 		if e != nil {
 			err = e
 		} else {
@@ -223,7 +223,7 @@ func (h *DocumentHandler) GetDocumentVersions(c *gin.Context) {
 	}
 
 	h.logger.Info("Getting document versions", "request_id", requestID, "doc_id", docID)
-	result, err := h.docUseCase.GetDocumentVersions(c.Request.Context(), docID)
+	result, err := h.docUseCase.GetDocumentVersions(c.Request.Context(), callerFromContext(c), docID)
 
 	if err != nil {
 		httpErr := intererror.MapToHTTPError(err, requestID)
@@ -268,7 +268,7 @@ func (h *DocumentHandler) GetDocumentVersion(c *gin.Context) {
 	}
 
 	h.logger.Info("Getting document version", "request_id", requestID, "doc_id", docID, "version", versionNumber)
-	result, err := h.docUseCase.GetDocumentVersion(c.Request.Context(), docID, versionNumber)
+	result, err := h.docUseCase.GetDocumentVersion(c.Request.Context(), callerFromContext(c), docID, versionNumber)
 
 	if err != nil {
 		httpErr := intererror.MapToHTTPError(err, requestID)
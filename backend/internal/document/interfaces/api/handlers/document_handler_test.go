@@ -140,7 +140,7 @@ func TestDocumentHandler_GetDocument(t *testing.T) {
 			UpdatedAt:    now,
 		}
 
-		mockUseCase.On("GetDocument", mock.Anything, docID).Return(mockResponse, nil)
+		mockUseCase.On("GetDocument", mock.Anything, mock.Anything, docID).Return(mockResponse, nil)
 
 		router.GET("/documents/:docId", handler.GetDocument)
 
@@ -161,7 +161,7 @@ func TestDocumentHandler_GetDocument(t *testing.T) {
 		mockUseCase, _, handler, router, rec := setupDocumentTest()
 
 		docID := "nonexistent-uuid-1234-5678-abcdefabcdef"
-		mockUseCase.On("GetDocument", mock.Anything, docID).Return(nil, &mockNotFoundError{})
+		mockUseCase.On("GetDocument", mock.Anything, mock.Anything, docID).Return(nil, &mockNotFoundError{})
 
 		router.GET("/documents/:docId", handler.GetDocument)
 
@@ -206,7 +206,7 @@ func TestDocumentHandler_ListDocuments(t *testing.T) {
 			},
 		}
 
-		mockUseCase.On("ListDocuments", mock.Anything).Return(mockResponse, nil)
+		mockUseCase.On("ListDocuments", mock.Anything, mock.Anything).Return(mockResponse, nil)
 
 		router.GET("/documents", handler.ListDocuments)
 
@@ -229,7 +229,7 @@ func TestDocumentHandler_ListDocuments(t *testing.T) {
 		repoID := "repo-uuid-1234"
 		mockResponse := []dto.DocumentListItemResponse{}
 
-		mockUseCase.On("ListDocumentsByRepository", mock.Anything, repoID).Return(mockResponse, nil)
+		mockUseCase.On("ListDocumentsByRepository", mock.Anything, mock.Anything, repoID).Return(mockResponse, nil)
 
 		router.GET("/documents", handler.ListDocuments)
 
@@ -278,7 +278,7 @@ func TestDocumentHandler_GetDocumentVersions(t *testing.T) {
 			},
 		}
 
-		mockUseCase.On("GetDocumentVersions", mock.Anything, docID).Return(mockResponse, nil)
+		mockUseCase.On("GetDocumentVersions", mock.Anything, mock.Anything, docID).Return(mockResponse, nil)
 
 		router.GET("/documents/:docId/versions", handler.GetDocumentVersions)
 
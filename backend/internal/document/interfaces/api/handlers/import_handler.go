@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+
+	"opscore/backend/internal/document/application/dto"
+	"opscore/backend/internal/document/application/usecase"
+	"opscore/backend/internal/document/interfaces/api/schema"
+	intererror "opscore/backend/internal/document/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ImportHandler holds dependencies for document import handlers
+type ImportHandler struct {
+	importUseCase usecase.ImportUseCase
+	logger        Logger
+}
+
+// NewImportHandler creates a new ImportHandler
+func NewImportHandler(uc usecase.ImportUseCase, logger Logger) *ImportHandler {
+	return &ImportHandler{
+		importUseCase: uc,
+		logger:        logger,
+	}
+}
+
+// ImportDocuments godoc
+// @Summary Import documents from repository frontmatter
+// @Description Creates or updates a document for every managed Markdown file of the repository from its YAML frontmatter.
+// @Description Files that cannot be imported are reported with status "failed" and their validation errors; the request itself still succeeds.
+// @Tags documents
+// @Accept json
+// @Produce json
+// @Param repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param import body schema.ImportDocumentsRequest false "Import options"
+// @Success 200 {object} schema.ImportResultResponse "Import result for each managed file"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or repository ID"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/documents/import [post]
+func (h *ImportHandler) ImportDocuments(c *gin.Context) {
+	repoID := c.Param("repoId")
+	requestID := c.GetString("request_id")
+	var req schema.ImportDocumentsRequest
+
+	if repoID == "" {
+		h.logger.Warn("Missing repository ID", "request_id", requestID)
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_ID", Message: "Repository ID is required"})
+		return
+	}
+
+	// The body is optional
+	if c.Request.ContentLength != 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			h.logger.Error("Invalid request body", "request_id", requestID, "repo_id", repoID, "error", err.Error())
+			c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request format"})
+			return
+		}
+	}
+
+	h.logger.Info("Importing documents", "request_id", requestID, "repo_id", repoID)
+	result, err := h.importUseCase.ImportFromRepository(c.Request.Context(), &dto.ImportDocumentsRequest{
+		RepositoryID: repoID,
+		Owner:        callerFromContext(c).UserID,
+		AccessScope:  req.AccessScope,
+	})
+
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to import documents", "request_id", requestID, "repo_id", repoID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message, Details: httpErr.Details})
+		return
+	}
+
+	h.logger.Info("Documents imported", "request_id", requestID, "repo_id", repoID, "files", len(result.Files))
+	c.JSON(http.StatusOK, schema.FromImportResultDTO(*result))
+}
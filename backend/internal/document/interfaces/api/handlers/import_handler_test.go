@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opscore/backend/internal/document/application/dto"
+	apperror "opscore/backend/internal/document/application/error"
+	"opscore/backend/internal/document/application/usecase"
+	"opscore/backend/internal/document/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportHandler_ImportDocuments(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	repoID := "a1b2c3d4-e5f6-7890-1234-567890abcdef"
+
+	newContext := func(body string) (*gin.Context, *httptest.ResponseRecorder) {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Params = gin.Params{{Key: "repoId", Value: repoID}}
+		c.Request = httptest.NewRequest("POST", "/api/v1/repositories/"+repoID+"/documents/import", bytes.NewBufferString(body))
+		c.Request.Header.Set("Content-Type", "application/json")
+		c.Set("user_id", "user-1")
+		return c, w
+	}
+
+	t.Run("ファイルごとの取り込み結果を返す", func(t *testing.T) {
+		mockUseCase := new(usecase.MockImportUseCase)
+		mockLogger := new(MockLogger)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+		handler := NewImportHandler(mockUseCase, mockLogger)
+
+		expectedReq := &dto.ImportDocumentsRequest{RepositoryID: repoID, Owner: "user-1", AccessScope: "private"}
+		mockUseCase.On("ImportFromRepository", mock.Anything, expectedReq).Return(&dto.ImportResultResponse{
+			RepositoryID: repoID,
+			Files: []dto.ImportFileResult{
+				{FilePath: "docs/backup.md", Status: dto.ImportStatusCreated, DocumentID: "doc-1"},
+				{FilePath: "docs/invalid.md", Status: dto.ImportStatusFailed, Errors: []dto.ImportFileError{{Field: "type", Message: "invalid document type"}}},
+			},
+		}, nil)
+
+		c, w := newContext(`{"access_scope":"private"}`)
+		handler.ImportDocuments(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response schema.ImportResultResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, repoID, response.RepositoryID)
+		require.Len(t, response.Files, 2)
+		assert.Equal(t, "created", response.Files[0].Status)
+		assert.Equal(t, "doc-1", response.Files[0].DocumentID)
+		assert.Empty(t, response.Files[0].Errors)
+		assert.Equal(t, "failed", response.Files[1].Status)
+		assert.Equal(t, "type", response.Files[1].Errors[0].Field)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("リクエストボディは省略できる", func(t *testing.T) {
+		mockUseCase := new(usecase.MockImportUseCase)
+		mockLogger := new(MockLogger)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+		handler := NewImportHandler(mockUseCase, mockLogger)
+
+		expectedReq := &dto.ImportDocumentsRequest{RepositoryID: repoID, Owner: "user-1"}
+		mockUseCase.On("ImportFromRepository", mock.Anything, expectedReq).Return(&dto.ImportResultResponse{RepositoryID: repoID}, nil)
+
+		c, w := newContext("")
+		handler.ImportDocuments(c)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("不正なリクエストボディは400を返す", func(t *testing.T) {
+		mockUseCase := new(usecase.MockImportUseCase)
+		mockLogger := new(MockLogger)
+		mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+		handler := NewImportHandler(mockUseCase, mockLogger)
+
+		c, w := newContext(`{"access_scope":`)
+		handler.ImportDocuments(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUseCase.AssertNotCalled(t, "ImportFromRepository", mock.Anything, mock.Anything)
+	})
+
+	t.Run("バリデーションエラーは400を返す", func(t *testing.T) {
+		mockUseCase := new(usecase.MockImportUseCase)
+		mockLogger := new(MockLogger)
+		mockLogger.On("Info", mock.Anything, mock.Anything).Maybe()
+		mockLogger.On("Error", mock.Anything, mock.Anything).Maybe()
+		handler := NewImportHandler(mockUseCase, mockLogger)
+
+		mockUseCase.On("ImportFromRepository", mock.Anything, mock.Anything).Return(nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "access_scope", Message: "invalid access scope"},
+		}))
+
+		c, w := newContext(`{"access_scope":"everyone"}`)
+		handler.ImportDocuments(c)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
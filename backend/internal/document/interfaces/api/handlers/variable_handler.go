@@ -46,7 +46,7 @@ func (h *VariableHandler) GetVariableDefinitions(c *gin.Context) {
 	}
 
 	h.logger.Info("Getting variable definitions", "request_id", requestID, "doc_id", docID)
-	variables, err := h.varUseCase.GetVariableDefinitions(c.Request.Context(), docID)
+	variables, err := h.varUseCase.GetVariableDefinitions(c.Request.Context(), callerFromContext(c), docID)
 
 	if err != nil {
 		httpErr := intererror.MapToHTTPError(err, requestID)
@@ -92,6 +92,7 @@ func (h *VariableHandler) GetVariableDefinitions(c *gin.Context) {
 // @Param values body schema.ValidateVariableValuesRequest true "Variable values to validate"
 // @Success 200 {object} schema.ValidateVariableValuesResponse "Validation result (valid:true or valid:false with errors)"
 // @Failure 400 {object} schema.ErrorResponse "Invalid request body or document ID"
+// @Failure 403 {object} schema.ErrorResponse "Execute access required"
 // @Failure 404 {object} schema.ErrorResponse "Document not found"
 // @Failure 500 {object} schema.ErrorResponse "Internal server error"
 // @Router /documents/{docId}/validate-variables [post]
@@ -122,7 +123,7 @@ func (h *VariableHandler) ValidateVariableValues(c *gin.Context) {
 	}
 
 	h.logger.Info("Validating variable values", "request_id", requestID, "doc_id", docID, "value_count", len(values))
-	err := h.varUseCase.ValidateVariableValues(c.Request.Context(), docID, values)
+	err := h.varUseCase.ValidateVariableValues(c.Request.Context(), callerFromContext(c), docID, values)
 
 	if err != nil {
 		// Check if it's a validation error
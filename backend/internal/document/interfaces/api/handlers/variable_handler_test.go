@@ -42,7 +42,7 @@ func TestVariableHandler_GetVariableDefinitions(t *testing.T) {
 		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 
 		// Mock usecase call
-		mockUseCase.On("GetVariableDefinitions", mock.Anything, docID).Return(expectedVars, nil)
+		mockUseCase.On("GetVariableDefinitions", mock.Anything, mock.Anything, docID).Return(expectedVars, nil)
 
 		// Create request
 		w := httptest.NewRecorder()
@@ -106,7 +106,7 @@ func TestVariableHandler_ValidateVariableValues(t *testing.T) {
 		mockLogger.On("Info", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).Maybe()
 
 		// Mock usecase call - validation succeeds (returns nil)
-		mockUseCase.On("ValidateVariableValues", mock.Anything, docID, mock.AnythingOfType("[]usecase.VariableValue")).Return(nil)
+		mockUseCase.On("ValidateVariableValues", mock.Anything, mock.Anything, docID, mock.AnythingOfType("[]usecase.VariableValue")).Return(nil)
 
 		// Create request
 		body, _ := json.Marshal(reqBody)
@@ -175,7 +175,7 @@ func TestVariableHandler_ValidateVariableValues(t *testing.T) {
 		validationErr := apperror.NewValidationFailedError([]apperror.FieldError{
 			{Field: "server_name", Message: "Server Name is required"},
 		})
-		mockUseCase.On("ValidateVariableValues", mock.Anything, docID, mock.AnythingOfType("[]usecase.VariableValue")).Return(validationErr)
+		mockUseCase.On("ValidateVariableValues", mock.Anything, mock.Anything, docID, mock.AnythingOfType("[]usecase.VariableValue")).Return(validationErr)
 
 		// Create request
 		body, _ := json.Marshal(reqBody)
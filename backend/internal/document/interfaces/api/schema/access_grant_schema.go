@@ -0,0 +1,23 @@
+package schema
+
+import "time"
+
+// GrantAccessRequest represents the API request for granting a user or group access to a document
+type GrantAccessRequest struct {
+	Level string `json:"level" binding:"required" example:"view"` // "view" or "execute"
+}
+
+// AccessGrantResponse represents the API response for a document ACL entry
+type AccessGrantResponse struct {
+	DocumentID    string    `json:"document_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	PrincipalType string    `json:"principal_type" example:"group"`
+	PrincipalID   string    `json:"principal_id" example:"sre"`
+	Level         string    `json:"level" example:"view"`
+	GrantedBy     string    `json:"granted_by" example:"user-123"`
+	GrantedAt     time.Time `json:"granted_at" example:"2025-04-22T10:00:00Z"`
+}
+
+// ListAccessGrantsResponse represents the API response for listing the ACL entries of a document
+type ListAccessGrantsResponse struct {
+	Grants []AccessGrantResponse `json:"grants"`
+}
@@ -76,16 +76,18 @@ func FromDocumentDTO(dtoResp dto.DocumentResponse) DocumentResponse {
 	}
 
 	return DocumentResponse{
-		ID:             dtoResp.ID,
-		RepositoryID:   dtoResp.RepositoryID,
-		Owner:          dtoResp.Owner,
-		IsPublished:    dtoResp.IsPublished,
-		IsAutoUpdate:   dtoResp.IsAutoUpdate,
-		AccessScope:    dtoResp.AccessScope,
-		CurrentVersion: currentVersion,
-		VersionCount:   dtoResp.VersionCount,
-		CreatedAt:      dtoResp.CreatedAt,
-		UpdatedAt:      dtoResp.UpdatedAt,
+		ID:              dtoResp.ID,
+		RepositoryID:    dtoResp.RepositoryID,
+		Owner:           dtoResp.Owner,
+		IsPublished:     dtoResp.IsPublished,
+		IsAutoUpdate:    dtoResp.IsAutoUpdate,
+		AccessScope:     dtoResp.AccessScope,
+		UpdateAvailable: dtoResp.UpdateAvailable,
+		PendingCommit:   dtoResp.PendingCommit,
+		CurrentVersion:  currentVersion,
+		VersionCount:    dtoResp.VersionCount,
+		CreatedAt:       dtoResp.CreatedAt,
+		UpdatedAt:       dtoResp.UpdatedAt,
 	}
 }
 
@@ -123,16 +125,17 @@ func FromDocumentVersionDTO(dtoResp dto.DocumentVersionResponse) DocumentVersion
 // FromDocumentListItemDTO converts application DTO to API schema
 func FromDocumentListItemDTO(dtoResp dto.DocumentListItemResponse) DocumentListItemResponse {
 	return DocumentListItemResponse{
-		ID:           dtoResp.ID,
-		RepositoryID: dtoResp.RepositoryID,
-		Title:        dtoResp.Title,
-		Owner:        dtoResp.Owner,
-		DocType:      dtoResp.DocType,
-		Tags:         dtoResp.Tags,
-		IsPublished:  dtoResp.IsPublished,
-		VersionCount: dtoResp.VersionCount,
-		CreatedAt:    dtoResp.CreatedAt,
-		UpdatedAt:    dtoResp.UpdatedAt,
+		ID:              dtoResp.ID,
+		RepositoryID:    dtoResp.RepositoryID,
+		Title:           dtoResp.Title,
+		Owner:           dtoResp.Owner,
+		DocType:         dtoResp.DocType,
+		Tags:            dtoResp.Tags,
+		IsPublished:     dtoResp.IsPublished,
+		VersionCount:    dtoResp.VersionCount,
+		CreatedAt:       dtoResp.CreatedAt,
+		UpdatedAt:       dtoResp.UpdatedAt,
+		UpdateAvailable: dtoResp.UpdateAvailable,
 	}
 }
 
@@ -156,3 +159,45 @@ func FromVersionHistoryDTO(dtoResp dto.VersionHistoryResponse) VersionHistoryRes
 		Versions:   versions,
 	}
 }
+
+// FromAccessGrantDTO converts application DTO to API schema
+func FromAccessGrantDTO(dtoResp dto.AccessGrantResponse) AccessGrantResponse {
+	return AccessGrantResponse{
+		DocumentID:    dtoResp.DocumentID,
+		PrincipalType: dtoResp.PrincipalType,
+		PrincipalID:   dtoResp.PrincipalID,
+		Level:         dtoResp.Level,
+		GrantedBy:     dtoResp.GrantedBy,
+		GrantedAt:     dtoResp.GrantedAt,
+	}
+}
+
+// FromAccessGrantListDTO converts a slice of application DTOs to API schemas
+func FromAccessGrantListDTO(dtoResp []dto.AccessGrantResponse) []AccessGrantResponse {
+	result := make([]AccessGrantResponse, len(dtoResp))
+	for i, g := range dtoResp {
+		result[i] = FromAccessGrantDTO(g)
+	}
+	return result
+}
+
+// FromImportResultDTO converts application DTO to API schema
+func FromImportResultDTO(dtoResp dto.ImportResultResponse) ImportResultResponse {
+	files := make([]ImportFileResult, len(dtoResp.Files))
+	for i, f := range dtoResp.Files {
+		var fileErrors []ImportFileError
+		for _, e := range f.Errors {
+			fileErrors = append(fileErrors, ImportFileError{Field: e.Field, Message: e.Message})
+		}
+		files[i] = ImportFileResult{
+			FilePath:   f.FilePath,
+			Status:     f.Status,
+			DocumentID: f.DocumentID,
+			Errors:     fileErrors,
+		}
+	}
+	return ImportResultResponse{
+		RepositoryID: dtoResp.RepositoryID,
+		Files:        files,
+	}
+}
@@ -6,7 +6,7 @@ import "time"
 type CreateDocumentRequest struct {
 	RepositoryID string                       `json:"repository_id" binding:"required" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
 	FilePath     string                       `json:"file_path" binding:"required" example:"docs/backup-procedure.md"`
-	CommitHash   string                       `json:"commit_hash" binding:"required" example:"abc1234567890"`
+	CommitHash   string                       `json:"commit_hash" example:"abc1234567890"` // Optional; the last commit that touched the file is used when omitted
 	Title        string                       `json:"title" binding:"required" example:"Database Backup Procedure"`
 	DocType      string                       `json:"doc_type" binding:"required" example:"procedure"`
 	Owner        string                       `json:"owner" binding:"required" example:"database-team"`
@@ -20,7 +20,7 @@ type CreateDocumentRequest struct {
 // UpdateDocumentRequest represents the API request for updating a document
 type UpdateDocumentRequest struct {
 	FilePath   string                      `json:"file_path" binding:"required" example:"docs/backup-procedure.md"`
-	CommitHash string                      `json:"commit_hash" binding:"required" example:"def4567890123"`
+	CommitHash string                      `json:"commit_hash" example:"def4567890123"` // Optional; the last commit that touched the file is used when omitted
 	Title      string                      `json:"title" binding:"required" example:"Database Backup Procedure v2"`
 	DocType    string                      `json:"doc_type" binding:"required" example:"procedure"`
 	Tags       []string                    `json:"tags" example:"[\"database\",\"backup\",\"v2\"]"`
@@ -63,6 +63,8 @@ type DocumentResponse struct {
 	IsPublished     bool                     `json:"is_published" example:"true"`
 	IsAutoUpdate    bool                     `json:"is_auto_update" example:"true"`
 	AccessScope     string                   `json:"access_scope" example:"public"`
+	UpdateAvailable bool                     `json:"update_available" example:"false"`
+	PendingCommit   string                   `json:"pending_commit_hash,omitempty" example:"def4567890123"`
 	CurrentVersion  *DocumentVersionResponse `json:"current_version"`
 	VersionCount    int                      `json:"version_count" example:"3"`
 	CreatedAt       time.Time                `json:"created_at" example:"2025-04-22T10:00:00Z"`
@@ -88,16 +90,17 @@ type DocumentVersionResponse struct {
 
 // DocumentListItemResponse represents a document item in a list response
 type DocumentListItemResponse struct {
-	ID           string    `json:"id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
-	RepositoryID string    `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
-	Title        string    `json:"title" example:"Database Backup Procedure"`
-	Owner        string    `json:"owner" example:"database-team"`
-	DocType      string    `json:"doc_type" example:"procedure"`
-	Tags         []string  `json:"tags" example:"[\"database\",\"backup\"]"`
-	IsPublished  bool      `json:"is_published" example:"true"`
-	VersionCount int       `json:"version_count" example:"3"`
-	CreatedAt    time.Time `json:"created_at" example:"2025-04-22T10:00:00Z"`
-	UpdatedAt    time.Time `json:"updated_at" example:"2025-04-22T12:00:00Z"`
+	ID              string    `json:"id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	RepositoryID    string    `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Title           string    `json:"title" example:"Database Backup Procedure"`
+	Owner           string    `json:"owner" example:"database-team"`
+	DocType         string    `json:"doc_type" example:"procedure"`
+	Tags            []string  `json:"tags" example:"[\"database\",\"backup\"]"`
+	IsPublished     bool      `json:"is_published" example:"true"`
+	VersionCount    int       `json:"version_count" example:"3"`
+	CreatedAt       time.Time `json:"created_at" example:"2025-04-22T10:00:00Z"`
+	UpdatedAt       time.Time `json:"updated_at" example:"2025-04-22T12:00:00Z"`
+	UpdateAvailable bool      `json:"update_available" example:"false"`
 }
 
 // ListDocumentsResponse represents the API response for listing documents
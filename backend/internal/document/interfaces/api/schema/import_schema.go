@@ -0,0 +1,26 @@
+package schema
+
+// ImportDocumentsRequest represents the API request for importing documents from a repository
+type ImportDocumentsRequest struct {
+	AccessScope string `json:"access_scope" example:"public"` // Optional; access scope of created documents, "public" when omitted
+}
+
+// ImportResultResponse represents the API response for an import
+type ImportResultResponse struct {
+	RepositoryID string             `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Files        []ImportFileResult `json:"files"`
+}
+
+// ImportFileResult represents the outcome of importing a single managed file
+type ImportFileResult struct {
+	FilePath   string            `json:"file_path" example:"docs/backup-procedure.md"`
+	Status     string            `json:"status" example:"created"` // "created", "updated", "unchanged", "skipped" or "failed"
+	DocumentID string            `json:"document_id,omitempty" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Errors     []ImportFileError `json:"errors,omitempty"`
+}
+
+// ImportFileError represents a validation error found in a managed file
+type ImportFileError struct {
+	Field   string `json:"field" example:"variables[0].type"`
+	Message string `json:"message" example:"invalid variable type: must be 'string', 'number', 'boolean', or 'date'"`
+}
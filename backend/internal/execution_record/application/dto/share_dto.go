@@ -0,0 +1,27 @@
+package dto
+
+import "time"
+
+// Caller identifies the user on whose behalf an execution record is accessed.
+type Caller struct {
+	UserID   string
+	GroupIDs []string
+}
+
+// ShareRecordRequest represents the request to share an execution record with a user or group.
+type ShareRecordRequest struct {
+	ExecutionRecordID string
+	PrincipalType     string // "user" or "group"
+	PrincipalID       string
+	Level             string // "read" or "comment"
+}
+
+// ShareGrantResponse represents a share grant response.
+type ShareGrantResponse struct {
+	ExecutionRecordID string
+	PrincipalType     string
+	PrincipalID       string
+	Level             string
+	GrantedBy         string
+	GrantedAt         time.Time
+}
@@ -2,6 +2,7 @@ package usecase
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"path/filepath"
@@ -15,9 +16,11 @@ import (
 )
 
 // AttachmentUsecase handles attachment business logic.
+// Attachments are visible to whoever may read the execution record they belong to.
 type AttachmentUsecase struct {
 	attachmentRepo repository.AttachmentRepository
 	recordRepo     repository.ExecutionRecordRepository
+	access         recordAccess
 	storageManager storage.StorageManager
 }
 
@@ -25,11 +28,14 @@ type AttachmentUsecase struct {
 func NewAttachmentUsecase(
 	attachmentRepo repository.AttachmentRepository,
 	recordRepo repository.ExecutionRecordRepository,
+	shareRepo repository.ShareGrantRepository,
 	storageManager storage.StorageManager,
+	permissions PermissionLister,
 ) *AttachmentUsecase {
 	return &AttachmentUsecase{
 		attachmentRepo: attachmentRepo,
 		recordRepo:     recordRepo,
+		access:         recordAccess{shareRepo: shareRepo, permissions: permissions},
 		storageManager: storageManager,
 	}
 }
@@ -37,6 +43,7 @@ func NewAttachmentUsecase(
 // UploadAttachment uploads a new attachment.
 func (uc *AttachmentUsecase) UploadAttachment(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.UploadAttachmentRequest,
 ) (*dto.AttachmentResponse, error) {
 	// Validate execution record ID
@@ -68,6 +75,9 @@ func (uc *AttachmentUsecase) UploadAttachment(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.require(ctx, record, caller, value_object.ShareLevelComment); err != nil {
+		return nil, err
+	}
 
 	// Generate attachment ID
 	attachmentID := value_object.GenerateAttachmentID()
@@ -121,6 +131,7 @@ func (uc *AttachmentUsecase) UploadAttachment(
 // GetAttachment retrieves an attachment by ID.
 func (uc *AttachmentUsecase) GetAttachment(
 	ctx context.Context,
+	caller dto.Caller,
 	attachmentID string,
 ) (*dto.AttachmentResponse, error) {
 	id, err := value_object.NewAttachmentID(attachmentID)
@@ -141,6 +152,9 @@ func (uc *AttachmentUsecase) GetAttachment(
 			ResourceID:   attachmentID,
 		}
 	}
+	if err := uc.authorizeRead(ctx, caller, attachment.ExecutionRecordID(), "Attachment", attachmentID); err != nil {
+		return nil, err
+	}
 
 	return toAttachmentResponse(attachment), nil
 }
@@ -148,6 +162,7 @@ func (uc *AttachmentUsecase) GetAttachment(
 // GetAttachmentFile retrieves the file content for an attachment.
 func (uc *AttachmentUsecase) GetAttachmentFile(
 	ctx context.Context,
+	caller dto.Caller,
 	attachmentID string,
 ) (io.ReadCloser, *dto.AttachmentResponse, error) {
 	id, err := value_object.NewAttachmentID(attachmentID)
@@ -168,6 +183,9 @@ func (uc *AttachmentUsecase) GetAttachmentFile(
 			ResourceID:   attachmentID,
 		}
 	}
+	if err := uc.authorizeRead(ctx, caller, attachment.ExecutionRecordID(), "Attachment", attachmentID); err != nil {
+		return nil, nil, err
+	}
 
 	// Retrieve the file from storage
 	file, err := uc.storageManager.Retrieve(ctx, attachment.StoragePath())
@@ -181,6 +199,7 @@ func (uc *AttachmentUsecase) GetAttachmentFile(
 // ListAttachmentsByRecordID lists attachments by execution record ID.
 func (uc *AttachmentUsecase) ListAttachmentsByRecordID(
 	ctx context.Context,
+	caller dto.Caller,
 	recordID string,
 ) ([]*dto.AttachmentResponse, error) {
 	id, err := value_object.NewExecutionRecordID(recordID)
@@ -191,6 +210,10 @@ func (uc *AttachmentUsecase) ListAttachmentsByRecordID(
 		}
 	}
 
+	if err := uc.authorizeRead(ctx, caller, id, "ExecutionRecord", recordID); err != nil {
+		return nil, err
+	}
+
 	attachments, err := uc.attachmentRepo.FindByExecutionRecordID(ctx, id)
 	if err != nil {
 		return nil, err
@@ -207,6 +230,7 @@ func (uc *AttachmentUsecase) ListAttachmentsByRecordID(
 // ListAttachmentsByStepID lists attachments by execution step ID.
 func (uc *AttachmentUsecase) ListAttachmentsByStepID(
 	ctx context.Context,
+	caller dto.Caller,
 	stepID string,
 ) ([]*dto.AttachmentResponse, error) {
 	id, err := value_object.NewExecutionStepID(stepID)
@@ -222,6 +246,13 @@ func (uc *AttachmentUsecase) ListAttachmentsByStepID(
 		return nil, err
 	}
 
+	// All attachments of a step belong to the same execution record
+	if len(attachments) > 0 {
+		if err := uc.authorizeRead(ctx, caller, attachments[0].ExecutionRecordID(), "ExecutionStep", stepID); err != nil {
+			return nil, err
+		}
+	}
+
 	responses := make([]*dto.AttachmentResponse, len(attachments))
 	for i, attachment := range attachments {
 		responses[i] = toAttachmentResponse(attachment)
@@ -263,10 +294,40 @@ func (uc *AttachmentUsecase) DeleteAttachment(
 	return uc.attachmentRepo.Delete(ctx, id)
 }
 
+// authorizeRead checks that the caller may read the execution record.
+// A record the caller cannot read, or that no longer exists, is reported as the requested resource not being found.
+func (uc *AttachmentUsecase) authorizeRead(
+	ctx context.Context,
+	caller dto.Caller,
+	recordID value_object.ExecutionRecordID,
+	resourceType string,
+	resourceID string,
+) error {
+	record, err := uc.recordRepo.FindByID(ctx, recordID)
+	if err != nil {
+		return err
+	}
+	notFound := &apperror.NotFoundError{
+		ResourceType: resourceType,
+		ResourceID:   resourceID,
+	}
+	if record == nil {
+		return notFound
+	}
+	if err := uc.access.require(ctx, record, caller, value_object.ShareLevelRead); err != nil {
+		if errors.Is(err, apperror.ErrNotFound) {
+			return notFound
+		}
+		return err
+	}
+	return nil
+}
+
 // GetAttachmentURL generates a presigned URL for accessing an attachment.
 // For local storage, returns empty string as files are served directly by the API.
 func (uc *AttachmentUsecase) GetAttachmentURL(
 	ctx context.Context,
+	caller dto.Caller,
 	attachmentID string,
 	expirationMinutes int,
 ) (string, error) {
@@ -288,6 +349,9 @@ func (uc *AttachmentUsecase) GetAttachmentURL(
 			ResourceID:   attachmentID,
 		}
 	}
+	if err := uc.authorizeRead(ctx, caller, attachment.ExecutionRecordID(), "Attachment", attachmentID); err != nil {
+		return "", err
+	}
 
 	// Generate presigned URL
 	url, err := uc.storageManager.GeneratePresignedURL(ctx, attachment.StoragePath(), expirationMinutes)
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"io"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -43,7 +44,7 @@ func TestAttachmentUsecase_UploadAttachment(t *testing.T) {
 	mockAttachmentRepo := &MockAttachmentRepository{}
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
 	fileContent := bytes.NewReader([]byte("test file content"))
 	req := &dto.UploadAttachmentRequest{
@@ -56,7 +57,7 @@ func TestAttachmentUsecase_UploadAttachment(t *testing.T) {
 		File:              fileContent,
 	}
 
-	resp, err := uc.UploadAttachment(ctx, req)
+	resp, err := uc.UploadAttachment(ctx, executorCaller, req)
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, req.FileName, resp.FileName)
@@ -71,7 +72,7 @@ func TestAttachmentUsecase_UploadAttachment_InvalidRecordID(t *testing.T) {
 	mockAttachmentRepo := &MockAttachmentRepository{}
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
 	req := &dto.UploadAttachmentRequest{
 		ExecutionRecordID: "invalid-id",
@@ -83,7 +84,7 @@ func TestAttachmentUsecase_UploadAttachment_InvalidRecordID(t *testing.T) {
 		File:              bytes.NewReader([]byte("test")),
 	}
 
-	_, err := uc.UploadAttachment(ctx, req)
+	_, err := uc.UploadAttachment(ctx, executorCaller, req)
 	assert.Error(t, err)
 	var validationErr *apperror.ValidationError
 	assert.True(t, errors.As(err, &validationErr))
@@ -115,12 +116,12 @@ func TestAttachmentUsecase_GetAttachment(t *testing.T) {
 			return nil, nil
 		},
 	}
-	mockRecordRepo := &MockExecutionRecordRepository{}
+	mockRecordRepo := newRecordRepoWith(t, recordID)
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
-	resp, err := uc.GetAttachment(ctx, attachmentID.String())
+	resp, err := uc.GetAttachment(ctx, executorCaller, attachmentID.String())
 	assert.NoError(t, err)
 	assert.NotNil(t, resp)
 	assert.Equal(t, attachmentID.String(), resp.ID)
@@ -139,9 +140,9 @@ func TestAttachmentUsecase_GetAttachment_NotFound(t *testing.T) {
 	mockRecordRepo := &MockExecutionRecordRepository{}
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
-	_, err := uc.GetAttachment(ctx, attachmentID.String())
+	_, err := uc.GetAttachment(ctx, executorCaller, attachmentID.String())
 	assert.Error(t, err)
 	var notFoundErr *apperror.NotFoundError
 	assert.True(t, errors.As(err, &notFoundErr))
@@ -176,7 +177,7 @@ func TestAttachmentUsecase_DeleteAttachment(t *testing.T) {
 	mockRecordRepo := &MockExecutionRecordRepository{}
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
 	err := uc.DeleteAttachment(ctx, attachmentID.String())
 	assert.NoError(t, err)
@@ -219,12 +220,12 @@ func TestAttachmentUsecase_ListAttachmentsByRecordID(t *testing.T) {
 			return nil, nil
 		},
 	}
-	mockRecordRepo := &MockExecutionRecordRepository{}
+	mockRecordRepo := newRecordRepoWith(t, recordID)
 	mockStorageManager := &MockStorageManager{}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
-	resp, err := uc.ListAttachmentsByRecordID(ctx, recordID.String())
+	resp, err := uc.ListAttachmentsByRecordID(ctx, executorCaller, recordID.String())
 	assert.NoError(t, err)
 	assert.Len(t, resp, 2)
 	assert.Equal(t, "test1.png", resp[0].FileName)
@@ -257,16 +258,105 @@ func TestAttachmentUsecase_GetAttachmentURL(t *testing.T) {
 			return nil, nil
 		},
 	}
-	mockRecordRepo := &MockExecutionRecordRepository{}
+	mockRecordRepo := newRecordRepoWith(t, recordID)
 	mockStorageManager := &MockStorageManager{
 		GeneratePresignedURLFunc: func(ctx context.Context, path string, expirationMinutes int) (string, error) {
 			return "", nil // Local storage returns empty string
 		},
 	}
 
-	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, mockStorageManager)
+	uc := NewAttachmentUsecase(mockAttachmentRepo, mockRecordRepo, &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
 
-	url, err := uc.GetAttachmentURL(ctx, attachmentID.String(), 60)
+	url, err := uc.GetAttachmentURL(ctx, executorCaller, attachmentID.String(), 60)
 	assert.NoError(t, err)
 	assert.Equal(t, "", url) // Local storage returns empty string
 }
+
+func TestAttachmentUsecase_GetAttachmentFile_HiddenRecord(t *testing.T) {
+	ctx := context.Background()
+	attachmentID := value_object.GenerateAttachmentID()
+	recordID := value_object.GenerateExecutionRecordID()
+
+	attachment, _ := entity.NewAttachment(
+		attachmentID,
+		recordID,
+		value_object.GenerateExecutionStepID(),
+		"test.png",
+		1024,
+		"image/png",
+		value_object.StorageTypeLocal,
+		"/path/to/file",
+		"user-123",
+	)
+
+	mockAttachmentRepo := &MockAttachmentRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.AttachmentID) (entity.Attachment, error) {
+			return attachment, nil
+		},
+	}
+	mockStorageManager := &MockStorageManager{
+		RetrieveFunc: func(ctx context.Context, path string) (io.ReadCloser, error) {
+			t.Fatal("Retrieve must not be called for a hidden record")
+			return nil, nil
+		},
+	}
+
+	uc := NewAttachmentUsecase(mockAttachmentRepo, newRecordRepoWith(t, recordID), &MockShareGrantRepository{}, mockStorageManager, &MockPermissionLister{})
+
+	_, _, err := uc.GetAttachmentFile(ctx, dto.Caller{UserID: "user-999"}, attachmentID.String())
+	var notFoundErr *apperror.NotFoundError
+	assert.True(t, errors.As(err, &notFoundErr))
+	assert.Equal(t, "Attachment", notFoundErr.ResourceType)
+}
+
+func TestAttachmentUsecase_UploadAttachment_ReadOnlyShare(t *testing.T) {
+	ctx := context.Background()
+	recordID := value_object.GenerateExecutionRecordID()
+
+	shareRepo := &MockShareGrantRepository{
+		FindByExecutionRecordIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+			grant, _ := entity.NewShareGrant(id, value_object.PrincipalTypeUser, "user-456", value_object.ShareLevelRead, "user-123")
+			return []entity.ShareGrant{grant}, nil
+		},
+	}
+
+	uc := NewAttachmentUsecase(&MockAttachmentRepository{}, newRecordRepoWith(t, recordID), shareRepo, &MockStorageManager{}, &MockPermissionLister{})
+
+	req := &dto.UploadAttachmentRequest{
+		ExecutionRecordID: recordID.String(),
+		ExecutionStepID:   value_object.GenerateExecutionStepID().String(),
+		FileName:          "test.png",
+		FileSize:          1024,
+		MimeType:          "image/png",
+		UploadedBy:        "user-456",
+		File:              bytes.NewReader([]byte("test")),
+	}
+
+	_, err := uc.UploadAttachment(ctx, dto.Caller{UserID: "user-456"}, req)
+	var forbiddenErr *apperror.ForbiddenError
+	assert.True(t, errors.As(err, &forbiddenErr))
+}
+
+// newRecordRepoWith returns a record repository holding a private record executed by user-123.
+func newRecordRepoWith(t *testing.T, recordID value_object.ExecutionRecordID) *MockExecutionRecordRepository {
+	t.Helper()
+	record, err := entity.NewExecutionRecord(
+		recordID,
+		docvo.GenerateDocumentID(),
+		docvo.GenerateVersionID(),
+		"user-123",
+		"Test Execution",
+		[]value_object.VariableValue{},
+	)
+	if err != nil {
+		t.Fatalf("NewExecutionRecord() error = %v", err)
+	}
+	return &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			if id.Equals(recordID) {
+				return record, nil
+			}
+			return nil, nil
+		},
+	}
+}
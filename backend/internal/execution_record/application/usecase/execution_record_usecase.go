@@ -4,6 +4,7 @@ import (
 	"context"
 	"strconv"
 
+	docdto "opscore/backend/internal/document/application/dto"
 	docvo "opscore/backend/internal/document/domain/value_object"
 	apperror "opscore/backend/internal/execution_record/application/error"
 	"opscore/backend/internal/execution_record/application/dto"
@@ -12,19 +13,37 @@ import (
 	"opscore/backend/internal/execution_record/domain/value_object"
 )
 
+// DocumentAccessChecker reports the access callers hold on the documents records are executed from.
+type DocumentAccessChecker interface {
+	// DocumentAccessLevel returns the highest access level the caller holds on a document,
+	// or false when the document does not exist or the caller may not view it.
+	DocumentAccessLevel(ctx context.Context, caller docdto.Caller, documentID string) (docvo.AccessLevel, bool, error)
+}
+
 // ExecutionRecordUsecase handles execution record business logic.
+// Records are visible to their executor, holders of user:admin, everyone when public,
+// and the users and groups they have been shared with.
 type ExecutionRecordUsecase struct {
-	repo repository.ExecutionRecordRepository
+	repo      repository.ExecutionRecordRepository
+	shareRepo repository.ShareGrantRepository
+	documents DocumentAccessChecker
+	access    recordAccess
 }
 
 // NewExecutionRecordUsecase creates a new ExecutionRecordUsecase.
-func NewExecutionRecordUsecase(repo repository.ExecutionRecordRepository) *ExecutionRecordUsecase {
-	return &ExecutionRecordUsecase{repo: repo}
+func NewExecutionRecordUsecase(repo repository.ExecutionRecordRepository, shareRepo repository.ShareGrantRepository, documents DocumentAccessChecker, permissions PermissionLister) *ExecutionRecordUsecase {
+	return &ExecutionRecordUsecase{
+		repo:      repo,
+		shareRepo: shareRepo,
+		documents: documents,
+		access:    recordAccess{shareRepo: shareRepo, permissions: permissions},
+	}
 }
 
-// CreateExecutionRecord creates a new execution record.
+// CreateExecutionRecord creates a new execution record of a document the caller may execute.
 func (uc *ExecutionRecordUsecase) CreateExecutionRecord(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.CreateExecutionRecordRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	// Generate ID
@@ -39,6 +58,11 @@ func (uc *ExecutionRecordUsecase) CreateExecutionRecord(
 		}
 	}
 
+	// Check that the caller may execute the document
+	if err := uc.requireExecutable(ctx, caller, documentID); err != nil {
+		return nil, err
+	}
+
 	// Parse version ID
 	versionID, err := docvo.NewVersionID(req.DocumentVersionID)
 	if err != nil {
@@ -88,6 +112,7 @@ func (uc *ExecutionRecordUsecase) CreateExecutionRecord(
 // GetExecutionRecord retrieves an execution record by ID.
 func (uc *ExecutionRecordUsecase) GetExecutionRecord(
 	ctx context.Context,
+	caller dto.Caller,
 	recordID string,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(recordID)
@@ -108,6 +133,9 @@ func (uc *ExecutionRecordUsecase) GetExecutionRecord(
 			ResourceID:   recordID,
 		}
 	}
+	if err := uc.access.require(ctx, record, caller, value_object.ShareLevelRead); err != nil {
+		return nil, err
+	}
 
 	return toExecutionRecordResponse(record), nil
 }
@@ -115,6 +143,7 @@ func (uc *ExecutionRecordUsecase) GetExecutionRecord(
 // AddStep adds a step to an execution record.
 func (uc *ExecutionRecordUsecase) AddStep(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.AddStepRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -135,6 +164,9 @@ func (uc *ExecutionRecordUsecase) AddStep(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.requireManager(ctx, record, caller, "add step"); err != nil {
+		return nil, err
+	}
 
 	if err := record.AddStep(req.StepNumber, req.Description); err != nil {
 		return nil, &apperror.ValidationError{
@@ -153,6 +185,7 @@ func (uc *ExecutionRecordUsecase) AddStep(
 // UpdateStepNotes updates notes for a specific step.
 func (uc *ExecutionRecordUsecase) UpdateStepNotes(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.UpdateStepNotesRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -173,6 +206,9 @@ func (uc *ExecutionRecordUsecase) UpdateStepNotes(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.require(ctx, record, caller, value_object.ShareLevelComment); err != nil {
+		return nil, err
+	}
 
 	if err := record.UpdateStepNotes(req.StepNumber, req.Notes); err != nil {
 		return nil, &apperror.NotFoundError{
@@ -191,6 +227,7 @@ func (uc *ExecutionRecordUsecase) UpdateStepNotes(
 // UpdateNotes updates the overall notes.
 func (uc *ExecutionRecordUsecase) UpdateNotes(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.UpdateNotesRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -211,6 +248,9 @@ func (uc *ExecutionRecordUsecase) UpdateNotes(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.require(ctx, record, caller, value_object.ShareLevelComment); err != nil {
+		return nil, err
+	}
 
 	record.UpdateNotes(req.Notes)
 
@@ -224,6 +264,7 @@ func (uc *ExecutionRecordUsecase) UpdateNotes(
 // UpdateTitle updates the execution title.
 func (uc *ExecutionRecordUsecase) UpdateTitle(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.UpdateTitleRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -244,6 +285,9 @@ func (uc *ExecutionRecordUsecase) UpdateTitle(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.requireManager(ctx, record, caller, "update title"); err != nil {
+		return nil, err
+	}
 
 	if err := record.UpdateTitle(req.Title); err != nil {
 		return nil, &apperror.ValidationError{
@@ -262,6 +306,7 @@ func (uc *ExecutionRecordUsecase) UpdateTitle(
 // Complete marks an execution as completed.
 func (uc *ExecutionRecordUsecase) Complete(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.CompleteExecutionRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -282,6 +327,9 @@ func (uc *ExecutionRecordUsecase) Complete(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.requireManager(ctx, record, caller, "complete"); err != nil {
+		return nil, err
+	}
 
 	if err := record.Complete(); err != nil {
 		return nil, &apperror.ConflictError{
@@ -301,6 +349,7 @@ func (uc *ExecutionRecordUsecase) Complete(
 // MarkAsFailed marks an execution as failed.
 func (uc *ExecutionRecordUsecase) MarkAsFailed(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.MarkAsFailedRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -321,6 +370,9 @@ func (uc *ExecutionRecordUsecase) MarkAsFailed(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.requireManager(ctx, record, caller, "mark as failed"); err != nil {
+		return nil, err
+	}
 
 	if err := record.MarkAsFailed(); err != nil {
 		return nil, &apperror.ConflictError{
@@ -340,6 +392,7 @@ func (uc *ExecutionRecordUsecase) MarkAsFailed(
 // UpdateAccessScope updates the access scope.
 func (uc *ExecutionRecordUsecase) UpdateAccessScope(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.UpdateAccessScopeRequest,
 ) (*dto.ExecutionRecordResponse, error) {
 	id, err := value_object.NewExecutionRecordID(req.ExecutionRecordID)
@@ -368,6 +421,9 @@ func (uc *ExecutionRecordUsecase) UpdateAccessScope(
 			ResourceID:   req.ExecutionRecordID,
 		}
 	}
+	if err := uc.access.requireManager(ctx, record, caller, "update access scope"); err != nil {
+		return nil, err
+	}
 
 	record.UpdateAccessScope(scope)
 
@@ -378,9 +434,10 @@ func (uc *ExecutionRecordUsecase) UpdateAccessScope(
 	return toExecutionRecordResponse(record), nil
 }
 
-// SearchExecutionRecords searches for execution records.
+// SearchExecutionRecords searches for execution records the caller may read.
 func (uc *ExecutionRecordUsecase) SearchExecutionRecords(
 	ctx context.Context,
+	caller dto.Caller,
 	req *dto.SearchExecutionRecordRequest,
 ) ([]*dto.ExecutionRecordResponse, error) {
 	criteria := repository.SearchCriteria{}
@@ -427,6 +484,11 @@ func (uc *ExecutionRecordUsecase) SearchExecutionRecords(
 		return nil, err
 	}
 
+	records, err = uc.access.filterReadable(ctx, records, caller)
+	if err != nil {
+		return nil, err
+	}
+
 	responses := make([]*dto.ExecutionRecordResponse, len(records))
 	for i, record := range records {
 		responses[i] = toExecutionRecordResponse(record)
@@ -435,9 +497,10 @@ func (uc *ExecutionRecordUsecase) SearchExecutionRecords(
 	return responses, nil
 }
 
-// GetByExecutorID retrieves execution records by executor ID.
+// GetByExecutorID retrieves execution records by executor ID that the caller may read.
 func (uc *ExecutionRecordUsecase) GetByExecutorID(
 	ctx context.Context,
+	caller dto.Caller,
 	executorID string,
 ) ([]*dto.ExecutionRecordResponse, error) {
 	records, err := uc.repo.FindByExecutorID(ctx, executorID)
@@ -445,6 +508,11 @@ func (uc *ExecutionRecordUsecase) GetByExecutorID(
 		return nil, err
 	}
 
+	records, err = uc.access.filterReadable(ctx, records, caller)
+	if err != nil {
+		return nil, err
+	}
+
 	responses := make([]*dto.ExecutionRecordResponse, len(records))
 	for i, record := range records {
 		responses[i] = toExecutionRecordResponse(record)
@@ -480,6 +548,144 @@ func (uc *ExecutionRecordUsecase) DeleteExecutionRecord(
 	return uc.repo.Delete(ctx, id)
 }
 
+// ListShares lists the users and groups an execution record is shared with.
+// Only the executor and administrators may list shares.
+func (uc *ExecutionRecordUsecase) ListShares(
+	ctx context.Context,
+	caller dto.Caller,
+	recordID string,
+) ([]*dto.ShareGrantResponse, error) {
+	record, err := uc.findRecord(ctx, recordID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.access.requireManager(ctx, record, caller, "list shares"); err != nil {
+		return nil, err
+	}
+
+	grants, err := uc.shareRepo.FindByExecutionRecordID(ctx, record.ID())
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]*dto.ShareGrantResponse, len(grants))
+	for i, grant := range grants {
+		responses[i] = toShareGrantResponse(grant)
+	}
+
+	return responses, nil
+}
+
+// ShareRecord shares an execution record with a user or group, replacing any existing level.
+// Only the executor and administrators may share a record.
+func (uc *ExecutionRecordUsecase) ShareRecord(
+	ctx context.Context,
+	caller dto.Caller,
+	req *dto.ShareRecordRequest,
+) (*dto.ShareGrantResponse, error) {
+	record, err := uc.findRecord(ctx, req.ExecutionRecordID)
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.access.requireManager(ctx, record, caller, "share"); err != nil {
+		return nil, err
+	}
+
+	principalType, err := value_object.NewPrincipalType(req.PrincipalType)
+	if err != nil {
+		return nil, &apperror.ValidationError{
+			Field:   "principalType",
+			Message: err.Error(),
+		}
+	}
+
+	level, err := value_object.NewShareLevel(req.Level)
+	if err != nil {
+		return nil, &apperror.ValidationError{
+			Field:   "level",
+			Message: err.Error(),
+		}
+	}
+
+	grant, err := entity.NewShareGrant(record.ID(), principalType, req.PrincipalID, level, caller.UserID)
+	if err != nil {
+		return nil, &apperror.ValidationError{
+			Field:   "share",
+			Message: err.Error(),
+		}
+	}
+
+	if err := uc.shareRepo.Save(ctx, grant); err != nil {
+		return nil, err
+	}
+
+	return toShareGrantResponse(grant), nil
+}
+
+// UnshareRecord removes the share of a user or group from an execution record.
+// Only the executor and administrators may remove shares.
+func (uc *ExecutionRecordUsecase) UnshareRecord(
+	ctx context.Context,
+	caller dto.Caller,
+	recordID string,
+	principalType string,
+	principalID string,
+) error {
+	record, err := uc.findRecord(ctx, recordID)
+	if err != nil {
+		return err
+	}
+	if err := uc.access.requireManager(ctx, record, caller, "unshare"); err != nil {
+		return err
+	}
+
+	pt, err := value_object.NewPrincipalType(principalType)
+	if err != nil {
+		return &apperror.ValidationError{
+			Field:   "principalType",
+			Message: err.Error(),
+		}
+	}
+
+	return uc.shareRepo.Delete(ctx, record.ID(), pt, principalID)
+}
+
+// findRecord loads an execution record by ID, returning a NotFoundError if it does not exist.
+func (uc *ExecutionRecordUsecase) findRecord(ctx context.Context, recordID string) (entity.ExecutionRecord, error) {
+	id, err := value_object.NewExecutionRecordID(recordID)
+	if err != nil {
+		return nil, &apperror.ValidationError{
+			Field:   "recordID",
+			Message: "invalid execution record ID format",
+		}
+	}
+
+	record, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if record == nil {
+		return nil, &apperror.NotFoundError{
+			ResourceType: "ExecutionRecord",
+			ResourceID:   recordID,
+		}
+	}
+
+	return record, nil
+}
+
+// Helper function to convert a share grant to DTO response
+func toShareGrantResponse(grant entity.ShareGrant) *dto.ShareGrantResponse {
+	return &dto.ShareGrantResponse{
+		ExecutionRecordID: grant.ExecutionRecordID().String(),
+		PrincipalType:     grant.PrincipalType().String(),
+		PrincipalID:       grant.PrincipalID(),
+		Level:             grant.Level().String(),
+		GrantedBy:         grant.GrantedBy(),
+		GrantedAt:         grant.GrantedAt(),
+	}
+}
+
 // Helper function to convert entity to DTO response
 func toExecutionRecordResponse(record entity.ExecutionRecord) *dto.ExecutionRecordResponse {
 	steps := make([]dto.ExecutionStepResponse, len(record.Steps()))
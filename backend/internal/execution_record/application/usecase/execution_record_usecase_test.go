@@ -5,16 +5,22 @@ import (
 	"errors"
 	"testing"
 
+	docdto "opscore/backend/internal/document/application/dto"
 	docvo "opscore/backend/internal/document/domain/value_object"
 	apperror "opscore/backend/internal/execution_record/application/error"
 	"opscore/backend/internal/execution_record/application/dto"
 	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
 	"opscore/backend/internal/execution_record/domain/value_object"
+	uservo "opscore/backend/internal/user/domain/value_object"
 )
 
+// executorCaller is the caller who executed the records created in these tests.
+var executorCaller = dto.Caller{UserID: "user-123"}
+
 func TestExecutionRecordUsecase_CreateExecutionRecord(t *testing.T) {
 	mockRepo := &MockExecutionRecordRepository{}
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 
 	ctx := context.Background()
 	docID := docvo.GenerateDocumentID()
@@ -28,7 +34,7 @@ func TestExecutionRecordUsecase_CreateExecutionRecord(t *testing.T) {
 		VariableValues:    []dto.VariableValueDTO{},
 	}
 
-	resp, err := uc.CreateExecutionRecord(ctx, req)
+	resp, err := uc.CreateExecutionRecord(ctx, executorCaller, req)
 	if err != nil {
 		t.Fatalf("CreateExecutionRecord() error = %v", err)
 	}
@@ -52,7 +58,7 @@ func TestExecutionRecordUsecase_CreateExecutionRecord(t *testing.T) {
 
 func TestExecutionRecordUsecase_CreateExecutionRecord_InvalidDocumentID(t *testing.T) {
 	mockRepo := &MockExecutionRecordRepository{}
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 
 	ctx := context.Background()
 
@@ -63,7 +69,7 @@ func TestExecutionRecordUsecase_CreateExecutionRecord_InvalidDocumentID(t *testi
 		Title:             "Test Execution",
 	}
 
-	_, err := uc.CreateExecutionRecord(ctx, req)
+	_, err := uc.CreateExecutionRecord(ctx, executorCaller, req)
 	if err == nil {
 		t.Fatal("CreateExecutionRecord() should return error for invalid document ID")
 	}
@@ -73,6 +79,58 @@ func TestExecutionRecordUsecase_CreateExecutionRecord_InvalidDocumentID(t *testi
 	}
 }
 
+// createWithDocumentAccess creates a record of a document on which the caller holds the given access,
+// and reports whether the record was saved.
+func createWithDocumentAccess(t *testing.T, level docvo.AccessLevel, visible bool) (bool, error) {
+	t.Helper()
+	docID := docvo.GenerateDocumentID()
+
+	saved := false
+	mockRepo := &MockExecutionRecordRepository{
+		SaveFunc: func(ctx context.Context, record entity.ExecutionRecord) error {
+			saved = true
+			return nil
+		},
+	}
+	documents := &MockDocumentAccessChecker{
+		DocumentAccessLevelFunc: func(ctx context.Context, caller docdto.Caller, documentID string) (docvo.AccessLevel, bool, error) {
+			if caller.UserID != executorCaller.UserID || documentID != docID.String() {
+				t.Errorf("DocumentAccessLevel() called with %v, %s", caller, documentID)
+			}
+			return level, visible, nil
+		},
+	}
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, documents, &MockPermissionLister{})
+
+	_, err := uc.CreateExecutionRecord(context.Background(), executorCaller, &dto.CreateExecutionRecordRequest{
+		DocumentID:        docID.String(),
+		DocumentVersionID: docvo.GenerateVersionID().String(),
+		ExecutorID:        executorCaller.UserID,
+		Title:             "Test Execution",
+	})
+	return saved, err
+}
+
+func TestExecutionRecordUsecase_CreateExecutionRecord_ViewOnlyDocument(t *testing.T) {
+	saved, err := createWithDocumentAccess(t, docvo.AccessLevelView, true)
+	if !errors.Is(err, apperror.ErrForbidden) {
+		t.Errorf("Error should be forbidden error, got %v", err)
+	}
+	if saved {
+		t.Error("CreateExecutionRecord() should not save a record of a document the caller may not execute")
+	}
+}
+
+func TestExecutionRecordUsecase_CreateExecutionRecord_HiddenDocument(t *testing.T) {
+	saved, err := createWithDocumentAccess(t, "", false)
+	if !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("Error should be not found error, got %v", err)
+	}
+	if saved {
+		t.Error("CreateExecutionRecord() should not save a record of a document the caller cannot view")
+	}
+}
+
 func TestExecutionRecordUsecase_GetExecutionRecord(t *testing.T) {
 	docID := docvo.GenerateDocumentID()
 	versionID := docvo.GenerateVersionID()
@@ -96,10 +154,10 @@ func TestExecutionRecordUsecase_GetExecutionRecord(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
-	resp, err := uc.GetExecutionRecord(ctx, recordID.String())
+	resp, err := uc.GetExecutionRecord(ctx, executorCaller, recordID.String())
 	if err != nil {
 		t.Fatalf("GetExecutionRecord() error = %v", err)
 	}
@@ -120,11 +178,11 @@ func TestExecutionRecordUsecase_GetExecutionRecord_NotFound(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	recordID := value_object.GenerateExecutionRecordID()
-	_, err := uc.GetExecutionRecord(ctx, recordID.String())
+	_, err := uc.GetExecutionRecord(ctx, executorCaller, recordID.String())
 	if err == nil {
 		t.Fatal("GetExecutionRecord() should return error for not found")
 	}
@@ -154,7 +212,7 @@ func TestExecutionRecordUsecase_AddStep(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	req := &dto.AddStepRequest{
@@ -163,7 +221,7 @@ func TestExecutionRecordUsecase_AddStep(t *testing.T) {
 		Description:       "First step",
 	}
 
-	resp, err := uc.AddStep(ctx, req)
+	resp, err := uc.AddStep(ctx, executorCaller, req)
 	if err != nil {
 		t.Fatalf("AddStep() error = %v", err)
 	}
@@ -197,14 +255,14 @@ func TestExecutionRecordUsecase_Complete(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	req := &dto.CompleteExecutionRequest{
 		ExecutionRecordID: recordID.String(),
 	}
 
-	resp, err := uc.Complete(ctx, req)
+	resp, err := uc.Complete(ctx, executorCaller, req)
 	if err != nil {
 		t.Fatalf("Complete() error = %v", err)
 	}
@@ -238,14 +296,14 @@ func TestExecutionRecordUsecase_MarkAsFailed(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	req := &dto.MarkAsFailedRequest{
 		ExecutionRecordID: recordID.String(),
 	}
 
-	resp, err := uc.MarkAsFailed(ctx, req)
+	resp, err := uc.MarkAsFailed(ctx, executorCaller, req)
 	if err != nil {
 		t.Fatalf("MarkAsFailed() error = %v", err)
 	}
@@ -275,7 +333,7 @@ func TestExecutionRecordUsecase_UpdateAccessScope(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	req := &dto.UpdateAccessScopeRequest{
@@ -283,7 +341,7 @@ func TestExecutionRecordUsecase_UpdateAccessScope(t *testing.T) {
 		AccessScope:       "public",
 	}
 
-	resp, err := uc.UpdateAccessScope(ctx, req)
+	resp, err := uc.UpdateAccessScope(ctx, executorCaller, req)
 	if err != nil {
 		t.Fatalf("UpdateAccessScope() error = %v", err)
 	}
@@ -318,7 +376,7 @@ func TestExecutionRecordUsecase_DeleteExecutionRecord(t *testing.T) {
 		},
 	}
 
-	uc := NewExecutionRecordUsecase(mockRepo)
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, &MockPermissionLister{})
 	ctx := context.Background()
 
 	err := uc.DeleteExecutionRecord(ctx, recordID.String())
@@ -330,3 +388,236 @@ func TestExecutionRecordUsecase_DeleteExecutionRecord(t *testing.T) {
 		t.Error("Delete was not called")
 	}
 }
+
+func TestExecutionRecordUsecase_GetExecutionRecord_SharedWithGroup(t *testing.T) {
+	record := newPrivateTestRecord(t)
+	mockRepo := &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			return record, nil
+		},
+	}
+	shareRepo := &MockShareGrantRepository{
+		FindByExecutionRecordIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+			grant, _ := entity.NewShareGrant(id, value_object.PrincipalTypeGroup, "sre", value_object.ShareLevelRead, "user-123")
+			return []entity.ShareGrant{grant}, nil
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, shareRepo, &MockDocumentAccessChecker{}, &MockPermissionLister{})
+	ctx := context.Background()
+
+	resp, err := uc.GetExecutionRecord(ctx, dto.Caller{UserID: "user-456", GroupIDs: []string{"sre"}}, record.ID().String())
+	if err != nil {
+		t.Fatalf("GetExecutionRecord() error = %v", err)
+	}
+	if resp.ID != record.ID().String() {
+		t.Errorf("ID = %v, want %v", resp.ID, record.ID().String())
+	}
+
+	_, err = uc.GetExecutionRecord(ctx, dto.Caller{UserID: "user-789", GroupIDs: []string{"dev"}}, record.ID().String())
+	if !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("Error should be not found error for a caller without a share, got %v", err)
+	}
+
+	_, err = uc.UpdateNotes(ctx, dto.Caller{UserID: "user-456", GroupIDs: []string{"sre"}}, &dto.UpdateNotesRequest{
+		ExecutionRecordID: record.ID().String(),
+		Notes:             "notes",
+	})
+	if !errors.Is(err, apperror.ErrForbidden) {
+		t.Errorf("Error should be forbidden error for a read-only share, got %v", err)
+	}
+}
+
+func TestExecutionRecordUsecase_SearchExecutionRecords_FiltersUnreadable(t *testing.T) {
+	shared := newPrivateTestRecord(t)
+	hidden := newPrivateTestRecord(t)
+	public := newPrivateTestRecord(t)
+	public.UpdateAccessScope(value_object.AccessScopePublic)
+
+	findByPrincipalsCalls := 0
+	mockRepo := &MockExecutionRecordRepository{
+		SearchFunc: func(ctx context.Context, criteria repository.SearchCriteria) ([]entity.ExecutionRecord, error) {
+			return []entity.ExecutionRecord{shared, hidden, public}, nil
+		},
+	}
+	shareRepo := &MockShareGrantRepository{
+		FindByPrincipalsFunc: func(ctx context.Context, userID string, groupIDs []string) ([]entity.ShareGrant, error) {
+			findByPrincipalsCalls++
+			grant, _ := entity.NewShareGrant(shared.ID(), value_object.PrincipalTypeUser, userID, value_object.ShareLevelComment, "user-123")
+			return []entity.ShareGrant{grant}, nil
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, shareRepo, &MockDocumentAccessChecker{}, &MockPermissionLister{})
+
+	resp, err := uc.SearchExecutionRecords(context.Background(), dto.Caller{UserID: "user-456"}, &dto.SearchExecutionRecordRequest{})
+	if err != nil {
+		t.Fatalf("SearchExecutionRecords() error = %v", err)
+	}
+	if len(resp) != 2 {
+		t.Fatalf("len(resp) = %d, want 2", len(resp))
+	}
+	if resp[0].ID != shared.ID().String() || resp[1].ID != public.ID().String() {
+		t.Errorf("unexpected records returned: %v, %v", resp[0].ID, resp[1].ID)
+	}
+	if findByPrincipalsCalls != 1 {
+		t.Errorf("FindByPrincipals called %d times, want 1", findByPrincipalsCalls)
+	}
+}
+
+func TestExecutionRecordUsecase_ShareRecord(t *testing.T) {
+	record := newPrivateTestRecord(t)
+	mockRepo := &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			return record, nil
+		},
+	}
+	var saved entity.ShareGrant
+	shareRepo := &MockShareGrantRepository{
+		SaveFunc: func(ctx context.Context, grant entity.ShareGrant) error {
+			saved = grant
+			return nil
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, shareRepo, &MockDocumentAccessChecker{}, &MockPermissionLister{})
+	ctx := context.Background()
+
+	req := &dto.ShareRecordRequest{
+		ExecutionRecordID: record.ID().String(),
+		PrincipalType:     "group",
+		PrincipalID:       "sre",
+		Level:             "comment",
+	}
+
+	resp, err := uc.ShareRecord(ctx, executorCaller, req)
+	if err != nil {
+		t.Fatalf("ShareRecord() error = %v", err)
+	}
+	if saved == nil {
+		t.Fatal("Save was not called")
+	}
+	if resp.PrincipalID != "sre" || resp.Level != "comment" || resp.GrantedBy != "user-123" {
+		t.Errorf("unexpected response: %+v", resp)
+	}
+
+	req.Level = "edit"
+	_, err = uc.ShareRecord(ctx, executorCaller, req)
+	if !errors.Is(err, apperror.ErrValidationFailed) {
+		t.Errorf("Error should be validation error for an invalid level, got %v", err)
+	}
+}
+
+func TestExecutionRecordUsecase_ShareRecord_NotExecutor(t *testing.T) {
+	record := newPrivateTestRecord(t)
+	mockRepo := &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			return record, nil
+		},
+	}
+	shareRepo := &MockShareGrantRepository{
+		FindByExecutionRecordIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+			grant, _ := entity.NewShareGrant(id, value_object.PrincipalTypeUser, "user-456", value_object.ShareLevelComment, "user-123")
+			return []entity.ShareGrant{grant}, nil
+		},
+		SaveFunc: func(ctx context.Context, grant entity.ShareGrant) error {
+			t.Fatal("Save must not be called")
+			return nil
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, shareRepo, &MockDocumentAccessChecker{}, &MockPermissionLister{})
+	ctx := context.Background()
+
+	req := &dto.ShareRecordRequest{
+		ExecutionRecordID: record.ID().String(),
+		PrincipalType:     "user",
+		PrincipalID:       "user-789",
+		Level:             "read",
+	}
+
+	_, err := uc.ShareRecord(ctx, dto.Caller{UserID: "user-456"}, req)
+	if !errors.Is(err, apperror.ErrForbidden) {
+		t.Errorf("Error should be forbidden error for a shared user, got %v", err)
+	}
+
+	_, err = uc.ShareRecord(ctx, dto.Caller{UserID: "user-999"}, req)
+	if !errors.Is(err, apperror.ErrNotFound) {
+		t.Errorf("Error should be not found error for an unrelated user, got %v", err)
+	}
+}
+
+// newPrivateTestRecord creates a private execution record executed by user-123.
+func newPrivateTestRecord(t *testing.T) entity.ExecutionRecord {
+	t.Helper()
+	record, err := entity.NewExecutionRecord(
+		value_object.GenerateExecutionRecordID(),
+		docvo.GenerateDocumentID(),
+		docvo.GenerateVersionID(),
+		"user-123",
+		"Test Execution",
+		[]value_object.VariableValue{},
+	)
+	if err != nil {
+		t.Fatalf("NewExecutionRecord() error = %v", err)
+	}
+	return record
+}
+
+func TestExecutionRecordUsecase_ShareRecord_UserAdminPermission(t *testing.T) {
+	record := newPrivateTestRecord(t)
+	mockRepo := &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			return record, nil
+		},
+	}
+	shareRepo := &MockShareGrantRepository{
+		FindByExecutionRecordIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+			t.Fatal("Shares must not be looked up for a user:admin holder")
+			return nil, nil
+		},
+	}
+	permissions := &MockPermissionLister{
+		EffectivePermissionsFunc: func(ctx context.Context, userID string) ([]string, error) {
+			if userID == "admin-1" {
+				return []string{uservo.PermissionUserAdmin}, nil
+			}
+			return nil, nil
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, shareRepo, &MockDocumentAccessChecker{}, permissions)
+	ctx := context.Background()
+
+	req := &dto.ShareRecordRequest{
+		ExecutionRecordID: record.ID().String(),
+		PrincipalType:     "group",
+		PrincipalID:       "sre",
+		Level:             "read",
+	}
+
+	if _, err := uc.ShareRecord(ctx, dto.Caller{UserID: "admin-1"}, req); err != nil {
+		t.Fatalf("ShareRecord() error = %v", err)
+	}
+}
+
+func TestExecutionRecordUsecase_GetExecutionRecord_PermissionLookupFails(t *testing.T) {
+	record := newPrivateTestRecord(t)
+	mockRepo := &MockExecutionRecordRepository{
+		FindByIDFunc: func(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+			return record, nil
+		},
+	}
+	permissions := &MockPermissionLister{
+		EffectivePermissionsFunc: func(ctx context.Context, userID string) ([]string, error) {
+			return nil, errors.New("db error")
+		},
+	}
+
+	uc := NewExecutionRecordUsecase(mockRepo, &MockShareGrantRepository{}, &MockDocumentAccessChecker{}, permissions)
+
+	_, err := uc.GetExecutionRecord(context.Background(), dto.Caller{UserID: "user-456"}, record.ID().String())
+	if err == nil {
+		t.Error("Expected error when permissions cannot be looked up")
+	}
+}
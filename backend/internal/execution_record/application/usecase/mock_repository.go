@@ -4,6 +4,7 @@ import (
 	"context"
 	"io"
 
+	docdto "opscore/backend/internal/document/application/dto"
 	docvo "opscore/backend/internal/document/domain/value_object"
 	"opscore/backend/internal/execution_record/domain/entity"
 	"opscore/backend/internal/execution_record/domain/repository"
@@ -165,3 +166,65 @@ func (m *MockStorageManager) Type() value_object.StorageType {
 	}
 	return value_object.StorageTypeLocal
 }
+
+// MockShareGrantRepository is a mock implementation of ShareGrantRepository for testing.
+type MockShareGrantRepository struct {
+	SaveFunc                    func(ctx context.Context, grant entity.ShareGrant) error
+	DeleteFunc                  func(ctx context.Context, recordID value_object.ExecutionRecordID, principalType value_object.PrincipalType, principalID string) error
+	FindByExecutionRecordIDFunc func(ctx context.Context, recordID value_object.ExecutionRecordID) ([]entity.ShareGrant, error)
+	FindByPrincipalsFunc        func(ctx context.Context, userID string, groupIDs []string) ([]entity.ShareGrant, error)
+}
+
+func (m *MockShareGrantRepository) Save(ctx context.Context, grant entity.ShareGrant) error {
+	if m.SaveFunc != nil {
+		return m.SaveFunc(ctx, grant)
+	}
+	return nil
+}
+
+func (m *MockShareGrantRepository) Delete(ctx context.Context, recordID value_object.ExecutionRecordID, principalType value_object.PrincipalType, principalID string) error {
+	if m.DeleteFunc != nil {
+		return m.DeleteFunc(ctx, recordID, principalType, principalID)
+	}
+	return nil
+}
+
+func (m *MockShareGrantRepository) FindByExecutionRecordID(ctx context.Context, recordID value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+	if m.FindByExecutionRecordIDFunc != nil {
+		return m.FindByExecutionRecordIDFunc(ctx, recordID)
+	}
+	return nil, nil
+}
+
+func (m *MockShareGrantRepository) FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.ShareGrant, error) {
+	if m.FindByPrincipalsFunc != nil {
+		return m.FindByPrincipalsFunc(ctx, userID, groupIDs)
+	}
+	return nil, nil
+}
+
+// MockDocumentAccessChecker is a mock implementation of DocumentAccessChecker for testing.
+// Without a DocumentAccessLevelFunc every caller may execute every document.
+type MockDocumentAccessChecker struct {
+	DocumentAccessLevelFunc func(ctx context.Context, caller docdto.Caller, documentID string) (docvo.AccessLevel, bool, error)
+}
+
+func (m *MockDocumentAccessChecker) DocumentAccessLevel(ctx context.Context, caller docdto.Caller, documentID string) (docvo.AccessLevel, bool, error) {
+	if m.DocumentAccessLevelFunc != nil {
+		return m.DocumentAccessLevelFunc(ctx, caller, documentID)
+	}
+	return docvo.AccessLevelExecute, true, nil
+}
+
+// MockPermissionLister is a mock implementation of PermissionLister for testing.
+// Without an EffectivePermissionsFunc every user holds no permissions.
+type MockPermissionLister struct {
+	EffectivePermissionsFunc func(ctx context.Context, userID string) ([]string, error)
+}
+
+func (m *MockPermissionLister) EffectivePermissions(ctx context.Context, userID string) ([]string, error) {
+	if m.EffectivePermissionsFunc != nil {
+		return m.EffectivePermissionsFunc(ctx, userID)
+	}
+	return nil, nil
+}
@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	docdto "opscore/backend/internal/document/application/dto"
+	docvo "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/execution_record/application/dto"
+	apperror "opscore/backend/internal/execution_record/application/error"
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+	uservo "opscore/backend/internal/user/domain/value_object"
+)
+
+// PermissionLister lists the permissions a user holds through their roles.
+type PermissionLister interface {
+	// EffectivePermissions returns the permissions held by the user.
+	EffectivePermissions(ctx context.Context, userID string) ([]string, error)
+}
+
+// recordAccess enforces execution record shares on behalf of the execution record and attachment usecases.
+type recordAccess struct {
+	shareRepo   repository.ShareGrantRepository
+	permissions PermissionLister
+}
+
+// viewerFor returns the domain viewer of the caller, which overrides shares when the caller holds
+// the user:admin permission.
+func (a recordAccess) viewerFor(ctx context.Context, caller dto.Caller) (value_object.Viewer, error) {
+	if caller.UserID == "" {
+		return value_object.NewViewer(caller.UserID, caller.GroupIDs, false), nil
+	}
+	held, err := a.permissions.EffectivePermissions(ctx, caller.UserID)
+	if err != nil {
+		return value_object.Viewer{}, fmt.Errorf("failed to find permissions: %w", err)
+	}
+	for _, permission := range held {
+		if permission == uservo.PermissionUserAdmin {
+			return value_object.NewViewer(caller.UserID, caller.GroupIDs, true), nil
+		}
+	}
+	return value_object.NewViewer(caller.UserID, caller.GroupIDs, false), nil
+}
+
+// require returns an error unless the caller holds the required share level on the record.
+// Callers who cannot read the record get a NotFoundError so that its existence is not revealed.
+func (a recordAccess) require(ctx context.Context, record entity.ExecutionRecord, caller dto.Caller, required value_object.ShareLevel) error {
+	viewer, err := a.viewerFor(ctx, caller)
+	if err != nil {
+		return err
+	}
+
+	level, ok := record.ShareLevelFor(viewer, nil)
+	if !ok || !level.Includes(required) {
+		grants, err := a.shareRepo.FindByExecutionRecordID(ctx, record.ID())
+		if err != nil {
+			return err
+		}
+		level, ok = record.ShareLevelFor(viewer, grants)
+	}
+
+	if !ok {
+		return &apperror.NotFoundError{
+			ResourceType: "ExecutionRecord",
+			ResourceID:   record.ID().String(),
+		}
+	}
+	if !level.Includes(required) {
+		return &apperror.ForbiddenError{
+			Resource: "ExecutionRecord " + record.ID().String(),
+			Action:   required.String(),
+			UserID:   caller.UserID,
+		}
+	}
+	return nil
+}
+
+// requireManager returns an error unless the caller is the executor of the record or holds user:admin.
+// Callers who cannot read the record get a NotFoundError.
+func (a recordAccess) requireManager(ctx context.Context, record entity.ExecutionRecord, caller dto.Caller, action string) error {
+	viewer, err := a.viewerFor(ctx, caller)
+	if err != nil {
+		return err
+	}
+	if record.CanManageShares(viewer) {
+		return nil
+	}
+	if err := a.require(ctx, record, caller, value_object.ShareLevelRead); err != nil {
+		return err
+	}
+	return &apperror.ForbiddenError{
+		Resource: "ExecutionRecord " + record.ID().String(),
+		Action:   action,
+		UserID:   caller.UserID,
+	}
+}
+
+// filterReadable returns the records the caller may read, preserving their order.
+func (a recordAccess) filterReadable(ctx context.Context, records []entity.ExecutionRecord, caller dto.Caller) ([]entity.ExecutionRecord, error) {
+	viewer, err := a.viewerFor(ctx, caller)
+	if err != nil {
+		return nil, err
+	}
+
+	var grants []entity.ShareGrant
+	for _, record := range records {
+		if _, ok := record.ShareLevelFor(viewer, nil); ok {
+			continue
+		}
+		// At least one record depends on shares; load the caller's shares once
+		grants, err = a.shareRepo.FindByPrincipals(ctx, caller.UserID, caller.GroupIDs)
+		if err != nil {
+			return nil, err
+		}
+		break
+	}
+
+	readable := make([]entity.ExecutionRecord, 0, len(records))
+	for _, record := range records {
+		if _, ok := record.ShareLevelFor(viewer, grants); ok {
+			readable = append(readable, record)
+		}
+	}
+	return readable, nil
+}
+
+// requireExecutable returns an error unless the caller holds execute access on the document.
+// Callers who cannot view the document get a NotFoundError so that its existence is not revealed.
+func (uc *ExecutionRecordUsecase) requireExecutable(ctx context.Context, caller dto.Caller, documentID docvo.DocumentID) error {
+	level, ok, err := uc.documents.DocumentAccessLevel(ctx, docdto.Caller{
+		UserID:   caller.UserID,
+		GroupIDs: caller.GroupIDs,
+	}, documentID.String())
+	if err != nil {
+		return fmt.Errorf("failed to check document access: %w", err)
+	}
+	if !ok {
+		return &apperror.NotFoundError{
+			ResourceType: "Document",
+			ResourceID:   documentID.String(),
+		}
+	}
+	if !level.Includes(docvo.AccessLevelExecute) {
+		return &apperror.ForbiddenError{
+			Resource: "Document " + documentID.String(),
+			Action:   docvo.AccessLevelExecute.String(),
+			UserID:   caller.UserID,
+		}
+	}
+	return nil
+}
@@ -51,6 +51,8 @@ type ExecutionRecord interface {
 	Complete() error
 	MarkAsFailed() error
 	UpdateAccessScope(scope value_object.AccessScope)
+	ShareLevelFor(viewer value_object.Viewer, grants []ShareGrant) (value_object.ShareLevel, bool)
+	CanManageShares(viewer value_object.Viewer) bool
 }
 
 // NewExecutionRecord creates a new ExecutionRecord instance.
@@ -287,3 +289,32 @@ func (e *executionRecord) UpdateAccessScope(scope value_object.AccessScope) {
 	e.accessScope = scope
 	e.updatedAt = time.Now()
 }
+
+// ShareLevelFor returns the highest share level the viewer holds on the execution record.
+// Administrators and the executor hold comment access and everyone can read public records;
+// other access comes only from share grants to the viewer or their groups.
+func (e *executionRecord) ShareLevelFor(viewer value_object.Viewer, grants []ShareGrant) (value_object.ShareLevel, bool) {
+	if e.CanManageShares(viewer) {
+		return value_object.ShareLevelComment, true
+	}
+
+	var held value_object.ShareLevel
+	if e.accessScope.IsPublic() {
+		held = value_object.ShareLevelRead
+	}
+	for _, g := range grants {
+		if !g.ExecutionRecordID().Equals(e.id) || !viewer.Matches(g.PrincipalType(), g.PrincipalID()) {
+			continue
+		}
+		if held == "" || g.Level().Includes(held) {
+			held = g.Level()
+		}
+	}
+
+	return held, held != ""
+}
+
+// CanManageShares returns true if the viewer may share the record or revoke its shares.
+func (e *executionRecord) CanManageShares(viewer value_object.Viewer) bool {
+	return viewer.IsAdmin() || (viewer.UserID() != "" && viewer.UserID() == e.executorID)
+}
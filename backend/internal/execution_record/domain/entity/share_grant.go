@@ -0,0 +1,110 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"opscore/backend/internal/execution_record/domain/value_object"
+)
+
+// shareGrant represents the sharing of an execution record with a user or a group.
+type shareGrant struct {
+	executionRecordID value_object.ExecutionRecordID
+	principalType     value_object.PrincipalType
+	principalID       string
+	level             value_object.ShareLevel
+	grantedBy         string
+	grantedAt         time.Time
+}
+
+// ShareGrant is the interface for a share grant on an execution record.
+type ShareGrant interface {
+	ExecutionRecordID() value_object.ExecutionRecordID
+	PrincipalType() value_object.PrincipalType
+	PrincipalID() string
+	Level() value_object.ShareLevel
+	GrantedBy() string
+	GrantedAt() time.Time
+}
+
+// NewShareGrant creates a new ShareGrant instance.
+func NewShareGrant(
+	executionRecordID value_object.ExecutionRecordID,
+	principalType value_object.PrincipalType,
+	principalID string,
+	level value_object.ShareLevel,
+	grantedBy string,
+) (ShareGrant, error) {
+	if executionRecordID.IsEmpty() {
+		return nil, errors.New("execution record ID cannot be empty")
+	}
+	if !principalType.IsValid() {
+		return nil, errors.New("invalid principal type")
+	}
+	if principalID == "" {
+		return nil, errors.New("principal ID cannot be empty")
+	}
+	if !level.IsValid() {
+		return nil, errors.New("invalid share level")
+	}
+
+	return &shareGrant{
+		executionRecordID: executionRecordID,
+		principalType:     principalType,
+		principalID:       principalID,
+		level:             level,
+		grantedBy:         grantedBy,
+		grantedAt:         time.Now(),
+	}, nil
+}
+
+// ReconstructShareGrant reconstructs a ShareGrant from persistence data.
+func ReconstructShareGrant(
+	executionRecordID value_object.ExecutionRecordID,
+	principalType value_object.PrincipalType,
+	principalID string,
+	level value_object.ShareLevel,
+	grantedBy string,
+	grantedAt time.Time,
+) ShareGrant {
+	return &shareGrant{
+		executionRecordID: executionRecordID,
+		principalType:     principalType,
+		principalID:       principalID,
+		level:             level,
+		grantedBy:         grantedBy,
+		grantedAt:         grantedAt,
+	}
+}
+
+// Getter methods
+
+// ExecutionRecordID returns the ID of the shared execution record.
+func (g *shareGrant) ExecutionRecordID() value_object.ExecutionRecordID {
+	return g.executionRecordID
+}
+
+// PrincipalType returns whether the record is shared with a user or a group.
+func (g *shareGrant) PrincipalType() value_object.PrincipalType {
+	return g.principalType
+}
+
+// PrincipalID returns the ID of the user or group the record is shared with.
+func (g *shareGrant) PrincipalID() string {
+	return g.principalID
+}
+
+// Level returns the granted share level.
+func (g *shareGrant) Level() value_object.ShareLevel {
+	return g.level
+}
+
+// GrantedBy returns the ID of the user who shared the record.
+func (g *shareGrant) GrantedBy() string {
+	return g.grantedBy
+}
+
+// GrantedAt returns when the record was shared.
+func (g *shareGrant) GrantedAt() time.Time {
+	return g.grantedAt
+}
@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"testing"
+
+	"opscore/backend/internal/execution_record/domain/value_object"
+)
+
+func TestNewShareGrant(t *testing.T) {
+	recordID := value_object.GenerateExecutionRecordID()
+
+	tests := []struct {
+		name          string
+		recordID      value_object.ExecutionRecordID
+		principalType value_object.PrincipalType
+		principalID   string
+		level         value_object.ShareLevel
+		wantErr       bool
+	}{
+		{name: "valid group grant", recordID: recordID, principalType: value_object.PrincipalTypeGroup, principalID: "sre", level: value_object.ShareLevelRead, wantErr: false},
+		{name: "empty record ID", recordID: "", principalType: value_object.PrincipalTypeUser, principalID: "user-1", level: value_object.ShareLevelRead, wantErr: true},
+		{name: "invalid principal type", recordID: recordID, principalType: "team", principalID: "sre", level: value_object.ShareLevelRead, wantErr: true},
+		{name: "empty principal ID", recordID: recordID, principalType: value_object.PrincipalTypeUser, principalID: "", level: value_object.ShareLevelRead, wantErr: true},
+		{name: "invalid level", recordID: recordID, principalType: value_object.PrincipalTypeUser, principalID: "user-1", level: "edit", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewShareGrant(tt.recordID, tt.principalType, tt.principalID, tt.level, "user-123")
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewShareGrant() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExecutionRecord_ShareLevelFor(t *testing.T) {
+	record := createTestExecutionRecord(t)
+	other := createTestExecutionRecord(t)
+
+	readGrant, _ := NewShareGrant(record.ID(), value_object.PrincipalTypeGroup, "sre", value_object.ShareLevelRead, "user-123")
+	commentGrant, _ := NewShareGrant(record.ID(), value_object.PrincipalTypeUser, "user-2", value_object.ShareLevelComment, "user-123")
+	otherGrant, _ := NewShareGrant(other.ID(), value_object.PrincipalTypeUser, "user-3", value_object.ShareLevelComment, "user-123")
+	grants := []ShareGrant{readGrant, commentGrant, otherGrant}
+
+	tests := []struct {
+		name      string
+		viewer    value_object.Viewer
+		wantLevel value_object.ShareLevel
+		wantOK    bool
+	}{
+		{name: "executor", viewer: value_object.NewViewer("user-123", nil, false), wantLevel: value_object.ShareLevelComment, wantOK: true},
+		{name: "admin", viewer: value_object.NewViewer("admin-1", nil, true), wantLevel: value_object.ShareLevelComment, wantOK: true},
+		{name: "group member", viewer: value_object.NewViewer("user-1", []string{"sre"}, false), wantLevel: value_object.ShareLevelRead, wantOK: true},
+		{name: "user grant beats group grant", viewer: value_object.NewViewer("user-2", []string{"sre"}, false), wantLevel: value_object.ShareLevelComment, wantOK: true},
+		{name: "grant on another record", viewer: value_object.NewViewer("user-3", nil, false), wantLevel: "", wantOK: false},
+		{name: "anonymous", viewer: value_object.NewViewer("", nil, false), wantLevel: "", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			level, ok := record.ShareLevelFor(tt.viewer, grants)
+			if level != tt.wantLevel || ok != tt.wantOK {
+				t.Errorf("ShareLevelFor() = (%v, %v), want (%v, %v)", level, ok, tt.wantLevel, tt.wantOK)
+			}
+		})
+	}
+
+	t.Run("public record is readable by everyone", func(t *testing.T) {
+		record.UpdateAccessScope(value_object.AccessScopePublic)
+		level, ok := record.ShareLevelFor(value_object.NewViewer("user-9", nil, false), nil)
+		if !ok || level != value_object.ShareLevelRead {
+			t.Errorf("ShareLevelFor() = (%v, %v), want (read, true)", level, ok)
+		}
+	})
+}
@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/value_object"
+)
+
+// ShareGrantRepository defines the interface for execution record share persistence.
+type ShareGrantRepository interface {
+	// Save creates a share grant or replaces the level of an existing grant for the same principal.
+	Save(ctx context.Context, grant entity.ShareGrant) error
+
+	// Delete removes the share grant of a principal from an execution record.
+	Delete(ctx context.Context, recordID value_object.ExecutionRecordID, principalType value_object.PrincipalType, principalID string) error
+
+	// FindByExecutionRecordID retrieves all share grants of an execution record.
+	FindByExecutionRecordID(ctx context.Context, recordID value_object.ExecutionRecordID) ([]entity.ShareGrant, error)
+
+	// FindByPrincipals retrieves the share grants given to a user or to any of the given groups.
+	FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.ShareGrant, error)
+}
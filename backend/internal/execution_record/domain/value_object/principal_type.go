@@ -0,0 +1,37 @@
+package value_object
+
+import "errors"
+
+// PrincipalType represents the kind of principal an execution record is shared with.
+type PrincipalType string
+
+const (
+	// PrincipalTypeUser shares the record with a single user.
+	PrincipalTypeUser PrincipalType = "user"
+	// PrincipalTypeGroup shares the record with every member of a group.
+	PrincipalTypeGroup PrincipalType = "group"
+)
+
+// NewPrincipalType creates a new PrincipalType from a string.
+func NewPrincipalType(principalType string) (PrincipalType, error) {
+	p := PrincipalType(principalType)
+	if !p.IsValid() {
+		return "", errors.New("invalid principal type: must be 'user' or 'group'")
+	}
+	return p, nil
+}
+
+// IsValid checks if the PrincipalType is valid.
+func (p PrincipalType) IsValid() bool {
+	return p == PrincipalTypeUser || p == PrincipalTypeGroup
+}
+
+// String returns the string representation of PrincipalType.
+func (p PrincipalType) String() string {
+	return string(p)
+}
+
+// Equals checks if two PrincipalTypes are equal.
+func (p PrincipalType) Equals(other PrincipalType) bool {
+	return p == other
+}
@@ -0,0 +1,50 @@
+package value_object
+
+import "errors"
+
+// ShareLevel represents the level of access a share grant gives on an execution record.
+// Comment access always includes read access.
+type ShareLevel string
+
+const (
+	// ShareLevelRead allows reading the record, its steps and its attachments.
+	ShareLevelRead ShareLevel = "read"
+	// ShareLevelComment allows adding notes and attachments in addition to reading.
+	ShareLevelComment ShareLevel = "comment"
+)
+
+// NewShareLevel creates a new ShareLevel from a string.
+func NewShareLevel(level string) (ShareLevel, error) {
+	shareLevel := ShareLevel(level)
+	if !shareLevel.IsValid() {
+		return "", errors.New("invalid share level: must be 'read' or 'comment'")
+	}
+	return shareLevel, nil
+}
+
+// IsValid checks if the ShareLevel is valid.
+func (s ShareLevel) IsValid() bool {
+	return s == ShareLevelRead || s == ShareLevelComment
+}
+
+// String returns the string representation of ShareLevel.
+func (s ShareLevel) String() string {
+	return string(s)
+}
+
+// Equals checks if two ShareLevels are equal.
+func (s ShareLevel) Equals(other ShareLevel) bool {
+	return s == other
+}
+
+// Includes returns true if holding this level also satisfies the required level.
+func (s ShareLevel) Includes(required ShareLevel) bool {
+	switch s {
+	case ShareLevelComment:
+		return required.IsValid()
+	case ShareLevelRead:
+		return required == ShareLevelRead
+	default:
+		return false
+	}
+}
@@ -0,0 +1,77 @@
+package value_object
+
+import "testing"
+
+func TestNewShareLevel(t *testing.T) {
+	tests := []struct {
+		name    string
+		level   string
+		wantErr bool
+	}{
+		{name: "valid read", level: "read", wantErr: false},
+		{name: "valid comment", level: "comment", wantErr: false},
+		{name: "invalid level", level: "edit", wantErr: true},
+		{name: "empty level", level: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewShareLevel(tt.level)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewShareLevel() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !tt.wantErr && got.String() != tt.level {
+				t.Errorf("NewShareLevel() = %v, want %v", got, tt.level)
+			}
+		})
+	}
+}
+
+func TestShareLevel_Includes(t *testing.T) {
+	tests := []struct {
+		name     string
+		held     ShareLevel
+		required ShareLevel
+		want     bool
+	}{
+		{name: "comment includes read", held: ShareLevelComment, required: ShareLevelRead, want: true},
+		{name: "comment includes comment", held: ShareLevelComment, required: ShareLevelComment, want: true},
+		{name: "read includes read", held: ShareLevelRead, required: ShareLevelRead, want: true},
+		{name: "read does not include comment", held: ShareLevelRead, required: ShareLevelComment, want: false},
+		{name: "empty includes nothing", held: "", required: ShareLevelRead, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.held.Includes(tt.required); got != tt.want {
+				t.Errorf("Includes() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestViewer_Matches(t *testing.T) {
+	viewer := NewViewer("user-1", []string{"sre", "dba"}, false)
+
+	tests := []struct {
+		name          string
+		principalType PrincipalType
+		principalID   string
+		want          bool
+	}{
+		{name: "same user", principalType: PrincipalTypeUser, principalID: "user-1", want: true},
+		{name: "other user", principalType: PrincipalTypeUser, principalID: "user-2", want: false},
+		{name: "member group", principalType: PrincipalTypeGroup, principalID: "dba", want: true},
+		{name: "other group", principalType: PrincipalTypeGroup, principalID: "dev", want: false},
+		{name: "user ID as group", principalType: PrincipalTypeGroup, principalID: "user-1", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := viewer.Matches(tt.principalType, tt.principalID); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
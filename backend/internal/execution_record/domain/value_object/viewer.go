@@ -0,0 +1,49 @@
+package value_object
+
+// Viewer represents the user on whose behalf an execution record is accessed.
+type Viewer struct {
+	userID   string
+	groupIDs []string
+	isAdmin  bool
+}
+
+// NewViewer creates a new Viewer.
+func NewViewer(userID string, groupIDs []string, isAdmin bool) Viewer {
+	ids := make([]string, len(groupIDs))
+	copy(ids, groupIDs)
+	return Viewer{
+		userID:   userID,
+		groupIDs: ids,
+		isAdmin:  isAdmin,
+	}
+}
+
+// UserID returns the ID of the viewing user.
+func (v Viewer) UserID() string {
+	return v.userID
+}
+
+// GroupIDs returns the IDs of the groups the viewing user belongs to.
+func (v Viewer) GroupIDs() []string {
+	return v.groupIDs
+}
+
+// IsAdmin returns true if the viewing user is an administrator.
+func (v Viewer) IsAdmin() bool {
+	return v.isAdmin
+}
+
+// Matches returns true if the principal refers to the viewing user or one of their groups.
+func (v Viewer) Matches(principalType PrincipalType, principalID string) bool {
+	switch principalType {
+	case PrincipalTypeUser:
+		return v.userID != "" && v.userID == principalID
+	case PrincipalTypeGroup:
+		for _, id := range v.groupIDs {
+			if id == principalID {
+				return true
+			}
+		}
+	}
+	return false
+}
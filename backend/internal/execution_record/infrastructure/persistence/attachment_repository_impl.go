@@ -0,0 +1,189 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+	"opscore/backend/internal/execution_record/infrastructure/storage"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AttachmentRepositoryImpl is a PostgreSQL implementation of the AttachmentRepository interface.
+// Metadata is stored in the attachments table; file content lives in the configured StorageManager.
+type AttachmentRepositoryImpl struct {
+	db             *pgxpool.Pool
+	storageManager storage.StorageManager
+}
+
+// NewAttachmentRepositoryImpl creates a new AttachmentRepositoryImpl
+func NewAttachmentRepositoryImpl(db *pgxpool.Pool, storageManager storage.StorageManager) repository.AttachmentRepository {
+	return &AttachmentRepositoryImpl{db: db, storageManager: storageManager}
+}
+
+const selectAttachmentColumns = `
+	SELECT id, execution_record_id, execution_step_id, file_name, file_size, mime_type,
+		storage_type, storage_path, uploaded_by, uploaded_at
+	FROM attachments
+`
+
+// Save persists attachment metadata.
+// If file is non-nil it is written to the attachment's storage path first; callers that
+// already stored the content through the StorageManager pass nil.
+func (r *AttachmentRepositoryImpl) Save(ctx context.Context, attachment entity.Attachment, file io.Reader) error {
+	if file != nil {
+		if _, err := r.storageManager.Store(ctx, attachment.StoragePath(), file); err != nil {
+			return fmt.Errorf("failed to store attachment file: %w", err)
+		}
+	}
+
+	query := `
+		INSERT INTO attachments (id, execution_record_id, execution_step_id, file_name, file_size, mime_type,
+			storage_type, storage_path, uploaded_by, uploaded_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);
+	`
+	_, err := r.db.Exec(ctx, query,
+		attachment.ID().String(),
+		attachment.ExecutionRecordID().String(),
+		attachment.ExecutionStepID().String(),
+		attachment.FileName(),
+		attachment.FileSize(),
+		attachment.MimeType(),
+		attachment.StorageType().String(),
+		attachment.StoragePath(),
+		attachment.UploadedBy(),
+		attachment.UploadedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save attachment: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves an attachment by ID
+func (r *AttachmentRepositoryImpl) FindByID(ctx context.Context, id value_object.AttachmentID) (entity.Attachment, error) {
+	row := r.db.QueryRow(ctx, selectAttachmentColumns+` WHERE id = $1;`, id.String())
+
+	attachment, err := scanAttachment(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find attachment by ID: %w", err)
+	}
+
+	return attachment, nil
+}
+
+// FindByExecutionRecordID retrieves attachments by execution record ID
+func (r *AttachmentRepositoryImpl) FindByExecutionRecordID(ctx context.Context, recordID value_object.ExecutionRecordID) ([]entity.Attachment, error) {
+	return r.findAttachments(ctx, selectAttachmentColumns+` WHERE execution_record_id = $1 ORDER BY uploaded_at;`, recordID.String())
+}
+
+// FindByExecutionStepID retrieves attachments by execution step ID
+func (r *AttachmentRepositoryImpl) FindByExecutionStepID(ctx context.Context, stepID value_object.ExecutionStepID) ([]entity.Attachment, error) {
+	return r.findAttachments(ctx, selectAttachmentColumns+` WHERE execution_step_id = $1 ORDER BY uploaded_at;`, stepID.String())
+}
+
+// GetFile retrieves the file content for an attachment from the StorageManager
+func (r *AttachmentRepositoryImpl) GetFile(ctx context.Context, id value_object.AttachmentID) (io.ReadCloser, error) {
+	attachment, err := r.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if attachment == nil {
+		return nil, fmt.Errorf("attachment with ID %s not found", id.String())
+	}
+
+	file, err := r.storageManager.Retrieve(ctx, attachment.StoragePath())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve attachment file: %w", err)
+	}
+
+	return file, nil
+}
+
+// Delete deletes attachment metadata by ID.
+// The stored file is removed by the use case through the StorageManager.
+func (r *AttachmentRepositoryImpl) Delete(ctx context.Context, id value_object.AttachmentID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM attachments WHERE id = $1;`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete attachment: %w", err)
+	}
+
+	return nil
+}
+
+// findAttachments runs an attachments query and converts every row
+func (r *AttachmentRepositoryImpl) findAttachments(ctx context.Context, query string, args ...interface{}) ([]entity.Attachment, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query attachments: %w", err)
+	}
+	defer rows.Close()
+
+	var attachments []entity.Attachment
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan attachment row: %w", err)
+		}
+		attachments = append(attachments, attachment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over attachment rows: %w", err)
+	}
+
+	return attachments, nil
+}
+
+// scanAttachment scans a row selected with selectAttachmentColumns into a domain entity
+func scanAttachment(row pgx.Row) (entity.Attachment, error) {
+	var id, recordID, stepID, fileName, mimeType, storageType, storagePath, uploadedBy string
+	var fileSize int64
+	var uploadedAt time.Time
+
+	if err := row.Scan(&id, &recordID, &stepID, &fileName, &fileSize, &mimeType,
+		&storageType, &storagePath, &uploadedBy, &uploadedAt); err != nil {
+		return nil, err
+	}
+
+	attachmentID, err := value_object.NewAttachmentID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid attachment ID: %w", err)
+	}
+	recID, err := value_object.NewExecutionRecordID(recordID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution record ID: %w", err)
+	}
+	stpID, err := value_object.NewExecutionStepID(stepID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution step ID: %w", err)
+	}
+	st, err := value_object.NewStorageType(storageType)
+	if err != nil {
+		return nil, fmt.Errorf("invalid storage type: %w", err)
+	}
+
+	return entity.ReconstructAttachment(
+		attachmentID,
+		recID,
+		stpID,
+		fileName,
+		fileSize,
+		mimeType,
+		st,
+		storagePath,
+		uploadedBy,
+		uploadedAt,
+	), nil
+}
@@ -0,0 +1,483 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	docvo "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExecutionRecordRepositoryImpl is a PostgreSQL implementation of the ExecutionRecordRepository interface.
+// An execution record and its steps are written in a single transaction.
+type ExecutionRecordRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewExecutionRecordRepositoryImpl creates a new ExecutionRecordRepositoryImpl
+func NewExecutionRecordRepositoryImpl(db *pgxpool.Pool) repository.ExecutionRecordRepository {
+	return &ExecutionRecordRepositoryImpl{db: db}
+}
+
+// variableValueRecord is the JSONB representation of a VariableValue.
+// Values are stored as an array of {name, value} objects so that containment
+// queries (variable_values @> '[{"name": ..., "value": ...}]') can use the GIN index.
+type variableValueRecord struct {
+	Name  string      `json:"name"`
+	Value interface{} `json:"value"`
+}
+
+const selectExecutionRecordColumns = `
+	SELECT id, document_id, document_version_id, executor_id, title, variable_values, notes,
+		status, access_scope, started_at, completed_at, created_at, updated_at
+	FROM execution_records
+`
+
+// Save persists a new execution record and its steps
+func (r *ExecutionRecordRepositoryImpl) Save(ctx context.Context, record entity.ExecutionRecord) error {
+	variableValues, err := marshalVariableValues(record.VariableValues())
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		INSERT INTO execution_records (id, document_id, document_version_id, executor_id, title, variable_values,
+			notes, status, access_scope, started_at, completed_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13);
+	`
+	_, err = tx.Exec(ctx, query,
+		record.ID().String(),
+		record.DocumentID().String(),
+		record.DocumentVersionID().String(),
+		record.ExecutorID(),
+		record.Title(),
+		variableValues,
+		record.Notes(),
+		record.Status().String(),
+		record.AccessScope().String(),
+		record.StartedAt(),
+		record.CompletedAt(),
+		record.CreatedAt(),
+		record.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save execution record: %w", err)
+	}
+
+	if err := saveSteps(ctx, tx, record); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// Update updates an existing execution record and synchronizes its steps
+func (r *ExecutionRecordRepositoryImpl) Update(ctx context.Context, record entity.ExecutionRecord) error {
+	variableValues, err := marshalVariableValues(record.VariableValues())
+	if err != nil {
+		return err
+	}
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		UPDATE execution_records
+		SET title = $1, variable_values = $2, notes = $3, status = $4, access_scope = $5,
+			completed_at = $6, updated_at = $7
+		WHERE id = $8;
+	`
+	result, err := tx.Exec(ctx, query,
+		record.Title(),
+		variableValues,
+		record.Notes(),
+		record.Status().String(),
+		record.AccessScope().String(),
+		record.CompletedAt(),
+		record.UpdatedAt(),
+		record.ID().String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update execution record: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("execution record with ID %s not found", record.ID().String())
+	}
+
+	if err := saveSteps(ctx, tx, record); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// saveSteps upserts the steps of a record and removes rows for steps no longer in the aggregate
+func saveSteps(ctx context.Context, tx pgx.Tx, record entity.ExecutionRecord) error {
+	steps := record.Steps()
+	stepIDs := make([]string, 0, len(steps))
+
+	if len(steps) > 0 {
+		query := `
+			INSERT INTO execution_steps (id, execution_record_id, step_number, description, notes, executed_at)
+			VALUES ($1, $2, $3, $4, $5, $6)
+			ON CONFLICT (id) DO UPDATE SET
+				description = EXCLUDED.description,
+				notes = EXCLUDED.notes;
+		`
+		batch := &pgx.Batch{}
+		for _, step := range steps {
+			stepIDs = append(stepIDs, step.ID().String())
+			batch.Queue(query,
+				step.ID().String(),
+				record.ID().String(),
+				step.StepNumber(),
+				step.Description(),
+				step.Notes(),
+				step.ExecutedAt(),
+			)
+		}
+
+		results := tx.SendBatch(ctx, batch)
+		for range steps {
+			if _, err := results.Exec(); err != nil {
+				results.Close()
+				return fmt.Errorf("failed to save execution step: %w", err)
+			}
+		}
+		if err := results.Close(); err != nil {
+			return fmt.Errorf("failed to save execution steps: %w", err)
+		}
+	}
+
+	_, err := tx.Exec(ctx,
+		`DELETE FROM execution_steps WHERE execution_record_id = $1 AND NOT (id = ANY($2::uuid[]));`,
+		record.ID().String(), stepIDs)
+	if err != nil {
+		return fmt.Errorf("failed to remove stale execution steps: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves an execution record by ID
+func (r *ExecutionRecordRepositoryImpl) FindByID(ctx context.Context, id value_object.ExecutionRecordID) (entity.ExecutionRecord, error) {
+	records, err := r.findRecords(ctx, selectExecutionRecordColumns+` WHERE id = $1;`, id.String())
+	if err != nil {
+		return nil, err
+	}
+	if len(records) == 0 {
+		return nil, nil
+	}
+	return records[0], nil
+}
+
+// FindByExecutorID retrieves execution records by executor ID
+func (r *ExecutionRecordRepositoryImpl) FindByExecutorID(ctx context.Context, executorID string) ([]entity.ExecutionRecord, error) {
+	return r.findRecords(ctx, selectExecutionRecordColumns+` WHERE executor_id = $1 ORDER BY started_at DESC;`, executorID)
+}
+
+// FindByDocumentID retrieves execution records by document ID
+func (r *ExecutionRecordRepositoryImpl) FindByDocumentID(ctx context.Context, documentID docvo.DocumentID) ([]entity.ExecutionRecord, error) {
+	return r.findRecords(ctx, selectExecutionRecordColumns+` WHERE document_id = $1 ORDER BY started_at DESC;`, documentID.String())
+}
+
+// Search searches for execution records based on criteria
+func (r *ExecutionRecordRepositoryImpl) Search(ctx context.Context, criteria repository.SearchCriteria) ([]entity.ExecutionRecord, error) {
+	query, args, err := buildSearchQuery(criteria)
+	if err != nil {
+		return nil, err
+	}
+	return r.findRecords(ctx, query, args...)
+}
+
+// buildSearchQuery translates SearchCriteria into a parameterized SQL query.
+// Variable filters are combined into a single JSONB containment predicate.
+func buildSearchQuery(criteria repository.SearchCriteria) (string, []interface{}, error) {
+	var conditions []string
+	var args []interface{}
+
+	addCondition := func(format string, value interface{}) {
+		args = append(args, value)
+		conditions = append(conditions, fmt.Sprintf(format, len(args)))
+	}
+
+	if criteria.ExecutorID != nil {
+		addCondition("executor_id = $%d", *criteria.ExecutorID)
+	}
+	if criteria.DocumentID != nil {
+		addCondition("document_id = $%d", criteria.DocumentID.String())
+	}
+	if criteria.Status != nil {
+		addCondition("status = $%d", criteria.Status.String())
+	}
+	if criteria.StartedFrom != nil {
+		addCondition("started_at >= $%d", *criteria.StartedFrom)
+	}
+	if criteria.StartedTo != nil {
+		addCondition("started_at <= $%d", *criteria.StartedTo)
+	}
+	if len(criteria.VariableFilters) > 0 {
+		filters := make([]variableValueRecord, len(criteria.VariableFilters))
+		for i, f := range criteria.VariableFilters {
+			filters[i] = variableValueRecord{Name: f.Name, Value: f.Value}
+		}
+		containment, err := json.Marshal(filters)
+		if err != nil {
+			return "", nil, fmt.Errorf("failed to marshal variable filters: %w", err)
+		}
+		addCondition("variable_values @> $%d::jsonb", string(containment))
+	}
+
+	query := selectExecutionRecordColumns
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+	query += " ORDER BY started_at DESC;"
+
+	return query, args, nil
+}
+
+// Delete deletes an execution record by ID; its steps and attachments are removed by cascade
+func (r *ExecutionRecordRepositoryImpl) Delete(ctx context.Context, id value_object.ExecutionRecordID) error {
+	result, err := r.db.Exec(ctx, `DELETE FROM execution_records WHERE id = $1;`, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete execution record: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("execution record with ID %s not found", id.String())
+	}
+
+	return nil
+}
+
+// executionRecordRow holds a scanned execution_records row
+type executionRecordRow struct {
+	id, documentID, documentVersionID, executorID, title, status, accessScope string
+	variableValues                                                            []byte
+	notes                                                                     *string
+	startedAt, createdAt, updatedAt                                           time.Time
+	completedAt                                                               *time.Time
+}
+
+// findRecords runs an execution_records query and loads the steps of every row
+func (r *ExecutionRecordRepositoryImpl) findRecords(ctx context.Context, query string, args ...interface{}) ([]entity.ExecutionRecord, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution records: %w", err)
+	}
+
+	var recordRows []executionRecordRow
+	for rows.Next() {
+		var row executionRecordRow
+		if err := rows.Scan(
+			&row.id,
+			&row.documentID,
+			&row.documentVersionID,
+			&row.executorID,
+			&row.title,
+			&row.variableValues,
+			&row.notes,
+			&row.status,
+			&row.accessScope,
+			&row.startedAt,
+			&row.completedAt,
+			&row.createdAt,
+			&row.updatedAt,
+		); err != nil {
+			rows.Close()
+			return nil, fmt.Errorf("failed to scan execution record row: %w", err)
+		}
+		recordRows = append(recordRows, row)
+	}
+	rows.Close()
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over execution record rows: %w", err)
+	}
+
+	if len(recordRows) == 0 {
+		return nil, nil
+	}
+
+	recordIDs := make([]string, len(recordRows))
+	for i, row := range recordRows {
+		recordIDs[i] = row.id
+	}
+
+	steps, err := r.findSteps(ctx, recordIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	records := make([]entity.ExecutionRecord, 0, len(recordRows))
+	for _, row := range recordRows {
+		record, err := toExecutionRecordEntity(row, steps[row.id])
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// findSteps retrieves the steps for the given records, grouped by record ID and ordered by step number
+func (r *ExecutionRecordRepositoryImpl) findSteps(ctx context.Context, recordIDs []string) (map[string][]entity.ExecutionStep, error) {
+	query := `
+		SELECT id, execution_record_id, step_number, description, notes, executed_at
+		FROM execution_steps
+		WHERE execution_record_id = ANY($1::uuid[])
+		ORDER BY execution_record_id, step_number;
+	`
+
+	rows, err := r.db.Query(ctx, query, recordIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query execution steps: %w", err)
+	}
+	defer rows.Close()
+
+	steps := make(map[string][]entity.ExecutionStep)
+	for rows.Next() {
+		var id, recordID, description string
+		var notes *string
+		var stepNumber int
+		var executedAt time.Time
+
+		if err := rows.Scan(&id, &recordID, &stepNumber, &description, &notes, &executedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan execution step row: %w", err)
+		}
+
+		stepID, err := value_object.NewExecutionStepID(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid execution step ID: %w", err)
+		}
+		recID, err := value_object.NewExecutionRecordID(recordID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid execution record ID: %w", err)
+		}
+
+		steps[recordID] = append(steps[recordID],
+			entity.ReconstructExecutionStep(stepID, recID, stepNumber, description, stringValue(notes), executedAt))
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over execution step rows: %w", err)
+	}
+
+	return steps, nil
+}
+
+// toExecutionRecordEntity converts database data to a domain entity
+func toExecutionRecordEntity(row executionRecordRow, steps []entity.ExecutionStep) (entity.ExecutionRecord, error) {
+	id, err := value_object.NewExecutionRecordID(row.id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution record ID: %w", err)
+	}
+	documentID, err := docvo.NewDocumentID(row.documentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+	versionID, err := docvo.NewVersionID(row.documentVersionID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document version ID: %w", err)
+	}
+	status, err := value_object.NewExecutionStatus(row.status)
+	if err != nil {
+		return nil, fmt.Errorf("invalid status: %w", err)
+	}
+	scope, err := value_object.NewAccessScope(row.accessScope)
+	if err != nil {
+		return nil, fmt.Errorf("invalid access scope: %w", err)
+	}
+	variableValues, err := unmarshalVariableValues(row.variableValues)
+	if err != nil {
+		return nil, err
+	}
+	if steps == nil {
+		steps = []entity.ExecutionStep{}
+	}
+
+	return entity.ReconstructExecutionRecord(
+		id,
+		documentID,
+		versionID,
+		row.executorID,
+		row.title,
+		variableValues,
+		stringValue(row.notes),
+		status,
+		scope,
+		steps,
+		row.startedAt,
+		row.completedAt,
+		row.createdAt,
+		row.updatedAt,
+	), nil
+}
+
+// marshalVariableValues encodes variable values for the JSONB column
+func marshalVariableValues(values []value_object.VariableValue) ([]byte, error) {
+	records := make([]variableValueRecord, len(values))
+	for i, v := range values {
+		records[i] = variableValueRecord{Name: v.Name(), Value: v.Value()}
+	}
+
+	data, err := json.Marshal(records)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal variable values: %w", err)
+	}
+	return data, nil
+}
+
+// unmarshalVariableValues decodes variable values from the JSONB column
+func unmarshalVariableValues(data []byte) ([]value_object.VariableValue, error) {
+	if len(data) == 0 {
+		return []value_object.VariableValue{}, nil
+	}
+
+	var records []variableValueRecord
+	if err := json.Unmarshal(data, &records); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal variable values: %w", err)
+	}
+
+	values := make([]value_object.VariableValue, len(records))
+	for i, rec := range records {
+		values[i] = value_object.ReconstructVariableValue(rec.Name, rec.Value)
+	}
+	return values, nil
+}
+
+// stringValue dereferences a nullable TEXT column
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
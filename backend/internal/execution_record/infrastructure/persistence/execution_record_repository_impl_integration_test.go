@@ -0,0 +1,266 @@
+package persistence
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	docvo "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+	"opscore/backend/internal/execution_record/infrastructure/storage"
+	"opscore/backend/internal/shared/infrastructure/testdb"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// executionFixture holds the rows execution records depend on
+type executionFixture struct {
+	userID     string
+	documentID docvo.DocumentID
+	versionID  docvo.VersionID
+}
+
+// seedExecutionFixture inserts a user, repository, document and document version
+func seedExecutionFixture(t *testing.T, db *pgxpool.Pool) executionFixture {
+	t.Helper()
+	ctx := context.Background()
+
+	userID := uuid.NewString()
+	repoID := uuid.NewString()
+	docID := uuid.NewString()
+	versionID := uuid.NewString()
+
+	_, err := db.Exec(ctx, `INSERT INTO users (id, name, email) VALUES ($1, 'Test User', $2);`, userID, userID+"@example.com")
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO repositories (id, name, url) VALUES ($1, 'repo', $2);`, repoID, "https://github.com/example/"+repoID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO documents (id, repository_id, owner, access_scope) VALUES ($1, $2, $3, 'public');`, docID, repoID, userID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `
+		INSERT INTO document_versions (id, document_id, version_number, file_path, commit_hash, title, doc_type, content, published_at)
+		VALUES ($1, $2, 1, 'docs/runbook.md', 'abc1234', 'Runbook', 'procedure', '# Runbook', NOW());`, versionID, docID)
+	require.NoError(t, err)
+
+	documentID, err := docvo.NewDocumentID(docID)
+	require.NoError(t, err)
+	docVersionID, err := docvo.NewVersionID(versionID)
+	require.NoError(t, err)
+
+	return executionFixture{userID: userID, documentID: documentID, versionID: docVersionID}
+}
+
+func newExecutionRecord(t *testing.T, f executionFixture, vars map[string]interface{}) entity.ExecutionRecord {
+	t.Helper()
+
+	var values []value_object.VariableValue
+	for name, value := range vars {
+		v, err := value_object.NewVariableValue(name, value)
+		require.NoError(t, err)
+		values = append(values, v)
+	}
+
+	record, err := entity.NewExecutionRecord(value_object.GenerateExecutionRecordID(), f.documentID, f.versionID, f.userID, "Monthly patching", values)
+	require.NoError(t, err)
+	return record
+}
+
+func TestExecutionRecordRepositoryImpl_Integration(t *testing.T) {
+	db := testdb.New(t)
+	repo := NewExecutionRecordRepositoryImpl(db)
+	ctx := context.Background()
+	fixture := seedExecutionFixture(t, db)
+
+	t.Run("保存した実行記録をステップ付きで取得できる", func(t *testing.T) {
+		record := newExecutionRecord(t, fixture, map[string]interface{}{"env": "prod"})
+		require.NoError(t, record.AddStep(1, "Drain node"))
+		require.NoError(t, repo.Save(ctx, record))
+
+		found, err := repo.FindByID(ctx, record.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "Monthly patching", found.Title())
+		assert.Equal(t, fixture.userID, found.ExecutorID())
+		assert.True(t, found.Status().IsInProgress())
+		require.Len(t, found.VariableValues(), 1)
+		assert.Equal(t, "prod", found.VariableValues()[0].Value())
+		require.Len(t, found.Steps(), 1)
+		assert.Equal(t, "Drain node", found.Steps()[0].Description())
+	})
+
+	t.Run("更新でステップとステータスが反映される", func(t *testing.T) {
+		record := newExecutionRecord(t, fixture, nil)
+		require.NoError(t, repo.Save(ctx, record))
+
+		require.NoError(t, record.AddStep(1, "Drain node"))
+		require.NoError(t, record.AddStep(2, "Reboot"))
+		require.NoError(t, record.UpdateStepNotes(1, "done"))
+		record.UpdateNotes("all good")
+		require.NoError(t, record.Complete())
+		require.NoError(t, repo.Update(ctx, record))
+
+		found, err := repo.FindByID(ctx, record.ID())
+		require.NoError(t, err)
+		assert.True(t, found.Status().IsCompleted())
+		assert.NotNil(t, found.CompletedAt())
+		assert.Equal(t, "all good", found.Notes())
+		require.Len(t, found.Steps(), 2)
+		assert.Equal(t, "done", found.Steps()[0].Notes())
+		assert.Equal(t, 2, found.Steps()[1].StepNumber())
+	})
+
+	t.Run("存在しない実行記録の取得はnilを返す", func(t *testing.T) {
+		found, err := repo.FindByID(ctx, value_object.GenerateExecutionRecordID())
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+
+	t.Run("変数フィルタで検索できる", func(t *testing.T) {
+		other := seedExecutionFixture(t, db)
+		staging := newExecutionRecord(t, other, map[string]interface{}{"env": "staging", "region": "tokyo"})
+		prod := newExecutionRecord(t, other, map[string]interface{}{"env": "prod", "region": "tokyo"})
+		require.NoError(t, repo.Save(ctx, staging))
+		require.NoError(t, repo.Save(ctx, prod))
+
+		results, err := repo.Search(ctx, repository.SearchCriteria{
+			DocumentID: &other.documentID,
+			VariableFilters: []repository.VariableFilter{
+				{Name: "region", Value: "tokyo"},
+				{Name: "env", Value: "prod"},
+			},
+		})
+		require.NoError(t, err)
+		require.Len(t, results, 1)
+		assert.True(t, results[0].ID().Equals(prod.ID()))
+
+		results, err = repo.Search(ctx, repository.SearchCriteria{
+			DocumentID:      &other.documentID,
+			VariableFilters: []repository.VariableFilter{{Name: "region", Value: "tokyo"}},
+		})
+		require.NoError(t, err)
+		assert.Len(t, results, 2)
+	})
+
+	t.Run("削除した実行記録は取得できない", func(t *testing.T) {
+		record := newExecutionRecord(t, fixture, nil)
+		require.NoError(t, repo.Save(ctx, record))
+		require.NoError(t, repo.Delete(ctx, record.ID()))
+
+		found, err := repo.FindByID(ctx, record.ID())
+		require.NoError(t, err)
+		assert.Nil(t, found)
+
+		assert.Error(t, repo.Delete(ctx, record.ID()))
+	})
+}
+
+func TestAttachmentRepositoryImpl_Integration(t *testing.T) {
+	db := testdb.New(t)
+	ctx := context.Background()
+
+	storageDir := filepath.Join(os.TempDir(), "test-attachment-repository-"+uuid.NewString()[:8])
+	defer os.RemoveAll(storageDir)
+	storageManager, err := storage.NewLocalStorageManager(storageDir)
+	require.NoError(t, err)
+
+	recordRepo := NewExecutionRecordRepositoryImpl(db)
+	repo := NewAttachmentRepositoryImpl(db, storageManager)
+
+	fixture := seedExecutionFixture(t, db)
+	record := newExecutionRecord(t, fixture, nil)
+	require.NoError(t, record.AddStep(1, "Take screenshot"))
+	require.NoError(t, recordRepo.Save(ctx, record))
+	step := record.Steps()[0]
+
+	t.Run("添付ファイルを保存して取得できる", func(t *testing.T) {
+		attachmentID := value_object.GenerateAttachmentID()
+		attachment, err := entity.NewAttachment(attachmentID, record.ID(), step.ID(), "screen.png", 4, "image/png",
+			value_object.StorageTypeLocal, filepath.Join(record.ID().String(), attachmentID.String()+".png"), fixture.userID)
+		require.NoError(t, err)
+
+		require.NoError(t, repo.Save(ctx, attachment, bytes.NewReader([]byte("data"))))
+
+		found, err := repo.FindByID(ctx, attachmentID)
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.Equal(t, "screen.png", found.FileName())
+		assert.Equal(t, int64(4), found.FileSize())
+		assert.Equal(t, fixture.userID, found.UploadedBy())
+
+		byRecord, err := repo.FindByExecutionRecordID(ctx, record.ID())
+		require.NoError(t, err)
+		assert.Len(t, byRecord, 1)
+
+		byStep, err := repo.FindByExecutionStepID(ctx, step.ID())
+		require.NoError(t, err)
+		assert.Len(t, byStep, 1)
+
+		file, err := repo.GetFile(ctx, attachmentID)
+		require.NoError(t, err)
+		defer file.Close()
+		content, err := io.ReadAll(file)
+		require.NoError(t, err)
+		assert.Equal(t, "data", string(content))
+
+		require.NoError(t, repo.Delete(ctx, attachmentID))
+		found, err = repo.FindByID(ctx, attachmentID)
+		require.NoError(t, err)
+		assert.Nil(t, found)
+	})
+}
+
+func TestShareGrantRepositoryImpl_Integration(t *testing.T) {
+	db := testdb.New(t)
+	recordRepo := NewExecutionRecordRepositoryImpl(db)
+	repo := NewShareGrantRepositoryImpl(db)
+	ctx := context.Background()
+	fixture := seedExecutionFixture(t, db)
+
+	record := newExecutionRecord(t, fixture, nil)
+	require.NoError(t, recordRepo.Save(ctx, record))
+
+	t.Run("共有を保存すると同じ主体のレベルが上書きされる", func(t *testing.T) {
+		grant, err := entity.NewShareGrant(record.ID(), value_object.PrincipalTypeGroup, "sre", value_object.ShareLevelRead, fixture.userID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, grant))
+
+		grant, err = entity.NewShareGrant(record.ID(), value_object.PrincipalTypeGroup, "sre", value_object.ShareLevelComment, fixture.userID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, grant))
+
+		grants, err := repo.FindByExecutionRecordID(ctx, record.ID())
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, value_object.ShareLevelComment, grants[0].Level())
+	})
+
+	t.Run("ユーザーまたは所属グループへの共有を検索できる", func(t *testing.T) {
+		userGrant, err := entity.NewShareGrant(record.ID(), value_object.PrincipalTypeUser, "user-share-1", value_object.ShareLevelRead, fixture.userID)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, userGrant))
+
+		grants, err := repo.FindByPrincipals(ctx, "user-share-1", nil)
+		require.NoError(t, err)
+		assert.Len(t, grants, 1)
+
+		grants, err = repo.FindByPrincipals(ctx, "user-share-1", []string{"sre"})
+		require.NoError(t, err)
+		assert.Len(t, grants, 2)
+	})
+
+	t.Run("共有を削除できる", func(t *testing.T) {
+		require.NoError(t, repo.Delete(ctx, record.ID(), value_object.PrincipalTypeGroup, "sre"))
+
+		grants, err := repo.FindByExecutionRecordID(ctx, record.ID())
+		require.NoError(t, err)
+		require.Len(t, grants, 1)
+		assert.Equal(t, value_object.PrincipalTypeUser, grants[0].PrincipalType())
+	})
+}
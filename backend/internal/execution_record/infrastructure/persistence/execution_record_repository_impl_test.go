@@ -0,0 +1,85 @@
+package persistence
+
+import (
+	"testing"
+	"time"
+
+	docvo "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildSearchQuery(t *testing.T) {
+	t.Run("条件なしでWHERE句を含まない", func(t *testing.T) {
+		query, args, err := buildSearchQuery(repository.SearchCriteria{})
+
+		require.NoError(t, err)
+		assert.NotContains(t, query, "WHERE")
+		assert.Contains(t, query, "ORDER BY started_at DESC")
+		assert.Empty(t, args)
+	})
+
+	t.Run("すべての条件がプレースホルダー付きで連結される", func(t *testing.T) {
+		executorID := "11111111-1111-1111-1111-111111111111"
+		documentID, err := docvo.NewDocumentID("22222222-2222-2222-2222-222222222222")
+		require.NoError(t, err)
+		status := value_object.ExecutionStatusCompleted
+		from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+		to := time.Date(2025, 12, 31, 0, 0, 0, 0, time.UTC)
+
+		query, args, err := buildSearchQuery(repository.SearchCriteria{
+			ExecutorID:  &executorID,
+			DocumentID:  &documentID,
+			Status:      &status,
+			StartedFrom: &from,
+			StartedTo:   &to,
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, query, "executor_id = $1 AND document_id = $2 AND status = $3 AND started_at >= $4 AND started_at <= $5")
+		assert.Equal(t, []interface{}{executorID, documentID.String(), "completed", from, to}, args)
+	})
+
+	t.Run("変数フィルタは単一のJSONB包含条件になる", func(t *testing.T) {
+		query, args, err := buildSearchQuery(repository.SearchCriteria{
+			VariableFilters: []repository.VariableFilter{
+				{Name: "env", Value: "prod"},
+				{Name: "replicas", Value: 3},
+			},
+		})
+
+		require.NoError(t, err)
+		assert.Contains(t, query, "variable_values @> $1::jsonb")
+		require.Len(t, args, 1)
+		assert.JSONEq(t, `[{"name":"env","value":"prod"},{"name":"replicas","value":3}]`, args[0].(string))
+	})
+}
+
+func TestVariableValuesRoundTrip(t *testing.T) {
+	t.Run("変数値をJSONBとの間で相互変換できる", func(t *testing.T) {
+		env, err := value_object.NewVariableValue("env", "prod")
+		require.NoError(t, err)
+		dryRun, err := value_object.NewVariableValue("dry_run", true)
+		require.NoError(t, err)
+
+		data, err := marshalVariableValues([]value_object.VariableValue{env, dryRun})
+		require.NoError(t, err)
+
+		values, err := unmarshalVariableValues(data)
+		require.NoError(t, err)
+		require.Len(t, values, 2)
+		assert.Equal(t, "env", values[0].Name())
+		assert.Equal(t, "prod", values[0].Value())
+		assert.Equal(t, "dry_run", values[1].Name())
+		assert.Equal(t, true, values[1].Value())
+	})
+
+	t.Run("NULLは空スライスになる", func(t *testing.T) {
+		values, err := unmarshalVariableValues(nil)
+		require.NoError(t, err)
+		assert.Empty(t, values)
+	})
+}
@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/execution_record/domain/entity"
+	"opscore/backend/internal/execution_record/domain/repository"
+	"opscore/backend/internal/execution_record/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ShareGrantRepositoryImpl is a PostgreSQL implementation of the ShareGrantRepository interface.
+type ShareGrantRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewShareGrantRepositoryImpl creates a new ShareGrantRepositoryImpl
+func NewShareGrantRepositoryImpl(db *pgxpool.Pool) repository.ShareGrantRepository {
+	return &ShareGrantRepositoryImpl{db: db}
+}
+
+const selectShareGrantColumns = `
+	SELECT execution_record_id, principal_type, principal_id, share_level, granted_by, granted_at
+	FROM execution_record_shares
+`
+
+// Save creates a share grant or replaces the level of an existing grant for the same principal
+func (r *ShareGrantRepositoryImpl) Save(ctx context.Context, grant entity.ShareGrant) error {
+	query := `
+		INSERT INTO execution_record_shares (execution_record_id, principal_type, principal_id, share_level, granted_by, granted_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (execution_record_id, principal_type, principal_id) DO UPDATE SET
+			share_level = EXCLUDED.share_level,
+			granted_by = EXCLUDED.granted_by,
+			granted_at = EXCLUDED.granted_at;
+	`
+	_, err := r.db.Exec(ctx, query,
+		grant.ExecutionRecordID().String(),
+		grant.PrincipalType().String(),
+		grant.PrincipalID(),
+		grant.Level().String(),
+		grant.GrantedBy(),
+		grant.GrantedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save share grant: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes the share grant of a principal from an execution record
+func (r *ShareGrantRepositoryImpl) Delete(ctx context.Context, recordID value_object.ExecutionRecordID, principalType value_object.PrincipalType, principalID string) error {
+	query := `
+		DELETE FROM execution_record_shares
+		WHERE execution_record_id = $1 AND principal_type = $2 AND principal_id = $3;
+	`
+
+	if _, err := r.db.Exec(ctx, query, recordID.String(), principalType.String(), principalID); err != nil {
+		return fmt.Errorf("failed to delete share grant: %w", err)
+	}
+
+	return nil
+}
+
+// FindByExecutionRecordID retrieves all share grants of an execution record
+func (r *ShareGrantRepositoryImpl) FindByExecutionRecordID(ctx context.Context, recordID value_object.ExecutionRecordID) ([]entity.ShareGrant, error) {
+	query := selectShareGrantColumns + `
+		WHERE execution_record_id = $1
+		ORDER BY principal_type, principal_id;
+	`
+
+	return r.findGrants(ctx, query, recordID.String())
+}
+
+// FindByPrincipals retrieves the share grants given to a user or to any of the given groups
+func (r *ShareGrantRepositoryImpl) FindByPrincipals(ctx context.Context, userID string, groupIDs []string) ([]entity.ShareGrant, error) {
+	query := selectShareGrantColumns + `
+		WHERE (principal_type = 'user' AND principal_id = $1)
+			OR (principal_type = 'group' AND principal_id = ANY($2))
+		ORDER BY execution_record_id;
+	`
+
+	if groupIDs == nil {
+		groupIDs = []string{}
+	}
+
+	return r.findGrants(ctx, query, userID, groupIDs)
+}
+
+// findGrants runs a query returning share grant rows
+func (r *ShareGrantRepositoryImpl) findGrants(ctx context.Context, query string, args ...interface{}) ([]entity.ShareGrant, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query share grants: %w", err)
+	}
+	defer rows.Close()
+
+	grants := []entity.ShareGrant{}
+	for rows.Next() {
+		grant, err := scanShareGrant(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan share grant row: %w", err)
+		}
+		grants = append(grants, grant)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over share grant rows: %w", err)
+	}
+
+	return grants, nil
+}
+
+// scanShareGrant scans a single share grant row and converts it to a domain entity
+func scanShareGrant(row pgx.Row) (entity.ShareGrant, error) {
+	var recordID, principalType, principalID, level, grantedBy string
+	var grantedAt time.Time
+
+	if err := row.Scan(&recordID, &principalType, &principalID, &level, &grantedBy, &grantedAt); err != nil {
+		return nil, err
+	}
+
+	id, err := value_object.NewExecutionRecordID(recordID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid execution record ID: %w", err)
+	}
+	pt, err := value_object.NewPrincipalType(principalType)
+	if err != nil {
+		return nil, err
+	}
+	shareLevel, err := value_object.NewShareLevel(level)
+	if err != nil {
+		return nil, err
+	}
+
+	return entity.ReconstructShareGrant(id, pt, principalID, shareLevel, grantedBy, grantedAt), nil
+}
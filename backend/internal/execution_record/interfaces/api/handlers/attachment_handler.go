@@ -13,13 +13,13 @@ import (
 
 // AttachmentUsecase defines the interface for attachment business logic.
 type AttachmentUsecase interface {
-	UploadAttachment(ctx context.Context, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error)
-	GetAttachment(ctx context.Context, attachmentID string) (*dto.AttachmentResponse, error)
-	GetAttachmentFile(ctx context.Context, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error)
-	ListAttachmentsByRecordID(ctx context.Context, recordID string) ([]*dto.AttachmentResponse, error)
-	ListAttachmentsByStepID(ctx context.Context, stepID string) ([]*dto.AttachmentResponse, error)
+	UploadAttachment(ctx context.Context, caller dto.Caller, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error)
+	GetAttachment(ctx context.Context, caller dto.Caller, attachmentID string) (*dto.AttachmentResponse, error)
+	GetAttachmentFile(ctx context.Context, caller dto.Caller, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error)
+	ListAttachmentsByRecordID(ctx context.Context, caller dto.Caller, recordID string) ([]*dto.AttachmentResponse, error)
+	ListAttachmentsByStepID(ctx context.Context, caller dto.Caller, stepID string) ([]*dto.AttachmentResponse, error)
 	DeleteAttachment(ctx context.Context, attachmentID string) error
-	GetAttachmentURL(ctx context.Context, attachmentID string, expirationMinutes int) (string, error)
+	GetAttachmentURL(ctx context.Context, caller dto.Caller, attachmentID string, expirationMinutes int) (string, error)
 }
 
 // AttachmentHandler handles HTTP requests for attachments.
@@ -90,7 +90,7 @@ func (h *AttachmentHandler) UploadAttachment(c *gin.Context) {
 		File:              file,
 	}
 
-	resp, err := h.usecase.UploadAttachment(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.UploadAttachment(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -117,7 +117,7 @@ func (h *AttachmentHandler) GetAttachment(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.usecase.GetAttachment(c.Request.Context(), attachmentID)
+	resp, err := h.usecase.GetAttachment(c.Request.Context(), callerFromContext(c), attachmentID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -144,7 +144,7 @@ func (h *AttachmentHandler) DownloadAttachment(c *gin.Context) {
 		return
 	}
 
-	file, attachment, err := h.usecase.GetAttachmentFile(c.Request.Context(), attachmentID)
+	file, attachment, err := h.usecase.GetAttachmentFile(c.Request.Context(), callerFromContext(c), attachmentID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -173,7 +173,7 @@ func (h *AttachmentHandler) ListAttachments(c *gin.Context) {
 		return
 	}
 
-	attachments, err := h.usecase.ListAttachmentsByRecordID(c.Request.Context(), recordID)
+	attachments, err := h.usecase.ListAttachmentsByRecordID(c.Request.Context(), callerFromContext(c), recordID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -205,7 +205,7 @@ func (h *AttachmentHandler) ListStepAttachments(c *gin.Context) {
 		return
 	}
 
-	attachments, err := h.usecase.ListAttachmentsByStepID(c.Request.Context(), stepID)
+	attachments, err := h.usecase.ListAttachmentsByStepID(c.Request.Context(), callerFromContext(c), stepID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -266,7 +266,7 @@ func (h *AttachmentHandler) GetAttachmentURL(c *gin.Context) {
 	// Default expiration is 60 minutes
 	expirationMinutes := 60
 
-	url, err := h.usecase.GetAttachmentURL(c.Request.Context(), attachmentID, expirationMinutes)
+	url, err := h.usecase.GetAttachmentURL(c.Request.Context(), callerFromContext(c), attachmentID, expirationMinutes)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -19,46 +19,46 @@ import (
 
 // MockAttachmentUsecase is a mock implementation of AttachmentUsecase
 type MockAttachmentUsecase struct {
-	UploadAttachmentFunc          func(ctx context.Context, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error)
-	GetAttachmentFunc             func(ctx context.Context, attachmentID string) (*dto.AttachmentResponse, error)
-	GetAttachmentFileFunc         func(ctx context.Context, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error)
-	ListAttachmentsByRecordIDFunc func(ctx context.Context, recordID string) ([]*dto.AttachmentResponse, error)
-	ListAttachmentsByStepIDFunc   func(ctx context.Context, stepID string) ([]*dto.AttachmentResponse, error)
+	UploadAttachmentFunc          func(ctx context.Context, caller dto.Caller, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error)
+	GetAttachmentFunc             func(ctx context.Context, caller dto.Caller, attachmentID string) (*dto.AttachmentResponse, error)
+	GetAttachmentFileFunc         func(ctx context.Context, caller dto.Caller, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error)
+	ListAttachmentsByRecordIDFunc func(ctx context.Context, caller dto.Caller, recordID string) ([]*dto.AttachmentResponse, error)
+	ListAttachmentsByStepIDFunc   func(ctx context.Context, caller dto.Caller, stepID string) ([]*dto.AttachmentResponse, error)
 	DeleteAttachmentFunc          func(ctx context.Context, attachmentID string) error
-	GetAttachmentURLFunc          func(ctx context.Context, attachmentID string, expirationMinutes int) (string, error)
+	GetAttachmentURLFunc          func(ctx context.Context, caller dto.Caller, attachmentID string, expirationMinutes int) (string, error)
 }
 
-func (m *MockAttachmentUsecase) UploadAttachment(ctx context.Context, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error) {
+func (m *MockAttachmentUsecase) UploadAttachment(ctx context.Context, caller dto.Caller, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error) {
 	if m.UploadAttachmentFunc != nil {
-		return m.UploadAttachmentFunc(ctx, req)
+		return m.UploadAttachmentFunc(ctx, caller, req)
 	}
 	return nil, nil
 }
 
-func (m *MockAttachmentUsecase) GetAttachment(ctx context.Context, attachmentID string) (*dto.AttachmentResponse, error) {
+func (m *MockAttachmentUsecase) GetAttachment(ctx context.Context, caller dto.Caller, attachmentID string) (*dto.AttachmentResponse, error) {
 	if m.GetAttachmentFunc != nil {
-		return m.GetAttachmentFunc(ctx, attachmentID)
+		return m.GetAttachmentFunc(ctx, caller, attachmentID)
 	}
 	return nil, nil
 }
 
-func (m *MockAttachmentUsecase) GetAttachmentFile(ctx context.Context, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error) {
+func (m *MockAttachmentUsecase) GetAttachmentFile(ctx context.Context, caller dto.Caller, attachmentID string) (io.ReadCloser, *dto.AttachmentResponse, error) {
 	if m.GetAttachmentFileFunc != nil {
-		return m.GetAttachmentFileFunc(ctx, attachmentID)
+		return m.GetAttachmentFileFunc(ctx, caller, attachmentID)
 	}
 	return nil, nil, nil
 }
 
-func (m *MockAttachmentUsecase) ListAttachmentsByRecordID(ctx context.Context, recordID string) ([]*dto.AttachmentResponse, error) {
+func (m *MockAttachmentUsecase) ListAttachmentsByRecordID(ctx context.Context, caller dto.Caller, recordID string) ([]*dto.AttachmentResponse, error) {
 	if m.ListAttachmentsByRecordIDFunc != nil {
-		return m.ListAttachmentsByRecordIDFunc(ctx, recordID)
+		return m.ListAttachmentsByRecordIDFunc(ctx, caller, recordID)
 	}
 	return nil, nil
 }
 
-func (m *MockAttachmentUsecase) ListAttachmentsByStepID(ctx context.Context, stepID string) ([]*dto.AttachmentResponse, error) {
+func (m *MockAttachmentUsecase) ListAttachmentsByStepID(ctx context.Context, caller dto.Caller, stepID string) ([]*dto.AttachmentResponse, error) {
 	if m.ListAttachmentsByStepIDFunc != nil {
-		return m.ListAttachmentsByStepIDFunc(ctx, stepID)
+		return m.ListAttachmentsByStepIDFunc(ctx, caller, stepID)
 	}
 	return nil, nil
 }
@@ -70,9 +70,9 @@ func (m *MockAttachmentUsecase) DeleteAttachment(ctx context.Context, attachment
 	return nil
 }
 
-func (m *MockAttachmentUsecase) GetAttachmentURL(ctx context.Context, attachmentID string, expirationMinutes int) (string, error) {
+func (m *MockAttachmentUsecase) GetAttachmentURL(ctx context.Context, caller dto.Caller, attachmentID string, expirationMinutes int) (string, error) {
 	if m.GetAttachmentURLFunc != nil {
-		return m.GetAttachmentURLFunc(ctx, attachmentID, expirationMinutes)
+		return m.GetAttachmentURLFunc(ctx, caller, attachmentID, expirationMinutes)
 	}
 	return "", nil
 }
@@ -85,7 +85,7 @@ func TestAttachmentHandler_UploadAttachment(t *testing.T) {
 	attachmentID := value_object.GenerateAttachmentID()
 
 	mockUsecase := &MockAttachmentUsecase{
-		UploadAttachmentFunc: func(ctx context.Context, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error) {
+		UploadAttachmentFunc: func(ctx context.Context, caller dto.Caller, req *dto.UploadAttachmentRequest) (*dto.AttachmentResponse, error) {
 			return &dto.AttachmentResponse{
 				ID:                attachmentID.String(),
 				ExecutionRecordID: recordID.String(),
@@ -131,7 +131,7 @@ func TestAttachmentHandler_GetAttachment(t *testing.T) {
 	stepID := value_object.GenerateExecutionStepID()
 
 	mockUsecase := &MockAttachmentUsecase{
-		GetAttachmentFunc: func(ctx context.Context, id string) (*dto.AttachmentResponse, error) {
+		GetAttachmentFunc: func(ctx context.Context, caller dto.Caller, id string) (*dto.AttachmentResponse, error) {
 			return &dto.AttachmentResponse{
 				ID:                attachmentID.String(),
 				ExecutionRecordID: recordID.String(),
@@ -194,7 +194,7 @@ func TestAttachmentHandler_ListAttachments(t *testing.T) {
 	stepID := value_object.GenerateExecutionStepID()
 
 	mockUsecase := &MockAttachmentUsecase{
-		ListAttachmentsByRecordIDFunc: func(ctx context.Context, id string) ([]*dto.AttachmentResponse, error) {
+		ListAttachmentsByRecordIDFunc: func(ctx context.Context, caller dto.Caller, id string) ([]*dto.AttachmentResponse, error) {
 			return []*dto.AttachmentResponse{
 				{
 					ID:                value_object.GenerateAttachmentID().String(),
@@ -244,7 +244,7 @@ func TestAttachmentHandler_GetAttachmentURL(t *testing.T) {
 	attachmentID := value_object.GenerateAttachmentID()
 
 	mockUsecase := &MockAttachmentUsecase{
-		GetAttachmentURLFunc: func(ctx context.Context, id string, expirationMinutes int) (string, error) {
+		GetAttachmentURLFunc: func(ctx context.Context, caller dto.Caller, id string, expirationMinutes int) (string, error) {
 			return "", nil // Local storage returns empty
 		},
 	}
@@ -33,6 +33,8 @@ func NewExecutionRecordHandler(uc *usecase.ExecutionRecordUsecase) *ExecutionRec
 // @Success 201 {object} schema.ExecutionRecordResponse "Execution record created successfully"
 // @Failure 400 {object} map[string]string "Invalid request body"
 // @Failure 401 {object} map[string]string "User not authenticated"
+// @Failure 403 {object} map[string]string "Not allowed to execute the document"
+// @Failure 404 {object} map[string]string "Document not found"
 // @Failure 500 {object} map[string]string "Internal server error"
 // @Router /execution-records [post]
 func (h *ExecutionRecordHandler) CreateExecutionRecord(c *gin.Context) {
@@ -50,7 +52,7 @@ func (h *ExecutionRecordHandler) CreateExecutionRecord(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToCreateExecutionRecordDTO(req, executorID)
-	resp, err := h.usecase.CreateExecutionRecord(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.CreateExecutionRecord(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -77,7 +79,7 @@ func (h *ExecutionRecordHandler) GetExecutionRecord(c *gin.Context) {
 		return
 	}
 
-	resp, err := h.usecase.GetExecutionRecord(c.Request.Context(), recordID)
+	resp, err := h.usecase.GetExecutionRecord(c.Request.Context(), callerFromContext(c), recordID)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -113,7 +115,7 @@ func (h *ExecutionRecordHandler) AddStep(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToAddStepDTO(req, recordID)
-	resp, err := h.usecase.AddStep(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.AddStep(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -153,7 +155,7 @@ func (h *ExecutionRecordHandler) UpdateStepNotes(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToUpdateStepNotesDTO(req, recordID, stepNumber)
-	resp, err := h.usecase.UpdateStepNotes(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.UpdateStepNotes(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -189,7 +191,7 @@ func (h *ExecutionRecordHandler) UpdateNotes(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToUpdateNotesDTO(req, recordID)
-	resp, err := h.usecase.UpdateNotes(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.UpdateNotes(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -225,7 +227,7 @@ func (h *ExecutionRecordHandler) UpdateTitle(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToUpdateTitleDTO(req, recordID)
-	resp, err := h.usecase.UpdateTitle(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.UpdateTitle(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -255,7 +257,7 @@ func (h *ExecutionRecordHandler) Complete(c *gin.Context) {
 	req := &dto.CompleteExecutionRequest{
 		ExecutionRecordID: recordID,
 	}
-	resp, err := h.usecase.Complete(c.Request.Context(), req)
+	resp, err := h.usecase.Complete(c.Request.Context(), callerFromContext(c), req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -285,7 +287,7 @@ func (h *ExecutionRecordHandler) MarkAsFailed(c *gin.Context) {
 	req := &dto.MarkAsFailedRequest{
 		ExecutionRecordID: recordID,
 	}
-	resp, err := h.usecase.MarkAsFailed(c.Request.Context(), req)
+	resp, err := h.usecase.MarkAsFailed(c.Request.Context(), callerFromContext(c), req)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -321,7 +323,7 @@ func (h *ExecutionRecordHandler) UpdateAccessScope(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToUpdateAccessScopeDTO(req, recordID)
-	resp, err := h.usecase.UpdateAccessScope(c.Request.Context(), dtoReq)
+	resp, err := h.usecase.UpdateAccessScope(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -332,7 +334,7 @@ func (h *ExecutionRecordHandler) UpdateAccessScope(c *gin.Context) {
 
 // SearchExecutionRecords godoc
 // @Summary Search execution records
-// @Description Search and filter execution records by various criteria
+// @Description Search and filter the execution records the caller may read by various criteria
 // @Tags execution-records
 // @Produce json
 // @Param executor_id query string false "Executor User ID" example:"user-123"
@@ -352,7 +354,7 @@ func (h *ExecutionRecordHandler) SearchExecutionRecords(c *gin.Context) {
 	}
 
 	dtoReq := schema.ToSearchExecutionRecordDTO(req)
-	records, err := h.usecase.SearchExecutionRecords(c.Request.Context(), dtoReq)
+	records, err := h.usecase.SearchExecutionRecords(c.Request.Context(), callerFromContext(c), dtoReq)
 	if err != nil {
 		handleError(c, err)
 		return
@@ -392,6 +394,115 @@ func (h *ExecutionRecordHandler) DeleteExecutionRecord(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ListShares godoc
+// @Summary List execution record shares
+// @Description Lists the users and groups an execution record is shared with. Only the executor and holders of the user:admin permission may list shares.
+// @Tags execution-records
+// @Produce json
+// @Param id path string true "Execution Record ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Success 200 {object} schema.ListSharesResponse "List of shares"
+// @Failure 400 {object} map[string]string "Invalid record ID"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Execution record not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /execution-records/{id}/shares [get]
+func (h *ExecutionRecordHandler) ListShares(c *gin.Context) {
+	recordID := c.Param("id")
+	if recordID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Record ID is required"})
+		return
+	}
+
+	grants, err := h.usecase.ListShares(c.Request.Context(), callerFromContext(c), recordID)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	responses := make([]schema.ShareGrantResponse, len(grants))
+	for i, grant := range grants {
+		responses[i] = schema.FromShareGrantDTO(grant)
+	}
+
+	c.JSON(http.StatusOK, schema.ListSharesResponse{Shares: responses})
+}
+
+// ShareRecord godoc
+// @Summary Share an execution record
+// @Description Shares an execution record with a user or group at read or comment level, replacing any existing level. Only the executor and holders of the user:admin permission may share a record.
+// @Tags execution-records
+// @Accept json
+// @Produce json
+// @Param id path string true "Execution Record ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param principalType path string true "Principal type" Enums(user, group)
+// @Param principalId path string true "User ID or group ID" example:"sre"
+// @Param share body schema.ShareRecordRequest true "Share level"
+// @Success 200 {object} schema.ShareGrantResponse "Execution record shared successfully"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Execution record not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /execution-records/{id}/shares/{principalType}/{principalId} [put]
+func (h *ExecutionRecordHandler) ShareRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	if recordID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Record ID is required"})
+		return
+	}
+
+	var req schema.ShareRecordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid request body: " + err.Error()})
+		return
+	}
+
+	dtoReq := schema.ToShareRecordDTO(req, recordID, c.Param("principalType"), c.Param("principalId"))
+	resp, err := h.usecase.ShareRecord(c.Request.Context(), callerFromContext(c), dtoReq)
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.FromShareGrantDTO(resp))
+}
+
+// UnshareRecord godoc
+// @Summary Remove an execution record share
+// @Description Removes the share of a user or group from an execution record. Only the executor and holders of the user:admin permission may remove shares.
+// @Tags execution-records
+// @Param id path string true "Execution Record ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param principalType path string true "Principal type" Enums(user, group)
+// @Param principalId path string true "User ID or group ID" example:"sre"
+// @Success 204 "Share removed successfully"
+// @Failure 400 {object} map[string]string "Invalid request"
+// @Failure 403 {object} map[string]string "Permission denied"
+// @Failure 404 {object} map[string]string "Execution record not found"
+// @Failure 500 {object} map[string]string "Internal server error"
+// @Router /execution-records/{id}/shares/{principalType}/{principalId} [delete]
+func (h *ExecutionRecordHandler) UnshareRecord(c *gin.Context) {
+	recordID := c.Param("id")
+	if recordID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Record ID is required"})
+		return
+	}
+
+	err := h.usecase.UnshareRecord(c.Request.Context(), callerFromContext(c), recordID, c.Param("principalType"), c.Param("principalId"))
+	if err != nil {
+		handleError(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// callerFromContext builds the caller identity stored in the gin context by the authentication middleware.
+func callerFromContext(c *gin.Context) dto.Caller {
+	return dto.Caller{
+		UserID:   c.GetString("user_id"),
+		GroupIDs: c.GetStringSlice("user_group_ids"),
+	}
+}
+
 // handleError maps application errors to HTTP responses.
 func handleError(c *gin.Context, err error) {
 	switch {
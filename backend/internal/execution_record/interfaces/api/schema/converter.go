@@ -116,6 +116,28 @@ func ToSearchExecutionRecordDTO(req SearchExecutionRecordRequest) *dto.SearchExe
 	}
 }
 
+// ToShareRecordDTO converts API schema to application DTO.
+func ToShareRecordDTO(req ShareRecordRequest, recordID, principalType, principalID string) *dto.ShareRecordRequest {
+	return &dto.ShareRecordRequest{
+		ExecutionRecordID: recordID,
+		PrincipalType:     principalType,
+		PrincipalID:       principalID,
+		Level:             req.Level,
+	}
+}
+
+// FromShareGrantDTO converts application DTO to API schema.
+func FromShareGrantDTO(dtoResp *dto.ShareGrantResponse) ShareGrantResponse {
+	return ShareGrantResponse{
+		ExecutionRecordID: dtoResp.ExecutionRecordID,
+		PrincipalType:     dtoResp.PrincipalType,
+		PrincipalID:       dtoResp.PrincipalID,
+		Level:             dtoResp.Level,
+		GrantedBy:         dtoResp.GrantedBy,
+		GrantedAt:         dtoResp.GrantedAt,
+	}
+}
+
 // FromAttachmentDTO converts application DTO to API schema.
 func FromAttachmentDTO(dtoResp *dto.AttachmentResponse) AttachmentResponse {
 	return AttachmentResponse{
@@ -84,3 +84,23 @@ type SearchExecutionRecordRequest struct {
 	StartedFrom *time.Time `form:"started_from" time_format:"2006-01-02T15:04:05Z07:00"`
 	StartedTo   *time.Time `form:"started_to" time_format:"2006-01-02T15:04:05Z07:00"`
 }
+
+// ShareRecordRequest represents the API request to share an execution record with a user or group.
+type ShareRecordRequest struct {
+	Level string `json:"level" binding:"required,oneof=read comment"`
+}
+
+// ShareGrantResponse represents the API response for a share grant.
+type ShareGrantResponse struct {
+	ExecutionRecordID string    `json:"execution_record_id"`
+	PrincipalType     string    `json:"principal_type"`
+	PrincipalID       string    `json:"principal_id"`
+	Level             string    `json:"level"`
+	GrantedBy         string    `json:"granted_by"`
+	GrantedAt         time.Time `json:"granted_at"`
+}
+
+// ListSharesResponse represents the API response for listing the shares of an execution record.
+type ListSharesResponse struct {
+	Shares []ShareGrantResponse `json:"shares"`
+}
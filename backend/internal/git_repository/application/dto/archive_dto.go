@@ -0,0 +1,11 @@
+package dto
+
+import "io"
+
+// RegisterArchiveRequest represents the use case request for registering a repository served from an uploaded archive
+type RegisterArchiveRequest struct {
+	Name     string    // Optional display name; derived from FileName when empty
+	FileName string    // Name of the uploaded file
+	RootPath string    // Optional sub-directory of the archive the managed files are taken from
+	Content  io.Reader // tar.gz or zip archive
+}
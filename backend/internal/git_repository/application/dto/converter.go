@@ -7,11 +7,23 @@ import (
 // ToRepositoryResponse converts a domain Repository entity to RepositoryResponse DTO
 func ToRepositoryResponse(repo entity.Repository) RepositoryResponse {
 	return RepositoryResponse{
-		ID:        repo.ID(),
-		Name:      repo.Name(),
-		URL:       repo.URL(),
-		CreatedAt: repo.CreatedAt(),
-		UpdatedAt: repo.UpdatedAt(),
+		ID:                 repo.ID(),
+		Name:               repo.Name(),
+		URL:                repo.URL(),
+		Provider:           repo.Provider().String(),
+		AuthMethod:         repo.AuthMethod().String(),
+		GitHubAppID:        repo.Installation().AppID,
+		InstallationID:     repo.Installation().InstallationID,
+		Ref:                repo.Ref(),
+		RootPath:           repo.RootPath(),
+		IncludeGlobs:       repo.IncludeGlobs(),
+		ExcludeGlobs:       repo.ExcludeGlobs(),
+		TokenStatus:        repo.TokenHealth().Status.String(),
+		TokenExpiresAt:     repo.TokenHealth().ExpiresAt,
+		TokenCheckedAt:     repo.TokenHealth().CheckedAt,
+		TokenStatusMessage: repo.TokenHealth().Message,
+		CreatedAt:          repo.CreatedAt(),
+		UpdatedAt:          repo.UpdatedAt(),
 	}
 }
 
@@ -26,10 +38,22 @@ func ToRepositoryResponseList(repos []entity.Repository) []RepositoryResponse {
 
 // ToFileNode converts a domain FileNode entity to FileNode DTO
 func ToFileNode(domainFile entity.FileNode) FileNode {
-	return FileNode{
-		Path: domainFile.Path(),
-		Type: domainFile.Type(),
+	node := FileNode{
+		Path:     domainFile.Path(),
+		Name:     domainFile.Name(),
+		Type:     domainFile.Type(),
+		Size:     domainFile.Size(),
+		Children: ToFileNodeList(domainFile.Children()),
 	}
+	if commit := domainFile.LastCommit(); commit != nil {
+		node.LastCommit = &FileCommit{
+			SHA:     commit.SHA,
+			Author:  commit.Author,
+			Date:    commit.Date,
+			Message: commit.Message,
+		}
+	}
+	return node
 }
 
 // ToFileNodeList converts a slice of domain FileNode entities to a slice of FileNode DTOs
@@ -40,3 +64,47 @@ func ToFileNodeList(domainFiles []entity.FileNode) []FileNode {
 	}
 	return files
 }
+
+// ToSyncRunResponse converts a domain SyncRun entity to SyncRunResponse DTO
+func ToSyncRunResponse(run entity.SyncRun) SyncRunResponse {
+	return SyncRunResponse{
+		ID:           run.ID(),
+		RepositoryID: run.RepositoryID(),
+		Trigger:      string(run.Trigger()),
+		Status:       string(run.Status()),
+		StartedAt:    run.StartedAt(),
+		FinishedAt:   run.FinishedAt(),
+		CommitSHA:    run.CommitSHA(),
+		FilesChanged: run.FilesChanged(),
+		ErrorMessage: run.ErrorMessage(),
+	}
+}
+
+// ToSyncRunResponseList converts a slice of domain SyncRun entities to a slice of SyncRunResponse DTOs
+func ToSyncRunResponseList(runs []entity.SyncRun) []SyncRunResponse {
+	responses := make([]SyncRunResponse, 0, len(runs))
+	for _, run := range runs {
+		responses = append(responses, ToSyncRunResponse(run))
+	}
+	return responses
+}
+
+// ToGitHubAppResponse converts a domain GitHubApp entity to GitHubAppResponse DTO
+func ToGitHubAppResponse(app entity.GitHubApp) GitHubAppResponse {
+	return GitHubAppResponse{
+		ID:        app.ID(),
+		Name:      app.Name(),
+		AppID:     app.AppID(),
+		CreatedAt: app.CreatedAt(),
+		UpdatedAt: app.UpdatedAt(),
+	}
+}
+
+// ToGitHubAppResponseList converts a slice of domain GitHubApp entities to a slice of GitHubAppResponse DTOs
+func ToGitHubAppResponseList(apps []entity.GitHubApp) []GitHubAppResponse {
+	responses := make([]GitHubAppResponse, 0, len(apps))
+	for _, app := range apps {
+		responses = append(responses, ToGitHubAppResponse(app))
+	}
+	return responses
+}
@@ -15,7 +15,14 @@ func TestToRepositoryResponse(t *testing.T) {
 		"test-id",
 		"test-repo",
 		"https://github.com/test/repo.git",
+		entity.ProviderGitHub,
 		"test-token",
+		entity.AuthMethodToken,
+		"",
+		"", // ref
+		"", // rootPath
+		[]string{"docs/**/*.md"},
+		[]string{"docs/drafts/**"},
 		now,
 		now,
 	)
@@ -27,6 +34,10 @@ func TestToRepositoryResponse(t *testing.T) {
 	assert.Equal(t, repo.ID(), dto.ID)
 	assert.Equal(t, repo.Name(), dto.Name)
 	assert.Equal(t, repo.URL(), dto.URL)
+	assert.Equal(t, repo.Ref(), dto.Ref)
+	assert.Equal(t, repo.RootPath(), dto.RootPath)
+	assert.Equal(t, []string{"docs/**/*.md"}, dto.IncludeGlobs)
+	assert.Equal(t, []string{"docs/drafts/**"}, dto.ExcludeGlobs)
 	assert.Equal(t, repo.CreatedAt(), dto.CreatedAt)
 	assert.Equal(t, repo.UpdatedAt(), dto.UpdatedAt)
 }
@@ -38,7 +49,14 @@ func TestToRepositoryResponseList(t *testing.T) {
 		"test-id-1",
 		"test-repo-1",
 		"https://github.com/test/repo1.git",
+		entity.ProviderGitHub,
 		"test-token-1",
+		entity.AuthMethodToken,
+		"",
+		"",  // ref
+		"",  // rootPath
+		nil, // includeGlobs
+		nil, // excludeGlobs
 		now,
 		now,
 	)
@@ -46,7 +64,14 @@ func TestToRepositoryResponseList(t *testing.T) {
 		"test-id-2",
 		"test-repo-2",
 		"https://github.com/test/repo2.git",
+		entity.ProviderGitHub,
 		"test-token-2",
+		entity.AuthMethodToken,
+		"",
+		"",  // ref
+		"",  // rootPath
+		nil, // includeGlobs
+		nil, // excludeGlobs
 		now,
 		now,
 	)
@@ -87,6 +112,26 @@ func TestToFileNode(t *testing.T) {
 	assert.Equal(t, fileNode.Type(), dto.Type)
 }
 
+func TestToFileNode_Directory(t *testing.T) {
+	// Create a directory node with a file that has commit info
+	commit := &entity.FileCommit{SHA: "abc123", Author: "ops", Date: time.Now(), Message: "Add guide"}
+	dir := entity.NewDirectoryNode("docs", []entity.FileNode{
+		entity.NewFileNodeWithInfo("docs/guide.md", 42, commit),
+	})
+
+	// Convert to DTO
+	dto := ToFileNode(dir)
+
+	// Verify the directory and its children are converted
+	assert.Equal(t, "docs", dto.Name)
+	assert.Equal(t, "dir", dto.Type)
+	assert.Equal(t, int64(42), dto.Size)
+	assert.Len(t, dto.Children, 1)
+	assert.Equal(t, "guide.md", dto.Children[0].Name)
+	assert.Equal(t, "abc123", dto.Children[0].LastCommit.SHA)
+	assert.Equal(t, "Add guide", dto.LastCommit.Message)
+}
+
 func TestToFileNodeList(t *testing.T) {
 	// Create test file node entities
 	fileNode1 := entity.NewFileNode("README.md", "file")
@@ -1,9 +1,23 @@
 package dto
 
+import "time"
+
 // FileNode represents a file or directory within a repository
 type FileNode struct {
-	Path string
-	Type string // "file" or "dir"
+	Path       string
+	Name       string
+	Type       string // "file" or "dir"
+	Size       int64  // Size in bytes; the total size of the contained files for a directory
+	LastCommit *FileCommit
+	Children   []FileNode // Entries of a directory
+}
+
+// FileCommit represents the last commit that touched a file or directory
+type FileCommit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Message string
 }
 
 // SelectFilesRequest represents the use case request for selecting files
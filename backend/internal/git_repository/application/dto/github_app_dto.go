@@ -0,0 +1,28 @@
+package dto
+
+import "time"
+
+// RegisterGitHubAppRequest represents the use case request for registering a GitHub App
+type RegisterGitHubAppRequest struct {
+	Name       string // Optional display name; derived from the App ID when empty
+	AppID      int64  // App ID assigned by GitHub
+	PrivateKey string // PEM encoded private key generated for the App
+}
+
+// RegisterInstallationRepositoryRequest represents the use case request for registering a repository
+// that is fetched with the installation of a GitHub App
+type RegisterInstallationRepositoryRequest struct {
+	URL            string
+	InstallationID int64  // Installation of the App on the repository's owner
+	Ref            string // Optional branch or tag to sync; the default branch is tracked when empty
+	RootPath       string // Optional sub-directory the managed files are taken from; the repository root when empty
+}
+
+// GitHubAppResponse represents a registered GitHub App; the private key is never returned
+type GitHubAppResponse struct {
+	ID        string
+	Name      string
+	AppID     int64
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
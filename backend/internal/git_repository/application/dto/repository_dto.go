@@ -4,8 +4,15 @@ import "time"
 
 // RegisterRepositoryRequest represents the use case request for registering a repository
 type RegisterRepositoryRequest struct {
-	URL         string
-	AccessToken string // Optional access token for private repositories
+	URL            string
+	Name           string // Optional display name; derived from the URL when empty
+	Provider       string // Optional hosting provider ("github", "gitlab", "gitea", "generic" or "local"); detected from the URL when empty
+	AccessToken    string // Optional access token for private repositories
+	DeployKey      string // Optional SSH private key for repositories registered with an SSH URL
+	GitHubAppID    string // Optional ID of the GitHub App whose installation fetches the repository instead of an access token
+	InstallationID int64  // Installation ID of the GitHub App; required with GitHubAppID
+	Ref            string // Optional branch or tag to sync; the default branch is tracked when empty
+	RootPath       string // Optional sub-directory the managed files are taken from; the repository root when empty
 }
 
 // UpdateAccessTokenRequest represents the use case request for updating a repository's access token
@@ -13,11 +20,41 @@ type UpdateAccessTokenRequest struct {
 	AccessToken string
 }
 
+// UpdateTrackingRequest represents the use case request for changing the ref and root path a repository is synced from
+type UpdateTrackingRequest struct {
+	Ref      string // Branch or tag to sync; empty tracks the default branch
+	RootPath string // Sub-directory the managed files are taken from; empty is the repository root
+}
+
+// UpdateFileRulesRequest represents the use case request for changing the glob rules that select managed files
+type UpdateFileRulesRequest struct {
+	IncludeGlobs []string // Files matching any of these patterns become managed on each sync
+	ExcludeGlobs []string // Files matching any of these patterns are never managed by the rules
+}
+
+// UpdateRepositoryRequest represents the use case request for renaming a repository or changing its URL
+type UpdateRepositoryRequest struct {
+	Name string // New display name; empty keeps the current name
+	URL  string // New URL on the same provider; empty keeps the current URL
+}
+
 // RepositoryResponse represents the use case response for a repository
 type RepositoryResponse struct {
-	ID        string
-	Name      string
-	URL       string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                 string
+	Name               string
+	URL                string
+	Provider           string
+	AuthMethod         string
+	GitHubAppID        string // ID of the GitHub App when AuthMethod is github_app
+	InstallationID     int64  // Installation ID of the GitHub App when AuthMethod is github_app
+	Ref                string
+	RootPath           string
+	IncludeGlobs       []string
+	ExcludeGlobs       []string
+	TokenStatus        string     // unchecked, valid, expiring_soon or invalid
+	TokenExpiresAt     *time.Time // When the access token expires, if known
+	TokenCheckedAt     *time.Time // When the access token was last verified
+	TokenStatusMessage string     // Why the access token is invalid
+	CreatedAt          time.Time
+	UpdatedAt          time.Time
 }
@@ -0,0 +1,16 @@
+package dto
+
+import "time"
+
+// SyncRunResponse represents a recorded repository sync
+type SyncRunResponse struct {
+	ID           string
+	RepositoryID string
+	Trigger      string
+	Status       string
+	StartedAt    time.Time
+	FinishedAt   *time.Time // nil while the sync is running
+	CommitSHA    string
+	FilesChanged []string
+	ErrorMessage string
+}
@@ -0,0 +1,20 @@
+package dto
+
+// PushEvent represents a push notification received from a Git hosting provider's webhook
+type PushEvent struct {
+	Provider       string   // Provider that sent the webhook ("github" or "gitlab")
+	RepositoryURLs []string // Clone and web URLs of the pushed repository, used to find the registered repository
+	Ref            string   // Pushed ref, e.g. refs/heads/main
+	DefaultBranch  string   // Default branch of the repository; pushes to other branches are ignored when set
+	CommitSHA      string   // Head commit after the push
+	ChangedPaths   []string // Paths added, modified or removed by the pushed commits
+}
+
+// PushSyncResult represents the outcome of handling a push event
+type PushSyncResult struct {
+	RepositoryID        string
+	Ref                 string
+	CommitSHA           string
+	ChangedManagedFiles []string // Managed files touched by the pushed commits
+	Queued              bool     // Whether a sync was enqueued; false when the push was ignored
+}
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	domainerror "opscore/backend/internal/git_repository/domain/error"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+)
+
+// ArchiveUseCase defines the interface for repositories served from archives uploaded to the server,
+// for sites without Git hosting.
+type ArchiveUseCase interface {
+	// RegisterArchive stores an uploaded tar.gz or zip archive and registers a local repository served from it.
+	RegisterArchive(ctx context.Context, req dto.RegisterArchiveRequest) (entity.Repository, error)
+	// ReplaceArchive replaces the archive of a repository registered with RegisterArchive and syncs it.
+	ReplaceArchive(ctx context.Context, repoID string, content io.Reader) error
+}
+
+// archiveUseCase implements the ArchiveUseCase interface.
+type archiveUseCase struct {
+	repo         repository.Repository // Repository persistence layer
+	repositories RepositoryUseCase     // Registers the repositories served from the archives
+	archives     git.ArchiveStore      // Stores the uploaded archives
+	queue        SyncQueue             // Receives the syncs of replaced archives
+}
+
+// NewArchiveUseCase creates a new instance of archiveUseCase.
+func NewArchiveUseCase(repo repository.Repository, repositories RepositoryUseCase, archives git.ArchiveStore, queue SyncQueue) ArchiveUseCase {
+	return &archiveUseCase{
+		repo:         repo,
+		repositories: repositories,
+		archives:     archives,
+		queue:        queue,
+	}
+}
+
+// RegisterArchive implements the logic for registering a repository from an uploaded archive.
+// The archive is removed again when the repository cannot be registered.
+func (uc *archiveUseCase) RegisterArchive(ctx context.Context, req dto.RegisterArchiveRequest) (entity.Repository, error) {
+	name := strings.TrimSpace(req.Name)
+	if name == "" {
+		name = trimSourceSuffix(filepath.Base(filepath.ToSlash(req.FileName)))
+	}
+	if name == "" || name == "." || name == "/" {
+		name = "archive"
+	}
+
+	// 1. Store the archive
+	archiveURL, err := uc.archives.SaveArchive(req.Content)
+	if err != nil {
+		return nil, archiveError(err)
+	}
+
+	// 2. Register the repository served from it
+	repo, err := uc.repositories.Register(ctx, dto.RegisterRepositoryRequest{
+		URL:      archiveURL,
+		Name:     name,
+		Provider: entity.ProviderLocal.String(),
+		RootPath: req.RootPath,
+	})
+	if err != nil {
+		// The registration error is reported; an archive left behind is only unused disk space
+		_ = uc.archives.RemoveArchive(archiveURL)
+		return nil, err
+	}
+	return repo, nil
+}
+
+// ReplaceArchive implements the logic for uploading a new version of a repository's archive.
+func (uc *archiveUseCase) ReplaceArchive(ctx context.Context, repoID string, content io.Reader) error {
+	// 1. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+	if !uc.archives.IsUploadedArchive(repo.URL()) {
+		return toValidationFailed(domainerror.NewValidationError("repositoryId", repoID, "the repository is not served from an uploaded archive"))
+	}
+
+	// 2. Replace the archive and sync the repository from it
+	if err := uc.archives.ReplaceArchive(repo.URL(), content); err != nil {
+		return archiveError(err)
+	}
+	if !uc.queue.Enqueue(SyncJob{RepositoryID: repo.ID(), Trigger: entity.SyncTriggerManual}) {
+		return fmt.Errorf("failed to enqueue sync for repository %s: sync queue is full", repo.ID())
+	}
+	return nil
+}
+
+// archiveError turns the errors of archives that are not accepted into validation errors.
+func archiveError(err error) error {
+	switch {
+	case errors.Is(err, git.ErrUnsupportedArchive):
+		return toValidationFailed(domainerror.NewValidationError("file", "", err.Error()))
+	case errors.Is(err, git.ErrArchiveTooLarge):
+		return toValidationFailed(domainerror.NewValidationError("file", "", fmt.Sprintf("archive must be at most %d bytes", git.MaxArchiveSize)))
+	}
+	return err
+}
@@ -0,0 +1,127 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestRegisterArchive はRegisterArchiveメソッドのテストです
+func TestRegisterArchive(t *testing.T) {
+	archiveURL := "file:///var/lib/opscore/uploads/0b6c1e9e.archive"
+
+	// テスト：アップロードしたアーカイブからローカルリポジトリを登録できることを確認する
+	t.Run("アップロードしたアーカイブからローカルリポジトリを登録できる", func(t *testing.T) {
+		content := strings.NewReader("archive")
+		mockArchives := new(git.MockArchiveStore)
+		mockArchives.On("SaveArchive", content).Return(archiveURL, nil)
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByURL", mock.Anything, archiveURL).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager := new(git.MockGitManager)
+		mockGitManager.On("VerifyAccess", mock.Anything, mock.Anything).Return(git.AccessCheck{Valid: true}, nil)
+
+		uc := NewArchiveUseCase(mockRepo, NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{}), mockArchives, &fakeSyncQueue{})
+		repo, err := uc.RegisterArchive(context.Background(), dto.RegisterArchiveRequest{FileName: "runbooks.tar.gz", RootPath: "docs", Content: content})
+
+		require.NoError(t, err)
+		assert.Equal(t, "runbooks", repo.Name())
+		assert.Equal(t, archiveURL, repo.URL())
+		assert.Equal(t, entity.ProviderLocal, repo.Provider())
+		assert.Equal(t, "docs", repo.RootPath())
+		mockRepo.AssertExpectations(t)
+		mockArchives.AssertNotCalled(t, "RemoveArchive", mock.Anything)
+	})
+
+	// テスト：対応していない形式のアーカイブはバリデーションエラーになることを確認する
+	t.Run("対応していない形式のアーカイブはバリデーションエラーになる", func(t *testing.T) {
+		mockArchives := new(git.MockArchiveStore)
+		mockArchives.On("SaveArchive", mock.Anything).Return("", git.ErrUnsupportedArchive)
+
+		uc := NewArchiveUseCase(new(repository.MockRepository), new(MockRepositoryUseCase), mockArchives, &fakeSyncQueue{})
+		_, err := uc.RegisterArchive(context.Background(), dto.RegisterArchiveRequest{FileName: "runbooks.rar", Content: strings.NewReader("archive")})
+
+		var validationErr *apperror.ValidationFailedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "file", validationErr.Errors[0].Field)
+	})
+
+	// テスト：登録に失敗した場合は保存したアーカイブが削除されることを確認する
+	t.Run("登録に失敗した場合は保存したアーカイブが削除される", func(t *testing.T) {
+		mockArchives := new(git.MockArchiveStore)
+		mockArchives.On("SaveArchive", mock.Anything).Return(archiveURL, nil)
+		mockArchives.On("RemoveArchive", archiveURL).Return(nil)
+		mockRepositories := new(MockRepositoryUseCase)
+		mockRepositories.On("Register", mock.Anything, mock.Anything).Return(nil, fmt.Errorf("database error"))
+
+		uc := NewArchiveUseCase(new(repository.MockRepository), mockRepositories, mockArchives, &fakeSyncQueue{})
+		_, err := uc.RegisterArchive(context.Background(), dto.RegisterArchiveRequest{Name: "Runbooks", Content: strings.NewReader("archive")})
+
+		require.Error(t, err)
+		mockArchives.AssertExpectations(t)
+	})
+}
+
+// TestReplaceArchive はReplaceArchiveメソッドのテストです
+func TestReplaceArchive(t *testing.T) {
+	archiveURL := "file:///var/lib/opscore/uploads/0b6c1e9e.archive"
+	repo := entity.NewRepository("repo-id", "runbooks", archiveURL, entity.ProviderLocal, "")
+
+	// テスト：アーカイブを置き換えて同期が登録されることを確認する
+	t.Run("アーカイブを置き換えて同期が登録される", func(t *testing.T) {
+		content := strings.NewReader("archive")
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockArchives := new(git.MockArchiveStore)
+		mockArchives.On("IsUploadedArchive", archiveURL).Return(true)
+		mockArchives.On("ReplaceArchive", archiveURL, content).Return(nil)
+		queue := &fakeSyncQueue{}
+
+		uc := NewArchiveUseCase(mockRepo, new(MockRepositoryUseCase), mockArchives, queue)
+		err := uc.ReplaceArchive(context.Background(), "repo-id", content)
+
+		require.NoError(t, err)
+		mockArchives.AssertExpectations(t)
+		require.Len(t, queue.jobs, 1)
+		assert.Equal(t, SyncJob{RepositoryID: "repo-id", Trigger: entity.SyncTriggerManual}, queue.jobs[0])
+	})
+
+	// テスト：アップロードしたアーカイブ以外のリポジトリはバリデーションエラーになることを確認する
+	t.Run("アップロードしたアーカイブ以外のリポジトリはバリデーションエラーになる", func(t *testing.T) {
+		directoryRepo := entity.NewRepository("repo-id", "runbooks", "file:///srv/runbooks", entity.ProviderLocal, "")
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(directoryRepo, nil)
+		mockArchives := new(git.MockArchiveStore)
+		mockArchives.On("IsUploadedArchive", "file:///srv/runbooks").Return(false)
+
+		uc := NewArchiveUseCase(mockRepo, new(MockRepositoryUseCase), mockArchives, &fakeSyncQueue{})
+		err := uc.ReplaceArchive(context.Background(), "repo-id", strings.NewReader("archive"))
+
+		var validationErr *apperror.ValidationFailedError
+		assert.ErrorAs(t, err, &validationErr)
+		mockArchives.AssertNotCalled(t, "ReplaceArchive", mock.Anything, mock.Anything)
+	})
+
+	// テスト：リポジトリが存在しない場合はNotFoundエラーになることを確認する
+	t.Run("リポジトリが存在しない場合はNotFoundエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewArchiveUseCase(mockRepo, new(MockRepositoryUseCase), new(git.MockArchiveStore), &fakeSyncQueue{})
+		err := uc.ReplaceArchive(context.Background(), "missing", strings.NewReader("archive"))
+
+		var notFoundErr *apperror.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+}
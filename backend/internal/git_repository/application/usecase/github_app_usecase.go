@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	domainerror "opscore/backend/internal/git_repository/domain/error"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/google/uuid"
+)
+
+// GitHubAppUseCase defines the interface for registering GitHub Apps and the repositories fetched with their installations.
+type GitHubAppUseCase interface {
+	// RegisterApp registers a GitHub App with its App ID and private key.
+	RegisterApp(ctx context.Context, req dto.RegisterGitHubAppRequest) (entity.GitHubApp, error)
+	// ListApps retrieves all registered GitHub Apps.
+	ListApps(ctx context.Context) ([]entity.GitHubApp, error)
+	// RegisterRepository registers a GitHub repository that is fetched with an installation of the App
+	// instead of an access token.
+	RegisterRepository(ctx context.Context, appID string, req dto.RegisterInstallationRepositoryRequest) (entity.Repository, error)
+}
+
+// githubAppUseCase implements the GitHubAppUseCase interface.
+type githubAppUseCase struct {
+	apps         repository.GitHubAppRepository // Persistence for GitHub Apps
+	repositories RepositoryUseCase              // Registers the repositories of an installation
+}
+
+// NewGitHubAppUseCase creates a new instance of githubAppUseCase.
+func NewGitHubAppUseCase(apps repository.GitHubAppRepository, repositories RepositoryUseCase) GitHubAppUseCase {
+	return &githubAppUseCase{
+		apps:         apps,
+		repositories: repositories,
+	}
+}
+
+// RegisterApp implements the logic for registering a GitHub App.
+func (uc *githubAppUseCase) RegisterApp(ctx context.Context, req dto.RegisterGitHubAppRequest) (entity.GitHubApp, error) {
+	if req.AppID <= 0 {
+		return nil, toValidationFailed(domainerror.NewValidationError("appId", req.AppID, "App ID must be a positive integer"))
+	}
+	if _, err := git.ParseGitHubAppPrivateKey(req.PrivateKey); err != nil {
+		return nil, toValidationFailed(domainerror.NewValidationError("privateKey", "", "private key must be the PEM encoded RSA key generated for the GitHub App"))
+	}
+	name := strings.TrimSpace(req.Name)
+	if len(name) > maxNameLength {
+		return nil, toValidationFailed(domainerror.NewValidationError("name", name, fmt.Sprintf("name must be at most %d characters", maxNameLength)))
+	}
+	if name == "" {
+		name = fmt.Sprintf("GitHub App %d", req.AppID)
+	}
+
+	existing, err := uc.apps.FindByAppID(ctx, req.AppID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing GitHub App: %w", err)
+	}
+	if existing != nil {
+		return nil, apperror.NewConflictError("GitHubApp", fmt.Sprint(req.AppID), "GitHub App with this App ID is already registered", nil)
+	}
+
+	app := entity.NewGitHubApp(uuid.NewString(), name, req.AppID, req.PrivateKey)
+	if err := uc.apps.Save(ctx, app); err != nil {
+		return nil, fmt.Errorf("failed to save GitHub App: %w", err)
+	}
+	return app, nil
+}
+
+// ListApps implements the logic for listing GitHub Apps.
+func (uc *githubAppUseCase) ListApps(ctx context.Context) ([]entity.GitHubApp, error) {
+	apps, err := uc.apps.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve GitHub Apps: %w", err)
+	}
+	return apps, nil
+}
+
+// RegisterRepository implements the logic for registering a repository with a GitHub App installation.
+func (uc *githubAppUseCase) RegisterRepository(ctx context.Context, appID string, req dto.RegisterInstallationRepositoryRequest) (entity.Repository, error) {
+	app, err := uc.apps.FindByID(ctx, appID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find GitHub App: %w", err)
+	}
+	if app == nil {
+		return nil, apperror.NewNotFoundError("GitHubApp", appID, nil)
+	}
+
+	return uc.repositories.Register(ctx, dto.RegisterRepositoryRequest{
+		URL:            req.URL,
+		Provider:       entity.ProviderGitHub.String(),
+		GitHubAppID:    app.ID(),
+		InstallationID: req.InstallationID,
+		Ref:            req.Ref,
+		RootPath:       req.RootPath,
+	})
+}
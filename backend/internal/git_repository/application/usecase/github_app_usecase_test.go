@@ -0,0 +1,124 @@
+package repository
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAppPrivateKey generates a PEM encoded RSA private key in the form GitHub provides
+func newTestAppPrivateKey(t *testing.T) string {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+}
+
+// TestRegisterApp はRegisterAppメソッドのテストです
+func TestRegisterApp(t *testing.T) {
+	privateKey := newTestAppPrivateKey(t)
+
+	// テスト：App IDと秘密鍵でGitHub Appを登録できることを確認する
+	t.Run("App IDと秘密鍵でGitHub Appを登録できる", func(t *testing.T) {
+		mockApps := new(repository.MockGitHubAppRepository)
+		mockApps.On("FindByAppID", mock.Anything, int64(1234)).Return(nil, nil)
+		mockApps.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewGitHubAppUseCase(mockApps, new(MockRepositoryUseCase))
+		app, err := uc.RegisterApp(context.Background(), dto.RegisterGitHubAppRequest{AppID: 1234, PrivateKey: privateKey})
+
+		require.NoError(t, err)
+		assert.Equal(t, int64(1234), app.AppID())
+		assert.Equal(t, "GitHub App 1234", app.Name())
+		assert.Equal(t, privateKey, app.PrivateKey())
+		mockApps.AssertExpectations(t)
+	})
+
+	// テスト：秘密鍵が不正な場合はバリデーションエラーになることを確認する
+	t.Run("秘密鍵が不正な場合はバリデーションエラーになる", func(t *testing.T) {
+		uc := NewGitHubAppUseCase(new(repository.MockGitHubAppRepository), new(MockRepositoryUseCase))
+		_, err := uc.RegisterApp(context.Background(), dto.RegisterGitHubAppRequest{AppID: 1234, PrivateKey: "not a key"})
+
+		var validationErr *apperror.ValidationFailedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "privateKey", validationErr.Errors[0].Field)
+	})
+
+	// テスト：同じApp IDが登録済みの場合は競合エラーになることを確認する
+	t.Run("同じApp IDが登録済みの場合は競合エラーになる", func(t *testing.T) {
+		mockApps := new(repository.MockGitHubAppRepository)
+		mockApps.On("FindByAppID", mock.Anything, int64(1234)).Return(entity.NewGitHubApp("app-id", "OpsCore", 1234, privateKey), nil)
+
+		uc := NewGitHubAppUseCase(mockApps, new(MockRepositoryUseCase))
+		_, err := uc.RegisterApp(context.Background(), dto.RegisterGitHubAppRequest{AppID: 1234, PrivateKey: privateKey})
+
+		var conflictErr *apperror.ConflictError
+		assert.ErrorAs(t, err, &conflictErr)
+		mockApps.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
+
+// TestRegisterInstallationRepository はGitHub Appのインストールによるリポジトリ登録のテストです
+func TestRegisterInstallationRepository(t *testing.T) {
+	app := entity.NewGitHubApp("app-id", "OpsCore", 1234, newTestAppPrivateKey(t))
+	url := "https://github.com/example/runbooks"
+
+	// テスト：アクセストークンなしでインストールによりリポジトリを登録できることを確認する
+	t.Run("インストールによりリポジトリを登録できる", func(t *testing.T) {
+		mockApps := new(repository.MockGitHubAppRepository)
+		mockApps.On("FindByID", mock.Anything, "app-id").Return(app, nil)
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewGitHubAppUseCase(mockApps, NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{}))
+		repo, err := uc.RegisterRepository(context.Background(), "app-id", dto.RegisterInstallationRepositoryRequest{URL: url, InstallationID: 42, Ref: "main"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.AuthMethodGitHubApp, repo.AuthMethod())
+		assert.Equal(t, entity.GitHubAppInstallation{AppID: "app-id", InstallationID: 42}, repo.Installation())
+		assert.Empty(t, repo.AccessToken())
+		assert.Equal(t, "main", repo.Ref())
+		mockRepo.AssertExpectations(t)
+	})
+
+	// テスト：GitHub Appが存在しない場合はNotFoundエラーになることを確認する
+	t.Run("GitHub Appが存在しない場合はNotFoundエラーになる", func(t *testing.T) {
+		mockApps := new(repository.MockGitHubAppRepository)
+		mockApps.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewGitHubAppUseCase(mockApps, new(MockRepositoryUseCase))
+		_, err := uc.RegisterRepository(context.Background(), "missing", dto.RegisterInstallationRepositoryRequest{URL: url, InstallationID: 42})
+
+		var notFoundErr *apperror.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+	})
+
+	// テスト：GitHub以外のリポジトリやインストールIDのない登録はバリデーションエラーになることを確認する
+	t.Run("GitHub以外のリポジトリやインストールIDのない登録はバリデーションエラーになる", func(t *testing.T) {
+		uc := NewRepositoryUseCase(new(repository.MockRepository), new(git.MockGitManager), &fakeSyncQueue{})
+
+		requests := []dto.RegisterRepositoryRequest{
+			{URL: "https://gitlab.com/example/runbooks", GitHubAppID: "app-id", InstallationID: 42},
+			{URL: url, GitHubAppID: "app-id"},
+			{URL: url, GitHubAppID: "app-id", InstallationID: 42, AccessToken: "ghp_token"},
+		}
+		for _, req := range requests {
+			_, err := uc.Register(context.Background(), req)
+			var validationErr *apperror.ValidationFailedError
+			assert.ErrorAs(t, err, &validationErr)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"opscore/backend/internal/git_repository/application/dto"
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockArchiveUseCase is a mock implementation of the ArchiveUseCase interface for testing
+type MockArchiveUseCase struct {
+	mock.Mock
+}
+
+// RegisterArchive is a mock implementation of the ArchiveUseCase.RegisterArchive method
+func (m *MockArchiveUseCase) RegisterArchive(ctx context.Context, req dto.RegisterArchiveRequest) (entity.Repository, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
+
+// ReplaceArchive is a mock implementation of the ArchiveUseCase.ReplaceArchive method
+func (m *MockArchiveUseCase) ReplaceArchive(ctx context.Context, repoID string, content io.Reader) error {
+	args := m.Called(ctx, repoID, content)
+	return args.Error(0)
+}
@@ -0,0 +1,41 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/application/dto"
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGitHubAppUseCase is a mock implementation of the GitHubAppUseCase interface for testing
+type MockGitHubAppUseCase struct {
+	mock.Mock
+}
+
+// RegisterApp is a mock implementation of the GitHubAppUseCase.RegisterApp method
+func (m *MockGitHubAppUseCase) RegisterApp(ctx context.Context, req dto.RegisterGitHubAppRequest) (entity.GitHubApp, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.GitHubApp), args.Error(1)
+}
+
+// ListApps is a mock implementation of the GitHubAppUseCase.ListApps method
+func (m *MockGitHubAppUseCase) ListApps(ctx context.Context) ([]entity.GitHubApp, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.GitHubApp), args.Error(1)
+}
+
+// RegisterRepository is a mock implementation of the GitHubAppUseCase.RegisterRepository method
+func (m *MockGitHubAppUseCase) RegisterRepository(ctx context.Context, appID string, req dto.RegisterInstallationRepositoryRequest) (entity.Repository, error) {
+	args := m.Called(ctx, appID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
@@ -2,6 +2,7 @@ package repository
 
 import (
 	"context"
+	"opscore/backend/internal/git_repository/application/dto"
 	"opscore/backend/internal/git_repository/domain/entity"
 
 	"github.com/stretchr/testify/mock"
@@ -13,8 +14,8 @@ type MockRepositoryUseCase struct {
 }
 
 // Register is a mock implementation of the RepositoryUseCase.Register method
-func (m *MockRepositoryUseCase) Register(ctx context.Context, repoURL string, accessToken string) (entity.Repository, error) {
-	args := m.Called(ctx, repoURL, accessToken)
+func (m *MockRepositoryUseCase) Register(ctx context.Context, req dto.RegisterRepositoryRequest) (entity.Repository, error) {
+	args := m.Called(ctx, req)
 	if args.Get(0) == nil {
 		return nil, args.Error(1)
 	}
@@ -65,3 +66,69 @@ func (m *MockRepositoryUseCase) UpdateAccessToken(ctx context.Context, repoID st
 	args := m.Called(ctx, repoID, accessToken)
 	return args.Error(0)
 }
+
+// VerifyAccessToken is a mock implementation of the RepositoryUseCase.VerifyAccessToken method
+func (m *MockRepositoryUseCase) VerifyAccessToken(ctx context.Context, repoID string) (entity.Repository, error) {
+	args := m.Called(ctx, repoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
+
+// UpdateTracking is a mock implementation of the RepositoryUseCase.UpdateTracking method
+func (m *MockRepositoryUseCase) UpdateTracking(ctx context.Context, repoID string, req dto.UpdateTrackingRequest) (entity.Repository, error) {
+	args := m.Called(ctx, repoID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
+
+// UpdateFileRules is a mock implementation of the RepositoryUseCase.UpdateFileRules method
+func (m *MockRepositoryUseCase) UpdateFileRules(ctx context.Context, repoID string, req dto.UpdateFileRulesRequest) (entity.Repository, error) {
+	args := m.Called(ctx, repoID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
+
+// UpdateRepository is a mock implementation of the RepositoryUseCase.UpdateRepository method
+func (m *MockRepositoryUseCase) UpdateRepository(ctx context.Context, repoID string, req dto.UpdateRepositoryRequest) (entity.Repository, error) {
+	args := m.Called(ctx, repoID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Repository), args.Error(1)
+}
+
+// DeleteRepository is a mock implementation of the RepositoryUseCase.DeleteRepository method
+func (m *MockRepositoryUseCase) DeleteRepository(ctx context.Context, repoID string, cascade bool) error {
+	args := m.Called(ctx, repoID, cascade)
+	return args.Error(0)
+}
+
+// ResolveFileCommit is a mock implementation of the RepositoryUseCase.ResolveFileCommit method
+func (m *MockRepositoryUseCase) ResolveFileCommit(ctx context.Context, repoID string, filePath string) (string, error) {
+	args := m.Called(ctx, repoID, filePath)
+	return args.String(0), args.Error(1)
+}
+
+// ListManagedFiles is a mock implementation of the RepositoryUseCase.ListManagedFiles method
+func (m *MockRepositoryUseCase) ListManagedFiles(ctx context.Context, repoID string) ([]string, error) {
+	args := m.Called(ctx, repoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+// ReadManagedFile is a mock implementation of the RepositoryUseCase.ReadManagedFile method
+func (m *MockRepositoryUseCase) ReadManagedFile(ctx context.Context, repoID string, filePath string) ([]byte, error) {
+	args := m.Called(ctx, repoID, filePath)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]byte), args.Error(1)
+}
@@ -0,0 +1,51 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSyncUseCase is a mock implementation of the SyncUseCase interface for testing
+type MockSyncUseCase struct {
+	mock.Mock
+}
+
+// TriggerSync is a mock implementation of the SyncUseCase.TriggerSync method
+func (m *MockSyncUseCase) TriggerSync(ctx context.Context, repoID string) error {
+	args := m.Called(ctx, repoID)
+	return args.Error(0)
+}
+
+// ListSyncRuns is a mock implementation of the SyncUseCase.ListSyncRuns method
+func (m *MockSyncUseCase) ListSyncRuns(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error) {
+	args := m.Called(ctx, repoID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SyncRun), args.Error(1)
+}
+
+// GetLastSuccessfulSync is a mock implementation of the SyncUseCase.GetLastSuccessfulSync method
+func (m *MockSyncUseCase) GetLastSuccessfulSync(ctx context.Context, repoID string) (entity.SyncRun, error) {
+	args := m.Called(ctx, repoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.SyncRun), args.Error(1)
+}
+
+// MockSyncRunner is a mock implementation of the SyncRunner interface for testing
+type MockSyncRunner struct {
+	mock.Mock
+}
+
+// Run is a mock implementation of the SyncRunner.Run method
+func (m *MockSyncRunner) Run(ctx context.Context, job SyncJob) (entity.SyncRun, error) {
+	args := m.Called(ctx, job)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.SyncRun), args.Error(1)
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/application/dto"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockWebhookUseCase is a mock implementation of the WebhookUseCase interface for testing
+type MockWebhookUseCase struct {
+	mock.Mock
+}
+
+// HandlePush is a mock implementation of the WebhookUseCase.HandlePush method
+func (m *MockWebhookUseCase) HandlePush(ctx context.Context, event dto.PushEvent) (*dto.PushSyncResult, error) {
+	args := m.Called(ctx, event)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.PushSyncResult), args.Error(1)
+}
@@ -5,11 +5,13 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"os"
 	"path"
 	"path/filepath"
 	"regexp"
 	"strings"
 
+	"opscore/backend/internal/git_repository/application/dto"
 	apperror "opscore/backend/internal/git_repository/application/error"
 	domainerror "opscore/backend/internal/git_repository/domain/error"
 	"opscore/backend/internal/git_repository/domain/entity"
@@ -17,6 +19,7 @@ import (
 	"opscore/backend/internal/git_repository/infrastructure/git"
 
 	"github.com/google/uuid"
+	"golang.org/x/crypto/ssh"
 )
 
 // Legacy error definitions - kept for backward compatibility during migration
@@ -31,42 +34,130 @@ var (
 
 // 有効なGitリポジトリURLのパターン
 var (
-	validGitURLPattern = regexp.MustCompile(`^https://(?:github\.com|gitlab\.com|bitbucket\.org)/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(?:\.git)?$`)
+	// GitHub: https://github.com/owner/repo
+	validGitHubURLPattern = regexp.MustCompile(`^https://github\.com/[a-zA-Z0-9_.-]+/[a-zA-Z0-9_.-]+(?:\.git)?$`)
+	// GitLab (gitlab.com or self-hosted): https://host[:port]/group[/subgroup...]/project
+	validGitLabURLPattern = regexp.MustCompile(`^https://[a-zA-Z0-9.-]+(?::[0-9]+)?(?:/[a-zA-Z0-9_.-]+){2,}(?:\.git)?$`)
+	// Gitea/Forgejo over HTTPS: https://host[:port]/owner/repo
+	validGiteaURLPattern = regexp.MustCompile(`^https://[a-zA-Z0-9.-]+(?::[0-9]+)?(?:/[a-zA-Z0-9_.-]+){2,}(?:\.git)?$`)
+	// Any Git host over HTTPS: https://host[:port]/path
+	validGenericURLPattern = regexp.MustCompile(`^https://[a-zA-Z0-9.-]+(?::[0-9]+)?(?:/[a-zA-Z0-9_.~-]+)+(?:\.git)?/?$`)
 )
 
+// 追跡するブランチ・タグ名として許可するパターン（gitのref名の安全なサブセット）
+var validRefPattern = regexp.MustCompile(`^[a-zA-Z0-9_][a-zA-Z0-9_./-]*$`)
+
+// maxRefLength is the longest branch or tag name accepted for tracking.
+const maxRefLength = 255
+
+// maxNameLength is the longest repository name accepted.
+const maxNameLength = 255
+
 // RepositoryUseCase defines the interface for repository related use cases.
 type RepositoryUseCase interface {
-	Register(ctx context.Context, repoURL string, accessToken string) (entity.Repository, error) // Return created repository
+	// Register registers a new repository. The provider is detected from the URL unless given explicitly.
+	Register(ctx context.Context, req dto.RegisterRepositoryRequest) (entity.Repository, error) // Return created repository
 	// GetRepository retrieves a single repository by its ID
 	GetRepository(ctx context.Context, repoID string) (entity.Repository, error)
 	// ListRepositories retrieves all registered repositories
 	ListRepositories(ctx context.Context) ([]entity.Repository, error)
-	// ListFiles retrieves the file structure for a given repository ID.
+	// ListFiles retrieves the file tree, with sizes and last commits, for a given repository ID.
 	ListFiles(ctx context.Context, repoID string) ([]entity.FileNode, error) // Use entity.FileNode
 	// SelectFiles marks specific files within a repository as manageable.
 	SelectFiles(ctx context.Context, repoID string, filePaths []string) error
 	// GetSelectedMarkdown retrieves the concatenated content of selected Markdown files.
 	GetSelectedMarkdown(ctx context.Context, repoID string) (string, error)
-	// UpdateAccessToken updates the access token for a repository.
+	// UpdateAccessToken updates the access token for a repository and verifies it with the provider.
 	UpdateAccessToken(ctx context.Context, repoID string, accessToken string) error
+	// VerifyAccessToken verifies the access token of a repository with the provider and records its status.
+	VerifyAccessToken(ctx context.Context, repoID string) (entity.Repository, error)
+	// UpdateTracking changes the branch or tag and the root path a repository is synced from, and enqueues a sync.
+	UpdateTracking(ctx context.Context, repoID string, req dto.UpdateTrackingRequest) (entity.Repository, error)
+	// UpdateFileRules changes the glob rules that select managed files and applies them to the local copy.
+	UpdateFileRules(ctx context.Context, repoID string, req dto.UpdateFileRulesRequest) (entity.Repository, error)
+	// UpdateRepository renames a repository or changes its URL; a new URL enqueues a sync from scratch.
+	UpdateRepository(ctx context.Context, repoID string, req dto.UpdateRepositoryRequest) (entity.Repository, error)
+	// DeleteRepository deletes a repository and its local copy. With cascade its documents and execution
+	// records are deleted as well; otherwise they are kept.
+	DeleteRepository(ctx context.Context, repoID string, cascade bool) error
+	// ResolveFileCommit returns the last commit that touched a file, or the HEAD commit if filePath is empty.
+	ResolveFileCommit(ctx context.Context, repoID string, filePath string) (string, error)
+	// ListManagedFiles returns the paths of the files selected for management.
+	ListManagedFiles(ctx context.Context, repoID string) ([]string, error)
+	// ReadManagedFile reads a managed file from the local copy of the repository.
+	ReadManagedFile(ctx context.Context, repoID string, filePath string) ([]byte, error)
 }
 
 // repositoryUseCase implements the RepositoryUseCase interface.
 type repositoryUseCase struct {
 	repo       repository.Repository // Persistence for repository metadata
 	gitManager git.GitManager        // For interacting with Git repositories
+	queue      SyncQueue             // Runs repository syncs in the background
 }
 
 // NewRepositoryUseCase creates a new instance of repositoryUseCase.
-func NewRepositoryUseCase(repo repository.Repository, gitManager git.GitManager) RepositoryUseCase {
+func NewRepositoryUseCase(repo repository.Repository, gitManager git.GitManager, queue SyncQueue) RepositoryUseCase {
 	return &repositoryUseCase{
 		repo:       repo,
 		gitManager: gitManager, // Initialize GitManager
+		queue:      queue,
 	}
 }
 
-// validateRepositoryURL validates that the URL is properly formatted and uses supported schemes
-func validateRepositoryURL(repoURL string) error {
+// resolveProvider returns the explicitly requested provider, or detects it from the URL host.
+// github.com is GitHub; gitlab.com and hosts whose name contains "gitlab" are GitLab;
+// codeberg.org and hosts whose name contains "gitea" or "forgejo" are Gitea; file:// URLs are local sources.
+func resolveProvider(repoURL string, provider string) (entity.Provider, error) {
+	if provider != "" {
+		p, err := entity.ParseProvider(provider)
+		if err != nil {
+			return "", domainerror.NewValidationError("provider", provider, "provider must be one of: github, gitlab, gitea, generic, local")
+		}
+		return p, nil
+	}
+	if strings.HasPrefix(repoURL, "file://") {
+		return entity.ProviderLocal, nil
+	}
+
+	host, _, err := git.ParseRemoteURL(repoURL)
+	if err != nil {
+		return "", domainerror.NewURLValidationError("url", repoURL, "invalid repository URL format", false)
+	}
+
+	host = strings.ToLower(host)
+	switch {
+	case host == "github.com":
+		return entity.ProviderGitHub, nil
+	case host == "gitlab.com" || strings.Contains(host, "gitlab"):
+		return entity.ProviderGitLab, nil
+	case host == "codeberg.org" || strings.Contains(host, "gitea") || strings.Contains(host, "forgejo"):
+		return entity.ProviderGitea, nil
+	}
+	return "", domainerror.NewValidationError("provider", provider, "provider could not be detected from the URL; specify github, gitlab, gitea or generic")
+}
+
+// validateRepositoryURL validates that the URL is properly formatted and uses supported schemes.
+// SSH URLs are accepted only for repositories authenticated with a deploy key.
+func validateRepositoryURL(repoURL string, provider entity.Provider, hasDeployKey bool) error {
+	if provider == entity.ProviderLocal {
+		if _, err := git.ParseFileURL(repoURL); err != nil {
+			return domainerror.NewURLValidationError("url", repoURL, "local repository URL must match pattern: file:///path/to/directory-or-archive", false)
+		}
+		return nil
+	}
+	if git.IsSSHURL(repoURL) {
+		if !hasDeployKey {
+			return domainerror.NewURLValidationError("url", repoURL, "SSH repository URLs require a deploy key", true)
+		}
+		if _, _, err := git.ParseRemoteURL(repoURL); err != nil {
+			return domainerror.NewURLValidationError("url", repoURL, "invalid SSH repository URL format", false)
+		}
+		return nil
+	}
+	if hasDeployKey {
+		return domainerror.NewURLValidationError("url", repoURL, "deploy keys can only be used with SSH repository URLs", true)
+	}
+
 	// 1. Parse the URL
 	parsedURL, err := url.ParseRequestURI(repoURL)
 	if err != nil {
@@ -78,28 +169,186 @@ func validateRepositoryURL(repoURL string) error {
 		return domainerror.NewURLValidationError("url", repoURL, "unsupported repository URL scheme: only https is supported", true)
 	}
 
-	// 3. Validate against whitelist pattern
-	if !validGitURLPattern.MatchString(repoURL) {
-		return domainerror.NewURLValidationError("url", repoURL, "repository URL must match pattern: https://github.com|gitlab.com|bitbucket.org/owner/repo", false)
+	// 3. Validate against the provider's pattern
+	switch provider {
+	case entity.ProviderGitHub:
+		if !validGitHubURLPattern.MatchString(repoURL) {
+			return domainerror.NewURLValidationError("url", repoURL, "repository URL must match pattern: https://github.com/owner/repo", false)
+		}
+	case entity.ProviderGitLab:
+		if !validGitLabURLPattern.MatchString(repoURL) {
+			return domainerror.NewURLValidationError("url", repoURL, "repository URL must match pattern: https://host/group[/subgroup...]/project", false)
+		}
+	case entity.ProviderGitea:
+		if !validGiteaURLPattern.MatchString(repoURL) {
+			return domainerror.NewURLValidationError("url", repoURL, "repository URL must match pattern: https://host/owner/repo", false)
+		}
+	case entity.ProviderGeneric:
+		if !validGenericURLPattern.MatchString(repoURL) {
+			return domainerror.NewURLValidationError("url", repoURL, "repository URL must match pattern: https://host/path/to/repo", false)
+		}
+	default:
+		return domainerror.NewValidationError("provider", provider.String(), "unsupported provider")
 	}
 
 	return nil
 }
 
-// Register implements the logic for registering a new repository.
-func (uc *repositoryUseCase) Register(ctx context.Context, repoURL string, accessToken string) (entity.Repository, error) {
-	// 1. Validate URL with enhanced security
-	if err := validateRepositoryURL(repoURL); err != nil {
-		// Wrap domain validation error with application context
-		if errors.Is(err, domainerror.ErrInvalidEntity) {
-			return nil, apperror.NewValidationFailedError([]apperror.FieldError{
-				{Field: "url", Message: err.Error()},
-			})
+// validateDeployKey checks that the deploy key is an unencrypted SSH private key usable by git.
+func validateDeployKey(deployKey string) error {
+	if _, err := ssh.ParsePrivateKey([]byte(deployKey)); err != nil {
+		return domainerror.NewValidationError("deployKey", "", "deploy key must be an unencrypted SSH private key")
+	}
+	return nil
+}
+
+// validateInstallation checks that a repository fetched with a GitHub App installation is a GitHub repository
+// registered without other credentials.
+func validateInstallation(req dto.RegisterRepositoryRequest, provider entity.Provider) error {
+	if provider != entity.ProviderGitHub {
+		return domainerror.NewValidationError("provider", provider.String(), "GitHub App installations can only be used with GitHub repositories")
+	}
+	if req.InstallationID <= 0 {
+		return domainerror.NewValidationError("installationId", req.InstallationID, "installation ID must be a positive integer")
+	}
+	if req.AccessToken != "" || req.DeployKey != "" {
+		return domainerror.NewValidationError("accessToken", "", "a GitHub App installation cannot be combined with an access token or deploy key")
+	}
+	return nil
+}
+
+// validateLocalSource checks that a repository served from a local source is registered without
+// credentials or a ref, which a directory or archive does not have.
+func validateLocalSource(req dto.RegisterRepositoryRequest) error {
+	if req.AccessToken != "" || req.DeployKey != "" || req.GitHubAppID != "" {
+		return domainerror.NewValidationError("accessToken", "", "local repositories do not use credentials")
+	}
+	if req.Ref != "" {
+		return domainerror.NewValidationError("ref", req.Ref, "local repositories have no branches or tags")
+	}
+	return nil
+}
+
+// verifyLocalSource checks that the server may read a local repository's directory or archive.
+func verifyLocalSource(ctx context.Context, gitManager git.GitManager, repo entity.Repository) error {
+	check, err := gitManager.VerifyAccess(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to check local source: %w", err)
+	}
+	if !check.Valid {
+		return toValidationFailed(domainerror.NewValidationError("url", repo.URL(), check.Reason))
+	}
+	return nil
+}
+
+// repositoryName derives a repository's name from the last segment of its URL path,
+// without a .git suffix or, for local sources, an archive extension.
+func repositoryName(repoURL string, provider entity.Provider) string {
+	var repoPath string
+	if provider == entity.ProviderLocal {
+		sourcePath, _ := git.ParseFileURL(repoURL)
+		repoPath = filepath.ToSlash(sourcePath)
+	} else {
+		_, repoPath, _ = git.ParseRemoteURL(repoURL)
+	}
+	repoName := trimSourceSuffix(path.Base(repoPath))
+	if repoName == "" || repoName == "." || repoName == "/" {
+		repoName = "unknown"
+	}
+	return repoName
+}
+
+// trimSourceSuffix removes a .git suffix or an archive extension from a repository or file name.
+func trimSourceSuffix(name string) string {
+	for _, suffix := range []string{".git", ".tar.gz", ".tgz", ".zip"} {
+		if strings.HasSuffix(name, suffix) {
+			return strings.TrimSuffix(name, suffix)
 		}
-		return nil, err
 	}
+	return name
+}
+
+// validateRef checks that a branch or tag name is safe to pass to git and provider APIs.
+// An empty ref tracks the default branch.
+func validateRef(ref string) error {
+	if ref == "" {
+		return nil
+	}
+	if len(ref) > maxRefLength || !validRefPattern.MatchString(ref) ||
+		strings.Contains(ref, "..") || strings.Contains(ref, "//") ||
+		strings.HasSuffix(ref, "/") || strings.HasSuffix(ref, ".") || strings.HasSuffix(ref, ".lock") {
+		return domainerror.NewValidationError("ref", ref, "ref must be a branch or tag name")
+	}
+	return nil
+}
+
+// normalizeRootPath validates a root sub-path and returns it without leading or trailing slashes.
+// An empty path (or "/") is the repository root.
+func normalizeRootPath(rootPath string) (string, error) {
+	trimmed := strings.Trim(rootPath, "/")
+	if trimmed == "" {
+		return "", nil
+	}
+	if strings.Contains(trimmed, "\\") || path.Clean(trimmed) != trimmed {
+		return "", domainerror.NewValidationError("rootPath", rootPath, "root path must be a relative directory path without . or .. segments")
+	}
+	for _, segment := range strings.Split(trimmed, "/") {
+		if segment == "." || segment == ".." {
+			return "", domainerror.NewValidationError("rootPath", rootPath, "root path must be a relative directory path without . or .. segments")
+		}
+	}
+	return trimmed, nil
+}
+
+// validateTracking validates the ref and root path and returns the normalized root path.
+func validateTracking(ref, rootPath string) (string, error) {
+	if err := validateRef(ref); err != nil {
+		return "", err
+	}
+	return normalizeRootPath(rootPath)
+}
+
+// toValidationFailed wraps a domain validation error with application context; other errors are returned as is.
+func toValidationFailed(err error) error {
+	var validationErr *domainerror.ValidationError
+	if errors.As(err, &validationErr) {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: validationErr.Field, Message: err.Error()},
+		})
+	}
+	return err
+}
 
-	parsedURL, _ := url.ParseRequestURI(repoURL)
+// Register implements the logic for registering a new repository.
+func (uc *repositoryUseCase) Register(ctx context.Context, req dto.RegisterRepositoryRequest) (entity.Repository, error) {
+	repoURL := req.URL
+
+	// 1. Determine the provider and validate the URL with enhanced security
+	provider, err := resolveProvider(repoURL, req.Provider)
+	if err == nil {
+		err = validateRepositoryURL(repoURL, provider, req.DeployKey != "")
+	}
+	if err == nil && req.DeployKey != "" {
+		err = validateDeployKey(req.DeployKey)
+	}
+	if err == nil && req.GitHubAppID != "" {
+		err = validateInstallation(req, provider)
+	}
+	if err == nil && provider == entity.ProviderLocal {
+		err = validateLocalSource(req)
+	}
+	var rootPath string
+	if err == nil {
+		rootPath, err = validateTracking(req.Ref, req.RootPath)
+	}
+	name := strings.TrimSpace(req.Name)
+	if err == nil && len(name) > maxNameLength {
+		err = domainerror.NewValidationError("name", req.Name, fmt.Sprintf("name must be at most %d characters", maxNameLength))
+	}
+	if err != nil {
+		// Wrap domain validation error with application context
+		return nil, toValidationFailed(err)
+	}
 
 	// 2. Check if repository already exists
 	existingRepo, err := uc.repo.FindByURL(ctx, repoURL)
@@ -112,21 +361,34 @@ func (uc *repositoryUseCase) Register(ctx context.Context, repoURL string, acces
 		return nil, apperror.NewConflictError("Repository", repoURL, "repository with this URL already exists", nil)
 	}
 
-	// 3. Extract repository name from URL (simple approach)
-	repoName := path.Base(parsedURL.Path)
-	repoName = strings.TrimSuffix(repoName, ".git") // Remove .git suffix if present
-	if repoName == "" || repoName == "." {
-		// Fallback or error if name extraction fails
-		repoName = "unknown" // Or return an error
+	// 3. Use the given name or extract it from the URL (simple approach)
+	if name == "" {
+		name = repositoryName(repoURL, provider)
 	}
 
 	// 4. Create new repository model
 	newRepo := entity.NewRepository(
 		uuid.NewString(), // Generate new UUID
-		repoName,
+		name,
 		repoURL,
-		accessToken,
+		provider,
+		req.AccessToken,
 	)
+	if req.DeployKey != "" {
+		newRepo.SetDeployKey(req.DeployKey)
+	}
+	if req.GitHubAppID != "" {
+		newRepo.SetInstallation(entity.GitHubAppInstallation{AppID: req.GitHubAppID, InstallationID: req.InstallationID})
+	}
+	if req.Ref != "" || rootPath != "" {
+		newRepo.SetTracking(req.Ref, rootPath)
+	}
+	if provider == entity.ProviderLocal {
+		// Reject sources the server may not read before the repository is saved
+		if err := verifyLocalSource(ctx, uc.gitManager, newRepo); err != nil {
+			return nil, err
+		}
+	}
 
 	// 5. Persist the new repository
 	err = uc.repo.Save(ctx, newRepo)
@@ -176,33 +438,41 @@ func (uc *repositoryUseCase) ListFiles(ctx context.Context, repoID string) ([]en
 		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
 	}
 
-	// Check if access token is set
-	if repo.AccessToken() == "" {
+	// Check if credentials are set (an access token, or a deploy key for SSH repositories); local sources need none
+	if repo.AuthMethod() == entity.AuthMethodNone && repo.Provider() != entity.ProviderLocal {
 		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
 			{Field: "access_token", Message: "access token is required for this operation"},
 		})
 	}
 
-	// 3. Ensure repository is cloned/updated locally and get its path
-	localPath, err := uc.gitManager.EnsureCloned(ctx, repo)
+	// 3. Use the existing local copy; syncs keep it up to date and record what changed
+	localPath, err := uc.localCopy(ctx, repo)
 	if err != nil {
-		return nil, fmt.Errorf("failed to ensure repository is cloned: %w", err)
+		return nil, err
 	}
 
-	// 4. List files using GitManager
-	files, err := uc.gitManager.ListRepositoryFiles(ctx, localPath, repo)
+	// 4. Describe the files using GitManager
+	files, err := uc.gitManager.DescribeFiles(ctx, localPath, repo)
 	if err != nil {
 		return nil, fmt.Errorf("failed to list repository files: %w", err)
 	}
 
-	// 5. Map the output to []entity.FileNode (Basic mapping)
+	// 5. Map the output to []entity.FileNode and arrange it into directories
 	fileNodes := make([]entity.FileNode, 0, len(files))
 	for _, f := range files {
-		fileType := "file"
-		fileNodes = append(fileNodes, entity.NewFileNode(f, fileType))
+		var lastCommit *entity.FileCommit
+		if f.LastCommit.SHA != "" {
+			lastCommit = &entity.FileCommit{
+				SHA:     f.LastCommit.SHA,
+				Author:  f.LastCommit.Author,
+				Date:    f.LastCommit.Date,
+				Message: f.LastCommit.Message,
+			}
+		}
+		fileNodes = append(fileNodes, entity.NewFileNodeWithInfo(f.Path, f.Size, lastCommit))
 	}
 
-	return fileNodes, nil
+	return entity.BuildFileTree(fileNodes), nil
 }
 
 // SelectFiles implements the logic for selecting manageable files.
@@ -216,10 +486,10 @@ func (uc *repositoryUseCase) SelectFiles(ctx context.Context, repoID string, fil
 		return apperror.NewNotFoundError("Repository", repoID, nil)
 	}
 
-	// 3. Ensure repository is cloned/updated locally and get its path
-	localPath, err := uc.gitManager.EnsureCloned(ctx, repo)
+	// 3. Use the existing local copy; syncs keep it up to date and record what changed
+	localPath, err := uc.localCopy(ctx, repo)
 	if err != nil {
-		return fmt.Errorf("failed to ensure repository is cloned: %w", err)
+		return err
 	}
 
 	// 4. Validate that each file path in filePaths exists in the repository
@@ -258,10 +528,10 @@ func (uc *repositoryUseCase) GetSelectedMarkdown(ctx context.Context, repoID str
 		return "", nil // No files selected, return empty string
 	}
 
-	// 5. Ensure repository is cloned/updated locally and get its path
-	localPath, err := uc.gitManager.EnsureCloned(ctx, repo)
+	// 5. Use the existing local copy; syncs keep it up to date and record what changed
+	localPath, err := uc.localCopy(ctx, repo)
 	if err != nil {
-		return "", fmt.Errorf("failed to ensure repository is cloned: %w", err)
+		return "", err
 	}
 
 	// 6 & 7. Read and concatenate content of selected Markdown files
@@ -306,5 +576,325 @@ func (uc *repositoryUseCase) UpdateAccessToken(ctx context.Context, repoID strin
 		return fmt.Errorf("failed to update repository access token: %w", err)
 	}
 
+	// 3. Verify the new token. A check that cannot be completed leaves the token unchecked
+	// for the periodic checker to retry, so it does not fail the update.
+	repo.SetAccessToken(accessToken)
+	_ = verifyAccessToken(ctx, uc.repo, uc.gitManager, repo)
+
 	return nil
 }
+
+// VerifyAccessToken implements the logic for verifying the access token of a repository on demand.
+func (uc *repositoryUseCase) VerifyAccessToken(ctx context.Context, repoID string) (entity.Repository, error) {
+	// 1. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+	if repo.AuthMethod() != entity.AuthMethodToken {
+		return nil, toValidationFailed(domainerror.NewValidationError("accessToken", "", "the repository does not use an access token"))
+	}
+
+	// 2. Ask the provider and record the result
+	if err := verifyAccessToken(ctx, uc.repo, uc.gitManager, repo); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// UpdateTracking implements the logic for changing the ref and root path a repository is synced from.
+// A different ref discards the local copy, so the enqueued sync starts from scratch.
+func (uc *repositoryUseCase) UpdateTracking(ctx context.Context, repoID string, req dto.UpdateTrackingRequest) (entity.Repository, error) {
+	// 1. Validate the new tracking settings
+	rootPath, err := validateTracking(req.Ref, req.RootPath)
+	if err != nil {
+		return nil, toValidationFailed(err)
+	}
+
+	// 2. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+	if repo.Provider() == entity.ProviderLocal && req.Ref != "" {
+		return nil, toValidationFailed(domainerror.NewValidationError("ref", req.Ref, "local repositories have no branches or tags"))
+	}
+	if repo.Ref() == req.Ref && repo.RootPath() == rootPath {
+		return repo, nil // Nothing changed
+	}
+	refChanged := repo.Ref() != req.Ref
+
+	// 3. Persist the new settings
+	repo.SetTracking(req.Ref, rootPath)
+	if err := uc.repo.Save(ctx, repo); err != nil {
+		return nil, fmt.Errorf("failed to save repository: %w", err)
+	}
+
+	// 4. Drop the copy of the previously tracked ref and sync the new settings
+	if refChanged {
+		if err := uc.gitManager.RemoveLocalCopy(repo); err != nil {
+			return nil, fmt.Errorf("failed to remove local copy of repository: %w", err)
+		}
+	}
+	// A full queue is not an error: the settings are saved and the next scheduled sync applies them
+	uc.queue.Enqueue(SyncJob{RepositoryID: repo.ID(), Trigger: entity.SyncTriggerManual})
+
+	return repo, nil
+}
+
+// UpdateFileRules implements the logic for changing the glob rules that select managed files.
+// The rules are applied to the current local copy right away and re-evaluated on every sync.
+func (uc *repositoryUseCase) UpdateFileRules(ctx context.Context, repoID string, req dto.UpdateFileRulesRequest) (entity.Repository, error) {
+	// 1. Validate the patterns
+	if err := validateGlobs("includeGlobs", req.IncludeGlobs); err != nil {
+		return nil, toValidationFailed(err)
+	}
+	if err := validateGlobs("excludeGlobs", req.ExcludeGlobs); err != nil {
+		return nil, toValidationFailed(err)
+	}
+
+	// 2. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	// 3. Persist the new rules
+	repo.SetFileRules(req.IncludeGlobs, req.ExcludeGlobs)
+	if err := uc.repo.Save(ctx, repo); err != nil {
+		return nil, fmt.Errorf("failed to save repository: %w", err)
+	}
+
+	// 4. Select the files of the current local copy that match the rules
+	localPath, err := uc.localCopy(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	if err := applyFileRules(ctx, uc.repo, uc.gitManager, repo, localPath); err != nil {
+		return nil, err
+	}
+
+	return repo, nil
+}
+
+// UpdateRepository implements the logic for renaming a repository and changing its URL.
+// The provider and credentials stay the same, so the new URL must belong to the same provider.
+func (uc *repositoryUseCase) UpdateRepository(ctx context.Context, repoID string, req dto.UpdateRepositoryRequest) (entity.Repository, error) {
+	// 1. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	// 2. Validate the new name and URL
+	name := strings.TrimSpace(req.Name)
+	if len(name) > maxNameLength {
+		return nil, toValidationFailed(domainerror.NewValidationError("name", req.Name, "name must be at most 255 characters"))
+	}
+	urlChanged := req.URL != "" && req.URL != repo.URL()
+	if urlChanged {
+		if err := validateRepositoryURL(req.URL, repo.Provider(), repo.AuthMethod() == entity.AuthMethodDeployKey); err != nil {
+			return nil, toValidationFailed(err)
+		}
+		existingRepo, err := uc.repo.FindByURL(ctx, req.URL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check for existing repository: %w", err)
+		}
+		if existingRepo != nil {
+			return nil, apperror.NewConflictError("Repository", req.URL, "repository with this URL already exists", nil)
+		}
+	}
+	nameChanged := name != "" && name != repo.Name()
+	if !nameChanged && !urlChanged {
+		return repo, nil // Nothing changed
+	}
+
+	// 3. Persist the changes
+	if nameChanged {
+		repo.SetName(name)
+	}
+	if urlChanged {
+		repo.SetURL(req.URL)
+		if repo.Provider() == entity.ProviderLocal {
+			if err := verifyLocalSource(ctx, uc.gitManager, repo); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := uc.repo.Save(ctx, repo); err != nil {
+		return nil, fmt.Errorf("failed to save repository: %w", err)
+	}
+
+	// 4. Drop the copy fetched from the previous URL and sync from the new one
+	if urlChanged {
+		if err := uc.gitManager.RemoveLocalCopy(repo); err != nil {
+			return nil, fmt.Errorf("failed to remove local copy of repository: %w", err)
+		}
+		// A full queue is not an error: the URL is saved and the next scheduled sync fetches from it
+		uc.queue.Enqueue(SyncJob{RepositoryID: repo.ID(), Trigger: entity.SyncTriggerManual})
+	}
+
+	return repo, nil
+}
+
+// DeleteRepository implements the logic for deleting a repository.
+// Without cascade the repository is archived, so the documents and execution records that reference it remain.
+func (uc *repositoryUseCase) DeleteRepository(ctx context.Context, repoID string, cascade bool) error {
+	// 1. Find the repository by ID to ensure it exists
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	// 2. Delete or archive the repository
+	if cascade {
+		err = uc.repo.Delete(ctx, repoID)
+	} else {
+		err = uc.repo.Archive(ctx, repoID)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+
+	// 3. Remove the local copy and any cached client
+	if err := uc.gitManager.Forget(repo); err != nil {
+		return fmt.Errorf("failed to remove local copy of repository: %w", err)
+	}
+
+	return nil
+}
+
+// validateGlobs checks each glob pattern, reporting the first invalid one as field[index].
+func validateGlobs(field string, patterns []string) error {
+	for i, pattern := range patterns {
+		if err := entity.ValidateGlob(pattern); err != nil {
+			return domainerror.NewValidationError(fmt.Sprintf("%s[%d]", field, i), pattern, err.Error())
+		}
+	}
+	return nil
+}
+
+// applyFileRules replaces the files matched by the repository's glob rules with the matches in the local copy.
+// Files selected explicitly are not affected.
+func applyFileRules(ctx context.Context, repoStore repository.Repository, gitManager git.GitManager, repo entity.Repository, localPath string) error {
+	var matched []string
+	if len(repo.IncludeGlobs()) > 0 {
+		files, err := gitManager.ListRepositoryFiles(ctx, localPath, repo)
+		if err != nil {
+			return fmt.Errorf("failed to list repository files: %w", err)
+		}
+		for _, filePath := range files {
+			if repo.MatchesFileRules(filePath) {
+				matched = append(matched, filePath)
+			}
+		}
+	}
+
+	if err := repoStore.SaveRuleMatchedFiles(ctx, repo.ID(), matched); err != nil {
+		return fmt.Errorf("failed to save files matched by rules: %w", err)
+	}
+	return nil
+}
+
+// ResolveFileCommit implements the logic for resolving the commit a file was read at.
+func (uc *repositoryUseCase) ResolveFileCommit(ctx context.Context, repoID string, filePath string) (string, error) {
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return "", fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return "", apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	// Resolve against the existing local copy
+	localPath, err := uc.localCopy(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+
+	if filePath == "" {
+		commit, err := uc.gitManager.ResolveHeadCommit(ctx, localPath, repo)
+		if err != nil {
+			return "", fmt.Errorf("failed to resolve head commit: %w", err)
+		}
+		return commit, nil
+	}
+
+	commit, err := uc.gitManager.ResolveFileCommit(ctx, localPath, filePath, repo)
+	if err != nil {
+		return "", apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "file_path", Message: fmt.Sprintf("failed to resolve the commit of %s: %v", filePath, err)},
+		})
+	}
+	return commit, nil
+}
+
+// ListManagedFiles implements the logic for listing the files selected for management.
+func (uc *repositoryUseCase) ListManagedFiles(ctx context.Context, repoID string) ([]string, error) {
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	filePaths, err := uc.repo.GetManagedFiles(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve managed files: %w", err)
+	}
+	return filePaths, nil
+}
+
+// ReadManagedFile implements the logic for reading a managed file from the local copy.
+func (uc *repositoryUseCase) ReadManagedFile(ctx context.Context, repoID string, filePath string) ([]byte, error) {
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+
+	localPath, err := uc.localCopy(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := uc.gitManager.ReadManagedFileContent(ctx, localPath, filePath, repo)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read content of file '%s': %w", filePath, err)
+	}
+	return content, nil
+}
+
+// localCopy returns the path of the existing local copy of a repository.
+// The repository is only fetched if it has never been synced.
+func (uc *repositoryUseCase) localCopy(ctx context.Context, repo entity.Repository) (string, error) {
+	localPath := uc.gitManager.LocalPath(repo)
+	if _, err := os.Stat(localPath); err == nil {
+		return localPath, nil
+	}
+
+	localPath, err := uc.gitManager.EnsureCloned(ctx, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to ensure repository is cloned: %w", err)
+	}
+	return localPath, nil
+}
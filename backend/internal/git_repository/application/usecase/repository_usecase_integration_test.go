@@ -2,8 +2,10 @@ package repository
 
 import (
 	"context"
+	"opscore/backend/internal/git_repository/application/dto"
 	"opscore/backend/internal/git_repository/domain/entity"
 	"opscore/backend/internal/git_repository/infrastructure/git"
+	"slices"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -16,9 +18,10 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 	// インメモリリポジトリとモックGitマネージャを使用して統合テスト環境を設定
 	repo := NewInMemoryRepository()
 	gitManager := git.NewMockGitManager()
+	gitManager.On("VerifyAccess", mock.Anything, mock.Anything).Return(git.AccessCheck{Valid: true}, nil)
 
 	// 実際のユースケース実装を使用（モックではなく）
-	useCase := NewRepositoryUseCase(repo, gitManager)
+	useCase := NewRepositoryUseCase(repo, gitManager, &fakeSyncQueue{})
 	ctx := context.Background()
 
 	// テスト: Register と GetRepository メソッド
@@ -27,7 +30,7 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 		repoURL := "https://github.com/example/test-usecase-integration"
 		accessToken := "test-token"
 
-		newRepo, err := useCase.Register(ctx, repoURL, accessToken)
+		newRepo, err := useCase.Register(ctx, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken})
 		require.NoError(t, err)
 		require.NotNil(t, newRepo)
 
@@ -47,11 +50,11 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 		accessToken := "test-token"
 
 		// 1回目の登録
-		_, err := useCase.Register(ctx, repoURL, accessToken)
+		_, err := useCase.Register(ctx, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken})
 		require.NoError(t, err)
 
 		// 2回目の登録（同じURL）
-		_, err = useCase.Register(ctx, repoURL, accessToken)
+		_, err = useCase.Register(ctx, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken})
 		assert.ErrorIs(t, err, ErrRepositoryAlreadyExists)
 	})
 
@@ -69,7 +72,7 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 		repoURL := "https://github.com/example/update-token-repo"
 		initialToken := "initial-token"
 
-		newRepo, err := useCase.Register(ctx, repoURL, initialToken)
+		newRepo, err := useCase.Register(ctx, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: initialToken})
 		require.NoError(t, err)
 
 		// アクセストークンを更新
@@ -77,6 +80,11 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 		err = useCase.UpdateAccessToken(ctx, newRepo.ID(), updatedToken)
 		require.NoError(t, err)
 
+		// 更新したトークンが確認され、その結果が記録される
+		retrieved, err := useCase.GetRepository(ctx, newRepo.ID())
+		require.NoError(t, err)
+		assert.Equal(t, entity.TokenStatusValid, retrieved.TokenHealth().Status)
+
 		// Note: リポジトリエンティティはアクセストークンを外部に公開しないので
 		// 直接検証はできないが、内部的に更新されていることを前提とする
 	})
@@ -90,7 +98,7 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 		repoURL := "https://github.com/example/markdown-repo"
 		accessToken := "token"
 
-		newRepo, err := useCase.Register(ctx, repoURL, accessToken)
+		newRepo, err := useCase.Register(ctx, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken})
 		require.NoError(t, err)
 		repoID := newRepo.ID()
 
@@ -105,6 +113,7 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 
 		// Set up mock expectations correctly with context and repo parameters
 		mockPath := "/mock/path/to/repo"
+		mockGitManager.On("LocalPath", repoMatcher).Return(mockPath)
 		mockGitManager.On("EnsureCloned", contextMatcher, repoMatcher).Return(mockPath, nil)
 
 		// Add mock files
@@ -124,7 +133,7 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 			Return([]byte("# ADR 0001\n\nThis is a test ADR."), nil)
 
 		// 実際のユースケースのGitManagerをモックに置き換え
-		useCaseWithMock := NewRepositoryUseCase(repo, mockGitManager)
+		useCaseWithMock := NewRepositoryUseCase(repo, mockGitManager, &fakeSyncQueue{})
 
 		// ファイルを選択
 		filePaths := []string{"README.md", "docs/adr/0001-test-adr.md"}
@@ -166,12 +175,14 @@ func TestRepositoryUseCaseIntegration(t *testing.T) {
 type InMemoryRepository struct {
 	repositories map[string]entity.Repository
 	managedFiles map[string][]string
+	ruleMatches  map[string][]string
 }
 
 func NewInMemoryRepository() *InMemoryRepository {
 	return &InMemoryRepository{
 		repositories: make(map[string]entity.Repository),
 		managedFiles: make(map[string][]string),
+		ruleMatches:  make(map[string][]string),
 	}
 }
 
@@ -216,6 +227,7 @@ func (r *InMemoryRepository) UpdateAccessToken(ctx context.Context, id string, a
 		repo.ID(),
 		repo.Name(),
 		repo.URL(),
+		entity.ProviderGitHub,
 		accessToken,
 	)
 	r.repositories[id] = updatedRepo
@@ -231,10 +243,44 @@ func (r *InMemoryRepository) SaveManagedFiles(ctx context.Context, repoID string
 	return nil
 }
 
+func (r *InMemoryRepository) SaveRuleMatchedFiles(ctx context.Context, repoID string, filePaths []string) error {
+	if _, exists := r.repositories[repoID]; !exists {
+		return ErrRepositoryNotFound
+	}
+	r.ruleMatches[repoID] = filePaths
+	return nil
+}
+
 func (r *InMemoryRepository) GetManagedFiles(ctx context.Context, repoID string) ([]string, error) {
-	filePaths, exists := r.managedFiles[repoID]
-	if !exists {
-		return []string{}, nil // Empty slice for no files
+	filePaths := append([]string{}, r.managedFiles[repoID]...)
+	for _, matched := range r.ruleMatches[repoID] {
+		if !slices.Contains(filePaths, matched) {
+			filePaths = append(filePaths, matched)
+		}
 	}
 	return filePaths, nil
 }
+
+func (r *InMemoryRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := r.repositories[id]; !exists {
+		return ErrRepositoryNotFound
+	}
+	delete(r.repositories, id)
+	delete(r.managedFiles, id)
+	delete(r.ruleMatches, id)
+	return nil
+}
+
+func (r *InMemoryRepository) Archive(ctx context.Context, id string) error {
+	// Documents are not kept in memory, so archiving removes the repository like Delete
+	return r.Delete(ctx, id)
+}
+
+func (r *InMemoryRepository) UpdateTokenHealth(ctx context.Context, id string, health entity.TokenHealth) error {
+	repo, exists := r.repositories[id]
+	if !exists {
+		return ErrRepositoryNotFound
+	}
+	repo.SetTokenHealth(health)
+	return nil
+}
@@ -2,15 +2,28 @@ package repository
 
 import (
 	"context"
+	"crypto/ed25519"
+	"crypto/rand"
+	"encoding/pem"
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
 	"errors"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
 	"opscore/backend/internal/git_repository/domain/entity"
 	"opscore/backend/internal/git_repository/domain/repository"
 	"opscore/backend/internal/git_repository/infrastructure/git"
 	"testing"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/ssh"
 )
 
 // TestRegister はRegisterメソッドのテストです
@@ -34,16 +47,17 @@ func TestRegister(t *testing.T) {
 		mockRepo.On("Save", contextMatcher, repoMatcher).Return(nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
-		repo, err := uc.Register(context.Background(), url, accessToken)
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: accessToken})
 
 		// 検証
 		assert.NoError(t, err)
 		assert.NotNil(t, repo)
 		assert.Equal(t, url, repo.URL())
 		assert.Equal(t, "valid-repo", repo.Name()) // URLからリポジトリ名が抽出されているか
+		assert.Equal(t, entity.ProviderGitHub, repo.Provider())
 
 		// モックの呼び出しを検証
 		mockRepo.AssertExpectations(t)
@@ -58,16 +72,16 @@ func TestRegister(t *testing.T) {
 
 		url := "https://github.com/example/existing-repo"
 		accessToken := "test-token"
-		existingRepo := entity.NewRepository(uuid.NewString(), "existing-repo", url, "")
+		existingRepo := entity.NewRepository(uuid.NewString(), "existing-repo", url, entity.ProviderGitHub, "")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByURL", mock.Anything, url).Return(existingRepo, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
-		repo, err := uc.Register(context.Background(), url, accessToken)
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: accessToken})
 
 		// 検証
 		assert.Error(t, err)
@@ -94,11 +108,11 @@ func TestRegister(t *testing.T) {
 		}
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// 各無効URLでテスト
 		for _, url := range invalidURLs {
-			repo, err := uc.Register(context.Background(), url, "test-token")
+			repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: "test-token"})
 			assert.Error(t, err)
 			assert.Nil(t, repo)
 		}
@@ -109,6 +123,203 @@ func TestRegister(t *testing.T) {
 		mockGitManager.AssertExpectations(t)
 	})
 
+	// テスト：GitLabのURLからプロバイダーが検出されることを確認する
+	t.Run("GitLabのURLからプロバイダーが検出される", func(t *testing.T) {
+		urls := map[string]string{
+			"https://gitlab.com/example/valid-repo":                      "valid-repo",
+			"https://gitlab.example.com/group/subgroup/nested-repo.git": "nested-repo",
+		}
+
+		for url, name := range urls {
+			mockRepo := new(repository.MockRepository)
+			mockGitManager := new(git.MockGitManager)
+			mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+			mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+			repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: "glpat-test"})
+
+			assert.NoError(t, err)
+			assert.NotNil(t, repo)
+			assert.Equal(t, entity.ProviderGitLab, repo.Provider())
+			assert.Equal(t, name, repo.Name())
+			mockRepo.AssertExpectations(t)
+		}
+	})
+
+	// テスト：明示的に指定したプロバイダーが使われることを確認する
+	t.Run("明示的に指定したプロバイダーが使われる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		url := "https://git.example.com/infra/ops/runbooks"
+		mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, Provider: "gitlab"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ProviderGitLab, repo.Provider())
+		mockRepo.AssertExpectations(t)
+	})
+
+	// テスト：プロバイダーを判別できない場合はエラーになることを確認する
+	t.Run("プロバイダーを判別できない場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		requests := []dto.RegisterRepositoryRequest{
+			{URL: "https://git.example.com/infra/runbooks"},                       // 判別できないホスト
+			{URL: "https://git.example.com/infra/runbooks", Provider: "bitbucket"}, // 未対応のプロバイダー
+			{URL: "https://github.com/group/subgroup/repo", Provider: "github"},    // GitHubはowner/repoのみ
+		}
+
+		for _, req := range requests {
+			repo, err := uc.Register(context.Background(), req)
+			assert.Error(t, err)
+			assert.Nil(t, repo)
+			var validationErr *apperror.ValidationFailedError
+			assert.True(t, errors.As(err, &validationErr), "Error should be a validation error: %v", err)
+		}
+
+		mockRepo.AssertNotCalled(t, "FindByURL")
+		mockRepo.AssertNotCalled(t, "Save")
+	})
+
+	// テスト：Gitea/ForgejoのURLからプロバイダーが検出されることを確認する
+	t.Run("Gitea・ForgejoのURLからプロバイダーが検出される", func(t *testing.T) {
+		for _, url := range []string{
+			"https://gitea.example.com/ops/runbooks",
+			"https://forgejo.internal:3000/ops/runbooks.git",
+			"https://codeberg.org/ops/runbooks",
+		} {
+			mockRepo := new(repository.MockRepository)
+			mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+			mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+			repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: "gitea-token"})
+
+			assert.NoError(t, err, url)
+			assert.Equal(t, entity.ProviderGitea, repo.Provider())
+			assert.Equal(t, "runbooks", repo.Name())
+			assert.Equal(t, entity.AuthMethodToken, repo.AuthMethod())
+		}
+	})
+
+	// テスト：汎用プロバイダーでは任意のHTTPSホストを登録できることを確認する
+	t.Run("汎用プロバイダーでは任意のHTTPSホストを登録できる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		url := "https://git.example.com/scm/runbooks.git"
+		mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, Provider: "generic"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.ProviderGeneric, repo.Provider())
+		assert.Equal(t, entity.AuthMethodNone, repo.AuthMethod())
+	})
+
+	// テスト：SSHのURLとデプロイキーで登録できることを確認する
+	t.Run("SSHのURLとデプロイキーで登録できる", func(t *testing.T) {
+		deployKey := newTestDeployKey(t)
+
+		testCases := []struct {
+			req          dto.RegisterRepositoryRequest
+			expectedName string
+			expectedProv entity.Provider
+		}{
+			{dto.RegisterRepositoryRequest{URL: "git@gitea.example.com:ops/runbooks.git", DeployKey: deployKey}, "runbooks", entity.ProviderGitea},
+			{dto.RegisterRepositoryRequest{URL: "ssh://git@git.example.com:2222/srv/ops-docs.git", Provider: "generic", DeployKey: deployKey}, "ops-docs", entity.ProviderGeneric},
+		}
+
+		for _, tc := range testCases {
+			mockRepo := new(repository.MockRepository)
+			mockRepo.On("FindByURL", mock.Anything, tc.req.URL).Return(nil, nil)
+			mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+			uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+			repo, err := uc.Register(context.Background(), tc.req)
+
+			assert.NoError(t, err, tc.req.URL)
+			assert.Equal(t, tc.expectedName, repo.Name())
+			assert.Equal(t, tc.expectedProv, repo.Provider())
+			assert.Equal(t, entity.AuthMethodDeployKey, repo.AuthMethod())
+			assert.Equal(t, deployKey, repo.DeployKey())
+		}
+	})
+
+	// テスト：SSHのURLとデプロイキーの組み合わせが不正な場合はエラーになることを確認する
+	t.Run("SSHのURLとデプロイキーの組み合わせが不正な場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		requests := []dto.RegisterRepositoryRequest{
+			{URL: "git@gitea.example.com:ops/runbooks.git", AccessToken: "token"},                          // SSHにはデプロイキーが必要
+			{URL: "https://gitea.example.com/ops/runbooks", DeployKey: newTestDeployKey(t)},                // デプロイキーはSSHのみ
+			{URL: "git@gitea.example.com:ops/runbooks.git", DeployKey: "not a private key"},                // 不正なデプロイキー
+			{URL: "ssh://-oProxyCommand=evil/ops/runbooks", Provider: "generic", DeployKey: "unused-key"}, // オプションとして解釈されるホスト
+		}
+
+		for _, req := range requests {
+			repo, err := uc.Register(context.Background(), req)
+			assert.Error(t, err, req.URL)
+			assert.Nil(t, repo)
+			var validationErr *apperror.ValidationFailedError
+			assert.True(t, errors.As(err, &validationErr), "Error should be a validation error: %v", err)
+		}
+
+		mockRepo.AssertNotCalled(t, "FindByURL")
+		mockRepo.AssertNotCalled(t, "Save")
+	})
+
+	// テスト：追跡するrefとルートパスを指定して登録できることを確認する
+	t.Run("追跡するrefとルートパスを指定して登録できる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByURL", mock.Anything, mock.Anything).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{
+			URL:      "https://github.com/example/valid-repo",
+			Ref:      "release/v1",
+			RootPath: "/docs/runbooks/",
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "release/v1", repo.Ref())
+		assert.Equal(t, "docs/runbooks", repo.RootPath())
+	})
+
+	// テスト：不正なrefやルートパスではバリデーションエラーになることを確認する
+	t.Run("不正なrefやルートパスではバリデーションエラーになる", func(t *testing.T) {
+		for _, req := range []dto.RegisterRepositoryRequest{
+			{URL: "https://github.com/example/valid-repo", Ref: "-evil"},
+			{URL: "https://github.com/example/valid-repo", Ref: "main..dev"},
+			{URL: "https://github.com/example/valid-repo", Ref: "feature branch"},
+			{URL: "https://github.com/example/valid-repo", RootPath: "../etc"},
+			{URL: "https://github.com/example/valid-repo", RootPath: "docs/./runbooks"},
+		} {
+			uc := NewRepositoryUseCase(new(repository.MockRepository), new(git.MockGitManager), &fakeSyncQueue{})
+
+			_, err := uc.Register(context.Background(), req)
+
+			var validationErr *apperror.ValidationFailedError
+			assert.True(t, errors.As(err, &validationErr), "%+v", req)
+		}
+	})
+
 	// テスト：リポジトリの保存中にエラーが発生した場合を確認する
 	t.Run("リポジトリの保存中にエラーが発生した場合", func(t *testing.T) {
 		// モックの準備
@@ -124,10 +335,10 @@ func TestRegister(t *testing.T) {
 		mockRepo.On("Save", mock.Anything, mock.Anything).Return(saveError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
-		repo, err := uc.Register(context.Background(), url, accessToken)
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, AccessToken: accessToken})
 
 		// 検証
 		assert.Error(t, err)
@@ -138,6 +349,63 @@ func TestRegister(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockGitManager.AssertExpectations(t)
 	})
+
+	// テスト：file:// URLでローカルディレクトリのリポジトリを登録できることを確認する
+	t.Run("file:// URLでローカルディレクトリのリポジトリを登録できる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		url := "file:///srv/runbooks.git"
+		mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, mock.Anything).Return(git.AccessCheck{Valid: true}, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		repo, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.ProviderLocal, repo.Provider())
+		assert.Equal(t, entity.AuthMethodNone, repo.AuthMethod())
+		assert.Equal(t, "runbooks", repo.Name())
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	// テスト：読み取りが許可されていないローカルソースは保存前にバリデーションエラーになることを確認する
+	t.Run("読み取りが許可されていないローカルソースは保存前にバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		url := "file:///etc"
+		mockRepo.On("FindByURL", mock.Anything, url).Return(nil, nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, mock.Anything).Return(git.AccessCheck{Valid: false, Reason: "local source is outside the allowed directories"}, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		_, err := uc.Register(context.Background(), dto.RegisterRepositoryRequest{URL: url, Name: "System"})
+
+		var validationErr *apperror.ValidationFailedError
+		require.ErrorAs(t, err, &validationErr)
+		assert.Equal(t, "url", validationErr.Errors[0].Field)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	// テスト：ローカルソースに認証情報やrefを指定するとバリデーションエラーになることを確認する
+	t.Run("ローカルソースに認証情報やrefを指定するとバリデーションエラーになる", func(t *testing.T) {
+		uc := NewRepositoryUseCase(new(repository.MockRepository), new(git.MockGitManager), &fakeSyncQueue{})
+
+		requests := []dto.RegisterRepositoryRequest{
+			{URL: "file:///srv/runbooks", AccessToken: "token"},
+			{URL: "file:///srv/runbooks", Ref: "main"},
+			{URL: "file://remote-host/srv/runbooks"},
+			{URL: "https://github.com/example/runbooks", Provider: "local"},
+			{URL: "file:///srv/runbooks", Provider: "github", AccessToken: "token"},
+		}
+		for _, req := range requests {
+			_, err := uc.Register(context.Background(), req)
+			var validationErr *apperror.ValidationFailedError
+			assert.ErrorAs(t, err, &validationErr, req.URL)
+		}
+	})
 }
 
 // TestGetRepository はGetRepositoryメソッドのテストです
@@ -149,13 +417,13 @@ func TestGetRepository(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		expectedRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		expectedRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(expectedRepo, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repo, err := uc.GetRepository(context.Background(), repoID)
@@ -181,7 +449,7 @@ func TestGetRepository(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repo, err := uc.GetRepository(context.Background(), repoID)
@@ -209,7 +477,7 @@ func TestGetRepository(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, findError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repo, err := uc.GetRepository(context.Background(), repoID)
@@ -234,15 +502,15 @@ func TestListRepositories(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		expectedRepos := []entity.Repository{
-			entity.NewRepository(uuid.NewString(), "repo1", "https://github.com/example/repo1", "token1"),
-			entity.NewRepository(uuid.NewString(), "repo2", "https://github.com/example/repo2", "token2"),
+			entity.NewRepository(uuid.NewString(), "repo1", "https://github.com/example/repo1", entity.ProviderGitHub, "token1"),
+			entity.NewRepository(uuid.NewString(), "repo2", "https://github.com/example/repo2", entity.ProviderGitHub, "token2"),
 		}
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindAll", mock.Anything).Return(expectedRepos, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repos, err := uc.ListRepositories(context.Background())
@@ -269,7 +537,7 @@ func TestListRepositories(t *testing.T) {
 		mockRepo.On("FindAll", mock.Anything).Return(emptyRepos, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repos, err := uc.ListRepositories(context.Background())
@@ -295,7 +563,7 @@ func TestListRepositories(t *testing.T) {
 		mockRepo.On("FindAll", mock.Anything).Return(nil, findAllError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		repos, err := uc.ListRepositories(context.Background())
@@ -313,48 +581,79 @@ func TestListRepositories(t *testing.T) {
 
 // TestListFiles はListFilesメソッドのテストです
 func TestListFiles(t *testing.T) {
-	// テスト：存在するリポジトリのファイル一覧が正常に取得できることを確認する
+	// テスト：存在するリポジトリのファイル一覧がディレクトリの階層として取得できることを確認する
 	t.Run("存在するリポジトリのファイル一覧が正常に取得できる", func(t *testing.T) {
 		// モックの準備
 		mockRepo := new(repository.MockRepository)
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 
-		fileList := []string{
-			"README.md",
-			"src/main.go",
-			"docs/index.md",
+		commit := git.CommitInfo{SHA: "abc123", Author: "ops", Date: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC), Message: "Add runbooks"}
+		fileInfos := []git.FileInfo{
+			{Path: "README.md", Size: 10, LastCommit: commit},
+			{Path: "src/main.go", Size: 20, LastCommit: commit},
+			{Path: "docs/index.md", Size: 30, LastCommit: commit},
 		}
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
-		mockGitManager.On("ListRepositoryFiles", mock.Anything, localPath, testRepo).Return(fileList, nil)
+		mockGitManager.On("DescribeFiles", mock.Anything, localPath, testRepo).Return(fileInfos, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		fileNodes, err := uc.ListFiles(context.Background(), repoID)
 
-		// 検証
+		// 検証：ディレクトリが先に、名前順に並ぶ
 		assert.NoError(t, err)
-		assert.Len(t, fileNodes, len(fileList))
-
-		// 返されたFileNodeが期待通りかチェック
-		for i, f := range fileNodes {
-			assert.Equal(t, fileList[i], f.Path())
-			assert.Equal(t, "file", f.Type())
-		}
+		require.Len(t, fileNodes, 3)
+		assert.Equal(t, "docs", fileNodes[0].Path())
+		assert.Equal(t, "dir", fileNodes[0].Type())
+		assert.Equal(t, "src", fileNodes[1].Path())
+		assert.Equal(t, "README.md", fileNodes[2].Path())
+		assert.Equal(t, "file", fileNodes[2].Type())
+		assert.Equal(t, int64(10), fileNodes[2].Size())
+		assert.Equal(t, "abc123", fileNodes[2].LastCommit().SHA)
+
+		require.Len(t, fileNodes[0].Children(), 1)
+		assert.Equal(t, "docs/index.md", fileNodes[0].Children()[0].Path())
+		assert.Equal(t, int64(30), fileNodes[0].Size())
 
 		// モックの呼び出しを検証
 		mockRepo.AssertExpectations(t)
 		mockGitManager.AssertExpectations(t)
 	})
 
+	// テスト：デプロイキーのリポジトリはアクセストークンなしでファイル一覧を取得できることを確認する
+	t.Run("デプロイキーのリポジトリはアクセストークンなしでファイル一覧を取得できる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "git@gitea.example.com:ops/test-repo.git", entity.ProviderGitea, "")
+		testRepo.SetDeployKey(newTestDeployKey(t))
+		localPath := "/tmp/repos/" + repoID
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
+		mockGitManager.On("DescribeFiles", mock.Anything, localPath, testRepo).Return([]git.FileInfo{{Path: "README.md", Size: 10}}, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		fileNodes, err := uc.ListFiles(context.Background(), repoID)
+
+		assert.NoError(t, err)
+		assert.Len(t, fileNodes, 1)
+		mockGitManager.AssertExpectations(t)
+	})
+
 	// テスト：存在しないリポジトリIDでエラーになることを確認する
 	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
 		// モックの準備
@@ -367,7 +666,7 @@ func TestListFiles(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		fileNodes, err := uc.ListFiles(context.Background(), repoID)
@@ -389,13 +688,13 @@ func TestListFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "") // 空のアクセストークン
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "") // 空のアクセストークン
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		fileNodes, err := uc.ListFiles(context.Background(), repoID)
@@ -417,15 +716,16 @@ func TestListFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		cloneError := errors.New("git clone error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return("/tmp/repos/" + repoID)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return("", cloneError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		fileNodes, err := uc.ListFiles(context.Background(), repoID)
@@ -447,17 +747,18 @@ func TestListFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		listError := errors.New("file listing error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
-		mockGitManager.On("ListRepositoryFiles", mock.Anything, localPath, testRepo).Return(nil, listError)
+		mockGitManager.On("DescribeFiles", mock.Anything, localPath, testRepo).Return(nil, listError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		fileNodes, err := uc.ListFiles(context.Background(), repoID)
@@ -471,6 +772,52 @@ func TestListFiles(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockGitManager.AssertExpectations(t)
 	})
+
+	// テスト：同期前にファイル一覧を取得しても、ローカルコピーは更新されず同期で変更が通知されることを確認する
+	t.Run("同期前にファイル一覧を取得しても同期で変更が通知される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
+		localPath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "runbook.md"), []byte("v1"), 0644))
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
+		mockGitManager.On("DescribeFiles", mock.Anything, localPath, testRepo).Return([]git.FileInfo{{Path: "runbook.md", Size: 2}}, nil)
+		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil).Run(func(args mock.Arguments) {
+			// Simulate the pull updating the local copy
+			require.NoError(t, os.WriteFile(filepath.Join(localPath, "runbook.md"), []byte("v2"), 0644))
+		})
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, repoID, []string(nil)).Return(nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return([]string{"runbook.md"}, nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, testRepo).Return("abc123", nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		listener := &fakeSyncListener{notified: make(chan []string, 1)}
+		worker := NewSyncWorker(NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager), slog.New(slog.NewTextHandler(io.Discard, nil)), 10)
+		worker.AddListener(listener)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go worker.Start(ctx)
+
+		// テスト実行：ファイル一覧の取得後に同期する
+		_, err := uc.ListFiles(context.Background(), repoID)
+		require.NoError(t, err)
+		mockGitManager.AssertNotCalled(t, "EnsureCloned", mock.Anything, mock.Anything)
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: repoID, Trigger: entity.SyncTriggerSchedule}))
+
+		// 検証：同期で変更されたファイルがリスナーに通知される
+		select {
+		case files := <-listener.notified:
+			assert.Equal(t, []string{"runbook.md"}, files)
+		case <-time.After(5 * time.Second):
+			t.Fatal("listener was not notified")
+		}
+	})
 }
 
 // TestSelectFiles はSelectFilesメソッドのテストです
@@ -482,18 +829,19 @@ func TestSelectFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		filePaths := []string{"README.md", "docs/index.md"}
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
 		mockGitManager.On("ValidateFilesExist", mock.Anything, localPath, filePaths, testRepo).Return(nil)
 		mockRepo.On("SaveManagedFiles", mock.Anything, repoID, filePaths).Return(nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.SelectFiles(context.Background(), repoID, filePaths)
@@ -519,7 +867,7 @@ func TestSelectFiles(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.SelectFiles(context.Background(), repoID, filePaths)
@@ -540,16 +888,17 @@ func TestSelectFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		filePaths := []string{"README.md"}
 		cloneError := errors.New("git clone error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return("/tmp/repos/" + repoID)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return("", cloneError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.SelectFiles(context.Background(), repoID, filePaths)
@@ -570,18 +919,19 @@ func TestSelectFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		filePaths := []string{"README.md", "nonexistent-file.md"}
 		validateError := errors.New("file validation error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
 		mockGitManager.On("ValidateFilesExist", mock.Anything, localPath, filePaths, testRepo).Return(validateError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.SelectFiles(context.Background(), repoID, filePaths)
@@ -602,19 +952,20 @@ func TestSelectFiles(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		filePaths := []string{"README.md"}
 		saveError := errors.New("file save error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
 		mockGitManager.On("ValidateFilesExist", mock.Anything, localPath, filePaths, testRepo).Return(nil)
 		mockRepo.On("SaveManagedFiles", mock.Anything, repoID, filePaths).Return(saveError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.SelectFiles(context.Background(), repoID, filePaths)
@@ -638,7 +989,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		filePaths := []string{"README.md", "docs/index.md"}
 
@@ -649,12 +1000,13 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
 		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return(filePaths, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
 		mockGitManager.On("ReadManagedFileContent", mock.Anything, localPath, "README.md", testRepo).Return(readme, nil)
 		mockGitManager.On("ReadManagedFileContent", mock.Anything, localPath, "docs/index.md", testRepo).Return(index, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -682,7 +1034,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -704,7 +1056,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		var emptyPaths []string
 
 		// モックの振る舞いを定義
@@ -712,7 +1064,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return(emptyPaths, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -733,7 +1085,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		getFilesError := errors.New("db error")
 
 		// モックの振る舞いを定義
@@ -741,7 +1093,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return(nil, getFilesError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -763,17 +1115,18 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		filePaths := []string{"README.md"}
 		cloneError := errors.New("git clone error")
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
 		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return(filePaths, nil)
+		mockGitManager.On("LocalPath", testRepo).Return("/tmp/repos/" + repoID)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return("", cloneError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -795,7 +1148,7 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "test-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "test-token")
 		localPath := "/tmp/repos/" + repoID
 		filePaths := []string{"README.md", "error.md"}
 		readError := errors.New("file read error")
@@ -803,12 +1156,13 @@ func TestGetSelectedMarkdown(t *testing.T) {
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
 		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return(filePaths, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
 		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
 		mockGitManager.On("ReadManagedFileContent", mock.Anything, localPath, "README.md", testRepo).Return([]byte("# README"), nil)
 		mockGitManager.On("ReadManagedFileContent", mock.Anything, localPath, "error.md", testRepo).Return(nil, readError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		content, err := uc.GetSelectedMarkdown(context.Background(), repoID)
@@ -833,15 +1187,21 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "old-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "old-token")
 		newToken := "new-token"
 
 		// モックの振る舞いを定義
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
 		mockRepo.On("UpdateAccessToken", mock.Anything, repoID, newToken).Return(nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, mock.MatchedBy(func(r entity.Repository) bool {
+			return r.AccessToken() == newToken
+		})).Return(git.AccessCheck{Valid: true}, nil)
+		mockRepo.On("UpdateTokenHealth", mock.Anything, repoID, mock.MatchedBy(func(h entity.TokenHealth) bool {
+			return h.Status == entity.TokenStatusValid
+		})).Return(nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.UpdateAccessToken(context.Background(), repoID, newToken)
@@ -861,7 +1221,7 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "old-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "old-token")
 		emptyToken := ""
 
 		// モックの振る舞いを定義
@@ -869,7 +1229,7 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockRepo.On("UpdateAccessToken", mock.Anything, repoID, emptyToken).Return(nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.UpdateAccessToken(context.Background(), repoID, emptyToken)
@@ -894,7 +1254,7 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockRepo.On("FindByID", mock.Anything, repoID).Return(nil, nil)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.UpdateAccessToken(context.Background(), repoID, "new-token")
@@ -915,7 +1275,7 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockGitManager := new(git.MockGitManager)
 
 		repoID := uuid.NewString()
-		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", "old-token")
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "old-token")
 		newToken := "new-token"
 		updateError := errors.New("db error")
 
@@ -924,7 +1284,7 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockRepo.On("UpdateAccessToken", mock.Anything, repoID, newToken).Return(updateError)
 
 		// テスト対象の UseCase を作成
-		uc := NewRepositoryUseCase(mockRepo, mockGitManager)
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
 
 		// テスト実行
 		err := uc.UpdateAccessToken(context.Background(), repoID, newToken)
@@ -937,4 +1297,583 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockRepo.AssertExpectations(t)
 		mockGitManager.AssertExpectations(t)
 	})
+
+	// テスト：トークンの確認ができなくても更新は成功することを確認する
+	t.Run("トークンの確認ができなくても更新は成功する", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "old-token")
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockRepo.On("UpdateAccessToken", mock.Anything, repoID, "new-token").Return(nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, testRepo).Return(git.AccessCheck{}, errors.New("network unreachable"))
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		err := uc.UpdateAccessToken(context.Background(), repoID, "new-token")
+
+		assert.NoError(t, err)
+		assert.Equal(t, entity.TokenStatusUnchecked, testRepo.TokenHealth().Status)
+		mockRepo.AssertNotCalled(t, "UpdateTokenHealth", mock.Anything, mock.Anything, mock.Anything)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+}
+
+// TestVerifyAccessToken はVerifyAccessTokenメソッドのテストです
+func TestVerifyAccessToken(t *testing.T) {
+	// テスト：拒否されたトークンが無効として記録されることを確認する
+	t.Run("拒否されたトークンが無効として記録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "revoked-token")
+		reason := "the token was rejected; it may have expired or been revoked"
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, testRepo).Return(git.AccessCheck{Valid: false, Reason: reason}, nil)
+		mockRepo.On("UpdateTokenHealth", mock.Anything, repoID, mock.AnythingOfType("entity.TokenHealth")).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		repo, err := uc.VerifyAccessToken(context.Background(), repoID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.TokenStatusInvalid, repo.TokenHealth().Status)
+		assert.Equal(t, reason, repo.TokenHealth().Message)
+		assert.NotNil(t, repo.TokenHealth().CheckedAt)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	// テスト：まもなく期限切れになるトークンが記録されることを確認する
+	t.Run("まもなく期限切れになるトークンが記録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://gitlab.com/example/test-repo", entity.ProviderGitLab, "token")
+		expiresAt := time.Now().Add(3 * 24 * time.Hour)
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, testRepo).Return(git.AccessCheck{Valid: true, ExpiresAt: &expiresAt}, nil)
+		mockRepo.On("UpdateTokenHealth", mock.Anything, repoID, mock.AnythingOfType("entity.TokenHealth")).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		repo, err := uc.VerifyAccessToken(context.Background(), repoID)
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.TokenStatusExpiringSoon, repo.TokenHealth().Status)
+		assert.Equal(t, &expiresAt, repo.TokenHealth().ExpiresAt)
+	})
+
+	// テスト：トークンを使わないリポジトリはバリデーションエラーになることを確認する
+	t.Run("トークンを使わないリポジトリはバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "public-repo", "https://github.com/example/public-repo", entity.ProviderGitHub, "")
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		_, err := uc.VerifyAccessToken(context.Background(), repoID)
+
+		var validationErr *apperror.ValidationFailedError
+		assert.True(t, errors.As(err, &validationErr))
+		mockGitManager.AssertNotCalled(t, "VerifyAccess", mock.Anything, mock.Anything)
+	})
+
+	// テスト：確認が完了しない場合はエラーになり状態が変わらないことを確認する
+	t.Run("確認が完了しない場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("VerifyAccess", mock.Anything, testRepo).Return(git.AccessCheck{}, errors.New("rate limited"))
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		_, err := uc.VerifyAccessToken(context.Background(), repoID)
+
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "failed to verify access token")
+		assert.Equal(t, entity.TokenStatusUnchecked, testRepo.TokenHealth().Status)
+		mockRepo.AssertNotCalled(t, "UpdateTokenHealth", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// newTestDeployKey generates an unencrypted OpenSSH private key for registration tests
+func newTestDeployKey(t *testing.T) string {
+	t.Helper()
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	block, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		t.Fatalf("failed to marshal key: %v", err)
+	}
+	return string(pem.EncodeToMemory(block))
+}
+
+// TestResolveFileCommit はResolveFileCommitメソッドのテストです
+func TestResolveFileCommit(t *testing.T) {
+	// テスト：ローカルのコピーからファイルのコミットを解決することを確認する
+	t.Run("ローカルのコピーからファイルのコミットを解決する", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
+		mockGitManager.On("ResolveFileCommit", mock.Anything, localPath, "docs/deploy.md", testRepo).Return("abc1234567", nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		commit, err := uc.ResolveFileCommit(context.Background(), repoID, "docs/deploy.md")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "abc1234567", commit)
+		mockGitManager.AssertNotCalled(t, "EnsureCloned", mock.Anything, mock.Anything)
+	})
+
+	// テスト：未同期のリポジトリは取得してからHEADを解決することを確認する
+	t.Run("未同期のリポジトリは取得してからHEADを解決する", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(filepath.Join(localPath, "missing"))
+		mockGitManager.On("EnsureCloned", mock.Anything, testRepo).Return(localPath, nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, testRepo).Return("def4567890", nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		commit, err := uc.ResolveFileCommit(context.Background(), repoID, "")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "def4567890", commit)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	// テスト：履歴に存在しないファイルはバリデーションエラーになることを確認する
+	t.Run("履歴に存在しないファイルはバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
+		mockGitManager.On("ResolveFileCommit", mock.Anything, localPath, "docs/missing.md", testRepo).Return("", errors.New("file does not exist in repository history"))
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		_, err := uc.ResolveFileCommit(context.Background(), repoID, "docs/missing.md")
+
+		var validationErr *apperror.ValidationFailedError
+		assert.ErrorAs(t, err, &validationErr)
+	})
+}
+
+// TestManagedFiles はListManagedFilesメソッドとReadManagedFileメソッドのテストです
+func TestManagedFiles(t *testing.T) {
+	// テスト：管理対象ファイルの一覧を取得できることを確認する
+	t.Run("管理対象ファイルの一覧を取得できる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, repoID).Return([]string{"docs/deploy.md", "docs/backup.md"}, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+		filePaths, err := uc.ListManagedFiles(context.Background(), repoID)
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs/deploy.md", "docs/backup.md"}, filePaths)
+	})
+
+	// テスト：ローカルのコピーから管理対象ファイルを読み込めることを確認する
+	t.Run("ローカルのコピーから管理対象ファイルを読み込める", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		repoID := uuid.NewString()
+		testRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, repoID).Return(testRepo, nil)
+		mockGitManager.On("LocalPath", testRepo).Return(localPath)
+		mockGitManager.On("ReadManagedFileContent", mock.Anything, localPath, "docs/deploy.md", testRepo).Return([]byte("# Deploy"), nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+		content, err := uc.ReadManagedFile(context.Background(), repoID, "docs/deploy.md")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "# Deploy", string(content))
+		mockGitManager.AssertNotCalled(t, "EnsureCloned", mock.Anything, mock.Anything)
+	})
+
+	// テスト：存在しないリポジトリIDでエラーになることを確認する
+	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.ListManagedFiles(context.Background(), "missing")
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+
+		_, err = uc.ReadManagedFile(context.Background(), "missing", "docs/deploy.md")
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+}
+
+// TestUpdateTracking はUpdateTrackingメソッドのテストです
+func TestUpdateTracking(t *testing.T) {
+	newRepo := func() entity.Repository {
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		repo.SetTracking("main", "docs")
+		return repo
+	}
+
+	t.Run("refを変更するとローカルのコピーを削除して同期がキューに登録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		queue := &fakeSyncQueue{}
+		repo := newRepo()
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+		mockGitManager.On("RemoveLocalCopy", repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, queue)
+
+		updated, err := uc.UpdateTracking(context.Background(), "repo-id", dto.UpdateTrackingRequest{Ref: "v2.0.0", RootPath: "docs"})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "v2.0.0", updated.Ref())
+		assert.Equal(t, "docs", updated.RootPath())
+		assert.Equal(t, []SyncJob{{RepositoryID: "repo-id", Trigger: entity.SyncTriggerManual}}, queue.jobs)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	t.Run("ルートパスのみ変更した場合はローカルのコピーを残して同期する", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		queue := &fakeSyncQueue{}
+		repo := newRepo()
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, queue)
+
+		updated, err := uc.UpdateTracking(context.Background(), "repo-id", dto.UpdateTrackingRequest{Ref: "main", RootPath: ""})
+
+		assert.NoError(t, err)
+		assert.Empty(t, updated.RootPath())
+		assert.Len(t, queue.jobs, 1)
+		mockGitManager.AssertNotCalled(t, "RemoveLocalCopy", mock.Anything)
+	})
+
+	t.Run("変更がない場合は保存も同期もしない", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		queue := &fakeSyncQueue{}
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(newRepo(), nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), queue)
+
+		_, err := uc.UpdateTracking(context.Background(), "repo-id", dto.UpdateTrackingRequest{Ref: "main", RootPath: "docs/"})
+
+		assert.NoError(t, err)
+		assert.Empty(t, queue.jobs)
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("不正なルートパスはバリデーションエラーになる", func(t *testing.T) {
+		uc := NewRepositoryUseCase(new(repository.MockRepository), new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateTracking(context.Background(), "repo-id", dto.UpdateTrackingRequest{RootPath: "docs/../../etc"})
+
+		var validationErr *apperror.ValidationFailedError
+		assert.True(t, errors.As(err, &validationErr))
+	})
+
+	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateTracking(context.Background(), "missing", dto.UpdateTrackingRequest{Ref: "main"})
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+}
+
+// TestUpdateFileRules はUpdateFileRulesメソッドのテストです
+func TestUpdateFileRules(t *testing.T) {
+	t.Run("ルールを保存し、一致するファイルが管理対象になる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockGitManager.On("ListRepositoryFiles", mock.Anything, localPath, repo).Return([]string{
+			"README.md", "docs/guide.md", "docs/runbooks/restart.md", "docs/drafts/new.md",
+		}, nil)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string{"docs/guide.md", "docs/runbooks/restart.md"}).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		updated, err := uc.UpdateFileRules(context.Background(), "repo-id", dto.UpdateFileRulesRequest{
+			IncludeGlobs: []string{"docs/**/*.md"},
+			ExcludeGlobs: []string{"docs/drafts/**"},
+		})
+
+		assert.NoError(t, err)
+		assert.Equal(t, []string{"docs/**/*.md"}, updated.IncludeGlobs())
+		assert.Equal(t, []string{"docs/drafts/**"}, updated.ExcludeGlobs())
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	t.Run("ルールを空にすると一致したファイルが管理対象から外れる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		repo.SetFileRules([]string{"docs/**/*.md"}, nil)
+		localPath := t.TempDir()
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string(nil)).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		_, err := uc.UpdateFileRules(context.Background(), "repo-id", dto.UpdateFileRulesRequest{})
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertNotCalled(t, "ListRepositoryFiles", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("不正なパターンは項目ごとのバリデーションエラーになる", func(t *testing.T) {
+		uc := NewRepositoryUseCase(new(repository.MockRepository), new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateFileRules(context.Background(), "repo-id", dto.UpdateFileRulesRequest{
+			IncludeGlobs: []string{"docs/**/*.md", "../secrets/*"},
+		})
+
+		var validationErr *apperror.ValidationFailedError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "includeGlobs[1]", validationErr.Errors[0].Field)
+	})
+
+	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateFileRules(context.Background(), "missing", dto.UpdateFileRulesRequest{IncludeGlobs: []string{"*.md"}})
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+}
+
+// TestUpdateRepository はUpdateRepositoryメソッドのテストです
+func TestUpdateRepository(t *testing.T) {
+	newRepo := func() entity.Repository {
+		return entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+	}
+
+	t.Run("名前のみ変更した場合はローカルのコピーを残して同期もしない", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		queue := &fakeSyncQueue{}
+		repo := newRepo()
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, queue)
+
+		updated, err := uc.UpdateRepository(context.Background(), "repo-id", dto.UpdateRepositoryRequest{Name: "  Runbooks  "})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "Runbooks", updated.Name())
+		assert.Equal(t, "https://github.com/example/test-repo", updated.URL())
+		assert.Empty(t, queue.jobs)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertNotCalled(t, "RemoveLocalCopy", mock.Anything)
+	})
+
+	t.Run("URLを変更するとローカルのコピーを削除して同期がキューに登録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		queue := &fakeSyncQueue{}
+		repo := newRepo()
+		newURL := "https://github.com/example/moved-repo"
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("FindByURL", mock.Anything, newURL).Return(nil, nil)
+		mockRepo.On("Save", mock.Anything, repo).Return(nil)
+		mockGitManager.On("RemoveLocalCopy", repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, queue)
+
+		updated, err := uc.UpdateRepository(context.Background(), "repo-id", dto.UpdateRepositoryRequest{URL: newURL})
+
+		assert.NoError(t, err)
+		assert.Equal(t, newURL, updated.URL())
+		assert.Equal(t, "test-repo", updated.Name())
+		assert.Equal(t, []SyncJob{{RepositoryID: "repo-id", Trigger: entity.SyncTriggerManual}}, queue.jobs)
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	t.Run("他のリポジトリと同じURLには変更できない", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		newURL := "https://github.com/example/other-repo"
+		other := entity.NewRepository("other-id", "other-repo", newURL, entity.ProviderGitHub, "")
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(newRepo(), nil)
+		mockRepo.On("FindByURL", mock.Anything, newURL).Return(other, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateRepository(context.Background(), "repo-id", dto.UpdateRepositoryRequest{URL: newURL})
+
+		var conflictErr *apperror.ConflictError
+		assert.True(t, errors.As(err, &conflictErr))
+		mockRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("別のプロバイダーのURLはバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(newRepo(), nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateRepository(context.Background(), "repo-id", dto.UpdateRepositoryRequest{URL: "https://gitlab.com/example/test-repo"})
+
+		var validationErr *apperror.ValidationFailedError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "url", validationErr.Errors[0].Field)
+	})
+
+	t.Run("長すぎる名前はバリデーションエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(newRepo(), nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateRepository(context.Background(), "repo-id", dto.UpdateRepositoryRequest{Name: strings.Repeat("a", 256)})
+
+		var validationErr *apperror.ValidationFailedError
+		require.True(t, errors.As(err, &validationErr))
+		assert.Equal(t, "name", validationErr.Errors[0].Field)
+	})
+
+	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		_, err := uc.UpdateRepository(context.Background(), "missing", dto.UpdateRepositoryRequest{Name: "renamed"})
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+}
+
+// TestDeleteRepository はDeleteRepositoryメソッドのテストです
+func TestDeleteRepository(t *testing.T) {
+	t.Run("cascadeを指定すると依存するデータごと削除してローカルのコピーも削除する", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Delete", mock.Anything, "repo-id").Return(nil)
+		mockGitManager.On("Forget", repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		err := uc.DeleteRepository(context.Background(), "repo-id", true)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Archive", mock.Anything, mock.Anything)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	t.Run("cascadeを指定しない場合はドキュメントを残してアーカイブする", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Archive", mock.Anything, "repo-id").Return(nil)
+		mockGitManager.On("Forget", repo).Return(nil)
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		err := uc.DeleteRepository(context.Background(), "repo-id", false)
+
+		assert.NoError(t, err)
+		mockRepo.AssertExpectations(t)
+		mockRepo.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+		mockGitManager.AssertExpectations(t)
+	})
+
+	t.Run("削除に失敗した場合はローカルのコピーを残す", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockGitManager := new(git.MockGitManager)
+		repo := entity.NewRepository("repo-id", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockRepo.On("Delete", mock.Anything, "repo-id").Return(errors.New("database error"))
+
+		uc := NewRepositoryUseCase(mockRepo, mockGitManager, &fakeSyncQueue{})
+
+		err := uc.DeleteRepository(context.Background(), "repo-id", true)
+
+		assert.Error(t, err)
+		mockGitManager.AssertNotCalled(t, "Forget", mock.Anything)
+	})
+
+	t.Run("存在しないリポジトリIDでエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		uc := NewRepositoryUseCase(mockRepo, new(git.MockGitManager), &fakeSyncQueue{})
+
+		err := uc.DeleteRepository(context.Background(), "missing", false)
+
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
 }
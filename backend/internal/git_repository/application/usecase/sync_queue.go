@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// syncTimeout bounds how long a single background sync may run.
+const syncTimeout = 10 * time.Minute
+
+// SyncJob describes a pending sync of a repository.
+type SyncJob struct {
+	RepositoryID string
+	Trigger      entity.SyncTrigger // What requested the sync
+	CommitSHA    string             // Head commit reported by the push, if known
+	ChangedFiles []string           // Managed files reported as changed by the push
+}
+
+// SyncQueue accepts repository syncs to be run in the background.
+type SyncQueue interface {
+	// Enqueue schedules a sync and reports whether it was accepted.
+	// Jobs for a repository that is already waiting are merged into the pending job.
+	Enqueue(job SyncJob) bool
+}
+
+// SyncListener is notified after a repository sync succeeds.
+type SyncListener interface {
+	// RepositorySynced receives the paths, relative to the repository root, that the sync changed.
+	RepositorySynced(ctx context.Context, repositoryID string, changedFiles []string) error
+}
+
+// SyncWorker is an in-process SyncQueue that runs syncs one at a time.
+type SyncWorker struct {
+	runner    SyncRunner
+	logger    *slog.Logger
+	listeners []SyncListener
+
+	mu      sync.Mutex
+	pending map[string]*SyncJob // Jobs waiting to run, keyed by repository ID
+	ready   chan string         // Repository IDs in the order they were enqueued
+}
+
+// NewSyncWorker creates a SyncWorker that holds up to capacity pending repositories.
+func NewSyncWorker(runner SyncRunner, logger *slog.Logger, capacity int) *SyncWorker {
+	return &SyncWorker{
+		runner:  runner,
+		logger:  logger,
+		pending: make(map[string]*SyncJob),
+		ready:   make(chan string, capacity),
+	}
+}
+
+// AddListener registers a listener for successful syncs. It must be called before Start.
+func (w *SyncWorker) AddListener(listener SyncListener) {
+	w.listeners = append(w.listeners, listener)
+}
+
+// Enqueue implements SyncQueue.
+func (w *SyncWorker) Enqueue(job SyncJob) bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	// A sync for this repository is already waiting; it will pick up the newer commit too
+	if pendingJob, ok := w.pending[job.RepositoryID]; ok {
+		if job.CommitSHA != "" {
+			pendingJob.CommitSHA = job.CommitSHA
+		}
+		pendingJob.ChangedFiles = mergeFiles(pendingJob.ChangedFiles, job.ChangedFiles)
+		return true
+	}
+
+	select {
+	case w.ready <- job.RepositoryID:
+		w.pending[job.RepositoryID] = &job
+		return true
+	default:
+		return false
+	}
+}
+
+// Start runs queued syncs until the context is cancelled.
+func (w *SyncWorker) Start(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case repoID := <-w.ready:
+			w.mu.Lock()
+			job := w.pending[repoID]
+			delete(w.pending, repoID)
+			w.mu.Unlock()
+
+			if job != nil {
+				w.run(ctx, *job)
+			}
+		}
+	}
+}
+
+// run syncs a single repository and reports the files that changed.
+func (w *SyncWorker) run(ctx context.Context, job SyncJob) {
+	ctx, cancel := context.WithTimeout(ctx, syncTimeout)
+	defer cancel()
+
+	run, err := w.runner.Run(ctx, job)
+	if err != nil {
+		w.logger.Error("Repository sync failed", "repo_id", job.RepositoryID, "trigger", job.Trigger, "commit", job.CommitSHA, "error", err.Error())
+		return
+	}
+	w.logger.Info("Repository synced", "repo_id", job.RepositoryID, "trigger", job.Trigger, "commit", run.CommitSHA(), "sync_run_id", run.ID(), "files_changed", len(run.FilesChanged()), "changed_managed_files", job.ChangedFiles)
+
+	if len(run.FilesChanged()) == 0 {
+		return
+	}
+	for _, listener := range w.listeners {
+		if err := listener.RepositorySynced(ctx, job.RepositoryID, run.FilesChanged()); err != nil {
+			w.logger.Error("Failed to apply repository changes", "repo_id", job.RepositoryID, "sync_run_id", run.ID(), "error", err.Error())
+		}
+	}
+}
+
+// mergeFiles appends the files from b that are not already in a.
+func mergeFiles(a, b []string) []string {
+	seen := make(map[string]bool, len(a))
+	for _, f := range a {
+		seen[f] = true
+	}
+	for _, f := range b {
+		if !seen[f] {
+			seen[f] = true
+			a = append(a, f)
+		}
+	}
+	return a
+}
@@ -0,0 +1,98 @@
+package repository
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestSyncWorker はSyncWorkerのテストです
+func TestSyncWorker(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// テスト：同じリポジトリの待機中のジョブはまとめられることを確認する
+	t.Run("同じリポジトリの待機中のジョブはまとめられる", func(t *testing.T) {
+		worker := NewSyncWorker(new(MockSyncRunner), logger, 10)
+
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: "repo-1", CommitSHA: "a", ChangedFiles: []string{"a.md"}}))
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: "repo-1", CommitSHA: "b", ChangedFiles: []string{"a.md", "b.md"}}))
+
+		assert.Len(t, worker.ready, 1)
+		assert.Equal(t, "b", worker.pending["repo-1"].CommitSHA)
+		assert.Equal(t, []string{"a.md", "b.md"}, worker.pending["repo-1"].ChangedFiles)
+	})
+
+	// テスト：キューが満杯の場合は受け付けないことを確認する
+	t.Run("キューが満杯の場合は受け付けない", func(t *testing.T) {
+		worker := NewSyncWorker(new(MockSyncRunner), logger, 1)
+
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: "repo-1"}))
+		assert.False(t, worker.Enqueue(SyncJob{RepositoryID: "repo-2"}))
+	})
+
+	// テスト：キューに登録されたリポジトリが同期されることを確認する
+	t.Run("キューに登録されたリポジトリが同期される", func(t *testing.T) {
+		mockRunner := new(MockSyncRunner)
+		synced := make(chan SyncJob, 1)
+		run := entity.NewSyncRun("run-1", "repo-1", entity.SyncTriggerManual)
+		mockRunner.On("Run", mock.Anything, mock.AnythingOfType("SyncJob")).Return(run, nil).Run(func(args mock.Arguments) {
+			synced <- args.Get(1).(SyncJob)
+		})
+
+		worker := NewSyncWorker(mockRunner, logger, 10)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go worker.Start(ctx)
+
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: "repo-1", Trigger: entity.SyncTriggerManual}))
+
+		select {
+		case job := <-synced:
+			assert.Equal(t, "repo-1", job.RepositoryID)
+			assert.Equal(t, entity.SyncTriggerManual, job.Trigger)
+		case <-time.After(5 * time.Second):
+			t.Fatal("repository was not synced")
+		}
+	})
+
+	// テスト：同期で変更されたファイルがリスナーに通知されることを確認する
+	t.Run("同期で変更されたファイルがリスナーに通知される", func(t *testing.T) {
+		mockRunner := new(MockSyncRunner)
+		run := entity.NewSyncRun("run-1", "repo-1", entity.SyncTriggerWebhook)
+		run.Succeed("abc123", []string{"docs/deploy.md"})
+		mockRunner.On("Run", mock.Anything, mock.AnythingOfType("SyncJob")).Return(run, nil)
+
+		listener := &fakeSyncListener{notified: make(chan []string, 1)}
+		worker := NewSyncWorker(mockRunner, logger, 10)
+		worker.AddListener(listener)
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+		go worker.Start(ctx)
+
+		assert.True(t, worker.Enqueue(SyncJob{RepositoryID: "repo-1", Trigger: entity.SyncTriggerWebhook}))
+
+		select {
+		case files := <-listener.notified:
+			assert.Equal(t, []string{"docs/deploy.md"}, files)
+		case <-time.After(5 * time.Second):
+			t.Fatal("listener was not notified")
+		}
+	})
+}
+
+// fakeSyncListener records the files of each successful sync
+type fakeSyncListener struct {
+	notified chan []string
+}
+
+func (l *fakeSyncListener) RepositorySynced(ctx context.Context, repositoryID string, changedFiles []string) error {
+	l.notified <- changedFiles
+	return nil
+}
@@ -0,0 +1,134 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/google/uuid"
+)
+
+// SyncRunner performs repository syncs and records each attempt as a SyncRun.
+type SyncRunner interface {
+	// Run refreshes the local copy of the job's repository. The returned run is recorded even when the sync fails.
+	Run(ctx context.Context, job SyncJob) (entity.SyncRun, error)
+}
+
+// syncRunner implements the SyncRunner interface.
+type syncRunner struct {
+	repo       repository.Repository        // Persistence for repository metadata
+	syncRuns   repository.SyncRunRepository // Persistence for sync history
+	gitManager git.GitManager               // For fetching repository contents
+}
+
+// NewSyncRunner creates a new instance of syncRunner.
+func NewSyncRunner(repo repository.Repository, syncRuns repository.SyncRunRepository, gitManager git.GitManager) SyncRunner {
+	return &syncRunner{
+		repo:       repo,
+		syncRuns:   syncRuns,
+		gitManager: gitManager,
+	}
+}
+
+// Run implements the logic for syncing a repository.
+func (r *syncRunner) Run(ctx context.Context, job SyncJob) (entity.SyncRun, error) {
+	// 1. Find the repository
+	repo, err := r.repo.FindByID(ctx, job.RepositoryID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", job.RepositoryID, nil)
+	}
+
+	trigger := job.Trigger
+	if trigger == "" {
+		trigger = entity.SyncTriggerManual
+	}
+
+	// 2. Record that the sync started
+	run := entity.NewSyncRun(uuid.NewString(), repo.ID(), trigger)
+	if err := r.syncRuns.Save(ctx, run); err != nil {
+		return nil, fmt.Errorf("failed to save sync run: %w", err)
+	}
+
+	// 3. Fetch the latest contents, comparing the local copy before and after,
+	// and re-evaluate the file rules so that new matching files become managed
+	filesChanged, syncErr := r.fetch(ctx, repo)
+	if syncErr == nil {
+		syncErr = applyFileRules(ctx, r.repo, r.gitManager, repo, r.gitManager.LocalPath(repo))
+	}
+	if syncErr == nil {
+		filesChanged, syncErr = r.managedChanges(ctx, repo.ID(), filesChanged)
+	}
+	// Record the commit the local copy is now at, whichever trigger started the sync
+	var commitSHA string
+	if syncErr == nil {
+		commitSHA, syncErr = r.gitManager.ResolveHeadCommit(ctx, r.gitManager.LocalPath(repo), repo)
+		if syncErr != nil {
+			syncErr = fmt.Errorf("failed to resolve head commit: %w", syncErr)
+		}
+	}
+	if syncErr != nil {
+		run.Fail(syncErr.Error())
+	} else {
+		run.Succeed(commitSHA, filesChanged)
+	}
+
+	// 4. Record the outcome; use a fresh context so a timed-out sync is still recorded
+	if err := r.syncRuns.Save(context.WithoutCancel(ctx), run); err != nil {
+		return run, fmt.Errorf("failed to save sync run: %w", err)
+	}
+	if syncErr != nil {
+		return run, fmt.Errorf("failed to sync repository: %w", syncErr)
+	}
+	return run, nil
+}
+
+// fetch updates the local copy of the repository and returns the paths it changed.
+func (r *syncRunner) fetch(ctx context.Context, repo entity.Repository) ([]string, error) {
+	localPath := r.gitManager.LocalPath(repo)
+	before, err := git.SnapshotFiles(localPath)
+	if err != nil {
+		return nil, err
+	}
+
+	clonedPath, err := r.gitManager.EnsureCloned(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	after, err := git.SnapshotFiles(clonedPath)
+	if err != nil {
+		return nil, err
+	}
+	return git.ChangedFiles(before, after), nil
+}
+
+// managedChanges narrows the changed paths to the files managed for the repository.
+func (r *syncRunner) managedChanges(ctx context.Context, repoID string, changed []string) ([]string, error) {
+	if len(changed) == 0 {
+		return changed, nil
+	}
+
+	managedPaths, err := r.repo.GetManagedFiles(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve managed files: %w", err)
+	}
+	managed := make(map[string]bool, len(managedPaths))
+	for _, filePath := range managedPaths {
+		managed[filePath] = true
+	}
+
+	filtered := []string{}
+	for _, filePath := range changed {
+		if managed[filePath] {
+			filtered = append(filtered, filePath)
+		}
+	}
+	return filtered, nil
+}
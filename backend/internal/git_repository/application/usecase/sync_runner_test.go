@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncRunnerRun はSyncRunner.Runメソッドのテストです
+func TestSyncRunnerRun(t *testing.T) {
+	repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+
+	// テスト：同期に成功し、変更されたファイルが記録されることを確認する
+	t.Run("同期に成功し変更されたファイルが記録される", func(t *testing.T) {
+		localPath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "README.md"), []byte("v1"), 0644))
+
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, repo).Return(localPath, nil).Run(func(args mock.Arguments) {
+			// Simulate the pull updating the local copy
+			require.NoError(t, os.WriteFile(filepath.Join(localPath, "README.md"), []byte("v2"), 0644))
+		})
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string(nil)).Return(nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, "repo-id").Return([]string{"README.md"}, nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, repo).Return("abc123", nil)
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id", Trigger: entity.SyncTriggerSchedule})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SyncStatusSucceeded, run.Status())
+		assert.Equal(t, entity.SyncTriggerSchedule, run.Trigger())
+		assert.Equal(t, "abc123", run.CommitSHA())
+		assert.Equal(t, []string{"README.md"}, run.FilesChanged())
+		assert.NotNil(t, run.FinishedAt())
+		mockSyncRuns.AssertNumberOfCalls(t, "Save", 2)
+	})
+
+	// テスト：Webhookで通知されたコミットではなく、取得後のHEADのコミットが記録されることを確認する
+	t.Run("取得後のHEADのコミットが記録される", func(t *testing.T) {
+		localPath := t.TempDir()
+
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, repo).Return(localPath, nil)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string(nil)).Return(nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, repo).Return("def4567890", nil)
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id", Trigger: entity.SyncTriggerWebhook, CommitSHA: "abc123"})
+
+		require.NoError(t, err)
+		assert.Equal(t, "def4567890", run.CommitSHA())
+		mockSyncRuns.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(r entity.SyncRun) bool {
+			return r.Status() == entity.SyncStatusSucceeded && r.CommitSHA() == "def4567890"
+		}))
+	})
+
+	// テスト：HEADのコミットを解決できない場合は同期が失敗として記録されることを確認する
+	t.Run("HEADのコミットを解決できない場合は失敗として記録される", func(t *testing.T) {
+		localPath := t.TempDir()
+
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, repo).Return(localPath, nil)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string(nil)).Return(nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, repo).Return("", errors.New("not a git repository"))
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id"})
+
+		require.Error(t, err)
+		assert.Equal(t, entity.SyncStatusFailed, run.Status())
+		assert.Contains(t, run.ErrorMessage(), "not a git repository")
+	})
+
+	// テスト：管理対象外のファイルの変更は記録されないことを確認する
+	t.Run("管理対象のファイルの変更だけが記録される", func(t *testing.T) {
+		localPath := t.TempDir()
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "runbook.md"), []byte("v1"), 0644))
+
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, repo).Return(localPath, nil).Run(func(args mock.Arguments) {
+			// Simulate the pull updating a managed file and adding an unmanaged one
+			require.NoError(t, os.WriteFile(filepath.Join(localPath, "runbook.md"), []byte("v2"), 0644))
+			require.NoError(t, os.WriteFile(filepath.Join(localPath, "main.go"), []byte("package main"), 0644))
+		})
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string(nil)).Return(nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, "repo-id").Return([]string{"runbook.md"}, nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, repo).Return("abc123", nil)
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SyncStatusSucceeded, run.Status())
+		assert.Equal(t, []string{"runbook.md"}, run.FilesChanged())
+	})
+
+	// テスト：同期のたびにルールが再評価され、新しく追加されたファイルが管理対象になることを確認する
+	t.Run("同期のたびにルールに一致するファイルが管理対象になる", func(t *testing.T) {
+		localPath := t.TempDir()
+		ruled := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		ruled.SetFileRules([]string{"docs/**/*.md"}, nil)
+
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(ruled, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", ruled).Return(localPath)
+		mockGitManager.On("EnsureCloned", mock.Anything, ruled).Return(localPath, nil)
+		mockGitManager.On("ListRepositoryFiles", mock.Anything, localPath, ruled).Return([]string{"README.md", "docs/new-runbook.md"}, nil)
+		mockRepo.On("SaveRuleMatchedFiles", mock.Anything, "repo-id", []string{"docs/new-runbook.md"}).Return(nil)
+		mockGitManager.On("ResolveHeadCommit", mock.Anything, localPath, ruled).Return("abc123", nil)
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id"})
+
+		require.NoError(t, err)
+		assert.Equal(t, entity.SyncStatusSucceeded, run.Status())
+		mockRepo.AssertExpectations(t)
+	})
+
+	// テスト：同期に失敗した場合もエラーが記録されることを確認する
+	t.Run("同期に失敗した場合もエラーが記録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockGitManager := new(git.MockGitManager)
+
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("Save", mock.Anything, mock.Anything).Return(nil)
+		mockGitManager.On("LocalPath", repo).Return(filepath.Join(t.TempDir(), "missing"))
+		mockGitManager.On("EnsureCloned", mock.Anything, repo).Return("", errors.New("authentication failed"))
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, mockGitManager)
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "repo-id"})
+
+		require.Error(t, err)
+		require.NotNil(t, run)
+		assert.Equal(t, entity.SyncStatusFailed, run.Status())
+		assert.Equal(t, entity.SyncTriggerManual, run.Trigger())
+		assert.Equal(t, "authentication failed", run.ErrorMessage())
+		mockSyncRuns.AssertCalled(t, "Save", mock.Anything, mock.MatchedBy(func(r entity.SyncRun) bool {
+			return r.Status() == entity.SyncStatusFailed
+		}))
+	})
+
+	// テスト：リポジトリが存在しない場合はエラーになることを確認する
+	t.Run("リポジトリが存在しない場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+
+		runner := NewSyncRunner(mockRepo, mockSyncRuns, new(git.MockGitManager))
+		run, err := runner.Run(context.Background(), SyncJob{RepositoryID: "missing"})
+
+		assert.Nil(t, run)
+		var notFoundErr *apperror.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		mockSyncRuns.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+}
@@ -0,0 +1,74 @@
+package repository
+
+import (
+	"context"
+	"log/slog"
+	"math/rand/v2"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+)
+
+// SyncScheduler periodically enqueues a sync of every registered repository,
+// so repositories that cannot send webhooks are still refreshed.
+type SyncScheduler struct {
+	repo     repository.Repository
+	queue    SyncQueue
+	interval time.Duration // Time between rounds
+	jitter   time.Duration // Upper bound of the random delay added to each interval
+	logger   *slog.Logger
+}
+
+// NewSyncScheduler creates a SyncScheduler. A non-positive interval disables scheduling.
+func NewSyncScheduler(repo repository.Repository, queue SyncQueue, interval, jitter time.Duration, logger *slog.Logger) *SyncScheduler {
+	return &SyncScheduler{
+		repo:     repo,
+		queue:    queue,
+		interval: interval,
+		jitter:   jitter,
+		logger:   logger,
+	}
+}
+
+// Start enqueues syncs every interval (plus jitter) until the context is cancelled.
+func (s *SyncScheduler) Start(ctx context.Context) {
+	if s.interval <= 0 {
+		s.logger.Info("Scheduled repository sync is disabled")
+		return
+	}
+
+	for {
+		timer := time.NewTimer(s.nextDelay())
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.enqueueAll(ctx)
+		}
+	}
+}
+
+// nextDelay returns the interval plus a random delay in [0, jitter).
+func (s *SyncScheduler) nextDelay() time.Duration {
+	if s.jitter <= 0 {
+		return s.interval
+	}
+	return s.interval + rand.N(s.jitter)
+}
+
+// enqueueAll enqueues a scheduled sync of every registered repository.
+func (s *SyncScheduler) enqueueAll(ctx context.Context) {
+	repos, err := s.repo.FindAll(ctx)
+	if err != nil {
+		s.logger.Error("Failed to list repositories for scheduled sync", "error", err.Error())
+		return
+	}
+
+	for _, repo := range repos {
+		if !s.queue.Enqueue(SyncJob{RepositoryID: repo.ID(), Trigger: entity.SyncTriggerSchedule}) {
+			s.logger.Warn("Sync queue is full; skipping scheduled sync", "repo_id", repo.ID())
+		}
+	}
+}
@@ -0,0 +1,75 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSyncScheduler はSyncSchedulerのテストです
+func TestSyncScheduler(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// テスト：登録されたすべてのリポジトリの同期がキューに登録されることを確認する
+	t.Run("すべてのリポジトリの同期がキューに登録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return([]entity.Repository{
+			entity.NewRepository("repo-1", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token"),
+			entity.NewRepository("repo-2", "wiki", "https://gitlab.com/example/wiki.git", entity.ProviderGitLab, "token"),
+		}, nil)
+		queue := &fakeSyncQueue{}
+
+		scheduler := NewSyncScheduler(mockRepo, queue, time.Hour, time.Minute, logger)
+		scheduler.enqueueAll(context.Background())
+
+		require.Len(t, queue.jobs, 2)
+		assert.Equal(t, SyncJob{RepositoryID: "repo-1", Trigger: entity.SyncTriggerSchedule}, queue.jobs[0])
+		assert.Equal(t, SyncJob{RepositoryID: "repo-2", Trigger: entity.SyncTriggerSchedule}, queue.jobs[1])
+	})
+
+	// テスト：リポジトリの取得に失敗した場合は何も登録しないことを確認する
+	t.Run("リポジトリの取得に失敗した場合は何も登録しない", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return(nil, errors.New("db error"))
+		queue := &fakeSyncQueue{}
+
+		NewSyncScheduler(mockRepo, queue, time.Hour, 0, logger).enqueueAll(context.Background())
+
+		assert.Empty(t, queue.jobs)
+	})
+
+	// テスト：待機時間が間隔とジッターの範囲に収まることを確認する
+	t.Run("待機時間が間隔とジッターの範囲に収まる", func(t *testing.T) {
+		scheduler := NewSyncScheduler(nil, nil, time.Hour, 5*time.Minute, logger)
+		for i := 0; i < 100; i++ {
+			delay := scheduler.nextDelay()
+			assert.GreaterOrEqual(t, delay, time.Hour)
+			assert.Less(t, delay, time.Hour+5*time.Minute)
+		}
+	})
+
+	// テスト：間隔が0の場合はすぐに終了することを確認する
+	t.Run("間隔が0の場合はすぐに終了する", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			NewSyncScheduler(nil, nil, 0, 0, logger).Start(context.Background())
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("scheduler did not return")
+		}
+	})
+}
@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+)
+
+const (
+	// defaultSyncRunLimit is the number of sync runs listed when no limit is given.
+	defaultSyncRunLimit = 20
+	// maxSyncRunLimit caps the number of sync runs listed at once.
+	maxSyncRunLimit = 100
+)
+
+// SyncUseCase defines the interface for inspecting and requesting repository syncs.
+type SyncUseCase interface {
+	// TriggerSync enqueues a manual sync of a repository.
+	TriggerSync(ctx context.Context, repoID string) error
+	// ListSyncRuns retrieves the most recent sync runs of a repository, newest first.
+	ListSyncRuns(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error)
+	// GetLastSuccessfulSync retrieves the most recent successful sync run, or nil if the repository never synced.
+	GetLastSuccessfulSync(ctx context.Context, repoID string) (entity.SyncRun, error)
+}
+
+// syncUseCase implements the SyncUseCase interface.
+type syncUseCase struct {
+	repo     repository.Repository        // Persistence for repository metadata
+	syncRuns repository.SyncRunRepository // Persistence for sync history
+	queue    SyncQueue                    // Runs repository syncs in the background
+}
+
+// NewSyncUseCase creates a new instance of syncUseCase.
+func NewSyncUseCase(repo repository.Repository, syncRuns repository.SyncRunRepository, queue SyncQueue) SyncUseCase {
+	return &syncUseCase{
+		repo:     repo,
+		syncRuns: syncRuns,
+		queue:    queue,
+	}
+}
+
+// TriggerSync implements the logic for requesting a manual sync.
+func (uc *syncUseCase) TriggerSync(ctx context.Context, repoID string) error {
+	if err := uc.ensureRepositoryExists(ctx, repoID); err != nil {
+		return err
+	}
+
+	if !uc.queue.Enqueue(SyncJob{RepositoryID: repoID, Trigger: entity.SyncTriggerManual}) {
+		return fmt.Errorf("failed to enqueue sync for repository %s: sync queue is full", repoID)
+	}
+	return nil
+}
+
+// ListSyncRuns implements the logic for listing recent sync runs.
+func (uc *syncUseCase) ListSyncRuns(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error) {
+	if err := uc.ensureRepositoryExists(ctx, repoID); err != nil {
+		return nil, err
+	}
+
+	if limit <= 0 {
+		limit = defaultSyncRunLimit
+	}
+	if limit > maxSyncRunLimit {
+		limit = maxSyncRunLimit
+	}
+
+	runs, err := uc.syncRuns.FindByRepositoryID(ctx, repoID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve sync runs: %w", err)
+	}
+	return runs, nil
+}
+
+// GetLastSuccessfulSync implements the logic for finding the last successful sync.
+func (uc *syncUseCase) GetLastSuccessfulSync(ctx context.Context, repoID string) (entity.SyncRun, error) {
+	if err := uc.ensureRepositoryExists(ctx, repoID); err != nil {
+		return nil, err
+	}
+
+	run, err := uc.syncRuns.FindLatestSucceeded(ctx, repoID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve last successful sync run: %w", err)
+	}
+	return run, nil
+}
+
+// ensureRepositoryExists returns a not found error when the repository is not registered.
+func (uc *syncUseCase) ensureRepositoryExists(ctx context.Context, repoID string) error {
+	repo, err := uc.repo.FindByID(ctx, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve repository details: %w", err)
+	}
+	if repo == nil {
+		return apperror.NewNotFoundError("Repository", repoID, nil)
+	}
+	return nil
+}
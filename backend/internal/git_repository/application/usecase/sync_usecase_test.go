@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTriggerSync はTriggerSyncメソッドのテストです
+func TestTriggerSync(t *testing.T) {
+	repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+
+	// テスト：手動同期がキューに登録されることを確認する
+	t.Run("手動同期がキューに登録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		queue := &fakeSyncQueue{}
+
+		uc := NewSyncUseCase(mockRepo, new(repository.MockSyncRunRepository), queue)
+		require.NoError(t, uc.TriggerSync(context.Background(), "repo-id"))
+
+		require.Len(t, queue.jobs, 1)
+		assert.Equal(t, SyncJob{RepositoryID: "repo-id", Trigger: entity.SyncTriggerManual}, queue.jobs[0])
+	})
+
+	// テスト：リポジトリが存在しない場合はNotFoundエラーになることを確認する
+	t.Run("リポジトリが存在しない場合はNotFoundエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "missing").Return(nil, nil)
+		queue := &fakeSyncQueue{}
+
+		uc := NewSyncUseCase(mockRepo, new(repository.MockSyncRunRepository), queue)
+		err := uc.TriggerSync(context.Background(), "missing")
+
+		var notFoundErr *apperror.NotFoundError
+		assert.ErrorAs(t, err, &notFoundErr)
+		assert.Empty(t, queue.jobs)
+	})
+
+	// テスト：キューが満杯の場合はエラーになることを確認する
+	t.Run("キューが満杯の場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+
+		uc := NewSyncUseCase(mockRepo, new(repository.MockSyncRunRepository), &fakeSyncQueue{reject: true})
+		assert.Error(t, uc.TriggerSync(context.Background(), "repo-id"))
+	})
+}
+
+// TestListSyncRuns はListSyncRunsメソッドのテストです
+func TestListSyncRuns(t *testing.T) {
+	repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+	runs := []entity.SyncRun{entity.NewSyncRun("run-1", "repo-id", entity.SyncTriggerSchedule)}
+
+	tests := []struct {
+		name          string
+		limit         int
+		expectedLimit int
+	}{
+		{name: "指定した件数で取得する", limit: 5, expectedLimit: 5},
+		{name: "未指定の場合は既定の件数で取得する", limit: 0, expectedLimit: defaultSyncRunLimit},
+		{name: "上限を超える件数は切り詰められる", limit: 1000, expectedLimit: maxSyncRunLimit},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(repository.MockRepository)
+			mockSyncRuns := new(repository.MockSyncRunRepository)
+			mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+			mockSyncRuns.On("FindByRepositoryID", mock.Anything, "repo-id", tt.expectedLimit).Return(runs, nil)
+
+			uc := NewSyncUseCase(mockRepo, mockSyncRuns, &fakeSyncQueue{})
+			result, err := uc.ListSyncRuns(context.Background(), "repo-id", tt.limit)
+
+			require.NoError(t, err)
+			assert.Equal(t, runs, result)
+			mockSyncRuns.AssertExpectations(t)
+		})
+	}
+}
+
+// TestGetLastSuccessfulSync はGetLastSuccessfulSyncメソッドのテストです
+func TestGetLastSuccessfulSync(t *testing.T) {
+	repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+
+	// テスト：一度も同期に成功していない場合はnilを返すことを確認する
+	t.Run("一度も同期に成功していない場合はnilを返す", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockSyncRuns := new(repository.MockSyncRunRepository)
+		mockRepo.On("FindByID", mock.Anything, "repo-id").Return(repo, nil)
+		mockSyncRuns.On("FindLatestSucceeded", mock.Anything, "repo-id").Return(nil, nil)
+
+		uc := NewSyncUseCase(mockRepo, mockSyncRuns, &fakeSyncQueue{})
+		run, err := uc.GetLastSuccessfulSync(context.Background(), "repo-id")
+
+		require.NoError(t, err)
+		assert.Nil(t, run)
+	})
+}
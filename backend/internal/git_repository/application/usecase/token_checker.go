@@ -0,0 +1,90 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+)
+
+// TokenChecker periodically verifies the access token of every repository that uses one,
+// so tokens that expire or are revoked after registration show up in the repository status.
+type TokenChecker struct {
+	repo       repository.Repository
+	gitManager git.GitManager
+	interval   time.Duration // Time between rounds
+	logger     *slog.Logger
+}
+
+// NewTokenChecker creates a TokenChecker. A non-positive interval disables the periodic check.
+func NewTokenChecker(repo repository.Repository, gitManager git.GitManager, interval time.Duration, logger *slog.Logger) *TokenChecker {
+	return &TokenChecker{
+		repo:       repo,
+		gitManager: gitManager,
+		interval:   interval,
+		logger:     logger,
+	}
+}
+
+// Start verifies the tokens every interval until the context is cancelled.
+func (c *TokenChecker) Start(ctx context.Context) {
+	if c.interval <= 0 {
+		c.logger.Info("Periodic access token check is disabled")
+		return
+	}
+
+	ticker := time.NewTicker(c.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			c.checkAll(ctx)
+		}
+	}
+}
+
+// checkAll verifies the access token of every registered repository that uses one.
+func (c *TokenChecker) checkAll(ctx context.Context) {
+	repos, err := c.repo.FindAll(ctx)
+	if err != nil {
+		c.logger.Error("Failed to list repositories for access token check", "error", err.Error())
+		return
+	}
+
+	for _, repo := range repos {
+		if err := verifyAccessToken(ctx, c.repo, c.gitManager, repo); err != nil {
+			c.logger.Warn("Failed to verify access token", "repo_id", repo.ID(), "error", err.Error())
+			continue
+		}
+		if health := repo.TokenHealth(); health.Status == entity.TokenStatusInvalid || health.Status == entity.TokenStatusExpiringSoon {
+			c.logger.Warn("Access token needs attention", "repo_id", repo.ID(), "token_status", health.Status.String(), "reason", health.Message)
+		}
+	}
+}
+
+// verifyAccessToken asks the provider whether the repository's access token still works and records the result.
+// Repositories that do not authenticate with a token are left unchecked. An error means the check could not be
+// completed or its result could not be saved; the previously recorded status is kept.
+func verifyAccessToken(ctx context.Context, repoStore repository.Repository, gitManager git.GitManager, repo entity.Repository) error {
+	if repo.AuthMethod() != entity.AuthMethodToken {
+		return nil
+	}
+
+	check, err := gitManager.VerifyAccess(ctx, repo)
+	if err != nil {
+		return fmt.Errorf("failed to verify access token: %w", err)
+	}
+
+	health := entity.NewTokenHealth(check.Valid, check.ExpiresAt, check.Reason, time.Now())
+	if err := repoStore.UpdateTokenHealth(ctx, repo.ID(), health); err != nil {
+		return fmt.Errorf("failed to save access token status: %w", err)
+	}
+	repo.SetTokenHealth(health)
+	return nil
+}
@@ -0,0 +1,76 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/git"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+// TestTokenChecker はTokenCheckerのテストです
+func TestTokenChecker(t *testing.T) {
+	logger := slog.New(slog.NewTextHandler(io.Discard, nil))
+
+	// テスト：トークンを使うリポジトリだけが確認されることを確認する
+	t.Run("トークンを使うリポジトリだけが確認される", func(t *testing.T) {
+		withToken := entity.NewRepository("repo-1", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		public := entity.NewRepository("repo-2", "wiki", "https://gitlab.com/example/wiki.git", entity.ProviderGitLab, "")
+
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return([]entity.Repository{withToken, public}, nil)
+		mockRepo.On("UpdateTokenHealth", mock.Anything, "repo-1", mock.MatchedBy(func(h entity.TokenHealth) bool {
+			return h.Status == entity.TokenStatusValid
+		})).Return(nil)
+		mockGitManager := new(git.MockGitManager)
+		mockGitManager.On("VerifyAccess", mock.Anything, withToken).Return(git.AccessCheck{Valid: true}, nil)
+
+		NewTokenChecker(mockRepo, mockGitManager, time.Hour, logger).checkAll(context.Background())
+
+		mockRepo.AssertExpectations(t)
+		mockGitManager.AssertExpectations(t)
+		mockGitManager.AssertNumberOfCalls(t, "VerifyAccess", 1)
+	})
+
+	// テスト：確認に失敗したリポジトリがあっても残りを確認することを確認する
+	t.Run("確認に失敗したリポジトリがあっても残りを確認する", func(t *testing.T) {
+		failing := entity.NewRepository("repo-1", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		revoked := entity.NewRepository("repo-2", "wiki", "https://gitlab.com/example/wiki.git", entity.ProviderGitLab, "token")
+
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindAll", mock.Anything).Return([]entity.Repository{failing, revoked}, nil)
+		mockRepo.On("UpdateTokenHealth", mock.Anything, "repo-2", mock.AnythingOfType("entity.TokenHealth")).Return(nil)
+		mockGitManager := new(git.MockGitManager)
+		mockGitManager.On("VerifyAccess", mock.Anything, failing).Return(git.AccessCheck{}, errors.New("timeout"))
+		mockGitManager.On("VerifyAccess", mock.Anything, revoked).Return(git.AccessCheck{Reason: "the token was rejected"}, nil)
+
+		NewTokenChecker(mockRepo, mockGitManager, time.Hour, logger).checkAll(context.Background())
+
+		assert.Equal(t, entity.TokenStatusUnchecked, failing.TokenHealth().Status)
+		assert.Equal(t, entity.TokenStatusInvalid, revoked.TokenHealth().Status)
+		mockRepo.AssertExpectations(t)
+	})
+
+	// テスト：間隔が0の場合はすぐに終了することを確認する
+	t.Run("間隔が0の場合はすぐに終了する", func(t *testing.T) {
+		done := make(chan struct{})
+		go func() {
+			NewTokenChecker(nil, nil, 0, logger).Start(context.Background())
+			close(done)
+		}()
+
+		select {
+		case <-done:
+		case <-time.After(5 * time.Second):
+			t.Fatal("token checker did not return")
+		}
+	})
+}
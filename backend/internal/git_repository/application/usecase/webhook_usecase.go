@@ -0,0 +1,128 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+)
+
+// WebhookUseCase defines the interface for handling Git hosting provider webhooks.
+type WebhookUseCase interface {
+	// HandlePush maps a push event to a registered repository and enqueues a sync of it.
+	HandlePush(ctx context.Context, event dto.PushEvent) (*dto.PushSyncResult, error)
+}
+
+// webhookUseCase implements the WebhookUseCase interface.
+type webhookUseCase struct {
+	repo  repository.Repository // Persistence for repository metadata
+	queue SyncQueue             // Runs repository syncs in the background
+}
+
+// NewWebhookUseCase creates a new instance of webhookUseCase.
+func NewWebhookUseCase(repo repository.Repository, queue SyncQueue) WebhookUseCase {
+	return &webhookUseCase{
+		repo:  repo,
+		queue: queue,
+	}
+}
+
+// HandlePush implements the logic for handling a push event.
+func (uc *webhookUseCase) HandlePush(ctx context.Context, event dto.PushEvent) (*dto.PushSyncResult, error) {
+	// 1. Find the registered repository the push belongs to
+	repo, err := uc.findRepository(ctx, event.RepositoryURLs)
+	if err != nil {
+		return nil, err
+	}
+	if repo == nil {
+		return nil, apperror.NewNotFoundError("Repository", strings.Join(event.RepositoryURLs, ", "), nil)
+	}
+
+	result := &dto.PushSyncResult{
+		RepositoryID:        repo.ID(),
+		Ref:                 event.Ref,
+		CommitSHA:           event.CommitSHA,
+		ChangedManagedFiles: []string{},
+	}
+
+	// 2. Only pushes to the tracked branch or tag change the synced contents
+	if !tracksPushRef(repo, event) {
+		return result, nil
+	}
+
+	// 3. Determine which managed files the pushed commits touched
+	managedFiles, err := uc.repo.GetManagedFiles(ctx, repo.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve managed files: %w", err)
+	}
+	result.ChangedManagedFiles = changedManagedFiles(managedFiles, event.ChangedPaths)
+
+	// 4. Enqueue the sync
+	result.Queued = uc.queue.Enqueue(SyncJob{
+		RepositoryID: repo.ID(),
+		Trigger:      entity.SyncTriggerWebhook,
+		CommitSHA:    event.CommitSHA,
+		ChangedFiles: result.ChangedManagedFiles,
+	})
+	if !result.Queued {
+		return nil, fmt.Errorf("failed to enqueue sync for repository %s: sync queue is full", repo.ID())
+	}
+
+	return result, nil
+}
+
+// findRepository returns the registered repository matching any of the URLs.
+// URLs are tried with and without the .git suffix, since either form may have been registered.
+func (uc *webhookUseCase) findRepository(ctx context.Context, urls []string) (entity.Repository, error) {
+	tried := map[string]bool{}
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		trimmed := strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+		for _, candidate := range []string{u, trimmed, trimmed + ".git"} {
+			if tried[candidate] {
+				continue
+			}
+			tried[candidate] = true
+
+			repo, err := uc.repo.FindByURL(ctx, candidate)
+			if err != nil {
+				return nil, fmt.Errorf("failed to find repository by URL: %w", err)
+			}
+			if repo != nil {
+				return repo, nil
+			}
+		}
+	}
+	return nil, nil
+}
+
+// tracksPushRef reports whether a push updated the ref the repository is synced from.
+// Repositories without a pinned ref track the default branch; pushes without a known default branch are always synced.
+func tracksPushRef(repo entity.Repository, event dto.PushEvent) bool {
+	if repo.Ref() != "" {
+		return event.Ref == "refs/heads/"+repo.Ref() || event.Ref == "refs/tags/"+repo.Ref()
+	}
+	return event.DefaultBranch == "" || event.Ref == "refs/heads/"+event.DefaultBranch
+}
+
+// changedManagedFiles returns the managed files that appear in the changed paths, in managed file order.
+func changedManagedFiles(managedFiles []string, changedPaths []string) []string {
+	changed := make(map[string]bool, len(changedPaths))
+	for _, p := range changedPaths {
+		changed[p] = true
+	}
+
+	result := []string{}
+	for _, f := range managedFiles {
+		if changed[f] {
+			result = append(result, f)
+		}
+	}
+	return result
+}
@@ -0,0 +1,136 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSyncQueue records enqueued jobs for tests
+type fakeSyncQueue struct {
+	jobs   []SyncJob
+	reject bool
+}
+
+func (q *fakeSyncQueue) Enqueue(job SyncJob) bool {
+	if q.reject {
+		return false
+	}
+	q.jobs = append(q.jobs, job)
+	return true
+}
+
+// TestHandlePush はHandlePushメソッドのテストです
+func TestHandlePush(t *testing.T) {
+	repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+	event := dto.PushEvent{
+		Provider:       "github",
+		RepositoryURLs: []string{"https://github.com/example/runbooks"},
+		Ref:            "refs/heads/main",
+		DefaultBranch:  "main",
+		CommitSHA:      "abc123",
+		ChangedPaths:   []string{"docs/deploy.md", "src/main.go", "docs/backup.md"},
+	}
+
+	// テスト：プッシュされたリポジトリの同期がキューに登録されることを確認する
+	t.Run("プッシュされたリポジトリの同期がキューに登録される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		queue := &fakeSyncQueue{}
+
+		// 登録URLは .git 付きのため、.git なしのURLでは見つからない
+		mockRepo.On("FindByURL", mock.Anything, "https://github.com/example/runbooks").Return(nil, nil)
+		mockRepo.On("FindByURL", mock.Anything, "https://github.com/example/runbooks.git").Return(repo, nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, "repo-id").Return([]string{"docs/backup.md", "docs/deploy.md", "README.md"}, nil)
+
+		uc := NewWebhookUseCase(mockRepo, queue)
+
+		result, err := uc.HandlePush(context.Background(), event)
+
+		require.NoError(t, err)
+		assert.True(t, result.Queued)
+		assert.Equal(t, "repo-id", result.RepositoryID)
+		assert.Equal(t, "abc123", result.CommitSHA)
+		assert.Equal(t, []string{"docs/backup.md", "docs/deploy.md"}, result.ChangedManagedFiles)
+		require.Len(t, queue.jobs, 1)
+		assert.Equal(t, SyncJob{RepositoryID: "repo-id", Trigger: entity.SyncTriggerWebhook, CommitSHA: "abc123", ChangedFiles: []string{"docs/backup.md", "docs/deploy.md"}}, queue.jobs[0])
+		mockRepo.AssertExpectations(t)
+	})
+
+	// テスト：デフォルトブランチ以外へのプッシュは無視されることを確認する
+	t.Run("デフォルトブランチ以外へのプッシュは無視される", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		queue := &fakeSyncQueue{}
+		mockRepo.On("FindByURL", mock.Anything, "https://github.com/example/runbooks").Return(repo, nil)
+
+		uc := NewWebhookUseCase(mockRepo, queue)
+
+		featureEvent := event
+		featureEvent.Ref = "refs/heads/feature"
+		result, err := uc.HandlePush(context.Background(), featureEvent)
+
+		require.NoError(t, err)
+		assert.False(t, result.Queued)
+		assert.Empty(t, queue.jobs)
+		mockRepo.AssertNotCalled(t, "GetManagedFiles", mock.Anything, mock.Anything)
+	})
+
+	// テスト：追跡するrefが指定されている場合はそのrefへのプッシュのみ同期されることを確認する
+	t.Run("追跡するタグへのプッシュのみ同期される", func(t *testing.T) {
+		pinned := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		pinned.SetTracking("v1.0.0", "")
+		mockRepo := new(repository.MockRepository)
+		queue := &fakeSyncQueue{}
+		mockRepo.On("FindByURL", mock.Anything, "https://github.com/example/runbooks").Return(pinned, nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, "repo-id").Return([]string{}, nil)
+
+		uc := NewWebhookUseCase(mockRepo, queue)
+
+		// デフォルトブランチへのプッシュは無視される
+		result, err := uc.HandlePush(context.Background(), event)
+		require.NoError(t, err)
+		assert.False(t, result.Queued)
+
+		tagEvent := event
+		tagEvent.Ref = "refs/tags/v1.0.0"
+		result, err = uc.HandlePush(context.Background(), tagEvent)
+		require.NoError(t, err)
+		assert.True(t, result.Queued)
+		assert.Len(t, queue.jobs, 1)
+	})
+
+	// テスト：登録されていないリポジトリはNotFoundエラーになることを確認する
+	t.Run("登録されていないリポジトリはNotFoundエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByURL", mock.Anything, mock.Anything).Return(nil, nil)
+
+		uc := NewWebhookUseCase(mockRepo, &fakeSyncQueue{})
+
+		result, err := uc.HandlePush(context.Background(), event)
+
+		assert.Nil(t, result)
+		var notFoundErr *apperror.NotFoundError
+		assert.True(t, errors.As(err, &notFoundErr))
+	})
+
+	// テスト：キューが満杯の場合はエラーになることを確認する
+	t.Run("キューが満杯の場合はエラーになる", func(t *testing.T) {
+		mockRepo := new(repository.MockRepository)
+		mockRepo.On("FindByURL", mock.Anything, "https://github.com/example/runbooks").Return(repo, nil)
+		mockRepo.On("GetManagedFiles", mock.Anything, "repo-id").Return([]string{}, nil)
+
+		uc := NewWebhookUseCase(mockRepo, &fakeSyncQueue{reject: true})
+
+		result, err := uc.HandlePush(context.Background(), event)
+
+		assert.Nil(t, result)
+		assert.Error(t, err)
+	})
+}
@@ -0,0 +1,36 @@
+package entity
+
+import "fmt"
+
+// AuthMethod identifies how OpsCore authenticates when fetching a repository.
+type AuthMethod string
+
+const (
+	// AuthMethodNone fetches the repository anonymously.
+	AuthMethodNone AuthMethod = "none"
+	// AuthMethodToken authenticates over HTTPS with the repository's access token.
+	AuthMethodToken AuthMethod = "token"
+	// AuthMethodDeployKey authenticates over SSH with the repository's deploy key.
+	AuthMethodDeployKey AuthMethod = "deploy_key"
+	// AuthMethodGitHubApp authenticates with installation tokens of a GitHub App.
+	AuthMethodGitHubApp AuthMethod = "github_app"
+)
+
+// ParseAuthMethod converts a string into an AuthMethod.
+func ParseAuthMethod(s string) (AuthMethod, error) {
+	m := AuthMethod(s)
+	if !m.IsValid() {
+		return "", fmt.Errorf("unsupported auth method: %s", s)
+	}
+	return m, nil
+}
+
+// IsValid reports whether the auth method is supported.
+func (m AuthMethod) IsValid() bool {
+	return m == AuthMethodNone || m == AuthMethodToken || m == AuthMethodDeployKey || m == AuthMethodGitHubApp
+}
+
+// String returns the string representation of the auth method.
+func (m AuthMethod) String() string {
+	return string(m)
+}
@@ -1,14 +1,42 @@
 package entity
 
+import (
+	"path"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Types of file nodes
+const (
+	FileNodeTypeFile = "file"
+	FileNodeTypeDir  = "dir"
+)
+
+// FileCommit describes the last commit that touched a file or directory.
+type FileCommit struct {
+	SHA     string
+	Author  string
+	Date    time.Time
+	Message string // Subject line of the commit message
+}
+
 // FileNode represents a file or directory within a repository.
 type fileNode struct {
-	path  string // Path relative to the repository root
-	type_ string // Type, e.g., "file" or "dir"
+	path       string      // Path relative to the repository root
+	type_      string      // Type, e.g., "file" or "dir"
+	size       int64       // Size in bytes; the total size of the contained files for a directory
+	lastCommit *FileCommit // Last commit that touched the node, nil when unknown
+	children   []FileNode  // Entries of a directory, directories first and then by name
 }
 
 type FileNode interface {
-	Path() string // Returns the file path relative to the repository root
-	Type() string // Returns the type of the file node (e.g., "file" or "dir")
+	Path() string            // Returns the file path relative to the repository root
+	Name() string            // Returns the last element of the path
+	Type() string            // Returns the type of the file node (e.g., "file" or "dir")
+	Size() int64             // Returns the size in bytes
+	LastCommit() *FileCommit // Returns the last commit that touched the node, or nil when unknown
+	Children() []FileNode    // Returns the entries of a directory
 }
 
 // NewFileNode creates a new FileNode with the given path and type.
@@ -19,6 +47,33 @@ func NewFileNode(path, type_ string) FileNode {
 	}
 }
 
+// NewFileNodeWithInfo creates a file node with its size and the last commit that touched it.
+func NewFileNodeWithInfo(path string, size int64, lastCommit *FileCommit) FileNode {
+	return &fileNode{
+		path:       path,
+		type_:      FileNodeTypeFile,
+		size:       size,
+		lastCommit: lastCommit,
+	}
+}
+
+// NewDirectoryNode creates a directory node containing the given entries.
+// Its size is the total size of the entries and its last commit is the most recent of theirs.
+func NewDirectoryNode(path string, children []FileNode) FileNode {
+	dir := &fileNode{
+		path:     path,
+		type_:    FileNodeTypeDir,
+		children: children,
+	}
+	for _, child := range children {
+		dir.size += child.Size()
+		if commit := child.LastCommit(); commit != nil && (dir.lastCommit == nil || commit.Date.After(dir.lastCommit.Date)) {
+			dir.lastCommit = commit
+		}
+	}
+	return dir
+}
+
 // ReconstructFileNode reconstructs a FileNode from persistence or external data.
 func ReconstructFileNode(path, type_ string) *fileNode {
 	return &fileNode{
@@ -27,12 +82,69 @@ func ReconstructFileNode(path, type_ string) *fileNode {
 	}
 }
 
+// BuildFileTree arranges files, given by their full paths, into a tree of directory nodes.
+// Each level lists directories first and then files, both sorted by name.
+func BuildFileTree(files []FileNode) []FileNode {
+	return buildFileTree("", files)
+}
+
+// buildFileTree builds the entries of the directory prefix (empty or ending with a slash).
+func buildFileTree(prefix string, files []FileNode) []FileNode {
+	var entries []FileNode
+	var dirNames []string
+	dirFiles := make(map[string][]FileNode)
+	for _, file := range files {
+		name, _, nested := strings.Cut(strings.TrimPrefix(file.Path(), prefix), "/")
+		if !nested {
+			entries = append(entries, file)
+			continue
+		}
+		if _, seen := dirFiles[name]; !seen {
+			dirNames = append(dirNames, name)
+		}
+		dirFiles[name] = append(dirFiles[name], file)
+	}
+
+	dirs := make([]FileNode, 0, len(dirNames)+len(entries))
+	for _, name := range dirNames {
+		dirs = append(dirs, NewDirectoryNode(prefix+name, buildFileTree(prefix+name+"/", dirFiles[name])))
+	}
+	sortByName(dirs)
+	sortByName(entries)
+	return append(dirs, entries...)
+}
+
+// sortByName sorts file nodes by name.
+func sortByName(nodes []FileNode) {
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name() < nodes[j].Name() })
+}
+
 // Path returns the file path relative to the repository root.
 func (f *fileNode) Path() string {
 	return f.path
 }
 
+// Name returns the last element of the path.
+func (f *fileNode) Name() string {
+	return path.Base(f.path)
+}
+
 // Type returns the type of the file node (e.g., "file" or "dir").
 func (f *fileNode) Type() string {
 	return f.type_
 }
+
+// Size returns the size in bytes; the total size of the contained files for a directory.
+func (f *fileNode) Size() int64 {
+	return f.size
+}
+
+// LastCommit returns the last commit that touched the node, or nil when unknown.
+func (f *fileNode) LastCommit() *FileCommit {
+	return f.lastCommit
+}
+
+// Children returns the entries of a directory.
+func (f *fileNode) Children() []FileNode {
+	return f.children
+}
@@ -2,8 +2,10 @@ package entity
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestNewFileNode(t *testing.T) {
@@ -67,3 +69,60 @@ func TestFileNodeMethods(t *testing.T) {
 		assert.Equal(t, fileType, fileNode.Type())
 	})
 }
+
+func TestNewDirectoryNode(t *testing.T) {
+	// テスト：ディレクトリのサイズと最後のコミットが子要素から集計される
+	t.Run("子要素のサイズの合計と最新のコミットを持つ", func(t *testing.T) {
+		older := &FileCommit{SHA: "aaa111", Date: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+		newer := &FileCommit{SHA: "bbb222", Date: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC)}
+
+		dir := NewDirectoryNode("docs", []FileNode{
+			NewFileNodeWithInfo("docs/a.md", 10, older),
+			NewFileNodeWithInfo("docs/b.md", 20, newer),
+		})
+
+		assert.Equal(t, FileNodeTypeDir, dir.Type())
+		assert.Equal(t, "docs", dir.Name())
+		assert.Equal(t, int64(30), dir.Size())
+		assert.Equal(t, newer, dir.LastCommit())
+		assert.Len(t, dir.Children(), 2)
+	})
+}
+
+func TestBuildFileTree(t *testing.T) {
+	// テスト：ファイルの一覧からディレクトリの階層が構築される
+	t.Run("ディレクトリを先に、名前順に並べた階層を返す", func(t *testing.T) {
+		files := []FileNode{
+			NewFileNodeWithInfo("README.md", 5, nil),
+			NewFileNodeWithInfo("docs/runbooks/restart.md", 7, nil),
+			NewFileNodeWithInfo("docs/guide.md", 3, nil),
+			NewFileNodeWithInfo("docs/runbooks/backup.md", 11, nil),
+			NewFileNodeWithInfo("LICENSE", 1, nil),
+		}
+
+		tree := BuildFileTree(files)
+
+		require.Len(t, tree, 3)
+		assert.Equal(t, "docs", tree[0].Path())
+		assert.Equal(t, FileNodeTypeDir, tree[0].Type())
+		assert.Equal(t, int64(21), tree[0].Size())
+		assert.Equal(t, "LICENSE", tree[1].Path())
+		assert.Equal(t, "README.md", tree[2].Path())
+
+		docs := tree[0].Children()
+		require.Len(t, docs, 2)
+		assert.Equal(t, "docs/runbooks", docs[0].Path())
+		assert.Equal(t, "docs/guide.md", docs[1].Path())
+
+		runbooks := docs[0].Children()
+		require.Len(t, runbooks, 2)
+		assert.Equal(t, "backup.md", runbooks[0].Name())
+		assert.Equal(t, "restart.md", runbooks[1].Name())
+		assert.Equal(t, FileNodeTypeFile, runbooks[0].Type())
+	})
+
+	// テスト：ファイルがない場合は空の階層を返す
+	t.Run("ファイルがない場合は空を返す", func(t *testing.T) {
+		assert.Empty(t, BuildFileTree(nil))
+	})
+}
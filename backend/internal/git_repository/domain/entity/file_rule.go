@@ -0,0 +1,76 @@
+package entity
+
+import (
+	"errors"
+	"path"
+	"strings"
+)
+
+// globAnyDirs is the pattern segment that matches any number of directories, including none.
+const globAnyDirs = "**"
+
+// ValidateGlob checks that a pattern is a repository-relative glob usable by MatchGlob.
+func ValidateGlob(pattern string) error {
+	if pattern == "" {
+		return errors.New("pattern cannot be empty")
+	}
+	if strings.HasPrefix(pattern, "/") || strings.Contains(pattern, "\\") {
+		return errors.New("pattern must be a relative path using / as the separator")
+	}
+	for _, segment := range strings.Split(pattern, "/") {
+		switch segment {
+		case "":
+			return errors.New("pattern cannot contain empty segments")
+		case ".", "..":
+			return errors.New("pattern cannot contain . or .. segments")
+		case globAnyDirs:
+			continue
+		}
+		if strings.Contains(segment, globAnyDirs) {
+			return errors.New("** must be a whole path segment")
+		}
+		if _, err := path.Match(segment, ""); err != nil {
+			return errors.New("pattern is malformed")
+		}
+	}
+	return nil
+}
+
+// MatchGlob reports whether a repository-relative file path matches a glob pattern.
+// Each segment is matched with path.Match, so * and ? do not cross directories;
+// a ** segment matches any number of directories, so docs/**/*.md matches docs/a.md and docs/a/b.md.
+func MatchGlob(pattern, filePath string) bool {
+	return matchSegments(strings.Split(pattern, "/"), strings.Split(filePath, "/"))
+}
+
+// matchSegments matches path segments against pattern segments.
+func matchSegments(pattern, segments []string) bool {
+	for len(pattern) > 0 {
+		if pattern[0] == globAnyDirs {
+			for i := 0; i <= len(segments); i++ {
+				if matchSegments(pattern[1:], segments[i:]) {
+					return true
+				}
+			}
+			return false
+		}
+		if len(segments) == 0 {
+			return false
+		}
+		if ok, _ := path.Match(pattern[0], segments[0]); !ok {
+			return false
+		}
+		pattern, segments = pattern[1:], segments[1:]
+	}
+	return len(segments) == 0
+}
+
+// matchAnyGlob reports whether the file path matches at least one of the patterns.
+func matchAnyGlob(patterns []string, filePath string) bool {
+	for _, pattern := range patterns {
+		if MatchGlob(pattern, filePath) {
+			return true
+		}
+	}
+	return false
+}
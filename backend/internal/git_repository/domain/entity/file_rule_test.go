@@ -0,0 +1,49 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateGlob(t *testing.T) {
+	// テスト：リポジトリ相対のパターンを受け付ける
+	t.Run("有効なパターンを受け付ける", func(t *testing.T) {
+		for _, pattern := range []string{"README.md", "docs/*.md", "docs/**/*.md", "**/runbook-?.md", "docs/[a-c]*/**"} {
+			assert.NoError(t, ValidateGlob(pattern), pattern)
+		}
+	})
+
+	// テスト：不正なパターンを拒否する
+	t.Run("不正なパターンを拒否する", func(t *testing.T) {
+		for _, pattern := range []string{"", "/docs/*.md", "docs\\*.md", "docs//*.md", "../*.md", "docs/./*.md", "docs/**.md", "docs/[a-.md"} {
+			assert.Error(t, ValidateGlob(pattern), pattern)
+		}
+	})
+}
+
+func TestMatchGlob(t *testing.T) {
+	tests := []struct {
+		pattern  string
+		filePath string
+		want     bool
+	}{
+		{"docs/*.md", "docs/guide.md", true},
+		{"docs/*.md", "docs/runbooks/restart.md", false},
+		{"docs/**/*.md", "docs/guide.md", true},
+		{"docs/**/*.md", "docs/runbooks/db/restart.md", true},
+		{"docs/**/*.md", "docs/image.png", false},
+		{"docs/**/*.md", "other/guide.md", false},
+		{"**/*.md", "README.md", true},
+		{"docs/**", "docs/runbooks/restart.md", true},
+		{"docs/**", "docs", true},
+		{"README.md", "docs/README.md", false},
+	}
+
+	// テスト：**はディレクトリをまたいで一致し、*と?はまたがない
+	for _, tt := range tests {
+		t.Run(tt.pattern+"と"+tt.filePath, func(t *testing.T) {
+			assert.Equal(t, tt.want, MatchGlob(tt.pattern, tt.filePath))
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package entity
+
+import "time"
+
+// githubApp is a GitHub App that OpsCore authenticates as to fetch the repositories it is installed on.
+type githubApp struct {
+	id         string    // Unique identifier (e.g., UUID)
+	name       string    // Display name
+	appID      int64     // App ID assigned by GitHub
+	privateKey string    // PEM encoded private key used to sign the App's JWTs
+	createdAt  time.Time // Timestamp of registration
+	updatedAt  time.Time // Timestamp of last update
+}
+
+// GitHubApp interface defines the methods for a GitHub App.
+type GitHubApp interface {
+	ID() string
+	Name() string
+	AppID() int64
+	PrivateKey() string
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+}
+
+// GitHubAppInstallation identifies the installation of a GitHub App a repository is fetched with.
+type GitHubAppInstallation struct {
+	AppID          string // ID of the registered GitHub App, not the App ID assigned by GitHub
+	InstallationID int64  // Installation ID assigned by GitHub
+}
+
+// NewGitHubApp creates a new GitHubApp instance.
+func NewGitHubApp(id, name string, appID int64, privateKey string) GitHubApp {
+	now := time.Now()
+	return &githubApp{
+		id:         id,
+		name:       name,
+		appID:      appID,
+		privateKey: privateKey,
+		createdAt:  now,
+		updatedAt:  now,
+	}
+}
+
+// ReconstructGitHubApp reconstructs a GitHubApp from persistence data.
+func ReconstructGitHubApp(id, name string, appID int64, privateKey string, createdAt, updatedAt time.Time) GitHubApp {
+	return &githubApp{
+		id:         id,
+		name:       name,
+		appID:      appID,
+		privateKey: privateKey,
+		createdAt:  createdAt,
+		updatedAt:  updatedAt,
+	}
+}
+
+// ID returns the GitHub App's unique identifier.
+func (a *githubApp) ID() string {
+	return a.id
+}
+
+// Name returns the GitHub App's display name.
+func (a *githubApp) Name() string {
+	return a.name
+}
+
+// AppID returns the App ID assigned by GitHub.
+func (a *githubApp) AppID() int64 {
+	return a.appID
+}
+
+// PrivateKey returns the PEM encoded private key of the GitHub App.
+func (a *githubApp) PrivateKey() string {
+	return a.privateKey
+}
+
+// CreatedAt returns the registration timestamp.
+func (a *githubApp) CreatedAt() time.Time {
+	return a.createdAt
+}
+
+// UpdatedAt returns the last update timestamp.
+func (a *githubApp) UpdatedAt() time.Time {
+	return a.updatedAt
+}
@@ -0,0 +1,42 @@
+package entity
+
+import "fmt"
+
+// Provider identifies the Git hosting service a repository is fetched from.
+type Provider string
+
+const (
+	// ProviderGitHub is a repository hosted on github.com.
+	ProviderGitHub Provider = "github"
+	// ProviderGitLab is a repository hosted on gitlab.com or a self-hosted GitLab instance.
+	ProviderGitLab Provider = "gitlab"
+	// ProviderGitea is a repository hosted on a Gitea or Forgejo instance.
+	ProviderGitea Provider = "gitea"
+	// ProviderGeneric is a repository on any other Git server reachable over HTTPS or SSH.
+	ProviderGeneric Provider = "generic"
+	// ProviderLocal is a directory or tar.gz/zip archive on the server, for sites without Git hosting.
+	ProviderLocal Provider = "local"
+)
+
+// ParseProvider converts a string into a Provider.
+func ParseProvider(s string) (Provider, error) {
+	p := Provider(s)
+	if !p.IsValid() {
+		return "", fmt.Errorf("unsupported provider: %s", s)
+	}
+	return p, nil
+}
+
+// IsValid reports whether the provider is supported.
+func (p Provider) IsValid() bool {
+	switch p {
+	case ProviderGitHub, ProviderGitLab, ProviderGitea, ProviderGeneric, ProviderLocal:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the provider.
+func (p Provider) String() string {
+	return string(p)
+}
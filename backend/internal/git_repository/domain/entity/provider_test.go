@@ -0,0 +1,40 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseProvider(t *testing.T) {
+	// テスト：対応しているプロバイダーをパースできることを確認する
+	t.Run("対応しているプロバイダーをパースできる", func(t *testing.T) {
+		provider, err := ParseProvider("github")
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderGitHub, provider)
+
+		provider, err = ParseProvider("gitlab")
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderGitLab, provider)
+
+		provider, err = ParseProvider("gitea")
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderGitea, provider)
+
+		provider, err = ParseProvider("generic")
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderGeneric, provider)
+
+		provider, err = ParseProvider("local")
+		assert.NoError(t, err)
+		assert.Equal(t, ProviderLocal, provider)
+	})
+
+	// テスト：未対応のプロバイダーはエラーになることを確認する
+	t.Run("未対応のプロバイダーはエラーになる", func(t *testing.T) {
+		for _, s := range []string{"", "bitbucket", "GitHub"} {
+			_, err := ParseProvider(s)
+			assert.Error(t, err, s)
+		}
+	})
+}
@@ -1,16 +1,28 @@
 package entity
 
-import "time"
+import (
+	"strings"
+	"time"
+)
 
 // Repository represents a registered code repository.
 // Fields are based on ADR-0005.
 type repository struct {
-	id          string    // Unique identifier (e.g., UUID)
-	name        string    // Repository name (e.g., derived from URL)
-	url         string    // Git repository URL
-	accessToken string    // Access token for private repositories (e.g., PAT, Deploy Key)
-	createdAt   time.Time // Timestamp of registration
-	updatedAt   time.Time // Timestamp of last update
+	id           string                // Unique identifier (e.g., UUID)
+	name         string                // Repository name (e.g., derived from URL)
+	url          string                // Git repository URL
+	provider     Provider              // Git hosting service the repository is fetched from
+	accessToken  string                // Access token for private repositories (e.g., PAT)
+	authMethod   AuthMethod            // How the repository is authenticated when fetched
+	deployKey    string                // SSH private key used when authMethod is AuthMethodDeployKey
+	installation GitHubAppInstallation // GitHub App installation used when authMethod is AuthMethodGitHubApp
+	ref          string                // Branch or tag that is synced; empty tracks the default branch
+	rootPath     string                // Sub-directory the managed files are taken from; empty is the repository root
+	includes     []string              // Glob patterns of files that become managed automatically on each sync
+	excludes     []string              // Glob patterns of files that never become managed automatically
+	tokenHealth  TokenHealth           // Outcome of the last verification of the access token
+	createdAt    time.Time             // Timestamp of registration
+	updatedAt    time.Time             // Timestamp of last update
 }
 
 // Repository interface defines the methods for a repository.
@@ -18,33 +30,64 @@ type Repository interface {
 	ID() string
 	Name() string
 	URL() string
+	Provider() Provider
 	AccessToken() string
+	AuthMethod() AuthMethod
+	DeployKey() string
+	Installation() GitHubAppInstallation
+	Ref() string
+	RootPath() string
+	ContainsPath(filePath string) bool
+	IncludeGlobs() []string
+	ExcludeGlobs() []string
+	MatchesFileRules(filePath string) bool
+	TokenHealth() TokenHealth
 	CreatedAt() time.Time
 	UpdatedAt() time.Time
 	SetUpdatedAt()
+	SetName(name string)
+	SetURL(url string)
 	SetAccessToken(token string)
+	SetDeployKey(privateKey string)
+	SetInstallation(installation GitHubAppInstallation)
+	SetTracking(ref, rootPath string)
+	SetFileRules(includeGlobs, excludeGlobs []string)
+	SetTokenHealth(health TokenHealth)
 }
 
 // NewRepository creates a new Repository instance.
-func NewRepository(id, name, url, accessToken string) Repository {
+func NewRepository(id, name, url string, provider Provider, accessToken string) Repository {
 	now := time.Now()
 	return &repository{
 		id:          id,
 		name:        name,
 		url:         url,
+		provider:    provider,
 		accessToken: accessToken,
+		authMethod:  tokenAuthMethod(accessToken),
+		tokenHealth: uncheckedTokenHealth(),
 		createdAt:   now,
 		updatedAt:   now,
 	}
 }
 
 // ReconstructRepository reconstructs a Repository from persistence data.
-func ReconstructRepository(id, name, url, accessToken string, createdAt, updatedAt time.Time) Repository {
+// The token health starts unchecked; persistence restores it with SetTokenHealth, and the GitHub App
+// installation with SetInstallation.
+func ReconstructRepository(id, name, url string, provider Provider, accessToken string, authMethod AuthMethod, deployKey string, ref, rootPath string, includeGlobs, excludeGlobs []string, createdAt, updatedAt time.Time) Repository {
 	return &repository{
 		id:          id,
 		name:        name,
 		url:         url,
+		provider:    provider,
 		accessToken: accessToken,
+		authMethod:  authMethod,
+		deployKey:   deployKey,
+		ref:         ref,
+		rootPath:    rootPath,
+		includes:    includeGlobs,
+		excludes:    excludeGlobs,
+		tokenHealth: uncheckedTokenHealth(),
 		createdAt:   createdAt,
 		updatedAt:   updatedAt,
 	}
@@ -65,11 +108,51 @@ func (r *repository) URL() string {
 	return r.url
 }
 
+// Provider returns the Git hosting service of the repository.
+func (r *repository) Provider() Provider {
+	return r.provider
+}
+
 // AccessToken returns the repository access token.
 func (r *repository) AccessToken() string {
 	return r.accessToken
 }
 
+// AuthMethod returns how the repository is authenticated when fetched.
+func (r *repository) AuthMethod() AuthMethod {
+	return r.authMethod
+}
+
+// DeployKey returns the SSH private key used to fetch the repository.
+func (r *repository) DeployKey() string {
+	return r.deployKey
+}
+
+// Installation returns the GitHub App installation the repository is fetched with, if any.
+func (r *repository) Installation() GitHubAppInstallation {
+	return r.installation
+}
+
+// Ref returns the branch or tag that is synced. An empty ref tracks the default branch.
+func (r *repository) Ref() string {
+	return r.ref
+}
+
+// RootPath returns the sub-directory the managed files are taken from. An empty path is the repository root.
+func (r *repository) RootPath() string {
+	return r.rootPath
+}
+
+// IncludeGlobs returns the glob patterns of files that become managed automatically on each sync.
+func (r *repository) IncludeGlobs() []string {
+	return r.includes
+}
+
+// ExcludeGlobs returns the glob patterns of files that never become managed automatically.
+func (r *repository) ExcludeGlobs() []string {
+	return r.excludes
+}
+
 // CreatedAt returns the timestamp when the repository was registered.
 func (r *repository) CreatedAt() time.Time {
 	return r.createdAt
@@ -80,13 +163,88 @@ func (r *repository) UpdatedAt() time.Time {
 	return r.updatedAt
 }
 
+// TokenHealth returns the outcome of the last verification of the access token.
+func (r *repository) TokenHealth() TokenHealth {
+	return r.tokenHealth
+}
+
 // SetUpdatedAt updates the updatedAt timestamp to the current time.
 func (r *repository) SetUpdatedAt() {
 	r.updatedAt = time.Now()
 }
 
+// SetName renames the repository.
+func (r *repository) SetName(name string) {
+	r.name = name
+	r.SetUpdatedAt()
+}
+
+// SetURL points the repository at another URL of the same provider, e.g. after the repository moved.
+func (r *repository) SetURL(url string) {
+	r.url = url
+	r.SetUpdatedAt()
+}
+
 // SetAccessToken updates the access token for the repository.
+// Repositories authenticated with a deploy key or a GitHub App keep using it; otherwise the auth method follows the token.
 func (r *repository) SetAccessToken(token string) {
 	r.accessToken = token
+	if r.authMethod != AuthMethodDeployKey && r.authMethod != AuthMethodGitHubApp {
+		r.authMethod = tokenAuthMethod(token)
+	}
+	r.tokenHealth = uncheckedTokenHealth()
+	r.SetUpdatedAt()
+}
+
+// SetTokenHealth records the outcome of verifying the access token. It is not an edit of the repository,
+// so the updatedAt timestamp is kept.
+func (r *repository) SetTokenHealth(health TokenHealth) {
+	r.tokenHealth = health
+}
+
+// SetDeployKey sets the SSH deploy key and switches the repository to deploy key authentication.
+func (r *repository) SetDeployKey(privateKey string) {
+	r.deployKey = privateKey
+	r.authMethod = AuthMethodDeployKey
+	r.SetUpdatedAt()
+}
+
+// SetInstallation switches the repository to authenticate with the installation tokens of a GitHub App.
+// The installation is chosen when the repository is registered, so the updatedAt timestamp is kept.
+func (r *repository) SetInstallation(installation GitHubAppInstallation) {
+	r.installation = installation
+	r.authMethod = AuthMethodGitHubApp
+}
+
+// SetTracking sets the branch or tag to sync and the sub-directory to take files from.
+func (r *repository) SetTracking(ref, rootPath string) {
+	r.ref = ref
+	r.rootPath = rootPath
+	r.SetUpdatedAt()
+}
+
+// SetFileRules sets the include and exclude glob patterns that select managed files on each sync.
+func (r *repository) SetFileRules(includeGlobs, excludeGlobs []string) {
+	r.includes = includeGlobs
+	r.excludes = excludeGlobs
 	r.SetUpdatedAt()
 }
+
+// MatchesFileRules reports whether a repository-relative file path matches an include pattern and no exclude pattern.
+// Without include patterns no file is selected by the rules.
+func (r *repository) MatchesFileRules(filePath string) bool {
+	return matchAnyGlob(r.includes, filePath) && !matchAnyGlob(r.excludes, filePath)
+}
+
+// ContainsPath reports whether a repository-relative file path is inside the root path.
+func (r *repository) ContainsPath(filePath string) bool {
+	return r.rootPath == "" || strings.HasPrefix(filePath, r.rootPath+"/")
+}
+
+// tokenAuthMethod returns the auth method implied by an access token.
+func tokenAuthMethod(token string) AuthMethod {
+	if token == "" {
+		return AuthMethodNone
+	}
+	return AuthMethodToken
+}
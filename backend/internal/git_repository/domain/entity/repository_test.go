@@ -16,7 +16,7 @@ func TestNewRepository(t *testing.T) {
 		accessToken := "ghp_abcdefg"
 
 		beforeCreate := time.Now()
-		repo := NewRepository(id, name, url, accessToken)
+		repo := NewRepository(id, name, url, ProviderGitHub, accessToken)
 		afterCreate := time.Now()
 
 		assert.NotNil(t, repo)
@@ -41,7 +41,7 @@ func TestNewRepository(t *testing.T) {
 		url := "https://github.com/example/test-repo"
 		accessToken := "" // 空のトークン
 
-		repo := NewRepository(id, name, url, accessToken)
+		repo := NewRepository(id, name, url, ProviderGitHub, accessToken)
 
 		assert.NotNil(t, repo)
 		assert.Equal(t, accessToken, repo.AccessToken())
@@ -58,7 +58,7 @@ func TestReconstructRepository(t *testing.T) {
 		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
 
-		repo := ReconstructRepository(id, name, url, accessToken, createdAt, updatedAt)
+		repo := ReconstructRepository(id, name, url, ProviderGitHub, accessToken, AuthMethodToken, "", "", "", nil, nil, createdAt, updatedAt)
 
 		assert.NotNil(t, repo)
 		assert.Equal(t, id, repo.ID())
@@ -80,7 +80,7 @@ func TestRepositoryGetters(t *testing.T) {
 		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
 
-		repo := ReconstructRepository(id, name, url, accessToken, createdAt, updatedAt)
+		repo := ReconstructRepository(id, name, url, ProviderGitHub, accessToken, AuthMethodToken, "", "", "", nil, nil, createdAt, updatedAt)
 
 		assert.Equal(t, id, repo.ID())
 		assert.Equal(t, name, repo.Name())
@@ -101,7 +101,7 @@ func TestSetUpdatedAt(t *testing.T) {
 		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
 
-		repo := ReconstructRepository(id, name, url, accessToken, createdAt, updatedAt)
+		repo := ReconstructRepository(id, name, url, ProviderGitHub, accessToken, AuthMethodToken, "", "", "", nil, nil, createdAt, updatedAt)
 
 		// 元の更新時間を確認
 		assert.Equal(t, updatedAt, repo.UpdatedAt())
@@ -131,7 +131,7 @@ func TestSetAccessToken(t *testing.T) {
 		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
 		updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
 
-		repo := ReconstructRepository(id, name, url, accessToken, createdAt, updatedAt)
+		repo := ReconstructRepository(id, name, url, ProviderGitHub, accessToken, AuthMethodToken, "", "", "", nil, nil, createdAt, updatedAt)
 
 		// 元のトークンと更新時間を確認
 		assert.Equal(t, accessToken, repo.AccessToken())
@@ -160,7 +160,7 @@ func TestSetAccessToken(t *testing.T) {
 		url := "https://github.com/example/test-repo"
 		accessToken := "ghp_abcdefg"
 
-		repo := NewRepository(id, name, url, accessToken)
+		repo := NewRepository(id, name, url, ProviderGitHub, accessToken)
 		assert.Equal(t, accessToken, repo.AccessToken())
 
 		// 空トークンを設定
@@ -170,3 +170,121 @@ func TestSetAccessToken(t *testing.T) {
 		assert.Equal(t, "", repo.AccessToken())
 	})
 }
+
+func TestRepositoryAuthMethod(t *testing.T) {
+	// テスト：アクセストークンの有無から認証方式が決まることを確認する
+	t.Run("アクセストークンの有無から認証方式が決まる", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "https://gitea.example.com/ops/test-repo", ProviderGitea, "")
+		assert.Equal(t, AuthMethodNone, repo.AuthMethod())
+
+		repo.SetAccessToken("gitea-token")
+		assert.Equal(t, AuthMethodToken, repo.AuthMethod())
+
+		repo.SetAccessToken("")
+		assert.Equal(t, AuthMethodNone, repo.AuthMethod())
+	})
+
+	// テスト：デプロイキーを設定すると認証方式がdeploy_keyになることを確認する
+	t.Run("デプロイキーを設定すると認証方式がdeploy_keyになる", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "git@gitea.example.com:ops/test-repo.git", ProviderGitea, "")
+
+		repo.SetDeployKey("private-key")
+		assert.Equal(t, AuthMethodDeployKey, repo.AuthMethod())
+		assert.Equal(t, "private-key", repo.DeployKey())
+
+		// アクセストークンを更新してもデプロイキー認証のまま
+		repo.SetAccessToken("api-token")
+		assert.Equal(t, AuthMethodDeployKey, repo.AuthMethod())
+	})
+
+	// テスト：GitHub Appのインストールを設定すると認証方式がgithub_appになることを確認する
+	t.Run("GitHub Appのインストールを設定すると認証方式がgithub_appになる", func(t *testing.T) {
+		updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+		repo := ReconstructRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "", AuthMethodNone, "", "", "", nil, nil, updatedAt, updatedAt)
+
+		installation := GitHubAppInstallation{AppID: "app-id", InstallationID: 42}
+		repo.SetInstallation(installation)
+		assert.Equal(t, AuthMethodGitHubApp, repo.AuthMethod())
+		assert.Equal(t, installation, repo.Installation())
+		assert.Equal(t, updatedAt, repo.UpdatedAt())
+
+		// アクセストークンを更新してもGitHub App認証のまま
+		repo.SetAccessToken("api-token")
+		assert.Equal(t, AuthMethodGitHubApp, repo.AuthMethod())
+	})
+}
+
+func TestSetTracking(t *testing.T) {
+	t.Run("追跡するrefとルートパスが設定され、更新時間も変更される", func(t *testing.T) {
+		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		repo := ReconstructRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "ghp_abcdefg", AuthMethodToken, "", "", "", nil, nil, createdAt, createdAt)
+
+		assert.Empty(t, repo.Ref())
+		assert.Empty(t, repo.RootPath())
+
+		repo.SetTracking("v1.2.0", "docs")
+
+		assert.Equal(t, "v1.2.0", repo.Ref())
+		assert.Equal(t, "docs", repo.RootPath())
+		assert.True(t, repo.UpdatedAt().After(createdAt))
+	})
+}
+
+func TestSetNameAndURL(t *testing.T) {
+	t.Run("名前とURLが変更され、更新時間も変更される", func(t *testing.T) {
+		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		repo := ReconstructRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "ghp_abcdefg", AuthMethodToken, "", "", "", nil, nil, createdAt, createdAt)
+
+		repo.SetName("renamed-repo")
+		repo.SetURL("https://github.com/example/renamed-repo")
+
+		assert.Equal(t, "renamed-repo", repo.Name())
+		assert.Equal(t, "https://github.com/example/renamed-repo", repo.URL())
+		assert.Equal(t, ProviderGitHub, repo.Provider())
+		assert.True(t, repo.UpdatedAt().After(createdAt))
+	})
+}
+
+func TestContainsPath(t *testing.T) {
+	t.Run("ルートパスが未設定の場合はすべてのファイルを含む", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "")
+
+		assert.True(t, repo.ContainsPath("README.md"))
+		assert.True(t, repo.ContainsPath("docs/guide.md"))
+	})
+
+	t.Run("ルートパス配下のファイルのみを含む", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "")
+		repo.SetTracking("", "docs")
+
+		assert.True(t, repo.ContainsPath("docs/guide.md"))
+		assert.True(t, repo.ContainsPath("docs/runbooks/restart.md"))
+		assert.False(t, repo.ContainsPath("README.md"))
+		assert.False(t, repo.ContainsPath("docs-old/guide.md"))
+		assert.False(t, repo.ContainsPath("docs"))
+	})
+}
+
+func TestMatchesFileRules(t *testing.T) {
+	t.Run("ルールが未設定の場合はどのファイルにも一致しない", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "")
+
+		assert.False(t, repo.MatchesFileRules("docs/guide.md"))
+	})
+
+	t.Run("包含パターンに一致し除外パターンに一致しないファイルのみ一致する", func(t *testing.T) {
+		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		repo := ReconstructRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "", AuthMethodNone, "", "", "", nil, nil, createdAt, createdAt)
+
+		repo.SetFileRules([]string{"docs/**/*.md"}, []string{"docs/drafts/**"})
+
+		assert.Equal(t, []string{"docs/**/*.md"}, repo.IncludeGlobs())
+		assert.Equal(t, []string{"docs/drafts/**"}, repo.ExcludeGlobs())
+		assert.True(t, repo.UpdatedAt().After(createdAt))
+		assert.True(t, repo.MatchesFileRules("docs/guide.md"))
+		assert.True(t, repo.MatchesFileRules("docs/runbooks/restart.md"))
+		assert.False(t, repo.MatchesFileRules("docs/drafts/new.md"))
+		assert.False(t, repo.MatchesFileRules("docs/image.png"))
+		assert.False(t, repo.MatchesFileRules("README.md"))
+	})
+}
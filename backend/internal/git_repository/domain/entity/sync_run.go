@@ -0,0 +1,153 @@
+package entity
+
+import "time"
+
+// SyncTrigger identifies what started a repository sync.
+type SyncTrigger string
+
+const (
+	// SyncTriggerWebhook is a sync started by a push webhook.
+	SyncTriggerWebhook SyncTrigger = "webhook"
+	// SyncTriggerSchedule is a sync started by the periodic scheduler.
+	SyncTriggerSchedule SyncTrigger = "schedule"
+	// SyncTriggerManual is a sync requested by an operator.
+	SyncTriggerManual SyncTrigger = "manual"
+)
+
+// SyncStatus is the state of a repository sync.
+type SyncStatus string
+
+const (
+	// SyncStatusRunning means the sync has started and not finished yet.
+	SyncStatusRunning SyncStatus = "running"
+	// SyncStatusSucceeded means the local copy was refreshed.
+	SyncStatusSucceeded SyncStatus = "succeeded"
+	// SyncStatusFailed means the sync stopped with an error.
+	SyncStatusFailed SyncStatus = "failed"
+)
+
+// syncRun records a single attempt to refresh a repository's local copy.
+type syncRun struct {
+	id           string
+	repositoryID string
+	trigger      SyncTrigger
+	status       SyncStatus
+	startedAt    time.Time
+	finishedAt   *time.Time // nil while the sync is running
+	commitSHA    string     // Commit the repository was synced to, if known
+	filesChanged []string   // Paths added, modified or removed by the sync
+	errorMessage string     // Set when the sync failed
+}
+
+// SyncRun interface defines the methods for a sync run.
+type SyncRun interface {
+	ID() string
+	RepositoryID() string
+	Trigger() SyncTrigger
+	Status() SyncStatus
+	StartedAt() time.Time
+	FinishedAt() *time.Time
+	CommitSHA() string
+	FilesChanged() []string
+	ErrorMessage() string
+	Succeed(commitSHA string, filesChanged []string)
+	Fail(errorMessage string)
+}
+
+// NewSyncRun creates a running SyncRun started now.
+func NewSyncRun(id, repositoryID string, trigger SyncTrigger) SyncRun {
+	return &syncRun{
+		id:           id,
+		repositoryID: repositoryID,
+		trigger:      trigger,
+		status:       SyncStatusRunning,
+		startedAt:    time.Now(),
+		filesChanged: []string{},
+	}
+}
+
+// ReconstructSyncRun reconstructs a SyncRun from persistence data.
+func ReconstructSyncRun(id, repositoryID string, trigger SyncTrigger, status SyncStatus, startedAt time.Time, finishedAt *time.Time, commitSHA string, filesChanged []string, errorMessage string) SyncRun {
+	if filesChanged == nil {
+		filesChanged = []string{}
+	}
+	return &syncRun{
+		id:           id,
+		repositoryID: repositoryID,
+		trigger:      trigger,
+		status:       status,
+		startedAt:    startedAt,
+		finishedAt:   finishedAt,
+		commitSHA:    commitSHA,
+		filesChanged: filesChanged,
+		errorMessage: errorMessage,
+	}
+}
+
+// ID returns the sync run's unique identifier.
+func (s *syncRun) ID() string {
+	return s.id
+}
+
+// RepositoryID returns the ID of the synced repository.
+func (s *syncRun) RepositoryID() string {
+	return s.repositoryID
+}
+
+// Trigger returns what started the sync.
+func (s *syncRun) Trigger() SyncTrigger {
+	return s.trigger
+}
+
+// Status returns the state of the sync.
+func (s *syncRun) Status() SyncStatus {
+	return s.status
+}
+
+// StartedAt returns when the sync started.
+func (s *syncRun) StartedAt() time.Time {
+	return s.startedAt
+}
+
+// FinishedAt returns when the sync finished, or nil while it is running.
+func (s *syncRun) FinishedAt() *time.Time {
+	return s.finishedAt
+}
+
+// CommitSHA returns the commit the repository was synced to.
+func (s *syncRun) CommitSHA() string {
+	return s.commitSHA
+}
+
+// FilesChanged returns the paths changed by the sync.
+func (s *syncRun) FilesChanged() []string {
+	return s.filesChanged
+}
+
+// ErrorMessage returns why the sync failed.
+func (s *syncRun) ErrorMessage() string {
+	return s.errorMessage
+}
+
+// Succeed marks the sync as finished successfully.
+func (s *syncRun) Succeed(commitSHA string, filesChanged []string) {
+	if filesChanged == nil {
+		filesChanged = []string{}
+	}
+	s.finish(SyncStatusSucceeded)
+	s.commitSHA = commitSHA
+	s.filesChanged = filesChanged
+}
+
+// Fail marks the sync as finished with an error.
+func (s *syncRun) Fail(errorMessage string) {
+	s.finish(SyncStatusFailed)
+	s.errorMessage = errorMessage
+}
+
+// finish records the final status and finish time.
+func (s *syncRun) finish(status SyncStatus) {
+	now := time.Now()
+	s.status = status
+	s.finishedAt = &now
+}
@@ -0,0 +1,41 @@
+package entity
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyncRun(t *testing.T) {
+	// テスト：新しい同期は実行中の状態で開始されることを確認する
+	t.Run("新しい同期は実行中の状態で開始される", func(t *testing.T) {
+		run := NewSyncRun("run-id", "repo-id", SyncTriggerSchedule)
+
+		assert.Equal(t, SyncStatusRunning, run.Status())
+		assert.Equal(t, SyncTriggerSchedule, run.Trigger())
+		assert.False(t, run.StartedAt().IsZero())
+		assert.Nil(t, run.FinishedAt())
+		assert.Empty(t, run.FilesChanged())
+	})
+
+	// テスト：成功した同期にはコミットと変更ファイルが記録されることを確認する
+	t.Run("成功した同期にはコミットと変更ファイルが記録される", func(t *testing.T) {
+		run := NewSyncRun("run-id", "repo-id", SyncTriggerManual)
+		run.Succeed("abc123", []string{"docs/deploy.md"})
+
+		assert.Equal(t, SyncStatusSucceeded, run.Status())
+		assert.Equal(t, "abc123", run.CommitSHA())
+		assert.Equal(t, []string{"docs/deploy.md"}, run.FilesChanged())
+		assert.NotNil(t, run.FinishedAt())
+	})
+
+	// テスト：失敗した同期にはエラーが記録されることを確認する
+	t.Run("失敗した同期にはエラーが記録される", func(t *testing.T) {
+		run := NewSyncRun("run-id", "repo-id", SyncTriggerWebhook)
+		run.Fail("authentication failed")
+
+		assert.Equal(t, SyncStatusFailed, run.Status())
+		assert.Equal(t, "authentication failed", run.ErrorMessage())
+		assert.NotNil(t, run.FinishedAt())
+	})
+}
@@ -0,0 +1,57 @@
+package entity
+
+import "time"
+
+// TokenStatus is the outcome of the last verification of a repository's access token.
+type TokenStatus string
+
+const (
+	// TokenStatusUnchecked means the token has not been verified yet, or the repository has no token.
+	TokenStatusUnchecked TokenStatus = "unchecked"
+	// TokenStatusValid means the provider accepted the token and it does not expire soon.
+	TokenStatusValid TokenStatus = "valid"
+	// TokenStatusExpiringSoon means the provider accepted the token but it expires within TokenExpiryWarning.
+	TokenStatusExpiringSoon TokenStatus = "expiring_soon"
+	// TokenStatusInvalid means the token was rejected, lacks a needed scope, cannot read the repository or has expired.
+	TokenStatusInvalid TokenStatus = "invalid"
+)
+
+// TokenExpiryWarning is how long before its expiry a token is reported as expiring soon.
+const TokenExpiryWarning = 14 * 24 * time.Hour
+
+// String returns the string representation of the token status.
+func (s TokenStatus) String() string {
+	return string(s)
+}
+
+// TokenHealth records the last verification of a repository's access token.
+type TokenHealth struct {
+	Status    TokenStatus
+	ExpiresAt *time.Time // When the token expires, if the provider reports it
+	CheckedAt *time.Time // When the token was last verified; nil if it never was
+	Message   string     // Why the token is invalid
+}
+
+// NewTokenHealth classifies the result of verifying a token at checkedAt.
+// reason explains why the provider rejected the token and is ignored when valid is true.
+func NewTokenHealth(valid bool, expiresAt *time.Time, reason string, checkedAt time.Time) TokenHealth {
+	health := TokenHealth{ExpiresAt: expiresAt, CheckedAt: &checkedAt}
+	switch {
+	case !valid:
+		health.Status = TokenStatusInvalid
+		health.Message = reason
+	case expiresAt != nil && !expiresAt.After(checkedAt):
+		health.Status = TokenStatusInvalid
+		health.Message = "the token has expired"
+	case expiresAt != nil && expiresAt.Sub(checkedAt) <= TokenExpiryWarning:
+		health.Status = TokenStatusExpiringSoon
+	default:
+		health.Status = TokenStatusValid
+	}
+	return health
+}
+
+// uncheckedTokenHealth is the health of a token that has not been verified.
+func uncheckedTokenHealth() TokenHealth {
+	return TokenHealth{Status: TokenStatusUnchecked}
+}
@@ -0,0 +1,62 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTokenHealth(t *testing.T) {
+	checkedAt := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) *time.Time {
+		ts := checkedAt.Add(d)
+		return &ts
+	}
+
+	tests := []struct {
+		name      string
+		valid     bool
+		expiresAt *time.Time
+		reason    string
+		want      TokenStatus
+		message   string
+	}{
+		{name: "有効期限のないトークンは有効", valid: true, want: TokenStatusValid},
+		{name: "有効期限まで余裕があるトークンは有効", valid: true, expiresAt: at(30 * 24 * time.Hour), want: TokenStatusValid},
+		{name: "有効期限が近いトークンは期限切れ間近", valid: true, expiresAt: at(3 * 24 * time.Hour), want: TokenStatusExpiringSoon},
+		{name: "有効期限を過ぎたトークンは無効", valid: true, expiresAt: at(-time.Hour), want: TokenStatusInvalid, message: "the token has expired"},
+		{name: "拒否されたトークンは理由とともに無効", valid: false, reason: "the token was rejected", want: TokenStatusInvalid, message: "the token was rejected"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := NewTokenHealth(tt.valid, tt.expiresAt, tt.reason, checkedAt)
+
+			assert.Equal(t, tt.want, health.Status)
+			assert.Equal(t, tt.message, health.Message)
+			assert.Equal(t, tt.expiresAt, health.ExpiresAt)
+			assert.Equal(t, checkedAt, *health.CheckedAt)
+		})
+	}
+}
+
+func TestRepositoryTokenHealth(t *testing.T) {
+	t.Run("新しいリポジトリのトークンは未確認", func(t *testing.T) {
+		repo := NewRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "ghp_abcdefg")
+
+		assert.Equal(t, TokenStatusUnchecked, repo.TokenHealth().Status)
+	})
+
+	t.Run("確認結果を記録しても更新時間は変わらず、トークンを変更すると未確認に戻る", func(t *testing.T) {
+		createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+		repo := ReconstructRepository("12345", "test-repo", "https://github.com/example/test-repo", ProviderGitHub, "ghp_abcdefg", AuthMethodToken, "", "", "", nil, nil, createdAt, createdAt)
+
+		repo.SetTokenHealth(NewTokenHealth(false, nil, "the token was rejected", time.Now()))
+		assert.Equal(t, TokenStatusInvalid, repo.TokenHealth().Status)
+		assert.Equal(t, createdAt, repo.UpdatedAt())
+
+		repo.SetAccessToken("ghp_new")
+		assert.Equal(t, TokenStatusUnchecked, repo.TokenHealth().Status)
+		assert.Nil(t, repo.TokenHealth().CheckedAt)
+	})
+}
@@ -0,0 +1,18 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// GitHubAppRepository defines the interface for data persistence operations related to GitHub Apps.
+type GitHubAppRepository interface {
+	// Save persists a new GitHub App or updates an existing one.
+	Save(ctx context.Context, app entity.GitHubApp) error
+	// FindByID retrieves a GitHub App by its ID. Returns nil if not found.
+	FindByID(ctx context.Context, id string) (entity.GitHubApp, error)
+	// FindByAppID retrieves a GitHub App by the App ID assigned by GitHub. Returns nil if not found.
+	FindByAppID(ctx context.Context, appID int64) (entity.GitHubApp, error)
+	// FindAll retrieves all GitHub Apps.
+	FindAll(ctx context.Context) ([]entity.GitHubApp, error)
+}
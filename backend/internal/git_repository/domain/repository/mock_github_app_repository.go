@@ -0,0 +1,46 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockGitHubAppRepository is a mock implementation of GitHubAppRepository interface for testing
+type MockGitHubAppRepository struct {
+	mock.Mock
+}
+
+// Save is a mock implementation of the GitHubAppRepository.Save method
+func (m *MockGitHubAppRepository) Save(ctx context.Context, app entity.GitHubApp) error {
+	args := m.Called(ctx, app)
+	return args.Error(0)
+}
+
+// FindByID is a mock implementation of the GitHubAppRepository.FindByID method
+func (m *MockGitHubAppRepository) FindByID(ctx context.Context, id string) (entity.GitHubApp, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.GitHubApp), args.Error(1)
+}
+
+// FindByAppID is a mock implementation of the GitHubAppRepository.FindByAppID method
+func (m *MockGitHubAppRepository) FindByAppID(ctx context.Context, appID int64) (entity.GitHubApp, error) {
+	args := m.Called(ctx, appID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.GitHubApp), args.Error(1)
+}
+
+// FindAll is a mock implementation of the GitHubAppRepository.FindAll method
+func (m *MockGitHubAppRepository) FindAll(ctx context.Context) ([]entity.GitHubApp, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.GitHubApp), args.Error(1)
+}
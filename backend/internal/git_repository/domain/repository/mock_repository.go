@@ -52,6 +52,12 @@ func (m *MockRepository) SaveManagedFiles(ctx context.Context, repoID string, fi
 	return args.Error(0)
 }
 
+// SaveRuleMatchedFiles is a mock implementation of the repository.SaveRuleMatchedFiles method
+func (m *MockRepository) SaveRuleMatchedFiles(ctx context.Context, repoID string, filePaths []string) error {
+	args := m.Called(ctx, repoID, filePaths)
+	return args.Error(0)
+}
+
 // GetManagedFiles is a mock implementation of the repository.GetManagedFiles method
 func (m *MockRepository) GetManagedFiles(ctx context.Context, repoID string) ([]string, error) {
 	args := m.Called(ctx, repoID)
@@ -66,3 +72,21 @@ func (m *MockRepository) UpdateAccessToken(ctx context.Context, repoID string, a
 	args := m.Called(ctx, repoID, accessToken)
 	return args.Error(0)
 }
+
+// Delete is a mock implementation of the repository.Delete method
+func (m *MockRepository) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// Archive is a mock implementation of the repository.Archive method
+func (m *MockRepository) Archive(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// UpdateTokenHealth is a mock implementation of the repository.UpdateTokenHealth method
+func (m *MockRepository) UpdateTokenHealth(ctx context.Context, repoID string, health entity.TokenHealth) error {
+	args := m.Called(ctx, repoID, health)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSyncRunRepository is a mock implementation of SyncRunRepository interface for testing
+type MockSyncRunRepository struct {
+	mock.Mock
+}
+
+// Save is a mock implementation of the SyncRunRepository.Save method
+func (m *MockSyncRunRepository) Save(ctx context.Context, run entity.SyncRun) error {
+	args := m.Called(ctx, run)
+	return args.Error(0)
+}
+
+// FindByRepositoryID is a mock implementation of the SyncRunRepository.FindByRepositoryID method
+func (m *MockSyncRunRepository) FindByRepositoryID(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error) {
+	args := m.Called(ctx, repoID, limit)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.SyncRun), args.Error(1)
+}
+
+// FindLatestSucceeded is a mock implementation of the SyncRunRepository.FindLatestSucceeded method
+func (m *MockSyncRunRepository) FindLatestSucceeded(ctx context.Context, repoID string) (entity.SyncRun, error) {
+	args := m.Called(ctx, repoID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.SyncRun), args.Error(1)
+}
@@ -18,9 +18,19 @@ type Repository interface {
 	// SaveManagedFiles saves the list of file paths selected for management for a given repository.
 	// This should replace any existing selection for the repository.
 	SaveManagedFiles(ctx context.Context, repoID string, filePaths []string) error
-	// GetManagedFiles retrieves the list of file paths selected for management for a given repository.
+	// SaveRuleMatchedFiles saves the list of file paths matched by the repository's include/exclude rules.
+	// This should replace any previous matches but keep the explicit selection.
+	SaveRuleMatchedFiles(ctx context.Context, repoID string, filePaths []string) error
+	// GetManagedFiles retrieves the file paths managed for a given repository, whether selected or matched by rules.
 	GetManagedFiles(ctx context.Context, repoID string) ([]string, error)
 	// UpdateAccessToken updates the access token for a repository.
+	// The token health is reset to unchecked.
 	UpdateAccessToken(ctx context.Context, repoID string, accessToken string) error
-	// TODO: Add other necessary methods (e.g., List, Delete)
+	// UpdateTokenHealth records the outcome of verifying a repository's access token.
+	UpdateTokenHealth(ctx context.Context, repoID string, health entity.TokenHealth) error
+	// Delete removes a repository together with its managed files, sync runs, documents and execution records.
+	Delete(ctx context.Context, id string) error
+	// Archive deletes a repository but keeps the documents and execution records that reference it.
+	// Its credentials and managed files are cleared and the finders no longer return it.
+	Archive(ctx context.Context, id string) error
 }
@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// SyncRunRepository defines the interface for data persistence operations related to repository sync runs.
+type SyncRunRepository interface {
+	// Save persists a new sync run or updates an existing one.
+	Save(ctx context.Context, run entity.SyncRun) error
+	// FindByRepositoryID retrieves the most recent sync runs of a repository, newest first.
+	FindByRepositoryID(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error)
+	// FindLatestSucceeded retrieves the most recent successful sync run of a repository. Returns nil if none.
+	FindLatestSucceeded(ctx context.Context, repoID string) (entity.SyncRun, error)
+}
@@ -1,6 +1,7 @@
 package encryption
 
 import (
+	"context"
 	"crypto/aes"
 	"crypto/cipher"
 	"crypto/rand"
@@ -8,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"strings"
 )
 
 var (
@@ -17,69 +19,267 @@ var (
 	ErrInvalidCiphertext = errors.New("invalid ciphertext")
 )
 
+// Ciphertext formats. Ciphertexts without a version prefix were written before key rotation and are plain base64.
+const (
+	// versionPrefix marks ciphertexts encrypted directly with a keyring key:
+	// "v1:<key ID>:<base64 of nonce and sealed data>".
+	versionPrefix = "v1:"
+	// envelopePrefix marks ciphertexts encrypted with their own data key, which is wrapped by a KeyProvider:
+	// "v2:<master key ID>:<base64 of wrapped data key>:<base64 of nonce and sealed data>".
+	envelopePrefix = "v2:"
+)
+
+// dataKeySize is the size of the per-record AES-256 data keys used for envelope encryption.
+const dataKeySize = 32
+
 // Encryptor handles encryption and decryption of sensitive data
 type Encryptor struct {
-	key []byte
+	keyring  *Keyring    // Direct encryption keys; with a provider only used to read older ciphertexts. May be nil.
+	provider KeyProvider // Wraps per-record data keys; nil encrypts directly with the keyring
 }
 
-// NewEncryptor creates a new Encryptor with the given key
+// NewEncryptor creates a new Encryptor with a single key, identified as DefaultKeyID
 // The key must be 32 bytes for AES-256
 func NewEncryptor(key []byte) (*Encryptor, error) {
 	if len(key) != 32 {
 		return nil, ErrInvalidKey
 	}
-	return &Encryptor{key: key}, nil
+	keyring, err := NewKeyring(DefaultKeyID, key)
+	if err != nil {
+		return nil, err
+	}
+	return &Encryptor{keyring: keyring}, nil
+}
+
+// NewKeyringEncryptor creates an Encryptor that encrypts with the active key of the keyring
+// and decrypts with whichever of its keys a ciphertext names.
+func NewKeyringEncryptor(keyring *Keyring) *Encryptor {
+	return &Encryptor{keyring: keyring}
+}
+
+// NewEnvelopeEncryptor creates an Encryptor that encrypts each value with a new data key and stores the data key
+// wrapped by the provider, so the master key never has to be in the application process. The keyring, which may
+// be nil, decrypts values written before envelope encryption was enabled.
+func NewEnvelopeEncryptor(provider KeyProvider, keyring *Keyring) *Encryptor {
+	return &Encryptor{keyring: keyring, provider: provider}
+}
+
+// Keyring returns the keys used by the encryptor for direct encryption, or nil if there are none.
+func (e *Encryptor) Keyring() *Keyring {
+	return e.keyring
+}
+
+// ActiveKeyID returns the ID of the key that encrypts new data: the provider's master key with envelope
+// encryption, and the keyring's active key otherwise.
+func (e *Encryptor) ActiveKeyID() string {
+	if e.provider != nil {
+		return e.provider.KeyID()
+	}
+	return e.keyring.ActiveKeyID()
 }
 
-// Encrypt encrypts the plaintext using AES-256-GCM and returns a base64-encoded ciphertext
+// Encrypt encrypts the plaintext like EncryptContext, without a deadline for the key provider
 func (e *Encryptor) Encrypt(plaintext string) (string, error) {
+	return e.EncryptContext(context.Background(), plaintext)
+}
+
+// EncryptContext encrypts the plaintext using AES-256-GCM and returns a ciphertext that names the key.
+// With a key provider the plaintext is encrypted with a new data key, which is wrapped by the provider and stored
+// alongside; otherwise it is encrypted with the active keyring key.
+func (e *Encryptor) EncryptContext(ctx context.Context, plaintext string) (string, error) {
 	if plaintext == "" {
 		return "", nil
 	}
 
-	block, err := aes.NewCipher(e.key)
+	if e.provider != nil {
+		return e.encryptEnvelope(ctx, plaintext)
+	}
+
+	keyID := e.keyring.ActiveKeyID()
+	key, err := e.keyring.key(keyID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+		return "", err
 	}
 
-	gcm, err := cipher.NewGCM(block)
+	// The key ID is authenticated so it cannot be swapped for another
+	header := versionPrefix + keyID
+	sealed, err := seal(key, []byte(plaintext), []byte(header))
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return "", err
 	}
 
-	// Create a nonce. GCM requires a unique nonce for each encryption
-	nonce := make([]byte, gcm.NonceSize())
-	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
-		return "", fmt.Errorf("failed to generate nonce: %w", err)
+	// Encode to base64 for storage
+	return header + ":" + base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+// encryptEnvelope encrypts the plaintext with a new data key wrapped by the provider.
+func (e *Encryptor) encryptEnvelope(ctx context.Context, plaintext string) (string, error) {
+	dataKey := make([]byte, dataKeySize)
+	if _, err := io.ReadFull(rand.Reader, dataKey); err != nil {
+		return "", fmt.Errorf("failed to generate data key: %w", err)
 	}
 
-	// Encrypt the data. The nonce is prepended to the ciphertext
-	ciphertext := gcm.Seal(nonce, nonce, []byte(plaintext), nil)
+	keyID := e.provider.KeyID()
+	wrapped, err := e.provider.WrapKey(ctx, dataKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to wrap data key: %w", err)
+	}
 
-	// Encode to base64 for storage
-	return base64.StdEncoding.EncodeToString(ciphertext), nil
+	// The master key ID and the wrapped data key are authenticated along with the data
+	header := envelopePrefix + keyID + ":" + base64.StdEncoding.EncodeToString(wrapped)
+	sealed, err := seal(dataKey, []byte(plaintext), []byte(header))
+	if err != nil {
+		return "", err
+	}
+	return header + ":" + base64.StdEncoding.EncodeToString(sealed), nil
 }
 
-// Decrypt decrypts the base64-encoded ciphertext using AES-256-GCM
+// Decrypt decrypts a ciphertext like DecryptContext, without a deadline for the key provider
 func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
+	return e.DecryptContext(context.Background(), ciphertext)
+}
+
+// DecryptContext decrypts a ciphertext produced by EncryptContext. Ciphertexts written before keys had IDs
+// are tried with every key of the keyring, the active key first.
+func (e *Encryptor) DecryptContext(ctx context.Context, ciphertext string) (string, error) {
 	if ciphertext == "" {
 		return "", nil
 	}
 
+	if rest, ok := strings.CutPrefix(ciphertext, envelopePrefix); ok {
+		return e.decryptEnvelope(ctx, rest)
+	}
+
+	keyID, encoded, versioned := parseCiphertext(ciphertext)
+
 	// Decode from base64
-	data, err := base64.StdEncoding.DecodeString(ciphertext)
+	data, err := base64.StdEncoding.DecodeString(encoded)
 	if err != nil {
 		return "", fmt.Errorf("failed to decode base64: %w", err)
 	}
 
-	block, err := aes.NewCipher(e.key)
+	if e.keyring == nil {
+		return "", fmt.Errorf("%w: no keyring is configured", ErrUnknownKeyID)
+	}
+
+	if !versioned {
+		var lastErr error
+		for _, id := range e.keyring.KeyIDs() {
+			key, _ := e.keyring.key(id)
+			plaintext, err := open(key, data, nil)
+			if err == nil {
+				return plaintext, nil
+			}
+			lastErr = err
+		}
+		return "", lastErr
+	}
+
+	key, err := e.keyring.key(keyID)
 	if err != nil {
-		return "", fmt.Errorf("failed to create cipher: %w", err)
+		return "", err
+	}
+	return open(key, data, []byte(versionPrefix+keyID))
+}
+
+// decryptEnvelope unwraps the data key of an envelope ciphertext (without its prefix) and decrypts the data.
+func (e *Encryptor) decryptEnvelope(ctx context.Context, rest string) (string, error) {
+	if e.provider == nil {
+		return "", fmt.Errorf("%w: no key provider is configured", ErrUnknownKeyID)
+	}
+
+	parts := strings.Split(rest, ":")
+	if len(parts) != 3 {
+		return "", ErrInvalidCiphertext
+	}
+	keyID, encodedKey, encodedData := parts[0], parts[1], parts[2]
+
+	wrapped, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode wrapped data key: %w", err)
+	}
+	data, err := base64.StdEncoding.DecodeString(encodedData)
+	if err != nil {
+		return "", fmt.Errorf("failed to decode base64: %w", err)
+	}
+
+	dataKey, err := e.provider.UnwrapKey(ctx, keyID, wrapped)
+	if err != nil {
+		return "", fmt.Errorf("failed to unwrap data key: %w", err)
+	}
+	if len(dataKey) != dataKeySize {
+		return "", ErrInvalidKey
+	}
+	return open(dataKey, data, []byte(envelopePrefix+keyID+":"+encodedKey))
+}
+
+// NeedsReencryption reports whether a ciphertext was not encrypted the way new data is: with the key provider's
+// current master key when envelope encryption is enabled, and with the active keyring key otherwise.
+func (e *Encryptor) NeedsReencryption(ciphertext string) bool {
+	if ciphertext == "" {
+		return false
+	}
+	if rest, ok := strings.CutPrefix(ciphertext, envelopePrefix); ok {
+		keyID, _, _ := strings.Cut(rest, ":")
+		return e.provider == nil || keyID != e.provider.KeyID()
+	}
+	if e.provider != nil {
+		return true
+	}
+	keyID, _, versioned := parseCiphertext(ciphertext)
+	return !versioned || keyID != e.keyring.ActiveKeyID()
+}
+
+// parseCiphertext splits a versioned ciphertext into its key ID and base64 data.
+// versioned is false for ciphertexts without a key ID, which are returned whole.
+func parseCiphertext(ciphertext string) (keyID, encoded string, versioned bool) {
+	rest, ok := strings.CutPrefix(ciphertext, versionPrefix)
+	if !ok {
+		return "", ciphertext, false
+	}
+	keyID, encoded, ok = strings.Cut(rest, ":")
+	if !ok {
+		return "", ciphertext, false
+	}
+	return keyID, encoded, true
+}
+
+// newGCM creates an AES-256-GCM cipher for the key.
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cipher: %w", err)
 	}
 
 	gcm, err := cipher.NewGCM(block)
 	if err != nil {
-		return "", fmt.Errorf("failed to create GCM: %w", err)
+		return nil, fmt.Errorf("failed to create GCM: %w", err)
+	}
+	return gcm, nil
+}
+
+// seal encrypts the plaintext with the key and returns it prefixed with a new random nonce.
+func seal(key, plaintext, additionalData []byte) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+
+	// Create a nonce. GCM requires a unique nonce for each encryption
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+
+	// Encrypt the data. The nonce is prepended to the ciphertext
+	return gcm.Seal(nonce, nonce, plaintext, additionalData), nil
+}
+
+// open decrypts the nonce-prefixed data with the key.
+func open(key, data, additionalData []byte) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
 	}
 
 	nonceSize := gcm.NonceSize()
@@ -91,7 +291,7 @@ func (e *Encryptor) Decrypt(ciphertext string) (string, error) {
 	nonce, cipherData := data[:nonceSize], data[nonceSize:]
 
 	// Decrypt the data
-	plaintext, err := gcm.Open(nil, nonce, cipherData, nil)
+	plaintext, err := gcm.Open(nil, nonce, cipherData, additionalData)
 	if err != nil {
 		return "", fmt.Errorf("failed to decrypt: %w", err)
 	}
@@ -0,0 +1,103 @@
+package encryption
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// KeyProvider wraps and unwraps the per-record data keys of envelope encryption with a master key
+// that it holds, so the Encryptor only ever sees data keys.
+type KeyProvider interface {
+	// KeyID returns the ID of the master key that wraps new data keys.
+	KeyID() string
+	// WrapKey encrypts a data key with the master key identified by KeyID.
+	WrapKey(ctx context.Context, dataKey []byte) ([]byte, error)
+	// UnwrapKey decrypts a data key wrapped with the master key identified by keyID.
+	UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error)
+}
+
+// LocalKeyProvider wraps data keys with master keys read from a local key file, which can be kept on a
+// separately managed volume (e.g. a mounted secret) instead of in the environment of the process.
+type LocalKeyProvider struct {
+	keyring *Keyring
+}
+
+// localKeyFile is the format of a local key file:
+//
+//	{"active": "2025-06", "keys": {"2025-06": "<base64 of 32 bytes>", "2024-01": "<base64 of 32 bytes>"}}
+//
+// The active key wraps new data keys; the others only unwrap data keys wrapped before a rotation.
+type localKeyFile struct {
+	Active string            `json:"active"`
+	Keys   map[string]string `json:"keys"`
+}
+
+// NewLocalKeyProvider creates a LocalKeyProvider whose keyring holds the master keys.
+func NewLocalKeyProvider(keyring *Keyring) *LocalKeyProvider {
+	return &LocalKeyProvider{keyring: keyring}
+}
+
+// LoadLocalKeyProvider reads the master keys from a local key file.
+func LoadLocalKeyProvider(path string) (*LocalKeyProvider, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read key file: %w", err)
+	}
+
+	var file localKeyFile
+	if err := json.Unmarshal(content, &file); err != nil {
+		return nil, fmt.Errorf("failed to parse key file: %w", err)
+	}
+
+	activeKey, err := ParseKey(file.Keys[file.Active])
+	if err != nil {
+		return nil, fmt.Errorf("key file: active key %q: %w", file.Active, err)
+	}
+	keyring, err := NewKeyring(file.Active, activeKey)
+	if err != nil {
+		return nil, fmt.Errorf("key file: %w", err)
+	}
+	for id, encoded := range file.Keys {
+		if id == file.Active {
+			continue
+		}
+		key, err := ParseKey(encoded)
+		if err != nil {
+			return nil, fmt.Errorf("key file: key %q: %w", id, err)
+		}
+		if err := keyring.AddDecryptionKey(id, key); err != nil {
+			return nil, fmt.Errorf("key file: %w", err)
+		}
+	}
+	return NewLocalKeyProvider(keyring), nil
+}
+
+// KeyID returns the ID of the active master key.
+func (p *LocalKeyProvider) KeyID() string {
+	return p.keyring.ActiveKeyID()
+}
+
+// WrapKey encrypts a data key with the active master key.
+func (p *LocalKeyProvider) WrapKey(_ context.Context, dataKey []byte) ([]byte, error) {
+	keyID := p.keyring.ActiveKeyID()
+	masterKey, err := p.keyring.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	return seal(masterKey, dataKey, []byte(keyID))
+}
+
+// UnwrapKey decrypts a data key with the master key identified by keyID.
+func (p *LocalKeyProvider) UnwrapKey(_ context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	masterKey, err := p.keyring.key(keyID)
+	if err != nil {
+		return nil, err
+	}
+	dataKey, err := open(masterKey, wrapped, []byte(keyID))
+	if err != nil {
+		return nil, err
+	}
+	return []byte(dataKey), nil
+}
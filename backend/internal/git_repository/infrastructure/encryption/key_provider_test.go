@@ -0,0 +1,130 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeKeyFile writes a local key file with the given active key ID and keys
+func writeKeyFile(t *testing.T, active string, keys map[string][]byte) string {
+	file := localKeyFile{Active: active, Keys: make(map[string]string)}
+	for id, key := range keys {
+		file.Keys[id] = base64.StdEncoding.EncodeToString(key)
+	}
+	content, err := json.Marshal(file)
+	require.NoError(t, err)
+
+	path := filepath.Join(t.TempDir(), "keys.json")
+	require.NoError(t, os.WriteFile(path, content, 0o600))
+	return path
+}
+
+func TestLoadLocalKeyProvider(t *testing.T) {
+	t.Run("valid key file", func(t *testing.T) {
+		path := writeKeyFile(t, "2025", map[string][]byte{"2025": newTestKey(t), "2024": newTestKey(t)})
+
+		provider, err := LoadLocalKeyProvider(path)
+		require.NoError(t, err)
+		assert.Equal(t, "2025", provider.KeyID())
+	})
+
+	t.Run("active key missing", func(t *testing.T) {
+		path := writeKeyFile(t, "2025", map[string][]byte{"2024": newTestKey(t)})
+
+		_, err := LoadLocalKeyProvider(path)
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+
+	t.Run("missing file", func(t *testing.T) {
+		_, err := LoadLocalKeyProvider(filepath.Join(t.TempDir(), "missing.json"))
+		assert.Error(t, err)
+	})
+}
+
+func TestEnvelopeEncryption(t *testing.T) {
+	ctx := context.Background()
+	oldMaster, newMaster := newTestKey(t), newTestKey(t)
+
+	oldProvider, err := LoadLocalKeyProvider(writeKeyFile(t, "m1", map[string][]byte{"m1": oldMaster}))
+	require.NoError(t, err)
+	enc := NewEnvelopeEncryptor(oldProvider, nil)
+
+	t.Run("encrypts each value with its own data key", func(t *testing.T) {
+		ciphertext1, err := enc.EncryptContext(ctx, "ghp_token")
+		require.NoError(t, err)
+		ciphertext2, err := enc.EncryptContext(ctx, "ghp_token")
+		require.NoError(t, err)
+
+		assert.True(t, strings.HasPrefix(ciphertext1, "v2:m1:"))
+		wrapped1 := strings.Split(ciphertext1, ":")[2]
+		wrapped2 := strings.Split(ciphertext2, ":")[2]
+		assert.NotEqual(t, wrapped1, wrapped2)
+
+		plaintext, err := enc.DecryptContext(ctx, ciphertext1)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_token", plaintext)
+		assert.False(t, enc.NeedsReencryption(ciphertext1))
+	})
+
+	t.Run("rejects a swapped data key", func(t *testing.T) {
+		ciphertext1, err := enc.EncryptContext(ctx, "first")
+		require.NoError(t, err)
+		ciphertext2, err := enc.EncryptContext(ctx, "second")
+		require.NoError(t, err)
+
+		parts1, parts2 := strings.Split(ciphertext1, ":"), strings.Split(ciphertext2, ":")
+		swapped := strings.Join([]string{parts1[0], parts1[1], parts2[2], parts1[3]}, ":")
+		_, err = enc.DecryptContext(ctx, swapped)
+		assert.Error(t, err)
+	})
+
+	t.Run("reads values of a previous master key and of the keyring", func(t *testing.T) {
+		oldCiphertext, err := enc.EncryptContext(ctx, "ghp_old_master")
+		require.NoError(t, err)
+
+		directKey := newTestKey(t)
+		direct, err := NewEncryptor(directKey)
+		require.NoError(t, err)
+		directCiphertext, err := direct.Encrypt("ghp_direct")
+		require.NoError(t, err)
+
+		// Rotate the master key and keep the direct key for values written before envelope encryption
+		provider, err := LoadLocalKeyProvider(writeKeyFile(t, "m2", map[string][]byte{"m2": newMaster, "m1": oldMaster}))
+		require.NoError(t, err)
+		rotated := NewEnvelopeEncryptor(provider, direct.Keyring())
+
+		plaintext, err := rotated.DecryptContext(ctx, oldCiphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_old_master", plaintext)
+		assert.True(t, rotated.NeedsReencryption(oldCiphertext))
+
+		plaintext, err = rotated.DecryptContext(ctx, directCiphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_direct", plaintext)
+		assert.True(t, rotated.NeedsReencryption(directCiphertext))
+
+		ciphertext, err := rotated.EncryptContext(ctx, "ghp_new")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(ciphertext, "v2:m2:"))
+		assert.Equal(t, "m2", rotated.ActiveKeyID())
+	})
+
+	t.Run("direct encryptor cannot read envelope values", func(t *testing.T) {
+		ciphertext, err := enc.EncryptContext(ctx, "ghp_token")
+		require.NoError(t, err)
+
+		direct, err := NewEncryptor(newTestKey(t))
+		require.NoError(t, err)
+		_, err = direct.Decrypt(ciphertext)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+		assert.True(t, direct.NeedsReencryption(ciphertext))
+	})
+}
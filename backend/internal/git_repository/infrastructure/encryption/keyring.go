@@ -0,0 +1,94 @@
+package encryption
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"regexp"
+)
+
+// DefaultKeyID is the ID of the key given to NewEncryptor, and of ENCRYPTION_KEY when no ID is configured.
+const DefaultKeyID = "default"
+
+var (
+	// ErrInvalidKeyID is returned when a key ID is empty or contains characters other than letters, digits, '.', '_' and '-'
+	ErrInvalidKeyID = errors.New("invalid encryption key ID: use 1-64 letters, digits, '.', '_' or '-'")
+	// ErrDuplicateKeyID is returned when two keys of a keyring share an ID
+	ErrDuplicateKeyID = errors.New("duplicate encryption key ID")
+	// ErrUnknownKeyID is returned when a ciphertext was encrypted with a key that is not in the keyring
+	ErrUnknownKeyID = errors.New("ciphertext was encrypted with a key that is not in the keyring")
+)
+
+// validKeyIDPattern keeps key IDs free of the ':' that separates them from the ciphertext.
+var validKeyIDPattern = regexp.MustCompile(`^[a-zA-Z0-9._-]{1,64}$`)
+
+// Keyring holds the active key used to encrypt and the previous keys that are still accepted for decryption.
+// Rotating a key means making a new key active, keeping the old one as a decryption key until every
+// ciphertext has been re-encrypted, and then removing it.
+type Keyring struct {
+	activeID string
+	keys     map[string][]byte // Key ID -> 32-byte AES-256 key, including the active key
+	order    []string          // Key IDs, the active key first, in the order they were added
+}
+
+// NewKeyring creates a keyring whose active key encrypts all new data.
+func NewKeyring(activeID string, activeKey []byte) (*Keyring, error) {
+	k := &Keyring{activeID: activeID, keys: make(map[string][]byte)}
+	if err := k.add(activeID, activeKey); err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// AddDecryptionKey adds a previous key that is only used to decrypt data encrypted with it.
+func (k *Keyring) AddDecryptionKey(id string, key []byte) error {
+	return k.add(id, key)
+}
+
+// add validates and stores a key.
+func (k *Keyring) add(id string, key []byte) error {
+	if !validKeyIDPattern.MatchString(id) {
+		return fmt.Errorf("%w: %q", ErrInvalidKeyID, id)
+	}
+	if len(key) != 32 {
+		return fmt.Errorf("key %q: %w", id, ErrInvalidKey)
+	}
+	if _, exists := k.keys[id]; exists {
+		return fmt.Errorf("%w: %q", ErrDuplicateKeyID, id)
+	}
+	k.keys[id] = key
+	k.order = append(k.order, id)
+	return nil
+}
+
+// ActiveKeyID returns the ID of the key used for encryption.
+func (k *Keyring) ActiveKeyID() string {
+	return k.activeID
+}
+
+// KeyIDs returns the IDs of all keys, the active key first.
+func (k *Keyring) KeyIDs() []string {
+	return append([]string{}, k.order...)
+}
+
+// key returns the key with the given ID.
+func (k *Keyring) key(id string) ([]byte, error) {
+	key, ok := k.keys[id]
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownKeyID, id)
+	}
+	return key, nil
+}
+
+// ParseKey decodes a key given as 32 raw bytes or as the base64 encoding of 32 bytes
+// (e.g. the output of `openssl rand -base64 32`).
+func ParseKey(s string) ([]byte, error) {
+	if len(s) == 32 {
+		return []byte(s), nil
+	}
+	key, err := base64.StdEncoding.DecodeString(s)
+	if err != nil || len(key) != 32 {
+		return nil, ErrInvalidKey
+	}
+	return key, nil
+}
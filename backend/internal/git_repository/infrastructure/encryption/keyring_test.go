@@ -0,0 +1,139 @@
+package encryption
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestKey generates a random 32-byte key
+func newTestKey(t *testing.T) []byte {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+// encryptLegacy produces a ciphertext in the format used before keys had IDs
+func encryptLegacy(t *testing.T, key []byte, plaintext string) string {
+	gcm, err := newGCM(key)
+	require.NoError(t, err)
+	nonce := make([]byte, gcm.NonceSize())
+	_, err = io.ReadFull(rand.Reader, nonce)
+	require.NoError(t, err)
+	return base64.StdEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(plaintext), nil))
+}
+
+func TestNewKeyring(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      string
+		key     []byte
+		errType error
+	}{
+		{name: "valid key", id: "2025-06", key: make([]byte, 32)},
+		{name: "empty ID", id: "", key: make([]byte, 32), errType: ErrInvalidKeyID},
+		{name: "ID with a colon", id: "a:b", key: make([]byte, 32), errType: ErrInvalidKeyID},
+		{name: "short key", id: "k1", key: make([]byte, 16), errType: ErrInvalidKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			keyring, err := NewKeyring(tt.id, tt.key)
+			if tt.errType != nil {
+				assert.ErrorIs(t, err, tt.errType)
+				assert.Nil(t, keyring)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.id, keyring.ActiveKeyID())
+		})
+	}
+
+	t.Run("duplicate ID", func(t *testing.T) {
+		keyring, err := NewKeyring("k1", newTestKey(t))
+		require.NoError(t, err)
+		assert.ErrorIs(t, keyring.AddDecryptionKey("k1", newTestKey(t)), ErrDuplicateKeyID)
+	})
+}
+
+func TestKeyRotation(t *testing.T) {
+	oldKey, newKey := newTestKey(t), newTestKey(t)
+
+	oldKeyring, err := NewKeyring("old", oldKey)
+	require.NoError(t, err)
+	oldEnc := NewKeyringEncryptor(oldKeyring)
+
+	oldCiphertext, err := oldEnc.Encrypt("ghp_old")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(oldCiphertext, "v1:old:"))
+
+	// The new key becomes active and the old one is kept for decryption
+	keyring, err := NewKeyring("new", newKey)
+	require.NoError(t, err)
+	require.NoError(t, keyring.AddDecryptionKey("old", oldKey))
+	enc := NewKeyringEncryptor(keyring)
+	assert.Equal(t, []string{"new", "old"}, keyring.KeyIDs())
+
+	t.Run("decrypts data encrypted with a previous key", func(t *testing.T) {
+		plaintext, err := enc.Decrypt(oldCiphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_old", plaintext)
+		assert.True(t, enc.NeedsReencryption(oldCiphertext))
+	})
+
+	t.Run("encrypts with the active key", func(t *testing.T) {
+		ciphertext, err := enc.Encrypt("ghp_new")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(ciphertext, "v1:new:"))
+		assert.False(t, enc.NeedsReencryption(ciphertext))
+
+		// The old keyring cannot read it
+		_, err = oldEnc.Decrypt(ciphertext)
+		assert.ErrorIs(t, err, ErrUnknownKeyID)
+	})
+
+	t.Run("decrypts ciphertexts without a key ID", func(t *testing.T) {
+		legacy := encryptLegacy(t, oldKey, "ghp_legacy")
+		plaintext, err := enc.Decrypt(legacy)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_legacy", plaintext)
+		assert.True(t, enc.NeedsReencryption(legacy))
+	})
+
+	t.Run("rejects a ciphertext whose key ID was changed", func(t *testing.T) {
+		tampered := "v1:new:" + strings.TrimPrefix(oldCiphertext, "v1:old:")
+		_, err := enc.Decrypt(tampered)
+		assert.Error(t, err)
+	})
+
+	t.Run("empty ciphertext needs no re-encryption", func(t *testing.T) {
+		assert.False(t, enc.NeedsReencryption(""))
+	})
+}
+
+func TestParseKey(t *testing.T) {
+	raw := newTestKey(t)
+
+	t.Run("raw 32-byte string", func(t *testing.T) {
+		key, err := ParseKey("dev-key-123456789012345678901234")
+		require.NoError(t, err)
+		assert.Len(t, key, 32)
+	})
+
+	t.Run("base64-encoded key", func(t *testing.T) {
+		key, err := ParseKey(base64.StdEncoding.EncodeToString(raw))
+		require.NoError(t, err)
+		assert.Equal(t, raw, key)
+	})
+
+	t.Run("wrong length", func(t *testing.T) {
+		_, err := ParseKey("too-short")
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
@@ -0,0 +1,147 @@
+package encryption
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxCachedDataKeys bounds the number of unwrapped data keys KMSKeyProvider keeps in memory.
+const maxCachedDataKeys = 1024
+
+// KMSKeyProvider wraps data keys with a master key held by an external key management service, so the
+// master key never enters the application process. It speaks a small JSON API that KMS gateways
+// (or a local fake) can implement:
+//
+//	POST {base}/v1/keys/{keyID}/wrap    {"plaintext": "<base64>"}  -> {"ciphertext": "<base64>"}
+//	POST {base}/v1/keys/{keyID}/unwrap  {"ciphertext": "<base64>"} -> {"plaintext": "<base64>"}
+//
+// Requests carry the token as a bearer token. Unwrapped data keys are cached, so reading the same
+// record again does not call the service.
+type KMSKeyProvider struct {
+	baseURL    string
+	keyID      string
+	token      string
+	httpClient *http.Client
+
+	mu    sync.Mutex
+	cache map[string][]byte // Wrapped data key (with its key ID) -> data key
+}
+
+// kmsRequest and kmsResponse are the bodies of the wrap and unwrap calls.
+type kmsRequest struct {
+	Plaintext  string `json:"plaintext,omitempty"`
+	Ciphertext string `json:"ciphertext,omitempty"`
+}
+
+type kmsResponse struct {
+	Plaintext  string `json:"plaintext"`
+	Ciphertext string `json:"ciphertext"`
+}
+
+// NewKMSKeyProvider creates a KMSKeyProvider that wraps new data keys with the master key keyID of the service at baseURL.
+func NewKMSKeyProvider(baseURL, keyID, token string) (*KMSKeyProvider, error) {
+	parsed, err := url.Parse(baseURL)
+	if err != nil || (parsed.Scheme != "https" && parsed.Scheme != "http") || parsed.Host == "" {
+		return nil, fmt.Errorf("invalid KMS URL %q", baseURL)
+	}
+	if !validKeyIDPattern.MatchString(keyID) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, keyID)
+	}
+	return &KMSKeyProvider{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		keyID:      keyID,
+		token:      token,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+		cache:      make(map[string][]byte),
+	}, nil
+}
+
+// KeyID returns the ID of the master key that wraps new data keys.
+func (p *KMSKeyProvider) KeyID() string {
+	return p.keyID
+}
+
+// WrapKey asks the service to encrypt a data key with the master key.
+func (p *KMSKeyProvider) WrapKey(ctx context.Context, dataKey []byte) ([]byte, error) {
+	var resp kmsResponse
+	if err := p.call(ctx, p.keyID, "wrap", kmsRequest{Plaintext: base64.StdEncoding.EncodeToString(dataKey)}, &resp); err != nil {
+		return nil, err
+	}
+	wrapped, err := base64.StdEncoding.DecodeString(resp.Ciphertext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode wrapped key from KMS: %w", err)
+	}
+	return wrapped, nil
+}
+
+// UnwrapKey asks the service to decrypt a data key wrapped with the master key keyID.
+func (p *KMSKeyProvider) UnwrapKey(ctx context.Context, keyID string, wrapped []byte) ([]byte, error) {
+	if !validKeyIDPattern.MatchString(keyID) {
+		return nil, fmt.Errorf("%w: %q", ErrInvalidKeyID, keyID)
+	}
+	encoded := base64.StdEncoding.EncodeToString(wrapped)
+	cacheKey := keyID + ":" + encoded
+
+	p.mu.Lock()
+	dataKey, ok := p.cache[cacheKey]
+	p.mu.Unlock()
+	if ok {
+		return dataKey, nil
+	}
+
+	var resp kmsResponse
+	if err := p.call(ctx, keyID, "unwrap", kmsRequest{Ciphertext: encoded}, &resp); err != nil {
+		return nil, err
+	}
+	dataKey, err := base64.StdEncoding.DecodeString(resp.Plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode data key from KMS: %w", err)
+	}
+
+	p.mu.Lock()
+	if len(p.cache) >= maxCachedDataKeys {
+		clear(p.cache)
+	}
+	p.cache[cacheKey] = dataKey
+	p.mu.Unlock()
+	return dataKey, nil
+}
+
+// call posts a request to an operation on a master key and decodes the response.
+func (p *KMSKeyProvider) call(ctx context.Context, keyID, operation string, body kmsRequest, out *kmsResponse) error {
+	payload, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to encode KMS request: %w", err)
+	}
+
+	endpoint := p.baseURL + "/v1/keys/" + url.PathEscape(keyID) + "/" + operation
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("failed to create KMS request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if p.token != "" {
+		req.Header.Set("Authorization", "Bearer "+p.token)
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("KMS request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("KMS %s with key %q returned status %d", operation, keyID, resp.StatusCode)
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode KMS response: %w", err)
+	}
+	return nil
+}
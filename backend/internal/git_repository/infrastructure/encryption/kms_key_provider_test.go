@@ -0,0 +1,153 @@
+package encryption
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeKMS is a local stand-in for a key management service that implements the wrap and unwrap API
+type fakeKMS struct {
+	provider *LocalKeyProvider // Holds the master keys on the service side
+	token    string
+	calls    atomic.Int32
+}
+
+func (f *fakeKMS) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.calls.Add(1)
+	if r.Header.Get("Authorization") != "Bearer "+f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	// /v1/keys/{keyID}/{operation}
+	parts := strings.Split(strings.TrimPrefix(r.URL.Path, "/v1/keys/"), "/")
+	if r.Method != http.MethodPost || len(parts) != 2 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	keyID, operation := parts[0], parts[1]
+
+	var req kmsRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+
+	var resp kmsResponse
+	switch operation {
+	case "wrap":
+		if keyID != f.provider.KeyID() {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		dataKey, _ := base64.StdEncoding.DecodeString(req.Plaintext)
+		wrapped, err := f.provider.WrapKey(r.Context(), dataKey)
+		if err != nil {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		resp.Ciphertext = base64.StdEncoding.EncodeToString(wrapped)
+	case "unwrap":
+		wrapped, _ := base64.StdEncoding.DecodeString(req.Ciphertext)
+		dataKey, err := f.provider.UnwrapKey(r.Context(), keyID, wrapped)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp.Plaintext = base64.StdEncoding.EncodeToString(dataKey)
+	default:
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(resp)
+}
+
+// newFakeKMS starts a fake KMS holding a single master key
+func newFakeKMS(t *testing.T, keyID, token string) (*fakeKMS, *httptest.Server) {
+	keyring, err := NewKeyring(keyID, newTestKey(t))
+	require.NoError(t, err)
+	fake := &fakeKMS{provider: NewLocalKeyProvider(keyring), token: token}
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func TestNewKMSKeyProvider(t *testing.T) {
+	_, err := NewKMSKeyProvider("ftp://kms.example.com", "key", "")
+	assert.Error(t, err)
+
+	_, err = NewKMSKeyProvider("https://kms.example.com", "bad/key", "")
+	assert.ErrorIs(t, err, ErrInvalidKeyID)
+
+	provider, err := NewKMSKeyProvider("https://kms.example.com/", "opscore", "")
+	require.NoError(t, err)
+	assert.Equal(t, "opscore", provider.KeyID())
+}
+
+func TestKMSKeyProvider(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("encrypts and decrypts through the service", func(t *testing.T) {
+		fake, server := newFakeKMS(t, "opscore", "kms-token")
+		provider, err := NewKMSKeyProvider(server.URL, "opscore", "kms-token")
+		require.NoError(t, err)
+		enc := NewEnvelopeEncryptor(provider, nil)
+
+		ciphertext, err := enc.EncryptContext(ctx, "ghp_token")
+		require.NoError(t, err)
+		assert.True(t, strings.HasPrefix(ciphertext, "v2:opscore:"))
+		assert.NotContains(t, ciphertext, "ghp_token")
+
+		plaintext, err := enc.DecryptContext(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_token", plaintext)
+
+		// The unwrapped data key is cached
+		calls := fake.calls.Load()
+		_, err = enc.DecryptContext(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, calls, fake.calls.Load())
+	})
+
+	t.Run("a fresh provider decrypts with the service", func(t *testing.T) {
+		_, server := newFakeKMS(t, "opscore", "kms-token")
+		writer, err := NewKMSKeyProvider(server.URL, "opscore", "kms-token")
+		require.NoError(t, err)
+		ciphertext, err := NewEnvelopeEncryptor(writer, nil).EncryptContext(ctx, "ghp_token")
+		require.NoError(t, err)
+
+		reader, err := NewKMSKeyProvider(server.URL, "opscore", "kms-token")
+		require.NoError(t, err)
+		plaintext, err := NewEnvelopeEncryptor(reader, nil).DecryptContext(ctx, ciphertext)
+		require.NoError(t, err)
+		assert.Equal(t, "ghp_token", plaintext)
+	})
+
+	t.Run("rejected credentials", func(t *testing.T) {
+		_, server := newFakeKMS(t, "opscore", "kms-token")
+		provider, err := NewKMSKeyProvider(server.URL, "opscore", "wrong-token")
+		require.NoError(t, err)
+
+		_, err = NewEnvelopeEncryptor(provider, nil).EncryptContext(ctx, "ghp_token")
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401")
+	})
+
+	t.Run("unknown master key", func(t *testing.T) {
+		_, server := newFakeKMS(t, "opscore", "kms-token")
+		provider, err := NewKMSKeyProvider(server.URL, "other", "kms-token")
+		require.NoError(t, err)
+
+		_, err = provider.WrapKey(ctx, newTestKey(t))
+		assert.Error(t, err)
+	})
+}
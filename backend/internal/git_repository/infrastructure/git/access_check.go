@@ -0,0 +1,21 @@
+package git
+
+import "time"
+
+// AccessCheck is the result of verifying that a repository's credentials still work.
+type AccessCheck struct {
+	Valid     bool       // The provider accepted the credentials and the repository is readable with them
+	ExpiresAt *time.Time // When the token expires, if the provider reports it
+	Reason    string     // Why the credentials were rejected
+}
+
+// Reasons for rejecting credentials shared by the managers
+const (
+	accessReasonRejected     = "the token was rejected; it may have expired or been revoked"
+	accessReasonInaccessible = "the repository is not accessible with the token"
+)
+
+// rejectedAccess returns a failed check with the given reason.
+func rejectedAccess(reason string) AccessCheck {
+	return AccessCheck{Valid: false, Reason: reason}
+}
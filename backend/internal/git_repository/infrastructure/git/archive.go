@@ -0,0 +1,199 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+)
+
+const (
+	// MaxArchiveSize is the largest archive accepted for upload, in bytes.
+	MaxArchiveSize = 256 << 20
+	// maxExtractedSize bounds the total size of the files extracted from an archive, so a small
+	// archive cannot fill the disk.
+	maxExtractedSize = 1 << 30
+	// maxArchiveEntries bounds the number of entries read from an archive.
+	maxArchiveEntries = 100000
+)
+
+// Errors returned for uploaded archives that are not accepted
+var (
+	ErrUnsupportedArchive = errors.New("unsupported archive format: only tar.gz and zip archives are supported")
+	ErrArchiveTooLarge    = errors.New("archive is too large")
+)
+
+// Magic numbers the supported archive formats start with
+var (
+	gzipMagic = []byte{0x1f, 0x8b}
+	zipMagic  = []byte("PK\x03\x04")
+)
+
+// archiveFormat is a supported archive format.
+type archiveFormat string
+
+const (
+	archiveFormatTarGz archiveFormat = "tar.gz"
+	archiveFormatZip   archiveFormat = "zip"
+)
+
+// detectArchiveFormat detects the format of an archive from its first bytes, whatever the file is named.
+func detectArchiveFormat(header []byte) (archiveFormat, error) {
+	switch {
+	case bytes.HasPrefix(header, gzipMagic):
+		return archiveFormatTarGz, nil
+	case bytes.HasPrefix(header, zipMagic):
+		return archiveFormatZip, nil
+	}
+	return "", ErrUnsupportedArchive
+}
+
+// readArchiveFormat detects the format of an open archive and rewinds it.
+func readArchiveFormat(file *os.File) (archiveFormat, error) {
+	header := make([]byte, len(zipMagic))
+	n, err := io.ReadFull(file, header)
+	if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) && !errors.Is(err, io.EOF) {
+		return "", fmt.Errorf("failed to read archive: %w", err)
+	}
+	format, err := detectArchiveFormat(header[:n])
+	if err != nil {
+		return "", err
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read archive: %w", err)
+	}
+	return format, nil
+}
+
+// extractArchive extracts the regular files of a tar.gz or zip archive into destPath.
+// Entries that would escape destPath, links and other special files are skipped.
+func extractArchive(archivePath, destPath string) error {
+	file, err := os.Open(archivePath)
+	if err != nil {
+		return fmt.Errorf("failed to open archive: %w", err)
+	}
+	defer file.Close()
+
+	format, err := readArchiveFormat(file)
+	if err != nil {
+		return err
+	}
+
+	switch format {
+	case archiveFormatZip:
+		info, err := file.Stat()
+		if err != nil {
+			return fmt.Errorf("failed to read archive: %w", err)
+		}
+		err = extractZip(file, info.Size(), destPath)
+		if err != nil {
+			return fmt.Errorf("failed to extract zip archive: %w", err)
+		}
+	default:
+		if err := extractTarGz(file, destPath); err != nil {
+			return fmt.Errorf("failed to extract tar.gz archive: %w", err)
+		}
+	}
+	return nil
+}
+
+// extractTarGz extracts a gzip-compressed tar archive.
+func extractTarGz(r io.Reader, destPath string) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	budget := archiveBudget{remaining: maxExtractedSize}
+	for entries := 0; ; entries++ {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entries >= maxArchiveEntries {
+			return fmt.Errorf("archive has more than %d entries", maxArchiveEntries)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue // Directories are created with their files; links and special files are skipped
+		}
+		if err := budget.extract(tr, destPath, header.Name); err != nil {
+			return err
+		}
+	}
+}
+
+// extractZip extracts a zip archive.
+func extractZip(r io.ReaderAt, size int64, destPath string) error {
+	zr, err := zip.NewReader(r, size)
+	if err != nil {
+		return err
+	}
+	if len(zr.File) > maxArchiveEntries {
+		return fmt.Errorf("archive has more than %d entries", maxArchiveEntries)
+	}
+
+	budget := archiveBudget{remaining: maxExtractedSize}
+	for _, entry := range zr.File {
+		if !entry.Mode().IsRegular() {
+			continue // Directories are created with their files; links and special files are skipped
+		}
+		content, err := entry.Open()
+		if err != nil {
+			return err
+		}
+		err = budget.extract(content, destPath, entry.Name)
+		content.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveBudget tracks how many bytes may still be extracted from an archive.
+type archiveBudget struct {
+	remaining int64
+}
+
+// extract writes one archive entry below destPath, failing once the archive exceeds maxExtractedSize.
+// Entries whose name would escape destPath are skipped.
+func (b *archiveBudget) extract(content io.Reader, destPath, name string) error {
+	name = filepath.ToSlash(name)
+	if filepath.IsAbs(name) {
+		return nil
+	}
+	target, err := resolveRepositoryPath(destPath, name)
+	if err != nil || target == filepath.Clean(destPath) {
+		return nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+		return err
+	}
+	out, err := os.OpenFile(target, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	written, err := io.Copy(out, io.LimitReader(content, b.remaining+1))
+	if closeErr := out.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	b.remaining -= written
+	if b.remaining < 0 {
+		return fmt.Errorf("archive expands to more than %d bytes", maxExtractedSize)
+	}
+	return nil
+}
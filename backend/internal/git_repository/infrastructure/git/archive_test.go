@@ -0,0 +1,91 @@
+package git
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeTarGz writes a tar.gz archive holding the given files
+func writeTarGz(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	gz := gzip.NewWriter(file)
+	tw := tar.NewWriter(gz)
+	for name, content := range files {
+		require.NoError(t, tw.WriteHeader(&tar.Header{Name: name, Mode: 0644, Size: int64(len(content)), Typeflag: tar.TypeReg}))
+		_, err := tw.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+}
+
+// writeZip writes a zip archive holding the given files
+func writeZip(t *testing.T, archivePath string, files map[string]string) {
+	t.Helper()
+	file, err := os.Create(archivePath)
+	require.NoError(t, err)
+	defer file.Close()
+
+	zw := zip.NewWriter(file)
+	for name, content := range files {
+		w, err := zw.Create(name)
+		require.NoError(t, err)
+		_, err = w.Write([]byte(content))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+}
+
+// TestExtractArchive tests extracting uploaded archives
+func TestExtractArchive(t *testing.T) {
+	files := map[string]string{"README.md": "readme", "docs/guide.md": "guide"}
+
+	// テスト：tar.gzとzipのアーカイブが展開されることを確認する
+	t.Run("tar.gzとzipのアーカイブが展開される", func(t *testing.T) {
+		dir := t.TempDir()
+		writeTarGz(t, filepath.Join(dir, "runbooks.tar.gz"), files)
+		writeZip(t, filepath.Join(dir, "runbooks.zip"), files)
+
+		for _, name := range []string{"runbooks.tar.gz", "runbooks.zip"} {
+			destPath := filepath.Join(dir, name+".out")
+			require.NoError(t, os.Mkdir(destPath, 0755))
+			require.NoError(t, extractArchive(filepath.Join(dir, name), destPath))
+			assert.Equal(t, files, readLocalFiles(t, destPath), name)
+		}
+	})
+
+	// テスト：展開先の外を指すエントリは書き込まれないことを確認する
+	t.Run("展開先の外を指すエントリは書き込まれない", func(t *testing.T) {
+		dir := t.TempDir()
+		archivePath := filepath.Join(dir, "evil.tar.gz")
+		writeTarGz(t, archivePath, map[string]string{"../escaped.md": "evil", "/etc/absolute.md": "evil", "./README.md": "readme"})
+		destPath := filepath.Join(dir, "out")
+		require.NoError(t, os.Mkdir(destPath, 0755))
+
+		require.NoError(t, extractArchive(archivePath, destPath))
+
+		assert.Equal(t, map[string]string{"README.md": "readme"}, readLocalFiles(t, destPath))
+		assert.NoFileExists(t, filepath.Join(dir, "escaped.md"))
+	})
+
+	// テスト：対応していない形式はエラーになることを確認する
+	t.Run("対応していない形式はエラーになる", func(t *testing.T) {
+		dir := t.TempDir()
+		archivePath := filepath.Join(dir, "runbooks.md")
+		require.NoError(t, os.WriteFile(archivePath, []byte("# not an archive"), 0644))
+
+		err := extractArchive(archivePath, dir)
+		assert.ErrorIs(t, err, ErrUnsupportedArchive)
+	})
+}
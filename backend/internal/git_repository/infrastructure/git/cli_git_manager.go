@@ -8,23 +8,28 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // cliGitManager implements the GitManager interface using Git CLI commands.
 type cliGitManager struct {
-	baseClonePath string // Base directory where repositories will be cloned
+	baseClonePath  string // Base directory where repositories will be cloned
+	knownHostsFile string // known_hosts file that SSH host keys are verified against
 }
 
 // NewCliGitManager creates a new cliGitManager.
 // baseClonePath is the directory where repositories will be stored locally.
-func NewCliGitManager(baseClonePath string) (GitManager, error) {
+// knownHostsFile is the known_hosts file that the host keys of SSH remotes are verified against;
+// repositories authenticated with a deploy key cannot be synced when it is empty.
+func NewCliGitManager(baseClonePath string, knownHostsFile string) (GitManager, error) {
 	// Ensure the base clone path exists
 	err := os.MkdirAll(baseClonePath, 0755)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create base clone directory '%s': %w", baseClonePath, err)
 	}
-	return &cliGitManager{baseClonePath: baseClonePath},
+	return &cliGitManager{baseClonePath: baseClonePath, knownHostsFile: knownHostsFile},
 		nil
 }
 
@@ -43,11 +48,14 @@ func (g *cliGitManager) runGitCommand(ctx context.Context, dir string, repo enti
 
 	// 許可されたgitコマンド（サブコマンド）のリスト
 	allowedCommands := map[string]bool{
-		"clone":    true,
-		"fetch":    true,
-		"reset":    true,
-		"ls-tree":  true,
-		"ls-files": true,
+		"clone":     true,
+		"fetch":     true,
+		"reset":     true,
+		"ls-tree":   true,
+		"ls-files":  true,
+		"rev-parse": true,
+		"log":       true,
+		"ls-remote": true,
 		// 必要に応じて他の安全なgitコマンドを追加
 	}
 
@@ -57,10 +65,10 @@ func (g *cliGitManager) runGitCommand(ctx context.Context, dir string, repo enti
 	}
 
 	// GitリポジトリのURLを含むコマンドの場合の追加検証（clone時など）
-	if args[0] == "clone" && len(args) > 1 {
-		// URLはHTTPSのみを許可することを明示的に検証
-		if !strings.HasPrefix(args[1], "https://") {
-			return nil, fmt.Errorf("only https URLs are allowed for git operations")
+	if (args[0] == "clone" || args[0] == "ls-remote") && len(args) > 1 {
+		// URLはHTTPSのみを許可する（デプロイキーを設定したリポジトリに限りSSHも許可）
+		if err := validateCloneURL(args[1], repo); err != nil {
+			return nil, err
 		}
 	}
 
@@ -70,91 +78,221 @@ func (g *cliGitManager) runGitCommand(ctx context.Context, dir string, repo enti
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	// アクセストークンがある場合は、環境変数として設定
 	env := []string{
 		"PATH=" + os.Getenv("PATH"), // 最小限のPATH環境変数のみ設定
 		"HOME=" + os.Getenv("HOME"), // Gitはホームディレクトリを必要とすることがある
 		"GIT_TERMINAL_PROMPT=0",     // インタラクティブプロンプトを無効化
 	}
 
-	// リポジトリにアクセストークンがあれば、それを使用
-	if repo != nil && repo.AccessToken() != "" {
-		// URLにトークンを含める代わりに、Git認証情報ヘルパーを使用
-		credsEnv := fmt.Sprintf("GIT_ASKPASS=%s", createGitAskPassScript(repo.AccessToken()))
-		env = append(env, credsEnv)
+	// リポジトリの認証方式に応じた環境変数を設定（コマンド終了後に一時ファイルを削除）
+	authEnv, cleanup, err := g.authEnv(repo)
+	if err != nil {
+		return nil, err
 	}
+	defer cleanup()
+	cmd.Env = append(env, authEnv...)
 
-	cmd.Env = env
-
-	err := cmd.Run()
+	err = cmd.Run()
 	if err != nil {
 		return nil, fmt.Errorf("git command failed: %v\nArgs: %v\nStderr: %s\nError: %w", args[0], args, stderr.String(), err)
 	}
 	return stdout.Bytes(), nil
 }
 
-// createGitAskPassScript creates a temporary script that provides the access token to Git
-func createGitAskPassScript(token string) string {
-	// 一時ディレクトリにスクリプトを作成
-	tempDir := os.TempDir()
-	scriptPath := filepath.Join(tempDir, "git-askpass.sh")
+// validateCloneURL checks that a clone URL uses https, or SSH for repositories authenticated with a deploy key.
+func validateCloneURL(cloneURL string, repo entity.Repository) error {
+	if strings.HasPrefix(cloneURL, "https://") {
+		return nil
+	}
+	if repo != nil && repo.AuthMethod() == entity.AuthMethodDeployKey && IsSSHURL(cloneURL) {
+		if _, _, err := ParseRemoteURL(cloneURL); err != nil {
+			return err
+		}
+		return nil
+	}
+	return fmt.Errorf("only https URLs are allowed for git operations (ssh URLs require a deploy key)")
+}
 
-	// スクリプトの内容を作成
+// authEnv returns the environment variables that authenticate git as the repository.
+// The returned cleanup function removes any temporary credential files and must always be called.
+func (g *cliGitManager) authEnv(repo entity.Repository) ([]string, func(), error) {
+	noop := func() {}
+	if repo == nil {
+		return nil, noop, nil
+	}
+
+	switch repo.AuthMethod() {
+	case entity.AuthMethodDeployKey:
+		// 初回接続時のなりすましを防ぐため、ホスト鍵は設定されたknown_hostsでのみ検証する
+		if g.knownHostsFile == "" {
+			return nil, noop, fmt.Errorf("SSH known hosts are not configured; host keys of deploy key repositories cannot be verified")
+		}
+		// デプロイキーを一時ファイルに書き出し、そのキーだけを使うようにSSHを設定
+		keyPath, err := writeDeployKeyFile(repo.DeployKey())
+		if err != nil {
+			return nil, noop, err
+		}
+		sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes -o BatchMode=yes -o StrictHostKeyChecking=yes -o UserKnownHostsFile=%s",
+			shellQuote(keyPath), shellQuote(g.knownHostsFile))
+		return []string{"GIT_SSH_COMMAND=" + sshCommand}, func() { os.Remove(keyPath) }, nil
+	case entity.AuthMethodToken:
+		// URLにトークンを含める代わりに、GIT_ASKPASSでトークンを渡す（任意のHTTPSホストで動作）
+		scriptPath, err := createGitAskPassScript(askPassUsername(repo.Provider()), repo.AccessToken())
+		if err != nil {
+			return nil, noop, err
+		}
+		return []string{"GIT_ASKPASS=" + scriptPath}, func() { os.Remove(scriptPath) }, nil
+	}
+	return nil, noop, nil
+}
+
+// askPassUsername returns the username sent alongside an access token.
+// GitLab expects "oauth2"; GitHub and Gitea ignore the username when the password is a token.
+// Hosts that require a specific username can include it in the repository URL (https://user@host/...).
+func askPassUsername(provider entity.Provider) string {
+	if provider == entity.ProviderGitLab {
+		return "oauth2"
+	}
+	return "x-access-token"
+}
+
+// createGitAskPassScript creates a uniquely named temporary script that answers Git's
+// username and password prompts with the given username and access token.
+func createGitAskPassScript(username, token string) (string, error) {
 	scriptContent := fmt.Sprintf(`#!/bin/sh
-echo "%s"
-`, token)
+case "$1" in
+Username*) echo %s ;;
+*) echo %s ;;
+esac
+`, shellQuote(username), shellQuote(token))
+
+	// 同時実行時に他のリポジトリのトークンを上書きしないよう、一意なファイル名で作成
+	file, err := os.CreateTemp("", "git-askpass-*.sh")
+	if err != nil {
+		return "", fmt.Errorf("failed to create git askpass script: %w", err)
+	}
+	defer file.Close()
 
-	// 既存のファイルがあれば削除
-	os.Remove(scriptPath)
+	if _, err := file.WriteString(scriptContent); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write git askpass script: %w", err)
+	}
+	if err := file.Chmod(0700); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to make git askpass script executable: %w", err)
+	}
+	return file.Name(), nil
+}
 
-	// スクリプトをファイルに書き込み
-	err := os.WriteFile(scriptPath, []byte(scriptContent), 0700)
+// writeDeployKeyFile writes an SSH private key to a temporary file readable only by the current user.
+func writeDeployKeyFile(deployKey string) (string, error) {
+	if deployKey == "" {
+		return "", fmt.Errorf("deploy key is not set for this repository")
+	}
+	// OpenSSH rejects keys without a trailing newline
+	if !strings.HasSuffix(deployKey, "\n") {
+		deployKey += "\n"
+	}
+
+	file, err := os.CreateTemp("", "git-deploy-key-*")
 	if err != nil {
-		fmt.Printf("Warning: Failed to create Git askpass script: %v\n", err)
-		return ""
+		return "", fmt.Errorf("failed to create deploy key file: %w", err)
+	}
+	defer file.Close()
+
+	if err := file.Chmod(0600); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to restrict deploy key file permissions: %w", err)
+	}
+	if _, err := file.WriteString(deployKey); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("failed to write deploy key file: %w", err)
 	}
+	return file.Name(), nil
+}
 
-	return scriptPath
+// shellQuote quotes a value for use as a single word in a POSIX shell command.
+func shellQuote(value string) string {
+	return "'" + strings.ReplaceAll(value, "'", `'\''`) + "'"
 }
 
-// EnsureCloned clones or updates the repository.
+// EnsureCloned clones or updates the repository and checks out its tracked ref.
+// A new clone is made next to the local path and moved into place once it is checked out.
+// Concurrent calls for the same repository are serialized.
 func (g *cliGitManager) EnsureCloned(ctx context.Context, repo entity.Repository) (string, error) {
 	localPath := g.getLocalPath(repo)
+	unlock, err := lockLocalCopy(ctx, localPath)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
 
 	// Check if the directory exists
 	if _, err := os.Stat(localPath); os.IsNotExist(err) {
 		// Directory does not exist, clone the repository
-		fmt.Printf("Cloning repository %s to %s\n", repo.URL(), localPath)
-
-		// アクセストークンがある場合は、URL内に埋め込まずに認証に使用する
-		_, err := g.runGitCommand(ctx, g.baseClonePath, repo, "clone", repo.URL(), localPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to clone repository %s: %w", repo.URL(), err)
-		}
-	} else if err == nil {
-		// Directory exists, update the repository (fetch + reset or pull)
-		fmt.Printf("Updating repository %s in %s\n", repo.URL(), localPath)
-		// Using fetch + reset --hard to ensure clean state, adjust if needed
-		_, err := g.runGitCommand(ctx, localPath, repo, "fetch", "origin")
-		if err != nil {
-			return "", fmt.Errorf("failed to fetch repository %s: %w", repo.URL(), err)
+		logger.Info("Cloning repository", "url", repo.URL(), "path", localPath)
+		if err := g.cloneRepository(ctx, localPath, repo); err != nil {
+			return "", err
 		}
-		// Determine the default branch (e.g., main or master) or use HEAD
-		// For simplicity, using origin/HEAD which usually points to the default branch
-		_, err = g.runGitCommand(ctx, localPath, repo, "reset", "--hard", "origin/HEAD") // Adjust branch if necessary
-		if err != nil {
-			// Attempt pull as a fallback? Or just report error.
-			return "", fmt.Errorf("failed to reset repository %s: %w", repo.URL(), err)
-		}
-	} else {
+		return localPath, nil
+	} else if err != nil {
 		// Other error checking directory (permissions?)
 		return "", fmt.Errorf("failed to check repository directory %s: %w", localPath, err)
 	}
 
+	// Directory exists, update the repository (fetch + reset)
+	logger.Info("Updating repository", "url", repo.URL(), "path", localPath)
+	if err := g.checkoutTrackedRef(ctx, localPath, repo); err != nil {
+		return "", err
+	}
 	return localPath, nil
 }
 
-// ListRepositoryFiles lists all files tracked by git.
+// cloneRepository clones the repository into a staging directory, checks out its tracked ref
+// and then moves the clone to localPath.
+func (g *cliGitManager) cloneRepository(ctx context.Context, localPath string, repo entity.Repository) error {
+	stagingPath, err := stageLocalCopy(localPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingPath)
+
+	// アクセストークンがある場合は、URL内に埋め込まずに認証に使用する
+	if _, err := g.runGitCommand(ctx, g.baseClonePath, repo, "clone", repo.URL(), stagingPath); err != nil {
+		return fmt.Errorf("failed to clone repository %s: %w", repo.URL(), err)
+	}
+	// A fresh clone is already at the default branch
+	if repo.Ref() != "" {
+		if err := g.checkoutTrackedRef(ctx, stagingPath, repo); err != nil {
+			return err
+		}
+	}
+	return swapLocalCopy(stagingPath, localPath)
+}
+
+// checkoutTrackedRef fetches the repository's tracked branch or tag (the remote HEAD when none is pinned)
+// and resets the working tree to it. Using fetch + reset --hard ensures a clean state.
+// Readers keep using the current tree during the fetch; only the reset holds them off.
+func (g *cliGitManager) checkoutTrackedRef(ctx context.Context, localPath string, repo entity.Repository) error {
+	ref := trackedRef(repo)
+	// オプションとして解釈されるrefは拒否する
+	if strings.HasPrefix(ref, "-") {
+		return fmt.Errorf("invalid ref: %s", ref)
+	}
+
+	_, err := g.runGitCommand(ctx, localPath, repo, "fetch", "origin", ref)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s of repository %s: %w", ref, repo.URL(), err)
+	}
+	return changeLocalCopy(localPath, func() error {
+		if _, err := g.runGitCommand(ctx, localPath, repo, "reset", "--hard", "FETCH_HEAD"); err != nil {
+			return fmt.Errorf("failed to reset repository %s to %s: %w", repo.URL(), ref, err)
+		}
+		return nil
+	})
+}
+
+// ListRepositoryFiles lists the files tracked by git under the repository's root path.
 func (g *cliGitManager) ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error) {
 	output, err := g.runGitCommand(ctx, localPath, repo, "ls-tree", "-r", "--name-only", "HEAD")
 	if err != nil {
@@ -169,7 +307,57 @@ func (g *cliGitManager) ListRepositoryFiles(ctx context.Context, localPath strin
 			result = append(result, f)
 		}
 	}
-	return result, nil
+	return filterRootPath(repo, result), nil
+}
+
+// DescribeFiles returns the size of each file tracked under the repository's root path and the last commit
+// that touched it, from a single pass over the local clone's history.
+func (g *cliGitManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	// ls-tree -l prints "<mode> <type> <object> <size>\t<path>" for each entry
+	output, err := g.runGitCommand(ctx, localPath, repo, "ls-tree", "-r", "-l", "-z", "HEAD")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files in %s: %w", localPath, err)
+	}
+	var infos []FileInfo
+	index := make(map[string]int)
+	for _, entry := range strings.Split(string(output), "\x00") {
+		meta, filePath, ok := strings.Cut(entry, "\t")
+		fields := strings.Fields(meta)
+		if !ok || len(fields) != 4 || fields[1] != "blob" || !repo.ContainsPath(filePath) {
+			continue
+		}
+		size, err := strconv.ParseInt(fields[3], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid size of %s: %w", filePath, err)
+		}
+		index[filePath] = len(infos)
+		infos = append(infos, FileInfo{Path: filePath, Size: size})
+	}
+
+	// Walk the history newest first; the first commit listing a file is the last one that touched it
+	pathspec := "."
+	if repo.RootPath() != "" {
+		pathspec = repo.RootPath()
+	}
+	output, err = g.runGitCommand(ctx, localPath, repo, "log", "-z", "--name-only", "--format=%x1e%H%x1f%an%x1f%aI%x1f%s", "HEAD", "--", pathspec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read history of %s: %w", localPath, err)
+	}
+	for _, record := range strings.Split(string(output), "\x1e") {
+		header, names, ok := strings.Cut(record, "\x00")
+		fields := strings.Split(header, "\x1f")
+		if !ok || len(fields) != 4 {
+			continue
+		}
+		date, _ := time.Parse(time.RFC3339, fields[2])
+		commit := CommitInfo{SHA: fields[0], Author: fields[1], Date: date, Message: fields[3]}
+		for _, name := range strings.Split(strings.TrimPrefix(names, "\n"), "\x00") {
+			if i, tracked := index[name]; tracked && infos[i].LastCommit.SHA == "" {
+				infos[i].LastCommit = commit
+			}
+		}
+	}
+	return infos, nil
 }
 
 // ValidateFilesExist checks if files exist in the git repository index.
@@ -177,6 +365,11 @@ func (g *cliGitManager) ValidateFilesExist(ctx context.Context, localPath string
 	if len(filePaths) == 0 {
 		return nil // Nothing to validate
 	}
+	for _, filePath := range filePaths {
+		if err := checkRootPath(repo, filePath); err != nil {
+			return err
+		}
+	}
 	args := append([]string{"ls-files", "--error-unmatch", "--"}, filePaths...)
 	_, err := g.runGitCommand(ctx, localPath, repo, args...)
 	if err != nil {
@@ -228,3 +421,62 @@ func (g *cliGitManager) ReadManagedFileContent(ctx context.Context, localPath st
 	}
 	return content, nil
 }
+
+// LocalPath returns the directory the repository is cloned into.
+func (g *cliGitManager) LocalPath(repo entity.Repository) string {
+	return g.getLocalPath(repo)
+}
+
+// RemoveLocalCopy deletes the local copy and its sync state.
+func (g *cliGitManager) RemoveLocalCopy(repo entity.Repository) error {
+	localPath := g.getLocalPath(repo)
+	unlock, err := lockLocalCopy(context.Background(), localPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return removeLocalCopy(localPath)
+}
+
+// Forget deletes the local copy and its sync state; no clients are cached per repository.
+func (g *cliGitManager) Forget(repo entity.Repository) error {
+	return g.RemoveLocalCopy(repo)
+}
+
+// VerifyAccess lists the remote HEAD with the repository's credentials. Git does not report scopes or
+// expiry dates, so a token or key that can read the repository is valid with no known expiry.
+func (g *cliGitManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	if _, err := g.runGitCommand(ctx, g.baseClonePath, repo, "ls-remote", repo.URL(), "HEAD"); err != nil {
+		if ctx.Err() != nil {
+			return AccessCheck{}, ctx.Err()
+		}
+		return rejectedAccess(accessReasonInaccessible), nil
+	}
+	return AccessCheck{Valid: true}, nil
+}
+
+// ResolveHeadCommit returns the commit checked out in the local clone.
+func (g *cliGitManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	output, err := g.runGitCommand(ctx, localPath, repo, "rev-parse", "HEAD")
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve HEAD in %s: %w", localPath, err)
+	}
+	return strings.TrimSpace(string(output)), nil
+}
+
+// ResolveFileCommit returns the last commit that touched the file, from the local clone's history.
+func (g *cliGitManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	if strings.Contains(filePath, "..") {
+		return "", fmt.Errorf("invalid file path containing path traversal sequences: %s", filePath)
+	}
+
+	output, err := g.runGitCommand(ctx, localPath, repo, "log", "-1", "--format=%H", "HEAD", "--", filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to resolve last commit of %s: %w", filePath, err)
+	}
+	commit := strings.TrimSpace(string(output))
+	if commit == "" {
+		return "", fmt.Errorf("file does not exist in repository history: %s", filePath)
+	}
+	return commit, nil
+}
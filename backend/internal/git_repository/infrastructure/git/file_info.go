@@ -0,0 +1,132 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+// maxConcurrentCommitLookups bounds the API requests made at once to resolve the last commits of files.
+const maxConcurrentCommitLookups = 8
+
+// CommitInfo describes a commit.
+type CommitInfo struct {
+	SHA     string    `json:"sha"`
+	Author  string    `json:"author,omitempty"`
+	Date    time.Time `json:"date"`
+	Message string    `json:"message,omitempty"` // Subject line of the commit message
+}
+
+// FileInfo describes a file of a local copy.
+type FileInfo struct {
+	Path       string     // Path relative to the repository root
+	Size       int64      // Size in bytes
+	LastCommit CommitInfo // Last commit up to the local copy's commit that touched the file
+}
+
+// commitSubject returns the first line of a commit message.
+func commitSubject(message string) string {
+	subject, _, _ := strings.Cut(message, "\n")
+	return strings.TrimSpace(subject)
+}
+
+// commitLookup resolves the last commit up to headSHA that touched a file through a provider API.
+type commitLookup func(ctx context.Context, headSHA, filePath string) (CommitInfo, error)
+
+// describeFilesFromAPI describes the files of a local copy downloaded through a provider API.
+// Sizes come from the local files; last commits are resolved with lookup and cached in the sync state
+// together with the blob SHA of each file. A cached commit is reused for as long as the file's content is
+// unchanged, so only the files changed by a sync call the API again. Like the import history of local
+// sources, a file that was changed and changed back keeps the commit of its earlier content.
+func describeFilesFromAPI(ctx context.Context, localPath, headSHA string, files []string, lookup commitLookup) ([]FileInfo, error) {
+	statePath := syncStatePath(localPath)
+	state := loadSyncState(statePath)
+
+	infos := make([]FileInfo, len(files))
+	blobs := make([]string, len(files))
+	var missing []int
+	for i, filePath := range files {
+		fullPath, err := resolveRepositoryPath(localPath, filePath)
+		if err != nil {
+			return nil, err
+		}
+		stat, err := os.Stat(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file %s: %w", filePath, err)
+		}
+		blob, err := gitBlobSHA(fullPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash file %s: %w", filePath, err)
+		}
+		infos[i] = FileInfo{Path: filePath, Size: stat.Size()}
+		blobs[i] = blob
+		if commit, ok := state.FileCommits[filePath]; ok && state.FileBlobs[filePath] == blob {
+			infos[i].LastCommit = commit
+		} else {
+			missing = append(missing, i)
+		}
+	}
+	if len(missing) == 0 {
+		return infos, nil
+	}
+
+	// Resolve the uncached commits concurrently; the first error cancels the remaining lookups
+	lookupCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	var wg sync.WaitGroup
+	var once sync.Once
+	var lookupErr error
+	slots := make(chan struct{}, maxConcurrentCommitLookups)
+	for _, i := range missing {
+		select {
+		case slots <- struct{}{}:
+		case <-lookupCtx.Done():
+		}
+		if lookupCtx.Err() != nil {
+			break
+		}
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			defer func() { <-slots }()
+			commit, err := lookup(lookupCtx, headSHA, infos[i].Path)
+			if err != nil {
+				once.Do(func() {
+					lookupErr = err
+					cancel()
+				})
+				return
+			}
+			infos[i].LastCommit = commit
+		}(i)
+	}
+	wg.Wait()
+	if lookupErr != nil {
+		return nil, lookupErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	// The local copy may have been synced to another commit meanwhile; its commits are not merged then.
+	// Files that no longer exist are dropped from the cache.
+	err := updateSyncState(statePath, func(current *syncState) bool {
+		if current.CommitSHA != headSHA {
+			return false
+		}
+		current.FileCommits = make(map[string]CommitInfo, len(infos))
+		current.FileBlobs = make(map[string]string, len(infos))
+		for i, info := range infos {
+			current.FileCommits[info.Path] = info.LastCommit
+			current.FileBlobs[info.Path] = blobs[i]
+		}
+		return true
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return infos, nil
+}
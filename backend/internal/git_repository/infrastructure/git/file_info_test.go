@@ -0,0 +1,86 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeLocalFiles writes files into a local copy.
+func writeLocalFiles(t *testing.T, localPath string, files map[string]string) {
+	t.Helper()
+	for filePath, content := range files {
+		fullPath := filepath.Join(localPath, filePath)
+		require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+		require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+	}
+}
+
+// TestDescribeFilesFromAPI tests describing files whose last commits are resolved through a provider API
+func TestDescribeFilesFromAPI(t *testing.T) {
+	// テスト：同期後も内容が変わっていないファイルはキャッシュしたコミットを引き継ぐことを確認する
+	t.Run("同期後も内容が変わっていないファイルはキャッシュしたコミットを引き継ぐ", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		writeLocalFiles(t, localPath, map[string]string{"docs/deploy.md": "# Deploy", "docs/rollback.md": "# Rollback"})
+		statePath := syncStatePath(localPath)
+		require.NoError(t, saveSyncState(statePath, syncState{CommitSHA: "commit-1"}))
+
+		var mu sync.Mutex
+		var looked []string
+		lookup := func(ctx context.Context, headSHA, filePath string) (CommitInfo, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			looked = append(looked, filePath)
+			return CommitInfo{SHA: headSHA}, nil
+		}
+		files := []string{"docs/deploy.md", "docs/rollback.md"}
+		_, err := describeFilesFromAPI(context.Background(), localPath, "commit-1", files, lookup)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, files, looked)
+
+		// A sync to the next commit changes one of the files
+		writeLocalFiles(t, localPath, map[string]string{"docs/deploy.md": "# Deploy v2"})
+		require.NoError(t, saveSyncState(statePath, syncState{CommitSHA: "commit-2"}.withFileCommits(loadSyncState(statePath))))
+		looked = nil
+
+		infos, err := describeFilesFromAPI(context.Background(), localPath, "commit-2", files, lookup)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"docs/deploy.md"}, looked)
+		assert.Equal(t, "commit-2", infos[0].LastCommit.SHA)
+		assert.Equal(t, "commit-1", infos[1].LastCommit.SHA)
+	})
+
+	// テスト：問い合わせが失敗すると残りの問い合わせが取り消されることを確認する
+	t.Run("問い合わせが失敗すると残りの問い合わせが取り消される", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		var files []string
+		for i := 0; i < maxConcurrentCommitLookups*4; i++ {
+			filePath := fmt.Sprintf("docs/%02d.md", i)
+			files = append(files, filePath)
+			writeLocalFiles(t, localPath, map[string]string{filePath: filePath})
+		}
+
+		var calls atomic.Int32
+		lookupErr := errors.New("rate limit exceeded")
+		lookup := func(ctx context.Context, headSHA, filePath string) (CommitInfo, error) {
+			if calls.Add(1) == 1 {
+				return CommitInfo{}, lookupErr
+			}
+			// The remaining lookups only finish once they are cancelled
+			<-ctx.Done()
+			return CommitInfo{}, ctx.Err()
+		}
+
+		_, err := describeFilesFromAPI(context.Background(), localPath, "commit-1", files, lookup)
+		assert.ErrorIs(t, err, lookupErr)
+		assert.LessOrEqual(t, int(calls.Load()), maxConcurrentCommitLookups, "no lookup should start after the failure")
+	})
+}
@@ -8,13 +8,30 @@ import (
 // GitManager defines the interface for interacting with Git repositories.
 type GitManager interface {
 	// EnsureCloned clones the repository if it's not already present locally
-	// or updates it if it exists. Returns the local path to the repository.
+	// or updates it if it exists, checking out the repository's tracked ref. Returns the local path to the repository.
 	EnsureCloned(ctx context.Context, repo entity.Repository) (string, error)
-	// ListRepositoryFiles lists all files in the repository at the HEAD commit.
+	// ListRepositoryFiles lists the files under the repository's root path at the HEAD commit.
 	// Returns a list of file paths relative to the repository root.
 	ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error)
-	// ValidateFilesExist checks if the given file paths exist in the repository.
+	// DescribeFiles returns the size and last commit of each file under the repository's root path.
+	DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error)
+	// ValidateFilesExist checks if the given file paths exist in the repository under its root path.
 	ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error
 	// ReadManagedFileContent reads the content of a specific file from the local repository.
 	ReadManagedFileContent(ctx context.Context, localPath string, filePath string, repo entity.Repository) ([]byte, error)
+	// ResolveHeadCommit returns the SHA of the commit the local copy is at.
+	ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error)
+	// ResolveFileCommit returns the SHA of the last commit up to the local copy's commit that touched the file.
+	ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error)
+	// LocalPath returns the directory EnsureCloned places the repository in, whether or not it exists yet.
+	LocalPath(repo entity.Repository) string
+	// RemoveLocalCopy deletes the local copy and any sync state, so the next EnsureCloned fetches it from scratch.
+	RemoveLocalCopy(repo entity.Repository) error
+	// Forget deletes everything kept for a repository that is no longer registered:
+	// its local copy, sync state and any cached API client.
+	Forget(repo entity.Repository) error
+	// VerifyAccess checks with the provider that the repository's credentials are accepted, grant the needed
+	// scopes and can read the repository, and reports when they expire. Rejected credentials are reported
+	// in the result; an error means the check could not be completed, e.g. because the provider is unreachable.
+	VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error)
 }
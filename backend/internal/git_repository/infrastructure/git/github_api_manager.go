@@ -2,12 +2,15 @@ package git
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
 	"opscore/backend/internal/git_repository/domain/entity"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 
 	"github.com/google/go-github/v60/github"
 	"golang.org/x/oauth2"
@@ -15,21 +18,30 @@ import (
 
 // GitHubAPIManager implements the GitManager interface using the GitHub API.
 type githubApiManager struct {
-	baseClonePath string                    // Base directory where repositories will be stored locally
-	clients       map[string]*github.Client // Cache of GitHub clients by token
+	baseClonePath      string                    // Base directory where repositories will be stored locally
+	clientsMu          sync.Mutex                // Guards clients and repoTokens
+	clients            map[string]*github.Client // Cache of GitHub clients by token
+	repoTokens         map[string]string         // Token each repository's client was last requested with, by repository ID
+	apiBaseURL         *url.URL                  // Overrides the GitHub API endpoint; nil uses api.github.com
+	apps               GitHubAppFinder           // Looks up the GitHub Apps of repositories authenticated with an installation
+	installationTokens *installationTokenCache   // Installation tokens minted for those repositories
 }
 
 // NewGithubApiManager creates a new githubApiManager.
 // baseClonePath is the directory where repositories will be stored locally.
-func NewGithubApiManager(baseClonePath string) (GitManager, error) {
+// apps is used to authenticate repositories registered with a GitHub App installation; it may be nil if there are none.
+func NewGithubApiManager(baseClonePath string, apps GitHubAppFinder) (GitManager, error) {
 	// Ensure the base clone path exists
 	err := os.MkdirAll(baseClonePath, 0755)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create base clone directory '%s': %w", baseClonePath, err)
 	}
 	return &githubApiManager{
-		baseClonePath: baseClonePath,
-		clients:       make(map[string]*github.Client),
+		baseClonePath:      baseClonePath,
+		clients:            make(map[string]*github.Client),
+		repoTokens:         make(map[string]string),
+		apps:               apps,
+		installationTokens: &installationTokenCache{tokens: make(map[entity.GitHubAppInstallation]installationToken)},
 	}, nil
 }
 
@@ -41,12 +53,46 @@ func (g *githubApiManager) getLocalPath(repo entity.Repository) string {
 
 // getGitHubClient returns a GitHub API client, authenticated if a token is provided.
 func (g *githubApiManager) getGitHubClient(accessToken string) *github.Client {
+	g.clientsMu.Lock()
+	defer g.clientsMu.Unlock()
+
 	// Check if we have a cached client for this token
 	if client, ok := g.clients[accessToken]; ok {
 		return client
 	}
 
-	// Create a new client
+	client := g.newGitHubClient(accessToken)
+
+	// Cache the client for future use
+	if accessToken != "" {
+		g.clients[accessToken] = client
+	}
+
+	return client
+}
+
+// repositoryClient returns the GitHub API client for a repository's token. When the repository's token changed
+// since its client was last requested, the client of the previous token is evicted from the cache.
+func (g *githubApiManager) repositoryClient(repoID, accessToken string) *github.Client {
+	g.clientsMu.Lock()
+	if previous, ok := g.repoTokens[repoID]; ok && previous != accessToken {
+		delete(g.clients, previous)
+	}
+	g.repoTokens[repoID] = accessToken
+	g.clientsMu.Unlock()
+
+	return g.getGitHubClient(accessToken)
+}
+
+// evictClient drops the cached client of a token that is no longer used.
+func (g *githubApiManager) evictClient(accessToken string) {
+	g.clientsMu.Lock()
+	defer g.clientsMu.Unlock()
+	delete(g.clients, accessToken)
+}
+
+// newGitHubClient creates a GitHub API client that sends the token as a bearer token, if one is provided.
+func (g *githubApiManager) newGitHubClient(accessToken string) *github.Client {
 	var httpClient *http.Client
 	if accessToken != "" {
 		// Create an authenticated client if we have a token
@@ -57,15 +103,25 @@ func (g *githubApiManager) getGitHubClient(accessToken string) *github.Client {
 	}
 
 	client := github.NewClient(httpClient)
-
-	// Cache the client for future use
-	if accessToken != "" {
-		g.clients[accessToken] = client
+	if g.apiBaseURL != nil {
+		client.BaseURL = g.apiBaseURL
 	}
-
 	return client
 }
 
+// clientFor returns a GitHub API client authenticated the way the repository is configured:
+// with an installation token of its GitHub App, or with its access token.
+func (g *githubApiManager) clientFor(ctx context.Context, repo entity.Repository) (*github.Client, error) {
+	if repo.AuthMethod() != entity.AuthMethodGitHubApp {
+		return g.repositoryClient(repo.ID(), repo.AccessToken()), nil
+	}
+	token, err := g.installationToken(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return g.repositoryClient(repo.ID(), token), nil
+}
+
 // parseGitHubURL extracts owner and repo name from a GitHub URL.
 func parseGitHubURL(repoURL string) (string, string, error) {
 	// Handle URLs like https://github.com/owner/repo.git or https://github.com/owner/repo
@@ -81,8 +137,15 @@ func parseGitHubURL(repoURL string) (string, string, error) {
 }
 
 // EnsureCloned ensures the repository is available locally, either by cloning it or updating an existing clone.
+// Only files whose content changed since the last sync are downloaded.
+// Concurrent calls for the same repository are serialized.
 func (g *githubApiManager) EnsureCloned(ctx context.Context, repo entity.Repository) (string, error) {
 	localPath := g.getLocalPath(repo)
+	unlock, err := lockLocalCopy(ctx, localPath)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
 
 	// Extract owner and repo name from URL
 	owner, repoName, err := parseGitHubURL(repo.URL())
@@ -91,44 +154,20 @@ func (g *githubApiManager) EnsureCloned(ctx context.Context, repo entity.Reposit
 	}
 
 	// Get GitHub client
-	client := g.getGitHubClient(repo.AccessToken())
+	client, err := g.clientFor(ctx, repo)
+	if err != nil {
+		return "", err
+	}
 
 	// Check if the directory exists
 	if _, err := os.Stat(localPath); os.IsNotExist(err) {
-		// Directory does not exist, clone the repository
-		fmt.Printf("Cloning repository %s to %s\n", repo.URL(), localPath)
-
-		// Create the directory
-		if err := os.MkdirAll(localPath, 0755); err != nil {
-			return "", fmt.Errorf("failed to create directory for repository: %w", err)
-		}
-
-		// Download the default branch content
-		err = g.downloadRepository(ctx, client, owner, repoName, "", localPath)
-		if err != nil {
+		logger.Info("Cloning repository", "url", repo.URL(), "path", localPath)
+		if err := g.syncRepository(ctx, client, owner, repoName, localPath, repo); err != nil {
 			return "", fmt.Errorf("failed to clone repository %s: %w", repo.URL(), err)
 		}
 	} else if err == nil {
-		// Directory exists, update the repository
-		fmt.Printf("Updating repository %s in %s\n", repo.URL(), localPath)
-
-		// Clear the directory and re-download
-		files, err := os.ReadDir(localPath)
-		if err != nil {
-			return "", fmt.Errorf("failed to read repository directory: %w", err)
-		}
-
-		// Only remove files and directories inside the local path, not the directory itself
-		for _, file := range files {
-			path := filepath.Join(localPath, file.Name())
-			if err := os.RemoveAll(path); err != nil {
-				return "", fmt.Errorf("failed to remove old files: %w", err)
-			}
-		}
-
-		// Download the repository content again
-		err = g.downloadRepository(ctx, client, owner, repoName, "", localPath)
-		if err != nil {
+		logger.Info("Updating repository", "url", repo.URL(), "path", localPath)
+		if err := g.syncRepository(ctx, client, owner, repoName, localPath, repo); err != nil {
 			return "", fmt.Errorf("failed to update repository %s: %w", repo.URL(), err)
 		}
 	} else {
@@ -139,58 +178,7 @@ func (g *githubApiManager) EnsureCloned(ctx context.Context, repo entity.Reposit
 	return localPath, nil
 }
 
-// downloadRepository recursively downloads repository content from GitHub.
-func (g *githubApiManager) downloadRepository(ctx context.Context, client *github.Client, owner string, repo string, path string, localPath string) error {
-	// List files and directories at the current path
-	_, contents, _, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{})
-	if err != nil {
-		return fmt.Errorf("failed to get repository contents: %w", err)
-	}
-
-	for _, content := range contents {
-		localFilePath := filepath.Join(localPath, *content.Path)
-
-		switch *content.Type {
-		case "file":
-			// Ensure directory exists
-			dir := filepath.Dir(localFilePath)
-			if err := os.MkdirAll(dir, 0755); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", dir, err)
-			}
-
-			fileContentStr, err := content.GetContent()
-			if err != nil {
-				fileContent, _, _, fetchErr := client.Repositories.GetContents(ctx, owner, repo, *content.Path, &github.RepositoryContentGetOptions{})
-				if fetchErr != nil {
-					return fmt.Errorf("failed to get file content for %s: %w", *content.Path, fetchErr)
-				}
-
-				fileContentStr, err = fileContent.GetContent()
-				if err != nil {
-					return fmt.Errorf("failed to decode content for %s: %w", *content.Path, err)
-				}
-			}
-
-			if err := os.WriteFile(localFilePath, []byte(fileContentStr), 0644); err != nil {
-				return fmt.Errorf("failed to write file %s: %w", localFilePath, err)
-			}
-
-		case "dir":
-			// Create directory and recursively download its contents
-			if err := os.MkdirAll(localFilePath, 0755); err != nil {
-				return fmt.Errorf("failed to create directory %s: %w", localFilePath, err)
-			}
-
-			if err := g.downloadRepository(ctx, client, owner, repo, *content.Path, localPath); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
-}
-
-// ListRepositoryFiles lists all files in the repository.
+// ListRepositoryFiles lists the files under the repository's root path.
 func (g *githubApiManager) ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error) {
 	// Get the file list from the local clone
 	var files []string
@@ -216,7 +204,7 @@ func (g *githubApiManager) ListRepositoryFiles(ctx context.Context, localPath st
 
 	if err != nil {
 		// If walking the local directory fails, try to get files directly from the API
-		fmt.Printf("Failed to walk local directory, falling back to API: %v\n", err)
+		logger.Warn("Failed to walk local directory, falling back to API", "path", localPath, "error", err)
 
 		// Extract owner and repo name from URL
 		owner, repoName, err := parseGitHubURL(repo.URL())
@@ -225,43 +213,63 @@ func (g *githubApiManager) ListRepositoryFiles(ctx context.Context, localPath st
 		}
 
 		// Get GitHub client
-		client := g.getGitHubClient(repo.AccessToken())
+		client, err := g.clientFor(ctx, repo)
+		if err != nil {
+			return nil, err
+		}
 
-		files, err = g.listFilesFromAPI(ctx, client, owner, repoName, "")
+		files, err = g.listFilesFromAPI(ctx, client, owner, repoName, trackedRef(repo))
 		if err != nil {
 			return nil, fmt.Errorf("failed to list files in repository: %w", err)
 		}
 	}
 
-	return files, nil
+	return filterRootPath(repo, files), nil
 }
 
-// listFilesFromAPI recursively lists files from the GitHub API.
-func (g *githubApiManager) listFilesFromAPI(ctx context.Context, client *github.Client, owner string, repo string, path string) ([]string, error) {
-	var files []string
-
-	// List files and directories at the current path
-	_, contents, _, err := client.Repositories.GetContents(ctx, owner, repo, path, &github.RepositoryContentGetOptions{})
+// listFilesFromAPI lists the files at the head of a branch or tag from the GitHub API.
+func (g *githubApiManager) listFilesFromAPI(ctx context.Context, client *github.Client, owner string, repo string, ref string) ([]string, error) {
+	commitSHA, _, _, err := fetchHeadCommitSHA(ctx, client, owner, repo, ref, "")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get repository contents: %w", err)
+		return nil, err
 	}
 
-	for _, content := range contents {
-		if *content.Type == "file" {
-			files = append(files, *content.Path)
-		} else if *content.Type == "dir" {
-			// Recursively get files from subdirectory
-			subFiles, err := g.listFilesFromAPI(ctx, client, owner, repo, *content.Path)
-			if err != nil {
-				return nil, err
-			}
-			files = append(files, subFiles...)
-		}
+	entries, err := fetchTreeFiles(ctx, client, owner, repo, commitSHA)
+	if err != nil {
+		return nil, err
 	}
 
+	files := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		files = append(files, entry.Path)
+	}
 	return files, nil
 }
 
+// DescribeFiles returns the size of each file under the repository's root path and the last commit that touched it.
+func (g *githubApiManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	files, err := g.ListRepositoryFiles(ctx, localPath, repo)
+	if err != nil {
+		return nil, err
+	}
+	headSHA, err := g.ResolveHeadCommit(ctx, localPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	owner, repoName, err := parseGitHubURL(repo.URL())
+	if err != nil {
+		return nil, err
+	}
+	client, err := g.clientFor(ctx, repo)
+	if err != nil {
+		return nil, err
+	}
+	return describeFilesFromAPI(ctx, localPath, headSHA, files, func(ctx context.Context, headSHA, filePath string) (CommitInfo, error) {
+		return fetchFileCommit(ctx, client, owner, repoName, headSHA, filePath)
+	})
+}
+
 // ValidateFilesExist checks if files exist in the repository.
 func (g *githubApiManager) ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error {
 	if len(filePaths) == 0 {
@@ -270,6 +278,9 @@ func (g *githubApiManager) ValidateFilesExist(ctx context.Context, localPath str
 
 	// Check if files exist in the local clone
 	for _, filePath := range filePaths {
+		if err := checkRootPath(repo, filePath); err != nil {
+			return err
+		}
 		fullPath := filepath.Join(localPath, filePath)
 		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
 			return fmt.Errorf("file does not exist in repository: %s", filePath)
@@ -287,6 +298,9 @@ func (g *githubApiManager) ReadManagedFileContent(ctx context.Context, localPath
 	if strings.Contains(filePath, "..") {
 		return nil, fmt.Errorf("invalid file path containing path traversal sequences: %s", filePath)
 	}
+	if err := checkRootPath(repo, filePath); err != nil {
+		return nil, err
+	}
 
 	// Join the local repository path with the requested file path
 	fullPath := filepath.Join(localPath, filePath)
@@ -310,8 +324,11 @@ func (g *githubApiManager) ReadManagedFileContent(ctx context.Context, localPath
 			}
 
 			// Get file content via GitHub API
-			client := g.getGitHubClient(repo.AccessToken())
-			fileContent, _, _, err := client.Repositories.GetContents(ctx, owner, repoName, filePath, &github.RepositoryContentGetOptions{})
+			client, err := g.clientFor(ctx, repo)
+			if err != nil {
+				return nil, err
+			}
+			fileContent, _, _, err := client.Repositories.GetContents(ctx, owner, repoName, filePath, &github.RepositoryContentGetOptions{Ref: repo.Ref()})
 			if err != nil {
 				return nil, fmt.Errorf("failed to get file content from API: %w", err)
 			}
@@ -328,3 +345,152 @@ func (g *githubApiManager) ReadManagedFileContent(ctx context.Context, localPath
 
 	return content, nil
 }
+
+// LocalPath returns the directory the repository is cloned into.
+func (g *githubApiManager) LocalPath(repo entity.Repository) string {
+	return g.getLocalPath(repo)
+}
+
+// RemoveLocalCopy deletes the local copy and its sync state.
+func (g *githubApiManager) RemoveLocalCopy(repo entity.Repository) error {
+	localPath := g.getLocalPath(repo)
+	unlock, err := lockLocalCopy(context.Background(), localPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return removeLocalCopy(localPath)
+}
+
+// Forget deletes the local copy and its sync state and drops the client cached for the repository's token.
+func (g *githubApiManager) Forget(repo entity.Repository) error {
+	g.clientsMu.Lock()
+	delete(g.clients, repo.AccessToken())
+	if token, ok := g.repoTokens[repo.ID()]; ok {
+		delete(g.clients, token)
+		delete(g.repoTokens, repo.ID())
+	}
+	g.clientsMu.Unlock()
+	return g.RemoveLocalCopy(repo)
+}
+
+// VerifyAccess fetches the repository with its token. Classic tokens report their scopes in the X-OAuth-Scopes
+// header, and tokens with an expiry report it in the GitHub-Authentication-Token-Expiration header.
+func (g *githubApiManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	owner, repoName, err := parseGitHubURL(repo.URL())
+	if err != nil {
+		return AccessCheck{}, err
+	}
+
+	client, err := g.clientFor(ctx, repo)
+	if err != nil {
+		return AccessCheck{}, err
+	}
+	ghRepo, resp, err := client.Repositories.Get(ctx, owner, repoName)
+	if err != nil {
+		var rateLimitErr *github.RateLimitError
+		if resp == nil || errors.As(err, &rateLimitErr) {
+			return AccessCheck{}, fmt.Errorf("failed to verify access to repository %s: %w", repo.URL(), err)
+		}
+		switch resp.StatusCode {
+		case http.StatusUnauthorized:
+			return rejectedAccess(accessReasonRejected), nil
+		case http.StatusForbidden, http.StatusNotFound:
+			return rejectedAccess(accessReasonInaccessible), nil
+		}
+		return AccessCheck{}, fmt.Errorf("failed to verify access to repository %s: %w", repo.URL(), err)
+	}
+
+	if scopes := resp.Header.Values(githubScopesHeader); len(scopes) > 0 && ghRepo.GetPrivate() && !hasGitHubRepoScope(scopes) {
+		return rejectedAccess("the token lacks the repo scope"), nil
+	}
+	if permissions := ghRepo.GetPermissions(); permissions != nil && !permissions["pull"] {
+		return rejectedAccess("the token cannot read the repository contents"), nil
+	}
+
+	check := AccessCheck{Valid: true}
+	// Installation tokens are renewed automatically, so their expiry is not reported
+	if !resp.TokenExpiration.IsZero() && repo.AuthMethod() != entity.AuthMethodGitHubApp {
+		expiresAt := resp.TokenExpiration.Time
+		check.ExpiresAt = &expiresAt
+	}
+	return check, nil
+}
+
+// githubScopesHeader lists the OAuth scopes of a classic personal access token.
+const githubScopesHeader = "X-OAuth-Scopes"
+
+// hasGitHubRepoScope reports whether the comma-separated scopes grant access to private repositories.
+func hasGitHubRepoScope(headerValues []string) bool {
+	for _, value := range headerValues {
+		for _, scope := range strings.Split(value, ",") {
+			if strings.TrimSpace(scope) == "repo" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// ResolveHeadCommit returns the commit the local copy was last synced to,
+// or the head of the tracked ref if the repository has not been synced yet.
+func (g *githubApiManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	if state := loadSyncState(syncStatePath(localPath)); state.CommitSHA != "" && state.tracks(repo) {
+		return state.CommitSHA, nil
+	}
+
+	owner, repoName, err := parseGitHubURL(repo.URL())
+	if err != nil {
+		return "", err
+	}
+	client, err := g.clientFor(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+	commitSHA, _, _, err := fetchHeadCommitSHA(ctx, client, owner, repoName, trackedRef(repo), "")
+	return commitSHA, err
+}
+
+// ResolveFileCommit returns the last commit up to the synced commit that touched the file.
+func (g *githubApiManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	headSHA, err := g.ResolveHeadCommit(ctx, localPath, repo)
+	if err != nil {
+		return "", err
+	}
+
+	owner, repoName, err := parseGitHubURL(repo.URL())
+	if err != nil {
+		return "", err
+	}
+	client, err := g.clientFor(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+	commit, err := fetchFileCommit(ctx, client, owner, repoName, headSHA, filePath)
+	if err != nil {
+		return "", err
+	}
+	return commit.SHA, nil
+}
+
+// fetchFileCommit returns the last commit up to headSHA that touched the file.
+func fetchFileCommit(ctx context.Context, client *github.Client, owner, repo, headSHA, filePath string) (CommitInfo, error) {
+	commits, _, err := client.Repositories.ListCommits(ctx, owner, repo, &github.CommitsListOptions{
+		SHA:         headSHA,
+		Path:        filePath,
+		ListOptions: github.ListOptions{PerPage: 1},
+	})
+	if err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to list commits of %s: %w", filePath, err)
+	}
+	if len(commits) == 0 {
+		return CommitInfo{}, fmt.Errorf("file does not exist in repository history: %s", filePath)
+	}
+	author := commits[0].GetCommit().GetAuthor()
+	return CommitInfo{
+		SHA:     commits[0].GetSHA(),
+		Author:  author.GetName(),
+		Date:    author.GetDate().Time,
+		Message: commitSubject(commits[0].GetCommit().GetMessage()),
+	}, nil
+}
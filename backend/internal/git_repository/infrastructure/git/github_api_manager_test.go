@@ -2,11 +2,17 @@ package git
 
 import (
 	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
 	"os"
 	"opscore/backend/internal/git_repository/domain/entity"
 	"path/filepath"
+	"sync"
 	"testing"
+	"time"
 
+	"github.com/google/go-github/v60/github"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -22,7 +28,7 @@ func TestNewGithubApiManager(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-api-manager")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, manager)
@@ -41,7 +47,7 @@ func TestNewGithubApiManager(t *testing.T) {
 		err := os.MkdirAll(tmpDir, 0755)
 		require.NoError(t, err)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 
 		assert.NoError(t, err)
 		assert.NotNil(t, manager)
@@ -51,7 +57,7 @@ func TestNewGithubApiManager(t *testing.T) {
 		// 無効なパス（書き込み権限のないパスをシミュレート）
 		invalidPath := "/root/invalid-test-path"
 
-		_, err := NewGithubApiManager(invalidPath)
+		_, err := NewGithubApiManager(invalidPath, nil)
 
 		assert.Error(t, err)
 	})
@@ -60,7 +66,7 @@ func TestNewGithubApiManager(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-api-cache")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 
 		githubManager := manager.(*githubApiManager)
@@ -75,11 +81,11 @@ func TestGetLocalPathGitHub(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-local-path")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 
 		githubManager := manager.(*githubApiManager)
-		repo := entity.NewRepository("test-repo-id", "test-repo", "https://github.com/example/test", "")
+		repo := entity.NewRepository("test-repo-id", "test-repo", "https://github.com/example/test", entity.ProviderGitHub, "")
 
 		localPath := githubManager.getLocalPath(repo)
 
@@ -163,7 +169,7 @@ func TestGetGitHubClient(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-client-1")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 		githubManager := manager.(*githubApiManager)
 
@@ -176,7 +182,7 @@ func TestGetGitHubClient(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-client-2")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 		githubManager := manager.(*githubApiManager)
 
@@ -195,7 +201,7 @@ func TestGetGitHubClient(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-client-3")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 		githubManager := manager.(*githubApiManager)
 
@@ -210,7 +216,7 @@ func TestGetGitHubClient(t *testing.T) {
 		tmpDir := filepath.Join(os.TempDir(), "test-github-client-4")
 		defer os.RemoveAll(tmpDir)
 
-		manager, err := NewGithubApiManager(tmpDir)
+		manager, err := NewGithubApiManager(tmpDir, nil)
 		require.NoError(t, err)
 		githubManager := manager.(*githubApiManager)
 
@@ -223,6 +229,70 @@ func TestGetGitHubClient(t *testing.T) {
 		assert.NotEqual(t, client1, client2)
 		assert.Equal(t, 2, len(githubManager.clients))
 	})
+
+	t.Run("リポジトリのトークンが変わると古いクライアントが破棄される", func(t *testing.T) {
+		manager, err := NewGithubApiManager(t.TempDir(), nil)
+		require.NoError(t, err)
+		githubManager := manager.(*githubApiManager)
+
+		repo := entity.NewRepository("rotated-repo", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "old-token")
+		_, err = githubManager.clientFor(context.Background(), repo)
+		require.NoError(t, err)
+		repo.SetAccessToken("new-token")
+		_, err = githubManager.clientFor(context.Background(), repo)
+		require.NoError(t, err)
+
+		assert.NotContains(t, githubManager.clients, "old-token")
+		assert.Contains(t, githubManager.clients, "new-token")
+	})
+
+	t.Run("同時に取得しても同じトークンのクライアントは1つだけキャッシュされる", func(t *testing.T) {
+		manager, err := NewGithubApiManager(t.TempDir(), nil)
+		require.NoError(t, err)
+		githubManager := manager.(*githubApiManager)
+
+		clients := make([]*github.Client, 16)
+		var wg sync.WaitGroup
+		for i := range clients {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				clients[i] = githubManager.getGitHubClient("shared-token")
+			}(i)
+		}
+		wg.Wait()
+
+		for _, client := range clients {
+			assert.Same(t, clients[0], client)
+		}
+		assert.Len(t, githubManager.clients, 1)
+	})
+}
+
+// TestForgetGitHub tests that Forget removes the local copy and the cached client
+func TestForgetGitHub(t *testing.T) {
+	t.Run("ローカルコピーと同期状態、キャッシュされたクライアントが削除される", func(t *testing.T) {
+		tmpDir := t.TempDir()
+
+		manager, err := NewGithubApiManager(tmpDir, nil)
+		require.NoError(t, err)
+		githubManager := manager.(*githubApiManager)
+
+		repo := entity.NewRepository("forget-repo", "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "forget-token")
+		localPath := githubManager.getLocalPath(repo)
+		require.NoError(t, os.MkdirAll(localPath, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "README.md"), []byte("# Test"), 0644))
+		require.NoError(t, saveSyncState(syncStatePath(localPath), syncState{CommitSHA: "abc123"}))
+		githubManager.getGitHubClient("forget-token")
+		githubManager.getGitHubClient("other-token")
+
+		require.NoError(t, manager.Forget(repo))
+
+		assert.NoDirExists(t, localPath)
+		assert.NoFileExists(t, syncStatePath(localPath))
+		assert.NotContains(t, githubManager.clients, "forget-token")
+		assert.Contains(t, githubManager.clients, "other-token")
+	})
 }
 
 // TestListRepositoryFilesGitHub tests the ListRepositoryFiles method for githubApiManager
@@ -230,10 +300,10 @@ func TestListRepositoryFilesGitHub(t *testing.T) {
 	tmpDir := filepath.Join(os.TempDir(), "test-github-list-files")
 	defer os.RemoveAll(tmpDir)
 
-	manager, err := NewGithubApiManager(tmpDir)
+	manager, err := NewGithubApiManager(tmpDir, nil)
 	require.NoError(t, err)
 
-	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", "")
+	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", entity.ProviderGitHub, "")
 	localPath := filepath.Join(tmpDir, "test-id")
 
 	t.Run("ローカルディレクトリが存在しない場合はエラー", func(t *testing.T) {
@@ -292,10 +362,10 @@ func TestValidateFilesExistGitHub(t *testing.T) {
 	tmpDir := filepath.Join(os.TempDir(), "test-github-validate-files")
 	defer os.RemoveAll(tmpDir)
 
-	manager, err := NewGithubApiManager(tmpDir)
+	manager, err := NewGithubApiManager(tmpDir, nil)
 	require.NoError(t, err)
 
-	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", "")
+	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", entity.ProviderGitHub, "")
 	localPath := filepath.Join(tmpDir, "test-id")
 
 	t.Run("ファイルパスが空の場合はエラーなし", func(t *testing.T) {
@@ -332,10 +402,10 @@ func TestReadManagedFileContentGitHub(t *testing.T) {
 	tmpDir := filepath.Join(os.TempDir(), "test-github-read-file")
 	defer os.RemoveAll(tmpDir)
 
-	manager, err := NewGithubApiManager(tmpDir)
+	manager, err := NewGithubApiManager(tmpDir, nil)
 	require.NoError(t, err)
 
-	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", "")
+	repo := entity.NewRepository("test-id", "test-repo", "https://github.com/example/test", entity.ProviderGitHub, "")
 	localPath := filepath.Join(tmpDir, "test-id")
 
 	t.Run("パストラバーサル攻撃を防ぐ - .. を含むパス", func(t *testing.T) {
@@ -389,3 +459,75 @@ func TestReadManagedFileContentGitHub(t *testing.T) {
 		assert.Error(t, err)
 	})
 }
+
+// TestVerifyAccessGitHub tests the VerifyAccess method for githubApiManager
+func TestVerifyAccessGitHub(t *testing.T) {
+	// serve returns a fake GitHub API that accepts only the given token for example/runbooks
+	serve := func(t *testing.T, token string, header http.Header, repo map[string]any) *httptest.Server {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Header.Get("Authorization") != "Bearer "+token {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			if r.URL.Path != "/repos/example/runbooks" {
+				http.NotFound(w, r)
+				return
+			}
+			for name, values := range header {
+				w.Header()[name] = values
+			}
+			json.NewEncoder(w).Encode(repo)
+		}))
+		t.Cleanup(server.Close)
+		return server
+	}
+	newRepo := func(url, token string) entity.Repository {
+		return entity.NewRepository("github-repo-id", "runbooks", url, entity.ProviderGitHub, token)
+	}
+
+	t.Run("有効期限付きのトークンは有効期限とともに有効になる", func(t *testing.T) {
+		header := http.Header{"Github-Authentication-Token-Expiration": {"2030-01-31 12:00:00 UTC"}}
+		server := serve(t, "ghp_valid", header, map[string]any{"private": true, "permissions": map[string]bool{"pull": true}})
+		manager := newTestGithubManager(t, server.URL)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo("https://github.com/example/runbooks", "ghp_valid"))
+
+		require.NoError(t, err)
+		assert.True(t, check.Valid)
+		require.NotNil(t, check.ExpiresAt)
+		assert.True(t, check.ExpiresAt.Equal(time.Date(2030, 1, 31, 12, 0, 0, 0, time.UTC)))
+	})
+
+	t.Run("拒否されたトークンは無効になる", func(t *testing.T) {
+		server := serve(t, "ghp_valid", nil, map[string]any{})
+		manager := newTestGithubManager(t, server.URL)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo("https://github.com/example/runbooks", "ghp_expired"))
+
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+		assert.Nil(t, check.ExpiresAt)
+	})
+
+	t.Run("repoスコープのないクラシックトークンはプライベートリポジトリで無効になる", func(t *testing.T) {
+		header := http.Header{"X-Oauth-Scopes": {"read:org, gist"}}
+		server := serve(t, "ghp_valid", header, map[string]any{"private": true})
+		manager := newTestGithubManager(t, server.URL)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo("https://github.com/example/runbooks", "ghp_valid"))
+
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+		assert.Contains(t, check.Reason, "repo scope")
+	})
+
+	t.Run("アクセスできないリポジトリは無効になる", func(t *testing.T) {
+		server := serve(t, "ghp_valid", nil, map[string]any{})
+		manager := newTestGithubManager(t, server.URL)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo("https://github.com/example/other", "ghp_valid"))
+
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+	})
+}
@@ -0,0 +1,148 @@
+package git
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+const (
+	// githubAppJWTLifetime is how long an App JWT is valid; GitHub rejects JWTs valid for more than 10 minutes.
+	githubAppJWTLifetime = 9 * time.Minute
+	// githubAppClockSkew backdates the issue time of an App JWT to tolerate clock drift with GitHub.
+	githubAppClockSkew = time.Minute
+	// installationTokenRefreshMargin renews a cached installation token this long before it expires,
+	// so that a sync started with it does not run into the expiry.
+	installationTokenRefreshMargin = 5 * time.Minute
+)
+
+// GitHubAppFinder looks up the GitHub Apps repositories are installed with.
+type GitHubAppFinder interface {
+	// FindByID retrieves a GitHub App by its ID. Returns nil if not found.
+	FindByID(ctx context.Context, id string) (entity.GitHubApp, error)
+}
+
+// ParseGitHubAppPrivateKey parses the PEM encoded RSA private key of a GitHub App,
+// either in PKCS#1 form as downloaded from GitHub or in PKCS#8 form.
+func ParseGitHubAppPrivateKey(privateKeyPEM string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, errors.New("private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.New("private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// signGitHubAppJWT creates the RS256 signed JWT a GitHub App authenticates with to request installation tokens.
+func signGitHubAppJWT(appID int64, key *rsa.PrivateKey, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-githubAppClockSkew).Unix(),
+		"exp": now.Add(githubAppJWTLifetime).Unix(),
+		"iss": strconv.FormatInt(appID, 10),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign GitHub App JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// installationToken is an access token minted for a GitHub App installation.
+type installationToken struct {
+	token     string
+	expiresAt time.Time
+}
+
+// installationTokenCache caches installation tokens until shortly before they expire.
+type installationTokenCache struct {
+	mu     sync.Mutex // Held while a token is minted so that concurrent syncs share one request
+	tokens map[entity.GitHubAppInstallation]installationToken
+}
+
+// installationToken returns a valid access token for the repository's GitHub App installation,
+// minting a new one when none is cached or the cached one is about to expire.
+func (g *githubApiManager) installationToken(ctx context.Context, repo entity.Repository) (string, error) {
+	installation := repo.Installation()
+
+	g.installationTokens.mu.Lock()
+	defer g.installationTokens.mu.Unlock()
+
+	cached, ok := g.installationTokens.tokens[installation]
+	if ok && time.Until(cached.expiresAt) > installationTokenRefreshMargin {
+		return cached.token, nil
+	}
+
+	minted, err := g.mintInstallationToken(ctx, installation)
+	if err != nil {
+		return "", fmt.Errorf("failed to create an installation token for repository %s: %w", repo.URL(), err)
+	}
+	if ok {
+		// The previous token is no longer used, so its client is dropped
+		g.evictClient(cached.token)
+	}
+	g.installationTokens.tokens[installation] = minted
+	return minted.token, nil
+}
+
+// mintInstallationToken requests a new access token for an installation, authenticating as the GitHub App.
+func (g *githubApiManager) mintInstallationToken(ctx context.Context, installation entity.GitHubAppInstallation) (installationToken, error) {
+	if g.apps == nil {
+		return installationToken{}, errors.New("GitHub App authentication is not configured")
+	}
+	app, err := g.apps.FindByID(ctx, installation.AppID)
+	if err != nil {
+		return installationToken{}, fmt.Errorf("failed to find GitHub App %s: %w", installation.AppID, err)
+	}
+	if app == nil {
+		return installationToken{}, fmt.Errorf("GitHub App %s not found", installation.AppID)
+	}
+
+	key, err := ParseGitHubAppPrivateKey(app.PrivateKey())
+	if err != nil {
+		return installationToken{}, err
+	}
+	jwt, err := signGitHubAppJWT(app.AppID(), key, time.Now())
+	if err != nil {
+		return installationToken{}, err
+	}
+
+	// The App JWT is short-lived, so its client is not cached
+	token, _, err := g.newGitHubClient(jwt).Apps.CreateInstallationToken(ctx, installation.InstallationID, nil)
+	if err != nil {
+		return installationToken{}, err
+	}
+	return installationToken{token: token.GetToken(), expiresAt: token.GetExpiresAt().Time}, nil
+}
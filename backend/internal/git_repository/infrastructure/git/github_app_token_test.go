@@ -0,0 +1,226 @@
+package git
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHubApp serves installation tokens for a single GitHub App in front of a fakeGitHub,
+// which only accepts requests authenticated with a token it issued.
+type fakeGitHubApp struct {
+	*fakeGitHub
+	appID     int64
+	publicKey *rsa.PublicKey
+	expiresIn time.Duration // Lifetime of the issued installation tokens
+
+	tokensMu sync.Mutex
+	issued   map[string]int64 // Installation token -> installation ID
+}
+
+func newFakeGitHubApp(t *testing.T, appID int64, publicKey *rsa.PublicKey) (*fakeGitHubApp, *httptest.Server) {
+	fake := &fakeGitHubApp{
+		fakeGitHub: &fakeGitHub{commits: map[string]map[string]string{}, refs: map[string]string{}, requests: map[string]int{}},
+		appID:      appID,
+		publicKey:  publicKey,
+		expiresIn:  time.Hour,
+		issued:     map[string]int64{},
+	}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveAppHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+func (f *fakeGitHubApp) serveAppHTTP(w http.ResponseWriter, r *http.Request) {
+	bearer := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+
+	var installationID int64
+	if _, err := fmt.Sscanf(r.URL.Path, "/app/installations/%d/access_tokens", &installationID); err == nil {
+		if r.Method != http.MethodPost || f.verifyJWT(bearer) != nil {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		f.tokensMu.Lock()
+		token := fmt.Sprintf("ghs_installation_%d_%d", installationID, len(f.issued)+1)
+		f.issued[token] = installationID
+		f.tokensMu.Unlock()
+
+		w.WriteHeader(http.StatusCreated)
+		json.NewEncoder(w).Encode(map[string]string{"token": token, "expires_at": time.Now().Add(f.expiresIn).UTC().Format(time.RFC3339)})
+		return
+	}
+
+	f.tokensMu.Lock()
+	_, ok := f.issued[bearer]
+	f.tokensMu.Unlock()
+	if !ok {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	f.serveHTTP(w, r)
+}
+
+// verifyJWT checks the signature, issuer and expiry of an App JWT.
+func (f *fakeGitHubApp) verifyJWT(jwt string) error {
+	parts := strings.Split(jwt, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed JWT")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return err
+	}
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(f.publicKey, crypto.SHA256, digest[:], signature); err != nil {
+		return err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return err
+	}
+	var claims struct {
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+		Iss string `json:"iss"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return err
+	}
+	now := time.Now().Unix()
+	if claims.Iss != fmt.Sprint(f.appID) || claims.Iat > now || claims.Exp < now || claims.Exp-claims.Iat > 600 {
+		return fmt.Errorf("invalid claims")
+	}
+	return nil
+}
+
+func (f *fakeGitHubApp) issuedCount() int {
+	f.tokensMu.Lock()
+	defer f.tokensMu.Unlock()
+	return len(f.issued)
+}
+
+// fakeGitHubAppFinder returns the GitHub Apps it holds by ID.
+type fakeGitHubAppFinder map[string]entity.GitHubApp
+
+func (f fakeGitHubAppFinder) FindByID(ctx context.Context, id string) (entity.GitHubApp, error) {
+	return f[id], nil
+}
+
+// newTestAppKey generates an RSA private key and returns it with its PKCS#1 PEM encoding, the form GitHub provides.
+func newTestAppKey(t *testing.T) (*rsa.PrivateKey, string) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+	return key, string(keyPEM)
+}
+
+// TestParseGitHubAppPrivateKey tests parsing the private key of a GitHub App
+func TestParseGitHubAppPrivateKey(t *testing.T) {
+	key, pkcs1 := newTestAppKey(t)
+
+	t.Run("PKCS#1形式の鍵を読み込める", func(t *testing.T) {
+		parsed, err := ParseGitHubAppPrivateKey(pkcs1)
+		require.NoError(t, err)
+		assert.True(t, key.Equal(parsed))
+	})
+
+	t.Run("PKCS#8形式の鍵を読み込める", func(t *testing.T) {
+		der, err := x509.MarshalPKCS8PrivateKey(key)
+		require.NoError(t, err)
+		parsed, err := ParseGitHubAppPrivateKey(string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})))
+		require.NoError(t, err)
+		assert.True(t, key.Equal(parsed))
+	})
+
+	t.Run("PEM形式でない場合はエラーになる", func(t *testing.T) {
+		_, err := ParseGitHubAppPrivateKey("not a key")
+		assert.Error(t, err)
+	})
+}
+
+// TestGithubApiManager_GitHubApp tests syncing repositories with installation tokens of a GitHub App
+func TestGithubApiManager_GitHubApp(t *testing.T) {
+	ctx := context.Background()
+	key, keyPEM := newTestAppKey(t)
+	apps := fakeGitHubAppFinder{"app-id": entity.NewGitHubApp("app-id", "OpsCore", 1234, keyPEM)}
+
+	newRepo := func(appID string) entity.Repository {
+		repo := entity.NewRepository("github-app-repo", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "")
+		repo.SetInstallation(entity.GitHubAppInstallation{AppID: appID, InstallationID: 42})
+		return repo
+	}
+	newManager := func(t *testing.T, serverURL string, apps GitHubAppFinder) *githubApiManager {
+		manager := newTestGithubManager(t, serverURL)
+		manager.apps = apps
+		return manager
+	}
+
+	t.Run("インストールトークンで同期し、トークンは有効期限までキャッシュされる", func(t *testing.T) {
+		fake, server := newFakeGitHubApp(t, 1234, &key.PublicKey)
+		manager := newManager(t, server.URL, apps)
+		fake.push("commit-1", map[string]string{"docs/deploy.md": "deploy v1"})
+
+		localPath, err := manager.EnsureCloned(ctx, newRepo("app-id"))
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"docs/deploy.md": "deploy v1"}, readLocalFiles(t, localPath))
+
+		fake.push("commit-2", map[string]string{"docs/deploy.md": "deploy v2"})
+		_, err = manager.EnsureCloned(ctx, newRepo("app-id"))
+		require.NoError(t, err)
+		assert.Equal(t, 1, fake.issuedCount())
+	})
+
+	t.Run("有効期限が近いトークンは再発行され、古いクライアントは破棄される", func(t *testing.T) {
+		fake, server := newFakeGitHubApp(t, 1234, &key.PublicKey)
+		fake.expiresIn = time.Minute
+		manager := newManager(t, server.URL, apps)
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks"})
+
+		_, err := manager.ResolveHeadCommit(ctx, t.TempDir(), newRepo("app-id"))
+		require.NoError(t, err)
+		_, err = manager.ResolveHeadCommit(ctx, t.TempDir(), newRepo("app-id"))
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, fake.issuedCount())
+		assert.NotContains(t, manager.clients, "ghs_installation_42_1")
+		assert.Contains(t, manager.clients, "ghs_installation_42_2")
+	})
+
+	t.Run("別の鍵で署名したJWTは拒否される", func(t *testing.T) {
+		otherKey, _ := newTestAppKey(t)
+		_, server := newFakeGitHubApp(t, 1234, &otherKey.PublicKey)
+		manager := newManager(t, server.URL, apps)
+
+		_, err := manager.ResolveHeadCommit(ctx, t.TempDir(), newRepo("app-id"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "installation token")
+	})
+
+	t.Run("登録されていないGitHub Appはエラーになる", func(t *testing.T) {
+		_, server := newFakeGitHubApp(t, 1234, &key.PublicKey)
+		manager := newManager(t, server.URL, apps)
+
+		_, err := manager.ResolveHeadCommit(ctx, t.TempDir(), newRepo("missing-app"))
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "not found")
+	})
+}
@@ -0,0 +1,256 @@
+package git
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/google/go-github/v60/github"
+)
+
+// githubTreeEntry is a file in a GitHub tree.
+type githubTreeEntry struct {
+	Path string
+	SHA  string // Git blob SHA of the file content
+}
+
+// syncRepository brings the local copy up to date with the repository's tracked ref, downloading only the blobs
+// under its root path whose content differs from the local files. Unchanged repositories cost a single
+// conditional request, which GitHub does not count against the rate limit.
+// The caller must hold the lock of the local copy.
+func (g *githubApiManager) syncRepository(ctx context.Context, client *github.Client, owner, repo, localPath string, tracking entity.Repository) error {
+	statePath := syncStatePath(localPath)
+	state := loadSyncState(statePath)
+	if !state.tracks(tracking) {
+		// The ref or root path changed since the last sync; the ETag no longer describes the local copy
+		state = syncState{}
+	}
+
+	_, statErr := os.Stat(localPath)
+	localExists := statErr == nil
+
+	commitSHA, etag, notModified, err := fetchHeadCommitSHA(ctx, client, owner, repo, trackedRef(tracking), state.ETag)
+	if err != nil {
+		return err
+	}
+	if notModified {
+		if localExists {
+			return nil
+		}
+		// The local copy was removed; restore it at the known commit
+		commitSHA, etag = state.CommitSHA, state.ETag
+	}
+
+	entries, err := fetchTreeFiles(ctx, client, owner, repo, commitSHA)
+	if err != nil {
+		return err
+	}
+	entries = filterTreeEntries(tracking, entries)
+
+	// The tree is applied to a copy of the local files, which then replaces the local copy in one step
+	stagingPath, err := stageLocalCopy(localPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(stagingPath)
+	if localExists {
+		if err := copyLocalFiles(localPath, stagingPath); err != nil {
+			return err
+		}
+	}
+	if err := applyTree(ctx, client, owner, repo, stagingPath, entries); err != nil {
+		return err
+	}
+	if err := swapLocalCopy(stagingPath, localPath); err != nil {
+		return err
+	}
+
+	if err := saveSyncState(statePath, newSyncState(tracking, commitSHA, etag).withFileCommits(state)); err != nil {
+		return fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return nil
+}
+
+// fetchHeadCommitSHA resolves the commit a branch or tag points to; HEAD is the head of the default branch.
+// When etag matches the current head, notModified is true and no SHA is returned.
+func fetchHeadCommitSHA(ctx context.Context, client *github.Client, owner, repo, ref, etag string) (sha string, newETag string, notModified bool, err error) {
+	req, err := client.NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, escapeRef(ref)), nil)
+	if err != nil {
+		return "", "", false, err
+	}
+	req.Header.Set("Accept", "application/vnd.github.sha")
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
+
+	var buf bytes.Buffer
+	resp, err := client.Do(ctx, req, &buf)
+	if resp != nil && resp.StatusCode == http.StatusNotModified {
+		return "", etag, true, nil
+	}
+	if err != nil {
+		return "", "", false, fmt.Errorf("failed to resolve head commit: %w", err)
+	}
+	return strings.TrimSpace(buf.String()), resp.Header.Get("ETag"), false, nil
+}
+
+// filterTreeEntries returns the tree entries inside the repository's root path.
+func filterTreeEntries(repo entity.Repository, entries []githubTreeEntry) []githubTreeEntry {
+	if repo.RootPath() == "" {
+		return entries
+	}
+	result := make([]githubTreeEntry, 0, len(entries))
+	for _, entry := range entries {
+		if repo.ContainsPath(entry.Path) {
+			result = append(result, entry)
+		}
+	}
+	return result
+}
+
+// fetchTreeFiles lists the files of a commit with their blob SHAs.
+func fetchTreeFiles(ctx context.Context, client *github.Client, owner, repo, commitSHA string) ([]githubTreeEntry, error) {
+	commit, _, err := client.Git.GetCommit(ctx, owner, repo, commitSHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit %s: %w", commitSHA, err)
+	}
+	return fetchTree(ctx, client, owner, repo, commit.GetTree().GetSHA(), "")
+}
+
+// fetchTree lists the files under a tree. Trees too large for a single recursive response
+// are walked one directory at a time.
+func fetchTree(ctx context.Context, client *github.Client, owner, repo, treeSHA, prefix string) ([]githubTreeEntry, error) {
+	tree, _, err := client.Git.GetTree(ctx, owner, repo, treeSHA, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tree %s: %w", treeSHA, err)
+	}
+
+	recursive := !tree.GetTruncated()
+	if !recursive {
+		tree, _, err = client.Git.GetTree(ctx, owner, repo, treeSHA, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tree %s: %w", treeSHA, err)
+		}
+	}
+
+	var files []githubTreeEntry
+	for _, entry := range tree.Entries {
+		entryPath := prefix + entry.GetPath()
+		switch entry.GetType() {
+		case "blob":
+			files = append(files, githubTreeEntry{Path: entryPath, SHA: entry.GetSHA()})
+		case "tree":
+			if recursive {
+				continue // Its files are already in the recursive listing
+			}
+			subFiles, err := fetchTree(ctx, client, owner, repo, entry.GetSHA(), entryPath+"/")
+			if err != nil {
+				return nil, err
+			}
+			files = append(files, subFiles...)
+		}
+		// Submodules ("commit" entries) have no content to download
+	}
+	return files, nil
+}
+
+// applyTree makes the local copy match the tree: changed blobs are downloaded and files
+// that are no longer in the tree are removed.
+func applyTree(ctx context.Context, client *github.Client, owner, repo, localPath string, entries []githubTreeEntry) error {
+	wanted := make(map[string]bool, len(entries))
+	for _, entry := range entries {
+		localFilePath, err := resolveRepositoryPath(localPath, entry.Path)
+		if err != nil {
+			return err
+		}
+		wanted[localFilePath] = true
+
+		if localSHA, err := gitBlobSHA(localFilePath); err == nil && localSHA == entry.SHA {
+			continue // Unchanged
+		}
+
+		content, _, err := client.Git.GetBlobRaw(ctx, owner, repo, entry.SHA)
+		if err != nil {
+			return fmt.Errorf("failed to get file content for %s: %w", entry.Path, err)
+		}
+		if err := os.MkdirAll(filepath.Dir(localFilePath), 0755); err != nil {
+			return fmt.Errorf("failed to create directory %s: %w", filepath.Dir(localFilePath), err)
+		}
+		if err := os.WriteFile(localFilePath, content, 0644); err != nil {
+			return fmt.Errorf("failed to write file %s: %w", localFilePath, err)
+		}
+	}
+
+	return removeStaleFiles(localPath, wanted)
+}
+
+// removeStaleFiles deletes files that are not wanted and the directories left empty.
+func removeStaleFiles(localPath string, wanted map[string]bool) error {
+	var dirs []string
+	err := filepath.WalkDir(localPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if path == localPath {
+			return nil
+		}
+		if d.IsDir() {
+			dirs = append(dirs, path)
+			return nil
+		}
+		if !wanted[path] {
+			return os.Remove(path)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove old files: %w", err)
+	}
+
+	// Remove empty directories, deepest first
+	sort.Sort(sort.Reverse(sort.StringSlice(dirs)))
+	for _, dir := range dirs {
+		if entries, err := os.ReadDir(dir); err == nil && len(entries) == 0 {
+			if err := os.Remove(dir); err != nil && !errors.Is(err, fs.ErrNotExist) {
+				return fmt.Errorf("failed to remove old directory %s: %w", dir, err)
+			}
+		}
+	}
+	return nil
+}
+
+// gitBlobSHA computes the Git blob SHA-1 of a file, which GitHub reports for each tree entry.
+func gitBlobSHA(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return "", err
+	}
+	if !info.Mode().IsRegular() {
+		return "", fmt.Errorf("not a regular file: %s", path)
+	}
+
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00", info.Size())
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
@@ -0,0 +1,413 @@
+package git
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitHub serves the subset of the GitHub REST API used to sync a repository.
+type fakeGitHub struct {
+	mu            sync.Mutex
+	commits       map[string]map[string]string // Commit SHA -> file path -> content
+	head          string
+	refs          map[string]string // Branch or tag name -> commit SHA
+	truncateTrees bool              // Report recursive trees as truncated
+	requests      map[string]int    // Request counts by kind
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *httptest.Server) {
+	fake := &fakeGitHub{commits: map[string]map[string]string{}, refs: map[string]string{}, requests: map[string]int{}}
+	server := httptest.NewServer(http.HandlerFunc(fake.serveHTTP))
+	t.Cleanup(server.Close)
+	return fake, server
+}
+
+// push records a new head commit with the given files.
+func (f *fakeGitHub) push(sha string, files map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commits[sha] = files
+	f.head = sha
+}
+
+// pushRef records a commit with the given files on a branch or tag other than the default branch.
+func (f *fakeGitHub) pushRef(ref, sha string, files map[string]string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.commits[sha] = files
+	f.refs[ref] = sha
+}
+
+func (f *fakeGitHub) count(kind string) int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.requests[kind]
+}
+
+func blobSHA(content string) string {
+	hash := sha1.New()
+	fmt.Fprintf(hash, "blob %d\x00%s", len(content), content)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func (f *fakeGitHub) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/example/runbooks/")
+	switch {
+	case path == "commits":
+		// Commits touching the requested path; the fake names them after the commit and file
+		commits := []map[string]string{}
+		sha, filePath := r.URL.Query().Get("sha"), r.URL.Query().Get("path")
+		if _, ok := f.commits[sha][filePath]; ok {
+			commits = append(commits, map[string]string{"sha": sha + ":" + filePath})
+		}
+		json.NewEncoder(w).Encode(commits)
+
+	case strings.HasPrefix(path, "commits/"):
+		f.requests["head"]++
+		sha := f.head
+		if ref := strings.TrimPrefix(path, "commits/"); ref != "HEAD" {
+			if sha = f.refs[ref]; sha == "" {
+				http.NotFound(w, r)
+				return
+			}
+		}
+		etag := `"` + sha + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		fmt.Fprint(w, sha)
+
+	case strings.HasPrefix(path, "git/commits/"):
+		sha := strings.TrimPrefix(path, "git/commits/")
+		json.NewEncoder(w).Encode(map[string]interface{}{"sha": sha, "tree": map[string]string{"sha": "tree:" + sha + ":"}})
+
+	case strings.HasPrefix(path, "git/trees/"):
+		f.requests["tree"]++
+		parts := strings.SplitN(strings.TrimPrefix(path, "git/trees/tree:"), ":", 2)
+		recursive := r.URL.Query().Get("recursive") != ""
+		f.writeTree(w, parts[0], parts[1], recursive)
+
+	case strings.HasPrefix(path, "git/blobs/"):
+		f.requests["blob"]++
+		sha := strings.TrimPrefix(path, "git/blobs/")
+		for _, files := range f.commits {
+			for _, content := range files {
+				if blobSHA(content) == sha {
+					fmt.Fprint(w, content)
+					return
+				}
+			}
+		}
+		http.NotFound(w, r)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// writeTree lists the entries under prefix, either recursively or one level deep.
+func (f *fakeGitHub) writeTree(w http.ResponseWriter, commit, prefix string, recursive bool) {
+	if recursive && f.truncateTrees {
+		json.NewEncoder(w).Encode(map[string]interface{}{"tree": []interface{}{}, "truncated": true})
+		return
+	}
+
+	entries := []map[string]string{}
+	dirs := map[string]bool{}
+	for filePath, content := range f.commits[commit] {
+		if !strings.HasPrefix(filePath, prefix) {
+			continue
+		}
+		rel := strings.TrimPrefix(filePath, prefix)
+		if dir, _, nested := strings.Cut(rel, "/"); nested && !recursive {
+			if !dirs[dir] {
+				dirs[dir] = true
+				entries = append(entries, map[string]string{"path": dir, "type": "tree", "sha": "tree:" + commit + ":" + prefix + dir + "/"})
+			}
+			continue
+		}
+		entries = append(entries, map[string]string{"path": rel, "type": "blob", "sha": blobSHA(content)})
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i]["path"] < entries[j]["path"] })
+	json.NewEncoder(w).Encode(map[string]interface{}{"tree": entries, "truncated": false})
+}
+
+func newTestGithubManager(t *testing.T, serverURL string) *githubApiManager {
+	manager, err := NewGithubApiManager(t.TempDir(), nil)
+	require.NoError(t, err)
+	githubManager := manager.(*githubApiManager)
+	githubManager.apiBaseURL, err = url.Parse(serverURL + "/")
+	require.NoError(t, err)
+	return githubManager
+}
+
+func readLocalFiles(t *testing.T, localPath string) map[string]string {
+	t.Helper()
+	files := map[string]string{}
+	err := filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+		require.NoError(t, err)
+		if !info.IsDir() {
+			rel, _ := filepath.Rel(localPath, path)
+			content, _ := os.ReadFile(path)
+			files[filepath.ToSlash(rel)] = string(content)
+		}
+		return nil
+	})
+	require.NoError(t, err)
+	return files
+}
+
+// TestGithubApiManager_EnsureCloned tests incremental syncs through the Git Trees API
+func TestGithubApiManager_EnsureCloned(t *testing.T) {
+	repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+	ctx := context.Background()
+
+	t.Run("変更されたファイルのみダウンロードされる", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+
+		fake.push("commit-1", map[string]string{
+			"README.md":         "# Runbooks",
+			"docs/deploy.md":    "deploy v1",
+			"docs/old/retry.md": "retry",
+		})
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, fake.commits["commit-1"], readLocalFiles(t, localPath))
+		assert.Equal(t, 3, fake.count("blob"))
+
+		fake.push("commit-2", map[string]string{
+			"README.md":      "# Runbooks",
+			"docs/deploy.md": "deploy v2",
+			"docs/backup.md": "backup",
+		})
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, fake.commits["commit-2"], readLocalFiles(t, localPath))
+		assert.Equal(t, 5, fake.count("blob"), "only the modified and added files should be downloaded")
+		assert.NoDirExists(t, filepath.Join(localPath, "docs", "old"))
+	})
+
+	t.Run("変更がない場合は条件付きリクエストのみ送信される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks"})
+		_, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+
+		assert.Equal(t, 2, fake.count("head"))
+		assert.Equal(t, 1, fake.count("tree"))
+		assert.Equal(t, 1, fake.count("blob"))
+	})
+
+	t.Run("ローカルのコピーが削除された場合は再取得される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks"})
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		require.NoError(t, os.RemoveAll(localPath))
+
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, fake.commits["commit-1"], readLocalFiles(t, localPath))
+	})
+
+	t.Run("切り詰められたツリーはディレクトリごとに取得される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		fake.truncateTrees = true
+		manager := newTestGithubManager(t, server.URL)
+
+		fake.push("commit-1", map[string]string{
+			"README.md":           "# Runbooks",
+			"docs/deploy.md":      "deploy",
+			"docs/db/backup.md":   "backup",
+			"scripts/rotate.sh":   "#!/bin/sh",
+			"docs/db/restore.md":  "restore",
+			"docs/network/vpn.md": "vpn",
+		})
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, fake.commits["commit-1"], readLocalFiles(t, localPath))
+	})
+
+	t.Run("同じリポジトリを同時に同期しても完全なツリーが配置される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks", "docs/deploy.md": "deploy v1"})
+		_, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+
+		fake.push("commit-2", map[string]string{"README.md": "# Runbooks", "docs/deploy.md": "deploy v2", "docs/backup.md": "backup"})
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				localPath, err := manager.EnsureCloned(ctx, repo)
+				if assert.NoError(t, err) {
+					assert.Equal(t, fake.commits["commit-2"], readLocalFiles(t, localPath))
+				}
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 2, fake.count("tree"), "later syncs should find the tree already up to date")
+		entries, err := os.ReadDir(manager.baseClonePath)
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		assert.ElementsMatch(t, []string{"github-repo-id", "github-repo-id.sync.json"}, names, "no staging directories should be left")
+	})
+}
+
+// TestGithubApiManager_Tracking tests syncing a pinned branch or tag and a root sub-path
+func TestGithubApiManager_Tracking(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("指定したブランチのルートパス配下のみ同期される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+		fake.push("commit-main", map[string]string{"docs/deploy.md": "deploy main"})
+		fake.pushRef("release/v1", "commit-release", map[string]string{
+			"README.md":              "# Runbooks",
+			"docs/deploy.md":         "deploy v1",
+			"docs/db/backup.md":      "backup v1",
+			"docs-archive/deploy.md": "old deploy",
+		})
+
+		repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		repo.SetTracking("release/v1", "docs")
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{
+			"docs/deploy.md":    "deploy v1",
+			"docs/db/backup.md": "backup v1",
+		}, readLocalFiles(t, localPath))
+
+		files, err := manager.ListRepositoryFiles(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.ElementsMatch(t, []string{"docs/deploy.md", "docs/db/backup.md"}, files)
+
+		commit, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-release", commit)
+
+		assert.Error(t, manager.ValidateFilesExist(ctx, localPath, []string{"README.md"}, repo))
+		_, err = manager.ReadManagedFileContent(ctx, localPath, "README.md", repo)
+		assert.Error(t, err)
+	})
+
+	t.Run("追跡設定が変わると条件付きリクエストを使わずに再同期される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+		fake.push("commit-1", map[string]string{
+			"README.md":      "# Runbooks",
+			"docs/deploy.md": "deploy",
+		})
+
+		repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		repo.SetTracking("", "docs")
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"docs/deploy.md": "deploy"}, readLocalFiles(t, localPath))
+
+		repo.SetTracking("", "")
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, fake.commits["commit-1"], readLocalFiles(t, localPath))
+	})
+
+	t.Run("存在しないrefはエラーになる", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks"})
+
+		repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		repo.SetTracking("missing", "")
+		_, err := manager.EnsureCloned(ctx, repo)
+		assert.Error(t, err)
+	})
+
+	t.Run("ローカルのコピーと同期状態が削除される", func(t *testing.T) {
+		fake, server := newFakeGitHub(t)
+		manager := newTestGithubManager(t, server.URL)
+		fake.push("commit-1", map[string]string{"README.md": "# Runbooks"})
+
+		repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		require.FileExists(t, syncStatePath(localPath))
+
+		require.NoError(t, manager.RemoveLocalCopy(repo))
+		assert.NoDirExists(t, localPath)
+		assert.NoFileExists(t, syncStatePath(localPath))
+	})
+}
+
+// TestGithubApiManager_ResolveCommits tests resolving the synced commit and the last commit of a file
+func TestGithubApiManager_ResolveCommits(t *testing.T) {
+	repo := entity.NewRepository("github-repo-id", "runbooks", "https://github.com/example/runbooks.git", entity.ProviderGitHub, "token")
+	ctx := context.Background()
+
+	fake, server := newFakeGitHub(t)
+	manager := newTestGithubManager(t, server.URL)
+	fake.push("commit-1", map[string]string{"docs/deploy.md": "deploy"})
+	localPath, err := manager.EnsureCloned(ctx, repo)
+	require.NoError(t, err)
+
+	// A later push is not reflected until the next sync
+	fake.push("commit-2", map[string]string{"docs/deploy.md": "deploy v2"})
+
+	t.Run("同期したコミットを返す", func(t *testing.T) {
+		commit, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-1", commit)
+	})
+
+	t.Run("同期したコミットまでにファイルを最後に変更したコミットを返す", func(t *testing.T) {
+		commit, err := manager.ResolveFileCommit(ctx, localPath, "docs/deploy.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-1:docs/deploy.md", commit)
+	})
+
+	t.Run("存在しないファイルはエラーになる", func(t *testing.T) {
+		_, err := manager.ResolveFileCommit(ctx, localPath, "docs/missing.md", repo)
+		assert.Error(t, err)
+	})
+
+	t.Run("未同期の場合はデフォルトブランチの先頭を返す", func(t *testing.T) {
+		commit, err := manager.ResolveHeadCommit(ctx, filepath.Join(t.TempDir(), "unsynced"), repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-2", commit)
+	})
+}
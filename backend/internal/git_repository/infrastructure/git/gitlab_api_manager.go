@@ -0,0 +1,577 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// gitlabTreePageSize is the number of tree entries requested per page (GitLab allows at most 100).
+const gitlabTreePageSize = 100
+
+// gitlabApiManager implements the GitManager interface using the GitLab REST API (v4).
+// It works with gitlab.com as well as self-hosted instances; the API endpoint is derived from the repository URL.
+type gitlabApiManager struct {
+	baseClonePath string       // Base directory where repositories will be stored locally
+	httpClient    *http.Client // HTTP client used for GitLab API requests
+	instanceURLs  []string     // Base URLs of self-hosted instances served under a path prefix
+}
+
+// gitlabProject is the subset of the GitLab project resource used by the manager.
+type gitlabProject struct {
+	ID            int    `json:"id"`
+	DefaultBranch string `json:"default_branch"`
+}
+
+// gitlabCommit is the subset of the GitLab commit resource used by the manager.
+type gitlabCommit struct {
+	ID           string    `json:"id"`
+	Title        string    `json:"title"`
+	AuthorName   string    `json:"author_name"`
+	AuthoredDate time.Time `json:"authored_date"`
+}
+
+// gitlabTokenInfo is the subset of the personal/project access token resource used to verify a token.
+type gitlabTokenInfo struct {
+	Scopes    []string `json:"scopes"`
+	Active    bool     `json:"active"`
+	Revoked   bool     `json:"revoked"`
+	ExpiresAt string   `json:"expires_at"` // Date (YYYY-MM-DD) the token expires on; empty when it never expires
+}
+
+// gitlabStatusError reports a GitLab API response with an unsuccessful status.
+type gitlabStatusError struct {
+	StatusCode int
+	Path       string
+}
+
+func (e *gitlabStatusError) Error() string {
+	return fmt.Sprintf("GitLab API returned status %d for %s", e.StatusCode, e.Path)
+}
+
+// gitlabTreeEntry is an entry returned by the repository tree API.
+type gitlabTreeEntry struct {
+	Path string `json:"path"`
+	Type string `json:"type"` // "blob" or "tree"
+}
+
+// NewGitlabApiManager creates a new gitlabApiManager.
+// baseClonePath is the directory where repositories will be stored locally.
+// httpClient is used for API requests; http.DefaultClient is used when nil.
+// instanceURLs lists the base URLs of self-hosted instances served under a path prefix
+// (e.g. https://host/gitlab); instances served from the host root need no entry.
+func NewGitlabApiManager(baseClonePath string, httpClient *http.Client, instanceURLs []string) (GitManager, error) {
+	// Ensure the base clone path exists
+	err := os.MkdirAll(baseClonePath, 0755)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create base clone directory '%s': %w", baseClonePath, err)
+	}
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &gitlabApiManager{
+		baseClonePath: baseClonePath,
+		httpClient:    httpClient,
+		instanceURLs:  instanceURLs,
+	}, nil
+}
+
+// getLocalPath determines the local directory path for a given repository.
+func (g *gitlabApiManager) getLocalPath(repo entity.Repository) string {
+	return filepath.Join(g.baseClonePath, repo.ID())
+}
+
+// parseGitLabURL extracts the API base URL and the full project path (including nested groups) from a GitLab URL.
+// For example, https://gitlab.example.com/group/subgroup/project.git yields
+// https://gitlab.example.com/api/v4 and group/subgroup/project. When the URL belongs to one of instanceURLs,
+// the instance's path prefix is part of the API base: with https://host/gitlab listed,
+// https://host/gitlab/group/project yields https://host/gitlab/api/v4 and group/project.
+func parseGitLabURL(repoURL string, instanceURLs []string) (string, string, error) {
+	parsedURL, err := url.Parse(repoURL)
+	if err != nil || parsedURL.Host == "" {
+		return "", "", fmt.Errorf("invalid GitLab URL format: %s", repoURL)
+	}
+
+	repoPath := strings.Trim(parsedURL.Path, "/")
+	prefix := ""
+	for _, instanceURL := range instanceURLs {
+		instance, err := url.Parse(instanceURL)
+		if err != nil || !strings.EqualFold(instance.Host, parsedURL.Host) || instance.Scheme != parsedURL.Scheme {
+			continue
+		}
+		instancePath := strings.Trim(instance.Path, "/")
+		if instancePath != "" && len(instancePath) > len(prefix) && strings.HasPrefix(repoPath, instancePath+"/") {
+			prefix = instancePath
+		}
+	}
+
+	projectPath := strings.TrimSuffix(strings.TrimPrefix(strings.TrimPrefix(repoPath, prefix), "/"), ".git")
+	if strings.Count(projectPath, "/") < 1 {
+		return "", "", fmt.Errorf("invalid GitLab URL format: %s", repoURL)
+	}
+
+	apiBase := parsedURL.Scheme + "://" + parsedURL.Host
+	if prefix != "" {
+		apiBase += "/" + prefix
+	}
+	return apiBase + "/api/v4", projectPath, nil
+}
+
+// EnsureCloned ensures the repository is available locally by downloading the files under its root path
+// at the head commit of its tracked ref. The files are downloaded next to the local copy, which is
+// replaced only once the download is complete. Concurrent calls for the same repository are serialized.
+func (g *gitlabApiManager) EnsureCloned(ctx context.Context, repo entity.Repository) (string, error) {
+	localPath := g.getLocalPath(repo)
+
+	apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+	if err != nil {
+		return "", err
+	}
+
+	unlock, err := lockLocalCopy(ctx, localPath)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		logger.Info("Cloning repository", "url", repo.URL(), "path", localPath)
+	} else if err == nil {
+		logger.Info("Updating repository", "url", repo.URL(), "path", localPath)
+	} else {
+		return "", fmt.Errorf("failed to check repository directory %s: %w", localPath, err)
+	}
+
+	stagingPath, err := stageLocalCopy(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(stagingPath)
+
+	ref, err := g.downloadRepository(ctx, apiBase, projectPath, stagingPath, repo)
+	if err != nil {
+		return "", fmt.Errorf("failed to clone repository %s: %w", repo.URL(), err)
+	}
+	if err := swapLocalCopy(stagingPath, localPath); err != nil {
+		return "", err
+	}
+	statePath := syncStatePath(localPath)
+	previous := loadSyncState(statePath)
+	if !previous.tracks(repo) {
+		previous = syncState{}
+	}
+	if err := saveSyncState(statePath, newSyncState(repo, ref, "").withFileCommits(previous)); err != nil {
+		return "", fmt.Errorf("failed to save sync state: %w", err)
+	}
+
+	return localPath, nil
+}
+
+// downloadRepository downloads every file under the repository's root path at the head commit of its tracked ref
+// into localPath. Returns the SHA of the downloaded commit.
+func (g *gitlabApiManager) downloadRepository(ctx context.Context, apiBase, projectPath, localPath string, repo entity.Repository) (string, error) {
+	accessToken := repo.AccessToken()
+	ref, err := g.resolveHeadCommit(ctx, apiBase, projectPath, accessToken, repo.Ref())
+	if err != nil {
+		return "", err
+	}
+
+	files, err := g.listFilesFromAPI(ctx, apiBase, projectPath, accessToken, ref, repo.RootPath())
+	if err != nil {
+		return "", err
+	}
+
+	for _, filePath := range files {
+		localFilePath, err := resolveRepositoryPath(localPath, filePath)
+		if err != nil {
+			return "", err
+		}
+
+		content, err := g.fetchRawFile(ctx, apiBase, projectPath, accessToken, filePath, ref)
+		if err != nil {
+			return "", err
+		}
+
+		if err := os.MkdirAll(filepath.Dir(localFilePath), 0755); err != nil {
+			return "", fmt.Errorf("failed to create directory for %s: %w", filePath, err)
+		}
+		if err := os.WriteFile(localFilePath, content, 0644); err != nil {
+			return "", fmt.Errorf("failed to write file %s: %w", localFilePath, err)
+		}
+	}
+	return ref, nil
+}
+
+// resolveHeadCommit returns the SHA of the commit a branch or tag points to.
+// An empty ref resolves the head of the project's default branch.
+// Pinning downloads to a single commit keeps the tree and the file contents consistent.
+func (g *gitlabApiManager) resolveHeadCommit(ctx context.Context, apiBase, projectPath, accessToken, ref string) (string, error) {
+	if ref == "" {
+		var project gitlabProject
+		if err := g.getJSON(ctx, apiBase+"/projects/"+url.PathEscape(projectPath), accessToken, &project, nil); err != nil {
+			return "", fmt.Errorf("failed to get project: %w", err)
+		}
+		if project.DefaultBranch == "" {
+			return "", fmt.Errorf("project %s has no default branch", projectPath)
+		}
+		ref = project.DefaultBranch
+	}
+
+	var commit gitlabCommit
+	endpoint := apiBase + "/projects/" + url.PathEscape(projectPath) + "/repository/commits/" + url.PathEscape(ref)
+	if err := g.getJSON(ctx, endpoint, accessToken, &commit, nil); err != nil {
+		return "", fmt.Errorf("failed to get head commit: %w", err)
+	}
+	return commit.ID, nil
+}
+
+// listFilesFromAPI lists every file (blob) of the project tree under rootPath at ref, following pagination.
+// An empty rootPath lists the whole tree.
+func (g *gitlabApiManager) listFilesFromAPI(ctx context.Context, apiBase, projectPath, accessToken, ref, rootPath string) ([]string, error) {
+	var files []string
+	page := "1"
+	for page != "" {
+		query := url.Values{}
+		query.Set("recursive", "true")
+		query.Set("per_page", fmt.Sprint(gitlabTreePageSize))
+		query.Set("page", page)
+		if ref != "" {
+			query.Set("ref", ref)
+		}
+		if rootPath != "" {
+			query.Set("path", rootPath)
+		}
+		endpoint := apiBase + "/projects/" + url.PathEscape(projectPath) + "/repository/tree?" + query.Encode()
+
+		var entries []gitlabTreeEntry
+		var header http.Header
+		if err := g.getJSON(ctx, endpoint, accessToken, &entries, &header); err != nil {
+			return nil, fmt.Errorf("failed to get repository tree: %w", err)
+		}
+		for _, entry := range entries {
+			if entry.Type == "blob" {
+				files = append(files, entry.Path)
+			}
+		}
+		page = header.Get("X-Next-Page")
+	}
+	return files, nil
+}
+
+// fetchRawFile downloads the raw content of a file at ref.
+func (g *gitlabApiManager) fetchRawFile(ctx context.Context, apiBase, projectPath, accessToken, filePath, ref string) ([]byte, error) {
+	endpoint := apiBase + "/projects/" + url.PathEscape(projectPath) + "/repository/files/" + url.PathEscape(filePath) + "/raw"
+	if ref != "" {
+		endpoint += "?ref=" + url.QueryEscape(ref)
+	}
+
+	resp, err := g.do(ctx, endpoint, accessToken)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get file content for %s: %w", filePath, err)
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file content for %s: %w", filePath, err)
+	}
+	return content, nil
+}
+
+// getJSON performs a GET request and decodes the JSON response into out.
+// When header is non-nil, the response headers are stored in it.
+func (g *gitlabApiManager) getJSON(ctx context.Context, endpoint, accessToken string, out interface{}, header *http.Header) error {
+	resp, err := g.do(ctx, endpoint, accessToken)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if header != nil {
+		*header = resp.Header
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode GitLab API response: %w", err)
+	}
+	return nil
+}
+
+// do performs an authenticated GET request and returns the response if it succeeded.
+func (g *gitlabApiManager) do(ctx context.Context, endpoint, accessToken string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create GitLab API request: %w", err)
+	}
+	if accessToken != "" {
+		req.Header.Set("PRIVATE-TOKEN", accessToken)
+	}
+
+	resp, err := g.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("GitLab API request failed: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, &gitlabStatusError{StatusCode: resp.StatusCode, Path: req.URL.Path}
+	}
+	return resp, nil
+}
+
+// ListRepositoryFiles lists the files under the repository's root path.
+func (g *gitlabApiManager) ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error) {
+	var files []string
+	err := filepath.Walk(localPath, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip the root directory and hidden files/dirs
+		if path == localPath {
+			return nil
+		}
+		if filepath.Base(path)[0] == '.' {
+			if info.IsDir() {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !info.IsDir() {
+			relPath, err := filepath.Rel(localPath, path)
+			if err != nil {
+				return err
+			}
+			files = append(files, filepath.ToSlash(relPath))
+		}
+		return nil
+	})
+
+	if err != nil {
+		// If walking the local directory fails, try to get files directly from the API
+		logger.Warn("Failed to walk local directory, falling back to API", "path", localPath, "error", err)
+
+		apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+		if err != nil {
+			return nil, err
+		}
+
+		files, err = g.listFilesFromAPI(ctx, apiBase, projectPath, repo.AccessToken(), repo.Ref(), repo.RootPath())
+		if err != nil {
+			return nil, fmt.Errorf("failed to list files in repository: %w", err)
+		}
+	}
+
+	return filterRootPath(repo, files), nil
+}
+
+// DescribeFiles returns the size of each file under the repository's root path and the last commit that touched it.
+func (g *gitlabApiManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	files, err := g.ListRepositoryFiles(ctx, localPath, repo)
+	if err != nil {
+		return nil, err
+	}
+	headSHA, err := g.ResolveHeadCommit(ctx, localPath, repo)
+	if err != nil {
+		return nil, err
+	}
+
+	apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+	if err != nil {
+		return nil, err
+	}
+	return describeFilesFromAPI(ctx, localPath, headSHA, files, func(ctx context.Context, headSHA, filePath string) (CommitInfo, error) {
+		return g.fetchFileCommit(ctx, apiBase, projectPath, repo.AccessToken(), headSHA, filePath)
+	})
+}
+
+// ValidateFilesExist checks if files exist in the repository.
+func (g *gitlabApiManager) ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error {
+	for _, filePath := range filePaths {
+		if err := checkRootPath(repo, filePath); err != nil {
+			return err
+		}
+		fullPath := filepath.Join(localPath, filePath)
+		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist in repository: %s", filePath)
+		} else if err != nil {
+			return fmt.Errorf("error checking if file exists: %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// ReadManagedFileContent reads the content of a repository file.
+func (g *gitlabApiManager) ReadManagedFileContent(ctx context.Context, localPath string, filePath string, repo entity.Repository) ([]byte, error) {
+	if err := checkRootPath(repo, filePath); err != nil {
+		return nil, err
+	}
+	fullPath, err := resolveRepositoryPath(localPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		// If the file is not available locally, try to get it from the API
+		if os.IsNotExist(err) {
+			apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+			if err != nil {
+				return nil, err
+			}
+			return g.fetchRawFile(ctx, apiBase, projectPath, repo.AccessToken(), filePath, trackedRef(repo))
+		}
+		return nil, fmt.Errorf("failed to read file %s: %w", fullPath, err)
+	}
+
+	return content, nil
+}
+
+// resolveRepositoryPath joins a repository-relative file path to localPath,
+// rejecting paths that would escape the repository directory.
+func resolveRepositoryPath(localPath, filePath string) (string, error) {
+	if strings.Contains(filePath, "..") {
+		return "", fmt.Errorf("invalid file path containing path traversal sequences: %s", filePath)
+	}
+
+	fullPath := filepath.Join(localPath, filePath)
+
+	absLocalPath, _ := filepath.Abs(localPath)
+	absFullPath, _ := filepath.Abs(fullPath)
+	if !strings.HasPrefix(absFullPath, absLocalPath) {
+		return "", fmt.Errorf("invalid file path: attempt to access file outside repository directory")
+	}
+	return fullPath, nil
+}
+
+// LocalPath returns the directory the repository is cloned into.
+func (g *gitlabApiManager) LocalPath(repo entity.Repository) string {
+	return g.getLocalPath(repo)
+}
+
+// RemoveLocalCopy deletes the local copy and its sync state.
+func (g *gitlabApiManager) RemoveLocalCopy(repo entity.Repository) error {
+	localPath := g.getLocalPath(repo)
+	unlock, err := lockLocalCopy(context.Background(), localPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return removeLocalCopy(localPath)
+}
+
+// Forget deletes the local copy and its sync state; no clients are cached per repository.
+func (g *gitlabApiManager) Forget(repo entity.Repository) error {
+	return g.RemoveLocalCopy(repo)
+}
+
+// VerifyAccess looks up the token itself, which reports its scopes and expiry date, and then fetches the project.
+// Instances that do not provide the token self-lookup (before GitLab 15.5) are only checked for access to the project.
+func (g *gitlabApiManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+	if err != nil {
+		return AccessCheck{}, err
+	}
+
+	var check AccessCheck
+	var token gitlabTokenInfo
+	err = g.getJSON(ctx, apiBase+"/personal_access_tokens/self", repo.AccessToken(), &token, nil)
+	var statusErr *gitlabStatusError
+	switch {
+	case err == nil:
+		if !token.Active || token.Revoked {
+			return rejectedAccess(accessReasonRejected), nil
+		}
+		if !slices.Contains(token.Scopes, "api") && !slices.Contains(token.Scopes, "read_api") {
+			return rejectedAccess("the token lacks the read_api scope"), nil
+		}
+		if token.ExpiresAt != "" {
+			expiresAt, err := time.Parse(time.DateOnly, token.ExpiresAt)
+			if err != nil {
+				return AccessCheck{}, fmt.Errorf("failed to parse token expiry date %q: %w", token.ExpiresAt, err)
+			}
+			check.ExpiresAt = &expiresAt
+		}
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized:
+		return rejectedAccess(accessReasonRejected), nil
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusNotFound:
+		// The self-lookup is not available; fall back to checking access to the project
+	default:
+		return AccessCheck{}, fmt.Errorf("failed to look up access token: %w", err)
+	}
+
+	var project gitlabProject
+	err = g.getJSON(ctx, apiBase+"/projects/"+url.PathEscape(projectPath), repo.AccessToken(), &project, nil)
+	switch {
+	case err == nil:
+		check.Valid = true
+		return check, nil
+	case errors.As(err, &statusErr) && statusErr.StatusCode == http.StatusUnauthorized:
+		return rejectedAccess(accessReasonRejected), nil
+	case errors.As(err, &statusErr) && (statusErr.StatusCode == http.StatusForbidden || statusErr.StatusCode == http.StatusNotFound):
+		return rejectedAccess(accessReasonInaccessible), nil
+	}
+	return AccessCheck{}, fmt.Errorf("failed to get project: %w", err)
+}
+
+// ResolveHeadCommit returns the commit the local copy was last downloaded at,
+// or the head of the tracked ref if the repository has not been downloaded yet.
+func (g *gitlabApiManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	if state := loadSyncState(syncStatePath(localPath)); state.CommitSHA != "" && state.tracks(repo) {
+		return state.CommitSHA, nil
+	}
+
+	apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+	if err != nil {
+		return "", err
+	}
+	return g.resolveHeadCommit(ctx, apiBase, projectPath, repo.AccessToken(), repo.Ref())
+}
+
+// ResolveFileCommit returns the last commit up to the downloaded commit that touched the file.
+func (g *gitlabApiManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	headSHA, err := g.ResolveHeadCommit(ctx, localPath, repo)
+	if err != nil {
+		return "", err
+	}
+
+	apiBase, projectPath, err := parseGitLabURL(repo.URL(), g.instanceURLs)
+	if err != nil {
+		return "", err
+	}
+	commit, err := g.fetchFileCommit(ctx, apiBase, projectPath, repo.AccessToken(), headSHA, filePath)
+	if err != nil {
+		return "", err
+	}
+	return commit.SHA, nil
+}
+
+// fetchFileCommit returns the last commit up to headSHA that touched the file.
+func (g *gitlabApiManager) fetchFileCommit(ctx context.Context, apiBase, projectPath, accessToken, headSHA, filePath string) (CommitInfo, error) {
+	query := url.Values{}
+	query.Set("ref_name", headSHA)
+	query.Set("path", filePath)
+	query.Set("per_page", "1")
+	endpoint := apiBase + "/projects/" + url.PathEscape(projectPath) + "/repository/commits?" + query.Encode()
+
+	var commits []gitlabCommit
+	if err := g.getJSON(ctx, endpoint, accessToken, &commits, nil); err != nil {
+		return CommitInfo{}, fmt.Errorf("failed to list commits of %s: %w", filePath, err)
+	}
+	if len(commits) == 0 {
+		return CommitInfo{}, fmt.Errorf("file does not exist in repository history: %s", filePath)
+	}
+	return CommitInfo{
+		SHA:     commits[0].ID,
+		Author:  commits[0].AuthorName,
+		Date:    commits[0].AuthoredDate,
+		Message: commits[0].Title,
+	}, nil
+}
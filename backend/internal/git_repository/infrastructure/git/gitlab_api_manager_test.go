@@ -0,0 +1,482 @@
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeGitLab is a minimal fake of the GitLab REST API (v4) serving a single project.
+type fakeGitLab struct {
+	projectPath string            // Full project path, e.g. group/subgroup/project
+	token       string            // Required PRIVATE-TOKEN value
+	files       map[string]string // File path -> content at the head commit
+	pageSize    int               // Number of tree entries per page
+	refs        map[string]string // Branches and tags other than main -> commit ID
+	treePaths   []string          // path parameter of each tree request
+	tokenInfo   map[string]any    // Response of the token self-lookup; nil responds 404 like GitLab before 15.5
+
+	commitLookups int // Number of requests for the commits touching a path
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("PRIVATE-TOKEN") != f.token {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+
+	if r.URL.Path == "/api/v4/personal_access_tokens/self" {
+		if f.tokenInfo == nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(f.tokenInfo)
+		return
+	}
+
+	// The project path is URL-encoded into a single path segment, so work on the escaped path
+	prefix := "/api/v4/projects/" + strings.ReplaceAll(f.projectPath, "/", "%2F")
+	path := r.URL.EscapedPath()
+	if !strings.HasPrefix(path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	rest := strings.TrimPrefix(path, prefix)
+
+	switch {
+	case rest == "":
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": 42, "default_branch": "main"})
+	case rest == "/repository/commits/main":
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": "abc123"})
+	case strings.HasPrefix(rest, "/repository/commits/"):
+		ref := strings.ReplaceAll(strings.TrimPrefix(rest, "/repository/commits/"), "%2F", "/")
+		id, ok := f.refs[ref]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{"id": id})
+	case rest == "/repository/commits":
+		// Commits touching the requested path; the fake names them after the file
+		f.commitLookups++
+		commits := []map[string]string{}
+		if filePath := r.URL.Query().Get("path"); r.URL.Query().Get("ref_name") == "abc123" {
+			if _, ok := f.files[filePath]; ok {
+				commits = append(commits, map[string]string{"id": "commit-of-" + filePath, "title": "Update " + filePath, "author_name": "ops"})
+			}
+		}
+		json.NewEncoder(w).Encode(commits)
+	case rest == "/repository/tree":
+		f.serveTree(w, r)
+	case strings.HasPrefix(rest, "/repository/files/") && strings.HasSuffix(rest, "/raw"):
+		filePath := strings.TrimSuffix(strings.TrimPrefix(rest, "/repository/files/"), "/raw")
+		filePath = strings.ReplaceAll(filePath, "%2F", "/")
+		content, ok := f.files[filePath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(content))
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func (f *fakeGitLab) serveTree(w http.ResponseWriter, r *http.Request) {
+	treePath := r.URL.Query().Get("path")
+	f.treePaths = append(f.treePaths, treePath)
+
+	// Build the tree including directory entries, sorted for stable pagination
+	var entries []map[string]string
+	dirs := map[string]bool{}
+	for filePath := range f.files {
+		if treePath != "" && !strings.HasPrefix(filePath, treePath+"/") {
+			continue
+		}
+		entries = append(entries, map[string]string{"path": filePath, "type": "blob"})
+		for dir := filepath.Dir(filePath); dir != "."; dir = filepath.Dir(dir) {
+			if !dirs[dir] {
+				dirs[dir] = true
+				entries = append(entries, map[string]string{"path": dir, "type": "tree"})
+			}
+		}
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i]["path"] < entries[j]["path"] })
+
+	page, err := strconv.Atoi(r.URL.Query().Get("page"))
+	if err != nil || page < 1 {
+		page = 1
+	}
+	start := min((page-1)*f.pageSize, len(entries))
+	end := start + f.pageSize
+	if end >= len(entries) {
+		end = len(entries)
+	} else {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	json.NewEncoder(w).Encode(entries[start:end])
+}
+
+// newGitLabTestManager starts a fake GitLab server and returns a manager that trusts it.
+func newGitLabTestManager(t *testing.T, fake *fakeGitLab) (*gitlabApiManager, *httptest.Server) {
+	t.Helper()
+	server := httptest.NewTLSServer(fake)
+	t.Cleanup(server.Close)
+
+	manager, err := NewGitlabApiManager(t.TempDir(), server.Client(), nil)
+	require.NoError(t, err)
+	return manager.(*gitlabApiManager), server
+}
+
+// TestParseGitLabURL tests the parseGitLabURL helper function
+func TestParseGitLabURL(t *testing.T) {
+	t.Run("有効なGitLab URLをパースできる", func(t *testing.T) {
+		testCases := []struct {
+			url             string
+			expectedAPIBase string
+			expectedProject string
+		}{
+			{"https://gitlab.com/example/test-repo", "https://gitlab.com/api/v4", "example/test-repo"},
+			{"https://gitlab.com/example/test-repo.git", "https://gitlab.com/api/v4", "example/test-repo"},
+			{"https://git.example.com:8443/group/sub/deep/repo.git", "https://git.example.com:8443/api/v4", "group/sub/deep/repo"},
+		}
+
+		for _, tc := range testCases {
+			apiBase, projectPath, err := parseGitLabURL(tc.url, nil)
+			assert.NoError(t, err, tc.url)
+			assert.Equal(t, tc.expectedAPIBase, apiBase)
+			assert.Equal(t, tc.expectedProject, projectPath)
+		}
+	})
+
+	t.Run("パスの配下で提供されるインスタンスのURLをパースできる", func(t *testing.T) {
+		instanceURLs := []string{"https://git.example.com/gitlab", "https://other.example.com/"}
+		testCases := []struct {
+			url             string
+			expectedAPIBase string
+			expectedProject string
+		}{
+			{"https://git.example.com/gitlab/group/proj.git", "https://git.example.com/gitlab/api/v4", "group/proj"},
+			{"https://git.example.com/gitlab/group/sub/proj", "https://git.example.com/gitlab/api/v4", "group/sub/proj"},
+			{"https://git.example.com/gitlab-group/proj", "https://git.example.com/api/v4", "gitlab-group/proj"},
+			{"https://other.example.com/group/proj", "https://other.example.com/api/v4", "group/proj"},
+		}
+
+		for _, tc := range testCases {
+			apiBase, projectPath, err := parseGitLabURL(tc.url, instanceURLs)
+			assert.NoError(t, err, tc.url)
+			assert.Equal(t, tc.expectedAPIBase, apiBase, tc.url)
+			assert.Equal(t, tc.expectedProject, projectPath, tc.url)
+		}
+
+		_, _, err := parseGitLabURL("https://git.example.com/gitlab/only-group", instanceURLs)
+		assert.Error(t, err)
+	})
+
+	t.Run("無効なGitLab URLでエラーになる", func(t *testing.T) {
+		for _, url := range []string{"", "https://gitlab.com/", "https://gitlab.com/only-group"} {
+			_, _, err := parseGitLabURL(url, nil)
+			assert.Error(t, err, url)
+		}
+	})
+}
+
+// TestGitlabApiManager_EnsureCloned tests cloning a nested-group project from a fake GitLab instance
+func TestGitlabApiManager_EnsureCloned(t *testing.T) {
+	fake := &fakeGitLab{
+		projectPath: "ops/platform/runbooks",
+		token:       "glpat-test",
+		files: map[string]string{
+			"README.md":             "# Runbooks",
+			"docs/deploy.md":        "# Deploy",
+			"docs/db/backup.md":     "# Backup",
+			"scripts/rotate-key.sh": "#!/bin/sh",
+		},
+		pageSize: 3, // Force pagination
+	}
+
+	t.Run("ネストしたグループのプロジェクトをダウンロードできる", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/platform/runbooks.git", entity.ProviderGitLab, "glpat-test")
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+		assert.Equal(t, filepath.Join(manager.baseClonePath, "gitlab-repo-id"), localPath)
+
+		files, err := manager.ListRepositoryFiles(context.Background(), localPath, repo)
+		require.NoError(t, err)
+		sort.Strings(files)
+		assert.Equal(t, []string{"README.md", "docs/db/backup.md", "docs/deploy.md", "scripts/rotate-key.sh"}, files)
+
+		content, err := manager.ReadManagedFileContent(context.Background(), localPath, "docs/db/backup.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "# Backup", string(content))
+
+		assert.NoError(t, manager.ValidateFilesExist(context.Background(), localPath, []string{"docs/deploy.md"}, repo))
+		assert.Error(t, manager.ValidateFilesExist(context.Background(), localPath, []string{"missing.md"}, repo))
+	})
+
+	t.Run("パスの配下で提供されるインスタンスからダウンロードできる", func(t *testing.T) {
+		server := httptest.NewTLSServer(http.StripPrefix("/gitlab", fake))
+		t.Cleanup(server.Close)
+		manager, err := NewGitlabApiManager(t.TempDir(), server.Client(), []string{server.URL + "/gitlab"})
+		require.NoError(t, err)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/gitlab/ops/platform/runbooks.git", entity.ProviderGitLab, "glpat-test")
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+
+		content, err := os.ReadFile(filepath.Join(localPath, "docs", "deploy.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Deploy", string(content))
+	})
+
+	t.Run("隠しディレクトリの中のファイルは一覧に含まれない", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/platform/runbooks", entity.ProviderGitLab, "glpat-test")
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+		require.NoError(t, os.MkdirAll(filepath.Join(localPath, ".git", "refs"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, ".git", "refs", "main"), []byte("abc"), 0644))
+
+		files, err := manager.ListRepositoryFiles(context.Background(), localPath, repo)
+		require.NoError(t, err)
+		sort.Strings(files)
+		assert.Equal(t, []string{"README.md", "docs/db/backup.md", "docs/deploy.md", "scripts/rotate-key.sh"}, files)
+	})
+
+	t.Run("再取得時に削除されたファイルが消える", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/platform/runbooks", entity.ProviderGitLab, "glpat-test")
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+
+		stale := filepath.Join(localPath, "stale.md")
+		require.NoError(t, os.WriteFile(stale, []byte("old"), 0644))
+
+		_, err = manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+		_, err = os.Stat(stale)
+		assert.True(t, os.IsNotExist(err))
+	})
+
+	t.Run("アクセストークンが不正な場合はエラーになる", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/platform/runbooks", entity.ProviderGitLab, "wrong-token")
+
+		_, err := manager.EnsureCloned(context.Background(), repo)
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "status 401")
+	})
+
+	t.Run("再取得に失敗した場合は以前のローカルコピーが残る", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/platform/runbooks", entity.ProviderGitLab, "glpat-test")
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+		require.NoError(t, err)
+
+		repo.SetAccessToken("wrong-token")
+		_, err = manager.EnsureCloned(context.Background(), repo)
+		require.Error(t, err)
+
+		content, err := os.ReadFile(filepath.Join(localPath, "docs", "deploy.md"))
+		require.NoError(t, err)
+		assert.Equal(t, "# Deploy", string(content))
+	})
+}
+
+// TestGitlabApiManager_ReadManagedFileContent tests reading files that are not available locally
+func TestGitlabApiManager_ReadManagedFileContent(t *testing.T) {
+	fake := &fakeGitLab{
+		projectPath: "group/repo",
+		token:       "glpat-test",
+		files:       map[string]string{"guide.md": "# Guide"},
+		pageSize:    100,
+	}
+
+	t.Run("ローカルにないファイルはAPIから取得する", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("repo-id", "repo", server.URL+"/group/repo", entity.ProviderGitLab, "glpat-test")
+
+		content, err := manager.ReadManagedFileContent(context.Background(), t.TempDir(), "guide.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "# Guide", string(content))
+	})
+
+	t.Run("パストラバーサルを含むパスはエラーになる", func(t *testing.T) {
+		manager, server := newGitLabTestManager(t, fake)
+		repo := entity.NewRepository("repo-id", "repo", server.URL+"/group/repo", entity.ProviderGitLab, "glpat-test")
+
+		_, err := manager.ReadManagedFileContent(context.Background(), t.TempDir(), "../secret", repo)
+		assert.Error(t, err)
+	})
+}
+
+// TestProviderGitManager tests routing calls to the manager of the repository's provider
+func TestProviderGitManager(t *testing.T) {
+	t.Run("プロバイダーごとのマネージャーに委譲する", func(t *testing.T) {
+		githubManager := NewMockGitManager()
+		gitlabManager := NewMockGitManager()
+		manager := NewProviderGitManager(map[entity.Provider]GitManager{
+			entity.ProviderGitHub: githubManager,
+			entity.ProviderGitLab: gitlabManager,
+		}, nil)
+
+		repo := entity.NewRepository("repo-id", "repo", "https://gitlab.com/group/repo", entity.ProviderGitLab, "")
+		gitlabManager.On("EnsureCloned", context.Background(), repo).Return("/tmp/repo-id", nil)
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/repo-id", localPath)
+		gitlabManager.AssertExpectations(t)
+		githubManager.AssertNotCalled(t, "EnsureCloned")
+	})
+
+	t.Run("デプロイキーのリポジトリはプロバイダーに関わらずSSH対応のマネージャーに委譲する", func(t *testing.T) {
+		githubManager := NewMockGitManager()
+		sshManager := NewMockGitManager()
+		manager := NewProviderGitManager(map[entity.Provider]GitManager{
+			entity.ProviderGitHub: githubManager,
+		}, sshManager)
+
+		repo := entity.NewRepository("repo-id", "repo", "git@github.com:owner/repo.git", entity.ProviderGitHub, "")
+		repo.SetDeployKey("test-key")
+		sshManager.On("EnsureCloned", context.Background(), repo).Return("/tmp/repo-id", nil)
+
+		localPath, err := manager.EnsureCloned(context.Background(), repo)
+
+		assert.NoError(t, err)
+		assert.Equal(t, "/tmp/repo-id", localPath)
+		sshManager.AssertExpectations(t)
+		githubManager.AssertNotCalled(t, "EnsureCloned")
+	})
+
+	t.Run("未登録のプロバイダーはエラーになる", func(t *testing.T) {
+		manager := NewProviderGitManager(map[entity.Provider]GitManager{}, nil)
+		repo := entity.NewRepository("repo-id", "repo", "https://gitlab.com/group/repo", entity.ProviderGitLab, "")
+
+		_, err := manager.EnsureCloned(context.Background(), repo)
+
+		assert.Error(t, err)
+	})
+}
+
+func TestGitlabApiManager_ResolveCommits(t *testing.T) {
+	fake := &fakeGitLab{
+		projectPath: "ops/runbooks",
+		token:       "glpat-test",
+		files:       map[string]string{"docs/deploy.md": "# Deploy"},
+		pageSize:    100,
+	}
+	manager, server := newGitLabTestManager(t, fake)
+	repo := entity.NewRepository("gitlab-repo-id", "runbooks", server.URL+"/ops/runbooks.git", entity.ProviderGitLab, "glpat-test")
+
+	localPath, err := manager.EnsureCloned(context.Background(), repo)
+	require.NoError(t, err)
+
+	t.Run("ダウンロードしたコミットを返す", func(t *testing.T) {
+		commit, err := manager.ResolveHeadCommit(context.Background(), localPath, repo)
+		require.NoError(t, err)
+		assert.Equal(t, "abc123", commit)
+	})
+
+	t.Run("ファイルを最後に変更したコミットを返す", func(t *testing.T) {
+		commit, err := manager.ResolveFileCommit(context.Background(), localPath, "docs/deploy.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-of-docs/deploy.md", commit)
+	})
+
+	t.Run("存在しないファイルはエラーになる", func(t *testing.T) {
+		_, err := manager.ResolveFileCommit(context.Background(), localPath, "docs/missing.md", repo)
+		assert.Error(t, err)
+	})
+
+	t.Run("ファイルごとのサイズと最後のコミットを返し、同じコミットの間はキャッシュする", func(t *testing.T) {
+		infos, err := manager.DescribeFiles(context.Background(), localPath, repo)
+		require.NoError(t, err)
+		require.Len(t, infos, 1)
+		assert.Equal(t, "docs/deploy.md", infos[0].Path)
+		assert.Equal(t, int64(len("# Deploy")), infos[0].Size)
+		assert.Equal(t, "commit-of-docs/deploy.md", infos[0].LastCommit.SHA)
+		assert.Equal(t, "Update docs/deploy.md", infos[0].LastCommit.Message)
+		lookups := fake.commitLookups
+
+		infos, err = manager.DescribeFiles(context.Background(), localPath, repo)
+		require.NoError(t, err)
+		assert.Equal(t, "commit-of-docs/deploy.md", infos[0].LastCommit.SHA)
+		assert.Equal(t, lookups, fake.commitLookups)
+	})
+}
+
+func TestGitlabApiManager_VerifyAccess(t *testing.T) {
+	newRepo := func(serverURL, token string) entity.Repository {
+		return entity.NewRepository("gitlab-repo-id", "runbooks", serverURL+"/ops/runbooks", entity.ProviderGitLab, token)
+	}
+
+	t.Run("トークンのスコープと有効期限を確認できる", func(t *testing.T) {
+		fake := &fakeGitLab{projectPath: "ops/runbooks", token: "glpat-test", tokenInfo: map[string]any{
+			"scopes": []string{"read_api"}, "active": true, "revoked": false, "expires_at": "2030-01-31",
+		}}
+		manager, server := newGitLabTestManager(t, fake)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo(server.URL, "glpat-test"))
+
+		require.NoError(t, err)
+		assert.True(t, check.Valid)
+		require.NotNil(t, check.ExpiresAt)
+		assert.Equal(t, time.Date(2030, 1, 31, 0, 0, 0, 0, time.UTC), *check.ExpiresAt)
+	})
+
+	t.Run("必要なスコープがないトークンは無効になる", func(t *testing.T) {
+		fake := &fakeGitLab{projectPath: "ops/runbooks", token: "glpat-test", tokenInfo: map[string]any{
+			"scopes": []string{"read_repository"}, "active": true,
+		}}
+		manager, server := newGitLabTestManager(t, fake)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo(server.URL, "glpat-test"))
+
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+		assert.Contains(t, check.Reason, "read_api")
+	})
+
+	t.Run("失効したトークンは無効になる", func(t *testing.T) {
+		fake := &fakeGitLab{projectPath: "ops/runbooks", token: "glpat-test", tokenInfo: map[string]any{"scopes": []string{"api"}}}
+		manager, server := newGitLabTestManager(t, fake)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo(server.URL, "glpat-other"))
+
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+	})
+
+	t.Run("トークンを参照できないインスタンスではプロジェクトへのアクセスのみ確認する", func(t *testing.T) {
+		fake := &fakeGitLab{projectPath: "ops/runbooks", token: "glpat-test"}
+		manager, server := newGitLabTestManager(t, fake)
+
+		check, err := manager.VerifyAccess(context.Background(), newRepo(server.URL, "glpat-test"))
+		require.NoError(t, err)
+		assert.True(t, check.Valid)
+		assert.Nil(t, check.ExpiresAt)
+
+		check, err = manager.VerifyAccess(context.Background(), entity.NewRepository("gitlab-repo-id", "other", server.URL+"/ops/other", entity.ProviderGitLab, "glpat-test"))
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+	})
+}
@@ -0,0 +1,204 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sync"
+)
+
+// localCopyLocks serializes the changes made to each local copy. It is shared by all managers because they
+// place repositories in the same base directory, and a repository moves between managers when its
+// authentication method changes.
+var localCopyLocks = &pathLocks{locks: make(map[string]*pathLock)}
+
+// pathLocks holds a lock for each local copy that is in use.
+type pathLocks struct {
+	mu    sync.Mutex
+	locks map[string]*pathLock
+}
+
+// pathLock is the lock of a single local copy.
+type pathLock struct {
+	sem   chan struct{} // Holds a value while the lock is held
+	users int           // Goroutines holding or waiting for the lock; it is dropped when none are left
+}
+
+// lock waits until no other goroutine holds the lock of the local copy, or ctx is done.
+// It returns the function that releases the lock.
+func (l *pathLocks) lock(ctx context.Context, localPath string) (func(), error) {
+	l.mu.Lock()
+	pl, ok := l.locks[localPath]
+	if !ok {
+		pl = &pathLock{sem: make(chan struct{}, 1)}
+		l.locks[localPath] = pl
+	}
+	pl.users++
+	l.mu.Unlock()
+
+	done := func() {
+		l.mu.Lock()
+		pl.users--
+		if pl.users == 0 {
+			delete(l.locks, localPath)
+		}
+		l.mu.Unlock()
+	}
+
+	select {
+	case pl.sem <- struct{}{}:
+		return func() {
+			<-pl.sem
+			done()
+		}, nil
+	case <-ctx.Done():
+		done()
+		return nil, fmt.Errorf("failed to lock local copy %s: %w", localPath, ctx.Err())
+	}
+}
+
+// lockLocalCopy acquires the lock of a local copy; see localCopyLocks.
+func lockLocalCopy(ctx context.Context, localPath string) (func(), error) {
+	return localCopyLocks.lock(ctx, localPath)
+}
+
+// localCopyReaders keeps readers away from local copies while they change. Readers hold a shared lock on a
+// local copy for as long as they use it, and changes to the tree hold the exclusive lock, so a reader never
+// finds the local copy missing between the renames of a swap or half reset by an update.
+var localCopyReaders = &readerLocks{locks: make(map[string]*readerLock)}
+
+// readerLocks holds a reader lock for each local copy that is in use.
+type readerLocks struct {
+	mu    sync.Mutex
+	locks map[string]*readerLock
+}
+
+// readerLock is the reader lock of a single local copy.
+type readerLock struct {
+	rw    sync.RWMutex
+	users int // Goroutines holding or waiting for the lock; it is dropped when none are left
+}
+
+// acquire returns the reader lock of the local copy and the function that gives it back.
+func (l *readerLocks) acquire(localPath string) (*readerLock, func()) {
+	localPath = filepath.Clean(localPath)
+	l.mu.Lock()
+	rl, ok := l.locks[localPath]
+	if !ok {
+		rl = &readerLock{}
+		l.locks[localPath] = rl
+	}
+	rl.users++
+	l.mu.Unlock()
+
+	return rl, func() {
+		l.mu.Lock()
+		rl.users--
+		if rl.users == 0 {
+			delete(l.locks, localPath)
+		}
+		l.mu.Unlock()
+	}
+}
+
+// readLocalCopy holds a shared lock on a local copy until the returned function is called; see localCopyReaders.
+func readLocalCopy(localPath string) func() {
+	rl, release := localCopyReaders.acquire(localPath)
+	rl.rw.RLock()
+	return func() {
+		rl.rw.RUnlock()
+		release()
+	}
+}
+
+// changeLocalCopy runs change while no reader uses the local copy; see localCopyReaders.
+// Changes must be quick, since readers wait for them.
+func changeLocalCopy(localPath string, change func() error) error {
+	rl, release := localCopyReaders.acquire(localPath)
+	defer release()
+	rl.rw.Lock()
+	defer rl.rw.Unlock()
+	return change()
+}
+
+// stageLocalCopy creates an empty directory next to the local copy to download a new tree into.
+// The directory is swapped into place with swapLocalCopy once it is complete, so readers never see a partial tree.
+func stageLocalCopy(localPath string) (string, error) {
+	stagingPath, err := os.MkdirTemp(filepath.Dir(localPath), filepath.Base(localPath)+".staging-")
+	if err != nil {
+		return "", fmt.Errorf("failed to create staging directory for %s: %w", localPath, err)
+	}
+	return stagingPath, nil
+}
+
+// swapLocalCopy replaces the local copy with a staged directory. The previous copy is renamed aside before it
+// is deleted; both renames happen while readers are held off, so they see either the previous or the new tree.
+func swapLocalCopy(stagingPath, localPath string) error {
+	oldPath := stagingPath + ".old"
+	err := changeLocalCopy(localPath, func() error {
+		if err := os.Rename(localPath, oldPath); err != nil && !os.IsNotExist(err) {
+			return fmt.Errorf("failed to move aside local copy %s: %w", localPath, err)
+		}
+		if err := os.Rename(stagingPath, localPath); err != nil {
+			// Put the previous copy back so the repository stays readable
+			os.Rename(oldPath, localPath)
+			return fmt.Errorf("failed to move new tree into %s: %w", localPath, err)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	if err := os.RemoveAll(oldPath); err != nil {
+		logger.Warn("Failed to remove previous local copy", "path", oldPath, "error", err)
+	}
+	return nil
+}
+
+// copyLocalFiles copies the regular files under src into dst, which must exist, keeping their relative paths.
+// Other entries such as symbolic links are skipped.
+func copyLocalFiles(src, dst string) error {
+	err := filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, relPath)
+		if d.IsDir() {
+			return os.MkdirAll(target, 0755)
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+		return copyFile(path, target)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy local copy %s: %w", src, err)
+	}
+	return nil
+}
+
+// copyFile copies the content of a regular file.
+func copyFile(src, dst string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -0,0 +1,181 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestLockLocalCopy tests the per-repository lock of local copies
+func TestLockLocalCopy(t *testing.T) {
+	// テスト：同じローカルコピーのロックは同時に1つしか取得できないことを確認する
+	t.Run("同じローカルコピーのロックは同時に1つしか取得できない", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+
+		var mu sync.Mutex
+		holders, maxHolders := 0, 0
+		var wg sync.WaitGroup
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				unlock, err := lockLocalCopy(context.Background(), localPath)
+				if !assert.NoError(t, err) {
+					return
+				}
+				mu.Lock()
+				holders++
+				maxHolders = max(maxHolders, holders)
+				mu.Unlock()
+
+				time.Sleep(time.Millisecond)
+
+				mu.Lock()
+				holders--
+				mu.Unlock()
+				unlock()
+			}()
+		}
+		wg.Wait()
+
+		assert.Equal(t, 1, maxHolders)
+		localCopyLocks.mu.Lock()
+		defer localCopyLocks.mu.Unlock()
+		assert.NotContains(t, localCopyLocks.locks, localPath, "the lock should be dropped once released")
+	})
+
+	// テスト：別のローカルコピーのロックは待たずに取得できることを確認する
+	t.Run("別のローカルコピーのロックは待たずに取得できる", func(t *testing.T) {
+		dir := t.TempDir()
+		unlock, err := lockLocalCopy(context.Background(), filepath.Join(dir, "repo-1"))
+		require.NoError(t, err)
+		defer unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		other, err := lockLocalCopy(ctx, filepath.Join(dir, "repo-2"))
+		require.NoError(t, err)
+		other()
+	})
+
+	// テスト：待機中にコンテキストが終了した場合はエラーになることを確認する
+	t.Run("待機中にコンテキストが終了した場合はエラーになる", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		unlock, err := lockLocalCopy(context.Background(), localPath)
+		require.NoError(t, err)
+		defer unlock()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		_, err = lockLocalCopy(ctx, localPath)
+		require.Error(t, err)
+		assert.ErrorIs(t, err, context.DeadlineExceeded)
+	})
+}
+
+// TestSwapLocalCopy tests replacing a local copy with a staged tree
+func TestSwapLocalCopy(t *testing.T) {
+	// テスト：既存のローカルコピーが置き換えられ、作業用のディレクトリが残らないことを確認する
+	t.Run("既存のローカルコピーが置き換えられ、作業用のディレクトリが残らない", func(t *testing.T) {
+		baseDir := t.TempDir()
+		localPath := filepath.Join(baseDir, "repo-id")
+		require.NoError(t, os.MkdirAll(filepath.Join(localPath, "docs"), 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "docs", "old.md"), []byte("old"), 0644))
+
+		stagingPath, err := stageLocalCopy(localPath)
+		require.NoError(t, err)
+		require.NoError(t, copyLocalFiles(localPath, stagingPath))
+		require.NoError(t, os.WriteFile(filepath.Join(stagingPath, "README.md"), []byte("new"), 0644))
+
+		require.NoError(t, swapLocalCopy(stagingPath, localPath))
+
+		assert.Equal(t, map[string]string{"docs/old.md": "old", "README.md": "new"}, readLocalFiles(t, localPath))
+		entries, err := os.ReadDir(baseDir)
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		assert.Equal(t, "repo-id", entries[0].Name())
+	})
+
+	// テスト：ローカルコピーが存在しない場合はそのまま配置されることを確認する
+	t.Run("ローカルコピーが存在しない場合はそのまま配置される", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		stagingPath, err := stageLocalCopy(localPath)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(stagingPath, "README.md"), []byte("new"), 0644))
+
+		require.NoError(t, swapLocalCopy(stagingPath, localPath))
+
+		assert.Equal(t, map[string]string{"README.md": "new"}, readLocalFiles(t, localPath))
+		assert.NoDirExists(t, stagingPath)
+	})
+}
+
+// TestReadLocalCopy tests holding readers off while a local copy is swapped
+func TestReadLocalCopy(t *testing.T) {
+	// テスト：読み取り中は入れ替えが待たされ、読み取りが終わると新しいツリーに置き換わることを確認する
+	t.Run("読み取り中は入れ替えが待たされる", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		require.NoError(t, os.MkdirAll(localPath, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "README.md"), []byte("old"), 0644))
+		stagingPath, err := stageLocalCopy(localPath)
+		require.NoError(t, err)
+		require.NoError(t, os.WriteFile(filepath.Join(stagingPath, "README.md"), []byte("new"), 0644))
+
+		done := readLocalCopy(localPath)
+		swapped := make(chan error, 1)
+		go func() { swapped <- swapLocalCopy(stagingPath, localPath) }()
+
+		select {
+		case <-swapped:
+			t.Fatal("the swap should wait for the reader")
+		case <-time.After(20 * time.Millisecond):
+		}
+		assert.Equal(t, map[string]string{"README.md": "old"}, readLocalFiles(t, localPath))
+
+		done()
+		require.NoError(t, <-swapped)
+		assert.Equal(t, map[string]string{"README.md": "new"}, readLocalFiles(t, localPath))
+		localCopyReaders.mu.Lock()
+		defer localCopyReaders.mu.Unlock()
+		assert.NotContains(t, localCopyReaders.locks, localPath, "the lock should be dropped once released")
+	})
+
+	// テスト：入れ替えの最中もローカルコピーが欠けて見えないことを確認する
+	t.Run("入れ替えの最中もローカルコピーが欠けて見えない", func(t *testing.T) {
+		localPath := filepath.Join(t.TempDir(), "repo-id")
+		require.NoError(t, os.MkdirAll(localPath, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(localPath, "README.md"), []byte("0"), 0644))
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for ctx.Err() == nil {
+					done := readLocalCopy(localPath)
+					_, err := os.Stat(filepath.Join(localPath, "README.md"))
+					done()
+					if !assert.NoError(t, err) {
+						return
+					}
+				}
+			}()
+		}
+
+		for i := 1; i <= 20; i++ {
+			stagingPath, err := stageLocalCopy(localPath)
+			require.NoError(t, err)
+			require.NoError(t, os.WriteFile(filepath.Join(stagingPath, "README.md"), []byte{byte('0' + i%10)}, 0644))
+			require.NoError(t, swapLocalCopy(stagingPath, localPath))
+		}
+		cancel()
+		wg.Wait()
+	})
+}
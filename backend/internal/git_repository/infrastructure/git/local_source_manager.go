@@ -0,0 +1,485 @@
+package git
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/google/uuid"
+)
+
+// uploadedArchiveExt is the extension of the archives kept in the upload directory.
+// The format of an archive is detected from its content, so tar.gz and zip archives share it.
+const uploadedArchiveExt = ".archive"
+
+// errSourceNotAllowed is returned for file:// sources outside the directories the server may read.
+var errSourceNotAllowed = errors.New("local source is outside the allowed directories")
+
+// ArchiveStore keeps the archives uploaded for repositories served from a local source.
+type ArchiveStore interface {
+	// SaveArchive stores an uploaded tar.gz or zip archive and returns the file:// URL it is served from.
+	SaveArchive(content io.Reader) (string, error)
+	// ReplaceArchive replaces the content of an archive stored by SaveArchive.
+	ReplaceArchive(archiveURL string, content io.Reader) error
+	// RemoveArchive deletes an archive stored by SaveArchive. URLs of other sources are ignored.
+	RemoveArchive(archiveURL string) error
+	// IsUploadedArchive reports whether the URL refers to an archive stored by SaveArchive.
+	IsUploadedArchive(archiveURL string) bool
+}
+
+// LocalSourceManager serves repositories from local directories and archives and stores uploaded archives.
+type LocalSourceManager interface {
+	GitManager
+	ArchiveStore
+}
+
+// localSourceManager implements the LocalSourceManager interface for sites without Git hosting.
+// A repository's URL is a file:// URL of a directory or of a tar.gz or zip archive. Having no history,
+// the manager records a hash of the imported content as the commit of each import.
+type localSourceManager struct {
+	baseClonePath string   // Base directory where repositories will be stored locally
+	uploadPath    string   // Directory uploaded archives are stored in
+	allowedRoots  []string // Directories sources may be read from, including uploadPath
+}
+
+// NewLocalSourceManager creates a new localSourceManager.
+// baseClonePath is the directory where repositories will be stored locally and uploadPath the directory
+// uploaded archives are kept in. Sources are only read from uploadPath and the allowedRoots directories.
+func NewLocalSourceManager(baseClonePath, uploadPath string, allowedRoots []string) (LocalSourceManager, error) {
+	for _, dir := range []string{baseClonePath, uploadPath} {
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return nil, fmt.Errorf("failed to create directory '%s': %w", dir, err)
+		}
+	}
+
+	m := &localSourceManager{baseClonePath: baseClonePath}
+	for i, dir := range append([]string{uploadPath}, allowedRoots...) {
+		resolved, err := resolveRealPath(dir)
+		if err != nil {
+			return nil, fmt.Errorf("failed to resolve local source directory '%s': %w", dir, err)
+		}
+		if i == 0 {
+			m.uploadPath = resolved
+		}
+		m.allowedRoots = append(m.allowedRoots, resolved)
+	}
+	return m, nil
+}
+
+// resolveRealPath returns the absolute path of a file with symbolic links resolved.
+func resolveRealPath(path string) (string, error) {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return "", err
+	}
+	return filepath.EvalSymlinks(abs)
+}
+
+// ParseFileURL returns the absolute path a file:// URL refers to. Only local paths are accepted,
+// with an empty host or localhost.
+func ParseFileURL(sourceURL string) (string, error) {
+	parsedURL, err := url.Parse(sourceURL)
+	if err != nil || parsedURL.Scheme != "file" {
+		return "", fmt.Errorf("invalid file URL: %s", sourceURL)
+	}
+	if parsedURL.Host != "" && parsedURL.Host != "localhost" {
+		return "", fmt.Errorf("file URL must refer to a local path: %s", sourceURL)
+	}
+	if !strings.HasPrefix(parsedURL.Path, "/") || parsedURL.RawQuery != "" || parsedURL.Fragment != "" {
+		return "", fmt.Errorf("file URL must have an absolute path: %s", sourceURL)
+	}
+	return filepath.Clean(filepath.FromSlash(parsedURL.Path)), nil
+}
+
+// fileURL returns the file:// URL of an absolute path.
+func fileURL(path string) string {
+	return (&url.URL{Scheme: "file", Path: filepath.ToSlash(path)}).String()
+}
+
+// getLocalPath determines the local directory path for a given repository.
+func (m *localSourceManager) getLocalPath(repo entity.Repository) string {
+	return filepath.Join(m.baseClonePath, repo.ID())
+}
+
+// resolveSource returns the path of the repository's source, checking that it is inside an allowed directory.
+func (m *localSourceManager) resolveSource(sourceURL string) (string, error) {
+	sourcePath, err := ParseFileURL(sourceURL)
+	if err != nil {
+		return "", err
+	}
+	resolved, err := resolveRealPath(sourcePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to access local source %s: %w", sourcePath, err)
+	}
+	for _, root := range m.allowedRoots {
+		if rel, err := filepath.Rel(root, resolved); err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			return resolved, nil
+		}
+	}
+	return "", fmt.Errorf("%w: %s", errSourceNotAllowed, sourcePath)
+}
+
+// EnsureCloned imports the files under the repository's root path from its directory or archive.
+// The import is prepared next to the local copy and replaces it only once it is complete.
+// Concurrent calls for the same repository are serialized.
+func (m *localSourceManager) EnsureCloned(ctx context.Context, repo entity.Repository) (string, error) {
+	localPath := m.getLocalPath(repo)
+	sourcePath, err := m.resolveSource(repo.URL())
+	if err != nil {
+		return "", err
+	}
+
+	unlock, err := lockLocalCopy(ctx, localPath)
+	if err != nil {
+		return "", err
+	}
+	defer unlock()
+
+	stagingPath, err := stageLocalCopy(localPath)
+	if err != nil {
+		return "", err
+	}
+	defer os.RemoveAll(stagingPath)
+	if err := m.importSource(ctx, sourcePath, stagingPath, repo); err != nil {
+		return "", fmt.Errorf("failed to import repository %s: %w", repo.URL(), err)
+	}
+
+	snapshot, err := SnapshotFiles(stagingPath)
+	if err != nil {
+		return "", err
+	}
+	contentHash := hashSnapshot(snapshot)
+
+	statePath := syncStatePath(localPath)
+	state := loadSyncState(statePath)
+	_, statErr := os.Stat(localPath)
+	if !state.tracks(repo) {
+		state = syncState{}
+	} else if state.CommitSHA == contentHash && statErr == nil {
+		return localPath, nil // Unchanged since the last import
+	}
+
+	// Files keep the commit of the import that last changed them
+	previous := FileSnapshot{}
+	if state.CommitSHA != "" {
+		if previous, err = SnapshotFiles(localPath); err != nil {
+			return "", err
+		}
+	}
+	imported := CommitInfo{SHA: contentHash, Date: time.Now().UTC(), Message: "Imported from " + filepath.Base(sourcePath)}
+	fileCommits := make(map[string]CommitInfo, len(snapshot))
+	for filePath, hash := range snapshot {
+		if commit, ok := state.FileCommits[filePath]; ok && previous[filePath] == hash {
+			fileCommits[filePath] = commit
+		} else {
+			fileCommits[filePath] = imported
+		}
+	}
+
+	logger.Info("Importing repository", "url", repo.URL(), "path", localPath)
+	if err := swapLocalCopy(stagingPath, localPath); err != nil {
+		return "", err
+	}
+	newState := newSyncState(repo, contentHash, "")
+	newState.FileCommits = fileCommits
+	if err := saveSyncState(statePath, newState); err != nil {
+		return "", fmt.Errorf("failed to save sync state: %w", err)
+	}
+	return localPath, nil
+}
+
+// importSource copies the files under the repository's root path from a source directory, or from
+// the directory an archive extracts to, into destPath.
+func (m *localSourceManager) importSource(ctx context.Context, sourcePath, destPath string, repo entity.Repository) error {
+	info, err := os.Stat(sourcePath)
+	if err != nil {
+		return fmt.Errorf("failed to access local source: %w", err)
+	}
+	if info.IsDir() {
+		return copySourceFiles(ctx, sourcePath, destPath, repo)
+	}
+
+	extractPath, err := stageLocalCopy(destPath)
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(extractPath)
+	if err := extractArchive(sourcePath, extractPath); err != nil {
+		return err
+	}
+	return copySourceFiles(ctx, archiveContentRoot(extractPath), destPath, repo)
+}
+
+// archiveContentRoot returns the directory holding the content of an extracted archive. Archives made
+// from a directory, such as those downloaded from a Git host, wrap all files in a single top-level
+// directory, which is not part of the repository.
+func archiveContentRoot(extractPath string) string {
+	entries, err := os.ReadDir(extractPath)
+	if err != nil || len(entries) != 1 || !entries[0].IsDir() {
+		return extractPath
+	}
+	return filepath.Join(extractPath, entries[0].Name())
+}
+
+// copySourceFiles copies the regular files under the repository's root path from src into dst,
+// skipping any .git directory.
+func copySourceFiles(ctx context.Context, src, dst string, repo entity.Repository) error {
+	return filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		relPath = filepath.ToSlash(relPath)
+		if !repo.ContainsPath(relPath) {
+			return nil
+		}
+		target := filepath.Join(dst, filepath.FromSlash(relPath))
+		if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+			return err
+		}
+		return copyFile(path, target)
+	})
+}
+
+// hashSnapshot returns the content hash recorded as the commit of an import: a SHA-256 over
+// the path and content hash of every file, so it changes whenever any file is added, removed or modified.
+func hashSnapshot(snapshot FileSnapshot) string {
+	paths := make([]string, 0, len(snapshot))
+	for path := range snapshot {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	hash := sha256.New()
+	for _, path := range paths {
+		fmt.Fprintf(hash, "%s\x00%s\n", path, snapshot[path])
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+// ListRepositoryFiles lists the imported files under the repository's root path.
+func (m *localSourceManager) ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error) {
+	snapshot, err := SnapshotFiles(localPath)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(snapshot))
+	for filePath := range snapshot {
+		files = append(files, filePath)
+	}
+	sort.Strings(files)
+	return filterRootPath(repo, files), nil
+}
+
+// DescribeFiles returns the size of each imported file and the import that last changed it.
+func (m *localSourceManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	files, err := m.ListRepositoryFiles(ctx, localPath, repo)
+	if err != nil {
+		return nil, err
+	}
+	state := loadSyncState(syncStatePath(localPath))
+
+	infos := make([]FileInfo, 0, len(files))
+	for _, filePath := range files {
+		stat, err := os.Stat(filepath.Join(localPath, filepath.FromSlash(filePath)))
+		if err != nil {
+			return nil, fmt.Errorf("failed to stat file %s: %w", filePath, err)
+		}
+		infos = append(infos, FileInfo{Path: filePath, Size: stat.Size(), LastCommit: state.FileCommits[filePath]})
+	}
+	return infos, nil
+}
+
+// ValidateFilesExist checks if files exist in the imported repository.
+func (m *localSourceManager) ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error {
+	for _, filePath := range filePaths {
+		if err := checkRootPath(repo, filePath); err != nil {
+			return err
+		}
+		fullPath, err := resolveRepositoryPath(localPath, filePath)
+		if err != nil {
+			return err
+		}
+		if _, err := os.Stat(fullPath); os.IsNotExist(err) {
+			return fmt.Errorf("file does not exist in repository: %s", filePath)
+		} else if err != nil {
+			return fmt.Errorf("error checking if file exists: %s: %w", filePath, err)
+		}
+	}
+	return nil
+}
+
+// ReadManagedFileContent reads the content of an imported file.
+func (m *localSourceManager) ReadManagedFileContent(ctx context.Context, localPath string, filePath string, repo entity.Repository) ([]byte, error) {
+	if err := checkRootPath(repo, filePath); err != nil {
+		return nil, err
+	}
+	fullPath, err := resolveRepositoryPath(localPath, filePath)
+	if err != nil {
+		return nil, err
+	}
+	content, err := os.ReadFile(fullPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read file %s: %w", fullPath, err)
+	}
+	return content, nil
+}
+
+// ResolveHeadCommit returns the content hash of the last import.
+func (m *localSourceManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	state := loadSyncState(syncStatePath(localPath))
+	if state.CommitSHA == "" || !state.tracks(repo) {
+		return "", fmt.Errorf("repository %s has not been imported", repo.URL())
+	}
+	return state.CommitSHA, nil
+}
+
+// ResolveFileCommit returns the content hash of the import that last changed the file.
+func (m *localSourceManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	if err := checkRootPath(repo, filePath); err != nil {
+		return "", err
+	}
+	state := loadSyncState(syncStatePath(localPath))
+	commit, ok := state.FileCommits[filePath]
+	if !ok || !state.tracks(repo) {
+		return "", fmt.Errorf("file %s has not been imported from repository %s", filePath, repo.URL())
+	}
+	return commit.SHA, nil
+}
+
+// LocalPath returns the directory the repository is imported into.
+func (m *localSourceManager) LocalPath(repo entity.Repository) string {
+	return m.getLocalPath(repo)
+}
+
+// RemoveLocalCopy deletes the local copy and its sync state; the source is left untouched.
+func (m *localSourceManager) RemoveLocalCopy(repo entity.Repository) error {
+	localPath := m.getLocalPath(repo)
+	unlock, err := lockLocalCopy(context.Background(), localPath)
+	if err != nil {
+		return err
+	}
+	defer unlock()
+	return removeLocalCopy(localPath)
+}
+
+// Forget deletes the local copy and its sync state, and the repository's archive if it was uploaded.
+func (m *localSourceManager) Forget(repo entity.Repository) error {
+	if err := m.RemoveLocalCopy(repo); err != nil {
+		return err
+	}
+	return m.RemoveArchive(repo.URL())
+}
+
+// VerifyAccess checks that the source can be read. Local sources have no credentials that could expire.
+func (m *localSourceManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	if _, err := m.resolveSource(repo.URL()); err != nil {
+		return rejectedAccess(err.Error()), nil
+	}
+	return AccessCheck{Valid: true}, nil
+}
+
+// SaveArchive stores an uploaded archive under a new name in the upload directory.
+func (m *localSourceManager) SaveArchive(content io.Reader) (string, error) {
+	archivePath := filepath.Join(m.uploadPath, uuid.NewString()+uploadedArchiveExt)
+	if err := m.writeArchive(archivePath, content); err != nil {
+		return "", err
+	}
+	return fileURL(archivePath), nil
+}
+
+// ReplaceArchive overwrites an uploaded archive. Imports running meanwhile read either the old or the new archive.
+func (m *localSourceManager) ReplaceArchive(archiveURL string, content io.Reader) error {
+	archivePath, ok := m.uploadedArchivePath(archiveURL)
+	if !ok {
+		return fmt.Errorf("not an uploaded archive: %s", archiveURL)
+	}
+	return m.writeArchive(archivePath, content)
+}
+
+// RemoveArchive deletes an uploaded archive.
+func (m *localSourceManager) RemoveArchive(archiveURL string) error {
+	archivePath, ok := m.uploadedArchivePath(archiveURL)
+	if !ok {
+		return nil
+	}
+	if err := os.Remove(archivePath); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove archive %s: %w", archivePath, err)
+	}
+	return nil
+}
+
+// IsUploadedArchive reports whether the URL refers to an archive in the upload directory.
+func (m *localSourceManager) IsUploadedArchive(archiveURL string) bool {
+	_, ok := m.uploadedArchivePath(archiveURL)
+	return ok
+}
+
+// uploadedArchivePath returns the path of an archive in the upload directory referred to by a file:// URL.
+func (m *localSourceManager) uploadedArchivePath(archiveURL string) (string, bool) {
+	archivePath, err := ParseFileURL(archiveURL)
+	if err != nil || filepath.Dir(archivePath) != m.uploadPath || filepath.Ext(archivePath) != uploadedArchiveExt {
+		return "", false
+	}
+	return archivePath, true
+}
+
+// writeArchive writes an archive to a temporary file in the upload directory and renames it to archivePath
+// once it is complete and known to be a supported archive of at most MaxArchiveSize bytes.
+func (m *localSourceManager) writeArchive(archivePath string, content io.Reader) error {
+	file, err := os.CreateTemp(m.uploadPath, ".upload-")
+	if err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	defer os.Remove(file.Name())
+	defer file.Close()
+
+	written, err := io.Copy(file, io.LimitReader(content, MaxArchiveSize+1))
+	if err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	if written > MaxArchiveSize {
+		return fmt.Errorf("%w: at most %d bytes are accepted", ErrArchiveTooLarge, MaxArchiveSize)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	if _, err := readArchiveFormat(file); err != nil {
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	if err := os.Rename(file.Name(), archivePath); err != nil {
+		return fmt.Errorf("failed to store archive: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,209 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestLocalSourceManager creates a localSourceManager that may read sources under the returned directory
+func newTestLocalSourceManager(t *testing.T) (LocalSourceManager, string) {
+	t.Helper()
+	dir := t.TempDir()
+	sourceRoot := filepath.Join(dir, "sources")
+	require.NoError(t, os.MkdirAll(sourceRoot, 0755))
+	manager, err := NewLocalSourceManager(filepath.Join(dir, "repos"), filepath.Join(dir, "uploads"), []string{sourceRoot})
+	require.NoError(t, err)
+	sourceRoot, err = filepath.EvalSymlinks(sourceRoot)
+	require.NoError(t, err)
+	return manager, sourceRoot
+}
+
+// writeSourceFiles writes files into a source directory
+func writeSourceFiles(t *testing.T, dir string, files map[string]string) {
+	t.Helper()
+	for name, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0644))
+	}
+}
+
+// TestParseFileURL tests parsing file:// URLs of local sources
+func TestParseFileURL(t *testing.T) {
+	// テスト：ローカルの絶対パスを指すURLが解析されることを確認する
+	t.Run("ローカルの絶対パスを指すURLが解析される", func(t *testing.T) {
+		for _, sourceURL := range []string{"file:///srv/runbooks", "file://localhost/srv/runbooks"} {
+			path, err := ParseFileURL(sourceURL)
+			require.NoError(t, err, sourceURL)
+			assert.Equal(t, filepath.FromSlash("/srv/runbooks"), path)
+		}
+	})
+
+	// テスト：file以外のスキームや他のホストを指すURLはエラーになることを確認する
+	t.Run("file以外のスキームや他のホストを指すURLはエラーになる", func(t *testing.T) {
+		for _, sourceURL := range []string{"https://github.com/example/runbooks", "file://fileserver/srv/runbooks", "file:relative/path", "/srv/runbooks"} {
+			_, err := ParseFileURL(sourceURL)
+			assert.Error(t, err, sourceURL)
+		}
+	})
+}
+
+// TestLocalSourceManager_EnsureCloned tests importing repositories from local directories and archives
+func TestLocalSourceManager_EnsureCloned(t *testing.T) {
+	ctx := context.Background()
+
+	// テスト：ディレクトリのファイルがルートパス配下だけ取り込まれ、内容のハッシュがコミットになることを確認する
+	t.Run("ディレクトリのファイルがルートパス配下だけ取り込まれ、内容のハッシュがコミットになる", func(t *testing.T) {
+		manager, sourceRoot := newTestLocalSourceManager(t)
+		sourcePath := filepath.Join(sourceRoot, "runbooks")
+		writeSourceFiles(t, sourcePath, map[string]string{"docs/guide.md": "guide", "README.md": "readme", ".git/HEAD": "ref"})
+		repo := entity.NewRepository("local-repo-id", "runbooks", fileURL(sourcePath), entity.ProviderLocal, "")
+		repo.SetTracking("", "docs")
+
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[string]string{"docs/guide.md": "guide"}, readLocalFiles(t, localPath))
+		head, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.Len(t, head, 64)
+		fileCommit, err := manager.ResolveFileCommit(ctx, localPath, "docs/guide.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, head, fileCommit)
+	})
+
+	// テスト：変更されたファイルだけ新しい取り込みのコミットになることを確認する
+	t.Run("変更されたファイルだけ新しい取り込みのコミットになる", func(t *testing.T) {
+		manager, sourceRoot := newTestLocalSourceManager(t)
+		writeSourceFiles(t, sourceRoot, map[string]string{"guide.md": "guide", "README.md": "readme"})
+		repo := entity.NewRepository("local-repo-id", "runbooks", fileURL(sourceRoot), entity.ProviderLocal, "")
+
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		firstHead, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+
+		// 内容が変わらなければコミットも変わらない
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		head, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.Equal(t, firstHead, head)
+
+		writeSourceFiles(t, sourceRoot, map[string]string{"guide.md": "updated guide"})
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+
+		secondHead, err := manager.ResolveHeadCommit(ctx, localPath, repo)
+		require.NoError(t, err)
+		assert.NotEqual(t, firstHead, secondHead)
+		guideCommit, err := manager.ResolveFileCommit(ctx, localPath, "guide.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, secondHead, guideCommit)
+		readmeCommit, err := manager.ResolveFileCommit(ctx, localPath, "README.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, firstHead, readmeCommit)
+		content, err := manager.ReadManagedFileContent(ctx, localPath, "guide.md", repo)
+		require.NoError(t, err)
+		assert.Equal(t, "updated guide", string(content))
+	})
+
+	// テスト：アーカイブが展開され、単一の最上位ディレクトリは取り除かれることを確認する
+	t.Run("アーカイブが展開され、単一の最上位ディレクトリは取り除かれる", func(t *testing.T) {
+		manager, sourceRoot := newTestLocalSourceManager(t)
+		writeTarGz(t, filepath.Join(sourceRoot, "runbooks.tar.gz"), map[string]string{"runbooks-main/README.md": "readme", "runbooks-main/docs/guide.md": "guide"})
+		writeZip(t, filepath.Join(sourceRoot, "runbooks.zip"), map[string]string{"README.md": "readme", "docs/guide.md": "guide"})
+
+		for i, name := range []string{"runbooks.tar.gz", "runbooks.zip"} {
+			repo := entity.NewRepository(fmt.Sprintf("archive-repo-%d", i), "runbooks", fileURL(filepath.Join(sourceRoot, name)), entity.ProviderLocal, "")
+			localPath, err := manager.EnsureCloned(ctx, repo)
+			require.NoError(t, err, name)
+
+			assert.Equal(t, map[string]string{"README.md": "readme", "docs/guide.md": "guide"}, readLocalFiles(t, localPath), name)
+			files, err := manager.ListRepositoryFiles(ctx, localPath, repo)
+			require.NoError(t, err)
+			assert.Equal(t, []string{"README.md", "docs/guide.md"}, files)
+		}
+	})
+
+	// テスト：許可されたディレクトリの外にあるソースは取り込めないことを確認する
+	t.Run("許可されたディレクトリの外にあるソースは取り込めない", func(t *testing.T) {
+		manager, _ := newTestLocalSourceManager(t)
+		outside := t.TempDir()
+		writeSourceFiles(t, outside, map[string]string{"README.md": "readme"})
+		repo := entity.NewRepository("local-repo-id", "runbooks", fileURL(outside), entity.ProviderLocal, "")
+
+		_, err := manager.EnsureCloned(ctx, repo)
+		assert.ErrorIs(t, err, errSourceNotAllowed)
+
+		check, err := manager.VerifyAccess(ctx, repo)
+		require.NoError(t, err)
+		assert.False(t, check.Valid)
+		assert.Contains(t, check.Reason, "outside the allowed directories")
+	})
+}
+
+// TestLocalSourceManager_Archives tests storing uploaded archives
+func TestLocalSourceManager_Archives(t *testing.T) {
+	ctx := context.Background()
+
+	// テスト：アップロードしたアーカイブを保存・置き換え・削除できることを確認する
+	t.Run("アップロードしたアーカイブを保存・置き換え・削除できる", func(t *testing.T) {
+		manager, sourceRoot := newTestLocalSourceManager(t)
+		writeTarGz(t, filepath.Join(sourceRoot, "v1.tar.gz"), map[string]string{"README.md": "v1"})
+		writeZip(t, filepath.Join(sourceRoot, "v2.zip"), map[string]string{"README.md": "v2"})
+
+		v1, err := os.Open(filepath.Join(sourceRoot, "v1.tar.gz"))
+		require.NoError(t, err)
+		defer v1.Close()
+		archiveURL, err := manager.SaveArchive(v1)
+		require.NoError(t, err)
+		assert.True(t, manager.IsUploadedArchive(archiveURL))
+
+		repo := entity.NewRepository("upload-repo-id", "runbooks", archiveURL, entity.ProviderLocal, "")
+		localPath, err := manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"README.md": "v1"}, readLocalFiles(t, localPath))
+
+		v2, err := os.Open(filepath.Join(sourceRoot, "v2.zip"))
+		require.NoError(t, err)
+		defer v2.Close()
+		require.NoError(t, manager.ReplaceArchive(archiveURL, v2))
+		_, err = manager.EnsureCloned(ctx, repo)
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"README.md": "v2"}, readLocalFiles(t, localPath))
+
+		require.NoError(t, manager.Forget(repo))
+		archivePath, err := ParseFileURL(archiveURL)
+		require.NoError(t, err)
+		assert.NoFileExists(t, archivePath)
+		assert.NoDirExists(t, localPath)
+	})
+
+	// テスト：アーカイブ以外のファイルは保存されないことを確認する
+	t.Run("アーカイブ以外のファイルは保存されない", func(t *testing.T) {
+		manager, _ := newTestLocalSourceManager(t)
+
+		_, err := manager.SaveArchive(strings.NewReader("# not an archive"))
+		assert.ErrorIs(t, err, ErrUnsupportedArchive)
+	})
+
+	// テスト：アップロード先以外のURLはアップロードしたアーカイブとして扱われないことを確認する
+	t.Run("アップロード先以外のURLはアップロードしたアーカイブとして扱われない", func(t *testing.T) {
+		manager, sourceRoot := newTestLocalSourceManager(t)
+		sourceURL := fileURL(filepath.Join(sourceRoot, "runbooks.archive"))
+
+		assert.False(t, manager.IsUploadedArchive(sourceURL))
+		assert.Error(t, manager.ReplaceArchive(sourceURL, strings.NewReader("archive")))
+		assert.NoError(t, manager.RemoveArchive(sourceURL))
+	})
+}
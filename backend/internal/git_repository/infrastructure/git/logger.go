@@ -0,0 +1,11 @@
+package git
+
+import "log/slog"
+
+// logger records the progress of syncs and the failures the managers recover from.
+var logger = slog.Default()
+
+// SetLogger replaces the logger used by the managers. It is meant to be called once at startup.
+func SetLogger(l *slog.Logger) {
+	logger = l
+}
@@ -0,0 +1,36 @@
+package git
+
+import (
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockArchiveStore is a mock implementation of ArchiveStore interface for testing
+type MockArchiveStore struct {
+	mock.Mock
+}
+
+// SaveArchive is a mock implementation of the ArchiveStore.SaveArchive method
+func (m *MockArchiveStore) SaveArchive(content io.Reader) (string, error) {
+	args := m.Called(content)
+	return args.String(0), args.Error(1)
+}
+
+// ReplaceArchive is a mock implementation of the ArchiveStore.ReplaceArchive method
+func (m *MockArchiveStore) ReplaceArchive(archiveURL string, content io.Reader) error {
+	args := m.Called(archiveURL, content)
+	return args.Error(0)
+}
+
+// RemoveArchive is a mock implementation of the ArchiveStore.RemoveArchive method
+func (m *MockArchiveStore) RemoveArchive(archiveURL string) error {
+	args := m.Called(archiveURL)
+	return args.Error(0)
+}
+
+// IsUploadedArchive is a mock implementation of the ArchiveStore.IsUploadedArchive method
+func (m *MockArchiveStore) IsUploadedArchive(archiveURL string) bool {
+	args := m.Called(archiveURL)
+	return args.Bool(0)
+}
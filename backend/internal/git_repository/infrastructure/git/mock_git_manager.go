@@ -43,6 +43,15 @@ func (m *MockGitManager) ListRepositoryFiles(ctx context.Context, localPath stri
 	return args.Get(0).([]string), args.Error(1)
 }
 
+// DescribeFiles is a mock implementation of the GitManager.DescribeFiles method
+func (m *MockGitManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	args := m.Called(ctx, localPath, repo)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]FileInfo), args.Error(1)
+}
+
 // ValidateFilesExist is a mock implementation of the GitManager.ValidateFilesExist method
 func (m *MockGitManager) ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error {
 	args := m.Called(ctx, localPath, filePaths, repo)
@@ -57,3 +66,39 @@ func (m *MockGitManager) ReadManagedFileContent(ctx context.Context, localPath s
 	}
 	return args.Get(0).([]byte), args.Error(1)
 }
+
+// LocalPath is a mock implementation of the GitManager.LocalPath method
+func (m *MockGitManager) LocalPath(repo entity.Repository) string {
+	args := m.Called(repo)
+	return args.String(0)
+}
+
+// ResolveHeadCommit is a mock implementation of the GitManager.ResolveHeadCommit method
+func (m *MockGitManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	args := m.Called(ctx, localPath, repo)
+	return args.String(0), args.Error(1)
+}
+
+// ResolveFileCommit is a mock implementation of the GitManager.ResolveFileCommit method
+func (m *MockGitManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	args := m.Called(ctx, localPath, filePath, repo)
+	return args.String(0), args.Error(1)
+}
+
+// RemoveLocalCopy is a mock implementation of the GitManager.RemoveLocalCopy method
+func (m *MockGitManager) RemoveLocalCopy(repo entity.Repository) error {
+	args := m.Called(repo)
+	return args.Error(0)
+}
+
+// VerifyAccess is a mock implementation of the GitManager.VerifyAccess method
+func (m *MockGitManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	args := m.Called(ctx, repo)
+	return args.Get(0).(AccessCheck), args.Error(1)
+}
+
+// Forget is a mock implementation of the GitManager.Forget method
+func (m *MockGitManager) Forget(repo entity.Repository) error {
+	args := m.Called(repo)
+	return args.Error(0)
+}
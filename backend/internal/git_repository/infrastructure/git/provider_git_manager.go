@@ -0,0 +1,140 @@
+package git
+
+import (
+	"context"
+	"fmt"
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// providerGitManager implements the GitManager interface by delegating to the manager registered for the repository's provider.
+// Calls that read a local copy hold it against concurrent swaps and updates for their duration (see localCopyReaders).
+type providerGitManager struct {
+	managers         map[entity.Provider]GitManager
+	deployKeyManager GitManager // Handles repositories authenticated with an SSH deploy key regardless of provider
+}
+
+// NewProviderGitManager creates a GitManager that routes each call to the manager of the repository's provider.
+// Repositories authenticated with a deploy key are routed to deployKeyManager, which must support SSH.
+func NewProviderGitManager(managers map[entity.Provider]GitManager, deployKeyManager GitManager) GitManager {
+	return &providerGitManager{managers: managers, deployKeyManager: deployKeyManager}
+}
+
+// managerFor returns the manager registered for the repository's provider.
+func (p *providerGitManager) managerFor(repo entity.Repository) (GitManager, error) {
+	if repo.AuthMethod() == entity.AuthMethodDeployKey {
+		if p.deployKeyManager == nil {
+			return nil, fmt.Errorf("no git manager registered for deploy key authentication")
+		}
+		return p.deployKeyManager, nil
+	}
+	manager, ok := p.managers[repo.Provider()]
+	if !ok {
+		return nil, fmt.Errorf("no git manager registered for provider %q", repo.Provider())
+	}
+	return manager, nil
+}
+
+// EnsureCloned delegates to the provider's manager.
+func (p *providerGitManager) EnsureCloned(ctx context.Context, repo entity.Repository) (string, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return "", err
+	}
+	return manager.EnsureCloned(ctx, repo)
+}
+
+// ListRepositoryFiles delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) ListRepositoryFiles(ctx context.Context, localPath string, repo entity.Repository) ([]string, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return nil, err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.ListRepositoryFiles(ctx, localPath, repo)
+}
+
+// DescribeFiles delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) DescribeFiles(ctx context.Context, localPath string, repo entity.Repository) ([]FileInfo, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return nil, err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.DescribeFiles(ctx, localPath, repo)
+}
+
+// ValidateFilesExist delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) ValidateFilesExist(ctx context.Context, localPath string, filePaths []string, repo entity.Repository) error {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.ValidateFilesExist(ctx, localPath, filePaths, repo)
+}
+
+// ReadManagedFileContent delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) ReadManagedFileContent(ctx context.Context, localPath string, filePath string, repo entity.Repository) ([]byte, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return nil, err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.ReadManagedFileContent(ctx, localPath, filePath, repo)
+}
+
+// LocalPath delegates to the provider's manager; it is empty when no manager handles the repository.
+func (p *providerGitManager) LocalPath(repo entity.Repository) string {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return ""
+	}
+	return manager.LocalPath(repo)
+}
+
+// ResolveHeadCommit delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) ResolveHeadCommit(ctx context.Context, localPath string, repo entity.Repository) (string, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return "", err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.ResolveHeadCommit(ctx, localPath, repo)
+}
+
+// ResolveFileCommit delegates to the provider's manager while holding the local copy.
+func (p *providerGitManager) ResolveFileCommit(ctx context.Context, localPath string, filePath string, repo entity.Repository) (string, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return "", err
+	}
+	defer readLocalCopy(localPath)()
+	return manager.ResolveFileCommit(ctx, localPath, filePath, repo)
+}
+
+// RemoveLocalCopy delegates to the provider's manager.
+func (p *providerGitManager) RemoveLocalCopy(repo entity.Repository) error {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return err
+	}
+	return manager.RemoveLocalCopy(repo)
+}
+
+// VerifyAccess delegates to the provider's manager.
+func (p *providerGitManager) VerifyAccess(ctx context.Context, repo entity.Repository) (AccessCheck, error) {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return AccessCheck{}, err
+	}
+	return manager.VerifyAccess(ctx, repo)
+}
+
+// Forget delegates to the provider's manager.
+func (p *providerGitManager) Forget(repo entity.Repository) error {
+	manager, err := p.managerFor(repo)
+	if err != nil {
+		return err
+	}
+	return manager.Forget(repo)
+}
@@ -0,0 +1,58 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// scpLikeURLPattern matches scp-like SSH remotes such as git@host:owner/repo.git.
+// The host and path must not start with "-" so the URL cannot be mistaken for a command-line option.
+var scpLikeURLPattern = regexp.MustCompile(`^(?:[a-zA-Z0-9._-]+@)?([a-zA-Z0-9][a-zA-Z0-9.-]*):([a-zA-Z0-9_.~][a-zA-Z0-9_.~/-]*)$`)
+
+// IsSSHURL reports whether the remote URL uses SSH, either as ssh://host/path or in the scp-like user@host:path form.
+func IsSSHURL(remoteURL string) bool {
+	if strings.HasPrefix(remoteURL, "ssh://") {
+		return true
+	}
+	return !strings.Contains(remoteURL, "://") && scpLikeURLPattern.MatchString(remoteURL)
+}
+
+// ParseRemoteURL extracts the host and repository path from an https, ssh or scp-like remote URL.
+// The returned path never has a leading slash.
+func ParseRemoteURL(remoteURL string) (string, string, error) {
+	if !strings.Contains(remoteURL, "://") {
+		matches := scpLikeURLPattern.FindStringSubmatch(remoteURL)
+		if matches == nil {
+			return "", "", fmt.Errorf("invalid remote URL: %s", remoteURL)
+		}
+		return validateRemoteParts(remoteURL, matches[1], matches[2])
+	}
+
+	parsedURL, err := url.Parse(remoteURL)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid remote URL: %w", err)
+	}
+	if parsedURL.Scheme != "https" && parsedURL.Scheme != "ssh" {
+		return "", "", fmt.Errorf("unsupported remote URL scheme: %s", parsedURL.Scheme)
+	}
+	if parsedURL.Scheme == "https" && parsedURL.User != nil {
+		if _, hasPassword := parsedURL.User.Password(); hasPassword {
+			return "", "", fmt.Errorf("remote URL must not contain credentials: use an access token instead")
+		}
+	}
+	return validateRemoteParts(remoteURL, parsedURL.Hostname(), parsedURL.Path)
+}
+
+// validateRemoteParts checks the host and path extracted from a remote URL.
+func validateRemoteParts(remoteURL, host, repoPath string) (string, string, error) {
+	repoPath = strings.Trim(repoPath, "/")
+	if host == "" || strings.HasPrefix(host, "-") {
+		return "", "", fmt.Errorf("invalid host in remote URL: %s", remoteURL)
+	}
+	if repoPath == "" || strings.Contains(repoPath, "..") {
+		return "", "", fmt.Errorf("invalid repository path in remote URL: %s", remoteURL)
+	}
+	return host, repoPath, nil
+}
@@ -0,0 +1,89 @@
+package git
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+)
+
+// FileSnapshot maps each file path in a local copy, relative to its root, to a hash of its content.
+type FileSnapshot map[string]string
+
+// SnapshotFiles hashes every file under localPath, skipping the .git directory.
+// A missing localPath yields an empty snapshot.
+func SnapshotFiles(localPath string) (FileSnapshot, error) {
+	snapshot := FileSnapshot{}
+	if localPath == "" {
+		return snapshot, nil
+	}
+	if _, err := os.Stat(localPath); os.IsNotExist(err) {
+		return snapshot, nil
+	}
+
+	err := filepath.WalkDir(localPath, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if d.Name() == ".git" {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !d.Type().IsRegular() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(localPath, path)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFile(path)
+		if err != nil {
+			return err
+		}
+		snapshot[filepath.ToSlash(relPath)] = hash
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to snapshot files in %s: %w", localPath, err)
+	}
+	return snapshot, nil
+}
+
+// ChangedFiles returns the sorted paths added, modified or removed between two snapshots.
+func ChangedFiles(before, after FileSnapshot) []string {
+	changed := []string{}
+	for path, hash := range after {
+		if before[path] != hash {
+			changed = append(changed, path)
+		}
+	}
+	for path := range before {
+		if _, ok := after[path]; !ok {
+			changed = append(changed, path)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+// hashFile returns the hex-encoded SHA-256 of a file's content.
+func hashFile(path string) (string, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return "", err
+	}
+	defer file.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, file); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
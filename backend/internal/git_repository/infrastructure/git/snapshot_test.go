@@ -0,0 +1,47 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestSnapshotFiles tests hashing a local copy and comparing snapshots
+func TestSnapshotFiles(t *testing.T) {
+	t.Run("存在しないディレクトリは空のスナップショットになる", func(t *testing.T) {
+		snapshot, err := SnapshotFiles(filepath.Join(t.TempDir(), "missing"))
+		require.NoError(t, err)
+		assert.Empty(t, snapshot)
+	})
+
+	t.Run("追加・変更・削除されたファイルを検出する", func(t *testing.T) {
+		dir := t.TempDir()
+		writeFile := func(path, content string) {
+			fullPath := filepath.Join(dir, path)
+			require.NoError(t, os.MkdirAll(filepath.Dir(fullPath), 0755))
+			require.NoError(t, os.WriteFile(fullPath, []byte(content), 0644))
+		}
+		writeFile("README.md", "# Runbooks")
+		writeFile("docs/deploy.md", "v1")
+		writeFile("docs/old.md", "old")
+		writeFile(".git/HEAD", "ref: refs/heads/main")
+
+		before, err := SnapshotFiles(dir)
+		require.NoError(t, err)
+		assert.Len(t, before, 3, ".git should be skipped")
+
+		writeFile("docs/deploy.md", "v2")
+		writeFile("docs/new.md", "new")
+		require.NoError(t, os.Remove(filepath.Join(dir, "docs/old.md")))
+		writeFile(".git/HEAD", "ref: refs/heads/other")
+
+		after, err := SnapshotFiles(dir)
+		require.NoError(t, err)
+
+		assert.Equal(t, []string{"docs/deploy.md", "docs/new.md", "docs/old.md"}, ChangedFiles(before, after))
+		assert.Empty(t, ChangedFiles(after, after))
+	})
+}
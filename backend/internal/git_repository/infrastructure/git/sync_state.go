@@ -0,0 +1,108 @@
+package git
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// syncStateMu serializes writes of sync states, so that an update based on a state that was read
+// cannot overwrite a newer state saved by a sync in the meantime.
+var syncStateMu sync.Mutex
+
+// syncState is persisted next to a local copy downloaded through a provider API.
+// It records the commit the copy is at and lets later syncs use conditional requests.
+type syncState struct {
+	CommitSHA string `json:"commit_sha"`          // Commit the local copy was last synced to
+	ETag      string `json:"etag,omitempty"`      // ETag of the HEAD commit response for CommitSHA, if the provider sends one
+	Ref       string `json:"ref,omitempty"`       // Tracked ref the local copy was synced from; empty is the default branch
+	RootPath  string `json:"root_path,omitempty"` // Root path the local copy was restricted to
+
+	// FileCommits caches the last commit of each file. For repositories downloaded through a provider API it is
+	// carried across syncs and each entry is valid while the file's blob SHA matches FileBlobs.
+	// For local sources, which have no history, it records the import that last changed each file instead.
+	FileCommits map[string]CommitInfo `json:"file_commits,omitempty"`
+	FileBlobs   map[string]string     `json:"file_blobs,omitempty"` // Git blob SHA of each file when its commit was cached
+}
+
+// newSyncState returns the state of a local copy synced to commitSHA with the repository's tracking settings.
+func newSyncState(repo entity.Repository, commitSHA, etag string) syncState {
+	return syncState{CommitSHA: commitSHA, ETag: etag, Ref: repo.Ref(), RootPath: repo.RootPath()}
+}
+
+// withFileCommits returns the state with the file commits cached in previous, which stay valid for the files
+// whose content a sync did not change; see describeFilesFromAPI.
+func (s syncState) withFileCommits(previous syncState) syncState {
+	s.FileCommits, s.FileBlobs = previous.FileCommits, previous.FileBlobs
+	return s
+}
+
+// tracks reports whether the state was recorded with the repository's current ref and root path.
+func (s syncState) tracks(repo entity.Repository) bool {
+	return s.Ref == repo.Ref() && s.RootPath == repo.RootPath()
+}
+
+// syncStatePath returns the file that stores the sync state of a repository.
+// It lives outside the local copy so it never shows up as a repository file.
+func syncStatePath(localPath string) string {
+	return localPath + ".sync.json"
+}
+
+// loadSyncState reads the sync state, returning an empty state if there is none.
+func loadSyncState(statePath string) syncState {
+	var state syncState
+	data, err := os.ReadFile(statePath)
+	if err != nil {
+		return syncState{}
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return syncState{}
+	}
+	return state
+}
+
+// saveSyncState writes the sync state.
+func saveSyncState(statePath string, state syncState) error {
+	syncStateMu.Lock()
+	defer syncStateMu.Unlock()
+	return writeSyncState(statePath, state)
+}
+
+// updateSyncState applies update to the current sync state and writes the result if update returns true.
+func updateSyncState(statePath string, update func(state *syncState) bool) error {
+	syncStateMu.Lock()
+	defer syncStateMu.Unlock()
+	state := loadSyncState(statePath)
+	if !update(&state) {
+		return nil
+	}
+	return writeSyncState(statePath, state)
+}
+
+// writeSyncState writes the sync state to a temporary file that replaces the previous state,
+// so readers never load a partially written state.
+func writeSyncState(statePath string, state syncState) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	file, err := os.CreateTemp(filepath.Dir(statePath), filepath.Base(statePath)+".tmp-")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	if err := os.Chmod(file.Name(), 0644); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), statePath)
+}
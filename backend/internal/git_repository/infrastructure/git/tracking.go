@@ -0,0 +1,63 @@
+package git
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+)
+
+// trackedRef returns the ref the repository is synced from, or HEAD (the default branch) when none is pinned.
+func trackedRef(repo entity.Repository) string {
+	if repo.Ref() != "" {
+		return repo.Ref()
+	}
+	return "HEAD"
+}
+
+// escapeRef escapes a ref for use in a URL path, keeping the slashes of names like release/v1.
+func escapeRef(ref string) string {
+	segments := strings.Split(ref, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// filterRootPath returns the files that are inside the repository's root path, in their original order.
+func filterRootPath(repo entity.Repository, files []string) []string {
+	if repo.RootPath() == "" {
+		return files
+	}
+	result := make([]string, 0, len(files))
+	for _, f := range files {
+		if repo.ContainsPath(f) {
+			result = append(result, f)
+		}
+	}
+	return result
+}
+
+// checkRootPath returns an error if the file is outside the repository's root path.
+func checkRootPath(repo entity.Repository, filePath string) error {
+	if !repo.ContainsPath(filePath) {
+		return fmt.Errorf("file is outside the repository root path %s: %s", repo.RootPath(), filePath)
+	}
+	return nil
+}
+
+// removeLocalCopy deletes a local copy together with its sync state, so the next sync starts from scratch.
+func removeLocalCopy(localPath string) error {
+	err := changeLocalCopy(localPath, func() error {
+		return os.RemoveAll(localPath)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove local copy %s: %w", localPath, err)
+	}
+	if err := os.Remove(syncStatePath(localPath)); err != nil && !os.IsNotExist(err) {
+		return fmt.Errorf("failed to remove sync state of %s: %w", localPath, err)
+	}
+	return nil
+}
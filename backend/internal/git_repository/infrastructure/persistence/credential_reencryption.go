@@ -0,0 +1,139 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"opscore/backend/internal/git_repository/infrastructure/encryption"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ReencryptCredentials re-encrypts every stored access token, deploy key and GitHub App private key that was not
+// encrypted with the active key of the encryptor, and returns the number of repositories and GitHub Apps updated.
+// It runs in a single transaction, so either all credentials move to the active key or none do. The keys that encrypted the current data must
+// still be in the encryptor's keyring.
+func ReencryptCredentials(ctx context.Context, db *pgxpool.Pool, encryptor *encryption.Encryptor) (int, error) {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	query := `
+		SELECT id, access_token, deploy_key
+		FROM repositories
+		FOR UPDATE;
+	`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query repository credentials: %w", err)
+	}
+
+	type credentials struct {
+		id                     string
+		accessToken, deployKey sql.NullString
+	}
+	var stale []credentials
+	for rows.Next() {
+		var c credentials
+		if err := rows.Scan(&c.id, &c.accessToken, &c.deployKey); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan repository credentials: %w", err)
+		}
+		if encryptor.NeedsReencryption(c.accessToken.String) || encryptor.NeedsReencryption(c.deployKey.String) {
+			stale = append(stale, c)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating repository credentials: %w", err)
+	}
+
+	for _, c := range stale {
+		accessToken, err := reencrypt(ctx, encryptor, c.accessToken.String)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt access token of repository %s: %w", c.id, err)
+		}
+		deployKey, err := reencrypt(ctx, encryptor, c.deployKey.String)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt deploy key of repository %s: %w", c.id, err)
+		}
+
+		update := `
+			UPDATE repositories
+			SET access_token = $2, deploy_key = $3
+			WHERE id = $1;
+		`
+		if _, err := tx.Exec(ctx, update, c.id, accessToken, deployKey); err != nil {
+			return 0, fmt.Errorf("failed to update credentials of repository %s: %w", c.id, err)
+		}
+	}
+
+	apps, err := reencryptGitHubAppKeys(ctx, tx, encryptor)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return len(stale) + apps, nil
+}
+
+// reencryptGitHubAppKeys re-encrypts the private keys of the GitHub Apps within the transaction and returns the number updated.
+func reencryptGitHubAppKeys(ctx context.Context, tx pgx.Tx, encryptor *encryption.Encryptor) (int, error) {
+	query := `
+		SELECT id, private_key
+		FROM github_apps
+		FOR UPDATE;
+	`
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query GitHub App private keys: %w", err)
+	}
+
+	type privateKey struct {
+		id, key string
+	}
+	var stale []privateKey
+	for rows.Next() {
+		var k privateKey
+		if err := rows.Scan(&k.id, &k.key); err != nil {
+			rows.Close()
+			return 0, fmt.Errorf("failed to scan GitHub App private key: %w", err)
+		}
+		if encryptor.NeedsReencryption(k.key) {
+			stale = append(stale, k)
+		}
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, fmt.Errorf("error iterating GitHub App private keys: %w", err)
+	}
+
+	for _, k := range stale {
+		key, err := reencrypt(ctx, encryptor, k.key)
+		if err != nil {
+			return 0, fmt.Errorf("failed to re-encrypt private key of GitHub App %s: %w", k.id, err)
+		}
+		if _, err := tx.Exec(ctx, `UPDATE github_apps SET private_key = $2 WHERE id = $1;`, k.id, key); err != nil {
+			return 0, fmt.Errorf("failed to update private key of GitHub App %s: %w", k.id, err)
+		}
+	}
+	return len(stale), nil
+}
+
+// reencrypt decrypts a ciphertext and encrypts it again with the active key; ciphertexts that already use it are kept.
+func reencrypt(ctx context.Context, encryptor *encryption.Encryptor, ciphertext string) (string, error) {
+	if !encryptor.NeedsReencryption(ciphertext) {
+		return ciphertext, nil
+	}
+	plaintext, err := encryptor.DecryptContext(ctx, ciphertext)
+	if err != nil {
+		return "", err
+	}
+	return encryptor.EncryptContext(ctx, plaintext)
+}
@@ -0,0 +1,5 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000008_create_auth_tables.down.sql
+-- Drop user_sessions and user_credentials tables
+
+DROP TABLE IF EXISTS user_sessions;
+DROP TABLE IF EXISTS user_credentials;
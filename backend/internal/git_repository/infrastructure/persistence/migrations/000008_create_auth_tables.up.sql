@@ -0,0 +1,20 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000008_create_auth_tables.up.sql
+-- Create user_credentials and user_sessions tables for local authentication
+
+-- user_credentials table (bcrypt password hashes, one per user)
+CREATE TABLE user_credentials (
+    user_id UUID PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    password_hash VARCHAR(255) NOT NULL,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- user_sessions table (server-side sessions, only the SHA-256 of the token is stored)
+CREATE TABLE user_sessions (
+    token_hash VARCHAR(64) PRIMARY KEY,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    expires_at TIMESTAMPTZ NOT NULL
+);
+
+CREATE INDEX idx_user_sessions_user_id ON user_sessions(user_id);
+CREATE INDEX idx_user_sessions_expires_at ON user_sessions(expires_at);
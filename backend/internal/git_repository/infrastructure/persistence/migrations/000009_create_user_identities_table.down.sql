@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000009_create_user_identities_table.down.sql
+-- Drop user_identities table
+
+DROP TABLE IF EXISTS user_identities;
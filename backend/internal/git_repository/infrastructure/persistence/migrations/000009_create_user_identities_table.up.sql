@@ -0,0 +1,13 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000009_create_user_identities_table.up.sql
+-- Create user_identities table linking external (OIDC) identities to users
+
+CREATE TABLE user_identities (
+    issuer VARCHAR(512) NOT NULL,
+    subject VARCHAR(255) NOT NULL,
+    user_id UUID NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    last_login_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (issuer, subject)
+);
+
+CREATE INDEX idx_user_identities_user_id ON user_identities(user_id);
@@ -0,0 +1,6 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000010_create_roles_tables.down.sql
+-- Drop group_roles, user_roles and roles tables
+
+DROP TABLE IF EXISTS group_roles;
+DROP TABLE IF EXISTS user_roles;
+DROP TABLE IF EXISTS roles;
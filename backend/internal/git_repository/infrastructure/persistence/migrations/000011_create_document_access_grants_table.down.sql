@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000011_create_document_access_grants_table.down.sql
+-- Drop document_access_grants table
+
+DROP TABLE IF EXISTS document_access_grants;
@@ -0,0 +1,15 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000011_create_document_access_grants_table.up.sql
+-- Create document_access_grants table for per-document ACL entries
+
+-- document_access_grants table (grants a user or a group view or execute access to a private document)
+CREATE TABLE document_access_grants (
+    document_id UUID NOT NULL REFERENCES documents(id) ON DELETE CASCADE,
+    principal_type VARCHAR(50) NOT NULL CHECK (principal_type IN ('user', 'group')),
+    principal_id VARCHAR(255) NOT NULL,
+    access_level VARCHAR(50) NOT NULL CHECK (access_level IN ('view', 'execute')),
+    granted_by VARCHAR(255) NOT NULL DEFAULT '',
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (document_id, principal_type, principal_id)
+);
+
+CREATE INDEX idx_document_access_grants_principal ON document_access_grants(principal_type, principal_id);
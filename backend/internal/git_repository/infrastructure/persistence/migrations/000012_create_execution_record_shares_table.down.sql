@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000012_create_execution_record_shares_table.down.sql
+-- Drop execution_record_shares table
+
+DROP TABLE IF EXISTS execution_record_shares;
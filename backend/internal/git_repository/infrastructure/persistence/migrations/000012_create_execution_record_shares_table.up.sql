@@ -0,0 +1,15 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000012_create_execution_record_shares_table.up.sql
+-- Create execution_record_shares table for sharing execution records with users and groups
+
+-- execution_record_shares table (grants a user or a group read or comment access to an execution record)
+CREATE TABLE execution_record_shares (
+    execution_record_id UUID NOT NULL REFERENCES execution_records(id) ON DELETE CASCADE,
+    principal_type VARCHAR(50) NOT NULL CHECK (principal_type IN ('user', 'group')),
+    principal_id VARCHAR(255) NOT NULL,
+    share_level VARCHAR(50) NOT NULL CHECK (share_level IN ('read', 'comment')),
+    granted_by VARCHAR(255) NOT NULL DEFAULT '',
+    granted_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (execution_record_id, principal_type, principal_id)
+);
+
+CREATE INDEX idx_execution_record_shares_principal ON execution_record_shares(principal_type, principal_id);
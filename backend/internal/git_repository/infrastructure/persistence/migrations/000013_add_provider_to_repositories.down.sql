@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000013_add_provider_to_repositories.down.sql
+-- Remove provider column from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS provider;
@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000013_add_provider_to_repositories.up.sql
+-- Add provider column to repositories table (existing repositories are all hosted on GitHub)
+ALTER TABLE repositories
+ADD COLUMN provider VARCHAR(50) NOT NULL DEFAULT 'github' CHECK (provider IN ('github', 'gitlab'));
@@ -0,0 +1,12 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000014_add_auth_method_to_repositories.down.sql
+-- Remove auth method and deploy key columns from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS deploy_key,
+DROP COLUMN IF EXISTS auth_method;
+
+-- Restore the original provider constraint (repositories on other hosts must be removed first)
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_provider_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_provider_check CHECK (provider IN ('github', 'gitlab'));
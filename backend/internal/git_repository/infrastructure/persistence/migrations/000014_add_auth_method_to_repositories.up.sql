@@ -0,0 +1,17 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000014_add_auth_method_to_repositories.up.sql
+-- Allow Gitea/Forgejo and generic Git hosts
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_provider_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_provider_check CHECK (provider IN ('github', 'gitlab', 'gitea', 'generic'));
+
+-- Record how each repository authenticates and store its encrypted SSH deploy key
+ALTER TABLE repositories
+ADD COLUMN auth_method VARCHAR(50) NOT NULL DEFAULT 'none' CHECK (auth_method IN ('none', 'token', 'deploy_key')),
+ADD COLUMN deploy_key TEXT;
+
+-- Existing repositories with an access token authenticate with it
+UPDATE repositories
+SET auth_method = 'token'
+WHERE access_token IS NOT NULL AND access_token <> '';
@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000015_create_repository_sync_runs_table.down.sql
+-- Drop repository_sync_runs table
+
+DROP TABLE IF EXISTS repository_sync_runs;
@@ -0,0 +1,17 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000015_create_repository_sync_runs_table.up.sql
+-- Create repository_sync_runs table recording each attempt to refresh a repository
+
+-- repository_sync_runs table (one row per webhook, scheduled or manual sync)
+CREATE TABLE repository_sync_runs (
+    id UUID PRIMARY KEY,
+    repository_id UUID NOT NULL REFERENCES repositories(id) ON DELETE CASCADE,
+    trigger_type VARCHAR(50) NOT NULL CHECK (trigger_type IN ('webhook', 'schedule', 'manual')),
+    status VARCHAR(50) NOT NULL CHECK (status IN ('running', 'succeeded', 'failed')),
+    started_at TIMESTAMPTZ NOT NULL,
+    finished_at TIMESTAMPTZ,
+    commit_sha VARCHAR(64) NOT NULL DEFAULT '',
+    files_changed TEXT[] NOT NULL DEFAULT '{}',
+    error_message TEXT NOT NULL DEFAULT ''
+);
+
+CREATE INDEX idx_repository_sync_runs_repository_started ON repository_sync_runs(repository_id, started_at DESC);
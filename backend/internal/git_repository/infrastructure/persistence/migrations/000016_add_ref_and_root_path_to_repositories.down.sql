@@ -0,0 +1,5 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000016_add_ref_and_root_path_to_repositories.down.sql
+-- Remove ref and root_path columns from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS root_path,
+DROP COLUMN IF EXISTS ref;
@@ -0,0 +1,5 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000016_add_ref_and_root_path_to_repositories.up.sql
+-- Add the tracked branch/tag and the root sub-directory to repositories table (empty values track the default branch at the repository root)
+ALTER TABLE repositories
+ADD COLUMN ref VARCHAR(255) NOT NULL DEFAULT '',
+ADD COLUMN root_path TEXT NOT NULL DEFAULT '';
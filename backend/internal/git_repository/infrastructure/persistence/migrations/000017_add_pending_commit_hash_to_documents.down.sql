@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000017_add_pending_commit_hash_to_documents.down.sql
+-- Remove pending_commit_hash column from documents table
+ALTER TABLE documents
+DROP COLUMN IF EXISTS pending_commit_hash;
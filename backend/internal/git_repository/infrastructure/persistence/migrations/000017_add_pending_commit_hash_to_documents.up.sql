@@ -0,0 +1,4 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000017_add_pending_commit_hash_to_documents.up.sql
+-- Add the upstream commit awaiting manual review to documents table (empty when the document is up to date)
+ALTER TABLE documents
+ADD COLUMN pending_commit_hash VARCHAR(64) NOT NULL DEFAULT '';
@@ -0,0 +1,17 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000018_add_file_rules_to_repositories.down.sql
+-- Remove the managed files matched by rules and restore one row per file
+DELETE FROM managed_files WHERE source = 'rule';
+
+ALTER TABLE managed_files
+DROP CONSTRAINT IF EXISTS managed_files_repository_id_file_path_source_key;
+
+ALTER TABLE managed_files
+DROP COLUMN IF EXISTS source;
+
+ALTER TABLE managed_files
+ADD CONSTRAINT managed_files_repository_id_file_path_key UNIQUE (repository_id, file_path);
+
+-- Remove include/exclude glob columns from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS exclude_globs,
+DROP COLUMN IF EXISTS include_globs;
@@ -0,0 +1,15 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000018_add_file_rules_to_repositories.up.sql
+-- Add the include/exclude glob patterns that select managed files on each sync
+ALTER TABLE repositories
+ADD COLUMN include_globs TEXT[] NOT NULL DEFAULT '{}',
+ADD COLUMN exclude_globs TEXT[] NOT NULL DEFAULT '{}';
+
+-- Record whether a managed file was selected explicitly or matched by the rules, so each sync only replaces the matches
+ALTER TABLE managed_files
+ADD COLUMN source VARCHAR(16) NOT NULL DEFAULT 'explicit' CHECK (source IN ('explicit', 'rule'));
+
+ALTER TABLE managed_files
+DROP CONSTRAINT IF EXISTS managed_files_repository_id_file_path_key;
+
+ALTER TABLE managed_files
+ADD CONSTRAINT managed_files_repository_id_file_path_source_key UNIQUE (repository_id, file_path, source);
@@ -0,0 +1,10 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000019_add_deleted_at_to_repositories.down.sql
+-- Restore the unique URL constraint; this fails while a deleted repository shares its URL with another one
+DROP INDEX IF EXISTS repositories_url_active_key;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_url_key UNIQUE (url);
+
+-- Remove deleted_at column from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS deleted_at;
@@ -0,0 +1,10 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000019_add_deleted_at_to_repositories.up.sql
+-- Mark repositories deleted while keeping their documents and execution history
+ALTER TABLE repositories
+ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Only repositories that are not deleted need a unique URL, so a deleted repository can be registered again
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_url_key;
+
+CREATE UNIQUE INDEX repositories_url_active_key ON repositories (url) WHERE deleted_at IS NULL;
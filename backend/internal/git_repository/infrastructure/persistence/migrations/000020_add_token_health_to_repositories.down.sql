@@ -0,0 +1,7 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000020_add_token_health_to_repositories.down.sql
+-- Remove access token verification columns from repositories table
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS token_status_message,
+DROP COLUMN IF EXISTS token_checked_at,
+DROP COLUMN IF EXISTS token_expires_at,
+DROP COLUMN IF EXISTS token_status;
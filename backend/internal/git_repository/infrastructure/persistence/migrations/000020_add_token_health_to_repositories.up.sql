@@ -0,0 +1,7 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000020_add_token_health_to_repositories.up.sql
+-- Record the outcome of the last access token verification
+ALTER TABLE repositories
+ADD COLUMN token_status VARCHAR(32) NOT NULL DEFAULT 'unchecked' CHECK (token_status IN ('unchecked', 'valid', 'expiring_soon', 'invalid')),
+ADD COLUMN token_expires_at TIMESTAMPTZ,
+ADD COLUMN token_checked_at TIMESTAMPTZ,
+ADD COLUMN token_status_message TEXT NOT NULL DEFAULT '';
@@ -0,0 +1,18 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000021_create_github_apps_table.down.sql
+-- Remove GitHub App authentication
+
+ALTER TABLE repositories
+DROP COLUMN IF EXISTS github_installation_id,
+DROP COLUMN IF EXISTS github_app_id;
+
+UPDATE repositories
+SET auth_method = 'none'
+WHERE auth_method = 'github_app';
+
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_auth_method_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_auth_method_check CHECK (auth_method IN ('none', 'token', 'deploy_key'));
+
+DROP TABLE IF EXISTS github_apps;
@@ -0,0 +1,23 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000021_create_github_apps_table.up.sql
+-- Create github_apps table storing the GitHub Apps OpsCore authenticates as
+
+-- github_apps table (the private key is encrypted like the repository credentials)
+CREATE TABLE github_apps (
+    id UUID PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    app_id BIGINT NOT NULL UNIQUE,
+    private_key TEXT NOT NULL,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Allow repositories to authenticate with the installation of a GitHub App
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_auth_method_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_auth_method_check CHECK (auth_method IN ('none', 'token', 'deploy_key', 'github_app'));
+
+ALTER TABLE repositories
+ADD COLUMN github_app_id UUID REFERENCES github_apps(id),
+ADD COLUMN github_installation_id BIGINT;
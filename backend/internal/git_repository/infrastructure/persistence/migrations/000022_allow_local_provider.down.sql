@@ -0,0 +1,7 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000022_allow_local_provider.down.sql
+-- Restore the previous provider constraint (local repositories must be removed first)
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_provider_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_provider_check CHECK (provider IN ('github', 'gitlab', 'gitea', 'generic'));
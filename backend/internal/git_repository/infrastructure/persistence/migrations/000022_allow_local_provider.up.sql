@@ -0,0 +1,7 @@
+-- Filepath: backend/internal/git_repository/infrastructure/persistence/migrations/000022_allow_local_provider.up.sql
+-- Allow repositories served from a local directory or an uploaded archive
+ALTER TABLE repositories
+DROP CONSTRAINT IF EXISTS repositories_provider_check;
+
+ALTER TABLE repositories
+ADD CONSTRAINT repositories_provider_check CHECK (provider IN ('github', 'gitlab', 'gitea', 'generic', 'local'));
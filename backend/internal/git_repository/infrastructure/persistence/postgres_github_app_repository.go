@@ -0,0 +1,132 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+	"opscore/backend/internal/git_repository/infrastructure/encryption"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// githubAppColumns lists the github_apps columns read by scanGitHubApp, in scan order.
+const githubAppColumns = `id, name, app_id, private_key, created_at, updated_at`
+
+// PostgresGitHubAppRepository is a PostgreSQL implementation of the repository.GitHubAppRepository interface.
+type PostgresGitHubAppRepository struct {
+	db        *pgxpool.Pool
+	encryptor *encryption.Encryptor
+}
+
+// NewPostgresGitHubAppRepository creates a new PostgresGitHubAppRepository.
+func NewPostgresGitHubAppRepository(db *pgxpool.Pool, encryptor *encryption.Encryptor) repository.GitHubAppRepository {
+	return &PostgresGitHubAppRepository{
+		db:        db,
+		encryptor: encryptor,
+	}
+}
+
+// Save persists a GitHub App in the PostgreSQL database.
+func (r *PostgresGitHubAppRepository) Save(ctx context.Context, app entity.GitHubApp) error {
+	// Encrypt the private key before saving
+	encryptedKey, err := r.encryptor.EncryptContext(ctx, app.PrivateKey())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt private key: %w", err)
+	}
+
+	query := `
+		INSERT INTO github_apps (id, name, app_id, private_key, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			app_id = EXCLUDED.app_id,
+			private_key = EXCLUDED.private_key,
+			updated_at = EXCLUDED.updated_at;
+	`
+	_, err = r.db.Exec(ctx, query, app.ID(), app.Name(), app.AppID(), encryptedKey, app.CreatedAt(), app.UpdatedAt())
+	if err != nil {
+		return fmt.Errorf("failed to save GitHub App: %w", err)
+	}
+	return nil
+}
+
+// FindByID retrieves a GitHub App by its ID from the PostgreSQL database.
+func (r *PostgresGitHubAppRepository) FindByID(ctx context.Context, id string) (entity.GitHubApp, error) {
+	query := `
+		SELECT ` + githubAppColumns + `
+		FROM github_apps
+		WHERE id = $1;
+	`
+	return r.findOne(ctx, query, id)
+}
+
+// FindByAppID retrieves a GitHub App by the App ID assigned by GitHub from the PostgreSQL database.
+func (r *PostgresGitHubAppRepository) FindByAppID(ctx context.Context, appID int64) (entity.GitHubApp, error) {
+	query := `
+		SELECT ` + githubAppColumns + `
+		FROM github_apps
+		WHERE app_id = $1;
+	`
+	return r.findOne(ctx, query, appID)
+}
+
+// FindAll retrieves all GitHub Apps from the PostgreSQL database.
+func (r *PostgresGitHubAppRepository) FindAll(ctx context.Context) ([]entity.GitHubApp, error) {
+	query := `
+		SELECT ` + githubAppColumns + `
+		FROM github_apps
+		ORDER BY created_at;
+	`
+	rows, err := r.db.Query(ctx, query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query GitHub Apps: %w", err)
+	}
+	defer rows.Close()
+
+	apps := []entity.GitHubApp{}
+	for rows.Next() {
+		app, err := r.scanGitHubApp(ctx, rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan GitHub App row: %w", err)
+		}
+		apps = append(apps, app)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over GitHub App rows: %w", err)
+	}
+	return apps, nil
+}
+
+// findOne runs a query selecting a single GitHub App; it returns nil if no row matches.
+func (r *PostgresGitHubAppRepository) findOne(ctx context.Context, query string, arg any) (entity.GitHubApp, error) {
+	app, err := r.scanGitHubApp(ctx, r.db.QueryRow(ctx, query, arg))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // Not found, no error
+		}
+		return nil, fmt.Errorf("failed to find GitHub App: %w", err)
+	}
+	return app, nil
+}
+
+// scanGitHubApp scans a github_apps row selected with githubAppColumns and decrypts its private key.
+func (r *PostgresGitHubAppRepository) scanGitHubApp(ctx context.Context, row pgx.Row) (entity.GitHubApp, error) {
+	var id, name, encryptedKey string
+	var appID int64
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &appID, &encryptedKey, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	privateKey, err := r.encryptor.DecryptContext(ctx, encryptedKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt private key: %w", err)
+	}
+	return entity.ReconstructGitHubApp(id, name, appID, privateKey, createdAt, updatedAt), nil
+}
@@ -32,22 +32,48 @@ func NewPostgresRepository(db *pgxpool.Pool, encryptor *encryption.Encryptor) re
 
 // Save persists a repository in the PostgreSQL database.
 func (r *PostgresRepository) Save(ctx context.Context, repo entity.Repository) error {
-	// Encrypt the access token before saving
-	encryptedToken, err := r.encryptor.Encrypt(repo.AccessToken())
+	// Encrypt the credentials before saving
+	encryptedToken, err := r.encryptor.EncryptContext(ctx, repo.AccessToken())
 	if err != nil {
 		return fmt.Errorf("failed to encrypt access token: %w", err)
 	}
 
+	encryptedDeployKey, err := r.encryptor.EncryptContext(ctx, repo.DeployKey())
+	if err != nil {
+		return fmt.Errorf("failed to encrypt deploy key: %w", err)
+	}
+
 	query := `
-		INSERT INTO repositories (id, name, url, access_token, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6)
+		INSERT INTO repositories (id, name, url, provider, access_token, auth_method, deploy_key, ref, root_path, include_globs, exclude_globs,
+			token_status, token_expires_at, token_checked_at, token_status_message, github_app_id, github_installation_id, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19)
 		ON CONFLICT (id) DO UPDATE SET
 			name = EXCLUDED.name,
 			url = EXCLUDED.url,
+			provider = EXCLUDED.provider,
 			access_token = EXCLUDED.access_token,
+			auth_method = EXCLUDED.auth_method,
+			deploy_key = EXCLUDED.deploy_key,
+			ref = EXCLUDED.ref,
+			root_path = EXCLUDED.root_path,
+			include_globs = EXCLUDED.include_globs,
+			exclude_globs = EXCLUDED.exclude_globs,
+			token_status = EXCLUDED.token_status,
+			token_expires_at = EXCLUDED.token_expires_at,
+			token_checked_at = EXCLUDED.token_checked_at,
+			token_status_message = EXCLUDED.token_status_message,
+			github_app_id = EXCLUDED.github_app_id,
+			github_installation_id = EXCLUDED.github_installation_id,
 			updated_at = EXCLUDED.updated_at;
 	`
-	_, err = r.db.Exec(ctx, query, repo.ID(), repo.Name(), repo.URL(), encryptedToken, repo.CreatedAt(), repo.UpdatedAt())
+	health := repo.TokenHealth()
+	var githubAppID *string
+	var installationID *int64
+	if installation := repo.Installation(); installation.AppID != "" {
+		githubAppID, installationID = &installation.AppID, &installation.InstallationID
+	}
+	_, err = r.db.Exec(ctx, query, repo.ID(), repo.Name(), repo.URL(), repo.Provider().String(), encryptedToken, repo.AuthMethod().String(), encryptedDeployKey, repo.Ref(), repo.RootPath(), globsOrEmpty(repo.IncludeGlobs()), globsOrEmpty(repo.ExcludeGlobs()),
+		health.Status.String(), health.ExpiresAt, health.CheckedAt, health.Message, githubAppID, installationID, repo.CreatedAt(), repo.UpdatedAt())
 	if err != nil {
 		// Check for unique constraint violation on URL if a separate constraint exists
 		// var pgErr *pgconn.PgError
@@ -60,91 +86,97 @@ func (r *PostgresRepository) Save(ctx context.Context, repo entity.Repository) e
 	return nil
 }
 
+// repositoryColumns lists the repositories columns read by scanRepository, in scan order.
+const repositoryColumns = `id, name, url, provider, access_token, auth_method, deploy_key, ref, root_path, include_globs, exclude_globs,
+	token_status, token_expires_at, token_checked_at, token_status_message, github_app_id, github_installation_id, created_at, updated_at`
+
+// globsOrEmpty returns a non-nil slice so that the NOT NULL glob columns receive an empty array.
+func globsOrEmpty(globs []string) []string {
+	if globs == nil {
+		return []string{}
+	}
+	return globs
+}
+
+// scanRepository scans a repositories row selected with repositoryColumns and decrypts its credentials.
+func (r *PostgresRepository) scanRepository(ctx context.Context, row pgx.Row) (entity.Repository, error) {
+	var id, name, url, provider, authMethod, ref, rootPath string
+	var accessToken, deployKey sql.NullString // 認証情報は NULL の可能性があるため sql.NullString を使用
+	var includeGlobs, excludeGlobs []string
+	var tokenStatus, tokenStatusMessage string
+	var tokenExpiresAt, tokenCheckedAt *time.Time
+	var githubAppID *string
+	var installationID *int64
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &url, &provider, &accessToken, &authMethod, &deployKey, &ref, &rootPath, &includeGlobs, &excludeGlobs,
+		&tokenStatus, &tokenExpiresAt, &tokenCheckedAt, &tokenStatusMessage, &githubAppID, &installationID, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	// NullString から通常の string へ変換し、復号化
+	tokenStr, err := r.encryptor.DecryptContext(ctx, accessToken.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt access token: %w", err)
+	}
+	deployKeyStr, err := r.encryptor.DecryptContext(ctx, deployKey.String)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt deploy key: %w", err)
+	}
+
+	repo := entity.ReconstructRepository(id, name, url, entity.Provider(provider), tokenStr, entity.AuthMethod(authMethod), deployKeyStr, ref, rootPath, includeGlobs, excludeGlobs, createdAt, updatedAt)
+	repo.SetTokenHealth(entity.TokenHealth{
+		Status:    entity.TokenStatus(tokenStatus),
+		ExpiresAt: tokenExpiresAt,
+		CheckedAt: tokenCheckedAt,
+		Message:   tokenStatusMessage,
+	})
+	if githubAppID != nil && installationID != nil {
+		repo.SetInstallation(entity.GitHubAppInstallation{AppID: *githubAppID, InstallationID: *installationID})
+	}
+	return repo, nil
+}
+
 // FindByURL retrieves a repository by its URL from the PostgreSQL database.
 func (r *PostgresRepository) FindByURL(ctx context.Context, url string) (entity.Repository, error) {
 	query := `
-		SELECT id, name, url, access_token, created_at, updated_at
+		SELECT ` + repositoryColumns + `
 		FROM repositories
-		WHERE url = $1;
+		WHERE url = $1 AND deleted_at IS NULL;
 	`
-	var id, name, repoURL string
-	var accessToken sql.NullString // トークンは NULL の可能性があるため sql.NullString を使用
-	var createdAt, updatedAt time.Time
-
-	err := r.db.QueryRow(ctx, query, url).Scan(
-		&id,
-		&name,
-		&repoURL,
-		&accessToken,
-		&createdAt,
-		&updatedAt,
-	)
+	repo, err := r.scanRepository(ctx, r.db.QueryRow(ctx, query, url))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
 			return nil, nil // Not found, no error
 		}
 		return nil, fmt.Errorf("failed to find repository by URL: %w", err)
 	}
-
-	// NullString から通常の string へ変換し、復号化
-	tokenStr := ""
-	if accessToken.Valid && accessToken.String != "" {
-		decrypted, err := r.encryptor.Decrypt(accessToken.String)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt access token: %w", err)
-		}
-		tokenStr = decrypted
-	}
-
-	return entity.ReconstructRepository(id, name, repoURL, tokenStr, createdAt, updatedAt), nil
+	return repo, nil
 }
 
 // FindByID retrieves a repository by its ID from the PostgreSQL database.
 func (r *PostgresRepository) FindByID(ctx context.Context, id string) (entity.Repository, error) {
 	query := `
-		SELECT id, name, url, access_token, created_at, updated_at
+		SELECT ` + repositoryColumns + `
 		FROM repositories
-		WHERE id = $1;
+		WHERE id = $1 AND deleted_at IS NULL;
 	`
-	var repoID, name, url string
-	var accessToken sql.NullString
-	var createdAt, updatedAt time.Time
-
-	err := r.db.QueryRow(ctx, query, id).Scan(
-		&repoID,
-		&name,
-		&url,
-		&accessToken,
-		&createdAt,
-		&updatedAt,
-	)
+	repo, err := r.scanRepository(ctx, r.db.QueryRow(ctx, query, id))
 	if err != nil {
 		if errors.Is(err, pgx.ErrNoRows) {
-			// Return a specific error type if needed by use cases
-			// return nil, repository.ErrNotFound
 			return nil, nil // Not found, no error
 		}
 		return nil, fmt.Errorf("failed to find repository by ID: %w", err)
 	}
-
-	// NullString から通常の string へ変換し、復号化
-	tokenStr := ""
-	if accessToken.Valid && accessToken.String != "" {
-		decrypted, err := r.encryptor.Decrypt(accessToken.String)
-		if err != nil {
-			return nil, fmt.Errorf("failed to decrypt access token: %w", err)
-		}
-		tokenStr = decrypted
-	}
-
-	return entity.ReconstructRepository(repoID, name, url, tokenStr, createdAt, updatedAt), nil
+	return repo, nil
 }
 
 // FindAll retrieves all repositories from the PostgreSQL database.
 func (r *PostgresRepository) FindAll(ctx context.Context) ([]entity.Repository, error) {
 	query := `
-		SELECT id, name, url, access_token, created_at, updated_at
+		SELECT ` + repositoryColumns + `
 		FROM repositories
+		WHERE deleted_at IS NULL
 		ORDER BY created_at DESC;
 	`
 
@@ -156,25 +188,10 @@ func (r *PostgresRepository) FindAll(ctx context.Context) ([]entity.Repository,
 
 	var repositories []entity.Repository
 	for rows.Next() {
-		var id, name, url string
-		var accessToken sql.NullString
-		var createdAt, updatedAt time.Time
-
-		if err := rows.Scan(&id, &name, &url, &accessToken, &createdAt, &updatedAt); err != nil {
+		repo, err := r.scanRepository(ctx, rows)
+		if err != nil {
 			return nil, fmt.Errorf("failed to scan repository row: %w", err)
 		}
-
-		// NullString から通常の string へ変換し、復号化
-		tokenStr := ""
-		if accessToken.Valid && accessToken.String != "" {
-			decrypted, err := r.encryptor.Decrypt(accessToken.String)
-			if err != nil {
-				return nil, fmt.Errorf("failed to decrypt access token: %w", err)
-			}
-			tokenStr = decrypted
-		}
-
-		repo := entity.ReconstructRepository(id, name, url, tokenStr, createdAt, updatedAt)
 		repositories = append(repositories, repo)
 	}
 
@@ -185,18 +202,34 @@ func (r *PostgresRepository) FindAll(ctx context.Context) ([]entity.Repository,
 	return repositories, nil
 }
 
-// SaveManagedFiles saves the list of selected file paths for a repository.
-// It first deletes existing entries for the repoID and then inserts the new ones.
+// Sources of managed files
+const (
+	managedFileSourceExplicit = "explicit" // Selected explicitly through SaveManagedFiles
+	managedFileSourceRule     = "rule"     // Matched by the repository's include/exclude rules
+)
+
+// SaveManagedFiles saves the list of explicitly selected file paths for a repository.
+// It first deletes existing selections for the repoID and then inserts the new ones.
 func (r *PostgresRepository) SaveManagedFiles(ctx context.Context, repoID string, filePaths []string) error {
+	return r.replaceManagedFiles(ctx, repoID, managedFileSourceExplicit, filePaths)
+}
+
+// SaveRuleMatchedFiles replaces the file paths matched by a repository's include/exclude rules.
+func (r *PostgresRepository) SaveRuleMatchedFiles(ctx context.Context, repoID string, filePaths []string) error {
+	return r.replaceManagedFiles(ctx, repoID, managedFileSourceRule, filePaths)
+}
+
+// replaceManagedFiles replaces the managed files of one source for a repository in a single transaction.
+func (r *PostgresRepository) replaceManagedFiles(ctx context.Context, repoID, source string, filePaths []string) error {
 	tx, err := r.db.Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback(ctx) // Rollback if anything fails
 
-	// Delete existing managed files for this repository
-	deleteQuery := `DELETE FROM managed_files WHERE repository_id = $1;`
-	_, err = tx.Exec(ctx, deleteQuery, repoID)
+	// Delete existing managed files of this source for this repository
+	deleteQuery := `DELETE FROM managed_files WHERE repository_id = $1 AND source = $2;`
+	_, err = tx.Exec(ctx, deleteQuery, repoID, source)
 	if err != nil {
 		return fmt.Errorf("failed to delete existing managed files: %w", err)
 	}
@@ -204,13 +237,13 @@ func (r *PostgresRepository) SaveManagedFiles(ctx context.Context, repoID string
 	// Insert new managed files if any paths are provided
 	if len(filePaths) > 0 {
 		insertQuery := `
-			INSERT INTO managed_files (repository_id, file_path)
-			VALUES ($1, $2);
+			INSERT INTO managed_files (repository_id, file_path, source)
+			VALUES ($1, $2, $3);
 		`
 		// Use Batch for potentially better performance with many files
 		batch := &pgx.Batch{}
 		for _, filePath := range filePaths {
-			batch.Queue(insertQuery, repoID, filePath)
+			batch.Queue(insertQuery, repoID, filePath, source)
 		}
 
 		results := tx.SendBatch(ctx, batch)
@@ -242,10 +275,10 @@ func (r *PostgresRepository) SaveManagedFiles(ctx context.Context, repoID string
 	return nil
 }
 
-// GetManagedFiles retrieves the list of selected file paths for a repository.
+// GetManagedFiles retrieves the file paths managed for a repository, whether selected explicitly or matched by its rules.
 func (r *PostgresRepository) GetManagedFiles(ctx context.Context, repoID string) ([]string, error) {
 	query := `
-		SELECT file_path
+		SELECT DISTINCT file_path
 		FROM managed_files
 		WHERE repository_id = $1
 		ORDER BY file_path; -- Optional: order for consistency
@@ -280,18 +313,30 @@ func (r *PostgresRepository) GetManagedFiles(ctx context.Context, repoID string)
 // UpdateAccessToken updates the access token for a repository.
 func (r *PostgresRepository) UpdateAccessToken(ctx context.Context, repoID string, accessToken string) error {
 	// Encrypt the access token before updating
-	encryptedToken, err := r.encryptor.Encrypt(accessToken)
+	encryptedToken, err := r.encryptor.EncryptContext(ctx, accessToken)
 	if err != nil {
 		return fmt.Errorf("failed to encrypt access token: %w", err)
 	}
 
+	// Repositories authenticated with a deploy key or a GitHub App keep using it; otherwise the auth method follows the token
+	authMethod := entity.AuthMethodToken
+	if accessToken == "" {
+		authMethod = entity.AuthMethodNone
+	}
+
 	query := `
 		UPDATE repositories
-		SET access_token = $1, updated_at = $2
-		WHERE id = $3;
+		SET access_token = $1,
+			auth_method = CASE WHEN auth_method IN ('deploy_key', 'github_app') THEN auth_method ELSE $2 END,
+			token_status = 'unchecked',
+			token_expires_at = NULL,
+			token_checked_at = NULL,
+			token_status_message = '',
+			updated_at = $3
+		WHERE id = $4 AND deleted_at IS NULL;
 	`
 	now := time.Now()
-	res, err := r.db.Exec(ctx, query, encryptedToken, now, repoID)
+	res, err := r.db.Exec(ctx, query, encryptedToken, authMethod.String(), now, repoID)
 	if err != nil {
 		return fmt.Errorf("failed to update repository access token: %w", err)
 	}
@@ -303,3 +348,72 @@ func (r *PostgresRepository) UpdateAccessToken(ctx context.Context, repoID strin
 
 	return nil
 }
+
+// Delete removes a repository; the foreign keys cascade the deletion to its managed files, sync runs,
+// documents and their execution records.
+func (r *PostgresRepository) Delete(ctx context.Context, id string) error {
+	query := `DELETE FROM repositories WHERE id = $1;`
+	res, err := r.db.Exec(ctx, query, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete repository: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("repository with ID %s not found", id)
+	}
+	return nil
+}
+
+// Archive marks a repository deleted and clears its credentials and managed files,
+// keeping the row so that its documents and execution records remain.
+func (r *PostgresRepository) Archive(ctx context.Context, id string) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx) // Rollback if anything fails
+
+	query := `
+		UPDATE repositories
+		SET deleted_at = $1,
+			access_token = NULL,
+			deploy_key = NULL,
+			updated_at = $1
+		WHERE id = $2 AND deleted_at IS NULL;
+	`
+	res, err := tx.Exec(ctx, query, time.Now(), id)
+	if err != nil {
+		return fmt.Errorf("failed to archive repository: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("repository with ID %s not found", id)
+	}
+
+	if _, err := tx.Exec(ctx, `DELETE FROM managed_files WHERE repository_id = $1;`, id); err != nil {
+		return fmt.Errorf("failed to delete managed files: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateTokenHealth records the outcome of verifying a repository's access token.
+func (r *PostgresRepository) UpdateTokenHealth(ctx context.Context, repoID string, health entity.TokenHealth) error {
+	query := `
+		UPDATE repositories
+		SET token_status = $1,
+			token_expires_at = $2,
+			token_checked_at = $3,
+			token_status_message = $4
+		WHERE id = $5 AND deleted_at IS NULL;
+	`
+	res, err := r.db.Exec(ctx, query, health.Status.String(), health.ExpiresAt, health.CheckedAt, health.Message, repoID)
+	if err != nil {
+		return fmt.Errorf("failed to update repository token health: %w", err)
+	}
+	if res.RowsAffected() == 0 {
+		return fmt.Errorf("repository with ID %s not found", repoID)
+	}
+	return nil
+}
@@ -0,0 +1,117 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/domain/repository"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// syncRunColumns lists the repository_sync_runs columns read by scanSyncRun, in scan order.
+const syncRunColumns = `id, repository_id, trigger_type, status, started_at, finished_at, commit_sha, files_changed, error_message`
+
+// PostgresSyncRunRepository is a PostgreSQL implementation of the repository.SyncRunRepository interface.
+type PostgresSyncRunRepository struct {
+	db *pgxpool.Pool
+}
+
+// NewPostgresSyncRunRepository creates a new PostgresSyncRunRepository.
+func NewPostgresSyncRunRepository(db *pgxpool.Pool) repository.SyncRunRepository {
+	return &PostgresSyncRunRepository{db: db}
+}
+
+// Save persists a sync run in the PostgreSQL database.
+func (r *PostgresSyncRunRepository) Save(ctx context.Context, run entity.SyncRun) error {
+	query := `
+		INSERT INTO repository_sync_runs (id, repository_id, trigger_type, status, started_at, finished_at, commit_sha, files_changed, error_message)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (id) DO UPDATE SET
+			status = EXCLUDED.status,
+			finished_at = EXCLUDED.finished_at,
+			commit_sha = EXCLUDED.commit_sha,
+			files_changed = EXCLUDED.files_changed,
+			error_message = EXCLUDED.error_message;
+	`
+	_, err := r.db.Exec(ctx, query,
+		run.ID(),
+		run.RepositoryID(),
+		string(run.Trigger()),
+		string(run.Status()),
+		run.StartedAt(),
+		run.FinishedAt(),
+		run.CommitSHA(),
+		run.FilesChanged(),
+		run.ErrorMessage(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save sync run: %w", err)
+	}
+	return nil
+}
+
+// FindByRepositoryID retrieves the most recent sync runs of a repository, newest first.
+func (r *PostgresSyncRunRepository) FindByRepositoryID(ctx context.Context, repoID string, limit int) ([]entity.SyncRun, error) {
+	query := `
+		SELECT ` + syncRunColumns + `
+		FROM repository_sync_runs
+		WHERE repository_id = $1
+		ORDER BY started_at DESC
+		LIMIT $2;
+	`
+	rows, err := r.db.Query(ctx, query, repoID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sync runs: %w", err)
+	}
+	defer rows.Close()
+
+	runs := []entity.SyncRun{}
+	for rows.Next() {
+		run, err := scanSyncRun(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan sync run row: %w", err)
+		}
+		runs = append(runs, run)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over sync run rows: %w", err)
+	}
+	return runs, nil
+}
+
+// FindLatestSucceeded retrieves the most recent successful sync run of a repository.
+func (r *PostgresSyncRunRepository) FindLatestSucceeded(ctx context.Context, repoID string) (entity.SyncRun, error) {
+	query := `
+		SELECT ` + syncRunColumns + `
+		FROM repository_sync_runs
+		WHERE repository_id = $1 AND status = 'succeeded'
+		ORDER BY started_at DESC
+		LIMIT 1;
+	`
+	run, err := scanSyncRun(r.db.QueryRow(ctx, query, repoID))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil // Not found, no error
+		}
+		return nil, fmt.Errorf("failed to find latest successful sync run: %w", err)
+	}
+	return run, nil
+}
+
+// scanSyncRun scans a repository_sync_runs row selected with syncRunColumns.
+func scanSyncRun(row pgx.Row) (entity.SyncRun, error) {
+	var id, repoID, trigger, status, commitSHA, errorMessage string
+	var startedAt time.Time
+	var finishedAt *time.Time
+	var filesChanged []string
+
+	if err := row.Scan(&id, &repoID, &trigger, &status, &startedAt, &finishedAt, &commitSHA, &filesChanged, &errorMessage); err != nil {
+		return nil, err
+	}
+	return entity.ReconstructSyncRun(id, repoID, entity.SyncTrigger(trigger), entity.SyncStatus(status), startedAt, finishedAt, commitSHA, filesChanged, errorMessage), nil
+}
@@ -0,0 +1,64 @@
+package persistence
+
+import (
+	"context"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestPostgresSyncRunRepositoryIntegration is an integration test for the PostgresSyncRunRepository
+func TestPostgresSyncRunRepositoryIntegration(t *testing.T) {
+	// Skip test if PostgreSQL is not available
+	if !checkDatabaseConnection(t) {
+		t.Skip("Skipping PostgreSQL integration test - database is not available")
+		return
+	}
+
+	repo, cleanup := setupPostgreSQLRepository(t)
+	defer cleanup()
+	syncRuns := &PostgresSyncRunRepository{db: repo.db}
+
+	ctx := context.Background()
+
+	testRepo := entity.NewRepository(uuid.New().String(), "test-repo", "https://github.com/example/sync-runs", entity.ProviderGitHub, "test-token")
+	require.NoError(t, repo.Save(ctx, testRepo))
+
+	// テスト: 同期履歴の保存と新しい順での取得
+	t.Run("Save and FindByRepositoryID", func(t *testing.T) {
+		failed := entity.NewSyncRun(uuid.New().String(), testRepo.ID(), entity.SyncTriggerSchedule)
+		require.NoError(t, syncRuns.Save(ctx, failed))
+		failed.Fail("network unreachable")
+		require.NoError(t, syncRuns.Save(ctx, failed))
+
+		time.Sleep(10 * time.Millisecond)
+		succeeded := entity.NewSyncRun(uuid.New().String(), testRepo.ID(), entity.SyncTriggerManual)
+		succeeded.Succeed("abc123", []string{"docs/deploy.md"})
+		require.NoError(t, syncRuns.Save(ctx, succeeded))
+
+		runs, err := syncRuns.FindByRepositoryID(ctx, testRepo.ID(), 10)
+		require.NoError(t, err)
+		require.Len(t, runs, 2)
+		assert.Equal(t, succeeded.ID(), runs[0].ID())
+		assert.Equal(t, []string{"docs/deploy.md"}, runs[0].FilesChanged())
+		assert.Equal(t, entity.SyncStatusFailed, runs[1].Status())
+		assert.Equal(t, "network unreachable", runs[1].ErrorMessage())
+		assert.NotNil(t, runs[1].FinishedAt())
+
+		latest, err := syncRuns.FindLatestSucceeded(ctx, testRepo.ID())
+		require.NoError(t, err)
+		require.NotNil(t, latest)
+		assert.Equal(t, "abc123", latest.CommitSHA())
+	})
+
+	// テスト: 成功した同期がない場合はnilを返す
+	t.Run("FindLatestSucceeded returns nil without successful runs", func(t *testing.T) {
+		latest, err := syncRuns.FindLatestSucceeded(ctx, uuid.New().String())
+		require.NoError(t, err)
+		assert.Nil(t, latest)
+	})
+}
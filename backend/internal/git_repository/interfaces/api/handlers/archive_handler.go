@@ -0,0 +1,122 @@
+package handlers
+
+import (
+	"net/http"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	repository "opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+	intererror "opscore/backend/internal/git_repository/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxArchiveUploadSize limits the size of archive upload requests: the largest accepted archive (256 MB)
+// and room for the other form fields.
+const maxArchiveUploadSize = 257 << 20
+
+// ArchiveHandler holds dependencies for the handlers of repositories served from uploaded archives.
+type ArchiveHandler struct {
+	archiveUseCase repository.ArchiveUseCase
+	logger         Logger
+}
+
+// NewArchiveHandler creates a new ArchiveHandler.
+func NewArchiveHandler(uc repository.ArchiveUseCase, logger Logger) *ArchiveHandler {
+	return &ArchiveHandler{
+		archiveUseCase: uc,
+		logger:         logger,
+	}
+}
+
+// RegisterArchive godoc
+// @Summary Register a repository from an uploaded archive
+// @Description Uploads a tar.gz or zip archive of runbooks and registers a local repository served from it, for sites without Git hosting. A single top-level directory in the archive is treated as its root. Each import is recorded with a hash of the archive content as its commit.
+// @Tags repositories
+// @Accept multipart/form-data
+// @Produce json
+// @Param name formData string false "Display name; derived from the file name when omitted"
+// @Param rootPath formData string false "Sub-directory of the managed files"
+// @Param file formData file true "tar.gz or zip archive (at most 256 MB)"
+// @Success 201 {object} schema.RepositoryResponse "Repository registered successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request or unsupported archive"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/archives [post]
+func (h *ArchiveHandler) RegisterArchive(c *gin.Context) {
+	requestID := c.GetString("request_id")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxArchiveUploadSize)
+
+	var req schema.RegisterArchiveRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Error("Invalid archive upload", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request: " + err.Error()})
+		return
+	}
+	file, err := req.File.Open()
+	if err != nil {
+		h.logger.Error("Failed to open uploaded archive", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Failed to open file: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	h.logger.Info("Registering repository from archive", "request_id", requestID, "file_name", req.File.Filename, "size", req.File.Size)
+	repo, err := h.archiveUseCase.RegisterArchive(c.Request.Context(), dto.RegisterArchiveRequest{
+		Name:     req.Name,
+		FileName: req.File.Filename,
+		RootPath: req.RootPath,
+		Content:  file,
+	})
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to register repository from archive", "request_id", requestID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository registered successfully", "request_id", requestID, "repo_id", repo.ID())
+	c.JSON(http.StatusCreated, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
+
+// ReplaceArchive godoc
+// @Summary Upload a new version of a repository's archive
+// @Description Replaces the archive of a repository registered from an upload and enqueues a sync, which imports the new content
+// @Tags repositories
+// @Accept multipart/form-data
+// @Produce json
+// @Param repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param file formData file true "tar.gz or zip archive (at most 256 MB)"
+// @Success 202 {object} schema.TriggerSyncResponse "Archive replaced and sync enqueued"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request, unsupported archive, or the repository is not served from an upload"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/archive [put]
+func (h *ArchiveHandler) ReplaceArchive(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, maxArchiveUploadSize)
+
+	var req schema.ReplaceArchiveRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.logger.Error("Invalid archive upload", "request_id", requestID, "repo_id", repoId, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request: " + err.Error()})
+		return
+	}
+	file, err := req.File.Open()
+	if err != nil {
+		h.logger.Error("Failed to open uploaded archive", "request_id", requestID, "repo_id", repoId, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Failed to open file: " + err.Error()})
+		return
+	}
+	defer file.Close()
+
+	if err := h.archiveUseCase.ReplaceArchive(c.Request.Context(), repoId, file); err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to replace archive", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Archive replaced and sync enqueued", "request_id", requestID, "repo_id", repoId)
+	c.JSON(http.StatusAccepted, schema.TriggerSyncResponse{RepositoryID: repoId, Queued: true})
+}
@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupArchiveTest() (*repository.MockArchiveUseCase, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(repository.MockArchiveUseCase)
+	handler := NewArchiveHandler(mockUseCase, nopLogger{})
+
+	router := gin.New()
+	router.POST("/repositories/archives", handler.RegisterArchive)
+	router.PUT("/repositories/:repoId/archive", handler.ReplaceArchive)
+	return mockUseCase, router
+}
+
+// newArchiveUploadRequest builds a multipart request with the given form fields and, unless fileName is empty, an archive
+func newArchiveUploadRequest(t *testing.T, method, target string, fields map[string]string, fileName string) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	for name, value := range fields {
+		require.NoError(t, writer.WriteField(name, value))
+	}
+	if fileName != "" {
+		part, err := writer.CreateFormFile("file", fileName)
+		require.NoError(t, err)
+		_, err = part.Write([]byte("archive content"))
+		require.NoError(t, err)
+	}
+	require.NoError(t, writer.Close())
+
+	req := httptest.NewRequest(method, target, &body)
+	req.Header.Set("Content-Type", writer.FormDataContentType())
+	return req
+}
+
+// TestRegisterArchive はRegisterArchiveハンドラのテストです
+func TestRegisterArchive(t *testing.T) {
+	// テスト：アップロードしたアーカイブからリポジトリが登録されることを確認する
+	t.Run("アップロードしたアーカイブからリポジトリが登録される", func(t *testing.T) {
+		mockUseCase, router := setupArchiveTest()
+		repo := entity.NewRepository("repo-id", "Runbooks", "file:///data/uploads/archive-id.archive", entity.ProviderLocal, "")
+		mockUseCase.On("RegisterArchive", mock.Anything, mock.MatchedBy(func(req dto.RegisterArchiveRequest) bool {
+			return req.Name == "Runbooks" && req.FileName == "runbooks.zip" && req.RootPath == "docs" && req.Content != nil
+		})).Return(repo, nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newArchiveUploadRequest(t, http.MethodPost, "/repositories/archives", map[string]string{"name": "Runbooks", "rootPath": "docs"}, "runbooks.zip"))
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response schema.RepositoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "repo-id", response.ID)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	// テスト：ファイルがない場合は400エラーになることを確認する
+	t.Run("ファイルがない場合は400エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupArchiveTest()
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newArchiveUploadRequest(t, http.MethodPost, "/repositories/archives", map[string]string{"name": "Runbooks"}, ""))
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUseCase.AssertNotCalled(t, "RegisterArchive", mock.Anything, mock.Anything)
+	})
+}
+
+// TestReplaceArchive はReplaceArchiveハンドラのテストです
+func TestReplaceArchive(t *testing.T) {
+	// テスト：アーカイブが置き換えられ、同期の登録が返されることを確認する
+	t.Run("アーカイブが置き換えられ、同期の登録が返される", func(t *testing.T) {
+		mockUseCase, router := setupArchiveTest()
+		mockUseCase.On("ReplaceArchive", mock.Anything, "repo-id", mock.Anything).Return(nil)
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newArchiveUploadRequest(t, http.MethodPut, "/repositories/repo-id/archive", nil, "runbooks.tar.gz"))
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response schema.TriggerSyncResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "repo-id", response.RepositoryID)
+		assert.True(t, response.Queued)
+	})
+
+	// テスト：リポジトリが存在しない場合は404エラーになることを確認する
+	t.Run("リポジトリが存在しない場合は404エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupArchiveTest()
+		mockUseCase.On("ReplaceArchive", mock.Anything, "missing", mock.Anything).Return(apperror.NewNotFoundError("Repository", "missing", nil))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, newArchiveUploadRequest(t, http.MethodPut, "/repositories/missing/archive", nil, "runbooks.tar.gz"))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
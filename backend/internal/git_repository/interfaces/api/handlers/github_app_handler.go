@@ -0,0 +1,120 @@
+package handlers
+
+import (
+	"net/http"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	repository "opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+	intererror "opscore/backend/internal/git_repository/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// GitHubAppHandler holds dependencies for GitHub App handlers.
+type GitHubAppHandler struct {
+	githubAppUseCase repository.GitHubAppUseCase
+	logger           Logger
+}
+
+// NewGitHubAppHandler creates a new GitHubAppHandler.
+func NewGitHubAppHandler(uc repository.GitHubAppUseCase, logger Logger) *GitHubAppHandler {
+	return &GitHubAppHandler{
+		githubAppUseCase: uc,
+		logger:           logger,
+	}
+}
+
+// RegisterGitHubApp godoc
+// @Summary Register a GitHub App
+// @Description Registers a GitHub App by its App ID and private key. Repositories of the App's installations can then be registered without a personal access token. The private key is stored encrypted and never returned.
+// @Tags github-apps
+// @Accept  json
+// @Produce  json
+// @Param   app body schema.RegisterGitHubAppRequest true "GitHub App information"
+// @Success 201 {object} schema.GitHubAppResponse "GitHub App registered successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or private key"
+// @Failure 409 {object} schema.ErrorResponse "GitHub App with this App ID already exists"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /github-apps [post]
+func (h *GitHubAppHandler) RegisterGitHubApp(c *gin.Context) {
+	var req schema.RegisterGitHubAppRequest
+	requestID := c.GetString("request_id")
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	app, err := h.githubAppUseCase.RegisterApp(c.Request.Context(), schema.ToRegisterGitHubAppDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to register GitHub App", "request_id", requestID, "app_id", req.AppID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("GitHub App registered successfully", "request_id", requestID, "github_app_id", app.ID(), "app_id", app.AppID())
+	c.JSON(http.StatusCreated, schema.FromGitHubAppDTO(dto.ToGitHubAppResponse(app)))
+}
+
+// ListGitHubApps godoc
+// @Summary List GitHub Apps
+// @Description Retrieves all registered GitHub Apps without their private keys
+// @Tags github-apps
+// @Produce  json
+// @Success 200 {object} schema.ListGitHubAppsResponse "Successfully retrieved GitHub Apps"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /github-apps [get]
+func (h *GitHubAppHandler) ListGitHubApps(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	apps, err := h.githubAppUseCase.ListApps(c.Request.Context())
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to list GitHub Apps", "request_id", requestID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.ListGitHubAppsResponse{GitHubApps: schema.FromGitHubAppListDTO(dto.ToGitHubAppResponseList(apps))})
+}
+
+// RegisterInstallationRepository godoc
+// @Summary Register a repository with a GitHub App installation
+// @Description Registers a GitHub repository that is fetched with installation tokens of the GitHub App instead of an access token. The tokens are created and renewed automatically.
+// @Tags github-apps
+// @Accept  json
+// @Produce  json
+// @Param   appId path string true "GitHub App ID" example:"c1d2e3f4-a5b6-7890-1234-567890abcdef"
+// @Param   repository body schema.RegisterInstallationRepositoryRequest true "Repository information"
+// @Success 201 {object} schema.RepositoryResponse "Repository registered successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or URL format"
+// @Failure 404 {object} schema.ErrorResponse "GitHub App not found"
+// @Failure 409 {object} schema.ErrorResponse "Repository with this URL already exists"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /github-apps/{appId}/repositories [post]
+func (h *GitHubAppHandler) RegisterInstallationRepository(c *gin.Context) {
+	appID := c.Param("appId")
+	requestID := c.GetString("request_id")
+
+	var req schema.RegisterInstallationRepositoryRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Registering repository with GitHub App installation", "request_id", requestID, "github_app_id", appID, "url", req.URL)
+	repo, err := h.githubAppUseCase.RegisterRepository(c.Request.Context(), appID, schema.ToRegisterInstallationRepositoryDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to register repository", "request_id", requestID, "github_app_id", appID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository registered successfully", "request_id", requestID, "repo_id", repo.ID())
+	c.JSON(http.StatusCreated, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
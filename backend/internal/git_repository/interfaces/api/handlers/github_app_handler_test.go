@@ -0,0 +1,123 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupGitHubAppTest() (*repository.MockGitHubAppUseCase, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(repository.MockGitHubAppUseCase)
+	handler := NewGitHubAppHandler(mockUseCase, nopLogger{})
+
+	router := gin.New()
+	router.POST("/github-apps", handler.RegisterGitHubApp)
+	router.GET("/github-apps", handler.ListGitHubApps)
+	router.POST("/github-apps/:appId/repositories", handler.RegisterInstallationRepository)
+	return mockUseCase, router
+}
+
+// TestRegisterGitHubApp はRegisterGitHubAppハンドラのテストです
+func TestRegisterGitHubApp(t *testing.T) {
+	// テスト：GitHub Appが登録され、秘密鍵はレスポンスに含まれないことを確認する
+	t.Run("GitHub Appが登録され、秘密鍵はレスポンスに含まれない", func(t *testing.T) {
+		mockUseCase, router := setupGitHubAppTest()
+		app := entity.NewGitHubApp("app-id", "OpsCore", 1234, "private-key")
+		mockUseCase.On("RegisterApp", mock.Anything, dto.RegisterGitHubAppRequest{Name: "OpsCore", AppID: 1234, PrivateKey: "private-key"}).Return(app, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/github-apps", strings.NewReader(`{"name": "OpsCore", "appId": 1234, "privateKey": "private-key"}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response schema.GitHubAppResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "app-id", response.ID)
+		assert.Equal(t, int64(1234), response.AppID)
+		assert.NotContains(t, w.Body.String(), "private-key")
+	})
+
+	// テスト：秘密鍵がない場合は400エラーになることを確認する
+	t.Run("秘密鍵がない場合は400エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupGitHubAppTest()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/github-apps", strings.NewReader(`{"appId": 1234}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUseCase.AssertNotCalled(t, "RegisterApp", mock.Anything, mock.Anything)
+	})
+}
+
+// TestListGitHubApps はListGitHubAppsハンドラのテストです
+func TestListGitHubApps(t *testing.T) {
+	// テスト：登録済みのGitHub Appの一覧が返されることを確認する
+	t.Run("登録済みのGitHub Appの一覧が返される", func(t *testing.T) {
+		mockUseCase, router := setupGitHubAppTest()
+		mockUseCase.On("ListApps", mock.Anything).Return([]entity.GitHubApp{entity.NewGitHubApp("app-id", "OpsCore", 1234, "private-key")}, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/github-apps", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response schema.ListGitHubAppsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.GitHubApps, 1)
+		assert.Equal(t, "OpsCore", response.GitHubApps[0].Name)
+	})
+}
+
+// TestRegisterInstallationRepository はRegisterInstallationRepositoryハンドラのテストです
+func TestRegisterInstallationRepository(t *testing.T) {
+	// テスト：インストールによりリポジトリが登録されることを確認する
+	t.Run("インストールによりリポジトリが登録される", func(t *testing.T) {
+		mockUseCase, router := setupGitHubAppTest()
+		repo := entity.NewRepository("repo-id", "runbooks", "https://github.com/example/runbooks", entity.ProviderGitHub, "")
+		repo.SetInstallation(entity.GitHubAppInstallation{AppID: "app-id", InstallationID: 42})
+		mockUseCase.On("RegisterRepository", mock.Anything, "app-id", dto.RegisterInstallationRepositoryRequest{URL: "https://github.com/example/runbooks", InstallationID: 42}).Return(repo, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/github-apps/app-id/repositories", strings.NewReader(`{"url": "https://github.com/example/runbooks", "installationId": 42}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusCreated, w.Code)
+		var response schema.RepositoryResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "github_app", response.AuthMethod)
+		assert.Equal(t, "app-id", response.GitHubAppID)
+		assert.Equal(t, int64(42), response.InstallationID)
+	})
+
+	// テスト：GitHub Appが存在しない場合は404エラーになることを確認する
+	t.Run("GitHub Appが存在しない場合は404エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupGitHubAppTest()
+		mockUseCase.On("RegisterRepository", mock.Anything, "missing", mock.Anything).Return(nil, apperror.NewNotFoundError("GitHubApp", "missing", nil))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/github-apps/missing/repositories", strings.NewReader(`{"url": "https://github.com/example/runbooks", "installationId": 42}`))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
@@ -6,6 +6,7 @@ import (
 	repository "opscore/backend/internal/git_repository/application/usecase"
 	"opscore/backend/internal/git_repository/interfaces/api/schema"
 	intererror "opscore/backend/internal/git_repository/interfaces/error"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 )
@@ -36,7 +37,7 @@ func NewRepositoryHandler(uc repository.RepositoryUseCase, logger Logger) *Repos
 
 // RegisterRepository godoc
 // @Summary Register a new repository
-// @Description Add a new repository to be managed by OpsCore by providing its Git URL and optional access token.
+// @Description Add a new GitHub or GitLab repository to be managed by OpsCore by providing its Git URL, optional provider and optional access token. The provider is detected from the URL when omitted; self-hosted GitLab instances whose host name does not contain "gitlab" require it. A file:// URL registers a local repository served from a directory or tar.gz/zip archive on the server.
 // @Tags repositories
 // @Accept  json
 // @Produce  json
@@ -60,7 +61,7 @@ func (h *RepositoryHandler) RegisterRepository(c *gin.Context) {
 	dtoReq := schema.ToRegisterRepositoryDTO(req)
 
 	h.logger.Info("Registering repository", "request_id", requestID, "url", dtoReq.URL)
-	newRepo, err := h.repoUseCase.Register(c.Request.Context(), dtoReq.URL, dtoReq.AccessToken)
+	newRepo, err := h.repoUseCase.Register(c.Request.Context(), dtoReq)
 
 	if err != nil {
 		// Use error mapper to convert application errors to HTTP errors
@@ -128,9 +129,183 @@ func (h *RepositoryHandler) UpdateAccessToken(c *gin.Context) {
 	})
 }
 
+// VerifyAccessToken godoc
+// @Summary Verify the access token of a repository
+// @Description Asks the provider whether the repository's access token is still accepted, has the scopes needed to read the repository and when it expires, and records the resulting token status.
+// @Tags repositories
+// @Produce  json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Success 200 {object} schema.RepositoryResponse "Repository with the updated token status"
+// @Failure 400 {object} schema.ErrorResponse "The repository does not use an access token"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "The provider could not be reached"
+// @Router /repositories/{repoId}/token/verify [post]
+func (h *RepositoryHandler) VerifyAccessToken(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Verifying repository access token", "request_id", requestID, "repo_id", repoId)
+	repo, err := h.repoUseCase.VerifyAccessToken(c.Request.Context(), repoId)
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to verify access token", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository access token verified", "request_id", requestID, "repo_id", repoId, "token_status", repo.TokenHealth().Status.String())
+	c.JSON(http.StatusOK, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
+
+// UpdateTracking godoc
+// @Summary Update the tracked ref and root path of a repository
+// @Description Changes the branch or tag a repository is synced from and the sub-directory its files are taken from, and enqueues a sync. Changing the ref discards the local copy.
+// @Tags repositories
+// @Accept  json
+// @Produce  json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param   tracking body schema.UpdateTrackingRequest true "Tracking settings"
+// @Success 200 {object} schema.RepositoryResponse "Tracking settings updated"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body, ref or root path"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/tracking [put]
+func (h *RepositoryHandler) UpdateTracking(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+	var req schema.UpdateTrackingRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "repo_id", repoId, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Updating repository tracking", "request_id", requestID, "repo_id", repoId, "ref", req.Ref, "root_path", req.RootPath)
+	repo, err := h.repoUseCase.UpdateTracking(c.Request.Context(), repoId, schema.ToUpdateTrackingDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to update repository tracking", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository tracking updated successfully", "request_id", requestID, "repo_id", repoId)
+	c.JSON(http.StatusOK, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
+
+// UpdateFileRules godoc
+// @Summary Update the managed file rules of a repository
+// @Description Sets the include and exclude glob patterns that select managed files (e.g. docs/**/*.md). Matching files of the current local copy become managed right away, and the rules are re-evaluated on every sync. Files selected explicitly are not affected.
+// @Tags repositories
+// @Accept  json
+// @Produce  json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param   rules body schema.UpdateFileRulesRequest true "Glob rules"
+// @Success 200 {object} schema.RepositoryResponse "File rules updated"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or glob pattern"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/files/rules [put]
+func (h *RepositoryHandler) UpdateFileRules(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+	var req schema.UpdateFileRulesRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "repo_id", repoId, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Updating repository file rules", "request_id", requestID, "repo_id", repoId, "include_globs", req.IncludeGlobs, "exclude_globs", req.ExcludeGlobs)
+	repo, err := h.repoUseCase.UpdateFileRules(c.Request.Context(), repoId, schema.ToUpdateFileRulesDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to update repository file rules", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository file rules updated successfully", "request_id", requestID, "repo_id", repoId)
+	c.JSON(http.StatusOK, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
+
+// UpdateRepository godoc
+// @Summary Rename a repository or change its URL
+// @Description Changes the display name and/or URL of a repository. The URL must belong to the same provider; changing it discards the local copy and enqueues a sync.
+// @Tags repositories
+// @Accept  json
+// @Produce  json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param   repository body schema.UpdateRepositoryRequest true "New name and/or URL"
+// @Success 200 {object} schema.RepositoryResponse "Repository updated"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body, name or URL"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 409 {object} schema.ErrorResponse "Another repository with this URL already exists"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId} [patch]
+func (h *RepositoryHandler) UpdateRepository(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+	var req schema.UpdateRepositoryRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "repo_id", repoId, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Updating repository", "request_id", requestID, "repo_id", repoId, "name", req.Name, "url", req.URL)
+	repo, err := h.repoUseCase.UpdateRepository(c.Request.Context(), repoId, schema.ToUpdateRepositoryDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to update repository", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository updated successfully", "request_id", requestID, "repo_id", repoId)
+	c.JSON(http.StatusOK, schema.FromRepositoryDTO(dto.ToRepositoryResponse(repo)))
+}
+
+// DeleteRepository godoc
+// @Summary Delete a repository
+// @Description Deletes a repository and its local copy. By default the documents and execution records created from it are kept; with cascade=true they are deleted as well.
+// @Tags repositories
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param   cascade query bool false "Also delete the documents and execution records of the repository" default(false)
+// @Success 204 "Repository deleted"
+// @Failure 400 {object} schema.ErrorResponse "Invalid cascade parameter"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId} [delete]
+func (h *RepositoryHandler) DeleteRepository(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+
+	cascade, err := strconv.ParseBool(c.DefaultQuery("cascade", "false"))
+	if err != nil {
+		h.logger.Warn("Invalid cascade parameter", "request_id", requestID, "repo_id", repoId, "cascade", c.Query("cascade"))
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "cascade must be true or false"})
+		return
+	}
+
+	h.logger.Info("Deleting repository", "request_id", requestID, "repo_id", repoId, "cascade", cascade)
+	if err := h.repoUseCase.DeleteRepository(c.Request.Context(), repoId, cascade); err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to delete repository", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Repository deleted successfully", "request_id", requestID, "repo_id", repoId, "cascade", cascade)
+	c.Status(http.StatusNoContent)
+}
+
 // ListRepositoryFiles godoc
 // @Summary List files in a repository
-// @Description Retrieves a list of files and directories within a specified repository.
+// @Description Retrieves the file tree of a specified repository. Directories contain their entries and every node has its size and last commit.
 // @Tags repositories
 // @Produce  json
 // @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
@@ -15,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 )
 
@@ -37,6 +38,13 @@ func (l *IntegrationTestLogger) Warn(msg string, args ...any) {
 	log.Printf("[WARN] %s %v", msg, args)
 }
 
+// noopSyncQueue accepts sync jobs without running them
+type noopSyncQueue struct{}
+
+func (noopSyncQueue) Enqueue(job repository.SyncJob) bool {
+	return true
+}
+
 // setupIntegrationTest creates a test environment with actual implementations
 // rather than mocks for integration testing
 func setupIntegrationTest() (*RepositoryHandler, *gin.Engine, *httptest.ResponseRecorder) {
@@ -55,9 +63,10 @@ func setupIntegrationTest() (*RepositoryHandler, *gin.Engine, *httptest.Response
 	// 実際のGitマネージャーを作成
 	// CLIの実装を避けてモックや簡易的な実装を使うことでテスト環境の依存を減らす
 	gitManager := git.NewMockGitManager() // 本来はCLIではなくAPIを使うとよい
+	gitManager.On("VerifyAccess", mock.Anything, mock.Anything).Return(git.AccessCheck{Valid: true}, nil)
 
 	// 実際のUseCaseを作成
-	useCase := repository.NewRepositoryUseCase(repo, gitManager)
+	useCase := repository.NewRepositoryUseCase(repo, gitManager, noopSyncQueue{})
 
 	// テスト対象ハンドラーを作成（実際の実装を使用）
 	handler := NewRepositoryHandler(useCase, logger)
@@ -229,12 +238,14 @@ func TestRepositoryEndToEndFlow(t *testing.T) {
 type inMemoryRepository struct {
 	repositories map[string]entity.Repository // Map of repository ID to Repository
 	managedFiles map[string][]string         // Map of repository ID to managed file paths
+	ruleMatches  map[string][]string         // Map of repository ID to file paths matched by rules
 }
 
 func NewInMemoryRepository() *inMemoryRepository {
 	return &inMemoryRepository{
 		repositories: make(map[string]entity.Repository),
 		managedFiles: make(map[string][]string),
+		ruleMatches:  make(map[string][]string),
 	}
 }
 
@@ -288,6 +299,14 @@ func (r *inMemoryRepository) SaveManagedFiles(ctx context.Context, repoID string
 	return nil
 }
 
+func (r *inMemoryRepository) SaveRuleMatchedFiles(ctx context.Context, repoID string, filePaths []string) error {
+	if _, exists := r.repositories[repoID]; !exists {
+		return repository.ErrRepositoryNotFound
+	}
+	r.ruleMatches[repoID] = filePaths
+	return nil
+}
+
 func (r *inMemoryRepository) GetManagedFiles(ctx context.Context, repoID string) ([]string, error) {
 	filePaths, exists := r.managedFiles[repoID]
 	if !exists {
@@ -295,3 +314,27 @@ func (r *inMemoryRepository) GetManagedFiles(ctx context.Context, repoID string)
 	}
 	return filePaths, nil
 }
+
+func (r *inMemoryRepository) Delete(ctx context.Context, id string) error {
+	if _, exists := r.repositories[id]; !exists {
+		return repository.ErrRepositoryNotFound
+	}
+	delete(r.repositories, id)
+	delete(r.managedFiles, id)
+	delete(r.ruleMatches, id)
+	return nil
+}
+
+func (r *inMemoryRepository) Archive(ctx context.Context, id string) error {
+	// Documents are not kept in memory, so archiving removes the repository like Delete
+	return r.Delete(ctx, id)
+}
+
+func (r *inMemoryRepository) UpdateTokenHealth(ctx context.Context, id string, health entity.TokenHealth) error {
+	repo, exists := r.repositories[id]
+	if !exists {
+		return repository.ErrRepositoryNotFound
+	}
+	repo.SetTokenHealth(health)
+	return nil
+}
@@ -6,6 +6,7 @@ import (
 	"errors"
 	"net/http"
 	"net/http/httptest"
+	"opscore/backend/internal/git_repository/application/dto"
 	apperror "opscore/backend/internal/git_repository/application/error"
 	"opscore/backend/internal/git_repository/application/usecase"
 	"opscore/backend/internal/git_repository/domain/entity"
@@ -67,19 +68,27 @@ func TestRegisterRepository(t *testing.T) {
 
 		// Using matchers for better flexibility
 		contextMatcher := mock.MatchedBy(func(ctx interface{}) bool { return true })
-		urlMatcher := mock.MatchedBy(func(url string) bool { return url == repoURL })
-		tokenMatcher := mock.MatchedBy(func(token string) bool { return token == accessToken })
+		reqMatcher := mock.MatchedBy(func(req dto.RegisterRepositoryRequest) bool {
+			return req.URL == repoURL && req.AccessToken == accessToken
+		})
 
 		// モックの振る舞いを定義
 		createdRepo := entity.ReconstructRepository(
 			uuid.NewString(),
 			"test-repo",
 			repoURL,
+			entity.ProviderGitHub,
 			accessToken,
+			entity.AuthMethodToken,
+			"",
+			"",  // ref
+			"",  // rootPath
+			nil, // includeGlobs
+			nil, // excludeGlobs
 			time.Now(),
 			time.Now(),
 		)
-		mockUseCase.On("Register", contextMatcher, urlMatcher, tokenMatcher).Return(createdRepo, nil)
+		mockUseCase.On("Register", contextMatcher, reqMatcher).Return(createdRepo, nil)
 
 		// ルーターの設定
 		router.POST("/repositories", handler.RegisterRepository)
@@ -98,6 +107,7 @@ func TestRegisterRepository(t *testing.T) {
 		assert.Equal(t, createdRepo.ID(), response.ID)
 		assert.Equal(t, createdRepo.Name(), response.Name)
 		assert.Equal(t, createdRepo.URL(), response.URL)
+		assert.Equal(t, "github", response.Provider)
 
 		// モックの呼び出しを検証
 		mockUseCase.AssertExpectations(t)
@@ -170,7 +180,7 @@ func TestRegisterRepository(t *testing.T) {
 		jsonBody, _ := json.Marshal(requestBody)
 
 		// モックの振る舞いを定義
-		mockUseCase.On("Register", mock.Anything, repoURL, accessToken).Return(nil, &apperror.ConflictError{
+		mockUseCase.On("Register", mock.Anything, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken}).Return(nil, &apperror.ConflictError{
 			Code:         apperror.CodeResourceConflict,
 			ResourceType: "Repository",
 			Identifier:   repoURL,
@@ -211,7 +221,7 @@ func TestRegisterRepository(t *testing.T) {
 		jsonBody, _ := json.Marshal(requestBody)
 
 		// モックの振る舞いを定義
-		mockUseCase.On("Register", mock.Anything, repoURL, accessToken).Return(nil, &apperror.ValidationFailedError{
+		mockUseCase.On("Register", mock.Anything, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken}).Return(nil, &apperror.ValidationFailedError{
 			Code: apperror.CodeValidationFailed,
 			Errors: []apperror.FieldError{
 				{Field: "url", Message: "invalid repository URL format"},
@@ -252,7 +262,7 @@ func TestRegisterRepository(t *testing.T) {
 		jsonBody, _ := json.Marshal(requestBody)
 
 		// モックの振る舞いを定義
-		mockUseCase.On("Register", mock.Anything, repoURL, accessToken).Return(nil, errors.New("internal error"))
+		mockUseCase.On("Register", mock.Anything, dto.RegisterRepositoryRequest{URL: repoURL, AccessToken: accessToken}).Return(nil, errors.New("internal error"))
 
 		// ルーターの設定
 		router.POST("/repositories", handler.RegisterRepository)
@@ -286,6 +296,7 @@ func TestGetRepository(t *testing.T) {
 			repoID,
 			"test-repo",
 			"https://github.com/example/test-repo",
+			entity.ProviderGitHub,
 			"test-token",
 		)
 
@@ -401,12 +412,14 @@ func TestListRepositories(t *testing.T) {
 			uuid.NewString(),
 			"repo1",
 			"https://github.com/example/repo1",
+			entity.ProviderGitHub,
 			"token1",
 		)
 		repo2 := entity.NewRepository(
 			uuid.NewString(),
 			"repo2",
 			"https://github.com/example/repo2",
+			entity.ProviderGitHub,
 			"token2",
 		)
 		repos := []entity.Repository{repo1, repo2}
@@ -1148,3 +1161,279 @@ func TestUpdateAccessToken(t *testing.T) {
 		mockUseCase.AssertExpectations(t)
 	})
 }
+
+func TestVerifyAccessToken(t *testing.T) {
+	t.Run("トークンの状態を含むリポジトリを返す", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		expiresAt := time.Now().Add(48 * time.Hour).UTC().Truncate(time.Second)
+		verifiedRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		verifiedRepo.SetTokenHealth(entity.NewTokenHealth(true, &expiresAt, "", time.Now()))
+		mockUseCase.On("VerifyAccessToken", mock.Anything, repoID).Return(verifiedRepo, nil)
+
+		router.POST("/repositories/:repoId/token/verify", handler.VerifyAccessToken)
+
+		req, _ := http.NewRequest("POST", "/repositories/"+repoID+"/token/verify", nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response schema.RepositoryResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "expiring_soon", response.TokenStatus)
+		require.NotNil(t, response.TokenExpiresAt)
+		assert.True(t, expiresAt.Equal(*response.TokenExpiresAt))
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("リポジトリが存在しない場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		mockUseCase.On("VerifyAccessToken", mock.Anything, repoID).Return(nil, apperror.NewNotFoundError("Repository", repoID, nil))
+
+		router.POST("/repositories/:repoId/token/verify", handler.VerifyAccessToken)
+
+		req, _ := http.NewRequest("POST", "/repositories/"+repoID+"/token/verify", nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+func TestUpdateTracking(t *testing.T) {
+	t.Run("正常に追跡設定を更新できる場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		jsonBody, _ := json.Marshal(schema.UpdateTrackingRequest{Ref: "release/v1", RootPath: "docs"})
+
+		updatedRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		updatedRepo.SetTracking("release/v1", "docs")
+		mockUseCase.On("UpdateTracking", mock.Anything, repoID, dto.UpdateTrackingRequest{Ref: "release/v1", RootPath: "docs"}).Return(updatedRepo, nil)
+
+		router.PUT("/repositories/:repoId/tracking", handler.UpdateTracking)
+
+		req, _ := http.NewRequest("PUT", "/repositories/"+repoID+"/tracking", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response schema.RepositoryResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "release/v1", response.Ref)
+		assert.Equal(t, "docs", response.RootPath)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("不正なルートパスの場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		jsonBody, _ := json.Marshal(schema.UpdateTrackingRequest{RootPath: "../etc"})
+
+		mockUseCase.On("UpdateTracking", mock.Anything, repoID, mock.Anything).Return(nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "rootPath", Message: "root path must be a relative directory path without . or .. segments"},
+		}))
+
+		router.PUT("/repositories/:repoId/tracking", handler.UpdateTracking)
+
+		req, _ := http.NewRequest("PUT", "/repositories/"+repoID+"/tracking", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("不正なリクエスト本文の場合", func(t *testing.T) {
+		_, _, handler, router, rec := setupTest()
+
+		router.PUT("/repositories/:repoId/tracking", handler.UpdateTracking)
+
+		req, _ := http.NewRequest("PUT", "/repositories/"+uuid.NewString()+"/tracking", bytes.NewBufferString("{invalid"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+	})
+}
+
+func TestUpdateFileRules(t *testing.T) {
+	t.Run("正常にファイルのルールを更新できる場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		rules := schema.UpdateFileRulesRequest{IncludeGlobs: []string{"docs/**/*.md"}, ExcludeGlobs: []string{"docs/drafts/**"}}
+		jsonBody, _ := json.Marshal(rules)
+
+		updatedRepo := entity.NewRepository(repoID, "test-repo", "https://github.com/example/test-repo", entity.ProviderGitHub, "token")
+		updatedRepo.SetFileRules(rules.IncludeGlobs, rules.ExcludeGlobs)
+		mockUseCase.On("UpdateFileRules", mock.Anything, repoID, dto.UpdateFileRulesRequest{IncludeGlobs: rules.IncludeGlobs, ExcludeGlobs: rules.ExcludeGlobs}).Return(updatedRepo, nil)
+
+		router.PUT("/repositories/:repoId/files/rules", handler.UpdateFileRules)
+
+		req, _ := http.NewRequest("PUT", "/repositories/"+repoID+"/files/rules", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response schema.RepositoryResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"docs/**/*.md"}, response.IncludeGlobs)
+		assert.Equal(t, []string{"docs/drafts/**"}, response.ExcludeGlobs)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("不正なパターンの場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		jsonBody, _ := json.Marshal(schema.UpdateFileRulesRequest{IncludeGlobs: []string{"/etc/*"}})
+
+		mockUseCase.On("UpdateFileRules", mock.Anything, repoID, mock.Anything).Return(nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "includeGlobs[0]", Message: "pattern must be a relative path using / as the separator"},
+		}))
+
+		router.PUT("/repositories/:repoId/files/rules", handler.UpdateFileRules)
+
+		req, _ := http.NewRequest("PUT", "/repositories/"+repoID+"/files/rules", bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
+
+// TestUpdateRepository はUpdateRepositoryハンドラーのテストです
+func TestUpdateRepository(t *testing.T) {
+	t.Run("正常に名前とURLを変更できる場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		body := schema.UpdateRepositoryRequest{Name: "runbooks", URL: "https://github.com/example/runbooks"}
+		jsonBody, _ := json.Marshal(body)
+
+		updatedRepo := entity.NewRepository(repoID, body.Name, body.URL, entity.ProviderGitHub, "token")
+		mockUseCase.On("UpdateRepository", mock.Anything, repoID, dto.UpdateRepositoryRequest{Name: body.Name, URL: body.URL}).Return(updatedRepo, nil)
+
+		router.PATCH("/repositories/:repoId", handler.UpdateRepository)
+
+		req, _ := http.NewRequest("PATCH", "/repositories/"+repoID, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+
+		var response schema.RepositoryResponse
+		err := json.Unmarshal(rec.Body.Bytes(), &response)
+		require.NoError(t, err)
+		assert.Equal(t, "runbooks", response.Name)
+		assert.Equal(t, "https://github.com/example/runbooks", response.URL)
+
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("URLが他のリポジトリと重複する場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		jsonBody, _ := json.Marshal(schema.UpdateRepositoryRequest{URL: "https://github.com/example/other"})
+
+		mockUseCase.On("UpdateRepository", mock.Anything, repoID, mock.Anything).Return(nil,
+			apperror.NewConflictError("Repository", "https://github.com/example/other", "repository with this URL already exists", nil))
+
+		router.PATCH("/repositories/:repoId", handler.UpdateRepository)
+
+		req, _ := http.NewRequest("PATCH", "/repositories/"+repoID, bytes.NewBuffer(jsonBody))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusConflict, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("不正なリクエストボディの場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		router.PATCH("/repositories/:repoId", handler.UpdateRepository)
+
+		req, _ := http.NewRequest("PATCH", "/repositories/"+uuid.NewString(), bytes.NewBufferString("{invalid json"))
+		req.Header.Set("Content-Type", "application/json")
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertNotCalled(t, "UpdateRepository", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+// TestDeleteRepository はDeleteRepositoryハンドラーのテストです
+func TestDeleteRepository(t *testing.T) {
+	t.Run("cascadeを指定しない場合はドキュメントを残して削除する", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		mockUseCase.On("DeleteRepository", mock.Anything, repoID, false).Return(nil)
+
+		router.DELETE("/repositories/:repoId", handler.DeleteRepository)
+
+		req, _ := http.NewRequest("DELETE", "/repositories/"+repoID, nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("cascade=trueで依存するデータごと削除する", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		mockUseCase.On("DeleteRepository", mock.Anything, repoID, true).Return(nil)
+
+		router.DELETE("/repositories/:repoId", handler.DeleteRepository)
+
+		req, _ := http.NewRequest("DELETE", "/repositories/"+repoID+"?cascade=true", nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNoContent, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("cascadeの値が不正な場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		router.DELETE("/repositories/:repoId", handler.DeleteRepository)
+
+		req, _ := http.NewRequest("DELETE", "/repositories/"+uuid.NewString()+"?cascade=maybe", nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusBadRequest, rec.Code)
+		mockUseCase.AssertNotCalled(t, "DeleteRepository", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("リポジトリが存在しない場合", func(t *testing.T) {
+		mockUseCase, _, handler, router, rec := setupTest()
+
+		repoID := uuid.NewString()
+		mockUseCase.On("DeleteRepository", mock.Anything, repoID, false).Return(apperror.NewNotFoundError("Repository", repoID, nil))
+
+		router.DELETE("/repositories/:repoId", handler.DeleteRepository)
+
+		req, _ := http.NewRequest("DELETE", "/repositories/"+repoID, nil)
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+}
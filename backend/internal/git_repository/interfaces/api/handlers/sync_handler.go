@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	repository "opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+	intererror "opscore/backend/internal/git_repository/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SyncHandler holds dependencies for repository sync handlers.
+type SyncHandler struct {
+	syncUseCase repository.SyncUseCase
+	logger      Logger
+}
+
+// NewSyncHandler creates a new SyncHandler.
+func NewSyncHandler(uc repository.SyncUseCase, logger Logger) *SyncHandler {
+	return &SyncHandler{
+		syncUseCase: uc,
+		logger:      logger,
+	}
+}
+
+// ListSyncRuns godoc
+// @Summary List recent syncs of a repository
+// @Description Retrieves the most recent sync runs of a repository, newest first, together with the last successful run
+// @Tags repositories
+// @Produce json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Param   limit query int false "Maximum number of runs to return (default 20, max 100)"
+// @Success 200 {object} schema.ListSyncRunsResponse "Successfully retrieved sync runs"
+// @Failure 400 {object} schema.ErrorResponse "Invalid repository ID or limit"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/sync-runs [get]
+func (h *SyncHandler) ListSyncRuns(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+
+	if repoId == "" {
+		h.logger.Warn("Missing repository ID", "request_id", requestID)
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_ID", Message: "Repository ID is required"})
+		return
+	}
+
+	limit := 0
+	if limitParam := c.Query("limit"); limitParam != "" {
+		parsed, err := strconv.Atoi(limitParam)
+		if err != nil || parsed <= 0 {
+			c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "limit must be a positive integer"})
+			return
+		}
+		limit = parsed
+	}
+
+	runs, err := h.syncUseCase.ListSyncRuns(c.Request.Context(), repoId, limit)
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to list sync runs", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	lastSuccessful, err := h.syncUseCase.GetLastSuccessfulSync(c.Request.Context(), repoId)
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to get last successful sync run", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	response := schema.ListSyncRunsResponse{
+		SyncRuns: schema.FromSyncRunListDTO(dto.ToSyncRunResponseList(runs)),
+	}
+	if lastSuccessful != nil {
+		run := schema.FromSyncRunDTO(dto.ToSyncRunResponse(lastSuccessful))
+		response.LastSuccessfulRun = &run
+	}
+	c.JSON(http.StatusOK, response)
+}
+
+// TriggerSync godoc
+// @Summary Trigger a repository sync
+// @Description Enqueues a manual sync of a repository. The outcome is recorded as a sync run.
+// @Tags repositories
+// @Produce json
+// @Param   repoId path string true "Repository ID" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"
+// @Success 202 {object} schema.TriggerSyncResponse "Sync enqueued"
+// @Failure 400 {object} schema.ErrorResponse "Invalid repository ID"
+// @Failure 404 {object} schema.ErrorResponse "Repository not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /repositories/{repoId}/sync [post]
+func (h *SyncHandler) TriggerSync(c *gin.Context) {
+	repoId := c.Param("repoId")
+	requestID := c.GetString("request_id")
+
+	if repoId == "" {
+		h.logger.Warn("Missing repository ID", "request_id", requestID)
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_ID", Message: "Repository ID is required"})
+		return
+	}
+
+	if err := h.syncUseCase.TriggerSync(c.Request.Context(), repoId); err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to trigger repository sync", "request_id", requestID, "repo_id", repoId, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Manual repository sync enqueued", "request_id", requestID, "repo_id", repoId)
+	c.JSON(http.StatusAccepted, schema.TriggerSyncResponse{RepositoryID: repoId, Queued: true})
+}
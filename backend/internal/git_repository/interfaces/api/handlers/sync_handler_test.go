@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/domain/entity"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func setupSyncTest() (*repository.MockSyncUseCase, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(repository.MockSyncUseCase)
+	handler := NewSyncHandler(mockUseCase, nopLogger{})
+
+	router := gin.New()
+	router.GET("/repositories/:repoId/sync-runs", handler.ListSyncRuns)
+	router.POST("/repositories/:repoId/sync", handler.TriggerSync)
+	return mockUseCase, router
+}
+
+// TestListSyncRuns はListSyncRunsハンドラのテストです
+func TestListSyncRuns(t *testing.T) {
+	// テスト：同期履歴と最後に成功した同期が返されることを確認する
+	t.Run("同期履歴と最後に成功した同期が返される", func(t *testing.T) {
+		mockUseCase, router := setupSyncTest()
+
+		succeeded := entity.NewSyncRun("run-1", "repo-id", entity.SyncTriggerSchedule)
+		succeeded.Succeed("abc123", []string{"docs/deploy.md"})
+		failed := entity.NewSyncRun("run-2", "repo-id", entity.SyncTriggerManual)
+		failed.Fail("authentication failed")
+
+		mockUseCase.On("ListSyncRuns", mock.Anything, "repo-id", 5).Return([]entity.SyncRun{failed, succeeded}, nil)
+		mockUseCase.On("GetLastSuccessfulSync", mock.Anything, "repo-id").Return(succeeded, nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/repositories/repo-id/sync-runs?limit=5", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response schema.ListSyncRunsResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.SyncRuns, 2)
+		assert.Equal(t, "failed", response.SyncRuns[0].Status)
+		assert.Equal(t, "authentication failed", response.SyncRuns[0].ErrorMessage)
+		require.NotNil(t, response.LastSuccessfulRun)
+		assert.Equal(t, "run-1", response.LastSuccessfulRun.ID)
+		assert.Equal(t, "abc123", response.LastSuccessfulRun.CommitSHA)
+		assert.Equal(t, []string{"docs/deploy.md"}, response.LastSuccessfulRun.FilesChanged)
+	})
+
+	// テスト：不正なlimitの場合は400エラーになることを確認する
+	t.Run("不正なlimitの場合は400エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupSyncTest()
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/repositories/repo-id/sync-runs?limit=abc", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+		mockUseCase.AssertNotCalled(t, "ListSyncRuns", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	// テスト：リポジトリが存在しない場合は404エラーになることを確認する
+	t.Run("リポジトリが存在しない場合は404エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupSyncTest()
+		mockUseCase.On("ListSyncRuns", mock.Anything, "missing", 0).Return(nil, apperror.NewNotFoundError("Repository", "missing", nil))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/repositories/missing/sync-runs", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+}
+
+// TestTriggerSync はTriggerSyncハンドラのテストです
+func TestTriggerSync(t *testing.T) {
+	// テスト：手動同期がキューに登録されると202を返すことを確認する
+	t.Run("手動同期がキューに登録されると202を返す", func(t *testing.T) {
+		mockUseCase, router := setupSyncTest()
+		mockUseCase.On("TriggerSync", mock.Anything, "repo-id").Return(nil)
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/repositories/repo-id/sync", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusAccepted, w.Code)
+		var response schema.TriggerSyncResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "repo-id", response.RepositoryID)
+		assert.True(t, response.Queued)
+	})
+
+	// テスト：キューへの登録に失敗した場合は500エラーになることを確認する
+	t.Run("キューへの登録に失敗した場合は500エラーになる", func(t *testing.T) {
+		mockUseCase, router := setupSyncTest()
+		mockUseCase.On("TriggerSync", mock.Anything, "repo-id").Return(errors.New("sync queue is full"))
+
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodPost, "/repositories/repo-id/sync", nil)
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusInternalServerError, w.Code)
+	})
+}
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"opscore/backend/internal/git_repository/application/dto"
+	repository "opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+	intererror "opscore/backend/internal/git_repository/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxWebhookPayloadSize limits the size of webhook request bodies (GitHub caps payloads at 25 MB).
+const maxWebhookPayloadSize = 25 << 20
+
+// WebhookSecrets holds the shared secrets used to verify webhook requests.
+// A provider whose secret is empty does not accept webhooks.
+type WebhookSecrets struct {
+	GitHub string // Secret used to sign GitHub payloads (X-Hub-Signature-256)
+	GitLab string // Secret token sent by GitLab (X-Gitlab-Token)
+}
+
+// WebhookHandler holds dependencies for webhook handlers.
+type WebhookHandler struct {
+	webhookUseCase repository.WebhookUseCase
+	secrets        WebhookSecrets
+	logger         Logger
+}
+
+// NewWebhookHandler creates a new WebhookHandler.
+func NewWebhookHandler(uc repository.WebhookUseCase, secrets WebhookSecrets, logger Logger) *WebhookHandler {
+	return &WebhookHandler{
+		webhookUseCase: uc,
+		secrets:        secrets,
+		logger:         logger,
+	}
+}
+
+// ReceiveWebhook godoc
+// @Summary Receive a push webhook
+// @Description Receives a push webhook from GitHub or GitLab, verifies it with the configured secret, and enqueues a sync of the matching registered repository. The response lists the managed files changed by the pushed commits.
+// @Tags webhooks
+// @Accept  json
+// @Produce  json
+// @Param   provider path string true "Git hosting provider" Enums(github, gitlab)
+// @Success 200 {object} schema.WebhookSyncResponse "Event ignored (not a push to the default branch)"
+// @Success 202 {object} schema.WebhookSyncResponse "Sync enqueued"
+// @Failure 400 {object} schema.ErrorResponse "Invalid payload"
+// @Failure 401 {object} schema.ErrorResponse "Signature or token verification failed"
+// @Failure 404 {object} schema.ErrorResponse "Unknown provider or repository not registered"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /webhooks/{provider} [post]
+func (h *WebhookHandler) ReceiveWebhook(c *gin.Context) {
+	provider := c.Param("provider")
+	requestID := c.GetString("request_id")
+
+	body, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxWebhookPayloadSize))
+	if err != nil {
+		h.logger.Warn("Failed to read webhook payload", "request_id", requestID, "provider", provider, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Failed to read request body"})
+		return
+	}
+
+	var event *dto.PushEvent
+	switch provider {
+	case "github":
+		event, err = h.parseGitHubPush(c, body)
+	case "gitlab":
+		event, err = h.parseGitLabPush(c, body)
+	default:
+		c.JSON(http.StatusNotFound, schema.ErrorResponse{Code: "NOT_FOUND", Message: "Unsupported webhook provider: " + provider})
+		return
+	}
+	if err != nil {
+		httpErr, ok := err.(*intererror.HTTPError)
+		if !ok {
+			httpErr = intererror.BadRequest("Invalid webhook payload: " + err.Error())
+		}
+		h.logger.Warn("Rejected webhook", "request_id", requestID, "provider", provider, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+	if event == nil {
+		// Verified, but not a push event (e.g. GitHub's ping)
+		c.JSON(http.StatusOK, map[string]string{"message": "Event ignored"})
+		return
+	}
+
+	result, err := h.webhookUseCase.HandlePush(c.Request.Context(), *event)
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to handle push webhook", "request_id", requestID, "provider", provider, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	status := http.StatusOK
+	if result.Queued {
+		status = http.StatusAccepted
+		h.logger.Info("Repository sync enqueued from webhook", "request_id", requestID, "provider", provider, "repo_id", result.RepositoryID, "commit", result.CommitSHA, "changed_files", result.ChangedManagedFiles)
+	}
+	c.JSON(status, schema.FromPushSyncResultDTO(*result))
+}
+
+// parseGitHubPush verifies the HMAC-SHA256 signature of a GitHub webhook and parses its push payload.
+// It returns nil for verified events other than push.
+func (h *WebhookHandler) parseGitHubPush(c *gin.Context, body []byte) (*dto.PushEvent, error) {
+	if h.secrets.GitHub == "" {
+		return nil, intererror.NotFound("GitHub webhooks are not enabled")
+	}
+	if !verifyGitHubSignature(h.secrets.GitHub, body, c.GetHeader("X-Hub-Signature-256")) {
+		return nil, intererror.Unauthorized("Invalid webhook signature")
+	}
+	if c.GetHeader("X-GitHub-Event") != "push" {
+		return nil, nil
+	}
+
+	var payload schema.GitHubPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	event := schema.FromGitHubPushPayload(payload)
+	return &event, nil
+}
+
+// parseGitLabPush verifies the secret token of a GitLab webhook and parses its push payload.
+// Branch and tag pushes share the payload format; it returns nil for other verified events.
+func (h *WebhookHandler) parseGitLabPush(c *gin.Context, body []byte) (*dto.PushEvent, error) {
+	if h.secrets.GitLab == "" {
+		return nil, intererror.NotFound("GitLab webhooks are not enabled")
+	}
+	if subtle.ConstantTimeCompare([]byte(c.GetHeader("X-Gitlab-Token")), []byte(h.secrets.GitLab)) != 1 {
+		return nil, intererror.Unauthorized("Invalid webhook token")
+	}
+	if event := c.GetHeader("X-Gitlab-Event"); event != "Push Hook" && event != "Tag Push Hook" {
+		return nil, nil
+	}
+
+	var payload schema.GitLabPushPayload
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, err
+	}
+	event := schema.FromGitLabPushPayload(payload)
+	return &event, nil
+}
+
+// verifyGitHubSignature checks a "sha256=<hex>" signature against the HMAC-SHA256 of the body.
+func verifyGitHubSignature(secret string, body []byte, signature string) bool {
+	hexDigest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(hexDigest)
+	if err != nil {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
@@ -0,0 +1,269 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"opscore/backend/internal/git_repository/application/dto"
+	apperror "opscore/backend/internal/git_repository/application/error"
+	"opscore/backend/internal/git_repository/application/usecase"
+	"opscore/backend/internal/git_repository/interfaces/api/schema"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testGitHubSecret = "github-secret"
+	testGitLabToken  = "gitlab-token"
+)
+
+// nopLogger discards all log output
+type nopLogger struct{}
+
+func (nopLogger) Info(msg string, args ...any)  {}
+func (nopLogger) Error(msg string, args ...any) {}
+func (nopLogger) Debug(msg string, args ...any) {}
+func (nopLogger) Warn(msg string, args ...any)  {}
+
+func setupWebhookTest(secrets WebhookSecrets) (*repository.MockWebhookUseCase, *gin.Engine) {
+	gin.SetMode(gin.TestMode)
+
+	mockUseCase := new(repository.MockWebhookUseCase)
+	handler := NewWebhookHandler(mockUseCase, secrets, nopLogger{})
+
+	router := gin.New()
+	router.POST("/webhooks/:provider", handler.ReceiveWebhook)
+	return mockUseCase, router
+}
+
+func signGitHubPayload(body []byte) string {
+	mac := hmac.New(sha256.New, []byte(testGitHubSecret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func githubPushBody(t *testing.T) []byte {
+	t.Helper()
+	body, err := json.Marshal(map[string]interface{}{
+		"ref":   "refs/heads/main",
+		"after": "abc123",
+		"repository": map[string]interface{}{
+			"html_url":       "https://github.com/example/runbooks",
+			"clone_url":      "https://github.com/example/runbooks.git",
+			"ssh_url":        "git@github.com:example/runbooks.git",
+			"default_branch": "main",
+		},
+		"commits": []map[string]interface{}{
+			{"id": "a1", "added": []string{"docs/new.md"}, "modified": []string{"docs/deploy.md"}, "removed": []string{}},
+			{"id": "abc123", "added": []string{}, "modified": []string{"docs/deploy.md"}, "removed": []string{"docs/old.md"}},
+		},
+	})
+	require.NoError(t, err)
+	return body
+}
+
+func TestReceiveWebhook_GitHub(t *testing.T) {
+	secrets := WebhookSecrets{GitHub: testGitHubSecret, GitLab: testGitLabToken}
+
+	t.Run("署名が正しいプッシュで同期がキューに登録される", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		body := githubPushBody(t)
+
+		eventMatcher := mock.MatchedBy(func(event dto.PushEvent) bool {
+			return event.Provider == "github" &&
+				event.Ref == "refs/heads/main" &&
+				event.DefaultBranch == "main" &&
+				event.CommitSHA == "abc123" &&
+				assert.ObjectsAreEqual([]string{"https://github.com/example/runbooks", "https://github.com/example/runbooks.git", "git@github.com:example/runbooks.git"}, event.RepositoryURLs) &&
+				assert.ObjectsAreEqual([]string{"docs/new.md", "docs/deploy.md", "docs/old.md"}, event.ChangedPaths)
+		})
+		mockUseCase.On("HandlePush", mock.Anything, eventMatcher).Return(&dto.PushSyncResult{
+			RepositoryID:        "repo-id",
+			Ref:                 "refs/heads/main",
+			CommitSHA:           "abc123",
+			ChangedManagedFiles: []string{"docs/deploy.md"},
+			Queued:              true,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-Hub-Signature-256", signGitHubPayload(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		var resp schema.WebhookSyncResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &resp))
+		assert.Equal(t, "repo-id", resp.RepositoryID)
+		assert.Equal(t, []string{"docs/deploy.md"}, resp.ChangedManagedFiles)
+		assert.True(t, resp.Queued)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("署名が不正な場合は401になる", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		body := githubPushBody(t)
+
+		for _, signature := range []string{"", "sha256=deadbeef", "sha1=" + signGitHubPayload(body)[7:]} {
+			req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+			req.Header.Set("X-GitHub-Event", "push")
+			req.Header.Set("X-Hub-Signature-256", signature)
+			rec := httptest.NewRecorder()
+			router.ServeHTTP(rec, req)
+
+			assert.Equal(t, http.StatusUnauthorized, rec.Code, signature)
+		}
+		mockUseCase.AssertNotCalled(t, "HandlePush", mock.Anything, mock.Anything)
+	})
+
+	t.Run("プッシュ以外のイベントは無視される", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		body := []byte(`{"zen":"Keep it logically awesome."}`)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "ping")
+		req.Header.Set("X-Hub-Signature-256", signGitHubPayload(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusOK, rec.Code)
+		mockUseCase.AssertNotCalled(t, "HandlePush", mock.Anything, mock.Anything)
+	})
+
+	t.Run("登録されていないリポジトリは404になる", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		body := githubPushBody(t)
+		mockUseCase.On("HandlePush", mock.Anything, mock.Anything).Return(nil, apperror.NewNotFoundError("Repository", "https://github.com/example/runbooks", nil))
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-Hub-Signature-256", signGitHubPayload(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+
+	t.Run("シークレット未設定のプロバイダーは404になる", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(WebhookSecrets{GitLab: testGitLabToken})
+		body := githubPushBody(t)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/github", bytes.NewReader(body))
+		req.Header.Set("X-GitHub-Event", "push")
+		req.Header.Set("X-Hub-Signature-256", signGitHubPayload(body))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+		mockUseCase.AssertNotCalled(t, "HandlePush", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReceiveWebhook_GitLab(t *testing.T) {
+	secrets := WebhookSecrets{GitHub: testGitHubSecret, GitLab: testGitLabToken}
+	body := []byte(`{
+		"object_kind": "push",
+		"ref": "refs/heads/main",
+		"checkout_sha": "def456",
+		"project": {
+			"web_url": "https://gitlab.example.com/ops/runbooks",
+			"git_http_url": "https://gitlab.example.com/ops/runbooks.git",
+			"git_ssh_url": "git@gitlab.example.com:ops/runbooks.git",
+			"default_branch": "main"
+		},
+		"commits": [{"id": "def456", "added": [], "modified": ["docs/deploy.md"], "removed": []}]
+	}`)
+
+	t.Run("トークンが正しいプッシュで同期がキューに登録される", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		eventMatcher := mock.MatchedBy(func(event dto.PushEvent) bool {
+			return event.Provider == "gitlab" &&
+				event.CommitSHA == "def456" &&
+				event.RepositoryURLs[0] == "https://gitlab.example.com/ops/runbooks" &&
+				assert.ObjectsAreEqual([]string{"docs/deploy.md"}, event.ChangedPaths)
+		})
+		mockUseCase.On("HandlePush", mock.Anything, eventMatcher).Return(&dto.PushSyncResult{
+			RepositoryID:        "repo-id",
+			ChangedManagedFiles: []string{"docs/deploy.md"},
+			Queued:              true,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+		req.Header.Set("X-Gitlab-Event", "Push Hook")
+		req.Header.Set("X-Gitlab-Token", testGitLabToken)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("タグのプッシュで同期がキューに登録される", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+		tagBody := []byte(`{
+			"object_kind": "tag_push",
+			"ref": "refs/tags/v1.2.0",
+			"checkout_sha": "fed789",
+			"project": {
+				"web_url": "https://gitlab.example.com/ops/runbooks",
+				"git_http_url": "https://gitlab.example.com/ops/runbooks.git",
+				"git_ssh_url": "git@gitlab.example.com:ops/runbooks.git",
+				"default_branch": "main"
+			},
+			"commits": []
+		}`)
+		eventMatcher := mock.MatchedBy(func(event dto.PushEvent) bool {
+			return event.Provider == "gitlab" &&
+				event.Ref == "refs/tags/v1.2.0" &&
+				event.CommitSHA == "fed789"
+		})
+		mockUseCase.On("HandlePush", mock.Anything, eventMatcher).Return(&dto.PushSyncResult{
+			RepositoryID:        "repo-id",
+			Ref:                 "refs/tags/v1.2.0",
+			ChangedManagedFiles: []string{},
+			Queued:              true,
+		}, nil)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(tagBody))
+		req.Header.Set("X-Gitlab-Event", "Tag Push Hook")
+		req.Header.Set("X-Gitlab-Token", testGitLabToken)
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		mockUseCase.AssertExpectations(t)
+	})
+
+	t.Run("トークンが不正な場合は401になる", func(t *testing.T) {
+		mockUseCase, router := setupWebhookTest(secrets)
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/gitlab", bytes.NewReader(body))
+		req.Header.Set("X-Gitlab-Event", "Push Hook")
+		req.Header.Set("X-Gitlab-Token", "wrong-token")
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		mockUseCase.AssertNotCalled(t, "HandlePush", mock.Anything, mock.Anything)
+	})
+}
+
+func TestReceiveWebhook_UnsupportedProvider(t *testing.T) {
+	t.Run("未対応のプロバイダーは404になる", func(t *testing.T) {
+		_, router := setupWebhookTest(WebhookSecrets{GitHub: testGitHubSecret})
+
+		req := httptest.NewRequest(http.MethodPost, "/webhooks/bitbucket", bytes.NewReader([]byte(`{}`)))
+		rec := httptest.NewRecorder()
+		router.ServeHTTP(rec, req)
+
+		assert.Equal(t, http.StatusNotFound, rec.Code)
+	})
+}
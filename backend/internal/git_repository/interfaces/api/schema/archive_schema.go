@@ -0,0 +1,15 @@
+package schema
+
+import "mime/multipart"
+
+// RegisterArchiveRequest represents the multipart form for registering a repository served from an uploaded archive
+type RegisterArchiveRequest struct {
+	Name     string                `form:"name"`                    // Optional display name; derived from the file name when omitted
+	RootPath string                `form:"rootPath"`                // Optional sub-directory of the managed files; the archive root when omitted
+	File     *multipart.FileHeader `form:"file" binding:"required"` // tar.gz or zip archive
+}
+
+// ReplaceArchiveRequest represents the multipart form for uploading a new version of a repository's archive
+type ReplaceArchiveRequest struct {
+	File *multipart.FileHeader `form:"file" binding:"required"` // tar.gz or zip archive
+}
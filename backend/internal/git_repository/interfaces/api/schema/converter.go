@@ -8,7 +8,36 @@ import (
 func ToRegisterRepositoryDTO(req RegisterRepositoryRequest) dto.RegisterRepositoryRequest {
 	return dto.RegisterRepositoryRequest{
 		URL:         req.URL,
+		Name:        req.Name,
+		Provider:    req.Provider,
 		AccessToken: req.AccessToken,
+		DeployKey:   req.DeployKey,
+		Ref:         req.Ref,
+		RootPath:    req.RootPath,
+	}
+}
+
+// ToUpdateTrackingDTO converts API schema to application DTO
+func ToUpdateTrackingDTO(req UpdateTrackingRequest) dto.UpdateTrackingRequest {
+	return dto.UpdateTrackingRequest{
+		Ref:      req.Ref,
+		RootPath: req.RootPath,
+	}
+}
+
+// ToUpdateFileRulesDTO converts API schema to application DTO
+func ToUpdateFileRulesDTO(req UpdateFileRulesRequest) dto.UpdateFileRulesRequest {
+	return dto.UpdateFileRulesRequest{
+		IncludeGlobs: req.IncludeGlobs,
+		ExcludeGlobs: req.ExcludeGlobs,
+	}
+}
+
+// ToUpdateRepositoryDTO converts API schema to application DTO
+func ToUpdateRepositoryDTO(req UpdateRepositoryRequest) dto.UpdateRepositoryRequest {
+	return dto.UpdateRepositoryRequest{
+		Name: req.Name,
+		URL:  req.URL,
 	}
 }
 
@@ -29,11 +58,23 @@ func ToSelectFilesDTO(req SelectFilesRequest) dto.SelectFilesRequest {
 // FromRepositoryDTO converts application DTO to API schema
 func FromRepositoryDTO(dtoResp dto.RepositoryResponse) RepositoryResponse {
 	return RepositoryResponse{
-		ID:        dtoResp.ID,
-		Name:      dtoResp.Name,
-		URL:       dtoResp.URL,
-		CreatedAt: dtoResp.CreatedAt,
-		UpdatedAt: dtoResp.UpdatedAt,
+		ID:                 dtoResp.ID,
+		Name:               dtoResp.Name,
+		URL:                dtoResp.URL,
+		Provider:           dtoResp.Provider,
+		AuthMethod:         dtoResp.AuthMethod,
+		GitHubAppID:        dtoResp.GitHubAppID,
+		InstallationID:     dtoResp.InstallationID,
+		Ref:                dtoResp.Ref,
+		RootPath:           dtoResp.RootPath,
+		IncludeGlobs:       append([]string{}, dtoResp.IncludeGlobs...), // Always an array in JSON
+		ExcludeGlobs:       append([]string{}, dtoResp.ExcludeGlobs...),
+		TokenStatus:        dtoResp.TokenStatus,
+		TokenExpiresAt:     dtoResp.TokenExpiresAt,
+		TokenCheckedAt:     dtoResp.TokenCheckedAt,
+		TokenStatusMessage: dtoResp.TokenStatusMessage,
+		CreatedAt:          dtoResp.CreatedAt,
+		UpdatedAt:          dtoResp.UpdatedAt,
 	}
 }
 
@@ -48,10 +89,24 @@ func FromRepositoryListDTO(dtoList []dto.RepositoryResponse) []RepositoryRespons
 
 // FromFileNodeDTO converts application DTO to API schema
 func FromFileNodeDTO(dtoNode dto.FileNode) FileNode {
-	return FileNode{
+	node := FileNode{
 		Path: dtoNode.Path,
+		Name: dtoNode.Name,
 		Type: dtoNode.Type,
+		Size: dtoNode.Size,
+	}
+	if len(dtoNode.Children) > 0 {
+		node.Children = FromFileNodeListDTO(dtoNode.Children)
+	}
+	if dtoNode.LastCommit != nil {
+		node.LastCommit = &FileCommit{
+			SHA:     dtoNode.LastCommit.SHA,
+			Author:  dtoNode.LastCommit.Author,
+			Date:    dtoNode.LastCommit.Date,
+			Message: dtoNode.LastCommit.Message,
+		}
 	}
+	return node
 }
 
 // FromFileNodeListDTO converts application DTO list to API schema list
@@ -62,3 +117,122 @@ func FromFileNodeListDTO(dtoList []dto.FileNode) []FileNode {
 	}
 	return schemas
 }
+
+// FromGitHubPushPayload converts a GitHub push payload to an application DTO
+func FromGitHubPushPayload(payload GitHubPushPayload) dto.PushEvent {
+	return dto.PushEvent{
+		Provider:       "github",
+		RepositoryURLs: []string{payload.Repository.HTMLURL, payload.Repository.CloneURL, payload.Repository.SSHURL},
+		Ref:            payload.Ref,
+		DefaultBranch:  payload.Repository.DefaultBranch,
+		CommitSHA:      payload.After,
+		ChangedPaths:   changedPaths(payload.Commits),
+	}
+}
+
+// FromGitLabPushPayload converts a GitLab push payload to an application DTO
+func FromGitLabPushPayload(payload GitLabPushPayload) dto.PushEvent {
+	commitSHA := payload.CheckoutSHA
+	if commitSHA == "" {
+		commitSHA = payload.After
+	}
+	return dto.PushEvent{
+		Provider:       "gitlab",
+		RepositoryURLs: []string{payload.Project.WebURL, payload.Project.GitHTTPURL, payload.Project.GitSSHURL},
+		Ref:            payload.Ref,
+		DefaultBranch:  payload.Project.DefaultBranch,
+		CommitSHA:      commitSHA,
+		ChangedPaths:   changedPaths(payload.Commits),
+	}
+}
+
+// changedPaths collects the paths added, modified or removed by the commits
+func changedPaths(commits []PushCommit) []string {
+	seen := map[string]bool{}
+	paths := []string{}
+	for _, commit := range commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, f := range files {
+				if !seen[f] {
+					seen[f] = true
+					paths = append(paths, f)
+				}
+			}
+		}
+	}
+	return paths
+}
+
+// FromPushSyncResultDTO converts application DTO to API schema
+func FromPushSyncResultDTO(result dto.PushSyncResult) WebhookSyncResponse {
+	return WebhookSyncResponse{
+		RepositoryID:        result.RepositoryID,
+		Ref:                 result.Ref,
+		Commit:              result.CommitSHA,
+		ChangedManagedFiles: result.ChangedManagedFiles,
+		Queued:              result.Queued,
+	}
+}
+
+// FromSyncRunDTO converts a SyncRunResponse DTO to a SyncRunResponse schema
+func FromSyncRunDTO(dtoRun dto.SyncRunResponse) SyncRunResponse {
+	return SyncRunResponse{
+		ID:           dtoRun.ID,
+		RepositoryID: dtoRun.RepositoryID,
+		Trigger:      dtoRun.Trigger,
+		Status:       dtoRun.Status,
+		StartedAt:    dtoRun.StartedAt,
+		FinishedAt:   dtoRun.FinishedAt,
+		CommitSHA:    dtoRun.CommitSHA,
+		FilesChanged: dtoRun.FilesChanged,
+		ErrorMessage: dtoRun.ErrorMessage,
+	}
+}
+
+// FromSyncRunListDTO converts a slice of SyncRunResponse DTOs to a slice of SyncRunResponse schemas
+func FromSyncRunListDTO(dtoList []dto.SyncRunResponse) []SyncRunResponse {
+	runs := make([]SyncRunResponse, 0, len(dtoList))
+	for _, dtoRun := range dtoList {
+		runs = append(runs, FromSyncRunDTO(dtoRun))
+	}
+	return runs
+}
+
+// ToRegisterGitHubAppDTO converts API schema to application DTO
+func ToRegisterGitHubAppDTO(req RegisterGitHubAppRequest) dto.RegisterGitHubAppRequest {
+	return dto.RegisterGitHubAppRequest{
+		Name:       req.Name,
+		AppID:      req.AppID,
+		PrivateKey: req.PrivateKey,
+	}
+}
+
+// ToRegisterInstallationRepositoryDTO converts API schema to application DTO
+func ToRegisterInstallationRepositoryDTO(req RegisterInstallationRepositoryRequest) dto.RegisterInstallationRepositoryRequest {
+	return dto.RegisterInstallationRepositoryRequest{
+		URL:            req.URL,
+		InstallationID: req.InstallationID,
+		Ref:            req.Ref,
+		RootPath:       req.RootPath,
+	}
+}
+
+// FromGitHubAppDTO converts a GitHubAppResponse DTO to a GitHubAppResponse schema
+func FromGitHubAppDTO(dtoApp dto.GitHubAppResponse) GitHubAppResponse {
+	return GitHubAppResponse{
+		ID:        dtoApp.ID,
+		Name:      dtoApp.Name,
+		AppID:     dtoApp.AppID,
+		CreatedAt: dtoApp.CreatedAt,
+		UpdatedAt: dtoApp.UpdatedAt,
+	}
+}
+
+// FromGitHubAppListDTO converts a slice of GitHubAppResponse DTOs to a slice of GitHubAppResponse schemas
+func FromGitHubAppListDTO(dtoList []dto.GitHubAppResponse) []GitHubAppResponse {
+	apps := make([]GitHubAppResponse, 0, len(dtoList))
+	for _, dtoApp := range dtoList {
+		apps = append(apps, FromGitHubAppDTO(dtoApp))
+	}
+	return apps
+}
@@ -106,6 +106,40 @@ func TestFromFileNodeDTO(t *testing.T) {
 	assert.Equal(t, dtoNode.Type, schemaNode.Type)
 }
 
+func TestFromFileNodeDTO_Directory(t *testing.T) {
+	commit := &dto.FileCommit{SHA: "abc123", Author: "ops", Date: time.Now(), Message: "Add guide"}
+	dtoNode := dto.FileNode{
+		Path:       "docs",
+		Name:       "docs",
+		Type:       "dir",
+		Size:       42,
+		LastCommit: commit,
+		Children: []dto.FileNode{
+			{Path: "docs/guide.md", Name: "guide.md", Type: "file", Size: 42, LastCommit: commit, Children: []dto.FileNode{}},
+		},
+	}
+
+	schemaNode := FromFileNodeDTO(dtoNode)
+
+	assert.Equal(t, int64(42), schemaNode.Size)
+	assert.Equal(t, "abc123", schemaNode.LastCommit.SHA)
+	assert.Len(t, schemaNode.Children, 1)
+	assert.Equal(t, "guide.md", schemaNode.Children[0].Name)
+	assert.Nil(t, schemaNode.Children[0].Children)
+}
+
+func TestToUpdateFileRulesDTO(t *testing.T) {
+	req := UpdateFileRulesRequest{
+		IncludeGlobs: []string{"docs/**/*.md"},
+		ExcludeGlobs: []string{"docs/drafts/**"},
+	}
+
+	dtoReq := ToUpdateFileRulesDTO(req)
+
+	assert.Equal(t, req.IncludeGlobs, dtoReq.IncludeGlobs)
+	assert.Equal(t, req.ExcludeGlobs, dtoReq.ExcludeGlobs)
+}
+
 func TestFromFileNodeListDTO(t *testing.T) {
 	dtoList := []dto.FileNode{
 		{Path: "README.md", Type: "file"},
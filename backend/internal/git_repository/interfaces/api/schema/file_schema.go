@@ -1,9 +1,23 @@
 package schema
 
+import "time"
+
 // FileNode represents a file or directory within a repository in API responses
 type FileNode struct {
-	Path string `json:"path" example:"src/main.go"`
-	Type string `json:"type" example:"file"` // "file" or "dir"
+	Path       string      `json:"path" example:"src/main.go"`
+	Name       string      `json:"name" example:"main.go"`
+	Type       string      `json:"type" example:"file"`   // "file" or "dir"
+	Size       int64       `json:"size" example:"1024"`   // Size in bytes; the total size of the contained files for a directory
+	LastCommit *FileCommit `json:"last_commit,omitempty"` // Last commit that touched the file or directory
+	Children   []FileNode  `json:"children,omitempty"`    // Entries of a directory, directories first and then by name
+}
+
+// FileCommit represents the last commit that touched a file or directory in API responses
+type FileCommit struct {
+	SHA     string    `json:"sha" example:"9fceb02d0ae598e95dc970b74767f19372d61af8"`
+	Author  string    `json:"author" example:"Jane Doe"`
+	Date    time.Time `json:"date" example:"2025-04-22T10:00:00Z"`
+	Message string    `json:"message" example:"Update restart runbook"` // Subject line of the commit message
 }
 
 // ListFilesResponse represents the API response for listing repository files
@@ -16,6 +30,12 @@ type SelectFilesRequest struct {
 	FilePaths []string `json:"filePaths" binding:"required,dive,required" example:"[\"README.md\", \"docs/adr/0001.md\"]"` // List of file paths to select
 }
 
+// UpdateFileRulesRequest represents the API request body for changing the glob rules that select managed files
+type UpdateFileRulesRequest struct {
+	IncludeGlobs []string `json:"includeGlobs" example:"docs/**/*.md"`   // Files matching any pattern become managed on each sync
+	ExcludeGlobs []string `json:"excludeGlobs" example:"docs/drafts/**"` // Files matching any pattern are never managed by the rules
+}
+
 // SelectFilesResponse represents the API success response for selecting files
 type SelectFilesResponse struct {
 	Message       string `json:"message" example:"Files selected successfully"`
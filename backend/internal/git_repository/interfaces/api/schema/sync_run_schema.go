@@ -0,0 +1,28 @@
+package schema
+
+import "time"
+
+// SyncRunResponse represents the API response format for a repository sync run
+type SyncRunResponse struct {
+	ID           string     `json:"id" example:"f1e2d3c4-b5a6-7890-1234-567890abcdef"`
+	RepositoryID string     `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Trigger      string     `json:"trigger" example:"schedule"` // webhook, schedule or manual
+	Status       string     `json:"status" example:"succeeded"` // running, succeeded or failed
+	StartedAt    time.Time  `json:"started_at" example:"2025-04-22T10:00:00Z"`
+	FinishedAt   *time.Time `json:"finished_at,omitempty" example:"2025-04-22T10:00:05Z"`
+	CommitSHA    string     `json:"commit,omitempty" example:"9fceb02d0ae598e95dc970b74767f19372d61af8"`
+	FilesChanged []string   `json:"files_changed"`
+	ErrorMessage string     `json:"error,omitempty"`
+}
+
+// ListSyncRunsResponse represents the API response for listing a repository's sync runs
+type ListSyncRunsResponse struct {
+	SyncRuns          []SyncRunResponse `json:"sync_runs"`
+	LastSuccessfulRun *SyncRunResponse  `json:"last_successful_run"` // null if the repository never synced successfully
+}
+
+// TriggerSyncResponse represents the API response for a manually requested sync
+type TriggerSyncResponse struct {
+	RepositoryID string `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Queued       bool   `json:"queued" example:"true"`
+}
@@ -0,0 +1,46 @@
+package schema
+
+// GitHubPushPayload represents the parts of a GitHub push webhook payload used by OpsCore
+type GitHubPushPayload struct {
+	Ref        string `json:"ref"`
+	After      string `json:"after"`
+	Repository struct {
+		HTMLURL       string `json:"html_url"`
+		CloneURL      string `json:"clone_url"`
+		SSHURL        string `json:"ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+	Commits []PushCommit `json:"commits"`
+}
+
+// GitLabPushPayload represents the parts of a GitLab push hook payload used by OpsCore
+type GitLabPushPayload struct {
+	ObjectKind  string `json:"object_kind"`
+	Ref         string `json:"ref"`
+	CheckoutSHA string `json:"checkout_sha"`
+	After       string `json:"after"`
+	Project     struct {
+		WebURL        string `json:"web_url"`
+		GitHTTPURL    string `json:"git_http_url"`
+		GitSSHURL     string `json:"git_ssh_url"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"project"`
+	Commits []PushCommit `json:"commits"`
+}
+
+// PushCommit represents a commit in a push webhook payload (shared by GitHub and GitLab)
+type PushCommit struct {
+	ID       string   `json:"id"`
+	Added    []string `json:"added"`
+	Modified []string `json:"modified"`
+	Removed  []string `json:"removed"`
+}
+
+// WebhookSyncResponse represents the API response for a handled push webhook
+type WebhookSyncResponse struct {
+	RepositoryID        string   `json:"repository_id" example:"a1b2c3d4-e5f6-7890-1234-567890abcdef"`
+	Ref                 string   `json:"ref" example:"refs/heads/main"`
+	Commit              string   `json:"commit" example:"6113728f27ae82c7b1a177c8d03f9e96e0adf246"`
+	ChangedManagedFiles []string `json:"changed_managed_files" example:"docs/runbook.md"`
+	Queued              bool     `json:"queued" example:"true"` // false when the push was to a branch other than the default branch
+}
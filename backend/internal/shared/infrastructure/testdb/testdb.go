@@ -0,0 +1,125 @@
+// Package testdb provisions throwaway PostgreSQL databases for integration tests.
+//
+// Connection settings are read from POSTGRES_HOST, POSTGRES_PORT, POSTGRES_USER,
+// POSTGRES_PASSWORD and POSTGRES_DB. When no server is reachable the calling test is skipped.
+package testdb
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/require"
+)
+
+// config is the PostgreSQL configuration for integration tests
+type config struct {
+	Host     string
+	Port     string
+	User     string
+	Password string
+	DBName   string
+}
+
+func getConfig() config {
+	return config{
+		Host:     getEnvOrDefault("POSTGRES_HOST", "localhost"),
+		Port:     getEnvOrDefault("POSTGRES_PORT", "5432"),
+		User:     getEnvOrDefault("POSTGRES_USER", "postgres"),
+		Password: getEnvOrDefault("POSTGRES_PASSWORD", "postgres"),
+		DBName:   getEnvOrDefault("POSTGRES_DB", "opscore_test"),
+	}
+}
+
+func getEnvOrDefault(key, defaultValue string) string {
+	if value, exists := os.LookupEnv(key); exists {
+		return value
+	}
+	return defaultValue
+}
+
+func (c config) dsn(dbName string) string {
+	return fmt.Sprintf("postgresql://%s:%s@%s:%s/%s", c.User, c.Password, c.Host, c.Port, dbName)
+}
+
+// New creates a uniquely named database with every migration applied and returns a pool connected to it.
+// The database is dropped when the test finishes. The test is skipped if PostgreSQL is unavailable.
+func New(t *testing.T) *pgxpool.Pool {
+	t.Helper()
+
+	cfg := getConfig()
+	masterDSN := cfg.dsn("postgres")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	master, err := pgxpool.New(ctx, masterDSN)
+	if err != nil {
+		t.Skipf("Could not connect to PostgreSQL: %v", err)
+	}
+	defer master.Close()
+
+	if err := master.Ping(ctx); err != nil {
+		t.Skipf("Could not ping PostgreSQL: %v", err)
+	}
+
+	// テスト用の一意のデータベース名を生成（並列テスト実行のため）
+	testDBName := fmt.Sprintf("%s_%s", cfg.DBName, uuid.NewString()[:8])
+	_, err = master.Exec(context.Background(), fmt.Sprintf("CREATE DATABASE %s", testDBName))
+	require.NoError(t, err, "Failed to create test database")
+
+	pool, err := pgxpool.New(context.Background(), cfg.dsn(testDBName))
+	require.NoError(t, err, "Failed to connect to test database")
+
+	t.Cleanup(func() {
+		pool.Close()
+
+		cleanupConn, err := pgxpool.New(context.Background(), masterDSN)
+		if err != nil {
+			t.Logf("Failed to connect to master database for cleanup: %v", err)
+			return
+		}
+		defer cleanupConn.Close()
+
+		// 他の接続を強制的に切断
+		_, err = cleanupConn.Exec(context.Background(), fmt.Sprintf("DROP DATABASE IF EXISTS %s WITH (FORCE)", testDBName))
+		if err != nil {
+			t.Logf("Failed to drop test database: %v", err)
+		}
+	})
+
+	applyMigrations(t, pool)
+
+	return pool
+}
+
+// applyMigrations runs every *.up.sql file in the migrations directory in order
+func applyMigrations(t *testing.T, pool *pgxpool.Pool) {
+	t.Helper()
+
+	files, err := filepath.Glob(filepath.Join(migrationsDir(), "*.up.sql"))
+	require.NoError(t, err)
+	require.NotEmpty(t, files, "no migration files found")
+	sort.Strings(files)
+
+	for _, f := range files {
+		sql, err := os.ReadFile(f)
+		require.NoError(t, err)
+
+		_, err = pool.Exec(context.Background(), string(sql))
+		require.NoError(t, err, "Failed to apply migration %s", filepath.Base(f))
+	}
+}
+
+// migrationsDir resolves the migrations directory relative to this source file
+func migrationsDir() string {
+	_, file, _, _ := runtime.Caller(0)
+	return filepath.Join(filepath.Dir(file), "..", "..", "..", "git_repository", "infrastructure", "persistence", "migrations")
+}
@@ -0,0 +1,34 @@
+package dto
+
+import "time"
+
+// LoginRequest represents the use case request for logging in with email and password
+type LoginRequest struct {
+	Email    string
+	Password string
+}
+
+// LoginResponse represents the use case response for a successful login
+type LoginResponse struct {
+	Token     string
+	ExpiresAt time.Time
+	User      UserResponse
+}
+
+// ChangePasswordRequest represents the use case request for changing one's own password
+type ChangePasswordRequest struct {
+	CurrentPassword string
+	NewPassword     string
+}
+
+// SetPasswordRequest represents the use case request for an administrator setting a user's password
+type SetPasswordRequest struct {
+	Password string
+}
+
+// BootstrapAdminRequest represents the initial administrator account created at startup
+type BootstrapAdminRequest struct {
+	Name     string
+	Email    string
+	Password string
+}
@@ -56,3 +56,29 @@ func ToGroupResponseList(groups []entity.Group) []GroupResponse {
 	}
 	return result
 }
+
+// ToRoleResponse converts a Role entity to RoleResponse DTO
+func ToRoleResponse(role entity.Role) RoleResponse {
+	permissions := make([]string, len(role.Permissions()))
+	for i, p := range role.Permissions() {
+		permissions[i] = p.String()
+	}
+
+	return RoleResponse{
+		ID:          role.ID().String(),
+		Name:        role.Name(),
+		Description: role.Description(),
+		Permissions: permissions,
+		CreatedAt:   role.CreatedAt(),
+		UpdatedAt:   role.UpdatedAt(),
+	}
+}
+
+// ToRoleResponseList converts a slice of Role entities to a slice of RoleResponse DTOs
+func ToRoleResponseList(roles []entity.Role) []RoleResponse {
+	result := make([]RoleResponse, 0, len(roles))
+	for _, role := range roles {
+		result = append(result, ToRoleResponse(role))
+	}
+	return result
+}
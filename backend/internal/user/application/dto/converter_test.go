@@ -97,3 +97,21 @@ func TestToGroupResponseList_EmptyList(t *testing.T) {
 	responses := ToGroupResponseList([]entity.Group{})
 	assert.Empty(t, responses)
 }
+
+func TestToRoleResponse(t *testing.T) {
+	roleID, _ := value_object.NewRoleID("role-123")
+	publish, _ := value_object.NewPermission("document:publish")
+	createdAt := time.Date(2023, 1, 1, 12, 0, 0, 0, time.UTC)
+	updatedAt := time.Date(2023, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	role := entity.ReconstructRole(roleID, "Editor", "Description", []value_object.Permission{publish}, createdAt, updatedAt)
+
+	response := ToRoleResponse(role)
+
+	assert.Equal(t, "role-123", response.ID)
+	assert.Equal(t, "Editor", response.Name)
+	assert.Equal(t, "Description", response.Description)
+	assert.Equal(t, []string{"document:publish"}, response.Permissions)
+	assert.Equal(t, createdAt, response.CreatedAt)
+	assert.Equal(t, updatedAt, response.UpdatedAt)
+}
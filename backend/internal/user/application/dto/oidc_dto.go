@@ -0,0 +1,30 @@
+package dto
+
+// ExternalIdentity represents a user as asserted by an external identity provider's ID token
+type ExternalIdentity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	Name          string
+	// Groups holds the values of the configured group claim. It is nil when the claim is absent.
+	Groups []string
+}
+
+// OIDCLoginStart represents the values needed to redirect a browser to the identity provider.
+// State, Nonce and CodeVerifier must be kept by the client and returned on the callback.
+type OIDCLoginStart struct {
+	AuthURL      string
+	State        string
+	Nonce        string
+	CodeVerifier string
+}
+
+// OIDCCallbackRequest represents the use case request for completing an OIDC login
+type OIDCCallbackRequest struct {
+	Code          string
+	State         string
+	ExpectedState string
+	Nonce         string
+	CodeVerifier  string
+}
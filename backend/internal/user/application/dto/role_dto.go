@@ -0,0 +1,33 @@
+package dto
+
+import "time"
+
+// CreateRoleRequest represents the use case request for creating a role
+type CreateRoleRequest struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// UpdateRoleRequest represents the use case request for updating a role
+type UpdateRoleRequest struct {
+	Name        string
+	Description string
+	Permissions []string
+}
+
+// RoleResponse represents the use case response for a role
+type RoleResponse struct {
+	ID          string
+	Name        string
+	Description string
+	Permissions []string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// PermissionResponse represents an entry of the permission catalog
+type PermissionResponse struct {
+	Name        string
+	Description string
+}
@@ -162,6 +162,15 @@ func NewUnauthorizedError(reason string, cause error) *UnauthorizedError {
 	}
 }
 
+// NewInvalidCredentialsError creates an UnauthorizedError for a failed login.
+// The reason is deliberately the same whether the email or the password was wrong.
+func NewInvalidCredentialsError() *UnauthorizedError {
+	return &UnauthorizedError{
+		Code:   CodeInvalidCredentials,
+		Reason: "invalid email or password",
+	}
+}
+
 // NewForbiddenError creates a new ForbiddenError with the correct error code
 func NewForbiddenError(resource, action, userID string) *ForbiddenError {
 	return &ForbiddenError{
@@ -57,6 +57,13 @@ func TestUnauthorizedError_Is(t *testing.T) {
 	assert.True(t, errors.Is(err, ErrUnauthorized))
 }
 
+func TestNewInvalidCredentialsError(t *testing.T) {
+	err := NewInvalidCredentialsError()
+	assert.True(t, errors.Is(err, ErrUnauthorized))
+	assert.Equal(t, CodeInvalidCredentials, err.ErrorCode())
+	assert.Equal(t, "[USA0005] unauthorized: invalid email or password", err.Error())
+}
+
 func TestForbiddenError_Error(t *testing.T) {
 	err := &ForbiddenError{
 		Code:     CodeForbidden,
@@ -0,0 +1,310 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+// DefaultSessionTTL is the lifetime of a login session when none is configured
+const DefaultSessionTTL = 24 * time.Hour
+
+// AuthUseCase defines the interface for authentication related use cases
+type AuthUseCase interface {
+	// Login verifies email and password and starts a new session
+	Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error)
+	// Logout ends the session identified by the token
+	Logout(ctx context.Context, token string) error
+	// Authenticate resolves a session token to the user it belongs to
+	Authenticate(ctx context.Context, token string) (*dto.UserResponse, error)
+	// ChangePassword changes the password of the calling user and ends all of their sessions
+	ChangePassword(ctx context.Context, userID string, req dto.ChangePasswordRequest) error
+	// SetPassword sets another user's password. The actor needs the user:admin permission.
+	SetPassword(ctx context.Context, actorID string, userID string, req dto.SetPasswordRequest) error
+	// EnsureAdmin creates the initial administrator, or gives it a password if it has none
+	EnsureAdmin(ctx context.Context, req dto.BootstrapAdminRequest) error
+}
+
+// authUseCase implements the AuthUseCase interface
+type authUseCase struct {
+	userRepo       repository.UserRepository
+	credentialRepo repository.CredentialRepository
+	sessionRepo    repository.SessionRepository
+	authorizer     AuthorizationUseCase
+	sessionTTL     time.Duration
+}
+
+// NewAuthUseCase creates a new instance of authUseCase.
+// A non-positive sessionTTL falls back to DefaultSessionTTL.
+func NewAuthUseCase(
+	userRepo repository.UserRepository,
+	credentialRepo repository.CredentialRepository,
+	sessionRepo repository.SessionRepository,
+	authorizer AuthorizationUseCase,
+	sessionTTL time.Duration,
+) AuthUseCase {
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSessionTTL
+	}
+	return &authUseCase{
+		userRepo:       userRepo,
+		credentialRepo: credentialRepo,
+		sessionRepo:    sessionRepo,
+		authorizer:     authorizer,
+		sessionTTL:     sessionTTL,
+	}
+}
+
+// dummyPasswordHash is compared against when the email is unknown so that
+// a failed login takes the same time whether or not the account exists
+var dummyPasswordHash, _ = value_object.HashPassword("opscore-dummy-password")
+
+// Login verifies email and password and starts a new session
+func (uc *authUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
+	email, err := value_object.NewEmail(req.Email)
+	if err != nil {
+		return nil, apperror.NewInvalidCredentialsError()
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if user == nil {
+		dummyPasswordHash.Matches(req.Password)
+		return nil, apperror.NewInvalidCredentialsError()
+	}
+
+	hash, err := uc.credentialRepo.FindPasswordHash(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	if hash.IsEmpty() {
+		dummyPasswordHash.Matches(req.Password)
+		return nil, apperror.NewInvalidCredentialsError()
+	}
+	if !hash.Matches(req.Password) {
+		return nil, apperror.NewInvalidCredentialsError()
+	}
+
+	return startSession(ctx, uc.sessionRepo, user, uc.sessionTTL)
+}
+
+// Logout ends the session identified by the token
+func (uc *authUseCase) Logout(ctx context.Context, token string) error {
+	sessionToken, err := value_object.NewSessionToken(token)
+	if err != nil {
+		return apperror.NewUnauthorizedError("missing session token", nil)
+	}
+
+	if err := uc.sessionRepo.DeleteByTokenHash(ctx, sessionToken.Hash()); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	return nil
+}
+
+// Authenticate resolves a session token to the user it belongs to
+func (uc *authUseCase) Authenticate(ctx context.Context, token string) (*dto.UserResponse, error) {
+	sessionToken, err := value_object.NewSessionToken(token)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("missing session token", nil)
+	}
+
+	session, err := uc.sessionRepo.FindByTokenHash(ctx, sessionToken.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve session: %w", err)
+	}
+	if session == nil {
+		return nil, apperror.NewUnauthorizedError("invalid session token", nil)
+	}
+	if session.IsExpired(time.Now()) {
+		if err := uc.sessionRepo.DeleteByTokenHash(ctx, session.TokenHash()); err != nil {
+			return nil, fmt.Errorf("failed to delete expired session: %w", err)
+		}
+		return nil, apperror.NewUnauthorizedError("session expired", nil)
+	}
+
+	user, err := uc.userRepo.FindByID(ctx, session.UserID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if user == nil {
+		return nil, apperror.NewUnauthorizedError("user no longer exists", nil)
+	}
+
+	response := dto.ToUserResponse(user)
+	return &response, nil
+}
+
+// ChangePassword changes the password of the calling user and ends all of their sessions
+func (uc *authUseCase) ChangePassword(ctx context.Context, userID string, req dto.ChangePasswordRequest) error {
+	id, err := value_object.NewUserID(userID)
+	if err != nil {
+		return apperror.NewUnauthorizedError("missing user", nil)
+	}
+
+	current, err := uc.credentialRepo.FindPasswordHash(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve credentials: %w", err)
+	}
+	if !current.Matches(req.CurrentPassword) {
+		return apperror.NewInvalidCredentialsError()
+	}
+
+	hash, err := value_object.HashPassword(req.NewPassword)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "newPassword", Message: err.Error()},
+		})
+	}
+
+	return uc.replacePassword(ctx, id, hash)
+}
+
+// SetPassword sets another user's password. The actor needs the user:admin permission.
+func (uc *authUseCase) SetPassword(ctx context.Context, actorID string, userID string, req dto.SetPasswordRequest) error {
+	if err := uc.authorizer.Authorize(ctx, actorID, value_object.PermissionUserAdmin); err != nil {
+		return err
+	}
+
+	id, err := value_object.NewUserID(userID)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "id", Message: err.Error()},
+		})
+	}
+
+	target, err := uc.userRepo.FindByID(ctx, id)
+	if err != nil {
+		return fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	if target == nil {
+		return apperror.NewNotFoundError("User", userID, nil)
+	}
+
+	hash, err := value_object.HashPassword(req.Password)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "password", Message: err.Error()},
+		})
+	}
+
+	return uc.replacePassword(ctx, id, hash)
+}
+
+// EnsureAdmin creates the initial administrator, or gives it a password if it has none.
+// An existing password is never overwritten.
+func (uc *authUseCase) EnsureAdmin(ctx context.Context, req dto.BootstrapAdminRequest) error {
+	email, err := value_object.NewEmail(req.Email)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "email", Message: err.Error()},
+		})
+	}
+
+	hash, err := value_object.HashPassword(req.Password)
+	if err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "password", Message: err.Error()},
+		})
+	}
+
+	user, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing user: %w", err)
+	}
+
+	if user == nil {
+		userID, err := value_object.NewUserID(uuid.NewString())
+		if err != nil {
+			return fmt.Errorf("failed to generate user ID: %w", err)
+		}
+		role, _ := value_object.NewRole(value_object.RoleAdmin)
+
+		name := req.Name
+		if name == "" {
+			name = "Administrator"
+		}
+
+		user, err = entity.NewUser(userID, name, email, role)
+		if err != nil {
+			return apperror.NewValidationFailedError([]apperror.FieldError{
+				{Field: "name", Message: err.Error()},
+			})
+		}
+		if err := uc.userRepo.Save(ctx, user); err != nil {
+			return fmt.Errorf("failed to save user: %w", err)
+		}
+	} else {
+		existing, err := uc.credentialRepo.FindPasswordHash(ctx, user.ID())
+		if err != nil {
+			return fmt.Errorf("failed to retrieve credentials: %w", err)
+		}
+		if !existing.IsEmpty() {
+			return nil
+		}
+	}
+
+	if err := uc.credentialRepo.SavePasswordHash(ctx, user.ID(), hash); err != nil {
+		return fmt.Errorf("failed to save password: %w", err)
+	}
+
+	return nil
+}
+
+// replacePassword stores a new password hash and revokes every session of the user
+func (uc *authUseCase) replacePassword(ctx context.Context, userID value_object.UserID, hash value_object.PasswordHash) error {
+	if err := uc.credentialRepo.SavePasswordHash(ctx, userID, hash); err != nil {
+		return fmt.Errorf("failed to save password: %w", err)
+	}
+	if err := uc.sessionRepo.DeleteByUserID(ctx, userID); err != nil {
+		return fmt.Errorf("failed to revoke sessions: %w", err)
+	}
+	return nil
+}
+
+// startSession issues a new session token for the user and persists the session
+func startSession(ctx context.Context, sessionRepo repository.SessionRepository, user entity.User, ttl time.Duration) (*dto.LoginResponse, error) {
+	token, err := value_object.GenerateSessionToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate session token: %w", err)
+	}
+
+	session, err := entity.NewSession(token, user.ID(), ttl)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := sessionRepo.Save(ctx, session); err != nil {
+		return nil, fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return &dto.LoginResponse{
+		Token:     token.String(),
+		ExpiresAt: session.ExpiresAt(),
+		User:      dto.ToUserResponse(user),
+	}, nil
+}
+
+// findUser looks up a user by ID string. Returns nil if the ID is empty or unknown.
+func findUser(ctx context.Context, userRepo repository.UserRepository, userID string) (entity.User, error) {
+	id, err := value_object.NewUserID(userID)
+	if err != nil {
+		return nil, nil
+	}
+
+	user, err := userRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve user: %w", err)
+	}
+	return user, nil
+}
@@ -0,0 +1,289 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type authTestDeps struct {
+	userRepo       *repository.MockUserRepository
+	credentialRepo *repository.MockCredentialRepository
+	sessionRepo    *repository.MockSessionRepository
+	roleRepo       *repository.MockRoleRepository
+	uc             AuthUseCase
+}
+
+func newAuthTestDeps() authTestDeps {
+	d := authTestDeps{
+		userRepo:       new(repository.MockUserRepository),
+		credentialRepo: new(repository.MockCredentialRepository),
+		sessionRepo:    new(repository.MockSessionRepository),
+		roleRepo:       new(repository.MockRoleRepository),
+	}
+	authorizer := NewAuthorizationUseCase(d.userRepo, d.roleRepo)
+	d.uc = NewAuthUseCase(d.userRepo, d.credentialRepo, d.sessionRepo, authorizer, time.Hour)
+	return d
+}
+
+func mustHashPassword(t *testing.T, password string) value_object.PasswordHash {
+	hash, err := value_object.HashPassword(password)
+	require.NoError(t, err)
+	return hash
+}
+
+func TestAuthUseCase_Login(t *testing.T) {
+	user := createTestUserForUseCase(t, "user-1", "Test User", "test@example.com", "user")
+	email, _ := value_object.NewEmail("test@example.com")
+
+	t.Run("正しいパスワードでセッションが作成される", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(user, nil)
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, user.ID()).Return(mustHashPassword(t, "password123"), nil)
+
+		var saved entity.Session
+		d.sessionRepo.On("Save", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = args.Get(1).(entity.Session)
+		}).Return(nil)
+
+		result, err := d.uc.Login(context.Background(), dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.Token)
+		assert.Equal(t, "user-1", result.User.ID)
+		require.NotNil(t, saved)
+		token, _ := value_object.NewSessionToken(result.Token)
+		assert.Equal(t, token.Hash(), saved.TokenHash())
+		assert.Equal(t, saved.ExpiresAt(), result.ExpiresAt)
+		d.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("誤ったパスワードで認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(user, nil)
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, user.ID()).Return(mustHashPassword(t, "password123"), nil)
+
+		_, err := d.uc.Login(context.Background(), dto.LoginRequest{Email: "test@example.com", Password: "wrong-password"})
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+		d.sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("存在しないユーザーで認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+
+		_, err := d.uc.Login(context.Background(), dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+
+	t.Run("パスワード未設定のユーザーで認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(user, nil)
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, user.ID()).Return(value_object.PasswordHash{}, nil)
+
+		_, err := d.uc.Login(context.Background(), dto.LoginRequest{Email: "test@example.com", Password: "password123"})
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+}
+
+func TestAuthUseCase_Authenticate(t *testing.T) {
+	user := createTestUserForUseCase(t, "user-1", "Test User", "test@example.com", "user")
+	token, _ := value_object.NewSessionToken("session-token")
+
+	t.Run("有効なセッションでユーザーが返される", func(t *testing.T) {
+		d := newAuthTestDeps()
+		session := entity.ReconstructSession(token.Hash(), user.ID(), time.Now(), time.Now().Add(time.Hour))
+		d.sessionRepo.On("FindByTokenHash", mock.Anything, token.Hash()).Return(session, nil)
+		d.userRepo.On("FindByID", mock.Anything, user.ID()).Return(user, nil)
+
+		result, err := d.uc.Authenticate(context.Background(), token.String())
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", result.ID)
+	})
+
+	t.Run("期限切れのセッションは削除され認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		session := entity.ReconstructSession(token.Hash(), user.ID(), time.Now().Add(-2*time.Hour), time.Now().Add(-time.Hour))
+		d.sessionRepo.On("FindByTokenHash", mock.Anything, token.Hash()).Return(session, nil)
+		d.sessionRepo.On("DeleteByTokenHash", mock.Anything, token.Hash()).Return(nil)
+
+		_, err := d.uc.Authenticate(context.Background(), token.String())
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+		d.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("未知のトークンで認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.sessionRepo.On("FindByTokenHash", mock.Anything, token.Hash()).Return(nil, nil)
+
+		_, err := d.uc.Authenticate(context.Background(), token.String())
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+
+	t.Run("空のトークンで認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+
+		_, err := d.uc.Authenticate(context.Background(), "")
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	d := newAuthTestDeps()
+	token, _ := value_object.NewSessionToken("session-token")
+	d.sessionRepo.On("DeleteByTokenHash", mock.Anything, token.Hash()).Return(nil)
+
+	err := d.uc.Logout(context.Background(), token.String())
+
+	assert.NoError(t, err)
+	d.sessionRepo.AssertExpectations(t)
+}
+
+func TestAuthUseCase_ChangePassword(t *testing.T) {
+	userID, _ := value_object.NewUserID("user-1")
+
+	t.Run("現在のパスワードが正しければ変更されセッションが失効する", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, userID).Return(mustHashPassword(t, "password123"), nil)
+		d.credentialRepo.On("SavePasswordHash", mock.Anything, userID, mock.MatchedBy(func(h value_object.PasswordHash) bool {
+			return h.Matches("new-password")
+		})).Return(nil)
+		d.sessionRepo.On("DeleteByUserID", mock.Anything, userID).Return(nil)
+
+		err := d.uc.ChangePassword(context.Background(), "user-1", dto.ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "new-password",
+		})
+
+		assert.NoError(t, err)
+		d.credentialRepo.AssertExpectations(t)
+		d.sessionRepo.AssertExpectations(t)
+	})
+
+	t.Run("現在のパスワードが誤っていれば認証エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, userID).Return(mustHashPassword(t, "password123"), nil)
+
+		err := d.uc.ChangePassword(context.Background(), "user-1", dto.ChangePasswordRequest{
+			CurrentPassword: "wrong-password",
+			NewPassword:     "new-password",
+		})
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+
+	t.Run("新しいパスワードが短すぎるとバリデーションエラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, userID).Return(mustHashPassword(t, "password123"), nil)
+
+		err := d.uc.ChangePassword(context.Background(), "user-1", dto.ChangePasswordRequest{
+			CurrentPassword: "password123",
+			NewPassword:     "short",
+		})
+
+		assert.True(t, errors.Is(err, apperror.ErrBadRequest))
+	})
+}
+
+func TestAuthUseCase_SetPassword(t *testing.T) {
+	admin := createTestUserForUseCase(t, "admin-1", "Admin", "admin@example.com", "admin")
+	member := createTestUserForUseCase(t, "user-1", "Member", "member@example.com", "user")
+
+	t.Run("管理者は他のユーザーのパスワードを設定できる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByID", mock.Anything, admin.ID()).Return(admin, nil)
+		d.userRepo.On("FindByID", mock.Anything, member.ID()).Return(member, nil)
+		d.credentialRepo.On("SavePasswordHash", mock.Anything, member.ID(), mock.Anything).Return(nil)
+		d.sessionRepo.On("DeleteByUserID", mock.Anything, member.ID()).Return(nil)
+
+		err := d.uc.SetPassword(context.Background(), "admin-1", "user-1", dto.SetPasswordRequest{Password: "password123"})
+
+		assert.NoError(t, err)
+		d.credentialRepo.AssertExpectations(t)
+	})
+
+	t.Run("user:admin権限を持つロールがあれば設定できる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByID", mock.Anything, member.ID()).Return(member, nil)
+		d.userRepo.On("FindByID", mock.Anything, admin.ID()).Return(admin, nil)
+		d.roleRepo.On("FindAssigned", mock.Anything, member.ID(), mock.Anything).
+			Return([]entity.Role{createTestRoleForUseCase(t, "role-1", "User Admin", "user:admin")}, nil)
+		d.credentialRepo.On("SavePasswordHash", mock.Anything, admin.ID(), mock.Anything).Return(nil)
+		d.sessionRepo.On("DeleteByUserID", mock.Anything, admin.ID()).Return(nil)
+
+		err := d.uc.SetPassword(context.Background(), "user-1", "admin-1", dto.SetPasswordRequest{Password: "password123"})
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("一般ユーザーは権限エラーになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByID", mock.Anything, member.ID()).Return(member, nil)
+		d.roleRepo.On("FindAssigned", mock.Anything, member.ID(), mock.Anything).Return([]entity.Role{}, nil)
+
+		err := d.uc.SetPassword(context.Background(), "user-1", "admin-1", dto.SetPasswordRequest{Password: "password123"})
+
+		assert.True(t, errors.Is(err, apperror.ErrForbidden))
+		d.credentialRepo.AssertNotCalled(t, "SavePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("対象ユーザーが存在しないとNotFoundになる", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByID", mock.Anything, admin.ID()).Return(admin, nil)
+		d.userRepo.On("FindByID", mock.Anything, member.ID()).Return(nil, nil)
+
+		err := d.uc.SetPassword(context.Background(), "admin-1", "user-1", dto.SetPasswordRequest{Password: "password123"})
+
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+	})
+}
+
+func TestAuthUseCase_EnsureAdmin(t *testing.T) {
+	email, _ := value_object.NewEmail("admin@example.com")
+	req := dto.BootstrapAdminRequest{Email: "admin@example.com", Password: "password123"}
+
+	t.Run("管理者が存在しなければ作成される", func(t *testing.T) {
+		d := newAuthTestDeps()
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+		d.userRepo.On("Save", mock.Anything, mock.MatchedBy(func(u entity.User) bool {
+			return u.Role().IsAdmin() && u.Email().Equals(email)
+		})).Return(nil)
+		d.credentialRepo.On("SavePasswordHash", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		err := d.uc.EnsureAdmin(context.Background(), req)
+
+		assert.NoError(t, err)
+		d.userRepo.AssertExpectations(t)
+		d.credentialRepo.AssertExpectations(t)
+	})
+
+	t.Run("既存のパスワードは上書きしない", func(t *testing.T) {
+		d := newAuthTestDeps()
+		admin := createTestUserForUseCase(t, "admin-1", "Admin", "admin@example.com", "admin")
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(admin, nil)
+		d.credentialRepo.On("FindPasswordHash", mock.Anything, admin.ID()).Return(mustHashPassword(t, "existing-password"), nil)
+
+		err := d.uc.EnsureAdmin(context.Background(), req)
+
+		assert.NoError(t, err)
+		d.credentialRepo.AssertNotCalled(t, "SavePasswordHash", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
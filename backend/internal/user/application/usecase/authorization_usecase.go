@@ -0,0 +1,101 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// AuthorizationUseCase decides whether a user holds a permission.
+// Administrators hold every permission; other users hold the permissions of the roles
+// assigned to them directly or to any of their groups.
+type AuthorizationUseCase interface {
+	// Authorize returns a ForbiddenError unless the user holds the permission
+	Authorize(ctx context.Context, userID string, permission string) error
+	// EffectivePermissions returns the permissions held by the user
+	EffectivePermissions(ctx context.Context, userID string) ([]string, error)
+}
+
+// authorizationUseCase implements the AuthorizationUseCase interface
+type authorizationUseCase struct {
+	userRepo repository.UserRepository
+	roleRepo repository.RoleRepository
+}
+
+// NewAuthorizationUseCase creates a new instance of authorizationUseCase
+func NewAuthorizationUseCase(userRepo repository.UserRepository, roleRepo repository.RoleRepository) AuthorizationUseCase {
+	return &authorizationUseCase{
+		userRepo: userRepo,
+		roleRepo: roleRepo,
+	}
+}
+
+// Authorize returns a ForbiddenError unless the user holds the permission
+func (uc *authorizationUseCase) Authorize(ctx context.Context, userID string, permission string) error {
+	required, err := value_object.NewPermission(permission)
+	if err != nil {
+		return fmt.Errorf("invalid permission check: %w", err)
+	}
+
+	user, err := findUser(ctx, uc.userRepo, userID)
+	if err != nil {
+		return err
+	}
+	if user == nil {
+		return apperror.NewForbiddenError("Permission", required.String(), userID)
+	}
+	if user.Role().IsAdmin() {
+		return nil
+	}
+
+	roles, err := uc.roleRepo.FindAssigned(ctx, user.ID(), user.GroupIDs())
+	if err != nil {
+		return fmt.Errorf("failed to retrieve assigned roles: %w", err)
+	}
+	for _, role := range roles {
+		if role.HasPermission(required) {
+			return nil
+		}
+	}
+
+	return apperror.NewForbiddenError("Permission", required.String(), userID)
+}
+
+// EffectivePermissions returns the permissions held by the user
+func (uc *authorizationUseCase) EffectivePermissions(ctx context.Context, userID string) ([]string, error) {
+	user, err := findUser(ctx, uc.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.NewNotFoundError("User", userID, nil)
+	}
+
+	var held []value_object.Permission
+	if user.Role().IsAdmin() {
+		held = value_object.AllPermissions()
+	} else {
+		roles, err := uc.roleRepo.FindAssigned(ctx, user.ID(), user.GroupIDs())
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve assigned roles: %w", err)
+		}
+		for _, role := range roles {
+			held = append(held, role.Permissions()...)
+		}
+	}
+
+	// Report in catalog order without duplicates
+	result := []string{}
+	for _, p := range value_object.AllPermissions() {
+		for _, h := range held {
+			if h.Equals(p) {
+				result = append(result, p.String())
+				break
+			}
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func createTestRoleForUseCase(t *testing.T, id, name string, permissions ...string) entity.Role {
+	roleID, _ := value_object.NewRoleID(id)
+	perms := make([]value_object.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		permission, err := value_object.NewPermission(p)
+		require.NoError(t, err)
+		perms = append(perms, permission)
+	}
+	role, err := entity.NewRole(roleID, name, "", perms)
+	require.NoError(t, err)
+	return role
+}
+
+func TestAuthorizationUseCase_Authorize(t *testing.T) {
+	admin := createTestUserForUseCase(t, "admin-1", "Admin", "admin@example.com", "admin")
+	member := createTestUserForUseCase(t, "user-1", "Member", "member@example.com", "user")
+	groupID, _ := value_object.NewGroupID("group-1")
+	require.NoError(t, member.JoinGroup(groupID))
+
+	t.Run("管理者はすべての権限を持つ", func(t *testing.T) {
+		userRepo := new(repository.MockUserRepository)
+		roleRepo := new(repository.MockRoleRepository)
+		uc := NewAuthorizationUseCase(userRepo, roleRepo)
+		userRepo.On("FindByID", mock.Anything, admin.ID()).Return(admin, nil)
+
+		for _, p := range value_object.AllPermissions() {
+			assert.NoError(t, uc.Authorize(context.Background(), "admin-1", p.String()))
+		}
+		roleRepo.AssertNotCalled(t, "FindAssigned", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("ユーザーまたはグループに割り当てられたロールの権限で許可される", func(t *testing.T) {
+		userRepo := new(repository.MockUserRepository)
+		roleRepo := new(repository.MockRoleRepository)
+		uc := NewAuthorizationUseCase(userRepo, roleRepo)
+		userRepo.On("FindByID", mock.Anything, member.ID()).Return(member, nil)
+		roleRepo.On("FindAssigned", mock.Anything, member.ID(), []value_object.GroupID{groupID}).
+			Return([]entity.Role{createTestRoleForUseCase(t, "role-1", "Publisher", "document:publish")}, nil)
+
+		assert.NoError(t, uc.Authorize(context.Background(), "user-1", "document:publish"))
+		err := uc.Authorize(context.Background(), "user-1", "user:admin")
+		assert.True(t, errors.Is(err, apperror.ErrForbidden))
+	})
+
+	t.Run("存在しないユーザーは拒否される", func(t *testing.T) {
+		userRepo := new(repository.MockUserRepository)
+		roleRepo := new(repository.MockRoleRepository)
+		uc := NewAuthorizationUseCase(userRepo, roleRepo)
+		userRepo.On("FindByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+		err := uc.Authorize(context.Background(), "ghost", "document:publish")
+
+		assert.True(t, errors.Is(err, apperror.ErrForbidden))
+	})
+
+	t.Run("カタログにない権限の確認はエラーになる", func(t *testing.T) {
+		uc := NewAuthorizationUseCase(new(repository.MockUserRepository), new(repository.MockRoleRepository))
+
+		err := uc.Authorize(context.Background(), "user-1", "document:destroy")
+
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, apperror.ErrForbidden))
+	})
+}
+
+func TestAuthorizationUseCase_EffectivePermissions(t *testing.T) {
+	t.Run("複数のロールの権限が重複なくカタログ順で返される", func(t *testing.T) {
+		member := createTestUserForUseCase(t, "user-1", "Member", "member@example.com", "user")
+		userRepo := new(repository.MockUserRepository)
+		roleRepo := new(repository.MockRoleRepository)
+		uc := NewAuthorizationUseCase(userRepo, roleRepo)
+		userRepo.On("FindByID", mock.Anything, member.ID()).Return(member, nil)
+		roleRepo.On("FindAssigned", mock.Anything, member.ID(), mock.Anything).Return([]entity.Role{
+			createTestRoleForUseCase(t, "role-1", "Cleaner", "execution:delete", "document:publish"),
+			createTestRoleForUseCase(t, "role-2", "Publisher", "document:publish"),
+		}, nil)
+
+		permissions, err := uc.EffectivePermissions(context.Background(), "user-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, []string{"document:publish", "execution:delete"}, permissions)
+	})
+
+	t.Run("管理者はカタログのすべての権限を持つ", func(t *testing.T) {
+		admin := createTestUserForUseCase(t, "admin-1", "Admin", "admin@example.com", "admin")
+		userRepo := new(repository.MockUserRepository)
+		uc := NewAuthorizationUseCase(userRepo, new(repository.MockRoleRepository))
+		userRepo.On("FindByID", mock.Anything, admin.ID()).Return(admin, nil)
+
+		permissions, err := uc.EffectivePermissions(context.Background(), "admin-1")
+
+		require.NoError(t, err)
+		assert.Len(t, permissions, len(value_object.AllPermissions()))
+	})
+}
@@ -0,0 +1,260 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+// IdentityProvider is an OpenID Connect provider using the authorization code flow with PKCE
+type IdentityProvider interface {
+	// AuthCodeURL returns the authorization endpoint URL the browser is redirected to
+	AuthCodeURL(state, nonce, codeVerifier string) string
+	// Exchange redeems the authorization code and returns the identity from the verified ID token
+	Exchange(ctx context.Context, code, codeVerifier, nonce string) (*dto.ExternalIdentity, error)
+}
+
+// OIDCUseCase defines the interface for single sign-on use cases
+type OIDCUseCase interface {
+	// BeginLogin prepares a new authorization request
+	BeginLogin(ctx context.Context) (*dto.OIDCLoginStart, error)
+	// CompleteLogin verifies the callback, provisions the user and starts a session
+	CompleteLogin(ctx context.Context, req dto.OIDCCallbackRequest) (*dto.LoginResponse, error)
+}
+
+// oidcUseCase implements the OIDCUseCase interface
+type oidcUseCase struct {
+	provider     IdentityProvider
+	userRepo     repository.UserRepository
+	groupRepo    repository.GroupRepository
+	identityRepo repository.ExternalIdentityRepository
+	sessionRepo  repository.SessionRepository
+	sessionTTL   time.Duration
+}
+
+// NewOIDCUseCase creates a new instance of oidcUseCase.
+// A non-positive sessionTTL falls back to DefaultSessionTTL.
+func NewOIDCUseCase(
+	provider IdentityProvider,
+	userRepo repository.UserRepository,
+	groupRepo repository.GroupRepository,
+	identityRepo repository.ExternalIdentityRepository,
+	sessionRepo repository.SessionRepository,
+	sessionTTL time.Duration,
+) OIDCUseCase {
+	if sessionTTL <= 0 {
+		sessionTTL = DefaultSessionTTL
+	}
+	return &oidcUseCase{
+		provider:     provider,
+		userRepo:     userRepo,
+		groupRepo:    groupRepo,
+		identityRepo: identityRepo,
+		sessionRepo:  sessionRepo,
+		sessionTTL:   sessionTTL,
+	}
+}
+
+// BeginLogin prepares a new authorization request
+func (uc *oidcUseCase) BeginLogin(ctx context.Context) (*dto.OIDCLoginStart, error) {
+	state, err := randomURLSafeString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate state: %w", err)
+	}
+	nonce, err := randomURLSafeString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	verifier, err := randomURLSafeString()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate code verifier: %w", err)
+	}
+
+	return &dto.OIDCLoginStart{
+		AuthURL:      uc.provider.AuthCodeURL(state, nonce, verifier),
+		State:        state,
+		Nonce:        nonce,
+		CodeVerifier: verifier,
+	}, nil
+}
+
+// CompleteLogin verifies the callback, provisions the user and starts a session.
+// Users are matched by their linked identity first and by verified email second;
+// unknown users are created with the "user" role.
+func (uc *oidcUseCase) CompleteLogin(ctx context.Context, req dto.OIDCCallbackRequest) (*dto.LoginResponse, error) {
+	if req.State == "" || subtle.ConstantTimeCompare([]byte(req.State), []byte(req.ExpectedState)) != 1 {
+		return nil, apperror.NewUnauthorizedError("state mismatch", nil)
+	}
+	if req.Code == "" {
+		return nil, apperror.NewUnauthorizedError("missing authorization code", nil)
+	}
+
+	identity, err := uc.provider.Exchange(ctx, req.Code, req.CodeVerifier, req.Nonce)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("identity provider rejected the login", err)
+	}
+
+	email, err := value_object.NewEmail(identity.Email)
+	if err != nil {
+		return nil, apperror.NewUnauthorizedError("ID token has no valid email claim", err)
+	}
+
+	user, err := uc.findOrCreateUser(ctx, identity, email)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.syncProfile(ctx, user, identity, email); err != nil {
+		return nil, err
+	}
+
+	if identity.Groups != nil {
+		if err := uc.syncGroups(ctx, user, identity.Groups); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := uc.userRepo.Update(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to update user: %w", err)
+	}
+
+	if err := uc.identityRepo.Link(ctx, identity.Issuer, identity.Subject, user.ID()); err != nil {
+		return nil, fmt.Errorf("failed to link identity: %w", err)
+	}
+
+	return startSession(ctx, uc.sessionRepo, user, uc.sessionTTL)
+}
+
+// findOrCreateUser resolves the user for an external identity, creating one on first login
+func (uc *oidcUseCase) findOrCreateUser(ctx context.Context, identity *dto.ExternalIdentity, email value_object.Email) (entity.User, error) {
+	linkedID, err := uc.identityRepo.FindUserID(ctx, identity.Issuer, identity.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("failed to find linked identity: %w", err)
+	}
+	if !linkedID.IsEmpty() {
+		user, err := uc.userRepo.FindByID(ctx, linkedID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to retrieve user: %w", err)
+		}
+		if user != nil {
+			return user, nil
+		}
+	}
+
+	existing, err := uc.userRepo.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check for existing user: %w", err)
+	}
+	if existing != nil {
+		// Only a verified address may take over an account that already exists locally
+		if !identity.EmailVerified {
+			return nil, apperror.NewConflictError("User", email.String(), "email is registered but not verified by the identity provider", nil)
+		}
+		return existing, nil
+	}
+
+	userID, err := value_object.NewUserID(uuid.NewString())
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate user ID: %w", err)
+	}
+	role, _ := value_object.NewRole(value_object.RoleUser)
+
+	name := identity.Name
+	if name == "" {
+		name = email.String()
+	}
+
+	user, err := entity.NewUser(userID, name, email, role)
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "name", Message: err.Error()},
+		})
+	}
+	if err := uc.userRepo.Save(ctx, user); err != nil {
+		return nil, fmt.Errorf("failed to save user: %w", err)
+	}
+
+	return user, nil
+}
+
+// syncProfile copies the name and email asserted by the identity provider onto the user
+func (uc *oidcUseCase) syncProfile(ctx context.Context, user entity.User, identity *dto.ExternalIdentity, email value_object.Email) error {
+	name := identity.Name
+	if name == "" {
+		name = user.Name()
+	}
+	if name == user.Name() && email.Equals(user.Email()) {
+		return nil
+	}
+
+	if !email.Equals(user.Email()) {
+		other, err := uc.userRepo.FindByEmail(ctx, email)
+		if err != nil {
+			return fmt.Errorf("failed to check for existing user: %w", err)
+		}
+		if other != nil && !other.ID().Equals(user.ID()) {
+			return apperror.NewConflictError("User", email.String(), "email already registered", nil)
+		}
+	}
+
+	if err := user.UpdateProfile(name, email); err != nil {
+		return apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "name", Message: err.Error()},
+		})
+	}
+	return nil
+}
+
+// syncGroups makes the user's membership match the groups named in the claim.
+// Names without a matching Group entity are ignored.
+func (uc *oidcUseCase) syncGroups(ctx context.Context, user entity.User, groupNames []string) error {
+	desired := make(map[string]value_object.GroupID)
+	for _, name := range groupNames {
+		group, err := uc.groupRepo.FindByName(ctx, name)
+		if err != nil {
+			return fmt.Errorf("failed to find group %q: %w", name, err)
+		}
+		if group != nil {
+			desired[group.ID().String()] = group.ID()
+		}
+	}
+
+	for _, groupID := range user.GroupIDs() {
+		if _, ok := desired[groupID.String()]; ok {
+			delete(desired, groupID.String())
+			continue
+		}
+		if err := user.LeaveGroup(groupID); err != nil {
+			return fmt.Errorf("failed to leave group: %w", err)
+		}
+	}
+
+	for _, groupID := range desired {
+		if err := user.JoinGroup(groupID); err != nil {
+			return fmt.Errorf("failed to join group: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// randomURLSafeString returns 32 random bytes encoded as unpadded base64url (43 characters),
+// which is also a valid PKCE code verifier
+func randomURLSafeString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
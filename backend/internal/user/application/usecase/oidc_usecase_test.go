@@ -0,0 +1,243 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+const testIssuer = "https://idp.example.com"
+
+type mockIdentityProvider struct {
+	mock.Mock
+}
+
+func (m *mockIdentityProvider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	args := m.Called(state, nonce, codeVerifier)
+	return args.String(0)
+}
+
+func (m *mockIdentityProvider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*dto.ExternalIdentity, error) {
+	args := m.Called(ctx, code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.ExternalIdentity), args.Error(1)
+}
+
+type oidcTestDeps struct {
+	provider     *mockIdentityProvider
+	userRepo     *repository.MockUserRepository
+	groupRepo    *repository.MockGroupRepository
+	identityRepo *repository.MockExternalIdentityRepository
+	sessionRepo  *repository.MockSessionRepository
+	uc           OIDCUseCase
+}
+
+func newOIDCTestDeps() oidcTestDeps {
+	d := oidcTestDeps{
+		provider:     new(mockIdentityProvider),
+		userRepo:     new(repository.MockUserRepository),
+		groupRepo:    new(repository.MockGroupRepository),
+		identityRepo: new(repository.MockExternalIdentityRepository),
+		sessionRepo:  new(repository.MockSessionRepository),
+	}
+	d.uc = NewOIDCUseCase(d.provider, d.userRepo, d.groupRepo, d.identityRepo, d.sessionRepo, time.Hour)
+	return d
+}
+
+func validCallback() dto.OIDCCallbackRequest {
+	return dto.OIDCCallbackRequest{
+		Code:          "auth-code",
+		State:         "state-1",
+		ExpectedState: "state-1",
+		Nonce:         "nonce-1",
+		CodeVerifier:  "verifier-1",
+	}
+}
+
+func TestOIDCUseCase_BeginLogin(t *testing.T) {
+	t.Run("state・nonce・verifierが毎回異なる値で生成される", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		d.provider.On("AuthCodeURL", mock.Anything, mock.Anything, mock.Anything).Return("https://idp.example.com/authorize")
+
+		first, err := d.uc.BeginLogin(context.Background())
+		require.NoError(t, err)
+		second, err := d.uc.BeginLogin(context.Background())
+		require.NoError(t, err)
+
+		assert.Equal(t, "https://idp.example.com/authorize", first.AuthURL)
+		assert.Len(t, first.CodeVerifier, 43)
+		assert.NotEqual(t, first.State, second.State)
+		assert.NotEqual(t, first.Nonce, second.Nonce)
+		assert.NotEqual(t, first.CodeVerifier, second.CodeVerifier)
+		d.provider.AssertCalled(t, "AuthCodeURL", first.State, first.Nonce, first.CodeVerifier)
+	})
+}
+
+func TestOIDCUseCase_CompleteLogin(t *testing.T) {
+	email, _ := value_object.NewEmail("sso@example.com")
+
+	t.Run("stateが一致しない場合は認証エラーになる", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		req := validCallback()
+		req.State = "forged"
+
+		_, err := d.uc.CompleteLogin(context.Background(), req)
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+		d.provider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("IdPがトークンを拒否した場合は認証エラーになる", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(nil, errors.New("invalid_grant"))
+
+		_, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+
+	t.Run("初回ログインでユーザーが作成されグループが割り当てられる", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		identity := &dto.ExternalIdentity{
+			Issuer: testIssuer, Subject: "sub-1", Email: "sso@example.com", EmailVerified: true,
+			Name: "SSO User", Groups: []string{"ops", "unknown"},
+		}
+		opsGroup := createTestGroupForUseCase(t, "group-ops", "ops", "")
+
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+		d.identityRepo.On("FindUserID", mock.Anything, testIssuer, "sub-1").Return(value_object.UserID{}, nil)
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(nil, nil)
+		d.userRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+		d.groupRepo.On("FindByName", mock.Anything, "ops").Return(opsGroup, nil)
+		d.groupRepo.On("FindByName", mock.Anything, "unknown").Return(nil, nil)
+
+		var updated entity.User
+		d.userRepo.On("Update", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			updated = args.Get(1).(entity.User)
+		}).Return(nil)
+		d.identityRepo.On("Link", mock.Anything, testIssuer, "sub-1", mock.Anything).Return(nil)
+		d.sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.Token)
+		assert.Equal(t, "SSO User", result.User.Name)
+		assert.Equal(t, "user", result.User.Role)
+		require.NotNil(t, updated)
+		require.Len(t, updated.GroupIDs(), 1)
+		assert.Equal(t, "group-ops", updated.GroupIDs()[0].String())
+		d.identityRepo.AssertCalled(t, "Link", mock.Anything, testIssuer, "sub-1", updated.ID())
+	})
+
+	t.Run("リンク済みのユーザーはプロフィールとグループが同期される", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		user := createTestUserForUseCase(t, "user-1", "Old Name", "sso@example.com", "admin")
+		staleGroup, _ := value_object.NewGroupID("group-stale")
+		require.NoError(t, user.JoinGroup(staleGroup))
+		identity := &dto.ExternalIdentity{
+			Issuer: testIssuer, Subject: "sub-1", Email: "sso@example.com", EmailVerified: true,
+			Name: "New Name", Groups: []string{},
+		}
+
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+		d.identityRepo.On("FindUserID", mock.Anything, testIssuer, "sub-1").Return(user.ID(), nil)
+		d.userRepo.On("FindByID", mock.Anything, user.ID()).Return(user, nil)
+		d.userRepo.On("Update", mock.Anything, user).Return(nil)
+		d.identityRepo.On("Link", mock.Anything, testIssuer, "sub-1", user.ID()).Return(nil)
+		d.sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		require.NoError(t, err)
+		assert.Equal(t, "New Name", result.User.Name)
+		assert.Equal(t, "admin", result.User.Role)
+		assert.Empty(t, user.GroupIDs())
+		d.userRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("グループクレームがない場合は既存の所属を変更しない", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		user := createTestUserForUseCase(t, "user-1", "SSO User", "sso@example.com", "user")
+		groupID, _ := value_object.NewGroupID("group-local")
+		require.NoError(t, user.JoinGroup(groupID))
+		identity := &dto.ExternalIdentity{
+			Issuer: testIssuer, Subject: "sub-1", Email: "sso@example.com", EmailVerified: true, Name: "SSO User",
+		}
+
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+		d.identityRepo.On("FindUserID", mock.Anything, testIssuer, "sub-1").Return(user.ID(), nil)
+		d.userRepo.On("FindByID", mock.Anything, user.ID()).Return(user, nil)
+		d.userRepo.On("Update", mock.Anything, user).Return(nil)
+		d.identityRepo.On("Link", mock.Anything, testIssuer, "sub-1", user.ID()).Return(nil)
+		d.sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		_, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		require.NoError(t, err)
+		assert.Len(t, user.GroupIDs(), 1)
+		d.groupRepo.AssertNotCalled(t, "FindByName", mock.Anything, mock.Anything)
+	})
+
+	t.Run("検証済みメールアドレスで既存ユーザーにリンクされる", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		user := createTestUserForUseCase(t, "user-1", "SSO User", "sso@example.com", "user")
+		identity := &dto.ExternalIdentity{
+			Issuer: testIssuer, Subject: "sub-1", Email: "sso@example.com", EmailVerified: true, Name: "SSO User",
+		}
+
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+		d.identityRepo.On("FindUserID", mock.Anything, testIssuer, "sub-1").Return(value_object.UserID{}, nil)
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(user, nil)
+		d.userRepo.On("Update", mock.Anything, user).Return(nil)
+		d.identityRepo.On("Link", mock.Anything, testIssuer, "sub-1", user.ID()).Return(nil)
+		d.sessionRepo.On("Save", mock.Anything, mock.Anything).Return(nil)
+
+		result, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		require.NoError(t, err)
+		assert.Equal(t, "user-1", result.User.ID)
+		d.identityRepo.AssertExpectations(t)
+	})
+
+	t.Run("未検証のメールアドレスでは既存ユーザーを引き継げない", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		user := createTestUserForUseCase(t, "user-1", "SSO User", "sso@example.com", "admin")
+		identity := &dto.ExternalIdentity{
+			Issuer: testIssuer, Subject: "attacker", Email: "sso@example.com", EmailVerified: false,
+		}
+
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+		d.identityRepo.On("FindUserID", mock.Anything, testIssuer, "attacker").Return(value_object.UserID{}, nil)
+		d.userRepo.On("FindByEmail", mock.Anything, email).Return(user, nil)
+
+		_, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		assert.True(t, errors.Is(err, apperror.ErrConflict))
+		d.identityRepo.AssertNotCalled(t, "Link", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		d.sessionRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("メールアドレスがない場合は認証エラーになる", func(t *testing.T) {
+		d := newOIDCTestDeps()
+		identity := &dto.ExternalIdentity{Issuer: testIssuer, Subject: "sub-1"}
+		d.provider.On("Exchange", mock.Anything, "auth-code", "verifier-1", "nonce-1").Return(identity, nil)
+
+		_, err := d.uc.CompleteLogin(context.Background(), validCallback())
+
+		assert.True(t, errors.Is(err, apperror.ErrUnauthorized))
+	})
+}
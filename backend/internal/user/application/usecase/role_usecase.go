@@ -0,0 +1,348 @@
+package usecase
+
+import (
+	"context"
+	"fmt"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/google/uuid"
+)
+
+// RoleUseCase defines the interface for role related use cases
+type RoleUseCase interface {
+	// ListPermissions returns the permission catalog
+	ListPermissions(ctx context.Context) []dto.PermissionResponse
+	// Create creates a new role
+	Create(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error)
+	// GetByID retrieves a role by its ID
+	GetByID(ctx context.Context, roleID string) (*dto.RoleResponse, error)
+	// GetAll retrieves all roles
+	GetAll(ctx context.Context) ([]dto.RoleResponse, error)
+	// Update updates the name, description and permissions of a role
+	Update(ctx context.Context, roleID string, req dto.UpdateRoleRequest) (*dto.RoleResponse, error)
+	// Delete removes a role and all of its assignments
+	Delete(ctx context.Context, roleID string) error
+	// AssignToUser grants a role to a user
+	AssignToUser(ctx context.Context, roleID string, userID string) error
+	// UnassignFromUser revokes a role from a user
+	UnassignFromUser(ctx context.Context, roleID string, userID string) error
+	// AssignToGroup grants a role to every member of a group
+	AssignToGroup(ctx context.Context, roleID string, groupID string) error
+	// UnassignFromGroup revokes a role from a group
+	UnassignFromGroup(ctx context.Context, roleID string, groupID string) error
+	// GetUserRoles retrieves the roles assigned directly to a user
+	GetUserRoles(ctx context.Context, userID string) ([]dto.RoleResponse, error)
+	// GetGroupRoles retrieves the roles assigned to a group
+	GetGroupRoles(ctx context.Context, groupID string) ([]dto.RoleResponse, error)
+}
+
+// roleUseCase implements the RoleUseCase interface
+type roleUseCase struct {
+	roleRepo  repository.RoleRepository
+	userRepo  repository.UserRepository
+	groupRepo repository.GroupRepository
+}
+
+// NewRoleUseCase creates a new instance of roleUseCase
+func NewRoleUseCase(roleRepo repository.RoleRepository, userRepo repository.UserRepository, groupRepo repository.GroupRepository) RoleUseCase {
+	return &roleUseCase{
+		roleRepo:  roleRepo,
+		userRepo:  userRepo,
+		groupRepo: groupRepo,
+	}
+}
+
+// ListPermissions returns the permission catalog
+func (uc *roleUseCase) ListPermissions(ctx context.Context) []dto.PermissionResponse {
+	permissions := value_object.AllPermissions()
+	result := make([]dto.PermissionResponse, len(permissions))
+	for i, p := range permissions {
+		result[i] = dto.PermissionResponse{Name: p.String(), Description: p.Description()}
+	}
+	return result
+}
+
+// Create creates a new role
+func (uc *roleUseCase) Create(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	permissions, err := parsePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := uc.ensureNameAvailable(ctx, req.Name, nil); err != nil {
+		return nil, err
+	}
+
+	roleID, err := value_object.NewRoleID(uuid.NewString())
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "id", Message: err.Error()},
+		})
+	}
+
+	role, err := entity.NewRole(roleID, req.Name, req.Description, permissions)
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "name", Message: err.Error()},
+		})
+	}
+
+	if err := uc.roleRepo.Save(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to save role: %w", err)
+	}
+
+	response := dto.ToRoleResponse(role)
+	return &response, nil
+}
+
+// GetByID retrieves a role by its ID
+func (uc *roleUseCase) GetByID(ctx context.Context, roleID string) (*dto.RoleResponse, error) {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	response := dto.ToRoleResponse(role)
+	return &response, nil
+}
+
+// GetAll retrieves all roles
+func (uc *roleUseCase) GetAll(ctx context.Context) ([]dto.RoleResponse, error) {
+	roles, err := uc.roleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve roles: %w", err)
+	}
+
+	return dto.ToRoleResponseList(roles), nil
+}
+
+// Update updates the name, description and permissions of a role
+func (uc *roleUseCase) Update(ctx context.Context, roleID string, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return nil, err
+	}
+
+	permissions, err := parsePermissions(req.Permissions)
+	if err != nil {
+		return nil, err
+	}
+
+	if req.Name != role.Name() {
+		if err := uc.ensureNameAvailable(ctx, req.Name, role); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := role.UpdateInfo(req.Name, req.Description); err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "name", Message: err.Error()},
+		})
+	}
+	role.SetPermissions(permissions)
+
+	if err := uc.roleRepo.Update(ctx, role); err != nil {
+		return nil, fmt.Errorf("failed to update role: %w", err)
+	}
+
+	response := dto.ToRoleResponse(role)
+	return &response, nil
+}
+
+// Delete removes a role and all of its assignments
+func (uc *roleUseCase) Delete(ctx context.Context, roleID string) error {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.Delete(ctx, role.ID()); err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	return nil
+}
+
+// AssignToUser grants a role to a user
+func (uc *roleUseCase) AssignToUser(ctx context.Context, roleID string, userID string) error {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	user, err := uc.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.AssignToUser(ctx, role.ID(), user.ID()); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
+}
+
+// UnassignFromUser revokes a role from a user
+func (uc *roleUseCase) UnassignFromUser(ctx context.Context, roleID string, userID string) error {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	user, err := uc.findUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.UnassignFromUser(ctx, role.ID(), user.ID()); err != nil {
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+	return nil
+}
+
+// AssignToGroup grants a role to every member of a group
+func (uc *roleUseCase) AssignToGroup(ctx context.Context, roleID string, groupID string) error {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	group, err := uc.findGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.AssignToGroup(ctx, role.ID(), group.ID()); err != nil {
+		return fmt.Errorf("failed to assign role: %w", err)
+	}
+	return nil
+}
+
+// UnassignFromGroup revokes a role from a group
+func (uc *roleUseCase) UnassignFromGroup(ctx context.Context, roleID string, groupID string) error {
+	role, err := uc.findRole(ctx, roleID)
+	if err != nil {
+		return err
+	}
+	group, err := uc.findGroup(ctx, groupID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.roleRepo.UnassignFromGroup(ctx, role.ID(), group.ID()); err != nil {
+		return fmt.Errorf("failed to unassign role: %w", err)
+	}
+	return nil
+}
+
+// GetUserRoles retrieves the roles assigned directly to a user
+func (uc *roleUseCase) GetUserRoles(ctx context.Context, userID string) ([]dto.RoleResponse, error) {
+	user, err := uc.findUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := uc.roleRepo.FindByUserID(ctx, user.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve roles: %w", err)
+	}
+
+	return dto.ToRoleResponseList(roles), nil
+}
+
+// GetGroupRoles retrieves the roles assigned to a group
+func (uc *roleUseCase) GetGroupRoles(ctx context.Context, groupID string) ([]dto.RoleResponse, error) {
+	group, err := uc.findGroup(ctx, groupID)
+	if err != nil {
+		return nil, err
+	}
+
+	roles, err := uc.roleRepo.FindByGroupID(ctx, group.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve roles: %w", err)
+	}
+
+	return dto.ToRoleResponseList(roles), nil
+}
+
+// findRole retrieves a role or returns a NotFoundError
+func (uc *roleUseCase) findRole(ctx context.Context, roleID string) (entity.Role, error) {
+	id, err := value_object.NewRoleID(roleID)
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "id", Message: err.Error()},
+		})
+	}
+
+	role, err := uc.roleRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve role: %w", err)
+	}
+	if role == nil {
+		return nil, apperror.NewNotFoundError("Role", roleID, nil)
+	}
+	return role, nil
+}
+
+// findUser retrieves a user or returns a NotFoundError
+func (uc *roleUseCase) findUser(ctx context.Context, userID string) (entity.User, error) {
+	user, err := findUser(ctx, uc.userRepo, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return nil, apperror.NewNotFoundError("User", userID, nil)
+	}
+	return user, nil
+}
+
+// findGroup retrieves a group or returns a NotFoundError
+func (uc *roleUseCase) findGroup(ctx context.Context, groupID string) (entity.Group, error) {
+	id, err := value_object.NewGroupID(groupID)
+	if err != nil {
+		return nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "group_id", Message: err.Error()},
+		})
+	}
+
+	group, err := uc.groupRepo.FindByID(ctx, id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to retrieve group: %w", err)
+	}
+	if group == nil {
+		return nil, apperror.NewNotFoundError("Group", groupID, nil)
+	}
+	return group, nil
+}
+
+// ensureNameAvailable returns a ConflictError if another role already uses the name
+func (uc *roleUseCase) ensureNameAvailable(ctx context.Context, name string, self entity.Role) error {
+	existing, err := uc.roleRepo.FindByName(ctx, name)
+	if err != nil {
+		return fmt.Errorf("failed to check for existing role: %w", err)
+	}
+	if existing != nil && (self == nil || !existing.ID().Equals(self.ID())) {
+		return apperror.NewConflictError("Role", name, "role name already exists", nil)
+	}
+	return nil
+}
+
+// parsePermissions validates permission names against the catalog
+func parsePermissions(names []string) ([]value_object.Permission, error) {
+	permissions := make([]value_object.Permission, 0, len(names))
+	var fieldErrors []apperror.FieldError
+	for _, name := range names {
+		permission, err := value_object.NewPermission(name)
+		if err != nil {
+			fieldErrors = append(fieldErrors, apperror.FieldError{Field: "permissions", Message: err.Error()})
+			continue
+		}
+		permissions = append(permissions, permission)
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, apperror.NewValidationFailedError(fieldErrors)
+	}
+	return permissions, nil
+}
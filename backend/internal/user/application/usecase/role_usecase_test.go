@@ -0,0 +1,167 @@
+package usecase
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+type roleTestDeps struct {
+	roleRepo  *repository.MockRoleRepository
+	userRepo  *repository.MockUserRepository
+	groupRepo *repository.MockGroupRepository
+	uc        RoleUseCase
+}
+
+func newRoleTestDeps() roleTestDeps {
+	d := roleTestDeps{
+		roleRepo:  new(repository.MockRoleRepository),
+		userRepo:  new(repository.MockUserRepository),
+		groupRepo: new(repository.MockGroupRepository),
+	}
+	d.uc = NewRoleUseCase(d.roleRepo, d.userRepo, d.groupRepo)
+	return d
+}
+
+func TestRoleUseCase_ListPermissions(t *testing.T) {
+	d := newRoleTestDeps()
+
+	permissions := d.uc.ListPermissions(context.Background())
+
+	names := make([]string, len(permissions))
+	for i, p := range permissions {
+		names[i] = p.Name
+	}
+	assert.Equal(t, []string{"repository:manage", "document:publish", "execution:delete", "user:admin"}, names)
+}
+
+func TestRoleUseCase_Create(t *testing.T) {
+	t.Run("有効なリクエストでロールが作成される", func(t *testing.T) {
+		d := newRoleTestDeps()
+		d.roleRepo.On("FindByName", mock.Anything, "Publisher").Return(nil, nil)
+		d.roleRepo.On("Save", mock.Anything, mock.AnythingOfType("*entity.role")).Return(nil)
+
+		result, err := d.uc.Create(context.Background(), dto.CreateRoleRequest{
+			Name:        "Publisher",
+			Permissions: []string{"document:publish", "repository:manage"},
+		})
+
+		require.NoError(t, err)
+		assert.NotEmpty(t, result.ID)
+		assert.Equal(t, []string{"document:publish", "repository:manage"}, result.Permissions)
+		d.roleRepo.AssertExpectations(t)
+	})
+
+	t.Run("カタログにない権限でバリデーションエラーになる", func(t *testing.T) {
+		d := newRoleTestDeps()
+
+		_, err := d.uc.Create(context.Background(), dto.CreateRoleRequest{
+			Name:        "Publisher",
+			Permissions: []string{"document:publish", "everything"},
+		})
+
+		assert.True(t, errors.Is(err, apperror.ErrBadRequest))
+		d.roleRepo.AssertNotCalled(t, "Save", mock.Anything, mock.Anything)
+	})
+
+	t.Run("既存の名前で競合エラーになる", func(t *testing.T) {
+		d := newRoleTestDeps()
+		d.roleRepo.On("FindByName", mock.Anything, "Publisher").Return(createTestRoleForUseCase(t, "role-1", "Publisher"), nil)
+
+		_, err := d.uc.Create(context.Background(), dto.CreateRoleRequest{Name: "Publisher"})
+
+		assert.True(t, errors.Is(err, apperror.ErrConflict))
+	})
+}
+
+func TestRoleUseCase_Update(t *testing.T) {
+	t.Run("名前と権限が更新される", func(t *testing.T) {
+		d := newRoleTestDeps()
+		role := createTestRoleForUseCase(t, "role-1", "Publisher", "document:publish")
+		d.roleRepo.On("FindByID", mock.Anything, role.ID()).Return(role, nil)
+		d.roleRepo.On("FindByName", mock.Anything, "Operator").Return(nil, nil)
+		d.roleRepo.On("Update", mock.Anything, role).Return(nil)
+
+		result, err := d.uc.Update(context.Background(), "role-1", dto.UpdateRoleRequest{
+			Name:        "Operator",
+			Permissions: []string{"execution:delete"},
+		})
+
+		require.NoError(t, err)
+		assert.Equal(t, "Operator", result.Name)
+		assert.Equal(t, []string{"execution:delete"}, result.Permissions)
+	})
+
+	t.Run("存在しないロールはNotFoundになる", func(t *testing.T) {
+		d := newRoleTestDeps()
+		d.roleRepo.On("FindByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+		_, err := d.uc.Update(context.Background(), "missing", dto.UpdateRoleRequest{Name: "X"})
+
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+	})
+}
+
+func TestRoleUseCase_Assign(t *testing.T) {
+	role := createTestRoleForUseCase(t, "role-1", "Publisher", "document:publish")
+
+	t.Run("ユーザーにロールを割り当てられる", func(t *testing.T) {
+		d := newRoleTestDeps()
+		user := createTestUserForUseCase(t, "user-1", "Member", "member@example.com", "user")
+		d.roleRepo.On("FindByID", mock.Anything, role.ID()).Return(role, nil)
+		d.userRepo.On("FindByID", mock.Anything, user.ID()).Return(user, nil)
+		d.roleRepo.On("AssignToUser", mock.Anything, role.ID(), user.ID()).Return(nil)
+
+		err := d.uc.AssignToUser(context.Background(), "role-1", "user-1")
+
+		assert.NoError(t, err)
+		d.roleRepo.AssertExpectations(t)
+	})
+
+	t.Run("グループにロールを割り当てられる", func(t *testing.T) {
+		d := newRoleTestDeps()
+		group := createTestGroupForUseCase(t, "group-1", "Ops", "")
+		d.roleRepo.On("FindByID", mock.Anything, role.ID()).Return(role, nil)
+		d.groupRepo.On("FindByID", mock.Anything, group.ID()).Return(group, nil)
+		d.roleRepo.On("AssignToGroup", mock.Anything, role.ID(), group.ID()).Return(nil)
+
+		err := d.uc.AssignToGroup(context.Background(), "role-1", "group-1")
+
+		assert.NoError(t, err)
+		d.roleRepo.AssertExpectations(t)
+	})
+
+	t.Run("存在しないユーザーはNotFoundになる", func(t *testing.T) {
+		d := newRoleTestDeps()
+		d.roleRepo.On("FindByID", mock.Anything, role.ID()).Return(role, nil)
+		d.userRepo.On("FindByID", mock.Anything, mock.Anything).Return(nil, nil)
+
+		err := d.uc.AssignToUser(context.Background(), "role-1", "ghost")
+
+		assert.True(t, errors.Is(err, apperror.ErrNotFound))
+		d.roleRepo.AssertNotCalled(t, "AssignToUser", mock.Anything, mock.Anything, mock.Anything)
+	})
+}
+
+func TestRoleUseCase_GetUserRoles(t *testing.T) {
+	d := newRoleTestDeps()
+	user := createTestUserForUseCase(t, "user-1", "Member", "member@example.com", "user")
+	d.userRepo.On("FindByID", mock.Anything, user.ID()).Return(user, nil)
+	d.roleRepo.On("FindByUserID", mock.Anything, user.ID()).
+		Return([]entity.Role{createTestRoleForUseCase(t, "role-1", "Publisher", "document:publish")}, nil)
+
+	roles, err := d.uc.GetUserRoles(context.Background(), "user-1")
+
+	require.NoError(t, err)
+	require.Len(t, roles, 1)
+	assert.Equal(t, "Publisher", roles[0].Name)
+}
@@ -0,0 +1,145 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// role represents a named bundle of permissions with unexported fields.
+// Roles are assigned to users and groups in addition to the built-in admin/user role.
+type role struct {
+	id          value_object.RoleID
+	name        string
+	description string
+	permissions []value_object.Permission
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// Role interface defines the methods for a role entity
+type Role interface {
+	ID() value_object.RoleID
+	Name() string
+	Description() string
+	Permissions() []value_object.Permission
+	HasPermission(permission value_object.Permission) bool
+	CreatedAt() time.Time
+	UpdatedAt() time.Time
+	UpdateInfo(name string, description string) error
+	SetPermissions(permissions []value_object.Permission)
+}
+
+// NewRole creates a new Role instance with validation
+func NewRole(id value_object.RoleID, name string, description string, permissions []value_object.Permission) (Role, error) {
+	if name == "" {
+		return nil, errors.New("role name cannot be empty")
+	}
+
+	now := time.Now()
+	return &role{
+		id:          id,
+		name:        name,
+		description: description,
+		permissions: uniquePermissions(permissions),
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructRole reconstructs a Role from persistence data
+func ReconstructRole(
+	id value_object.RoleID,
+	name string,
+	description string,
+	permissions []value_object.Permission,
+	createdAt, updatedAt time.Time,
+) Role {
+	return &role{
+		id:          id,
+		name:        name,
+		description: description,
+		permissions: uniquePermissions(permissions),
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}
+}
+
+// ID returns the role's unique identifier
+func (r *role) ID() value_object.RoleID {
+	return r.id
+}
+
+// Name returns the role's name
+func (r *role) Name() string {
+	return r.name
+}
+
+// Description returns the role's description
+func (r *role) Description() string {
+	return r.description
+}
+
+// Permissions returns a copy of the role's permissions
+func (r *role) Permissions() []value_object.Permission {
+	result := make([]value_object.Permission, len(r.permissions))
+	copy(result, r.permissions)
+	return result
+}
+
+// HasPermission returns true if the role grants the permission
+func (r *role) HasPermission(permission value_object.Permission) bool {
+	for _, p := range r.permissions {
+		if p.Equals(permission) {
+			return true
+		}
+	}
+	return false
+}
+
+// CreatedAt returns the timestamp when the role was created
+func (r *role) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// UpdatedAt returns the timestamp of the last update
+func (r *role) UpdatedAt() time.Time {
+	return r.updatedAt
+}
+
+// UpdateInfo updates the role's name and description
+func (r *role) UpdateInfo(name string, description string) error {
+	if name == "" {
+		return errors.New("role name cannot be empty")
+	}
+
+	r.name = name
+	r.description = description
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// SetPermissions replaces the permissions granted by the role
+func (r *role) SetPermissions(permissions []value_object.Permission) {
+	r.permissions = uniquePermissions(permissions)
+	r.updatedAt = time.Now()
+}
+
+// uniquePermissions removes duplicates while keeping the original order
+func uniquePermissions(permissions []value_object.Permission) []value_object.Permission {
+	result := make([]value_object.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		duplicate := false
+		for _, existing := range result {
+			if existing.Equals(p) {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			result = append(result, p)
+		}
+	}
+	return result
+}
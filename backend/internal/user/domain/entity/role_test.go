@@ -0,0 +1,64 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func mustPermission(t *testing.T, name string) value_object.Permission {
+	p, err := value_object.NewPermission(name)
+	assert.NoError(t, err)
+	return p
+}
+
+func TestNewRole(t *testing.T) {
+	t.Run("有効なパラメータでロールが正常に作成される", func(t *testing.T) {
+		roleID, _ := value_object.NewRoleID("role-123")
+		publish := mustPermission(t, "document:publish")
+
+		role, err := NewRole(roleID, "Editor", "Publishes documents", []value_object.Permission{publish, publish})
+
+		assert.NoError(t, err)
+		assert.Equal(t, "role-123", role.ID().String())
+		assert.Equal(t, "Editor", role.Name())
+		assert.Equal(t, "Publishes documents", role.Description())
+		assert.Len(t, role.Permissions(), 1)
+		assert.True(t, role.HasPermission(publish))
+		assert.False(t, role.HasPermission(mustPermission(t, "user:admin")))
+	})
+
+	t.Run("空の名前でエラーになる", func(t *testing.T) {
+		roleID, _ := value_object.NewRoleID("role-123")
+
+		_, err := NewRole(roleID, "", "", nil)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "role name cannot be empty")
+	})
+}
+
+func TestRole_SetPermissions(t *testing.T) {
+	roleID, _ := value_object.NewRoleID("role-123")
+	role := ReconstructRole(roleID, "Editor", "", []value_object.Permission{mustPermission(t, "document:publish")},
+		time.Now().Add(-time.Hour), time.Now().Add(-time.Hour))
+	before := role.UpdatedAt()
+
+	role.SetPermissions([]value_object.Permission{mustPermission(t, "execution:delete")})
+
+	assert.False(t, role.HasPermission(mustPermission(t, "document:publish")))
+	assert.True(t, role.HasPermission(mustPermission(t, "execution:delete")))
+	assert.True(t, role.UpdatedAt().After(before))
+}
+
+func TestRole_UpdateInfo(t *testing.T) {
+	roleID, _ := value_object.NewRoleID("role-123")
+	role, _ := NewRole(roleID, "Editor", "", nil)
+
+	assert.NoError(t, role.UpdateInfo("Publisher", "Publishes documents"))
+	assert.Equal(t, "Publisher", role.Name())
+	assert.Error(t, role.UpdateInfo("", ""))
+}
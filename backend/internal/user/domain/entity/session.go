@@ -0,0 +1,78 @@
+package entity
+
+import (
+	"errors"
+	"time"
+
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// session represents a login session with unexported fields
+type session struct {
+	tokenHash string
+	userID    value_object.UserID
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+// Session interface defines the methods for a session entity
+type Session interface {
+	TokenHash() string
+	UserID() value_object.UserID
+	CreatedAt() time.Time
+	ExpiresAt() time.Time
+	IsExpired(now time.Time) bool
+}
+
+// NewSession creates a new Session for the token that expires after ttl
+func NewSession(token value_object.SessionToken, userID value_object.UserID, ttl time.Duration) (Session, error) {
+	if token.IsEmpty() {
+		return nil, errors.New("session token cannot be empty")
+	}
+	if ttl <= 0 {
+		return nil, errors.New("session lifetime must be positive")
+	}
+
+	now := time.Now()
+	return &session{
+		tokenHash: token.Hash(),
+		userID:    userID,
+		createdAt: now,
+		expiresAt: now.Add(ttl),
+	}, nil
+}
+
+// ReconstructSession reconstructs a Session from persistence data
+func ReconstructSession(tokenHash string, userID value_object.UserID, createdAt, expiresAt time.Time) Session {
+	return &session{
+		tokenHash: tokenHash,
+		userID:    userID,
+		createdAt: createdAt,
+		expiresAt: expiresAt,
+	}
+}
+
+// TokenHash returns the SHA-256 of the session token
+func (s *session) TokenHash() string {
+	return s.tokenHash
+}
+
+// UserID returns the ID of the user the session belongs to
+func (s *session) UserID() value_object.UserID {
+	return s.userID
+}
+
+// CreatedAt returns the timestamp when the session was created
+func (s *session) CreatedAt() time.Time {
+	return s.createdAt
+}
+
+// ExpiresAt returns the timestamp after which the session is no longer valid
+func (s *session) ExpiresAt() time.Time {
+	return s.expiresAt
+}
+
+// IsExpired reports whether the session has expired at the given time
+func (s *session) IsExpired(now time.Time) bool {
+	return !now.Before(s.expiresAt)
+}
@@ -0,0 +1,58 @@
+package entity
+
+import (
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewSession(t *testing.T) {
+	userID, _ := value_object.NewUserID("user-123")
+
+	t.Run("有効なパラメータで正常に作成できる", func(t *testing.T) {
+		token, err := value_object.GenerateSessionToken()
+		require.NoError(t, err)
+
+		session, err := NewSession(token, userID, time.Hour)
+
+		require.NoError(t, err)
+		assert.Equal(t, token.Hash(), session.TokenHash())
+		assert.True(t, session.UserID().Equals(userID))
+		assert.WithinDuration(t, session.CreatedAt().Add(time.Hour), session.ExpiresAt(), time.Millisecond)
+		assert.False(t, session.IsExpired(time.Now()))
+		assert.True(t, session.IsExpired(time.Now().Add(2*time.Hour)))
+	})
+
+	t.Run("空のトークンでエラーになる", func(t *testing.T) {
+		_, err := NewSession(value_object.SessionToken{}, userID, time.Hour)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "session token cannot be empty")
+	})
+
+	t.Run("有効期間が0以下でエラーになる", func(t *testing.T) {
+		token, _ := value_object.NewSessionToken("abc")
+
+		_, err := NewSession(token, userID, 0)
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "session lifetime must be positive")
+	})
+}
+
+func TestReconstructSession(t *testing.T) {
+	userID, _ := value_object.NewUserID("user-123")
+	createdAt := time.Now().Add(-2 * time.Hour)
+	expiresAt := time.Now().Add(-time.Hour)
+
+	session := ReconstructSession("hash", userID, createdAt, expiresAt)
+
+	assert.Equal(t, "hash", session.TokenHash())
+	assert.Equal(t, createdAt, session.CreatedAt())
+	assert.Equal(t, expiresAt, session.ExpiresAt())
+	assert.True(t, session.IsExpired(time.Now()))
+}
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// CredentialRepository defines the interface for persisting local login credentials
+type CredentialRepository interface {
+	// SavePasswordHash sets or replaces the password hash of a user
+	SavePasswordHash(ctx context.Context, userID value_object.UserID, hash value_object.PasswordHash) error
+	// FindPasswordHash retrieves the password hash of a user. Returns an empty hash if none is set.
+	FindPasswordHash(ctx context.Context, userID value_object.UserID) (value_object.PasswordHash, error)
+}
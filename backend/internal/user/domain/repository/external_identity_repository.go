@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// ExternalIdentityRepository links identities asserted by an external identity provider to users
+type ExternalIdentityRepository interface {
+	// FindUserID retrieves the user linked to the issuer and subject. Returns an empty UserID if not linked.
+	FindUserID(ctx context.Context, issuer, subject string) (value_object.UserID, error)
+	// Link associates the issuer and subject with a user and records the login time
+	Link(ctx context.Context, issuer, subject string, userID value_object.UserID) error
+}
@@ -13,6 +13,8 @@ type GroupRepository interface {
 	Save(ctx context.Context, group entity.Group) error
 	// FindByID retrieves a group by its ID. Returns nil if not found.
 	FindByID(ctx context.Context, id value_object.GroupID) (entity.Group, error)
+	// FindByName retrieves a group by its unique name. Returns nil if not found.
+	FindByName(ctx context.Context, name string) (entity.Group, error)
 	// FindByMemberID retrieves all groups that contain the specified user as a member
 	FindByMemberID(ctx context.Context, userID value_object.UserID) ([]entity.Group, error)
 	// FindAll retrieves all groups
@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockCredentialRepository is a mock implementation of CredentialRepository
+type MockCredentialRepository struct {
+	mock.Mock
+}
+
+// SavePasswordHash mocks the SavePasswordHash method
+func (m *MockCredentialRepository) SavePasswordHash(ctx context.Context, userID value_object.UserID, hash value_object.PasswordHash) error {
+	args := m.Called(ctx, userID, hash)
+	return args.Error(0)
+}
+
+// FindPasswordHash mocks the FindPasswordHash method
+func (m *MockCredentialRepository) FindPasswordHash(ctx context.Context, userID value_object.UserID) (value_object.PasswordHash, error) {
+	args := m.Called(ctx, userID)
+	return args.Get(0).(value_object.PasswordHash), args.Error(1)
+}
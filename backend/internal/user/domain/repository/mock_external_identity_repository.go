@@ -0,0 +1,26 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockExternalIdentityRepository is a mock implementation of ExternalIdentityRepository
+type MockExternalIdentityRepository struct {
+	mock.Mock
+}
+
+// FindUserID mocks the FindUserID method
+func (m *MockExternalIdentityRepository) FindUserID(ctx context.Context, issuer, subject string) (value_object.UserID, error) {
+	args := m.Called(ctx, issuer, subject)
+	return args.Get(0).(value_object.UserID), args.Error(1)
+}
+
+// Link mocks the Link method
+func (m *MockExternalIdentityRepository) Link(ctx context.Context, issuer, subject string, userID value_object.UserID) error {
+	args := m.Called(ctx, issuer, subject, userID)
+	return args.Error(0)
+}
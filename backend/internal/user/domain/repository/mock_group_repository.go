@@ -29,6 +29,15 @@ func (m *MockGroupRepository) FindByID(ctx context.Context, id value_object.Grou
 	return args.Get(0).(entity.Group), args.Error(1)
 }
 
+// FindByName mocks the FindByName method
+func (m *MockGroupRepository) FindByName(ctx context.Context, name string) (entity.Group, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Group), args.Error(1)
+}
+
 // FindByMemberID mocks the FindByMemberID method
 func (m *MockGroupRepository) FindByMemberID(ctx context.Context, userID value_object.UserID) ([]entity.Group, error) {
 	args := m.Called(ctx, userID)
@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockRoleRepository is a mock implementation of RoleRepository
+type MockRoleRepository struct {
+	mock.Mock
+}
+
+// Save mocks the Save method
+func (m *MockRoleRepository) Save(ctx context.Context, role entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+// FindByID mocks the FindByID method
+func (m *MockRoleRepository) FindByID(ctx context.Context, id value_object.RoleID) (entity.Role, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Role), args.Error(1)
+}
+
+// FindByName mocks the FindByName method
+func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (entity.Role, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Role), args.Error(1)
+}
+
+// FindAll mocks the FindAll method
+func (m *MockRoleRepository) FindAll(ctx context.Context) ([]entity.Role, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Role), args.Error(1)
+}
+
+// Update mocks the Update method
+func (m *MockRoleRepository) Update(ctx context.Context, role entity.Role) error {
+	args := m.Called(ctx, role)
+	return args.Error(0)
+}
+
+// Delete mocks the Delete method
+func (m *MockRoleRepository) Delete(ctx context.Context, id value_object.RoleID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+// AssignToUser mocks the AssignToUser method
+func (m *MockRoleRepository) AssignToUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error {
+	args := m.Called(ctx, roleID, userID)
+	return args.Error(0)
+}
+
+// UnassignFromUser mocks the UnassignFromUser method
+func (m *MockRoleRepository) UnassignFromUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error {
+	args := m.Called(ctx, roleID, userID)
+	return args.Error(0)
+}
+
+// AssignToGroup mocks the AssignToGroup method
+func (m *MockRoleRepository) AssignToGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error {
+	args := m.Called(ctx, roleID, groupID)
+	return args.Error(0)
+}
+
+// UnassignFromGroup mocks the UnassignFromGroup method
+func (m *MockRoleRepository) UnassignFromGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error {
+	args := m.Called(ctx, roleID, groupID)
+	return args.Error(0)
+}
+
+// FindByUserID mocks the FindByUserID method
+func (m *MockRoleRepository) FindByUserID(ctx context.Context, userID value_object.UserID) ([]entity.Role, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Role), args.Error(1)
+}
+
+// FindByGroupID mocks the FindByGroupID method
+func (m *MockRoleRepository) FindByGroupID(ctx context.Context, groupID value_object.GroupID) ([]entity.Role, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Role), args.Error(1)
+}
+
+// FindAssigned mocks the FindAssigned method
+func (m *MockRoleRepository) FindAssigned(ctx context.Context, userID value_object.UserID, groupIDs []value_object.GroupID) ([]entity.Role, error) {
+	args := m.Called(ctx, userID, groupIDs)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]entity.Role), args.Error(1)
+}
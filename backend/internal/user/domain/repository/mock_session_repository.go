@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/stretchr/testify/mock"
+)
+
+// MockSessionRepository is a mock implementation of SessionRepository
+type MockSessionRepository struct {
+	mock.Mock
+}
+
+// Save mocks the Save method
+func (m *MockSessionRepository) Save(ctx context.Context, session entity.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+// FindByTokenHash mocks the FindByTokenHash method
+func (m *MockSessionRepository) FindByTokenHash(ctx context.Context, tokenHash string) (entity.Session, error) {
+	args := m.Called(ctx, tokenHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(entity.Session), args.Error(1)
+}
+
+// DeleteByTokenHash mocks the DeleteByTokenHash method
+func (m *MockSessionRepository) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
+	args := m.Called(ctx, tokenHash)
+	return args.Error(0)
+}
+
+// DeleteByUserID mocks the DeleteByUserID method
+func (m *MockSessionRepository) DeleteByUserID(ctx context.Context, userID value_object.UserID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+// DeleteExpired mocks the DeleteExpired method
+func (m *MockSessionRepository) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,39 @@
+package repository
+
+import (
+	"context"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// RoleRepository defines the interface for role and role assignment persistence operations
+type RoleRepository interface {
+	// Save persists a new role or updates an existing one
+	Save(ctx context.Context, role entity.Role) error
+	// FindByID retrieves a role by its ID. Returns nil if not found.
+	FindByID(ctx context.Context, id value_object.RoleID) (entity.Role, error)
+	// FindByName retrieves a role by its unique name. Returns nil if not found.
+	FindByName(ctx context.Context, name string) (entity.Role, error)
+	// FindAll retrieves all roles
+	FindAll(ctx context.Context) ([]entity.Role, error)
+	// Update updates an existing role
+	Update(ctx context.Context, role entity.Role) error
+	// Delete removes a role and all of its assignments
+	Delete(ctx context.Context, id value_object.RoleID) error
+
+	// AssignToUser grants the role to a user. Assigning twice is not an error.
+	AssignToUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error
+	// UnassignFromUser revokes the role from a user
+	UnassignFromUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error
+	// AssignToGroup grants the role to every member of a group. Assigning twice is not an error.
+	AssignToGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error
+	// UnassignFromGroup revokes the role from a group
+	UnassignFromGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error
+	// FindByUserID retrieves the roles assigned directly to a user
+	FindByUserID(ctx context.Context, userID value_object.UserID) ([]entity.Role, error)
+	// FindByGroupID retrieves the roles assigned to a group
+	FindByGroupID(ctx context.Context, groupID value_object.GroupID) ([]entity.Role, error)
+	// FindAssigned retrieves the distinct roles assigned to the user directly or through any of the groups
+	FindAssigned(ctx context.Context, userID value_object.UserID, groupIDs []value_object.GroupID) ([]entity.Role, error)
+}
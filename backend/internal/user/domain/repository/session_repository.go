@@ -0,0 +1,23 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/value_object"
+)
+
+// SessionRepository defines the interface for login session persistence operations
+type SessionRepository interface {
+	// Save persists a new session
+	Save(ctx context.Context, session entity.Session) error
+	// FindByTokenHash retrieves a session by the hash of its token. Returns nil if not found.
+	FindByTokenHash(ctx context.Context, tokenHash string) (entity.Session, error)
+	// DeleteByTokenHash removes a single session
+	DeleteByTokenHash(ctx context.Context, tokenHash string) error
+	// DeleteByUserID removes every session of a user
+	DeleteByUserID(ctx context.Context, userID value_object.UserID) error
+	// DeleteExpired removes sessions that expired before the given time and returns how many were removed
+	DeleteExpired(ctx context.Context, before time.Time) (int64, error)
+}
@@ -0,0 +1,64 @@
+package value_object
+
+import (
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// Password length limits. bcrypt ignores everything after 72 bytes.
+const (
+	MinPasswordLength = 8
+	MaxPasswordLength = 72
+)
+
+// PasswordHash represents a bcrypt hash of a user's password
+type PasswordHash struct {
+	value string
+}
+
+// HashPassword validates a plain text password and hashes it with bcrypt
+func HashPassword(password string) (PasswordHash, error) {
+	if len(password) < MinPasswordLength {
+		return PasswordHash{}, errors.New("password must be at least 8 characters")
+	}
+	if len(password) > MaxPasswordLength {
+		return PasswordHash{}, errors.New("password must be at most 72 bytes")
+	}
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return PasswordHash{}, err
+	}
+
+	return PasswordHash{value: string(hash)}, nil
+}
+
+// NewPasswordHash creates a PasswordHash from a stored bcrypt hash
+func NewPasswordHash(hash string) (PasswordHash, error) {
+	if hash == "" {
+		return PasswordHash{}, errors.New("password hash cannot be empty")
+	}
+	if _, err := bcrypt.Cost([]byte(hash)); err != nil {
+		return PasswordHash{}, errors.New("invalid password hash")
+	}
+	return PasswordHash{value: hash}, nil
+}
+
+// Matches reports whether the plain text password matches the hash
+func (p PasswordHash) Matches(password string) bool {
+	if p.IsEmpty() {
+		return false
+	}
+	return bcrypt.CompareHashAndPassword([]byte(p.value), []byte(password)) == nil
+}
+
+// String returns the string representation of PasswordHash
+func (p PasswordHash) String() string {
+	return p.value
+}
+
+// IsEmpty returns true if no hash is set
+func (p PasswordHash) IsEmpty() bool {
+	return p.value == ""
+}
@@ -0,0 +1,70 @@
+package value_object
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHashPassword(t *testing.T) {
+	t.Run("有効なパスワードをハッシュ化できる", func(t *testing.T) {
+		hash, err := HashPassword("correct horse")
+
+		require.NoError(t, err)
+		assert.False(t, hash.IsEmpty())
+		assert.NotEqual(t, "correct horse", hash.String())
+		assert.True(t, hash.Matches("correct horse"))
+		assert.False(t, hash.Matches("wrong horse"))
+	})
+
+	t.Run("短すぎるパスワードでエラーになる", func(t *testing.T) {
+		_, err := HashPassword("short")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at least 8 characters")
+	})
+
+	t.Run("長すぎるパスワードでエラーになる", func(t *testing.T) {
+		_, err := HashPassword(strings.Repeat("a", MaxPasswordLength+1))
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "at most 72 bytes")
+	})
+}
+
+func TestNewPasswordHash(t *testing.T) {
+	t.Run("保存済みのハッシュから復元できる", func(t *testing.T) {
+		original, err := HashPassword("correct horse")
+		require.NoError(t, err)
+
+		restored, err := NewPasswordHash(original.String())
+
+		require.NoError(t, err)
+		assert.True(t, restored.Matches("correct horse"))
+	})
+
+	t.Run("空のハッシュでエラーになる", func(t *testing.T) {
+		_, err := NewPasswordHash("")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "password hash cannot be empty")
+	})
+
+	t.Run("bcrypt形式でないハッシュでエラーになる", func(t *testing.T) {
+		_, err := NewPasswordHash("plain-text")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid password hash")
+	})
+}
+
+func TestPasswordHash_Matches(t *testing.T) {
+	t.Run("空のハッシュはどのパスワードにも一致しない", func(t *testing.T) {
+		var hash PasswordHash
+
+		assert.True(t, hash.IsEmpty())
+		assert.False(t, hash.Matches(""))
+	})
+}
@@ -0,0 +1,76 @@
+package value_object
+
+import (
+	"errors"
+	"strings"
+)
+
+// Permission represents a single action that can be granted through a role
+type Permission struct {
+	value string
+}
+
+// Permission catalog
+const (
+	// PermissionRepositoryManage allows registering repositories, selecting managed files and updating access tokens
+	PermissionRepositoryManage = "repository:manage"
+	// PermissionDocumentPublish allows creating documents and publishing or rolling back their versions
+	PermissionDocumentPublish = "document:publish"
+	// PermissionExecutionDelete allows deleting execution records and their attachments
+	PermissionExecutionDelete = "execution:delete"
+	// PermissionUserAdmin allows managing users, groups, passwords and roles
+	PermissionUserAdmin = "user:admin"
+)
+
+// permissionCatalog lists every known permission with a short description
+var permissionCatalog = []struct {
+	name        string
+	description string
+}{
+	{PermissionRepositoryManage, "Register repositories, select managed files and update access tokens"},
+	{PermissionDocumentPublish, "Create documents and publish or roll back their versions"},
+	{PermissionExecutionDelete, "Delete execution records and attachments"},
+	{PermissionUserAdmin, "Manage users, groups, passwords and roles"},
+}
+
+// NewPermission creates a new Permission with validation against the catalog
+func NewPermission(permission string) (Permission, error) {
+	normalized := strings.ToLower(strings.TrimSpace(permission))
+
+	for _, p := range permissionCatalog {
+		if p.name == normalized {
+			return Permission{value: normalized}, nil
+		}
+	}
+
+	return Permission{}, errors.New("unknown permission: " + permission)
+}
+
+// AllPermissions returns every permission in the catalog
+func AllPermissions() []Permission {
+	result := make([]Permission, len(permissionCatalog))
+	for i, p := range permissionCatalog {
+		result[i] = Permission{value: p.name}
+	}
+	return result
+}
+
+// String returns the string representation of Permission
+func (p Permission) String() string {
+	return p.value
+}
+
+// Description returns a human readable description of the permission
+func (p Permission) Description() string {
+	for _, c := range permissionCatalog {
+		if c.name == p.value {
+			return c.description
+		}
+	}
+	return ""
+}
+
+// Equals checks if two Permissions are equal
+func (p Permission) Equals(other Permission) bool {
+	return p.value == other.value
+}
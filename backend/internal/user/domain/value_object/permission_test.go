@@ -0,0 +1,52 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPermission(t *testing.T) {
+	t.Run("カタログにある権限で正常に作成できる", func(t *testing.T) {
+		testCases := []struct {
+			input    string
+			expected string
+		}{
+			{"repository:manage", "repository:manage"},
+			{"DOCUMENT:PUBLISH", "document:publish"},
+			{"  execution:delete  ", "execution:delete"},
+			{"user:admin", "user:admin"},
+		}
+
+		for _, tc := range testCases {
+			permission, err := NewPermission(tc.input)
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected, permission.String())
+		}
+	})
+
+	t.Run("カタログにない権限でエラーになる", func(t *testing.T) {
+		for _, input := range []string{"", "repository:*", "admin", "document:delete"} {
+			_, err := NewPermission(input)
+			assert.Error(t, err)
+		}
+	})
+}
+
+func TestAllPermissions(t *testing.T) {
+	permissions := AllPermissions()
+
+	assert.Len(t, permissions, 4)
+	for _, p := range permissions {
+		assert.NotEmpty(t, p.Description())
+	}
+}
+
+func TestPermission_Equals(t *testing.T) {
+	p1, _ := NewPermission("user:admin")
+	p2, _ := NewPermission("USER:ADMIN")
+	p3, _ := NewPermission("document:publish")
+
+	assert.True(t, p1.Equals(p2))
+	assert.False(t, p1.Equals(p3))
+}
@@ -0,0 +1,26 @@
+package value_object
+
+import "errors"
+
+// RoleID represents the unique identifier for a Role
+type RoleID struct {
+	value string
+}
+
+// NewRoleID creates a new RoleID with validation
+func NewRoleID(id string) (RoleID, error) {
+	if id == "" {
+		return RoleID{}, errors.New("role ID cannot be empty")
+	}
+	return RoleID{value: id}, nil
+}
+
+// String returns the string representation of RoleID
+func (rid RoleID) String() string {
+	return rid.value
+}
+
+// Equals checks if two RoleIDs are equal
+func (rid RoleID) Equals(other RoleID) bool {
+	return rid.value == other.value
+}
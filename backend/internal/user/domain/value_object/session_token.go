@@ -0,0 +1,50 @@
+package value_object
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+)
+
+// sessionTokenBytes is the amount of randomness in a session token
+const sessionTokenBytes = 32
+
+// SessionToken represents the opaque bearer token handed to a client after login
+type SessionToken struct {
+	value string
+}
+
+// GenerateSessionToken creates a new random SessionToken
+func GenerateSessionToken() (SessionToken, error) {
+	b := make([]byte, sessionTokenBytes)
+	if _, err := rand.Read(b); err != nil {
+		return SessionToken{}, err
+	}
+	return SessionToken{value: base64.RawURLEncoding.EncodeToString(b)}, nil
+}
+
+// NewSessionToken creates a SessionToken from a value presented by a client
+func NewSessionToken(token string) (SessionToken, error) {
+	if token == "" {
+		return SessionToken{}, errors.New("session token cannot be empty")
+	}
+	return SessionToken{value: token}, nil
+}
+
+// Hash returns the hex encoded SHA-256 of the token. Only the hash is persisted.
+func (t SessionToken) Hash() string {
+	sum := sha256.Sum256([]byte(t.value))
+	return hex.EncodeToString(sum[:])
+}
+
+// String returns the string representation of SessionToken
+func (t SessionToken) String() string {
+	return t.value
+}
+
+// IsEmpty returns true if the token is empty
+func (t SessionToken) IsEmpty() bool {
+	return t.value == ""
+}
@@ -0,0 +1,54 @@
+package value_object
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateSessionToken(t *testing.T) {
+	t.Run("毎回異なるトークンが生成される", func(t *testing.T) {
+		token1, err := GenerateSessionToken()
+		require.NoError(t, err)
+		token2, err := GenerateSessionToken()
+		require.NoError(t, err)
+
+		assert.False(t, token1.IsEmpty())
+		assert.NotEqual(t, token1.String(), token2.String())
+	})
+}
+
+func TestNewSessionToken(t *testing.T) {
+	t.Run("有効なトークンで正常に作成できる", func(t *testing.T) {
+		token, err := NewSessionToken("abc")
+
+		assert.NoError(t, err)
+		assert.Equal(t, "abc", token.String())
+	})
+
+	t.Run("空のトークンでエラーになる", func(t *testing.T) {
+		_, err := NewSessionToken("")
+
+		assert.Error(t, err)
+		assert.Contains(t, err.Error(), "session token cannot be empty")
+	})
+}
+
+func TestSessionToken_Hash(t *testing.T) {
+	t.Run("同じトークンは同じハッシュになる", func(t *testing.T) {
+		token1, _ := NewSessionToken("abc")
+		token2, _ := NewSessionToken("abc")
+
+		assert.Equal(t, token1.Hash(), token2.Hash())
+		assert.Len(t, token1.Hash(), 64)
+		assert.NotEqual(t, token1.String(), token1.Hash())
+	})
+
+	t.Run("異なるトークンは異なるハッシュになる", func(t *testing.T) {
+		token1, _ := NewSessionToken("abc")
+		token2, _ := NewSessionToken("abd")
+
+		assert.NotEqual(t, token1.Hash(), token2.Hash())
+	})
+}
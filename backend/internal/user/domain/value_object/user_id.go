@@ -24,3 +24,8 @@ func (uid UserID) String() string {
 func (uid UserID) Equals(other UserID) bool {
 	return uid.value == other.value
 }
+
+// IsEmpty returns true if the UserID is empty
+func (uid UserID) IsEmpty() bool {
+	return uid.value == ""
+}
@@ -43,3 +43,11 @@ func TestUserID_Equals(t *testing.T) {
 		assert.False(t, userID1.Equals(userID2))
 	})
 }
+
+func TestUserID_IsEmpty(t *testing.T) {
+	var empty UserID
+	assert.True(t, empty.IsEmpty())
+
+	userID, _ := NewUserID("user-123")
+	assert.False(t, userID.IsEmpty())
+}
@@ -0,0 +1,234 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"strings"
+	"time"
+)
+
+// clockSkew is the tolerance applied to exp and iat
+const clockSkew = time.Minute
+
+// idTokenHeader is the JOSE header of an ID token
+type idTokenHeader struct {
+	Alg string `json:"alg"`
+	Kid string `json:"kid"`
+}
+
+// idTokenClaims holds the standard claims checked by the verifier (OIDC Core 3.1.3.7)
+type idTokenClaims struct {
+	Issuer          string      `json:"iss"`
+	Subject         string      `json:"sub"`
+	Audience        audience    `json:"aud"`
+	AuthorizedParty string      `json:"azp"`
+	Expiry          json.Number `json:"exp"`
+	IssuedAt        json.Number `json:"iat"`
+	Nonce           string      `json:"nonce"`
+}
+
+// audience accepts both the single string and the array form of the aud claim
+type audience []string
+
+func (a *audience) UnmarshalJSON(b []byte) error {
+	var single string
+	if err := json.Unmarshal(b, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+	var multi []string
+	if err := json.Unmarshal(b, &multi); err != nil {
+		return errors.New("aud must be a string or an array of strings")
+	}
+	*a = multi
+	return nil
+}
+
+func (a audience) contains(v string) bool {
+	for _, s := range a {
+		if s == v {
+			return true
+		}
+	}
+	return false
+}
+
+// idTokenVerifier checks the signature and standard claims of ID tokens from a single issuer
+type idTokenVerifier struct {
+	issuer   string
+	clientID string
+	keys     *keySet
+	now      func() time.Time
+}
+
+// verify validates the token and returns all of its claims
+func (v *idTokenVerifier) verify(ctx context.Context, rawToken, nonce string) (map[string]interface{}, error) {
+	parts := strings.Split(rawToken, ".")
+	if len(parts) != 3 {
+		return nil, errors.New("malformed ID token")
+	}
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token header: %w", err)
+	}
+	var header idTokenHeader
+	if err := json.Unmarshal(headerJSON, &header); err != nil {
+		return nil, fmt.Errorf("malformed ID token header: %w", err)
+	}
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token signature: %w", err)
+	}
+
+	key, err := v.keys.key(ctx, header.Kid)
+	if err != nil {
+		return nil, err
+	}
+	if err := verifySignature(header.Alg, key, []byte(parts[0]+"."+parts[1]), signature); err != nil {
+		return nil, err
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return nil, fmt.Errorf("malformed ID token payload: %w", err)
+	}
+
+	var claims idTokenClaims
+	if err := decodeJSON(payload, &claims); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %w", err)
+	}
+	if err := v.checkClaims(claims, nonce); err != nil {
+		return nil, err
+	}
+
+	var all map[string]interface{}
+	if err := decodeJSON(payload, &all); err != nil {
+		return nil, fmt.Errorf("malformed ID token claims: %w", err)
+	}
+	return all, nil
+}
+
+// checkClaims validates iss, aud, azp, exp, iat and nonce
+func (v *idTokenVerifier) checkClaims(claims idTokenClaims, nonce string) error {
+	if claims.Issuer != v.issuer {
+		return fmt.Errorf("ID token issued by %q, expected %q", claims.Issuer, v.issuer)
+	}
+	if claims.Subject == "" {
+		return errors.New("ID token has no subject")
+	}
+	if !claims.Audience.contains(v.clientID) {
+		return errors.New("ID token was not issued for this client")
+	}
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != v.clientID {
+		return errors.New("ID token authorized party does not match this client")
+	}
+
+	now := v.now()
+	exp, err := numericDate(claims.Expiry)
+	if err != nil {
+		return fmt.Errorf("invalid exp claim: %w", err)
+	}
+	if !now.Before(exp.Add(clockSkew)) {
+		return errors.New("ID token has expired")
+	}
+	if claims.IssuedAt != "" {
+		iat, err := numericDate(claims.IssuedAt)
+		if err != nil {
+			return fmt.Errorf("invalid iat claim: %w", err)
+		}
+		if iat.After(now.Add(clockSkew)) {
+			return errors.New("ID token was issued in the future")
+		}
+	}
+
+	if subtle.ConstantTimeCompare([]byte(claims.Nonce), []byte(nonce)) != 1 {
+		return errors.New("ID token nonce does not match")
+	}
+	return nil
+}
+
+// verifySignature checks a JWS signature. Only asymmetric algorithms are accepted.
+func verifySignature(alg string, key crypto.PublicKey, signingInput, signature []byte) error {
+	var hash crypto.Hash
+	switch alg {
+	case "RS256", "ES256", "PS256":
+		hash = crypto.SHA256
+	case "RS384", "ES384", "PS384":
+		hash = crypto.SHA384
+	case "RS512", "ES512", "PS512":
+		hash = crypto.SHA512
+	default:
+		return fmt.Errorf("unsupported signing algorithm %q", alg)
+	}
+
+	h := hash.New()
+	h.Write(signingInput)
+	digest := h.Sum(nil)
+
+	switch alg[:2] {
+	case "RS", "PS":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %q", alg)
+		}
+		var err error
+		if alg[:2] == "RS" {
+			err = rsa.VerifyPKCS1v15(pub, hash, digest, signature)
+		} else {
+			err = rsa.VerifyPSS(pub, hash, digest, signature, &rsa.PSSOptions{SaltLength: rsa.PSSSaltLengthEqualsHash})
+		}
+		if err != nil {
+			return errors.New("invalid ID token signature")
+		}
+
+	case "ES":
+		pub, ok := key.(*ecdsa.PublicKey)
+		if !ok {
+			return fmt.Errorf("key type does not match algorithm %q", alg)
+		}
+		if pub.Curve.Params().Name != ecdsaCurves[alg] {
+			return fmt.Errorf("key curve does not match algorithm %q", alg)
+		}
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if len(signature) != 2*size {
+			return errors.New("invalid ID token signature")
+		}
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		if !ecdsa.Verify(pub, digest, r, s) {
+			return errors.New("invalid ID token signature")
+		}
+	}
+
+	return nil
+}
+
+// numericDate converts a JSON NumericDate (seconds since the epoch, possibly fractional)
+func numericDate(n json.Number) (time.Time, error) {
+	if n == "" {
+		return time.Time{}, errors.New("missing value")
+	}
+	f, err := n.Float64()
+	if err != nil {
+		return time.Time{}, err
+	}
+	sec := int64(f)
+	return time.Unix(sec, int64((f-float64(sec))*1e9)), nil
+}
+
+func decodeJSON(b []byte, v interface{}) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	return dec.Decode(v)
+}
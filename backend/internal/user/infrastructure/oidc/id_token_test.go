@@ -0,0 +1,181 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeSegment(t *testing.T, v interface{}) string {
+	b, err := json.Marshal(v)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(b)
+}
+
+func newTestVerifier(keys map[string]crypto.PublicKey, now time.Time) *idTokenVerifier {
+	return &idTokenVerifier{
+		issuer:   "https://idp.example.com",
+		clientID: "client-1",
+		// A recent refresh keeps the key set from trying to download the JWKS
+		keys: &keySet{keys: keys, lastRefresh: time.Now(), minRefreshInterval: time.Hour},
+		now:  func() time.Time { return now },
+	}
+}
+
+func baseClaims(now time.Time) map[string]interface{} {
+	return map[string]interface{}{
+		"iss":   "https://idp.example.com",
+		"sub":   "user-1",
+		"aud":   "client-1",
+		"iat":   now.Unix(),
+		"exp":   now.Add(time.Hour).Unix(),
+		"nonce": "nonce-1",
+	}
+}
+
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims map[string]interface{}) string {
+	input := encodeSegment(t, map[string]string{"alg": "RS256", "kid": kid}) + "." + encodeSegment(t, claims)
+	digest := sha256.Sum256([]byte(input))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	require.NoError(t, err)
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestIDTokenVerifier_Verify(t *testing.T) {
+	now := time.Now()
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	verifier := newTestVerifier(map[string]crypto.PublicKey{"rsa": &rsaKey.PublicKey}, now)
+
+	t.Run("RS256で署名された正しいトークンを受け入れる", func(t *testing.T) {
+		claims := baseClaims(now)
+		claims["email"] = "alice@example.com"
+
+		result, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", result["email"])
+	})
+
+	t.Run("ES256で署名されたトークンを受け入れる", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+		require.NoError(t, err)
+		v := newTestVerifier(map[string]crypto.PublicKey{"ec": &ecKey.PublicKey}, now)
+
+		input := encodeSegment(t, map[string]string{"alg": "ES256", "kid": "ec"}) + "." + encodeSegment(t, baseClaims(now))
+		digest := sha256.Sum256([]byte(input))
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		require.NoError(t, err)
+		sig := make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+
+		_, err = v.verify(context.Background(), input+"."+base64.RawURLEncoding.EncodeToString(sig), "nonce-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("鍵の曲線と一致しないESアルゴリズムは拒否される", func(t *testing.T) {
+		ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+		require.NoError(t, err)
+		v := newTestVerifier(map[string]crypto.PublicKey{"ec": &ecKey.PublicKey}, now)
+
+		// A valid P-384 signature over a SHA-256 digest, labelled ES256
+		input := encodeSegment(t, map[string]string{"alg": "ES256", "kid": "ec"}) + "." + encodeSegment(t, baseClaims(now))
+		digest := sha256.Sum256([]byte(input))
+		r, s, err := ecdsa.Sign(rand.Reader, ecKey, digest[:])
+		require.NoError(t, err)
+		sig := make([]byte, 96)
+		r.FillBytes(sig[:48])
+		s.FillBytes(sig[48:])
+
+		_, err = v.verify(context.Background(), input+"."+base64.RawURLEncoding.EncodeToString(sig), "nonce-1")
+
+		assert.ErrorContains(t, err, "curve")
+	})
+
+	t.Run("改ざんされたペイロードは拒否される", func(t *testing.T) {
+		token := signRS256(t, rsaKey, "rsa", baseClaims(now))
+		tampered := baseClaims(now)
+		tampered["sub"] = "admin"
+		parts := strings.Split(token, ".")
+
+		_, err := verifier.verify(context.Background(), parts[0]+"."+encodeSegment(t, tampered)+"."+parts[2], "nonce-1")
+
+		assert.ErrorContains(t, err, "signature")
+	})
+
+	t.Run("alg=noneは拒否される", func(t *testing.T) {
+		token := encodeSegment(t, map[string]string{"alg": "none", "kid": "rsa"}) + "." + encodeSegment(t, baseClaims(now)) + "."
+
+		_, err := verifier.verify(context.Background(), token, "nonce-1")
+
+		assert.ErrorContains(t, err, "unsupported signing algorithm")
+	})
+
+	t.Run("HS256は拒否される", func(t *testing.T) {
+		token := encodeSegment(t, map[string]string{"alg": "HS256", "kid": "rsa"}) + "." + encodeSegment(t, baseClaims(now)) + ".c2ln"
+
+		_, err := verifier.verify(context.Background(), token, "nonce-1")
+
+		assert.ErrorContains(t, err, "unsupported signing algorithm")
+	})
+
+	t.Run("未知の鍵IDは拒否される", func(t *testing.T) {
+		_, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "unknown", baseClaims(now)), "nonce-1")
+
+		assert.ErrorContains(t, err, "unknown signing key")
+	})
+
+	t.Run("issuerが異なるトークンは拒否される", func(t *testing.T) {
+		claims := baseClaims(now)
+		claims["iss"] = "https://evil.example.com"
+
+		_, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+
+		assert.Error(t, err)
+	})
+
+	t.Run("複数のaudienceではazpが必要", func(t *testing.T) {
+		claims := baseClaims(now)
+		claims["aud"] = []string{"client-1", "other"}
+
+		_, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+		assert.ErrorContains(t, err, "authorized party")
+
+		claims["azp"] = "client-1"
+		_, err = verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+		assert.NoError(t, err)
+	})
+
+	t.Run("許容範囲内の時刻ずれは受け入れる", func(t *testing.T) {
+		claims := baseClaims(now)
+		claims["exp"] = now.Add(-30 * time.Second).Unix()
+
+		_, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+
+		assert.NoError(t, err)
+	})
+
+	t.Run("未来に発行されたトークンは拒否される", func(t *testing.T) {
+		claims := baseClaims(now)
+		claims["iat"] = now.Add(time.Hour).Unix()
+		claims["exp"] = now.Add(2 * time.Hour).Unix()
+
+		_, err := verifier.verify(context.Background(), signRS256(t, rsaKey, "rsa", claims), "nonce-1")
+
+		assert.ErrorContains(t, err, "future")
+	})
+}
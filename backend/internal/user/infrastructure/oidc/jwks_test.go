@@ -0,0 +1,86 @@
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"testing"
+
+	"opscore/backend/internal/user/infrastructure/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestKeySet_Key(t *testing.T) {
+	t.Run("未知の鍵IDでJWKSを再取得する", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		keys := newKeySet(idp.URL+"/jwks", http.DefaultClient)
+		keys.minRefreshInterval = 0
+
+		first, err := keys.key(context.Background(), idp.KeyID())
+		require.NoError(t, err)
+
+		idp.RotateKey()
+		second, err := keys.key(context.Background(), idp.KeyID())
+
+		require.NoError(t, err)
+		assert.NotEqual(t, first, second)
+	})
+
+	t.Run("再取得は一定間隔に制限される", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		keys := newKeySet(idp.URL+"/jwks", http.DefaultClient)
+
+		_, err := keys.key(context.Background(), idp.KeyID())
+		require.NoError(t, err)
+
+		idp.RotateKey()
+		_, err = keys.key(context.Background(), idp.KeyID())
+
+		assert.ErrorContains(t, err, "unknown signing key")
+	})
+
+	t.Run("鍵IDがなくても鍵が1つなら使用できる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		keys := newKeySet(idp.URL+"/jwks", http.DefaultClient)
+
+		key, err := keys.key(context.Background(), "")
+
+		require.NoError(t, err)
+		assert.NotNil(t, key)
+	})
+}
+
+func TestJSONWebKey_PublicKey(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	require.NoError(t, err)
+	jwk := jsonWebKey{
+		Kty: "EC",
+		Crv: "P-384",
+		X:   base64.RawURLEncoding.EncodeToString(ecKey.X.Bytes()),
+		Y:   base64.RawURLEncoding.EncodeToString(ecKey.Y.Bytes()),
+	}
+
+	t.Run("曲線に対応するalgのEC鍵を読み込める", func(t *testing.T) {
+		jwk := jwk
+		jwk.Alg = "ES384"
+
+		key, err := jwk.publicKey()
+
+		require.NoError(t, err)
+		assert.True(t, ecKey.PublicKey.Equal(key))
+	})
+
+	t.Run("曲線と一致しないalgのEC鍵は拒否される", func(t *testing.T) {
+		jwk := jwk
+		jwk.Alg = "ES256"
+
+		_, err := jwk.publicKey()
+
+		assert.ErrorContains(t, err, "curve")
+	})
+}
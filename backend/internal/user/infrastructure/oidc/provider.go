@@ -0,0 +1,258 @@
+// Package oidc implements the OpenID Connect authorization code flow with PKCE.
+//
+// The provider is configured from the issuer's discovery document. ID tokens are verified
+// against the keys published at the issuer's jwks_uri.
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	"opscore/backend/internal/user/application/usecase"
+
+	"golang.org/x/oauth2"
+)
+
+// DefaultGroupsClaim is the claim that carries group names when none is configured
+const DefaultGroupsClaim = "groups"
+
+// Config holds the client registration at the identity provider
+type Config struct {
+	// IssuerURL is the issuer identifier; the discovery document is read from IssuerURL/.well-known/openid-configuration
+	IssuerURL    string
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+	// Scopes requested in addition to "openid". Defaults to "email" and "profile".
+	Scopes []string
+	// GroupsClaim is the claim holding group names. Nested claims are addressed with dots, e.g. "realm_access.roles".
+	GroupsClaim string
+	// HTTPClient is used for discovery, JWKS and token requests. Defaults to a client with a 10 second timeout.
+	HTTPClient *http.Client
+}
+
+// discoveryDocument is the subset of the OpenID Provider Metadata used by the client
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+// Provider is an OpenID Connect relying party for a single issuer
+type Provider struct {
+	oauth2Config oauth2.Config
+	verifier     *idTokenVerifier
+	groupsClaim  string
+	httpClient   *http.Client
+}
+
+var _ usecase.IdentityProvider = (*Provider)(nil)
+
+// NewProvider fetches the issuer's discovery document and creates a Provider
+func NewProvider(ctx context.Context, cfg Config) (*Provider, error) {
+	if cfg.IssuerURL == "" || cfg.ClientID == "" || cfg.RedirectURL == "" {
+		return nil, errors.New("OIDC issuer URL, client ID and redirect URL are required")
+	}
+
+	httpClient := cfg.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 10 * time.Second}
+	}
+
+	doc, err := discover(ctx, httpClient, cfg.IssuerURL)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := cfg.Scopes
+	if len(scopes) == 0 {
+		scopes = []string{"email", "profile"}
+	}
+	scopes = append([]string{"openid"}, removeScope(scopes, "openid")...)
+
+	groupsClaim := cfg.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = DefaultGroupsClaim
+	}
+
+	return &Provider{
+		oauth2Config: oauth2.Config{
+			ClientID:     cfg.ClientID,
+			ClientSecret: cfg.ClientSecret,
+			RedirectURL:  cfg.RedirectURL,
+			Scopes:       scopes,
+			Endpoint: oauth2.Endpoint{
+				AuthURL:  doc.AuthorizationEndpoint,
+				TokenURL: doc.TokenEndpoint,
+			},
+		},
+		verifier: &idTokenVerifier{
+			issuer:   doc.Issuer,
+			clientID: cfg.ClientID,
+			keys:     newKeySet(doc.JWKSURI, httpClient),
+			now:      time.Now,
+		},
+		groupsClaim: groupsClaim,
+		httpClient:  httpClient,
+	}, nil
+}
+
+// AuthCodeURL returns the authorization endpoint URL with state, nonce and the S256 code challenge
+func (p *Provider) AuthCodeURL(state, nonce, codeVerifier string) string {
+	return p.oauth2Config.AuthCodeURL(state,
+		oauth2.S256ChallengeOption(codeVerifier),
+		oauth2.SetAuthURLParam("nonce", nonce),
+	)
+}
+
+// Exchange redeems the authorization code and returns the identity from the verified ID token
+func (p *Provider) Exchange(ctx context.Context, code, codeVerifier, nonce string) (*dto.ExternalIdentity, error) {
+	ctx = context.WithValue(ctx, oauth2.HTTPClient, p.httpClient)
+
+	token, err := p.oauth2Config.Exchange(ctx, code, oauth2.VerifierOption(codeVerifier))
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok || rawIDToken == "" {
+		return nil, errors.New("token response does not contain an ID token")
+	}
+
+	claims, err := p.verifier.verify(ctx, rawIDToken, nonce)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify ID token: %w", err)
+	}
+
+	return p.toIdentity(claims), nil
+}
+
+// toIdentity extracts the user attributes from verified claims
+func (p *Provider) toIdentity(claims map[string]interface{}) *dto.ExternalIdentity {
+	identity := &dto.ExternalIdentity{
+		Issuer:        p.verifier.issuer,
+		Subject:       stringClaim(claims, "sub"),
+		Email:         stringClaim(claims, "email"),
+		EmailVerified: boolClaim(claims, "email_verified"),
+		Name:          stringClaim(claims, "name"),
+	}
+	if identity.Name == "" {
+		identity.Name = stringClaim(claims, "preferred_username")
+	}
+
+	if value, ok := lookupClaim(claims, p.groupsClaim); ok {
+		identity.Groups = stringsClaim(value)
+	}
+
+	return identity
+}
+
+// discover reads and validates the OpenID Provider Metadata
+func discover(ctx context.Context, httpClient *http.Client, issuerURL string) (*discoveryDocument, error) {
+	wellKnown := strings.TrimSuffix(issuerURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch OIDC discovery document: unexpected status %d", resp.StatusCode)
+	}
+
+	var doc discoveryDocument
+	if err := json.NewDecoder(resp.Body).Decode(&doc); err != nil {
+		return nil, fmt.Errorf("failed to decode OIDC discovery document: %w", err)
+	}
+
+	if strings.TrimSuffix(doc.Issuer, "/") != strings.TrimSuffix(issuerURL, "/") {
+		return nil, fmt.Errorf("discovery document issuer %q does not match %q", doc.Issuer, issuerURL)
+	}
+	if doc.AuthorizationEndpoint == "" || doc.TokenEndpoint == "" || doc.JWKSURI == "" {
+		return nil, errors.New("OIDC discovery document is missing required endpoints")
+	}
+
+	return &doc, nil
+}
+
+// lookupClaim resolves a dotted claim path such as "realm_access.roles"
+func lookupClaim(claims map[string]interface{}, path string) (interface{}, bool) {
+	// A claim whose name itself contains dots takes precedence
+	if value, ok := claims[path]; ok {
+		return value, true
+	}
+
+	var current interface{} = claims
+	for _, part := range strings.Split(path, ".") {
+		m, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
+
+func stringClaim(claims map[string]interface{}, name string) string {
+	s, _ := claims[name].(string)
+	return s
+}
+
+// boolClaim accepts both JSON booleans and the string "true", which some providers send
+func boolClaim(claims map[string]interface{}, name string) bool {
+	switch v := claims[name].(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	default:
+		return false
+	}
+}
+
+// stringsClaim converts an array or a single string claim to a slice
+func stringsClaim(value interface{}) []string {
+	switch v := value.(type) {
+	case string:
+		if v == "" {
+			return []string{}
+		}
+		return []string{v}
+	case []interface{}:
+		result := make([]string, 0, len(v))
+		for _, item := range v {
+			if s, ok := item.(string); ok && s != "" {
+				result = append(result, s)
+			}
+		}
+		return result
+	default:
+		return []string{}
+	}
+}
+
+func removeScope(scopes []string, scope string) []string {
+	result := make([]string, 0, len(scopes))
+	for _, s := range scopes {
+		if s != scope {
+			result = append(result, s)
+		}
+	}
+	return result
+}
@@ -0,0 +1,184 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/infrastructure/oidc"
+	"opscore/backend/internal/user/infrastructure/oidc/oidctest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+const redirectURL = "https://opscore.example.com/api/v1/auth/oidc/callback"
+
+func newTestProvider(t *testing.T, idp *oidctest.Server, groupsClaim string) *oidc.Provider {
+	t.Helper()
+	provider, err := oidc.NewProvider(context.Background(), oidc.Config{
+		IssuerURL:    idp.Issuer(),
+		ClientID:     oidctest.ClientID,
+		ClientSecret: oidctest.ClientSecret,
+		RedirectURL:  redirectURL,
+		GroupsClaim:  groupsClaim,
+	})
+	require.NoError(t, err)
+	return provider
+}
+
+// authorize follows the authorization URL like a browser and returns the code from the redirect
+func authorize(t *testing.T, authURL, expectedState string) string {
+	t.Helper()
+	client := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+
+	resp, err := client.Get(authURL)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusFound, resp.StatusCode)
+
+	location, err := url.Parse(resp.Header.Get("Location"))
+	require.NoError(t, err)
+	assert.Equal(t, expectedState, location.Query().Get("state"))
+	return location.Query().Get("code")
+}
+
+func TestProvider_AuthorizationCodeFlow(t *testing.T) {
+	t.Run("PKCEとnonceを使ったフローでIDが取得できる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		idp.SetClaims(map[string]interface{}{
+			"sub":            "user-123",
+			"email":          "alice@example.com",
+			"email_verified": true,
+			"name":           "Alice",
+			"groups":         []string{"ops", "dev"},
+		})
+		provider := newTestProvider(t, idp, "")
+		verifier := oauth2.GenerateVerifier()
+
+		authURL := provider.AuthCodeURL("state-1", "nonce-1", verifier)
+		parsed, err := url.Parse(authURL)
+		require.NoError(t, err)
+		assert.Contains(t, parsed.Query().Get("scope"), "openid")
+		assert.Equal(t, "S256", parsed.Query().Get("code_challenge_method"))
+
+		code := authorize(t, authURL, "state-1")
+		identity, err := provider.Exchange(context.Background(), code, verifier, "nonce-1")
+
+		require.NoError(t, err)
+		assert.Equal(t, idp.Issuer(), identity.Issuer)
+		assert.Equal(t, "user-123", identity.Subject)
+		assert.Equal(t, "alice@example.com", identity.Email)
+		assert.True(t, identity.EmailVerified)
+		assert.Equal(t, "Alice", identity.Name)
+		assert.Equal(t, []string{"ops", "dev"}, identity.Groups)
+	})
+
+	t.Run("ネストしたグループクレームを読み取れる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		idp.SetClaims(map[string]interface{}{
+			"sub":                "user-123",
+			"email":              "alice@example.com",
+			"preferred_username": "alice",
+			"realm_access":       map[string]interface{}{"roles": []string{"ops"}},
+		})
+		provider := newTestProvider(t, idp, "realm_access.roles")
+		verifier := oauth2.GenerateVerifier()
+
+		code := authorize(t, provider.AuthCodeURL("s", "n", verifier), "s")
+		identity, err := provider.Exchange(context.Background(), code, verifier, "n")
+
+		require.NoError(t, err)
+		assert.Equal(t, "alice", identity.Name)
+		assert.False(t, identity.EmailVerified)
+		assert.Equal(t, []string{"ops"}, identity.Groups)
+	})
+
+	t.Run("グループクレームがない場合はnilになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		provider := newTestProvider(t, idp, "")
+		verifier := oauth2.GenerateVerifier()
+
+		code := authorize(t, provider.AuthCodeURL("s", "n", verifier), "s")
+		identity, err := provider.Exchange(context.Background(), code, verifier, "n")
+
+		require.NoError(t, err)
+		assert.Nil(t, identity.Groups)
+	})
+
+	t.Run("nonceが一致しない場合はエラーになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		provider := newTestProvider(t, idp, "")
+		verifier := oauth2.GenerateVerifier()
+
+		code := authorize(t, provider.AuthCodeURL("s", "nonce-1", verifier), "s")
+		_, err := provider.Exchange(context.Background(), code, verifier, "other-nonce")
+
+		assert.ErrorContains(t, err, "nonce")
+	})
+
+	t.Run("code verifierが一致しない場合はエラーになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		provider := newTestProvider(t, idp, "")
+
+		code := authorize(t, provider.AuthCodeURL("s", "n", oauth2.GenerateVerifier()), "s")
+		_, err := provider.Exchange(context.Background(), code, oauth2.GenerateVerifier(), "n")
+
+		assert.ErrorContains(t, err, "invalid_grant")
+	})
+
+	t.Run("期限切れのIDトークンはエラーになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		idp.SetClaims(map[string]interface{}{
+			"sub": "user-123",
+			"exp": time.Now().Add(-time.Hour).Unix(),
+		})
+		provider := newTestProvider(t, idp, "")
+		verifier := oauth2.GenerateVerifier()
+
+		code := authorize(t, provider.AuthCodeURL("s", "n", verifier), "s")
+		_, err := provider.Exchange(context.Background(), code, verifier, "n")
+
+		assert.ErrorContains(t, err, "expired")
+	})
+
+	t.Run("別のクライアント向けのIDトークンはエラーになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+		idp.SetClaims(map[string]interface{}{
+			"sub": "user-123",
+			"aud": "another-client",
+		})
+		provider := newTestProvider(t, idp, "")
+		verifier := oauth2.GenerateVerifier()
+
+		code := authorize(t, provider.AuthCodeURL("s", "n", verifier), "s")
+		_, err := provider.Exchange(context.Background(), code, verifier, "n")
+
+		assert.ErrorContains(t, err, "not issued for this client")
+	})
+}
+
+func TestNewProvider(t *testing.T) {
+	t.Run("issuerが一致しない場合はエラーになる", func(t *testing.T) {
+		idp := oidctest.NewServer(t)
+
+		_, err := oidc.NewProvider(context.Background(), oidc.Config{
+			IssuerURL:   idp.Issuer() + "/other",
+			ClientID:    oidctest.ClientID,
+			RedirectURL: redirectURL,
+		})
+
+		assert.Error(t, err)
+	})
+
+	t.Run("必須項目がない場合はエラーになる", func(t *testing.T) {
+		_, err := oidc.NewProvider(context.Background(), oidc.Config{IssuerURL: "https://idp.example.com"})
+
+		assert.Error(t, err)
+	})
+}
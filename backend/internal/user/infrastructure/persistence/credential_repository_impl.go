@@ -0,0 +1,59 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// CredentialRepositoryImpl is a PostgreSQL implementation of the CredentialRepository interface
+type CredentialRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewCredentialRepositoryImpl creates a new CredentialRepositoryImpl
+func NewCredentialRepositoryImpl(db *pgxpool.Pool) repository.CredentialRepository {
+	return &CredentialRepositoryImpl{db: db}
+}
+
+// SavePasswordHash sets or replaces the password hash of a user
+func (r *CredentialRepositoryImpl) SavePasswordHash(ctx context.Context, userID value_object.UserID, hash value_object.PasswordHash) error {
+	query := `
+		INSERT INTO user_credentials (user_id, password_hash, updated_at)
+		VALUES ($1, $2, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			password_hash = EXCLUDED.password_hash,
+			updated_at = EXCLUDED.updated_at;
+	`
+	_, err := r.db.Exec(ctx, query, userID.String(), hash.String())
+	if err != nil {
+		return fmt.Errorf("failed to save password hash: %w", err)
+	}
+
+	return nil
+}
+
+// FindPasswordHash retrieves the password hash of a user
+func (r *CredentialRepositoryImpl) FindPasswordHash(ctx context.Context, userID value_object.UserID) (value_object.PasswordHash, error) {
+	var hash string
+	err := r.db.QueryRow(ctx, `SELECT password_hash FROM user_credentials WHERE user_id = $1;`, userID.String()).Scan(&hash)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return value_object.PasswordHash{}, nil
+		}
+		return value_object.PasswordHash{}, fmt.Errorf("failed to find password hash: %w", err)
+	}
+
+	passwordHash, err := value_object.NewPasswordHash(hash)
+	if err != nil {
+		return value_object.PasswordHash{}, fmt.Errorf("invalid password hash for user %s: %w", userID.String(), err)
+	}
+
+	return passwordHash, nil
+}
@@ -0,0 +1,57 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ExternalIdentityRepositoryImpl is a PostgreSQL implementation of the ExternalIdentityRepository interface
+type ExternalIdentityRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewExternalIdentityRepositoryImpl creates a new ExternalIdentityRepositoryImpl
+func NewExternalIdentityRepositoryImpl(db *pgxpool.Pool) repository.ExternalIdentityRepository {
+	return &ExternalIdentityRepositoryImpl{db: db}
+}
+
+// FindUserID retrieves the user linked to the issuer and subject
+func (r *ExternalIdentityRepositoryImpl) FindUserID(ctx context.Context, issuer, subject string) (value_object.UserID, error) {
+	var userID string
+	err := r.db.QueryRow(ctx,
+		`SELECT user_id FROM user_identities WHERE issuer = $1 AND subject = $2;`,
+		issuer, subject,
+	).Scan(&userID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return value_object.UserID{}, nil
+		}
+		return value_object.UserID{}, fmt.Errorf("failed to find external identity: %w", err)
+	}
+
+	return value_object.NewUserID(userID)
+}
+
+// Link associates the issuer and subject with a user and records the login time
+func (r *ExternalIdentityRepositoryImpl) Link(ctx context.Context, issuer, subject string, userID value_object.UserID) error {
+	query := `
+		INSERT INTO user_identities (issuer, subject, user_id, created_at, last_login_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (issuer, subject) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			last_login_at = EXCLUDED.last_login_at;
+	`
+	_, err := r.db.Exec(ctx, query, issuer, subject, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to link external identity: %w", err)
+	}
+
+	return nil
+}
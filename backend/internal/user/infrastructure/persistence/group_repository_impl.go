@@ -121,6 +121,40 @@ func (r *GroupRepositoryImpl) FindByID(ctx context.Context, id value_object.Grou
 	return r.toDomainEntity(groupID, name, description, memberIDs, createdAt, updatedAt)
 }
 
+// FindByName retrieves a group by its unique name
+func (r *GroupRepositoryImpl) FindByName(ctx context.Context, name string) (entity.Group, error) {
+	query := `
+		SELECT id, name, description, created_at, updated_at
+		FROM groups
+		WHERE name = $1;
+	`
+
+	var groupID, groupName, description string
+	var createdAt, updatedAt time.Time
+
+	err := r.db.QueryRow(ctx, query, name).Scan(
+		&groupID,
+		&groupName,
+		&description,
+		&createdAt,
+		&updatedAt,
+	)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find group by name: %w", err)
+	}
+
+	// Get member IDs
+	memberIDs, err := r.getGroupMemberIDs(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get group members: %w", err)
+	}
+
+	return r.toDomainEntity(groupID, groupName, description, memberIDs, createdAt, updatedAt)
+}
+
 // FindByMemberID retrieves all groups that contain the specified user as a member
 func (r *GroupRepositoryImpl) FindByMemberID(ctx context.Context, userID value_object.UserID) ([]entity.Group, error) {
 	query := `
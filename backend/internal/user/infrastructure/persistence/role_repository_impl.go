@@ -0,0 +1,302 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// RoleRepositoryImpl is a PostgreSQL implementation of the RoleRepository interface
+type RoleRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewRoleRepositoryImpl creates a new RoleRepositoryImpl
+func NewRoleRepositoryImpl(db *pgxpool.Pool) repository.RoleRepository {
+	return &RoleRepositoryImpl{db: db}
+}
+
+// Save persists a new role or updates an existing one
+func (r *RoleRepositoryImpl) Save(ctx context.Context, role entity.Role) error {
+	query := `
+		INSERT INTO roles (id, name, description, permissions, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (id) DO UPDATE SET
+			name = EXCLUDED.name,
+			description = EXCLUDED.description,
+			permissions = EXCLUDED.permissions,
+			updated_at = EXCLUDED.updated_at;
+	`
+	_, err := r.db.Exec(ctx, query,
+		role.ID().String(),
+		role.Name(),
+		role.Description(),
+		permissionStrings(role.Permissions()),
+		role.CreatedAt(),
+		role.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save role: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a role by its ID
+func (r *RoleRepositoryImpl) FindByID(ctx context.Context, id value_object.RoleID) (entity.Role, error) {
+	query := `
+		SELECT id, name, description, permissions, created_at, updated_at
+		FROM roles
+		WHERE id = $1;
+	`
+
+	role, err := r.scanRole(r.db.QueryRow(ctx, query, id.String()))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find role by ID: %w", err)
+	}
+
+	return role, nil
+}
+
+// FindByName retrieves a role by its unique name
+func (r *RoleRepositoryImpl) FindByName(ctx context.Context, name string) (entity.Role, error) {
+	query := `
+		SELECT id, name, description, permissions, created_at, updated_at
+		FROM roles
+		WHERE name = $1;
+	`
+
+	role, err := r.scanRole(r.db.QueryRow(ctx, query, name))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find role by name: %w", err)
+	}
+
+	return role, nil
+}
+
+// FindAll retrieves all roles
+func (r *RoleRepositoryImpl) FindAll(ctx context.Context) ([]entity.Role, error) {
+	query := `
+		SELECT id, name, description, permissions, created_at, updated_at
+		FROM roles
+		ORDER BY name;
+	`
+
+	return r.queryRoles(ctx, query)
+}
+
+// Update updates an existing role
+func (r *RoleRepositoryImpl) Update(ctx context.Context, role entity.Role) error {
+	query := `
+		UPDATE roles
+		SET name = $1, description = $2, permissions = $3, updated_at = $4
+		WHERE id = $5;
+	`
+
+	result, err := r.db.Exec(ctx, query,
+		role.Name(),
+		role.Description(),
+		permissionStrings(role.Permissions()),
+		role.UpdatedAt(),
+		role.ID().String(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update role: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("role with ID %s not found", role.ID().String())
+	}
+
+	return nil
+}
+
+// Delete removes a role by its ID. Assignments are removed by the foreign key cascade.
+func (r *RoleRepositoryImpl) Delete(ctx context.Context, id value_object.RoleID) error {
+	query := `DELETE FROM roles WHERE id = $1;`
+
+	result, err := r.db.Exec(ctx, query, id.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete role: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("role with ID %s not found", id.String())
+	}
+
+	return nil
+}
+
+// AssignToUser grants the role to a user
+func (r *RoleRepositoryImpl) AssignToUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error {
+	query := `
+		INSERT INTO user_roles (user_id, role_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, role_id) DO NOTHING;
+	`
+
+	if _, err := r.db.Exec(ctx, query, userID.String(), roleID.String()); err != nil {
+		return fmt.Errorf("failed to assign role to user: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignFromUser revokes the role from a user
+func (r *RoleRepositoryImpl) UnassignFromUser(ctx context.Context, roleID value_object.RoleID, userID value_object.UserID) error {
+	query := `DELETE FROM user_roles WHERE user_id = $1 AND role_id = $2;`
+
+	if _, err := r.db.Exec(ctx, query, userID.String(), roleID.String()); err != nil {
+		return fmt.Errorf("failed to unassign role from user: %w", err)
+	}
+
+	return nil
+}
+
+// AssignToGroup grants the role to a group
+func (r *RoleRepositoryImpl) AssignToGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error {
+	query := `
+		INSERT INTO group_roles (group_id, role_id)
+		VALUES ($1, $2)
+		ON CONFLICT (group_id, role_id) DO NOTHING;
+	`
+
+	if _, err := r.db.Exec(ctx, query, groupID.String(), roleID.String()); err != nil {
+		return fmt.Errorf("failed to assign role to group: %w", err)
+	}
+
+	return nil
+}
+
+// UnassignFromGroup revokes the role from a group
+func (r *RoleRepositoryImpl) UnassignFromGroup(ctx context.Context, roleID value_object.RoleID, groupID value_object.GroupID) error {
+	query := `DELETE FROM group_roles WHERE group_id = $1 AND role_id = $2;`
+
+	if _, err := r.db.Exec(ctx, query, groupID.String(), roleID.String()); err != nil {
+		return fmt.Errorf("failed to unassign role from group: %w", err)
+	}
+
+	return nil
+}
+
+// FindByUserID retrieves the roles assigned directly to a user
+func (r *RoleRepositoryImpl) FindByUserID(ctx context.Context, userID value_object.UserID) ([]entity.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.permissions, r.created_at, r.updated_at
+		FROM roles r
+		INNER JOIN user_roles ur ON r.id = ur.role_id
+		WHERE ur.user_id = $1
+		ORDER BY r.name;
+	`
+
+	return r.queryRoles(ctx, query, userID.String())
+}
+
+// FindByGroupID retrieves the roles assigned to a group
+func (r *RoleRepositoryImpl) FindByGroupID(ctx context.Context, groupID value_object.GroupID) ([]entity.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.permissions, r.created_at, r.updated_at
+		FROM roles r
+		INNER JOIN group_roles gr ON r.id = gr.role_id
+		WHERE gr.group_id = $1
+		ORDER BY r.name;
+	`
+
+	return r.queryRoles(ctx, query, groupID.String())
+}
+
+// FindAssigned retrieves the distinct roles assigned to the user directly or through any of the groups
+func (r *RoleRepositoryImpl) FindAssigned(ctx context.Context, userID value_object.UserID, groupIDs []value_object.GroupID) ([]entity.Role, error) {
+	query := `
+		SELECT r.id, r.name, r.description, r.permissions, r.created_at, r.updated_at
+		FROM roles r
+		WHERE r.id IN (
+			SELECT role_id FROM user_roles WHERE user_id = $1
+			UNION
+			SELECT role_id FROM group_roles WHERE group_id::text = ANY($2)
+		)
+		ORDER BY r.name;
+	`
+
+	groups := make([]string, len(groupIDs))
+	for i, id := range groupIDs {
+		groups[i] = id.String()
+	}
+
+	return r.queryRoles(ctx, query, userID.String(), groups)
+}
+
+// queryRoles runs a query returning role rows
+func (r *RoleRepositoryImpl) queryRoles(ctx context.Context, query string, args ...interface{}) ([]entity.Role, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query roles: %w", err)
+	}
+	defer rows.Close()
+
+	roles := []entity.Role{}
+	for rows.Next() {
+		role, err := r.scanRole(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan role row: %w", err)
+		}
+		roles = append(roles, role)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over role rows: %w", err)
+	}
+
+	return roles, nil
+}
+
+// scanRole scans a single role row and converts it to a domain entity
+func (r *RoleRepositoryImpl) scanRole(row pgx.Row) (entity.Role, error) {
+	var id, name, description string
+	var permissions []string
+	var createdAt, updatedAt time.Time
+
+	if err := row.Scan(&id, &name, &description, &permissions, &createdAt, &updatedAt); err != nil {
+		return nil, err
+	}
+
+	roleID, err := value_object.NewRoleID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid role ID: %w", err)
+	}
+
+	perms := make([]value_object.Permission, 0, len(permissions))
+	for _, p := range permissions {
+		permission, err := value_object.NewPermission(p)
+		if err != nil {
+			// Permissions removed from the catalog are ignored rather than failing the lookup
+			continue
+		}
+		perms = append(perms, permission)
+	}
+
+	return entity.ReconstructRole(roleID, name, description, perms, createdAt, updatedAt), nil
+}
+
+// permissionStrings converts permissions to their string form for storage
+func permissionStrings(permissions []value_object.Permission) []string {
+	result := make([]string, len(permissions))
+	for i, p := range permissions {
+		result[i] = p.String()
+	}
+	return result
+}
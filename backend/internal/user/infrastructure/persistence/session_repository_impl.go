@@ -0,0 +1,98 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"opscore/backend/internal/user/domain/entity"
+	"opscore/backend/internal/user/domain/repository"
+	"opscore/backend/internal/user/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// SessionRepositoryImpl is a PostgreSQL implementation of the SessionRepository interface
+type SessionRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewSessionRepositoryImpl creates a new SessionRepositoryImpl
+func NewSessionRepositoryImpl(db *pgxpool.Pool) repository.SessionRepository {
+	return &SessionRepositoryImpl{db: db}
+}
+
+// Save persists a new session
+func (r *SessionRepositoryImpl) Save(ctx context.Context, session entity.Session) error {
+	query := `
+		INSERT INTO user_sessions (token_hash, user_id, created_at, expires_at)
+		VALUES ($1, $2, $3, $4);
+	`
+	_, err := r.db.Exec(ctx, query,
+		session.TokenHash(),
+		session.UserID().String(),
+		session.CreatedAt(),
+		session.ExpiresAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+
+	return nil
+}
+
+// FindByTokenHash retrieves a session by the hash of its token
+func (r *SessionRepositoryImpl) FindByTokenHash(ctx context.Context, tokenHash string) (entity.Session, error) {
+	query := `
+		SELECT token_hash, user_id, created_at, expires_at
+		FROM user_sessions
+		WHERE token_hash = $1;
+	`
+
+	var hash, userID string
+	var createdAt, expiresAt time.Time
+
+	err := r.db.QueryRow(ctx, query, tokenHash).Scan(&hash, &userID, &createdAt, &expiresAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find session: %w", err)
+	}
+
+	uid, err := value_object.NewUserID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid user ID: %w", err)
+	}
+
+	return entity.ReconstructSession(hash, uid, createdAt, expiresAt), nil
+}
+
+// DeleteByTokenHash removes a single session
+func (r *SessionRepositoryImpl) DeleteByTokenHash(ctx context.Context, tokenHash string) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM user_sessions WHERE token_hash = $1;`, tokenHash)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// DeleteByUserID removes every session of a user
+func (r *SessionRepositoryImpl) DeleteByUserID(ctx context.Context, userID value_object.UserID) error {
+	_, err := r.db.Exec(ctx, `DELETE FROM user_sessions WHERE user_id = $1;`, userID.String())
+	if err != nil {
+		return fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired removes sessions that expired before the given time
+func (r *SessionRepositoryImpl) DeleteExpired(ctx context.Context, before time.Time) (int64, error) {
+	tag, err := r.db.Exec(ctx, `DELETE FROM user_sessions WHERE expires_at <= $1;`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return tag.RowsAffected(), nil
+}
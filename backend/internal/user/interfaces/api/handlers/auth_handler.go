@@ -0,0 +1,194 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"opscore/backend/internal/user/application/usecase"
+	"opscore/backend/internal/user/interfaces/api/middleware"
+	"opscore/backend/internal/user/interfaces/api/schema"
+	intererror "opscore/backend/internal/user/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// AuthHandler holds dependencies for authentication handlers
+type AuthHandler struct {
+	authUseCase usecase.AuthUseCase
+	logger      Logger
+}
+
+// NewAuthHandler creates a new AuthHandler
+func NewAuthHandler(uc usecase.AuthUseCase, logger Logger) *AuthHandler {
+	return &AuthHandler{
+		authUseCase: uc,
+		logger:      logger,
+	}
+}
+
+// Login godoc
+// @Summary Log in with email and password
+// @Description Verifies the credentials and starts a session. The token is returned in the body and set as an HttpOnly cookie.
+// @Tags auth
+// @Accept json
+// @Produce json
+// @Param credentials body schema.LoginRequest true "Email and password"
+// @Success 200 {object} schema.LoginResponse "Logged in successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body"
+// @Failure 401 {object} schema.ErrorResponse "Invalid email or password"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/login [post]
+func (h *AuthHandler) Login(c *gin.Context) {
+	var req schema.LoginRequest
+	requestID := c.GetString("request_id")
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	result, err := h.authUseCase.Login(c.Request.Context(), schema.ToLoginDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Warn("Login failed", "request_id", requestID, "email", req.Email, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	setSessionCookie(c, result.Token, time.Until(result.ExpiresAt))
+
+	h.logger.Info("User logged in", "request_id", requestID, "user_id", result.User.ID)
+	c.JSON(http.StatusOK, schema.FromLoginDTO(*result))
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Ends the current session and clears the session cookie
+// @Tags auth
+// @Success 204 "Logged out successfully"
+// @Failure 401 {object} schema.ErrorResponse "Authentication required"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/logout [post]
+func (h *AuthHandler) Logout(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	err := h.authUseCase.Logout(c.Request.Context(), middleware.TokenFromRequest(c))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to log out", "request_id", requestID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	setSessionCookie(c, "", -1)
+
+	h.logger.Info("User logged out", "request_id", requestID, "user_id", middleware.CurrentUserID(c))
+	c.Status(http.StatusNoContent)
+}
+
+// Me godoc
+// @Summary Get the current user
+// @Description Returns the user the request is authenticated as
+// @Tags auth
+// @Produce json
+// @Success 200 {object} schema.UserResponse "Current user"
+// @Failure 401 {object} schema.ErrorResponse "Authentication required"
+// @Router /auth/me [get]
+func (h *AuthHandler) Me(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	user, err := h.authUseCase.Authenticate(c.Request.Context(), middleware.TokenFromRequest(c))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.FromUserDTO(*user))
+}
+
+// ChangePassword godoc
+// @Summary Change own password
+// @Description Changes the password of the current user. All of the user's sessions are ended.
+// @Tags auth
+// @Accept json
+// @Param request body schema.ChangePasswordRequest true "Current and new password"
+// @Success 204 "Password changed successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body"
+// @Failure 401 {object} schema.ErrorResponse "Current password is wrong"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/password [put]
+func (h *AuthHandler) ChangePassword(c *gin.Context) {
+	var req schema.ChangePasswordRequest
+	requestID := c.GetString("request_id")
+	userID := middleware.CurrentUserID(c)
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "user_id", userID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	err := h.authUseCase.ChangePassword(c.Request.Context(), userID, schema.ToChangePasswordDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to change password", "request_id", requestID, "user_id", userID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	setSessionCookie(c, "", -1)
+
+	h.logger.Info("Password changed", "request_id", requestID, "user_id", userID)
+	c.Status(http.StatusNoContent)
+}
+
+// SetUserPassword godoc
+// @Summary Set a user's password
+// @Description Sets the password of another user. Administrators only. All of the user's sessions are ended.
+// @Tags users
+// @Accept json
+// @Param userId path string true "User ID" example:"550e8400-e29b-41d4-a716-446655440000"
+// @Param request body schema.SetPasswordRequest true "New password"
+// @Success 204 "Password set successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body"
+// @Failure 403 {object} schema.ErrorResponse "Caller is not an administrator"
+// @Failure 404 {object} schema.ErrorResponse "User not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /users/{userId}/password [put]
+func (h *AuthHandler) SetUserPassword(c *gin.Context) {
+	var req schema.SetPasswordRequest
+	requestID := c.GetString("request_id")
+	userID := c.Param("userId")
+	actorID := middleware.CurrentUserID(c)
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "user_id", userID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	err := h.authUseCase.SetPassword(c.Request.Context(), actorID, userID, schema.ToSetPasswordDTO(req))
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to set password", "request_id", requestID, "user_id", userID, "actor_id", actorID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	h.logger.Info("Password set", "request_id", requestID, "user_id", userID, "actor_id", actorID)
+	c.Status(http.StatusNoContent)
+}
+
+// setSessionCookie writes the session cookie. A negative maxAge deletes it.
+func setSessionCookie(c *gin.Context, token string, maxAge time.Duration) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	seconds := int(maxAge.Seconds())
+	if maxAge < 0 {
+		seconds = -1
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(middleware.SessionCookieName, token, seconds, "/", "", secure, true)
+}
@@ -0,0 +1,207 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/interfaces/api/middleware"
+	"opscore/backend/internal/user/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockAuthUseCase is a mock implementation of the AuthUseCase interface
+type MockAuthUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuthUseCase) Login(ctx context.Context, req dto.LoginRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) Logout(ctx context.Context, token string) error {
+	args := m.Called(ctx, token)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) Authenticate(ctx context.Context, token string) (*dto.UserResponse, error) {
+	args := m.Called(ctx, token)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.UserResponse), args.Error(1)
+}
+
+func (m *MockAuthUseCase) ChangePassword(ctx context.Context, userID string, req dto.ChangePasswordRequest) error {
+	args := m.Called(ctx, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) SetPassword(ctx context.Context, actorID string, userID string, req dto.SetPasswordRequest) error {
+	args := m.Called(ctx, actorID, userID, req)
+	return args.Error(0)
+}
+
+func (m *MockAuthUseCase) EnsureAdmin(ctx context.Context, req dto.BootstrapAdminRequest) error {
+	args := m.Called(ctx, req)
+	return args.Error(0)
+}
+
+func setupAuthRouter(uc *MockAuthUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewAuthHandler(uc, &MockLogger{})
+
+	r := gin.New()
+	r.POST("/auth/login", handler.Login)
+
+	authed := r.Group("/", middleware.RequireAuthentication(uc))
+	authed.POST("/auth/logout", handler.Logout)
+	authed.GET("/auth/me", handler.Me)
+	authed.PUT("/auth/password", handler.ChangePassword)
+	authed.PUT("/users/:userId/password", handler.SetUserPassword)
+	return r
+}
+
+func TestAuthHandler_Login(t *testing.T) {
+	t.Run("ログインに成功するとトークンとCookieが返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		expiresAt := time.Now().Add(time.Hour)
+		uc.On("Login", mock.Anything, dto.LoginRequest{Email: "test@example.com", Password: "password123"}).
+			Return(&dto.LoginResponse{Token: "token-1", ExpiresAt: expiresAt, User: dto.UserResponse{ID: "user-1"}}, nil)
+		router := setupAuthRouter(uc)
+
+		body, _ := json.Marshal(schema.LoginRequest{Email: "test@example.com", Password: "password123"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp schema.LoginResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "token-1", resp.Token)
+		assert.Equal(t, "user-1", resp.User.ID)
+
+		cookies := w.Result().Cookies()
+		require.Len(t, cookies, 1)
+		assert.Equal(t, middleware.SessionCookieName, cookies[0].Name)
+		assert.Equal(t, "token-1", cookies[0].Value)
+		assert.True(t, cookies[0].HttpOnly)
+	})
+
+	t.Run("認証情報が誤っていると401が返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		uc.On("Login", mock.Anything, mock.Anything).Return(nil, apperror.NewInvalidCredentialsError())
+		router := setupAuthRouter(uc)
+
+		body, _ := json.Marshal(schema.LoginRequest{Email: "test@example.com", Password: "wrong"})
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Empty(t, w.Result().Cookies())
+	})
+
+	t.Run("不正なリクエストボディで400が返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		router := setupAuthRouter(uc)
+
+		req := httptest.NewRequest(http.MethodPost, "/auth/login", bytes.NewBufferString(`{"email":"not-an-email"}`))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestAuthHandler_Logout(t *testing.T) {
+	uc := new(MockAuthUseCase)
+	uc.On("Authenticate", mock.Anything, "token-1").Return(&dto.UserResponse{ID: "user-1"}, nil)
+	uc.On("Logout", mock.Anything, "token-1").Return(nil)
+	router := setupAuthRouter(uc)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/logout", nil)
+	req.Header.Set("Authorization", "Bearer token-1")
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusNoContent, w.Code)
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, "", cookies[0].Value)
+	assert.True(t, cookies[0].MaxAge < 0)
+	uc.AssertExpectations(t)
+}
+
+func TestAuthHandler_Me(t *testing.T) {
+	t.Run("認証済みなら現在のユーザーが返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		uc.On("Authenticate", mock.Anything, "token-1").Return(&dto.UserResponse{ID: "user-1", Name: "Test User"}, nil)
+		router := setupAuthRouter(uc)
+
+		req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+		req.Header.Set("Authorization", "Bearer token-1")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var resp schema.UserResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+		assert.Equal(t, "user-1", resp.ID)
+	})
+
+	t.Run("未認証なら401が返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		router := setupAuthRouter(uc)
+
+		req := httptest.NewRequest(http.MethodGet, "/auth/me", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+func TestAuthHandler_SetUserPassword(t *testing.T) {
+	t.Run("管理者以外は403が返される", func(t *testing.T) {
+		uc := new(MockAuthUseCase)
+		uc.On("Authenticate", mock.Anything, "token-1").Return(&dto.UserResponse{ID: "user-1", Role: "user"}, nil)
+		uc.On("SetPassword", mock.Anything, "user-1", "user-2", dto.SetPasswordRequest{Password: "password123"}).
+			Return(apperror.NewForbiddenError("User", "set password", "user-1"))
+		router := setupAuthRouter(uc)
+
+		body, _ := json.Marshal(schema.SetPasswordRequest{Password: "password123"})
+		req := httptest.NewRequest(http.MethodPut, "/users/user-2/password", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		req.Header.Set("Authorization", "Bearer token-1")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+		uc.AssertExpectations(t)
+	})
+}
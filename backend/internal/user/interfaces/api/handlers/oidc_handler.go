@@ -0,0 +1,153 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	"opscore/backend/internal/user/application/usecase"
+	"opscore/backend/internal/user/interfaces/api/schema"
+	intererror "opscore/backend/internal/user/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// oidcStateCookieName holds the state, nonce and PKCE verifier between login and callback
+	oidcStateCookieName = "opscore_oidc"
+	oidcStateCookiePath = "/api/v1/auth/oidc"
+	oidcStateCookieTTL  = 10 * time.Minute
+)
+
+// oidcLoginState is stored in the state cookie while the browser is at the identity provider
+type oidcLoginState struct {
+	State        string `json:"s"`
+	Nonce        string `json:"n"`
+	CodeVerifier string `json:"v"`
+}
+
+// OIDCHandler holds dependencies for single sign-on handlers
+type OIDCHandler struct {
+	oidcUseCase       usecase.OIDCUseCase
+	logger            Logger
+	postLoginRedirect string
+}
+
+// NewOIDCHandler creates a new OIDCHandler.
+// After a successful login the browser is redirected to postLoginRedirect ("/" if empty).
+func NewOIDCHandler(uc usecase.OIDCUseCase, logger Logger, postLoginRedirect string) *OIDCHandler {
+	if postLoginRedirect == "" {
+		postLoginRedirect = "/"
+	}
+	return &OIDCHandler{
+		oidcUseCase:       uc,
+		logger:            logger,
+		postLoginRedirect: postLoginRedirect,
+	}
+}
+
+// Login godoc
+// @Summary Start single sign-on
+// @Description Redirects the browser to the OpenID Connect provider
+// @Tags auth
+// @Success 302 "Redirect to the identity provider"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/oidc/login [get]
+func (h *OIDCHandler) Login(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	start, err := h.oidcUseCase.BeginLogin(c.Request.Context())
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Error("Failed to start OIDC login", "request_id", requestID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	value, err := json.Marshal(oidcLoginState{State: start.State, Nonce: start.Nonce, CodeVerifier: start.CodeVerifier})
+	if err != nil {
+		h.logger.Error("Failed to encode OIDC state", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusInternalServerError, schema.ErrorResponse{Code: "INTERNAL_ERROR", Message: "Failed to start login"})
+		return
+	}
+	setOIDCStateCookie(c, base64.RawURLEncoding.EncodeToString(value), oidcStateCookieTTL)
+
+	c.Redirect(http.StatusFound, start.AuthURL)
+}
+
+// Callback godoc
+// @Summary Complete single sign-on
+// @Description Handles the redirect from the OpenID Connect provider, starts a session and redirects to the application
+// @Tags auth
+// @Param code query string false "Authorization code"
+// @Param state query string true "State"
+// @Success 302 "Logged in; redirect to the application"
+// @Failure 401 {object} schema.ErrorResponse "Login was rejected"
+// @Failure 409 {object} schema.ErrorResponse "Email is registered to an account that cannot be linked"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/oidc/callback [get]
+func (h *OIDCHandler) Callback(c *gin.Context) {
+	requestID := c.GetString("request_id")
+
+	// The state cookie is single use
+	saved := readOIDCStateCookie(c)
+	setOIDCStateCookie(c, "", -1)
+
+	if errCode := c.Query("error"); errCode != "" {
+		h.logger.Warn("Identity provider returned an error", "request_id", requestID, "error", errCode, "description", c.Query("error_description"))
+		c.JSON(http.StatusUnauthorized, schema.ErrorResponse{Code: "UNAUTHORIZED", Message: "Login was cancelled or rejected by the identity provider"})
+		return
+	}
+
+	result, err := h.oidcUseCase.CompleteLogin(c.Request.Context(), dto.OIDCCallbackRequest{
+		Code:          c.Query("code"),
+		State:         c.Query("state"),
+		ExpectedState: saved.State,
+		Nonce:         saved.Nonce,
+		CodeVerifier:  saved.CodeVerifier,
+	})
+	if err != nil {
+		httpErr := intererror.MapToHTTPError(err, requestID)
+		h.logger.Warn("OIDC login failed", "request_id", requestID, "error", err.Error(), "http_code", httpErr.Code)
+		c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+		return
+	}
+
+	setSessionCookie(c, result.Token, time.Until(result.ExpiresAt))
+
+	h.logger.Info("User logged in via OIDC", "request_id", requestID, "user_id", result.User.ID)
+	c.Redirect(http.StatusFound, h.postLoginRedirect)
+}
+
+// readOIDCStateCookie decodes the state cookie; a missing or malformed cookie yields an empty state
+func readOIDCStateCookie(c *gin.Context) oidcLoginState {
+	var saved oidcLoginState
+
+	value, err := c.Cookie(oidcStateCookieName)
+	if err != nil {
+		return saved
+	}
+	raw, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return saved
+	}
+	if err := json.Unmarshal(raw, &saved); err != nil {
+		return oidcLoginState{}
+	}
+	return saved
+}
+
+// setOIDCStateCookie writes the state cookie. A negative maxAge deletes it.
+// SameSite=Lax lets the cookie accompany the top-level redirect back from the identity provider.
+func setOIDCStateCookie(c *gin.Context, value string, maxAge time.Duration) {
+	secure := c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https"
+	seconds := int(maxAge.Seconds())
+	if maxAge < 0 {
+		seconds = -1
+	}
+
+	c.SetSameSite(http.SameSiteLaxMode)
+	c.SetCookie(oidcStateCookieName, value, seconds, oidcStateCookiePath, "", secure, true)
+}
@@ -0,0 +1,147 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/interfaces/api/middleware"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockOIDCUseCase is a mock implementation of the OIDCUseCase interface
+type MockOIDCUseCase struct {
+	mock.Mock
+}
+
+func (m *MockOIDCUseCase) BeginLogin(ctx context.Context) (*dto.OIDCLoginStart, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.OIDCLoginStart), args.Error(1)
+}
+
+func (m *MockOIDCUseCase) CompleteLogin(ctx context.Context, req dto.OIDCCallbackRequest) (*dto.LoginResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.LoginResponse), args.Error(1)
+}
+
+func setupOIDCRouter(uc *MockOIDCUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewOIDCHandler(uc, &MockLogger{}, "/documents")
+
+	r := gin.New()
+	r.GET("/api/v1/auth/oidc/login", handler.Login)
+	r.GET("/api/v1/auth/oidc/callback", handler.Callback)
+	return r
+}
+
+func findCookie(cookies []*http.Cookie, name string) *http.Cookie {
+	for _, c := range cookies {
+		if c.Name == name {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestOIDCHandler_Login(t *testing.T) {
+	uc := new(MockOIDCUseCase)
+	uc.On("BeginLogin", mock.Anything).Return(&dto.OIDCLoginStart{
+		AuthURL: "https://idp.example.com/authorize?state=state-1", State: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1",
+	}, nil)
+	router := setupOIDCRouter(uc)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+	w := httptest.NewRecorder()
+
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "https://idp.example.com/authorize?state=state-1", w.Header().Get("Location"))
+	cookie := findCookie(w.Result().Cookies(), oidcStateCookieName)
+	require.NotNil(t, cookie)
+	assert.True(t, cookie.HttpOnly)
+	assert.Equal(t, oidcStateCookiePath, cookie.Path)
+	assert.NotContains(t, cookie.Value, "verifier-1")
+}
+
+func TestOIDCHandler_Callback(t *testing.T) {
+	// loginCookie runs the login endpoint and returns the state cookie it sets
+	loginCookie := func(t *testing.T, router *gin.Engine) *http.Cookie {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/login", nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		cookie := findCookie(w.Result().Cookies(), oidcStateCookieName)
+		require.NotNil(t, cookie)
+		return cookie
+	}
+
+	t.Run("ログインに成功するとセッションCookieを設定してリダイレクトする", func(t *testing.T) {
+		uc := new(MockOIDCUseCase)
+		uc.On("BeginLogin", mock.Anything).Return(&dto.OIDCLoginStart{
+			AuthURL: "https://idp.example.com/authorize", State: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1",
+		}, nil)
+		uc.On("CompleteLogin", mock.Anything, dto.OIDCCallbackRequest{
+			Code: "code-1", State: "state-1", ExpectedState: "state-1", Nonce: "nonce-1", CodeVerifier: "verifier-1",
+		}).Return(&dto.LoginResponse{Token: "token-1", ExpiresAt: time.Now().Add(time.Hour), User: dto.UserResponse{ID: "user-1"}}, nil)
+		router := setupOIDCRouter(uc)
+		stateCookie := loginCookie(t, router)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=code-1&state=state-1", nil)
+		req.AddCookie(stateCookie)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusFound, w.Code)
+		assert.Equal(t, "/documents", w.Header().Get("Location"))
+		session := findCookie(w.Result().Cookies(), middleware.SessionCookieName)
+		require.NotNil(t, session)
+		assert.Equal(t, "token-1", session.Value)
+		cleared := findCookie(w.Result().Cookies(), oidcStateCookieName)
+		require.NotNil(t, cleared)
+		assert.True(t, cleared.MaxAge < 0)
+		uc.AssertExpectations(t)
+	})
+
+	t.Run("stateCookieがない場合は401が返される", func(t *testing.T) {
+		uc := new(MockOIDCUseCase)
+		uc.On("CompleteLogin", mock.Anything, dto.OIDCCallbackRequest{Code: "code-1", State: "state-1"}).
+			Return(nil, apperror.NewUnauthorizedError("state mismatch", nil))
+		router := setupOIDCRouter(uc)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?code=code-1&state=state-1", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Nil(t, findCookie(w.Result().Cookies(), middleware.SessionCookieName))
+	})
+
+	t.Run("IdPがエラーを返した場合は401が返される", func(t *testing.T) {
+		uc := new(MockOIDCUseCase)
+		router := setupOIDCRouter(uc)
+
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/auth/oidc/callback?error=access_denied&state=state-1", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		uc.AssertNotCalled(t, "CompleteLogin", mock.Anything, mock.Anything)
+	})
+}
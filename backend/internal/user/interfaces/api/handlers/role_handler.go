@@ -0,0 +1,343 @@
+package handlers
+
+import (
+	"net/http"
+
+	"opscore/backend/internal/user/application/usecase"
+	"opscore/backend/internal/user/interfaces/api/middleware"
+	"opscore/backend/internal/user/interfaces/api/schema"
+	intererror "opscore/backend/internal/user/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// RoleHandler holds dependencies for role and permission handlers
+type RoleHandler struct {
+	roleUseCase          usecase.RoleUseCase
+	authorizationUseCase usecase.AuthorizationUseCase
+	logger               Logger
+}
+
+// NewRoleHandler creates a new RoleHandler
+func NewRoleHandler(roleUC usecase.RoleUseCase, authzUC usecase.AuthorizationUseCase, logger Logger) *RoleHandler {
+	return &RoleHandler{
+		roleUseCase:          roleUC,
+		authorizationUseCase: authzUC,
+		logger:               logger,
+	}
+}
+
+// ListPermissions godoc
+// @Summary List permissions
+// @Description Returns the catalog of permissions that can be granted through roles
+// @Tags roles
+// @Produce json
+// @Success 200 {object} schema.ListPermissionsResponse "Permission catalog"
+// @Router /permissions [get]
+func (h *RoleHandler) ListPermissions(c *gin.Context) {
+	permissions := h.roleUseCase.ListPermissions(c.Request.Context())
+	c.JSON(http.StatusOK, schema.ListPermissionsResponse{Permissions: schema.FromPermissionListDTO(permissions)})
+}
+
+// GetMyPermissions godoc
+// @Summary Get current user's permissions
+// @Description Returns the permissions held by the authenticated user through their role and assigned roles
+// @Tags roles
+// @Produce json
+// @Success 200 {object} schema.EffectivePermissionsResponse "Permissions held by the user"
+// @Failure 401 {object} schema.ErrorResponse "Not authenticated"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /auth/me/permissions [get]
+func (h *RoleHandler) GetMyPermissions(c *gin.Context) {
+	requestID := c.GetString("request_id")
+	userID := middleware.CurrentUserID(c)
+
+	permissions, err := h.authorizationUseCase.EffectivePermissions(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "Failed to resolve permissions", err, "user_id", userID)
+		return
+	}
+
+	h.logger.Info("Resolved permissions", "request_id", requestID, "user_id", userID, "permission_count", len(permissions))
+	c.JSON(http.StatusOK, schema.EffectivePermissionsResponse{Permissions: permissions})
+}
+
+// CreateRole godoc
+// @Summary Create a new role
+// @Description Creates a role bundling permissions from the catalog
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param role body schema.CreateRoleRequest true "Role information"
+// @Success 201 {object} schema.RoleResponse "Role created successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or unknown permission"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 409 {object} schema.ErrorResponse "Role name already exists"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /roles [post]
+func (h *RoleHandler) CreateRole(c *gin.Context) {
+	var req schema.CreateRoleRequest
+	requestID := c.GetString("request_id")
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Creating role", "request_id", requestID, "name", req.Name)
+	result, err := h.roleUseCase.Create(c.Request.Context(), schema.ToCreateRoleDTO(req))
+	if err != nil {
+		h.respondError(c, "Failed to create role", err)
+		return
+	}
+
+	h.logger.Info("Role created successfully", "request_id", requestID, "role_id", result.ID)
+	c.JSON(http.StatusCreated, schema.FromRoleDTO(*result))
+}
+
+// GetRole godoc
+// @Summary Get role details
+// @Description Retrieves a role by ID
+// @Tags roles
+// @Produce json
+// @Param roleId path string true "Role ID" example:"550e8400-e29b-41d4-a716-446655440000"
+// @Success 200 {object} schema.RoleResponse "Successfully retrieved role"
+// @Failure 404 {object} schema.ErrorResponse "Role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /roles/{roleId} [get]
+func (h *RoleHandler) GetRole(c *gin.Context) {
+	roleID := c.Param("roleId")
+
+	result, err := h.roleUseCase.GetByID(c.Request.Context(), roleID)
+	if err != nil {
+		h.respondError(c, "Failed to get role", err, "role_id", roleID)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.FromRoleDTO(*result))
+}
+
+// ListRoles godoc
+// @Summary List all roles
+// @Description Retrieves every role in the system
+// @Tags roles
+// @Produce json
+// @Success 200 {object} schema.ListRolesResponse "Successfully retrieved roles"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /roles [get]
+func (h *RoleHandler) ListRoles(c *gin.Context) {
+	results, err := h.roleUseCase.GetAll(c.Request.Context())
+	if err != nil {
+		h.respondError(c, "Failed to list roles", err)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.ListRolesResponse{Roles: schema.FromRoleListDTO(results)})
+}
+
+// UpdateRole godoc
+// @Summary Update a role
+// @Description Replaces the name, description and permissions of a role
+// @Tags roles
+// @Accept json
+// @Produce json
+// @Param roleId path string true "Role ID" example:"550e8400-e29b-41d4-a716-446655440000"
+// @Param role body schema.UpdateRoleRequest true "Role information"
+// @Success 200 {object} schema.RoleResponse "Role updated successfully"
+// @Failure 400 {object} schema.ErrorResponse "Invalid request body or unknown permission"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Role not found"
+// @Failure 409 {object} schema.ErrorResponse "Role name already exists"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /roles/{roleId} [put]
+func (h *RoleHandler) UpdateRole(c *gin.Context) {
+	roleID := c.Param("roleId")
+	requestID := c.GetString("request_id")
+	var req schema.UpdateRoleRequest
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Error("Invalid request body", "request_id", requestID, "error", err.Error())
+		c.JSON(http.StatusBadRequest, schema.ErrorResponse{Code: "INVALID_REQUEST", Message: "Invalid request body: " + err.Error()})
+		return
+	}
+
+	h.logger.Info("Updating role", "request_id", requestID, "role_id", roleID)
+	result, err := h.roleUseCase.Update(c.Request.Context(), roleID, schema.ToUpdateRoleDTO(req))
+	if err != nil {
+		h.respondError(c, "Failed to update role", err, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role updated successfully", "request_id", requestID, "role_id", roleID)
+	c.JSON(http.StatusOK, schema.FromRoleDTO(*result))
+}
+
+// DeleteRole godoc
+// @Summary Delete a role
+// @Description Removes a role together with all of its user and group assignments
+// @Tags roles
+// @Produce json
+// @Param roleId path string true "Role ID" example:"550e8400-e29b-41d4-a716-446655440000"
+// @Success 200 {object} map[string]string "Role deleted successfully"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /roles/{roleId} [delete]
+func (h *RoleHandler) DeleteRole(c *gin.Context) {
+	roleID := c.Param("roleId")
+	requestID := c.GetString("request_id")
+
+	h.logger.Info("Deleting role", "request_id", requestID, "role_id", roleID)
+	if err := h.roleUseCase.Delete(c.Request.Context(), roleID); err != nil {
+		h.respondError(c, "Failed to delete role", err, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role deleted successfully", "request_id", requestID, "role_id", roleID)
+	c.JSON(http.StatusOK, map[string]string{
+		"message": "Role deleted successfully",
+		"roleId":  roleID,
+	})
+}
+
+// AssignUserRole godoc
+// @Summary Assign a role to a user
+// @Tags roles
+// @Param userId path string true "User ID"
+// @Param roleId path string true "Role ID"
+// @Success 204 "Role assigned"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "User or role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /users/{userId}/roles/{roleId} [put]
+func (h *RoleHandler) AssignUserRole(c *gin.Context) {
+	userID, roleID := c.Param("userId"), c.Param("roleId")
+
+	if err := h.roleUseCase.AssignToUser(c.Request.Context(), roleID, userID); err != nil {
+		h.respondError(c, "Failed to assign role to user", err, "user_id", userID, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role assigned to user", "request_id", c.GetString("request_id"), "user_id", userID, "role_id", roleID)
+	c.Status(http.StatusNoContent)
+}
+
+// UnassignUserRole godoc
+// @Summary Revoke a role from a user
+// @Tags roles
+// @Param userId path string true "User ID"
+// @Param roleId path string true "Role ID"
+// @Success 204 "Role revoked"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "User or role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /users/{userId}/roles/{roleId} [delete]
+func (h *RoleHandler) UnassignUserRole(c *gin.Context) {
+	userID, roleID := c.Param("userId"), c.Param("roleId")
+
+	if err := h.roleUseCase.UnassignFromUser(c.Request.Context(), roleID, userID); err != nil {
+		h.respondError(c, "Failed to revoke role from user", err, "user_id", userID, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role revoked from user", "request_id", c.GetString("request_id"), "user_id", userID, "role_id", roleID)
+	c.Status(http.StatusNoContent)
+}
+
+// GetUserRoles godoc
+// @Summary List a user's roles
+// @Description Retrieves the roles assigned directly to a user
+// @Tags roles
+// @Produce json
+// @Param userId path string true "User ID"
+// @Success 200 {object} schema.ListRolesResponse "Roles assigned to the user"
+// @Failure 404 {object} schema.ErrorResponse "User not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /users/{userId}/roles [get]
+func (h *RoleHandler) GetUserRoles(c *gin.Context) {
+	userID := c.Param("userId")
+
+	results, err := h.roleUseCase.GetUserRoles(c.Request.Context(), userID)
+	if err != nil {
+		h.respondError(c, "Failed to get user roles", err, "user_id", userID)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.ListRolesResponse{Roles: schema.FromRoleListDTO(results)})
+}
+
+// AssignGroupRole godoc
+// @Summary Assign a role to a group
+// @Description Every member of the group holds the role's permissions
+// @Tags roles
+// @Param groupId path string true "Group ID"
+// @Param roleId path string true "Role ID"
+// @Success 204 "Role assigned"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Group or role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /groups/{groupId}/roles/{roleId} [put]
+func (h *RoleHandler) AssignGroupRole(c *gin.Context) {
+	groupID, roleID := c.Param("groupId"), c.Param("roleId")
+
+	if err := h.roleUseCase.AssignToGroup(c.Request.Context(), roleID, groupID); err != nil {
+		h.respondError(c, "Failed to assign role to group", err, "group_id", groupID, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role assigned to group", "request_id", c.GetString("request_id"), "group_id", groupID, "role_id", roleID)
+	c.Status(http.StatusNoContent)
+}
+
+// UnassignGroupRole godoc
+// @Summary Revoke a role from a group
+// @Tags roles
+// @Param groupId path string true "Group ID"
+// @Param roleId path string true "Role ID"
+// @Success 204 "Role revoked"
+// @Failure 403 {object} schema.ErrorResponse "Permission denied"
+// @Failure 404 {object} schema.ErrorResponse "Group or role not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /groups/{groupId}/roles/{roleId} [delete]
+func (h *RoleHandler) UnassignGroupRole(c *gin.Context) {
+	groupID, roleID := c.Param("groupId"), c.Param("roleId")
+
+	if err := h.roleUseCase.UnassignFromGroup(c.Request.Context(), roleID, groupID); err != nil {
+		h.respondError(c, "Failed to revoke role from group", err, "group_id", groupID, "role_id", roleID)
+		return
+	}
+
+	h.logger.Info("Role revoked from group", "request_id", c.GetString("request_id"), "group_id", groupID, "role_id", roleID)
+	c.Status(http.StatusNoContent)
+}
+
+// GetGroupRoles godoc
+// @Summary List a group's roles
+// @Tags roles
+// @Produce json
+// @Param groupId path string true "Group ID"
+// @Success 200 {object} schema.ListRolesResponse "Roles assigned to the group"
+// @Failure 404 {object} schema.ErrorResponse "Group not found"
+// @Failure 500 {object} schema.ErrorResponse "Internal server error"
+// @Router /groups/{groupId}/roles [get]
+func (h *RoleHandler) GetGroupRoles(c *gin.Context) {
+	groupID := c.Param("groupId")
+
+	results, err := h.roleUseCase.GetGroupRoles(c.Request.Context(), groupID)
+	if err != nil {
+		h.respondError(c, "Failed to get group roles", err, "group_id", groupID)
+		return
+	}
+
+	c.JSON(http.StatusOK, schema.ListRolesResponse{Roles: schema.FromRoleListDTO(results)})
+}
+
+// respondError logs the failure and writes the mapped error response
+func (h *RoleHandler) respondError(c *gin.Context, msg string, err error, fields ...any) {
+	requestID := c.GetString("request_id")
+	httpErr := intererror.MapToHTTPError(err, requestID)
+	args := append([]any{"request_id", requestID, "error", err.Error(), "http_code", httpErr.Code}, fields...)
+	h.logger.Error(msg, args...)
+	c.JSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+}
@@ -0,0 +1,240 @@
+package handlers
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+	"opscore/backend/internal/user/interfaces/api/middleware"
+	"opscore/backend/internal/user/interfaces/api/schema"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// MockRoleUseCase is a mock implementation of the RoleUseCase interface
+type MockRoleUseCase struct {
+	mock.Mock
+}
+
+func (m *MockRoleUseCase) ListPermissions(ctx context.Context) []dto.PermissionResponse {
+	args := m.Called(ctx)
+	return args.Get(0).([]dto.PermissionResponse)
+}
+
+func (m *MockRoleUseCase) Create(ctx context.Context, req dto.CreateRoleRequest) (*dto.RoleResponse, error) {
+	args := m.Called(ctx, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetByID(ctx context.Context, roleID string) (*dto.RoleResponse, error) {
+	args := m.Called(ctx, roleID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetAll(ctx context.Context) ([]dto.RoleResponse, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleUseCase) Update(ctx context.Context, roleID string, req dto.UpdateRoleRequest) (*dto.RoleResponse, error) {
+	args := m.Called(ctx, roleID, req)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*dto.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleUseCase) Delete(ctx context.Context, roleID string) error {
+	return m.Called(ctx, roleID).Error(0)
+}
+
+func (m *MockRoleUseCase) AssignToUser(ctx context.Context, roleID string, userID string) error {
+	return m.Called(ctx, roleID, userID).Error(0)
+}
+
+func (m *MockRoleUseCase) UnassignFromUser(ctx context.Context, roleID string, userID string) error {
+	return m.Called(ctx, roleID, userID).Error(0)
+}
+
+func (m *MockRoleUseCase) AssignToGroup(ctx context.Context, roleID string, groupID string) error {
+	return m.Called(ctx, roleID, groupID).Error(0)
+}
+
+func (m *MockRoleUseCase) UnassignFromGroup(ctx context.Context, roleID string, groupID string) error {
+	return m.Called(ctx, roleID, groupID).Error(0)
+}
+
+func (m *MockRoleUseCase) GetUserRoles(ctx context.Context, userID string) ([]dto.RoleResponse, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.RoleResponse), args.Error(1)
+}
+
+func (m *MockRoleUseCase) GetGroupRoles(ctx context.Context, groupID string) ([]dto.RoleResponse, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]dto.RoleResponse), args.Error(1)
+}
+
+// MockAuthorizationUseCase is a mock implementation of the AuthorizationUseCase interface
+type MockAuthorizationUseCase struct {
+	mock.Mock
+}
+
+func (m *MockAuthorizationUseCase) Authorize(ctx context.Context, userID string, permission string) error {
+	return m.Called(ctx, userID, permission).Error(0)
+}
+
+func (m *MockAuthorizationUseCase) EffectivePermissions(ctx context.Context, userID string) ([]string, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func setupRoleRouter(roleUC *MockRoleUseCase, authzUC *MockAuthorizationUseCase) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	handler := NewRoleHandler(roleUC, authzUC, &MockLogger{})
+
+	r := gin.New()
+	r.Use(func(c *gin.Context) {
+		c.Set(middleware.UserIDKey, "user-1")
+		c.Next()
+	})
+	r.GET("/api/v1/permissions", handler.ListPermissions)
+	r.GET("/api/v1/auth/me/permissions", handler.GetMyPermissions)
+	r.POST("/api/v1/roles", handler.CreateRole)
+	r.PUT("/api/v1/roles/:roleId", handler.UpdateRole)
+	r.DELETE("/api/v1/roles/:roleId", handler.DeleteRole)
+	r.PUT("/api/v1/users/:userId/roles/:roleId", handler.AssignUserRole)
+	r.PUT("/api/v1/groups/:groupId/roles/:roleId", handler.AssignGroupRole)
+	r.GET("/api/v1/groups/:groupId/roles", handler.GetGroupRoles)
+	return r
+}
+
+func TestRoleHandler_ListPermissions(t *testing.T) {
+	roleUC := new(MockRoleUseCase)
+	roleUC.On("ListPermissions", mock.Anything).Return([]dto.PermissionResponse{
+		{Name: "document:publish", Description: "Publish documents"},
+	})
+	router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/permissions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"permissions":[{"name":"document:publish","description":"Publish documents"}]}`, w.Body.String())
+}
+
+func TestRoleHandler_GetMyPermissions(t *testing.T) {
+	authzUC := new(MockAuthorizationUseCase)
+	authzUC.On("EffectivePermissions", mock.Anything, "user-1").Return([]string{"execution:delete"}, nil)
+	router := setupRoleRouter(new(MockRoleUseCase), authzUC)
+
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/auth/me/permissions", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"permissions":["execution:delete"]}`, w.Body.String())
+}
+
+func TestRoleHandler_CreateRole(t *testing.T) {
+	t.Run("ロールが作成される", func(t *testing.T) {
+		roleUC := new(MockRoleUseCase)
+		roleUC.On("Create", mock.Anything, dto.CreateRoleRequest{Name: "Publisher", Permissions: []string{"document:publish"}}).
+			Return(&dto.RoleResponse{ID: "role-1", Name: "Publisher", Permissions: []string{"document:publish"}}, nil)
+		router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+		body, _ := json.Marshal(schema.CreateRoleRequest{Name: "Publisher", Permissions: []string{"document:publish"}})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/roles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		require.Equal(t, http.StatusCreated, w.Code)
+		var response schema.RoleResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		assert.Equal(t, "role-1", response.ID)
+		assert.Equal(t, []string{"document:publish"}, response.Permissions)
+	})
+
+	t.Run("未知の権限は400が返される", func(t *testing.T) {
+		roleUC := new(MockRoleUseCase)
+		roleUC.On("Create", mock.Anything, mock.Anything).Return(nil, apperror.NewValidationFailedError([]apperror.FieldError{
+			{Field: "permissions", Message: "unknown permission: everything"},
+		}))
+		router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+		body, _ := json.Marshal(schema.CreateRoleRequest{Name: "Publisher", Permissions: []string{"everything"}})
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/roles", bytes.NewBuffer(body))
+		req.Header.Set("Content-Type", "application/json")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusBadRequest, w.Code)
+	})
+}
+
+func TestRoleHandler_Assignments(t *testing.T) {
+	t.Run("ユーザーにロールを割り当てられる", func(t *testing.T) {
+		roleUC := new(MockRoleUseCase)
+		roleUC.On("AssignToUser", mock.Anything, "role-1", "user-2").Return(nil)
+		router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/users/user-2/roles/role-1", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+		roleUC.AssertExpectations(t)
+	})
+
+	t.Run("存在しないグループは404が返される", func(t *testing.T) {
+		roleUC := new(MockRoleUseCase)
+		roleUC.On("AssignToGroup", mock.Anything, "role-1", "missing").Return(apperror.NewNotFoundError("Group", "missing", nil))
+		router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodPut, "/api/v1/groups/missing/roles/role-1", nil))
+
+		assert.Equal(t, http.StatusNotFound, w.Code)
+	})
+
+	t.Run("グループのロール一覧を取得できる", func(t *testing.T) {
+		roleUC := new(MockRoleUseCase)
+		roleUC.On("GetGroupRoles", mock.Anything, "group-1").Return([]dto.RoleResponse{{ID: "role-1", Name: "Publisher"}}, nil)
+		router := setupRoleRouter(roleUC, new(MockAuthorizationUseCase))
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/api/v1/groups/group-1/roles", nil))
+
+		require.Equal(t, http.StatusOK, w.Code)
+		var response schema.ListRolesResponse
+		require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		require.Len(t, response.Roles, 1)
+		assert.Equal(t, []string{}, response.Roles[0].Permissions)
+	})
+}
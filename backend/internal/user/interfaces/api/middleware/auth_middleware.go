@@ -0,0 +1,110 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"opscore/backend/internal/user/application/dto"
+	"opscore/backend/internal/user/interfaces/api/schema"
+	intererror "opscore/backend/internal/user/interfaces/error"
+
+	"github.com/gin-gonic/gin"
+)
+
+// SessionCookieName is the cookie that carries the session token for browser clients
+const SessionCookieName = "opscore_session"
+
+// Keys under which the authenticated caller is stored in the gin context
+const (
+	UserIDKey       = "user_id"
+	UserRoleKey     = "user_role"
+	UserGroupIDsKey = "user_group_ids"
+)
+
+// Authenticator resolves a session token to the user it belongs to
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (*dto.UserResponse, error)
+}
+
+// RequireAuthentication rejects requests that do not carry a valid session token.
+// On success the caller's ID, role and group IDs are stored in the gin context.
+func RequireAuthentication(auth Authenticator) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		token := TokenFromRequest(c)
+		if token == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, schema.ErrorResponse{Code: "UNAUTHORIZED", Message: "Authentication required"})
+			return
+		}
+
+		user, err := auth.Authenticate(c.Request.Context(), token)
+		if err != nil {
+			httpErr := intererror.MapToHTTPError(err, c.GetString("request_id"))
+			c.AbortWithStatusJSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+			return
+		}
+
+		c.Set(UserIDKey, user.ID)
+		c.Set(UserRoleKey, user.Role)
+		c.Set(UserGroupIDsKey, user.GroupIDs)
+
+		c.Next()
+	}
+}
+
+// TokenFromRequest extracts the session token from the Authorization header or the session cookie
+func TokenFromRequest(c *gin.Context) string {
+	if header := c.GetHeader("Authorization"); header != "" {
+		scheme, token, found := strings.Cut(header, " ")
+		if found && strings.EqualFold(scheme, "Bearer") {
+			return strings.TrimSpace(token)
+		}
+		return ""
+	}
+
+	if cookie, err := c.Cookie(SessionCookieName); err == nil {
+		return cookie
+	}
+
+	return ""
+}
+
+// CurrentUserID returns the ID of the authenticated caller, or an empty string
+func CurrentUserID(c *gin.Context) string {
+	return c.GetString(UserIDKey)
+}
+
+// CurrentUserRole returns the role of the authenticated caller, or an empty string
+func CurrentUserRole(c *gin.Context) string {
+	return c.GetString(UserRoleKey)
+}
+
+// CurrentUserGroupIDs returns the group IDs of the authenticated caller
+func CurrentUserGroupIDs(c *gin.Context) []string {
+	return c.GetStringSlice(UserGroupIDsKey)
+}
+
+// PermissionChecker decides whether a user holds a permission
+type PermissionChecker interface {
+	Authorize(ctx context.Context, userID string, permission string) error
+}
+
+// RequirePermission rejects requests whose authenticated caller does not hold the permission.
+// It must run after RequireAuthentication.
+func RequirePermission(checker PermissionChecker, permission string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userID := CurrentUserID(c)
+		if userID == "" {
+			c.AbortWithStatusJSON(http.StatusUnauthorized, schema.ErrorResponse{Code: "UNAUTHORIZED", Message: "Authentication required"})
+			return
+		}
+
+		if err := checker.Authorize(c.Request.Context(), userID, permission); err != nil {
+			httpErr := intererror.MapToHTTPError(err, c.GetString("request_id"))
+			c.AbortWithStatusJSON(httpErr.StatusCode, schema.ErrorResponse{Code: httpErr.Code, Message: httpErr.Message})
+			return
+		}
+
+		c.Next()
+	}
+}
@@ -0,0 +1,159 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"opscore/backend/internal/user/application/dto"
+	apperror "opscore/backend/internal/user/application/error"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubAuthenticator accepts a single token
+type stubAuthenticator struct {
+	token string
+	user  dto.UserResponse
+}
+
+func (s *stubAuthenticator) Authenticate(ctx context.Context, token string) (*dto.UserResponse, error) {
+	if token != s.token {
+		return nil, apperror.NewUnauthorizedError("invalid session token", nil)
+	}
+	return &s.user, nil
+}
+
+func setupRouter() *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	auth := &stubAuthenticator{
+		token: "valid-token",
+		user:  dto.UserResponse{ID: "user-1", Role: "admin", GroupIDs: []string{"group-1"}},
+	}
+
+	r := gin.New()
+	r.Use(RequireAuthentication(auth))
+	r.GET("/me", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{
+			"id":     CurrentUserID(c),
+			"role":   CurrentUserRole(c),
+			"groups": CurrentUserGroupIDs(c),
+		})
+	})
+	return r
+}
+
+func TestRequireAuthentication(t *testing.T) {
+	router := setupRouter()
+
+	t.Run("Bearerトークンで認証できる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer valid-token")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.JSONEq(t, `{"id":"user-1","role":"admin","groups":["group-1"]}`, w.Body.String())
+	})
+
+	t.Run("セッションCookieで認証できる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "valid-token"})
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusOK, w.Code)
+	})
+
+	t.Run("トークンがなければ401になる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+		assert.Contains(t, w.Body.String(), "UNAUTHORIZED")
+	})
+
+	t.Run("無効なトークンは401になる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Bearer invalid-token")
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+
+	t.Run("Bearer以外のスキームは401になる", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/me", nil)
+		req.Header.Set("Authorization", "Basic dXNlcjpwYXNz")
+		req.AddCookie(&http.Cookie{Name: SessionCookieName, Value: "valid-token"})
+		w := httptest.NewRecorder()
+
+		router.ServeHTTP(w, req)
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
+
+// stubPermissionChecker grants a fixed set of permissions to a single user
+type stubPermissionChecker struct {
+	userID      string
+	permissions []string
+}
+
+func (s *stubPermissionChecker) Authorize(ctx context.Context, userID string, permission string) error {
+	if userID == s.userID {
+		for _, p := range s.permissions {
+			if p == permission {
+				return nil
+			}
+		}
+	}
+	return apperror.NewForbiddenError("Permission", permission, userID)
+}
+
+func TestRequirePermission(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	checker := &stubPermissionChecker{userID: "user-1", permissions: []string{"document:publish"}}
+
+	newRouter := func(userID string, permission string) *gin.Engine {
+		r := gin.New()
+		r.Use(func(c *gin.Context) {
+			if userID != "" {
+				c.Set(UserIDKey, userID)
+			}
+			c.Next()
+		})
+		r.POST("/action", RequirePermission(checker, permission), func(c *gin.Context) {
+			c.Status(http.StatusNoContent)
+		})
+		return r
+	}
+
+	t.Run("権限を持つユーザーは許可される", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter("user-1", "document:publish").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/action", nil))
+
+		assert.Equal(t, http.StatusNoContent, w.Code)
+	})
+
+	t.Run("権限を持たないユーザーは403になる", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter("user-1", "user:admin").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/action", nil))
+
+		assert.Equal(t, http.StatusForbidden, w.Code)
+	})
+
+	t.Run("未認証のリクエストは401になる", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		newRouter("", "document:publish").ServeHTTP(w, httptest.NewRequest(http.MethodPost, "/action", nil))
+
+		assert.Equal(t, http.StatusUnauthorized, w.Code)
+	})
+}
@@ -0,0 +1,27 @@
+package schema
+
+import "time"
+
+// LoginRequest represents the API request body for logging in
+type LoginRequest struct {
+	Email    string `json:"email" binding:"required,email" example:"john.doe@example.com"`
+	Password string `json:"password" binding:"required" example:"correct horse battery staple"`
+}
+
+// LoginResponse represents the API response for a successful login
+type LoginResponse struct {
+	Token     string       `json:"token" example:"q3v8b1nM0x..."`
+	ExpiresAt time.Time    `json:"expiresAt" example:"2025-04-23T10:00:00Z"`
+	User      UserResponse `json:"user"`
+}
+
+// ChangePasswordRequest represents the API request body for changing one's own password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"currentPassword" binding:"required" example:"old password"`
+	NewPassword     string `json:"newPassword" binding:"required" example:"new password"`
+}
+
+// SetPasswordRequest represents the API request body for an administrator setting a user's password
+type SetPasswordRequest struct {
+	Password string `json:"password" binding:"required" example:"initial password"`
+}
@@ -122,3 +122,87 @@ func FromGroupListDTO(dtoList []dto.GroupResponse) []GroupResponse {
 	}
 	return schemas
 }
+
+// ToLoginDTO converts API schema to application DTO
+func ToLoginDTO(req LoginRequest) dto.LoginRequest {
+	return dto.LoginRequest{
+		Email:    req.Email,
+		Password: req.Password,
+	}
+}
+
+// ToChangePasswordDTO converts API schema to application DTO
+func ToChangePasswordDTO(req ChangePasswordRequest) dto.ChangePasswordRequest {
+	return dto.ChangePasswordRequest{
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	}
+}
+
+// ToSetPasswordDTO converts API schema to application DTO
+func ToSetPasswordDTO(req SetPasswordRequest) dto.SetPasswordRequest {
+	return dto.SetPasswordRequest{
+		Password: req.Password,
+	}
+}
+
+// FromLoginDTO converts application DTO to API schema
+func FromLoginDTO(result dto.LoginResponse) LoginResponse {
+	return LoginResponse{
+		Token:     result.Token,
+		ExpiresAt: result.ExpiresAt,
+		User:      FromUserDTO(result.User),
+	}
+}
+
+// ToCreateRoleDTO converts API schema to application DTO
+func ToCreateRoleDTO(req CreateRoleRequest) dto.CreateRoleRequest {
+	return dto.CreateRoleRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+}
+
+// ToUpdateRoleDTO converts API schema to application DTO
+func ToUpdateRoleDTO(req UpdateRoleRequest) dto.UpdateRoleRequest {
+	return dto.UpdateRoleRequest{
+		Name:        req.Name,
+		Description: req.Description,
+		Permissions: req.Permissions,
+	}
+}
+
+// FromRoleDTO converts application DTO to API schema
+func FromRoleDTO(dtoResp dto.RoleResponse) RoleResponse {
+	permissions := dtoResp.Permissions
+	if permissions == nil {
+		permissions = []string{}
+	}
+	return RoleResponse{
+		ID:          dtoResp.ID,
+		Name:        dtoResp.Name,
+		Description: dtoResp.Description,
+		Permissions: permissions,
+		CreatedAt:   dtoResp.CreatedAt,
+		UpdatedAt:   dtoResp.UpdatedAt,
+	}
+}
+
+// FromRoleListDTO converts application DTO list to API schema list
+func FromRoleListDTO(dtoList []dto.RoleResponse) []RoleResponse {
+	schemas := make([]RoleResponse, 0, len(dtoList))
+	for _, dtoResp := range dtoList {
+		schemas = append(schemas, FromRoleDTO(dtoResp))
+	}
+	return schemas
+}
+
+// FromPermissionListDTO converts the permission catalog to API schema
+func FromPermissionListDTO(dtoList []dto.PermissionResponse) []PermissionResponse {
+	schemas := make([]PermissionResponse, 0, len(dtoList))
+	for _, p := range dtoList {
+		schemas = append(schemas, PermissionResponse{Name: p.Name, Description: p.Description})
+	}
+	return schemas
+}
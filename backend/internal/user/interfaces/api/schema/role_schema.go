@@ -0,0 +1,48 @@
+package schema
+
+import "time"
+
+// CreateRoleRequest represents the API request body for creating a role
+type CreateRoleRequest struct {
+	Name        string   `json:"name" binding:"required" example:"Publisher"`
+	Description string   `json:"description" example:"Can publish documents"`
+	Permissions []string `json:"permissions" example:"document:publish,repository:manage"`
+}
+
+// UpdateRoleRequest represents the API request body for updating a role
+type UpdateRoleRequest struct {
+	Name        string   `json:"name" binding:"required" example:"Publisher"`
+	Description string   `json:"description" example:"Can publish documents"`
+	Permissions []string `json:"permissions" example:"document:publish,repository:manage"`
+}
+
+// RoleResponse represents the API response format for a role
+type RoleResponse struct {
+	ID          string    `json:"id" example:"550e8400-e29b-41d4-a716-446655440000"`
+	Name        string    `json:"name" example:"Publisher"`
+	Description string    `json:"description" example:"Can publish documents"`
+	Permissions []string  `json:"permissions" example:"document:publish,repository:manage"`
+	CreatedAt   time.Time `json:"createdAt" example:"2025-04-22T10:00:00Z"`
+	UpdatedAt   time.Time `json:"updatedAt" example:"2025-04-22T10:00:00Z"`
+}
+
+// ListRolesResponse represents the API response for listing roles
+type ListRolesResponse struct {
+	Roles []RoleResponse `json:"roles"`
+}
+
+// PermissionResponse represents an entry of the permission catalog
+type PermissionResponse struct {
+	Name        string `json:"name" example:"document:publish"`
+	Description string `json:"description" example:"Create documents and publish or roll back their versions"`
+}
+
+// ListPermissionsResponse represents the API response for the permission catalog
+type ListPermissionsResponse struct {
+	Permissions []PermissionResponse `json:"permissions"`
+}
+
+// EffectivePermissionsResponse represents the permissions held by the current user
+type EffectivePermissionsResponse struct {
+	Permissions []string `json:"permissions" example:"document:publish"`
+}
@@ -56,6 +56,23 @@ func NewViewHistory(
 	}, nil
 }
 
+// ReconstructViewHistory reconstructs a ViewHistory from persistence data.
+func ReconstructViewHistory(
+	id value_object.ViewHistoryID,
+	documentID documentVO.DocumentID,
+	userID userVO.UserID,
+	viewedAt time.Time,
+	viewDuration int,
+) ViewHistory {
+	return &viewHistory{
+		id:           id,
+		documentID:   documentID,
+		userID:       userID,
+		viewedAt:     viewedAt,
+		viewDuration: viewDuration,
+	}
+}
+
 // RecordViewHistory creates a new view history record with current time.
 func RecordViewHistory(
 	documentID documentVO.DocumentID,
@@ -0,0 +1,243 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	documentVO "opscore/backend/internal/document/domain/value_object"
+	userVO "opscore/backend/internal/user/domain/value_object"
+	"opscore/backend/internal/view_history/domain/entity"
+	"opscore/backend/internal/view_history/domain/repository"
+	"opscore/backend/internal/view_history/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ViewHistoryRepositoryImpl is a PostgreSQL implementation of the ViewHistoryRepository interface
+type ViewHistoryRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewViewHistoryRepositoryImpl creates a new ViewHistoryRepositoryImpl
+func NewViewHistoryRepositoryImpl(db *pgxpool.Pool) repository.ViewHistoryRepository {
+	return &ViewHistoryRepositoryImpl{db: db}
+}
+
+const selectViewHistoryColumns = `
+	SELECT id, document_id, user_id, viewed_at
+	FROM view_history
+`
+
+// Save records a view and updates the document's view_statistics row in the same transaction.
+// The counters are incremented with a single upsert so that concurrent replicas never lose updates.
+// A per document/user advisory lock makes the "first view by this user" check race free.
+func (r *ViewHistoryRepositoryImpl) Save(ctx context.Context, viewHistory entity.ViewHistory) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	documentID := viewHistory.DocumentID().String()
+	userID := viewHistory.UserID().String()
+
+	_, err = tx.Exec(ctx, `SELECT pg_advisory_xact_lock(hashtext($1));`, documentID+":"+userID)
+	if err != nil {
+		return fmt.Errorf("failed to acquire view lock: %w", err)
+	}
+
+	var firstView bool
+	err = tx.QueryRow(ctx,
+		`SELECT NOT EXISTS (SELECT 1 FROM view_history WHERE document_id = $1 AND user_id = $2);`,
+		documentID, userID,
+	).Scan(&firstView)
+	if err != nil {
+		return fmt.Errorf("failed to check previous views: %w", err)
+	}
+
+	_, err = tx.Exec(ctx,
+		`INSERT INTO view_history (id, document_id, user_id, viewed_at) VALUES ($1, $2, $3, $4);`,
+		viewHistory.ID().String(), documentID, userID, viewHistory.ViewedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save view history: %w", err)
+	}
+
+	uniqueIncrement := 0
+	if firstView {
+		uniqueIncrement = 1
+	}
+
+	statsQuery := `
+		INSERT INTO view_statistics (document_id, total_views, unique_users, last_viewed_at, updated_at)
+		VALUES ($1, 1, $2, $3, NOW())
+		ON CONFLICT (document_id) DO UPDATE SET
+			total_views = view_statistics.total_views + 1,
+			unique_users = view_statistics.unique_users + EXCLUDED.unique_users,
+			last_viewed_at = GREATEST(view_statistics.last_viewed_at, EXCLUDED.last_viewed_at),
+			updated_at = NOW();
+	`
+	_, err = tx.Exec(ctx, statsQuery, documentID, uniqueIncrement, viewHistory.ViewedAt())
+	if err != nil {
+		return fmt.Errorf("failed to update view statistics: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// FindByID retrieves a view history record by its ID
+func (r *ViewHistoryRepositoryImpl) FindByID(ctx context.Context, id value_object.ViewHistoryID) (entity.ViewHistory, error) {
+	row := r.db.QueryRow(ctx, selectViewHistoryColumns+` WHERE id = $1;`, id.String())
+
+	history, err := scanViewHistory(row)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find view history by ID: %w", err)
+	}
+
+	return history, nil
+}
+
+// FindByUserID retrieves view history records for a specific user, newest first
+func (r *ViewHistoryRepositoryImpl) FindByUserID(ctx context.Context, userID userVO.UserID, limit int, offset int) ([]entity.ViewHistory, int64, error) {
+	var total int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM view_history WHERE user_id = $1;`, userID.String()).Scan(&total)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to count view history: %w", err)
+	}
+
+	histories, err := r.findViewHistories(ctx,
+		selectViewHistoryColumns+` WHERE user_id = $1 ORDER BY viewed_at DESC LIMIT $2 OFFSET $3;`,
+		userID.String(), limitOrAll(limit), offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
+
+// FindByDocumentID retrieves view history records for a specific document, newest first
+func (r *ViewHistoryRepositoryImpl) FindByDocumentID(ctx context.Context, documentID documentVO.DocumentID, limit int, offset int) ([]entity.ViewHistory, int64, error) {
+	total, err := r.CountByDocumentID(ctx, documentID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	histories, err := r.findViewHistories(ctx,
+		selectViewHistoryColumns+` WHERE document_id = $1 ORDER BY viewed_at DESC LIMIT $2 OFFSET $3;`,
+		documentID.String(), limitOrAll(limit), offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return histories, total, nil
+}
+
+// FindByUserIDAndDocumentID retrieves view history records for a specific user and document
+func (r *ViewHistoryRepositoryImpl) FindByUserIDAndDocumentID(ctx context.Context, userID userVO.UserID, documentID documentVO.DocumentID) ([]entity.ViewHistory, error) {
+	return r.findViewHistories(ctx,
+		selectViewHistoryColumns+` WHERE user_id = $1 AND document_id = $2 ORDER BY viewed_at DESC;`,
+		userID.String(), documentID.String())
+}
+
+// FindRecentByUserID retrieves recent view history for a user within a time range
+func (r *ViewHistoryRepositoryImpl) FindRecentByUserID(ctx context.Context, userID userVO.UserID, since time.Time, limit int) ([]entity.ViewHistory, error) {
+	return r.findViewHistories(ctx,
+		selectViewHistoryColumns+` WHERE user_id = $1 AND viewed_at > $2 ORDER BY viewed_at DESC LIMIT $3;`,
+		userID.String(), since, limitOrAll(limit))
+}
+
+// CountByDocumentID counts the total number of views for a document
+func (r *ViewHistoryRepositoryImpl) CountByDocumentID(ctx context.Context, documentID documentVO.DocumentID) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM view_history WHERE document_id = $1;`, documentID.String()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count views: %w", err)
+	}
+	return count, nil
+}
+
+// CountUniqueViewersByDocumentID counts unique viewers for a document
+func (r *ViewHistoryRepositoryImpl) CountUniqueViewersByDocumentID(ctx context.Context, documentID documentVO.DocumentID) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(DISTINCT user_id) FROM view_history WHERE document_id = $1;`,
+		documentID.String(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unique viewers: %w", err)
+	}
+	return count, nil
+}
+
+// findViewHistories runs a view_history query and converts every row
+func (r *ViewHistoryRepositoryImpl) findViewHistories(ctx context.Context, query string, args ...interface{}) ([]entity.ViewHistory, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query view history: %w", err)
+	}
+	defer rows.Close()
+
+	histories := []entity.ViewHistory{}
+	for rows.Next() {
+		history, err := scanViewHistory(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan view history row: %w", err)
+		}
+		histories = append(histories, history)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over view history rows: %w", err)
+	}
+
+	return histories, nil
+}
+
+// scanViewHistory scans a row selected with selectViewHistoryColumns into a domain entity.
+// user_id is NULL when the viewer has since been deleted; an empty UserID is used in that case.
+func scanViewHistory(row pgx.Row) (entity.ViewHistory, error) {
+	var id, documentID string
+	var userID *string
+	var viewedAt time.Time
+
+	if err := row.Scan(&id, &documentID, &userID, &viewedAt); err != nil {
+		return nil, err
+	}
+
+	historyID, err := value_object.NewViewHistoryID(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid view history ID: %w", err)
+	}
+	docID, err := documentVO.NewDocumentID(documentID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+
+	var uid userVO.UserID
+	if userID != nil {
+		uid, err = userVO.NewUserID(*userID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid user ID: %w", err)
+		}
+	}
+
+	return entity.ReconstructViewHistory(historyID, docID, uid, viewedAt, 0), nil
+}
+
+// limitOrAll maps a non-positive limit to NULL, which PostgreSQL treats as LIMIT ALL
+func limitOrAll(limit int) *int {
+	if limit <= 0 {
+		return nil
+	}
+	return &limit
+}
@@ -0,0 +1,160 @@
+package persistence
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	documentVO "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/shared/infrastructure/testdb"
+	userVO "opscore/backend/internal/user/domain/value_object"
+	"opscore/backend/internal/view_history/domain/entity"
+	"opscore/backend/internal/view_history/domain/value_object"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedDocument inserts a repository and a document and returns the document ID
+func seedDocument(t *testing.T, db *pgxpool.Pool) documentVO.DocumentID {
+	t.Helper()
+	ctx := context.Background()
+
+	repoID := uuid.NewString()
+	docID := uuid.NewString()
+
+	_, err := db.Exec(ctx, `INSERT INTO repositories (id, name, url) VALUES ($1, 'repo', $2);`, repoID, "https://github.com/example/"+repoID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO documents (id, repository_id, owner, access_scope) VALUES ($1, $2, 'owner', 'public');`, docID, repoID)
+	require.NoError(t, err)
+
+	id, err := documentVO.NewDocumentID(docID)
+	require.NoError(t, err)
+	return id
+}
+
+// seedUser inserts a user and returns its ID
+func seedUser(t *testing.T, db *pgxpool.Pool) userVO.UserID {
+	t.Helper()
+
+	id := uuid.NewString()
+	_, err := db.Exec(context.Background(), `INSERT INTO users (id, name, email) VALUES ($1, 'Viewer', $2);`, id, id+"@example.com")
+	require.NoError(t, err)
+
+	userID, err := userVO.NewUserID(id)
+	require.NoError(t, err)
+	return userID
+}
+
+func TestViewHistoryRepositoryImpl_Integration(t *testing.T) {
+	db := testdb.New(t)
+	repo := NewViewHistoryRepositoryImpl(db)
+	ctx := context.Background()
+
+	readStats := func(t *testing.T, docID documentVO.DocumentID) (int64, int64, time.Time) {
+		var total, unique int64
+		var lastViewedAt time.Time
+		err := db.QueryRow(ctx,
+			`SELECT total_views, unique_users, last_viewed_at FROM view_statistics WHERE document_id = $1;`,
+			docID.String()).Scan(&total, &unique, &lastViewedAt)
+		require.NoError(t, err)
+		return total, unique, lastViewedAt
+	}
+
+	t.Run("閲覧を記録すると統計が更新される", func(t *testing.T) {
+		docID := seedDocument(t, db)
+		alice := seedUser(t, db)
+		bob := seedUser(t, db)
+
+		first := time.Now().Add(-time.Hour).Truncate(time.Microsecond)
+		latest := time.Now().Truncate(time.Microsecond)
+
+		views := []struct {
+			user     userVO.UserID
+			viewedAt time.Time
+		}{
+			{alice, first},
+			{alice, latest},
+			{bob, first},
+		}
+		for _, v := range views {
+			h, err := entity.NewViewHistory(value_object.GenerateViewHistoryID(), docID, v.user, v.viewedAt)
+			require.NoError(t, err)
+			require.NoError(t, repo.Save(ctx, h))
+		}
+
+		total, unique, lastViewedAt := readStats(t, docID)
+		assert.Equal(t, int64(3), total)
+		assert.Equal(t, int64(2), unique)
+		assert.True(t, lastViewedAt.Equal(latest))
+
+		count, err := repo.CountByDocumentID(ctx, docID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), count)
+
+		viewers, err := repo.CountUniqueViewersByDocumentID(ctx, docID)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), viewers)
+
+		histories, totalCount, err := repo.FindByDocumentID(ctx, docID, 2, 0)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), totalCount)
+		require.Len(t, histories, 2)
+		assert.True(t, histories[0].ViewedAt().Equal(latest))
+
+		byUser, err := repo.FindByUserIDAndDocumentID(ctx, alice, docID)
+		require.NoError(t, err)
+		assert.Len(t, byUser, 2)
+
+		recent, err := repo.FindRecentByUserID(ctx, alice, latest.Add(-time.Minute), 10)
+		require.NoError(t, err)
+		assert.Len(t, recent, 1)
+	})
+
+	t.Run("並行した閲覧でもカウンターが失われない", func(t *testing.T) {
+		docID := seedDocument(t, db)
+		users := []userVO.UserID{seedUser(t, db), seedUser(t, db), seedUser(t, db)}
+
+		const viewsPerUser = 10
+		var wg sync.WaitGroup
+		for _, u := range users {
+			for i := 0; i < viewsPerUser; i++ {
+				wg.Add(1)
+				go func(u userVO.UserID) {
+					defer wg.Done()
+					h, err := entity.RecordViewHistory(docID, u)
+					if assert.NoError(t, err) {
+						assert.NoError(t, repo.Save(ctx, h))
+					}
+				}(u)
+			}
+		}
+		wg.Wait()
+
+		total, unique, _ := readStats(t, docID)
+		assert.Equal(t, int64(len(users)*viewsPerUser), total)
+		assert.Equal(t, int64(len(users)), unique)
+	})
+
+	t.Run("IDで取得できる", func(t *testing.T) {
+		docID := seedDocument(t, db)
+		user := seedUser(t, db)
+
+		h, err := entity.RecordViewHistory(docID, user)
+		require.NoError(t, err)
+		require.NoError(t, repo.Save(ctx, h))
+
+		found, err := repo.FindByID(ctx, h.ID())
+		require.NoError(t, err)
+		require.NotNil(t, found)
+		assert.True(t, found.DocumentID().Equals(docID))
+		assert.True(t, found.UserID().Equals(user))
+
+		missing, err := repo.FindByID(ctx, value_object.GenerateViewHistoryID())
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+}
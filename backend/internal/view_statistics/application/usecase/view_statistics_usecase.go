@@ -52,6 +52,13 @@ func (uc *viewStatisticsUseCase) GetDocumentStatistics(ctx context.Context, docu
 		return nil, fmt.Errorf("failed to retrieve document statistics: %w", err)
 	}
 
+	// A document that has never been viewed has no statistics yet
+	if stats == nil {
+		return &dto.DocumentStatisticsResponse{
+			DocumentID: docID.String(),
+		}, nil
+	}
+
 	// Convert to response
 	return &dto.DocumentStatisticsResponse{
 		DocumentID:          stats.DocumentID().String(),
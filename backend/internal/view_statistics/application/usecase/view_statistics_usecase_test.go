@@ -97,6 +97,23 @@ func TestViewStatisticsUseCase_GetDocumentStatistics(t *testing.T) {
 		assert.Contains(t, err.Error(), "invalid document ID")
 	})
 
+	t.Run("閲覧されていないドキュメントは0件の統計を返す", func(t *testing.T) {
+		mockRepo := new(MockViewStatisticsRepository)
+		docID := documentVO.GenerateDocumentID()
+
+		mockRepo.On("FindByDocumentID", mock.Anything, docID).Return(nil, nil)
+
+		uc := NewViewStatisticsUseCase(mockRepo)
+		result, err := uc.GetDocumentStatistics(context.Background(), docID.String())
+
+		assert.NoError(t, err)
+		assert.NotNil(t, result)
+		assert.Equal(t, docID.String(), result.DocumentID)
+		assert.Equal(t, int64(0), result.TotalViews)
+
+		mockRepo.AssertExpectations(t)
+	})
+
 	t.Run("取得失敗でエラーになる", func(t *testing.T) {
 		mockRepo := new(MockViewStatisticsRepository)
 		docID := documentVO.GenerateDocumentID()
@@ -62,6 +62,29 @@ func NewViewStatistics(
 	}, nil
 }
 
+// ReconstructViewStatistics reconstructs a ViewStatistics from persistence data.
+func ReconstructViewStatistics(
+	id value_object.ViewStatisticsID,
+	documentID documentVO.DocumentID,
+	totalViews int64,
+	uniqueViewers int64,
+	lastViewedAt time.Time,
+	averageViewDuration int,
+	createdAt time.Time,
+	updatedAt time.Time,
+) ViewStatistics {
+	return &viewStatistics{
+		id:                  id,
+		documentID:          documentID,
+		totalViews:          totalViews,
+		uniqueViewers:       uniqueViewers,
+		lastViewedAt:        lastViewedAt,
+		averageViewDuration: averageViewDuration,
+		createdAt:           createdAt,
+		updatedAt:           updatedAt,
+	}
+}
+
 // ID returns the view statistics ID.
 func (v *viewStatistics) ID() value_object.ViewStatisticsID {
 	return v.id
@@ -0,0 +1,192 @@
+package persistence
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	documentVO "opscore/backend/internal/document/domain/value_object"
+	userVO "opscore/backend/internal/user/domain/value_object"
+	"opscore/backend/internal/view_statistics/domain/entity"
+	"opscore/backend/internal/view_statistics/domain/repository"
+	"opscore/backend/internal/view_statistics/domain/value_object"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// ViewStatisticsRepositoryImpl is a PostgreSQL implementation of the ViewStatisticsRepository interface.
+// Counters are maintained by the view history repository when a view is recorded;
+// this repository reads them and allows them to be overwritten, e.g. when rebuilding.
+type ViewStatisticsRepositoryImpl struct {
+	db *pgxpool.Pool
+}
+
+// NewViewStatisticsRepositoryImpl creates a new ViewStatisticsRepositoryImpl
+func NewViewStatisticsRepositoryImpl(db *pgxpool.Pool) repository.ViewStatisticsRepository {
+	return &ViewStatisticsRepositoryImpl{db: db}
+}
+
+// Save upserts the statistics row of a document with the values held by the entity
+func (r *ViewStatisticsRepositoryImpl) Save(ctx context.Context, stats entity.ViewStatistics) error {
+	var lastViewedAt *time.Time
+	if !stats.LastViewedAt().IsZero() {
+		t := stats.LastViewedAt()
+		lastViewedAt = &t
+	}
+
+	query := `
+		INSERT INTO view_statistics (document_id, total_views, unique_users, last_viewed_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (document_id) DO UPDATE SET
+			total_views = EXCLUDED.total_views,
+			unique_users = EXCLUDED.unique_users,
+			last_viewed_at = EXCLUDED.last_viewed_at,
+			updated_at = EXCLUDED.updated_at;
+	`
+	_, err := r.db.Exec(ctx, query,
+		stats.DocumentID().String(),
+		stats.TotalViews(),
+		stats.UniqueViewers(),
+		lastViewedAt,
+		stats.UpdatedAt(),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to save view statistics: %w", err)
+	}
+
+	return nil
+}
+
+// FindByDocumentID retrieves view statistics for a specific document
+func (r *ViewStatisticsRepositoryImpl) FindByDocumentID(ctx context.Context, documentID documentVO.DocumentID) (entity.ViewStatistics, error) {
+	query := `
+		SELECT document_id, total_views, unique_users, last_viewed_at, updated_at
+		FROM view_statistics
+		WHERE document_id = $1;
+	`
+
+	var docID string
+	var totalViews, uniqueUsers int64
+	var lastViewedAt *time.Time
+	var updatedAt time.Time
+
+	err := r.db.QueryRow(ctx, query, documentID.String()).Scan(&docID, &totalViews, &uniqueUsers, &lastViewedAt, &updatedAt)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find view statistics: %w", err)
+	}
+
+	// view_statistics is keyed by document, so the document ID doubles as the statistics ID
+	id, err := value_object.NewViewStatisticsID(docID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid view statistics ID: %w", err)
+	}
+	dID, err := documentVO.NewDocumentID(docID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid document ID: %w", err)
+	}
+
+	var lastViewed time.Time
+	if lastViewedAt != nil {
+		lastViewed = *lastViewedAt
+	}
+
+	return entity.ReconstructViewStatistics(id, dID, totalViews, uniqueUsers, lastViewed, 0, updatedAt, updatedAt), nil
+}
+
+// FindPopularDocuments retrieves the documents with the most views among those viewed since the given time
+func (r *ViewStatisticsRepositoryImpl) FindPopularDocuments(ctx context.Context, limit int, since time.Time) ([]repository.PopularDocument, error) {
+	query := `
+		SELECT document_id, total_views, unique_users, last_viewed_at
+		FROM view_statistics
+		WHERE last_viewed_at > $1
+		ORDER BY total_views DESC, last_viewed_at DESC
+		LIMIT $2;
+	`
+	return r.findPopularDocuments(ctx, query, since, limitOrAll(limit))
+}
+
+// FindRecentlyViewedDocuments retrieves recently viewed documents
+func (r *ViewStatisticsRepositoryImpl) FindRecentlyViewedDocuments(ctx context.Context, limit int) ([]repository.PopularDocument, error) {
+	query := `
+		SELECT document_id, total_views, unique_users, last_viewed_at
+		FROM view_statistics
+		WHERE last_viewed_at IS NOT NULL
+		ORDER BY last_viewed_at DESC
+		LIMIT $1;
+	`
+	return r.findPopularDocuments(ctx, query, limitOrAll(limit))
+}
+
+// GetUserViewCount returns the total number of views by a user
+func (r *ViewStatisticsRepositoryImpl) GetUserViewCount(ctx context.Context, userID userVO.UserID) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx, `SELECT COUNT(*) FROM view_history WHERE user_id = $1;`, userID.String()).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user views: %w", err)
+	}
+	return count, nil
+}
+
+// GetUserUniqueDocumentCount returns the number of unique documents viewed by a user
+func (r *ViewStatisticsRepositoryImpl) GetUserUniqueDocumentCount(ctx context.Context, userID userVO.UserID) (int64, error) {
+	var count int64
+	err := r.db.QueryRow(ctx,
+		`SELECT COUNT(DISTINCT document_id) FROM view_history WHERE user_id = $1;`,
+		userID.String(),
+	).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count user unique documents: %w", err)
+	}
+	return count, nil
+}
+
+// findPopularDocuments runs a view_statistics ranking query
+func (r *ViewStatisticsRepositoryImpl) findPopularDocuments(ctx context.Context, query string, args ...interface{}) ([]repository.PopularDocument, error) {
+	rows, err := r.db.Query(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query view statistics: %w", err)
+	}
+	defer rows.Close()
+
+	results := []repository.PopularDocument{}
+	for rows.Next() {
+		var docID string
+		var totalViews, uniqueUsers int64
+		var lastViewedAt time.Time
+
+		if err := rows.Scan(&docID, &totalViews, &uniqueUsers, &lastViewedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan view statistics row: %w", err)
+		}
+
+		documentID, err := documentVO.NewDocumentID(docID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid document ID: %w", err)
+		}
+
+		results = append(results, repository.PopularDocument{
+			DocumentID:    documentID,
+			TotalViews:    totalViews,
+			UniqueViewers: uniqueUsers,
+			LastViewedAt:  lastViewedAt,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating over view statistics rows: %w", err)
+	}
+
+	return results, nil
+}
+
+// limitOrAll maps a non-positive limit to NULL, which PostgreSQL treats as LIMIT ALL
+func limitOrAll(limit int) *int {
+	if limit <= 0 {
+		return nil
+	}
+	return &limit
+}
@@ -0,0 +1,112 @@
+package persistence
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	documentVO "opscore/backend/internal/document/domain/value_object"
+	"opscore/backend/internal/shared/infrastructure/testdb"
+	userVO "opscore/backend/internal/user/domain/value_object"
+	"opscore/backend/internal/view_statistics/domain/entity"
+	"opscore/backend/internal/view_statistics/domain/value_object"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedDocument inserts a repository and a document and returns the document ID
+func seedDocument(t *testing.T, db *pgxpool.Pool) documentVO.DocumentID {
+	t.Helper()
+	ctx := context.Background()
+
+	repoID := uuid.NewString()
+	docID := uuid.NewString()
+
+	_, err := db.Exec(ctx, `INSERT INTO repositories (id, name, url) VALUES ($1, 'repo', $2);`, repoID, "https://github.com/example/"+repoID)
+	require.NoError(t, err)
+	_, err = db.Exec(ctx, `INSERT INTO documents (id, repository_id, owner, access_scope) VALUES ($1, $2, 'owner', 'public');`, docID, repoID)
+	require.NoError(t, err)
+
+	id, err := documentVO.NewDocumentID(docID)
+	require.NoError(t, err)
+	return id
+}
+
+// saveStats stores statistics for a document with the given counters
+func saveStats(t *testing.T, repo interface {
+	Save(context.Context, entity.ViewStatistics) error
+}, docID documentVO.DocumentID, totalViews int64, lastViewedAt time.Time) {
+	t.Helper()
+
+	id, err := value_object.NewViewStatisticsID(docID.String())
+	require.NoError(t, err)
+	stats := entity.ReconstructViewStatistics(id, docID, totalViews, 1, lastViewedAt, 0, time.Now(), time.Now())
+	require.NoError(t, repo.Save(context.Background(), stats))
+}
+
+func TestViewStatisticsRepositoryImpl_Integration(t *testing.T) {
+	db := testdb.New(t)
+	repo := NewViewStatisticsRepositoryImpl(db)
+	ctx := context.Background()
+
+	now := time.Now().Truncate(time.Microsecond)
+	popular := seedDocument(t, db)
+	recent := seedDocument(t, db)
+	stale := seedDocument(t, db)
+
+	saveStats(t, repo, popular, 100, now.Add(-time.Hour))
+	saveStats(t, repo, recent, 5, now)
+	saveStats(t, repo, stale, 500, now.AddDate(0, 0, -60))
+
+	t.Run("ドキュメントの統計を取得できる", func(t *testing.T) {
+		stats, err := repo.FindByDocumentID(ctx, popular)
+		require.NoError(t, err)
+		require.NotNil(t, stats)
+		assert.Equal(t, int64(100), stats.TotalViews())
+		assert.Equal(t, int64(1), stats.UniqueViewers())
+
+		missing, err := repo.FindByDocumentID(ctx, documentVO.GenerateDocumentID())
+		require.NoError(t, err)
+		assert.Nil(t, missing)
+	})
+
+	t.Run("期間内の人気ドキュメントを閲覧数順に取得できる", func(t *testing.T) {
+		docs, err := repo.FindPopularDocuments(ctx, 10, now.AddDate(0, 0, -30))
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.True(t, docs[0].DocumentID.Equals(popular))
+		assert.True(t, docs[1].DocumentID.Equals(recent))
+	})
+
+	t.Run("最近閲覧されたドキュメントを取得できる", func(t *testing.T) {
+		docs, err := repo.FindRecentlyViewedDocuments(ctx, 2)
+		require.NoError(t, err)
+		require.Len(t, docs, 2)
+		assert.True(t, docs[0].DocumentID.Equals(recent))
+		assert.True(t, docs[1].DocumentID.Equals(popular))
+	})
+
+	t.Run("ユーザーの閲覧数を集計できる", func(t *testing.T) {
+		userID := uuid.NewString()
+		_, err := db.Exec(ctx, `INSERT INTO users (id, name, email) VALUES ($1, 'Viewer', $2);`, userID, userID+"@example.com")
+		require.NoError(t, err)
+		for _, docID := range []documentVO.DocumentID{popular, popular, recent} {
+			_, err := db.Exec(ctx, `INSERT INTO view_history (document_id, user_id) VALUES ($1, $2);`, docID.String(), userID)
+			require.NoError(t, err)
+		}
+
+		uid, err := userVO.NewUserID(userID)
+		require.NoError(t, err)
+
+		views, err := repo.GetUserViewCount(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, int64(3), views)
+
+		docs, err := repo.GetUserUniqueDocumentCount(ctx, uid)
+		require.NoError(t, err)
+		assert.Equal(t, int64(2), docs)
+	})
+}
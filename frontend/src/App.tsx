@@ -1,5 +1,5 @@
 import { useState, useEffect } from "react";
-import { Routes, Route, Link } from "react-router-dom";
+import { Routes, Route, Link, useLocation, useNavigate } from "react-router-dom";
 import BlogPage from "./pages/BlogPage";
 import RepositoriesPage from "./pages/RepositoriesPage";
 import RepositoryDetailPage from "./pages/RepositoryDetailPage";
@@ -10,6 +10,9 @@ import DocumentVersionHistoryPage from "./pages/DocumentVersionHistoryPage";
 import ExecutionRecordPage from "./pages/ExecutionRecordPage";
 import GroupListPage from "./pages/GroupListPage";
 import GroupDetailPage from "./pages/GroupDetailPage";
+import LoginPage from "./pages/LoginPage";
+import { authFetch, getSessionToken } from "./api/client";
+import { logout } from "./api/authApi";
 
 // Define a simple Home component for the root path
 function HomePage() {
@@ -21,7 +24,7 @@ function HomePage() {
 
     console.log(`Fetching data from: ${apiUrl}`);
 
-    fetch(apiUrl)
+    authFetch(apiUrl)
       .then((response) => {
         if (!response.ok) {
           throw new Error(`HTTP error! status: ${response.status}`);
@@ -79,6 +82,36 @@ function HomePage() {
   );
 }
 
+// Log out control shown in the navigation bar while a session token is stored
+function LogoutButton() {
+  const navigate = useNavigate();
+  // Re-render on navigation so the button follows logins and logouts
+  useLocation();
+
+  if (!getSessionToken()) {
+    return null;
+  }
+
+  const handleLogout = async () => {
+    try {
+      await logout();
+    } catch (error) {
+      console.error("Error logging out:", error);
+    }
+    navigate("/login");
+  };
+
+  return (
+    <button
+      type="button"
+      onClick={handleLogout}
+      className="text-gray-700 dark:text-gray-300 hover:text-blue-600 dark:hover:text-blue-400 px-3 py-2 rounded-md text-sm font-medium transition duration-150 ease-in-out"
+    >
+      Log out
+    </button>
+  );
+}
+
 function App() {
   return (
     // Apply base layout and background colors using Tailwind
@@ -128,6 +161,9 @@ function App() {
                   Groups
                 </Link>
               </li>
+              <li>
+                <LogoutButton />
+              </li>
             </ul>
           </div>
         </div>
@@ -136,6 +172,7 @@ function App() {
       {/* Page Content Area */}
       <main className="max-w-5xl mx-auto p-4">
         <Routes>
+          <Route path="/login" element={<LoginPage />} />
           <Route path="/" element={<HomePage />} />
           <Route path="/repositories" element={<RepositoriesPage />} />
           <Route
@@ -0,0 +1,70 @@
+/**
+ * Authentication API utilities
+ */
+
+import {
+  LOGIN_ENDPOINT,
+  authFetch,
+  get,
+  getApiBaseUrl,
+  post,
+  setSessionToken,
+} from "./client";
+
+/** User returned by the authentication endpoints */
+export interface AuthUser {
+  id: string;
+  name: string;
+  email: string;
+  role: string;
+  groupIds: string[];
+  createdAt: string;
+  updatedAt: string;
+}
+
+/** Login request */
+export interface LoginRequest {
+  email: string;
+  password: string;
+}
+
+/** Login response */
+export interface LoginResponse {
+  token: string;
+  expiresAt: string;
+  user: AuthUser;
+}
+
+/**
+ * Log in with email and password and store the session token
+ */
+export async function login(
+  req: LoginRequest,
+  signal?: AbortSignal
+): Promise<AuthUser> {
+  const response = await post<LoginResponse>(LOGIN_ENDPOINT, req, signal);
+  setSessionToken(response.token);
+  return response.user;
+}
+
+/**
+ * Log out and discard the session token
+ */
+export async function logout(signal?: AbortSignal): Promise<void> {
+  try {
+    // The endpoint answers 204 No Content, which apiRequest cannot parse
+    await authFetch(`${getApiBaseUrl()}/auth/logout`, {
+      method: "POST",
+      signal,
+    });
+  } finally {
+    setSessionToken(null);
+  }
+}
+
+/**
+ * Get the logged in user
+ */
+export async function getCurrentUser(signal?: AbortSignal): Promise<AuthUser> {
+  return get<AuthUser>("/auth/me", signal);
+}
@@ -5,11 +5,72 @@
 import type { ApiResponse, ApiError, RequestConfig } from "../types/api";
 
 /** Base API URL */
-const getApiBaseUrl = (): string => {
+export const getApiBaseUrl = (): string => {
   const apiHost = import.meta.env.VITE_API_HOST;
-  return apiHost ? `http://${apiHost}/api/v1` : "/api";
+  return apiHost ? `http://${apiHost}/api/v1` : "/api/v1";
 };
 
+/** localStorage key of the session token returned by POST /auth/login */
+const SESSION_TOKEN_KEY = "opscore_session";
+
+/** Returns the stored session token, or null when not logged in */
+export function getSessionToken(): string | null {
+  try {
+    return window.localStorage.getItem(SESSION_TOKEN_KEY);
+  } catch {
+    return null;
+  }
+}
+
+/** Stores the session token, or removes it when null */
+export function setSessionToken(token: string | null): void {
+  try {
+    if (token) {
+      window.localStorage.setItem(SESSION_TOKEN_KEY, token);
+    } else {
+      window.localStorage.removeItem(SESSION_TOKEN_KEY);
+    }
+  } catch (error) {
+    console.warn("Error storing session token:", error);
+  }
+}
+
+/** Path of the login page that unauthenticated users are sent to */
+export const LOGIN_PATH = "/login";
+
+/** API endpoint that issues session tokens */
+export const LOGIN_ENDPOINT = "/auth/login";
+
+/**
+ * fetch that sends the session token as a Bearer token. The backend also sets a session cookie,
+ * but the token works when the API is served from another origin. On 401 the stored token is
+ * discarded and the user is sent to the login page.
+ */
+export async function authFetch(
+  input: string,
+  init: RequestInit = {}
+): Promise<Response> {
+  const headers = new Headers(init.headers);
+  const token = getSessionToken();
+  if (token && !headers.has("Authorization")) {
+    headers.set("Authorization", `Bearer ${token}`);
+  }
+
+  const response = await fetch(input, { ...init, headers });
+
+  // A failed login is reported by the login page itself
+  if (response.status === 401 && !input.endsWith(LOGIN_ENDPOINT)) {
+    setSessionToken(null);
+    if (window.location.pathname !== LOGIN_PATH) {
+      const redirect = encodeURIComponent(
+        window.location.pathname + window.location.search
+      );
+      window.location.assign(`${LOGIN_PATH}?redirect=${redirect}`);
+    }
+  }
+  return response;
+}
+
 /** Custom error class for API errors */
 export class ApiRequestError extends Error {
   constructor(
@@ -56,7 +117,7 @@ export async function apiRequest<T>(
     requestOptions.body = JSON.stringify(body);
   }
 
-  const response = await fetch(url, requestOptions);
+  const response = await authFetch(url, requestOptions);
 
   const data = await response.json();
 
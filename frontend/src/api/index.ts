@@ -9,3 +9,4 @@ export * from "./client";
 export * from "./executionRecordApi";
 export * from "./groupApi";
 export * from "./userApi";
+export * from "./authApi";
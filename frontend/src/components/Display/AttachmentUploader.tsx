@@ -3,6 +3,7 @@
  */
 
 import { useState, useRef, type ChangeEvent } from "react";
+import { authFetch } from "../../api/client";
 
 export interface AttachmentUploaderProps {
   /** Execution record ID */
@@ -62,7 +63,7 @@ export function AttachmentUploader({
       formData.append("file", file);
       formData.append("execution_step_id", executionStepId);
 
-      const response = await fetch(
+      const response = await authFetch(
         `/api/v1/execution-records/${executionRecordId}/attachments`,
         {
           method: "POST",
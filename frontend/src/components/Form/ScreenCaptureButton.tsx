@@ -4,6 +4,7 @@
 
 import { useState } from "react";
 import { useScreenCapture } from "../../hooks/useScreenCapture";
+import { authFetch } from "../../api/client";
 
 export interface ScreenCaptureButtonProps {
   /** Execution record ID */
@@ -75,7 +76,7 @@ export function ScreenCaptureButton({
       formData.append("file", blob, filename);
       formData.append("execution_step_id", executionStepId);
 
-      const response = await fetch(
+      const response = await authFetch(
         `/api/v1/execution-records/${executionRecordId}/attachments`,
         {
           method: "POST",
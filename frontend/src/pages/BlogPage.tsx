@@ -1,6 +1,7 @@
 import { useState, useEffect } from "react";
 import { useSearchParams, Link } from "react-router-dom";
 import ReactMarkdown from "react-markdown";
+import { authFetch } from "../api/client";
 
 interface Metadata {
   [key: string]: any;
@@ -83,7 +84,7 @@ function BlogPage() {
     setError(null);
 
     try {
-      const response = await fetch(`${apiUrl}/repositories/${repoId}/markdown`);
+      const response = await authFetch(`${apiUrl}/repositories/${repoId}/markdown`);
       if (!response.ok) {
         throw new Error(`HTTP error! status: ${response.status}`);
       }
@@ -2,6 +2,7 @@ import { useState, useEffect } from "react";
 import { useParams, Link } from "react-router-dom";
 import ReactMarkdown from "react-markdown";
 import { Document, VariableDefinition } from "../types/domain";
+import { authFetch } from "../api/client";
 
 function DocumentDetailPage() {
   const { docId } = useParams<{ docId: string }>();
@@ -52,7 +53,7 @@ function DocumentDetailPage() {
     setError(null);
 
     try {
-      const response = await fetch(`${apiUrl}/documents/${docId}`);
+      const response = await authFetch(`${apiUrl}/documents/${docId}`);
       if (!response.ok) {
         if (response.status === 404) {
           setError("Document not found");
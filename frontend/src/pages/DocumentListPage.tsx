@@ -1,6 +1,7 @@
 import { useState, useEffect } from "react";
 import { Link } from "react-router-dom";
 import { DocumentListItem } from "../types/domain";
+import { authFetch } from "../api/client";
 
 function DocumentListPage() {
   const [documents, setDocuments] = useState<DocumentListItem[]>([]);
@@ -23,7 +24,7 @@ function DocumentListPage() {
     setError(null);
 
     try {
-      const response = await fetch(`${apiUrl}/documents`);
+      const response = await authFetch(`${apiUrl}/documents`);
       if (!response.ok) {
         throw new Error(`HTTP error! status: ${response.status}`);
       }
@@ -1,6 +1,7 @@
 import { useState, useEffect } from "react";
 import { useParams, Link } from "react-router-dom";
 import { DocumentVersion } from "../types/domain";
+import { authFetch } from "../api/client";
 
 interface VersionHistoryResponse {
   document_id: string;
@@ -33,7 +34,7 @@ function DocumentVersionHistoryPage() {
     setError(null);
 
     try {
-      const response = await fetch(`${apiUrl}/documents/${docId}/versions`);
+      const response = await authFetch(`${apiUrl}/documents/${docId}/versions`);
       if (!response.ok) {
         if (response.status === 404) {
           setError("Document not found");
@@ -65,7 +66,7 @@ function DocumentVersionHistoryPage() {
     setActionMessage(null);
 
     try {
-      const response = await fetch(
+      const response = await authFetch(
         `${apiUrl}/documents/${docId}/versions/${versionNumber}/rollback`,
         { method: "POST" }
       );
@@ -106,7 +107,7 @@ function DocumentVersionHistoryPage() {
     setActionMessage(null);
 
     try {
-      const response = await fetch(
+      const response = await authFetch(
         `${apiUrl}/documents/${docId}/versions/${versionNumber}/publish`,
         { method: "POST" }
       );
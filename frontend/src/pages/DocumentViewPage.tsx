@@ -5,6 +5,7 @@ import { ThreePaneLayout } from "../components/Layout/ThreePaneLayout";
 import { VariableForm } from "../components/Form/VariableForm";
 import { Document, VariableDefinition } from "../types/domain";
 import { substituteVariables } from "../utils/variableSubstitution";
+import { authFetch } from "../api/client";
 
 function DocumentViewPage() {
   const { docId } = useParams<{ docId: string }>();
@@ -28,7 +29,7 @@ function DocumentViewPage() {
       setError(null);
 
       try {
-        const response = await fetch(`${apiUrl}/documents/${docId}`);
+        const response = await authFetch(`${apiUrl}/documents/${docId}`);
         if (!response.ok) {
           if (response.status === 404) {
             setError("Document not found");
@@ -88,7 +89,7 @@ function DocumentViewPage() {
         value,
       }));
 
-      const response = await fetch(
+      const response = await authFetch(
         `${apiUrl}/documents/${docId}/validate-variables`,
         {
           method: "POST",
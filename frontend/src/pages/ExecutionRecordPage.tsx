@@ -20,6 +20,7 @@ import {
   completeExecutionRecord,
   failExecutionRecord,
 } from "../api";
+import { authFetch } from "../api/client";
 
 function ExecutionRecordPage() {
   const { docId, recordId } = useParams<{
@@ -52,7 +53,7 @@ function ExecutionRecordPage() {
       setError(null);
 
       try {
-        const response = await fetch(`${apiUrl}/documents/${docId}`);
+        const response = await authFetch(`${apiUrl}/documents/${docId}`);
         if (!response.ok) {
           if (response.status === 404) {
             setError("Document not found");
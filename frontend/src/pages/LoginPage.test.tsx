@@ -0,0 +1,92 @@
+import { describe, it, expect, vi, beforeEach } from "vitest";
+import { render, screen, waitFor, fireEvent } from "@testing-library/react";
+import { MemoryRouter, Routes, Route } from "react-router-dom";
+import LoginPage from "./LoginPage";
+import { getSessionToken, setSessionToken } from "../api/client";
+
+// Mock fetch globally
+const mockFetch = vi.fn();
+globalThis.fetch = mockFetch as unknown as typeof fetch;
+
+function renderLoginPage(initialEntry = "/login") {
+  return render(
+    <MemoryRouter initialEntries={[initialEntry]}>
+      <Routes>
+        <Route path="/login" element={<LoginPage />} />
+        <Route path="/" element={<div>Home page</div>} />
+        <Route path="/repositories" element={<div>Repositories page</div>} />
+      </Routes>
+    </MemoryRouter>
+  );
+}
+
+function submitLogin() {
+  fireEvent.change(screen.getByLabelText("Email"), {
+    target: { value: "admin@example.com" },
+  });
+  fireEvent.change(screen.getByLabelText("Password"), {
+    target: { value: "secret" },
+  });
+  fireEvent.click(screen.getByRole("button", { name: "Log in" }));
+}
+
+describe("LoginPage", () => {
+  beforeEach(() => {
+    mockFetch.mockClear();
+    setSessionToken(null);
+  });
+
+  it("stores the session token and redirects after login", async () => {
+    mockFetch.mockResolvedValue({
+      ok: true,
+      status: 200,
+      json: async () => ({
+        token: "session-token",
+        expiresAt: "2025-04-23T10:00:00Z",
+        user: { id: "user-1", name: "Admin", email: "admin@example.com" },
+      }),
+    });
+
+    renderLoginPage("/login?redirect=%2Frepositories");
+    submitLogin();
+
+    await waitFor(() => {
+      expect(screen.getByText("Repositories page")).toBeInTheDocument();
+    });
+    expect(getSessionToken()).toBe("session-token");
+  });
+
+  it("does not redirect outside the app", async () => {
+    mockFetch.mockResolvedValue({
+      ok: true,
+      status: 200,
+      json: async () => ({ token: "session-token", user: {} }),
+    });
+
+    renderLoginPage("/login?redirect=%2F%2Fevil.example.com");
+    submitLogin();
+
+    await waitFor(() => {
+      expect(screen.getByText("Home page")).toBeInTheDocument();
+    });
+  });
+
+  it("displays the error when login fails", async () => {
+    mockFetch.mockResolvedValue({
+      ok: false,
+      status: 401,
+      json: async () => ({
+        code: "UNAUTHORIZED",
+        message: "Invalid email or password",
+      }),
+    });
+
+    renderLoginPage();
+    submitLogin();
+
+    await waitFor(() => {
+      expect(screen.getByText("Invalid email or password")).toBeInTheDocument();
+    });
+    expect(getSessionToken()).toBeNull();
+  });
+});
@@ -1,5 +1,6 @@
 import { useState, useEffect, useRef } from "react";
 import { Link } from "react-router-dom";
+import { authFetch } from "../api/client";
 
 interface Repository {
   id: string;
@@ -59,7 +60,7 @@ function RepositoriesPage() {
     try {
       console.time("repositoriesFetch"); // Add timing measurement
 
-      const response = await fetch(`${apiUrl}/repositories`, {
+      const response = await authFetch(`${apiUrl}/repositories`, {
         signal: fetchControllerRef.current.signal,
         headers: {
           "Cache-Control": "max-age=60", // Cache for 60 seconds
@@ -104,7 +105,7 @@ function RepositoriesPage() {
     setSubmitMessage(null);
 
     try {
-      const response = await fetch(`${apiUrl}/repositories`, {
+      const response = await authFetch(`${apiUrl}/repositories`, {
         method: "POST",
         headers: {
           "Content-Type": "application/json",
@@ -1,5 +1,6 @@
 import { useState, useEffect } from "react";
 import { useParams, Link, useNavigate } from "react-router-dom";
+import { authFetch } from "../api/client";
 
 interface Repository {
   id: string;
@@ -11,9 +12,18 @@ interface Repository {
 
 interface FileNode {
   path: string;
+  name: string;
   type: "file" | "dir";
+  size: number;
+  children?: FileNode[];
 }
 
+// Flatten the file tree returned by the API into its files
+const flattenFiles = (nodes: FileNode[]): FileNode[] =>
+  nodes.flatMap((node) =>
+    node.type === "dir" ? flattenFiles(node.children ?? []) : [node]
+  );
+
 function RepositoryDetailPage() {
   const { repoId } = useParams<{ repoId: string }>();
   const navigate = useNavigate();
@@ -55,7 +65,7 @@ function RepositoryDetailPage() {
     setError(null);
 
     try {
-      const response = await fetch(`${apiUrl}/repositories/${repoId}`);
+      const response = await authFetch(`${apiUrl}/repositories/${repoId}`);
       if (!response.ok) {
         throw new Error(`HTTP error! status: ${response.status}`);
       }
@@ -75,7 +85,7 @@ function RepositoryDetailPage() {
     setNeedsToken(false);
 
     try {
-      const response = await fetch(`${apiUrl}/repositories/${repoId}/files`);
+      const response = await authFetch(`${apiUrl}/repositories/${repoId}/files`);
 
       if (response.status === 400) {
         // Check if this is an access token error
@@ -128,7 +138,7 @@ function RepositoryDetailPage() {
     setSubmitMessage(null);
 
     try {
-      const response = await fetch(
+      const response = await authFetch(
         `${apiUrl}/repositories/${repoId}/files/select`,
         {
           method: "POST",
@@ -179,7 +189,7 @@ function RepositoryDetailPage() {
     setTokenMessage(null);
 
     try {
-      const response = await fetch(`${apiUrl}/repositories/${repoId}/token`, {
+      const response = await authFetch(`${apiUrl}/repositories/${repoId}/token`, {
         method: "PUT",
         headers: {
           "Content-Type": "application/json",
@@ -212,7 +222,7 @@ function RepositoryDetailPage() {
   };
 
   // Filter files to only show markdown files
-  const markdownFiles = files.filter(
+  const markdownFiles = flattenFiles(files).filter(
     (file) => file.type === "file" && file.path.toLowerCase().endsWith(".md")
   );
 
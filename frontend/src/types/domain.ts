@@ -14,7 +14,19 @@ export interface Repository {
 /** File node in repository */
 export interface FileNode {
   path: string;
+  name: string;
   type: "file" | "dir";
+  /** Size in bytes; the total size of the contained files for a directory */
+  size: number;
+  /** Last commit that touched the file or directory */
+  last_commit?: {
+    sha: string;
+    author: string;
+    date: string;
+    message: string;
+  };
+  /** Entries of a directory, directories first and then by name */
+  children?: FileNode[];
 }
 
 /** Variable definition for documents */